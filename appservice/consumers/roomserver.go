@@ -80,7 +80,7 @@ func (c *OutputRoomEventConsumer) Start() error {
 func (c *OutputRoomEventConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	// Parse out the event JSON
 	var output roomserverapi.OutputEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		// If the message was invalid, log it and move on to the next message in the stream
 		log.Errorw("applicationservice: message parse failure", log.KeysAndValues{"error", err})
 		return
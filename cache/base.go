@@ -37,7 +37,7 @@ func (rc *RedisCache) encode(val interface{}) (interface{}, error) {
 
 //common
 func (rc *RedisCache) Del(key string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	err := conn.Send("DEL", key)
 	if err != nil {
@@ -47,16 +47,28 @@ func (rc *RedisCache) Del(key string) error {
 }
 
 func (rc *RedisCache) Scan(cursor uint64, match string, count int) (result []interface{}, next uint64, err error) {
-	values, err := redis.Values(rc.SafeDo("scan", cursor, "match", match, "count", count))
+	node, nodeCursor := splitScanCursor(cursor)
+	if node >= rc.poolSize {
+		return []interface{}{}, 0, nil
+	}
+	conn := rc.pools[node].Get()
+	defer conn.Close()
+	values, err := redis.Values(conn.Do("scan", nodeCursor, "match", match, "count", count))
 	if err != nil {
 		return []interface{}{}, cursor, err
 	}
-	values, err = redis.Scan(values, &cursor, &result)
+	values, err = redis.Scan(values, &nodeCursor, &result)
 	if err != nil {
 		return []interface{}{}, cursor, err
-	} else {
-		return result, cursor, nil
 	}
+	if nodeCursor != 0 {
+		return result, joinScanCursor(node, nodeCursor), nil
+	}
+	// current node finished, continue with the next one
+	if node+1 < rc.poolSize {
+		return result, joinScanCursor(node+1, 0), nil
+	}
+	return result, 0, nil
 }
 
 func (rc *RedisCache) TTL(key string) (ttl int64, err error) {
@@ -78,7 +90,7 @@ func (rc *RedisCache) Exists(key string) (bool, error) {
 }
 
 func (rc *RedisCache) Set(key string, val interface{}, expire int64) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	value, err := rc.encode(val)
 	if err != nil {
@@ -105,7 +117,7 @@ func (rc *RedisCache) HGetString(key, field string) (string, error) {
 }
 
 func (rc *RedisCache) HSet(key, field string, val interface{}) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	value, err := rc.encode(val)
 	if err != nil {
@@ -119,7 +131,7 @@ func (rc *RedisCache) HSet(key, field string, val interface{}) error {
 }
 
 func (rc *RedisCache) HDel(key, field string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	err := conn.Send("HDEL", key, field)
 	if err != nil {
@@ -169,7 +181,7 @@ func (rc *RedisCache) HGetAll(key string) (map[string]interface{}, error) {
 }
 
 func (rc *RedisCache) HDelMulti(key string, fields []interface{}) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	for _, field := range fields {
 		conn.Send("HDEL", key, field)
@@ -178,7 +190,7 @@ func (rc *RedisCache) HDelMulti(key string, fields []interface{}) error {
 }
 
 func (rc *RedisCache) HMSet(key string, val interface{}) (err error) {
-	conn := rc.pool().Get()
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	err = conn.Send("HMSET", redis.Args{}.Add(key).AddFlat(val)...)
 	if err != nil {
@@ -0,0 +1,49 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/gomodule/redigo/redis"
+)
+
+func (rc *RedisCache) SetDeviceLastSeen(userID, deviceID string, lastSeen *authtypes.DeviceLastSeen) error {
+	return rc.HSet(fmt.Sprintf("device_last_seen:%s", userID), deviceID, lastSeen)
+}
+
+func (rc *RedisCache) GetDevicesLastSeen(userID string) (map[string]*authtypes.DeviceLastSeen, error) {
+	result, err := rc.HGetAll(fmt.Sprintf("device_last_seen:%s", userID))
+	if err != nil {
+		return nil, err
+	}
+	lastSeens := make(map[string]*authtypes.DeviceLastSeen, len(result))
+	for deviceID, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var lastSeen authtypes.DeviceLastSeen
+		if err := json.Unmarshal(bytes, &lastSeen); err != nil {
+			log.Warnf("device last seen of user %s device %s is invalid: %v", userID, deviceID, err)
+			continue
+		}
+		lastSeens[deviceID] = &lastSeen
+	}
+	return lastSeens, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/gomodule/redigo/redis"
+)
+
+// remote device lists are dropped after a week without updates, by then the
+// users may no longer share a room with ours
+const remoteDeviceListExpire = 7 * 24 * 3600
+
+// GetDeviceListStreamID returns the stream id of the last device list change
+// of a local user, it is only kept here. When it is lost the encryption api
+// takes the last key change of the user in the sync db instead.
+func (rc *RedisCache) GetDeviceListStreamID(userID string) (int64, bool) {
+	streamID, err := Int64(rc.Get(fmt.Sprintf("device_list_stream:%s", userID)))
+	if err != nil {
+		return 0, false
+	}
+	return streamID, true
+}
+
+func (rc *RedisCache) SetDeviceListStreamID(userID string, streamID int64) error {
+	return rc.Set(fmt.Sprintf("device_list_stream:%s", userID), streamID, 0)
+}
+
+// GetRemoteDeviceList returns the cached device list of a remote user
+func (rc *RedisCache) GetRemoteDeviceList(userID string) (*gomatrixserverlib.RespUserDevices, bool) {
+	bytes, err := redis.Bytes(rc.Get(fmt.Sprintf("remote_device_list:%s", userID)))
+	if err != nil {
+		return nil, false
+	}
+	var devices gomatrixserverlib.RespUserDevices
+	if err := json.Unmarshal(bytes, &devices); err != nil {
+		log.Warnf("remote device list of user %s is invalid: %v", userID, err)
+		return nil, false
+	}
+	return &devices, true
+}
+
+func (rc *RedisCache) SetRemoteDeviceList(devices *gomatrixserverlib.RespUserDevices) error {
+	return rc.Set(fmt.Sprintf("remote_device_list:%s", devices.UserID), devices, remoteDeviceListExpire)
+}
+
+func (rc *RedisCache) DelRemoteDeviceList(userID string) error {
+	return rc.Del(fmt.Sprintf("remote_device_list:%s", userID))
+}
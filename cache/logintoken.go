@@ -0,0 +1,59 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"fmt"
+
+	"github.com/gomodule/redigo/redis"
+)
+
+func (rc *RedisCache) SetLoginToken(token, userID string, expire int64) error {
+	return rc.Set(fmt.Sprintf("login_token:%s", token), userID, expire)
+}
+
+// TakeLoginToken returns the user of the login token and revokes it, a login
+// token can only be used once
+func (rc *RedisCache) TakeLoginToken(token string) (string, error) {
+	return rc.take(fmt.Sprintf("login_token:%s", token))
+}
+
+func (rc *RedisCache) SetSSOSession(state, redirectURL string, expire int64) error {
+	return rc.Set(fmt.Sprintf("sso_session:%s", state), redirectURL, expire)
+}
+
+func (rc *RedisCache) TakeSSOSession(state string) (string, error) {
+	return rc.take(fmt.Sprintf("sso_session:%s", state))
+}
+
+// take gets the value and deletes the key, only the caller which deleted
+// the key gets the value so concurrent calls can't both succeed
+func (rc *RedisCache) take(key string) (string, error) {
+	val, err := rc.GetString(key)
+	if err != nil {
+		if err == redis.ErrNil {
+			return "", nil
+		}
+		return "", err
+	}
+	deleted, err := redis.Int(rc.SafeDo("DEL", key))
+	if err != nil {
+		return "", err
+	}
+	if deleted == 0 {
+		return "", nil
+	}
+	return val, nil
+}
@@ -15,6 +15,7 @@
 package cache
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/rand"
@@ -22,9 +23,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/authtypes"
 	push "github.com/finogeeks/ligase/model/pushapitypes"
 	e2e "github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/selector"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/gomodule/redigo/redis"
 )
@@ -32,6 +35,8 @@ import (
 type RedisCache struct {
 	pools    []*redis.Pool
 	poolSize int
+	uris     map[string]*redis.Pool
+	ring     core.ISelector
 }
 
 type DeviceInfo struct {
@@ -47,6 +52,11 @@ type DeviceInfo struct {
 func (rc *RedisCache) Prepare(cfg []string) (err error) {
 	rc.poolSize = len(cfg)
 	rc.pools = make([]*redis.Pool, rc.poolSize)
+	rc.uris = make(map[string]*redis.Pool, rc.poolSize)
+	rc.ring, err = selector.NewConsistent(nil)
+	if err != nil {
+		return err
+	}
 	for i := 0; i < rc.poolSize; i++ {
 		addr := cfg[i]
 		rc.pools[i] = &redis.Pool{
@@ -56,17 +66,32 @@ func (rc *RedisCache) Prepare(cfg []string) (err error) {
 			IdleTimeout: 240 * time.Second,
 			Dial:        func() (redis.Conn, error) { return redis.DialURL(addr) },
 		}
+		rc.uris[addr] = rc.pools[i]
+		rc.ring.AddNode(addr)
 	}
 	return err
 }
 
-func (rc *RedisCache) pool() *redis.Pool {
-	slot := rand.Int() % rc.poolSize
-	return rc.pools[slot]
+// pool returns the pool of the node owning key, keys with the same shard
+// key always land on the same node so pipelines and scripts keep working.
+func (rc *RedisCache) pool(key string) *redis.Pool {
+	if rc.poolSize == 1 {
+		return rc.pools[0]
+	}
+	addr, err := rc.ring.GetNode(shardKey(key))
+	if err != nil {
+		log.Errorf("redis cache get node for key:%s err:%v", key, err)
+		return rc.pools[0]
+	}
+	return rc.uris[addr]
 }
 
 func (rc *RedisCache) SafeDo(commandName string, args ...interface{}) (reply interface{}, err error) {
-	conn := rc.pool().Get()
+	key := ""
+	if len(args) > 0 {
+		key, _ = args[0].(string)
+	}
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 	reply, err = conn.Do(commandName, args...)
 	return reply, err
@@ -143,9 +168,9 @@ func (rc *RedisCache) GetDevicesByUserID(userID string) *[]authtypes.Device {
 }
 
 func (rc *RedisCache) SetPwdChangeDevcie(deviceID, userID string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	keyKey := fmt.Sprintf("%s:%s", "device_pwd_change", userID)
+	conn := rc.pool(keyKey).Get()
+	defer conn.Close()
 	err := conn.Send("hmset", keyKey, deviceID, "1")
 	if err != nil {
 		return err
@@ -154,9 +179,9 @@ func (rc *RedisCache) SetPwdChangeDevcie(deviceID, userID string) error {
 }
 
 func (rc *RedisCache) CheckPwdChangeDevice(deviceID, userID string) bool {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s:%s", "device_pwd_change", userID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	isExist, err := rc.SafeDo("hexists", key, deviceID)
 	if err != nil {
 		return false
@@ -167,9 +192,9 @@ func (rc *RedisCache) CheckPwdChangeDevice(deviceID, userID string) bool {
 }
 
 func (rc *RedisCache) DelPwdChangeDevice(deviceID, userID string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s:%s", "device_pwd_change", userID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	err := conn.Send("hdel", key, deviceID)
 	if err != nil {
 		return err
@@ -179,9 +204,9 @@ func (rc *RedisCache) DelPwdChangeDevice(deviceID, userID string) error {
 }
 
 func (rc *RedisCache) ExpirePwdChangeDevice(userID string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s:%s", "device_pwd_change", userID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	// err := conn.Send("expire", key, fmt.Sprintf("%d", 30*24*3600))
 	err := conn.Send("expire", key, strconv.Itoa(30*24*3600)) // faster than fmt.Sprintf
 	if err != nil {
@@ -604,10 +629,11 @@ func (rc *RedisCache) GetDeviceAlgorithm(userID, deviceID string) (*e2e.AlHolder
 }
 
 func (rc *RedisCache) DeleteDeviceOneTimeKey(userID, deviceID string) error {
-	conn := rc.pool().Get()
+	listKey := fmt.Sprintf("%s:%s:%s", "one_time_key_list", userID, deviceID)
+	conn := rc.pool(listKey).Get()
 	defer conn.Close()
 
-	result, err := redis.Values(conn.Do("hgetall", fmt.Sprintf("%s:%s:%s", "one_time_key_list", userID, deviceID)))
+	result, err := redis.Values(conn.Do("hgetall", listKey))
 	if err != nil {
 		return err
 	} else {
@@ -624,7 +650,7 @@ func (rc *RedisCache) DeleteDeviceOneTimeKey(userID, deviceID string) error {
 				return err
 			}
 
-			err = conn.Send("hdel", fmt.Sprintf("%s:%s:%s", "one_time_key_list", userID, deviceID), keyID)
+			err = conn.Send("hdel", listKey, keyID)
 			if err != nil {
 				return err
 			}
@@ -635,10 +661,11 @@ func (rc *RedisCache) DeleteDeviceOneTimeKey(userID, deviceID string) error {
 }
 
 func (rc *RedisCache) DeleteDeviceKey(userID, deviceID string) error {
-	conn := rc.pool().Get()
+	algKey := fmt.Sprintf("%s:%s:%s", "algorithm", userID, deviceID)
+	conn := rc.pool(algKey).Get()
 	defer conn.Close()
 
-	err := conn.Send("del", fmt.Sprintf("%s:%s:%s", "algorithm", userID, deviceID))
+	err := conn.Send("del", algKey)
 	if err != nil {
 		return err
 	}
@@ -671,9 +698,9 @@ func (rc *RedisCache) DeleteDeviceKey(userID, deviceID string) error {
 }
 
 func (rc *RedisCache) DeleteOneTimeKey(deviceID, userID, keyID, algorithm string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	keyKey := fmt.Sprintf("%s:%s:%s:%s:%s", "one_time_key", userID, deviceID, keyID, algorithm)
+	conn := rc.pool(keyKey).Get()
+	defer conn.Close()
 
 	err := conn.Send("del", keyKey)
 	if err != nil {
@@ -689,10 +716,11 @@ func (rc *RedisCache) DeleteOneTimeKey(deviceID, userID, keyID, algorithm string
 }
 
 func (rc *RedisCache) SetDeviceAlgorithm(userID, deviceID, algorithm string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s:%s", "algorithm", userID, deviceID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("hmset", fmt.Sprintf("%s:%s:%s", "algorithm", userID, deviceID),
+	err := conn.Send("hmset", key,
 		"device_id", deviceID, "user_id", userID, "algorithms", algorithm)
 	if err != nil {
 		return err
@@ -702,10 +730,9 @@ func (rc *RedisCache) SetDeviceAlgorithm(userID, deviceID, algorithm string) err
 }
 
 func (rc *RedisCache) SetDeviceKey(userID, deviceID, keyInfo, algorithm, signature string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
-
 	keyKey := fmt.Sprintf("%s:%s:%s:%s", "device_key", userID, deviceID, algorithm)
+	conn := rc.pool(keyKey).Get()
+	defer conn.Close()
 
 	err := conn.Send("hmset", keyKey, "device_id", deviceID, "user_id", userID, "key_info", keyInfo,
 		"algorithm", algorithm, "signature", signature)
@@ -722,10 +749,9 @@ func (rc *RedisCache) SetDeviceKey(userID, deviceID, keyInfo, algorithm, signatu
 }
 
 func (rc *RedisCache) SetOneTimeKey(userID, deviceID, keyID, keyInfo, algorithm, signature string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
-
 	keyKey := fmt.Sprintf("%s:%s:%s:%s:%s", "one_time_key", userID, deviceID, keyID, algorithm)
+	conn := rc.pool(keyKey).Get()
+	defer conn.Close()
 
 	err := conn.Send("hmset", keyKey, "device_id", deviceID, "user_id", userID, "key_id", keyID,
 		"key_info", keyInfo, "algorithm", algorithm, "signature", signature)
@@ -741,6 +767,229 @@ func (rc *RedisCache) SetOneTimeKey(userID, deviceID, keyID, keyInfo, algorithm,
 	return conn.Flush()
 }
 
+// GetCrossSigningKeys returns the cross-signing keys of the user by key type
+func (rc *RedisCache) GetCrossSigningKeys(userID string) (map[string]*e2e.CrossSigningKey, bool) {
+	result, err := rc.HGetAll(fmt.Sprintf("%s:%s", "cross_signing_key", userID))
+	if err != nil {
+		log.Warnw("cache missed for cross signing keys", log.KeysAndValues{"userID", userID, "err", err})
+		return nil, false
+	}
+	keys := make(map[string]*e2e.CrossSigningKey, len(result))
+	for keyType, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var key e2e.CrossSigningKey
+		if err := json.Unmarshal(bytes, &key); err != nil {
+			log.Errorw("invalid cross signing key", log.KeysAndValues{"userID", userID, "keyType", keyType, "error", err})
+			continue
+		}
+		keys[keyType] = &key
+	}
+	return keys, true
+}
+
+func (rc *RedisCache) SetCrossSigningKey(userID, keyType, keyInfo string) error {
+	return rc.HSet(fmt.Sprintf("%s:%s", "cross_signing_key", userID), keyType, keyInfo)
+}
+
+// GetCrossSigningSigs returns the signatures over the target key, the target
+// key is a device id or the key id of a master key
+func (rc *RedisCache) GetCrossSigningSigs(targetUserID, targetKeyID string) ([]e2e.CrossSigningSig, bool) {
+	result, err := rc.HGetAll(fmt.Sprintf("%s:%s:%s", "cross_signing_sig", targetUserID, targetKeyID))
+	if err != nil {
+		log.Warnw("cache missed for cross signing signatures", log.KeysAndValues{"userID", targetUserID, "keyID", targetKeyID, "err", err})
+		return nil, false
+	}
+	sigs := make([]e2e.CrossSigningSig, 0, len(result))
+	for _, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var sig e2e.CrossSigningSig
+		if err := json.Unmarshal(bytes, &sig); err != nil {
+			log.Errorw("invalid cross signing signature", log.KeysAndValues{"userID", targetUserID, "keyID", targetKeyID, "error", err})
+			continue
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, true
+}
+
+func (rc *RedisCache) SetCrossSigningSig(originUserID, originKeyID, targetUserID, targetKeyID, signature string) error {
+	return rc.HSet(
+		fmt.Sprintf("%s:%s:%s", "cross_signing_sig", targetUserID, targetKeyID),
+		fmt.Sprintf("%s:%s", originUserID, originKeyID),
+		&e2e.CrossSigningSig{OriginUserID: originUserID, OriginKeyID: originKeyID, Signature: signature},
+	)
+}
+
+// GetKeyBackupVersions returns the room key backup versions of the user,
+// deleted ones included
+func (rc *RedisCache) GetKeyBackupVersions(userID string) ([]*e2e.KeyBackupVersion, bool) {
+	result, err := rc.HGetAll(fmt.Sprintf("%s:%s", "key_backup_version", userID))
+	if err != nil {
+		log.Warnw("cache missed for key backup versions", log.KeysAndValues{"userID", userID, "err", err})
+		return nil, false
+	}
+	versions := make([]*e2e.KeyBackupVersion, 0, len(result))
+	for version, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var v e2e.KeyBackupVersion
+		if err := json.Unmarshal(bytes, &v); err != nil {
+			log.Errorw("invalid key backup version", log.KeysAndValues{"userID", userID, "version", version, "error", err})
+			continue
+		}
+		versions = append(versions, &v)
+	}
+	return versions, true
+}
+
+func (rc *RedisCache) SetKeyBackupVersion(userID string, version *e2e.KeyBackupVersion) error {
+	return rc.HSet(fmt.Sprintf("%s:%s", "key_backup_version", userID), strconv.FormatInt(version.Version, 10), version)
+}
+
+// NextKeyBackupVersion hands out the number of a new backup version of the
+// user, the counter starts after the versions already in the cache. The
+// counter shards with the versions, both keys hold the user id.
+func (rc *RedisCache) NextKeyBackupVersion(userID string) (int64, error) {
+	seqKey := fmt.Sprintf("%s:%s", "key_backup_version_seq", userID)
+	conn := rc.pool(seqKey).Get()
+	defer conn.Close()
+
+	const SCRIPT_NEXT = `
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	local last = 0
+	for _, field in ipairs(redis.call('HKEYS', KEYS[2])) do
+		local num = tonumber(field)
+		if num and num > last then
+			last = num
+		end
+	end
+	redis.call('SET', KEYS[1], last)
+end
+return redis.call('INCR', KEYS[1])
+`
+	lua := redis.NewScript(2, SCRIPT_NEXT)
+	return redis.Int64(lua.Do(conn, seqKey, fmt.Sprintf("%s:%s", "key_backup_version", userID)))
+}
+
+// IncrKeyBackupEtag bumps the etag of a backup version and returns it, the
+// version is read and written back in one step so no upload is lost
+func (rc *RedisCache) IncrKeyBackupEtag(userID string, version int64) (int64, error) {
+	key := fmt.Sprintf("%s:%s", "key_backup_version", userID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
+
+	const SCRIPT_INCR = `
+local val = redis.call('HGET', KEYS[1], ARGV[1])
+if not val then
+	return redis.error_reply('unknown key backup version')
+end
+local version = cjson.decode(val)
+version.etag = (version.etag or 0) + 1
+redis.call('HSET', KEYS[1], ARGV[1], cjson.encode(version))
+return version.etag
+`
+	lua := redis.NewScript(1, SCRIPT_INCR)
+	return redis.Int64(lua.Do(conn, key, strconv.FormatInt(version, 10)))
+}
+
+// GetKeyBackups returns the sessions backed up in the version
+func (rc *RedisCache) GetKeyBackups(userID string, version int64) ([]*e2e.KeyBackupSession, bool) {
+	result, err := rc.HGetAll(fmt.Sprintf("%s:%s:%d", "key_backup", userID, version))
+	if err != nil {
+		log.Warnw("cache missed for key backups", log.KeysAndValues{"userID", userID, "version", version, "err", err})
+		return nil, false
+	}
+	sessions := make([]*e2e.KeyBackupSession, 0, len(result))
+	for field, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var session e2e.KeyBackupSession
+		if err := json.Unmarshal(bytes, &session); err != nil {
+			log.Errorw("invalid key backup", log.KeysAndValues{"userID", userID, "version", version, "field", field, "error", err})
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+	return sessions, true
+}
+
+func (rc *RedisCache) GetKeyBackup(userID string, version int64, roomID, sessionID string) (*e2e.KeyBackupSession, bool) {
+	bytes, err := redis.Bytes(rc.HGet(fmt.Sprintf("%s:%s:%d", "key_backup", userID, version), fmt.Sprintf("%s:%s", roomID, sessionID)))
+	if err != nil {
+		return nil, false
+	}
+	var session e2e.KeyBackupSession
+	if err := json.Unmarshal(bytes, &session); err != nil {
+		log.Errorw("invalid key backup", log.KeysAndValues{"userID", userID, "version", version, "roomID", roomID, "sessionID", sessionID, "error", err})
+		return nil, false
+	}
+	return &session, true
+}
+
+func (rc *RedisCache) SetKeyBackup(userID string, version int64, session *e2e.KeyBackupSession) error {
+	return rc.HSet(fmt.Sprintf("%s:%s:%d", "key_backup", userID, version), fmt.Sprintf("%s:%s", session.RoomID, session.SessionID), session)
+}
+
+// DeleteKeyBackup deletes a backed up session, all sessions of the room when
+// sessionID is empty and the whole backup when roomID is empty too
+func (rc *RedisCache) DeleteKeyBackup(userID string, version int64, roomID, sessionID string) error {
+	key := fmt.Sprintf("%s:%s:%d", "key_backup", userID, version)
+	if roomID == "" {
+		return rc.Del(key)
+	}
+	if sessionID != "" {
+		return rc.HDel(key, fmt.Sprintf("%s:%s", roomID, sessionID))
+	}
+	sessions, _ := rc.GetKeyBackups(userID, version)
+	fields := []interface{}{}
+	for _, session := range sessions {
+		if session.RoomID == roomID {
+			fields = append(fields, fmt.Sprintf("%s:%s", session.RoomID, session.SessionID))
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return rc.HDelMulti(key, fields)
+}
+
+// GetFallbackKeys returns the fallback keys of the device by algorithm
+func (rc *RedisCache) GetFallbackKeys(userID, deviceID string) (map[string]*e2e.FallbackKey, bool) {
+	result, err := rc.HGetAll(fmt.Sprintf("%s:%s:%s", "fallback_key", userID, deviceID))
+	if err != nil {
+		log.Warnw("cache missed for fallback keys", log.KeysAndValues{"userID", userID, "deviceID", deviceID, "err", err})
+		return nil, false
+	}
+	keys := make(map[string]*e2e.FallbackKey, len(result))
+	for al, val := range result {
+		bytes, err := redis.Bytes(val, nil)
+		if err != nil {
+			continue
+		}
+		var key e2e.FallbackKey
+		if err := json.Unmarshal(bytes, &key); err != nil {
+			log.Errorw("invalid fallback key", log.KeysAndValues{"userID", userID, "deviceID", deviceID, "algorithm", al, "error", err})
+			continue
+		}
+		keys[al] = &key
+	}
+	return keys, true
+}
+
+func (rc *RedisCache) SetFallbackKey(userID, deviceID string, key *e2e.FallbackKey) error {
+	return rc.HSet(fmt.Sprintf("%s:%s:%s", "fallback_key", userID, deviceID), key.Algorithm, key)
+}
+
 func (rc *RedisCache) GetRoomUnreadCount(userID, roomID string) (int64, int64, error) {
 	key := fmt.Sprintf("%s:%s:%s", "unread_count", userID, roomID)
 
@@ -783,10 +1032,10 @@ func (rc *RedisCache) GetPresences(userID string) (*authtypes.Presences, bool) {
 }
 
 func (rc *RedisCache) SetPresences(userID, status, statusMsg, extStatusMsg string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "presences", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := fmt.Sprintf("%s:%s", "presences", userID)
 	err := conn.Send("hmset", key, "status", status, "status_msg", statusMsg, "ext_status_msg", extStatusMsg)
 	if err != nil {
 		return err
@@ -795,9 +1044,9 @@ func (rc *RedisCache) SetPresences(userID, status, statusMsg, extStatusMsg strin
 }
 
 func (rc *RedisCache) SetPresencesServerStatus(userID, serverStatus string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s:%s", "presences", userID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	err := conn.Send("hmset", key, "server_status", serverStatus)
 	if err != nil {
 		return err
@@ -806,7 +1055,7 @@ func (rc *RedisCache) SetPresencesServerStatus(userID, serverStatus string) erro
 }
 
 func (rc *RedisCache) SetAccountData(userID, roomID, acctType, content string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool(fmt.Sprintf("%s:%s", "account_data_list", userID)).Get()
 	defer conn.Close()
 
 	if roomID != "" {
@@ -853,10 +1102,9 @@ func (rc *RedisCache) GetRoomOffsets(roomNID int64) (map[string]int64, error) {
 }
 
 func (rc *RedisCache) SetRoomUnreadCount(userID, roomID string, notifyCount, hlCount int64) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
-
 	key := fmt.Sprintf("%s:%s:%s", "unread_count", userID, roomID)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 
 	err := conn.Send("hmset", key, "highlight_count", hlCount, "notification_count", notifyCount)
 	if err != nil {
@@ -867,10 +1115,11 @@ func (rc *RedisCache) SetRoomUnreadCount(userID, roomID string, notifyCount, hlC
 }
 
 func (rc *RedisCache) DelProfile(userID string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("DEL", fmt.Sprintf("%s:%s", "profile", userID))
+	err := conn.Send("DEL", key)
 	if err != nil {
 		return err
 	}
@@ -878,10 +1127,11 @@ func (rc *RedisCache) DelProfile(userID string) error {
 	return conn.Flush()
 }
 func (rc *RedisCache) DelAvatar(userID string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("HDEL", fmt.Sprintf("%s:%s", "profile", userID), "avatar_url")
+	err := conn.Send("HDEL", key, "avatar_url")
 	if err != nil {
 		return err
 	}
@@ -889,10 +1139,11 @@ func (rc *RedisCache) DelAvatar(userID string) error {
 	return conn.Flush()
 }
 func (rc *RedisCache) DelDisplayName(userID string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("HDEL", fmt.Sprintf("%s:%s", "profile", userID), "display_name")
+	err := conn.Send("HDEL", key, "display_name")
 	if err != nil {
 		return err
 	}
@@ -901,10 +1152,11 @@ func (rc *RedisCache) DelDisplayName(userID string) error {
 }
 
 func (rc *RedisCache) SetProfile(userID, displayName, avatar string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("hmset", fmt.Sprintf("%s:%s", "profile", userID), "user_id", userID, "display_name", displayName, "avatar_url", avatar)
+	err := conn.Send("hmset", key, "user_id", userID, "display_name", displayName, "avatar_url", avatar)
 	if err != nil {
 		return err
 	}
@@ -912,11 +1164,12 @@ func (rc *RedisCache) SetProfile(userID, displayName, avatar string) error {
 	return conn.Flush()
 }
 func (rc *RedisCache) ExpireProfile(userID string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
 	// 10~30 days
-	err := conn.Send("expire", fmt.Sprintf("%s:%s", "profile", userID), strconv.Itoa(10*24*3600+rand.Intn(20*24*3600)))
+	err := conn.Send("expire", key, strconv.Itoa(10*24*3600+rand.Intn(20*24*3600)))
 	if err != nil {
 		return err
 	}
@@ -925,10 +1178,11 @@ func (rc *RedisCache) ExpireProfile(userID string) error {
 }
 
 func (rc *RedisCache) SetDisplayName(userID, displayName string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("hmset", fmt.Sprintf("%s:%s", "profile", userID), "user_id", userID, "display_name", displayName)
+	err := conn.Send("hmset", key, "user_id", userID, "display_name", displayName)
 	if err != nil {
 		return err
 	}
@@ -937,10 +1191,11 @@ func (rc *RedisCache) SetDisplayName(userID, displayName string) error {
 }
 
 func (rc *RedisCache) SetAvatar(userID, avatar string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "profile", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("hmset", fmt.Sprintf("%s:%s", "profile", userID), "user_id", userID, "avatar_url", avatar)
+	err := conn.Send("hmset", key, "user_id", userID, "avatar_url", avatar)
 	if err != nil {
 		return err
 	}
@@ -949,10 +1204,10 @@ func (rc *RedisCache) SetAvatar(userID, avatar string) error {
 }
 
 func (rc *RedisCache) GetSetting(settingKey string) (int64, error) {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "setting", settingKey)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := fmt.Sprintf("%s:%s", "setting", settingKey)
 	ret, err := redis.String(rc.SafeDo("get", key))
 	if err != nil {
 		return 0, err
@@ -962,10 +1217,10 @@ func (rc *RedisCache) GetSetting(settingKey string) (int64, error) {
 }
 
 func (rc *RedisCache) GetSettingRaw(settingKey string) (string, error) {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "setting", settingKey)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := fmt.Sprintf("%s:%s", "setting", settingKey)
 	ret, err := redis.String(rc.SafeDo("get", key))
 	if err != nil {
 		return "", err
@@ -974,18 +1229,18 @@ func (rc *RedisCache) GetSettingRaw(settingKey string) (string, error) {
 }
 
 func (rc *RedisCache) SetSetting(settingKey string, val string) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "setting", settingKey)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := fmt.Sprintf("%s:%s", "setting", settingKey)
 	err := conn.Send("set", key, val)
 	return err
 }
 
 func (rc *RedisCache) AddDomain(domain string) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s", "setting:domains")
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	err := conn.Send("hmset", key, domain, 1)
 	if err != nil {
 		return err
@@ -1023,10 +1278,11 @@ func (rc *RedisCache) GetUserInfoByUserID(userID string) (result *authtypes.User
 }
 
 func (rc *RedisCache) SetUserInfo(userID, userName, jobNumber, mobile, landline, email string, state int) error {
-	conn := rc.pool().Get()
+	key := fmt.Sprintf("%s:%s", "user_info", userID)
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	err := conn.Send("hmset", fmt.Sprintf("%s:%s", "user_info", userID), "user_id", userID, "user_name", userName, "job_number", jobNumber, "mobile", mobile, "landline", landline, "email", email, "state", state)
+	err := conn.Send("hmset", key, "user_id", userID, "user_name", userName, "job_number", jobNumber, "mobile", mobile, "landline", landline, "email", email, "state", state)
 	if err != nil {
 		return err
 	}
@@ -1035,10 +1291,10 @@ func (rc *RedisCache) SetUserInfo(userID, userName, jobNumber, mobile, landline,
 }
 
 func (rc *RedisCache) DeleteUserInfo(userID string) error {
-	conn := rc.pool().Get()
+	UserInfokey := fmt.Sprintf("%s:%s", "user_info", userID)
+	conn := rc.pool(UserInfokey).Get()
 	defer conn.Close()
 
-	UserInfokey := fmt.Sprintf("%s:%s", "user_info", userID)
 	err := conn.Send("del", UserInfokey)
 	if err != nil {
 		return err
@@ -1056,10 +1312,10 @@ func (rc *RedisCache) GetFakePartition(key string) (int32, error) {
 }
 
 func (rc *RedisCache) AssignFedSendRecPartition(roomID, domain string, partition int32) error {
-	conn := rc.pool().Get()
+	key := "fedpart:" + roomID + ":" + domain
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := "fedpart:" + roomID + ":" + domain
 	reply, err := redis.String(conn.Do("SET", key, partition, "NX", "PX", 30000))
 	if err != nil {
 		return err
@@ -1072,10 +1328,10 @@ func (rc *RedisCache) AssignFedSendRecPartition(roomID, domain string, partition
 }
 
 func (rc *RedisCache) UnassignFedSendRecPartition(roomID, domain string) error {
-	conn := rc.pool().Get()
+	key := "fedpart:" + roomID + ":" + domain
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := "fedpart:" + roomID + ":" + domain
 	err := conn.Send("DEL", key)
 
 	return err
@@ -1100,7 +1356,7 @@ func (rc *RedisCache) ExpireFedSendRecPartition(roomID, domain string) error {
 }
 
 func (rc *RedisCache) AddFedPendingRooms(keys []string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsender:pendding").Get()
 	defer conn.Close()
 
 	args := make([]interface{}, len(keys)+1)
@@ -1113,7 +1369,7 @@ func (rc *RedisCache) AddFedPendingRooms(keys []string) error {
 }
 
 func (rc *RedisCache) DelFedPendingRooms(keys []string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsender:pendding").Get()
 	defer conn.Close()
 
 	args := make([]interface{}, len(keys)+1)
@@ -1143,7 +1399,7 @@ func (rc *RedisCache) QryFedPendingRooms() ([]string, error) {
 }
 
 func (rc *RedisCache) StoreFedSendRec(roomID, domain, eventID string, sendTimes, pendingSize int32, domainOffset int64) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsend:"+roomID+":"+domain).Get()
 	defer conn.Close()
 	err := conn.Send("HMSET", "fedsend:"+roomID+":"+domain,
 		"eventID", eventID,
@@ -1181,7 +1437,7 @@ func (rc *RedisCache) GetFedSendRec(roomID, domain string) (eventID string, send
 }
 
 func (rc *RedisCache) IncrFedRoomPending(roomID, domain string, amt int) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsend:"+roomID+":"+domain).Get()
 	defer conn.Close()
 
 	const SCRIPT_INCR = `
@@ -1199,7 +1455,7 @@ end
 }
 
 func (rc *RedisCache) IncrFedRoomDomainOffset(roomID, domain, eventID string, domainOffset int64, penddingDecr int32) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsend:"+roomID+":"+domain).Get()
 	defer conn.Close()
 
 	const SCRIPT_INCR = `
@@ -1221,17 +1477,17 @@ end
 }
 
 func (rc *RedisCache) FreeFedSendRec(roomID, domain string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedsend:"+roomID+":"+domain).Get()
 	defer conn.Close()
 	err := conn.Send("DEL", "fedsend:"+roomID+":"+domain)
 	return err
 }
 
 func (rc *RedisCache) AssignFedBackfillRecPartition(roomID string, partition int32) error {
-	conn := rc.pool().Get()
+	key := "fedbackfillpart:" + roomID
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := "fedbackfillpart:" + roomID
 	reply, err := redis.String(conn.Do("SET", key, partition, "NX", "PX", 30000))
 	if err != nil {
 		return err
@@ -1244,10 +1500,10 @@ func (rc *RedisCache) AssignFedBackfillRecPartition(roomID string, partition int
 }
 
 func (rc *RedisCache) UnassignFedBackfillRecPartition(roomID string) error {
-	conn := rc.pool().Get()
+	key := "fedbackfillpart:" + roomID
+	conn := rc.pool(key).Get()
 	defer conn.Close()
 
-	key := "fedbackfillpart:" + roomID
 	err := conn.Send("DEL", key)
 
 	return err
@@ -1272,7 +1528,7 @@ func (rc *RedisCache) ExpireFedBackfillRecPartition(roomID string) error {
 }
 
 func (rc *RedisCache) AddFedBackfillRooms(keys []string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedbackfill:pendding").Get()
 	defer conn.Close()
 
 	args := make([]interface{}, len(keys)+1)
@@ -1285,7 +1541,7 @@ func (rc *RedisCache) AddFedBackfillRooms(keys []string) error {
 }
 
 func (rc *RedisCache) DelFedBackfillRooms(roomIDs []string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedbackfill:pendding").Get()
 	defer conn.Close()
 
 	args := make([]interface{}, len(roomIDs)+1)
@@ -1315,7 +1571,7 @@ func (rc *RedisCache) QryFedBackfillRooms() ([]string, error) {
 }
 
 func (rc *RedisCache) StoreFedBackfillRec(roomID string, depth int64, finished bool, finishedDomains string, states string) (loaded bool, err error) {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedbackfill:"+roomID).Get()
 	defer conn.Close()
 
 	const SCRIPT_INCR = `
@@ -1335,7 +1591,7 @@ end
 }
 
 func (rc *RedisCache) UpdateFedBackfillRec(roomID string, depth int64, finished bool, finishedDomains string, states string) (updated bool, err error) {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedbackfill:"+roomID).Get()
 	defer conn.Close()
 
 	const SCRIPT_INCR = `
@@ -1381,16 +1637,16 @@ func (rc *RedisCache) GetFedBackfillRec(roomID string) (depth int64, finished bo
 }
 
 func (rc *RedisCache) FreeFedBackfill(roomID string) error {
-	conn := rc.pool().Get()
+	conn := rc.pool("fedbackfill:"+roomID).Get()
 	defer conn.Close()
 	err := conn.Send("DEL", "fedbackfill:"+roomID)
 	return err
 }
 
 func (rc *RedisCache) SetRoomLatestOffset(roomId string, offset int64) error {
-	conn := rc.pool().Get()
-	defer conn.Close()
 	key := fmt.Sprintf("%s:%s", "roomlatestoffset", roomId)
+	conn := rc.pool(key).Get()
+	defer conn.Close()
 	err := conn.Send("set", key, offset)
 	if err != nil {
 		return err
@@ -0,0 +1,65 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/gomodule/redigo/redis"
+)
+
+// SetRefreshToken saves the refresh token of the device and revokes the
+// previous one, a device only has one valid refresh token
+func (rc *RedisCache) SetRefreshToken(token, userID, deviceID string, expire int64) error {
+	if err := rc.DelDeviceRefreshToken(userID, deviceID); err != nil {
+		return err
+	}
+	refreshToken := &authtypes.RefreshToken{UserID: userID, DeviceID: deviceID}
+	if err := rc.Set(fmt.Sprintf("refresh_token:%s", token), refreshToken, expire); err != nil {
+		return err
+	}
+	return rc.Set(fmt.Sprintf("device_refresh_token:%s:%s", userID, deviceID), token, expire)
+}
+
+// TakeRefreshToken returns the device of the refresh token and revokes it,
+// a refresh token can only be used once
+func (rc *RedisCache) TakeRefreshToken(token string) (*authtypes.RefreshToken, error) {
+	val, err := rc.take(fmt.Sprintf("refresh_token:%s", token))
+	if err != nil || val == "" {
+		return nil, err
+	}
+	var refreshToken authtypes.RefreshToken
+	if err := json.Unmarshal([]byte(val), &refreshToken); err != nil {
+		return nil, err
+	}
+	return &refreshToken, nil
+}
+
+func (rc *RedisCache) DelDeviceRefreshToken(userID, deviceID string) error {
+	key := fmt.Sprintf("device_refresh_token:%s:%s", userID, deviceID)
+	token, err := rc.GetString(key)
+	if err != nil {
+		if err == redis.ErrNil {
+			return nil
+		}
+		return err
+	}
+	if err := rc.Del(fmt.Sprintf("refresh_token:%s", token)); err != nil {
+		return err
+	}
+	return rc.Del(key)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"strings"
+)
+
+const (
+	scanNodeShift = 56
+	scanNodeMask  = uint64(1)<<scanNodeShift - 1
+)
+
+// key families which are touched together by lua scripts or pipelines
+// across different owners, each family is pinned to a single node.
+var pinnedShardKeys = map[string]string{
+	"fedsend":         "fed",
+	"fedsender":       "fed",
+	"fedpart":         "fed",
+	"fedbackfill":     "fed",
+	"fedbackfillpart": "fed",
+	"setting":         "setting",
+}
+
+// shardKey returns the part of key used to pick a node. A redis cluster
+// style hash tag "{...}" wins, otherwise keys are sharded by their owner,
+// the segment after the prefix (user id, room id, token...), so that
+// "device:@u:domain:DEV" and "device_list:@u:domain" live together.
+func shardKey(key string) string {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			return key[start+1 : start+1+end]
+		}
+	}
+	parts := strings.SplitN(key, ":", 3)
+	if pinned, ok := pinnedShardKeys[parts[0]]; ok {
+		return pinned
+	}
+	if len(parts) >= 2 && parts[1] != "" {
+		return parts[1]
+	}
+	return key
+}
+
+// scan cursors walk every node in turn, the node index is kept in the high
+// bits of the cursor handed back to the caller.
+func splitScanCursor(cursor uint64) (node int, nodeCursor uint64) {
+	return int(cursor >> scanNodeShift), cursor & scanNodeMask
+}
+
+func joinScanCursor(node int, nodeCursor uint64) uint64 {
+	return uint64(node)<<scanNodeShift | nodeCursor&scanNodeMask
+}
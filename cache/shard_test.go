@@ -0,0 +1,48 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cache
+
+import (
+	"testing"
+)
+
+func TestShardKey(t *testing.T) {
+	cases := map[string]string{
+		"device:@alice:ligase:DEV":               "@alice",
+		"device_list:@alice:ligase":              "@alice",
+		"one_time_key:@alice:ligase:DEV:k:curve": "@alice",
+		"fedsend:!room:ligase:remote":            "fed",
+		"fedsender:pendding":                     "fed",
+		"setting:domains":                        "setting",
+		"msgid:{!room:ligase}":                   "!room:ligase",
+		"nocolon":                                "nocolon",
+	}
+	for key, expect := range cases {
+		if got := shardKey(key); got != expect {
+			t.Errorf("shardKey(%s) = %s, expect %s", key, got, expect)
+		}
+	}
+}
+
+func TestScanCursor(t *testing.T) {
+	cursor := joinScanCursor(3, 12345)
+	node, nodeCursor := splitScanCursor(cursor)
+	if node != 3 || nodeCursor != 12345 {
+		t.Errorf("unexpected split %d %d", node, nodeCursor)
+	}
+	if joinScanCursor(0, 42) != 42 {
+		t.Errorf("first node cursor must be unchanged")
+	}
+}
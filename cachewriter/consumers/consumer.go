@@ -106,7 +106,7 @@ func (s *DBEventCacheConsumer) Start() error {
 
 func (s *DBEventCacheConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output dbtypes.DBEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("dbevent: message parse failure", log.KeysAndValues{"error", err})
 		return
 	}
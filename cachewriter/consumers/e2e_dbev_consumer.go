@@ -23,6 +23,7 @@ import (
 	"github.com/finogeeks/ligase/common"
 	"github.com/finogeeks/ligase/common/config"
 	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/model/types"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"time"
 )
@@ -55,6 +56,16 @@ func (s *E2EDBEvCacheConsumer) startWorker(msgChan chan common.ContextMsg) {
 				res = s.onOneTimeKeyDelete(*data.KeyDelete)
 			case dbtypes.AlInsertKey:
 				res = s.onAlInsert(*data.AlInsert)
+			case dbtypes.CrossSigningKeyInsertKey:
+				res = s.onCrossSigningKeyInsert(*data.CrossSigningKeyInsert)
+			case dbtypes.CrossSigningSigInsertKey:
+				res = s.onCrossSigningSigInsert(*data.CrossSigningSigInsert)
+			case dbtypes.KeyBackupVersionInsertKey:
+				res = s.onKeyBackupVersionInsert(*data.KeyBackupVersionInsert)
+			case dbtypes.KeyBackupInsertKey:
+				res = s.onKeyBackupInsert(*data.KeyBackupInsert)
+			case dbtypes.FallbackKeyInsertKey:
+				res = s.onFallbackKeyInsert(*data.FallbackKeyInsert)
 			default:
 				res = nil
 				log.Infow("encrypt api db event: ignoring unknown output type", log.KeysAndValues{"key", output.Key})
@@ -171,3 +182,114 @@ func (s *E2EDBEvCacheConsumer) onAlInsert(
 
 	return conn.Flush()
 }
+
+func (s *E2EDBEvCacheConsumer) onCrossSigningKeyInsert(
+	msg dbtypes.CrossSigningKeyInsert,
+) error {
+	conn := s.pool.Pool().Get()
+	defer conn.Close()
+
+	err := conn.Send("hset", fmt.Sprintf("%s:%s", "cross_signing_key", msg.UserID), msg.KeyType, msg.KeyInfo)
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (s *E2EDBEvCacheConsumer) onCrossSigningSigInsert(
+	msg dbtypes.CrossSigningSigInsert,
+) error {
+	conn := s.pool.Pool().Get()
+	defer conn.Close()
+
+	sig, err := json.Marshal(&types.CrossSigningSig{
+		OriginUserID: msg.OriginUserID,
+		OriginKeyID:  msg.OriginKeyID,
+		Signature:    msg.Signature,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%s", "cross_signing_sig", msg.TargetUserID, msg.TargetKeyID),
+		fmt.Sprintf("%s:%s", msg.OriginUserID, msg.OriginKeyID), string(sig))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (s *E2EDBEvCacheConsumer) onKeyBackupVersionInsert(
+	msg dbtypes.KeyBackupVersionInsert,
+) error {
+	conn := s.pool.Pool().Get()
+	defer conn.Close()
+
+	version, err := json.Marshal(&types.KeyBackupVersion{
+		Version:   msg.Version,
+		Algorithm: msg.Algorithm,
+		AuthData:  msg.AuthData,
+		Etag:      msg.Etag,
+		Deleted:   msg.Deleted,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s", "key_backup_version", msg.UserID), fmt.Sprintf("%d", msg.Version), string(version))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (s *E2EDBEvCacheConsumer) onKeyBackupInsert(
+	msg dbtypes.KeyBackupInsert,
+) error {
+	conn := s.pool.Pool().Get()
+	defer conn.Close()
+
+	session, err := json.Marshal(&types.KeyBackupSession{
+		RoomID:            msg.RoomID,
+		SessionID:         msg.SessionID,
+		FirstMessageIndex: msg.FirstMessageIndex,
+		ForwardedCount:    msg.ForwardedCount,
+		IsVerified:        msg.IsVerified,
+		SessionData:       msg.SessionData,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%d", "key_backup", msg.UserID, msg.Version),
+		fmt.Sprintf("%s:%s", msg.RoomID, msg.SessionID), string(session))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (s *E2EDBEvCacheConsumer) onFallbackKeyInsert(
+	msg dbtypes.FallbackKeyInsert,
+) error {
+	conn := s.pool.Pool().Get()
+	defer conn.Close()
+
+	key, err := json.Marshal(&types.FallbackKey{
+		KeyID:     msg.KeyID,
+		Key:       msg.KeyInfo,
+		Algorithm: msg.Algorithm,
+		Signature: msg.Signature,
+		Used:      msg.Used,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%s", "fallback_key", msg.UserID, msg.DeviceID), msg.Algorithm, string(key))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
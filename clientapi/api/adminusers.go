@@ -0,0 +1,240 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqGetWhoIs{})
+	apiconsumer.SetAPIProcessor(ReqGetAdminUsers{})
+	apiconsumer.SetAPIProcessor(ReqGetAdminUserDevices{})
+	apiconsumer.SetAPIProcessor(ReqPostAdminUserPassword{})
+	apiconsumer.SetAPIProcessor(ReqPutAdminUserSuspend{})
+	apiconsumer.SetAPIProcessor(ReqPostAdminUserLogout{})
+}
+
+type ReqGetWhoIs struct{}
+
+func (ReqGetWhoIs) GetRoute() string       { return "/admin/whois/{userID}" }
+func (ReqGetWhoIs) GetMetricsName() string { return "whois" }
+func (ReqGetWhoIs) GetMsgType() int32      { return internals.MSG_GET_WHO_IS }
+func (ReqGetWhoIs) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetWhoIs) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetWhoIs) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetWhoIs) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetWhoIs) NewRequest() core.Coder {
+	return new(external.GetWhoIsRequest)
+}
+func (ReqGetWhoIs) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetWhoIsRequest)
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqGetWhoIs) NewResponse(code int) core.Coder {
+	return new(external.GetWhoIsResponse)
+}
+func (ReqGetWhoIs) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetWhoIsRequest)
+	return routing.WhoIs(ctx, req, device, &c.Cfg, c.cacheIn)
+}
+
+type ReqGetAdminUsers struct{}
+
+func (ReqGetAdminUsers) GetRoute() string       { return "/users" }
+func (ReqGetAdminUsers) GetMetricsName() string { return "admin_users" }
+func (ReqGetAdminUsers) GetMsgType() int32      { return internals.MSG_GET_ADMIN_USERS }
+func (ReqGetAdminUsers) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetAdminUsers) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetAdminUsers) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetAdminUsers) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqGetAdminUsers) NewRequest() core.Coder {
+	return new(external.GetAdminUsersRequest)
+}
+func (ReqGetAdminUsers) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetAdminUsersRequest)
+	query := req.URL.Query()
+	msg.Search = query.Get("search")
+	msg.From = query.Get("from")
+	if limit := query.Get("limit"); limit != "" {
+		msg.Limit, _ = strconv.Atoi(limit)
+	}
+	return nil
+}
+func (ReqGetAdminUsers) NewResponse(code int) core.Coder {
+	return new(external.GetAdminUsersResponse)
+}
+func (ReqGetAdminUsers) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetAdminUsersRequest)
+	return routing.GetAdminUsers(ctx, req, device, &c.Cfg, c.accountDB)
+}
+
+type ReqGetAdminUserDevices struct{}
+
+func (ReqGetAdminUserDevices) GetRoute() string       { return "/users/{userID}/devices" }
+func (ReqGetAdminUserDevices) GetMetricsName() string { return "admin_user_devices" }
+func (ReqGetAdminUserDevices) GetMsgType() int32      { return internals.MSG_GET_ADMIN_USER_DEVICES }
+func (ReqGetAdminUserDevices) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetAdminUserDevices) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetAdminUserDevices) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetAdminUserDevices) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqGetAdminUserDevices) NewRequest() core.Coder {
+	return new(external.GetAdminUserDevicesRequest)
+}
+func (ReqGetAdminUserDevices) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetAdminUserDevicesRequest)
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqGetAdminUserDevices) NewResponse(code int) core.Coder {
+	return new(external.GetAdminUserDevicesResponse)
+}
+func (ReqGetAdminUserDevices) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetAdminUserDevicesRequest)
+	return routing.GetAdminUserDevices(ctx, req, device, &c.Cfg, c.accountDB, c.cacheIn)
+}
+
+type ReqPostAdminUserPassword struct{}
+
+func (ReqPostAdminUserPassword) GetRoute() string       { return "/users/{userID}/password" }
+func (ReqPostAdminUserPassword) GetMetricsName() string { return "admin_user_password" }
+func (ReqPostAdminUserPassword) GetMsgType() int32      { return internals.MSG_POST_ADMIN_USER_PASSWORD }
+func (ReqPostAdminUserPassword) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostAdminUserPassword) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostAdminUserPassword) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostAdminUserPassword) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPostAdminUserPassword) NewRequest() core.Coder {
+	return new(external.PostAdminUserPasswordRequest)
+}
+func (ReqPostAdminUserPassword) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostAdminUserPasswordRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqPostAdminUserPassword) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPostAdminUserPassword) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostAdminUserPasswordRequest)
+	return routing.ResetUserPassword(
+		ctx, req, device, &c.Cfg, c.accountDB, c.deviceDB, c.cacheIn,
+		c.encryptDB, c.syncDB, c.tokenFilter, c.RpcCli,
+	)
+}
+
+type ReqPutAdminUserSuspend struct{}
+
+func (ReqPutAdminUserSuspend) GetRoute() string       { return "/users/{userID}/suspend" }
+func (ReqPutAdminUserSuspend) GetMetricsName() string { return "admin_user_suspend" }
+func (ReqPutAdminUserSuspend) GetMsgType() int32      { return internals.MSG_PUT_ADMIN_USER_SUSPEND }
+func (ReqPutAdminUserSuspend) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutAdminUserSuspend) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutAdminUserSuspend) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutAdminUserSuspend) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPutAdminUserSuspend) NewRequest() core.Coder {
+	return new(external.PutAdminUserSuspendRequest)
+}
+func (ReqPutAdminUserSuspend) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutAdminUserSuspendRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqPutAdminUserSuspend) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPutAdminUserSuspend) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutAdminUserSuspendRequest)
+	return routing.SuspendUser(
+		ctx, req, device, &c.Cfg, c.accountDB, c.deviceDB, c.cacheIn,
+		c.encryptDB, c.syncDB, c.tokenFilter, c.RpcCli,
+	)
+}
+
+type ReqPostAdminUserLogout struct{}
+
+func (ReqPostAdminUserLogout) GetRoute() string       { return "/users/{userID}/logout" }
+func (ReqPostAdminUserLogout) GetMetricsName() string { return "admin_user_logout" }
+func (ReqPostAdminUserLogout) GetMsgType() int32      { return internals.MSG_POST_ADMIN_USER_LOGOUT }
+func (ReqPostAdminUserLogout) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostAdminUserLogout) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostAdminUserLogout) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostAdminUserLogout) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPostAdminUserLogout) NewRequest() core.Coder {
+	return new(external.PostAdminUserLogoutRequest)
+}
+func (ReqPostAdminUserLogout) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostAdminUserLogoutRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqPostAdminUserLogout) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPostAdminUserLogout) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostAdminUserLogoutRequest)
+	return routing.LogoutUser(
+		ctx, req, device, &c.Cfg, c.accountDB, c.deviceDB, c.cacheIn,
+		c.encryptDB, c.syncDB, c.tokenFilter, c.RpcCli,
+	)
+}
@@ -61,6 +61,7 @@ type InternalMsgConsumer struct {
 	syncDB              model.SyncAPIDatabase
 	presenceDB          model.PresenceDatabase
 	roomDB              model.RoomServerDatabase
+	pushDB              model.PushAPIDatabase
 	tokenFilter         *filter.Filter
 	localcache          *cache.LocalCacheRepo
 	complexCache        *common.ComplexCache
@@ -81,6 +82,7 @@ func NewInternalMsgConsumer(
 	syncDB model.SyncAPIDatabase,
 	presenceDB model.PresenceDatabase,
 	roomDB model.RoomServerDatabase,
+	pushDB model.PushAPIDatabase,
 	rpcCli *common.RpcClient,
 	tokenFilter *filter.Filter,
 	complexCache *common.ComplexCache,
@@ -101,6 +103,7 @@ func NewInternalMsgConsumer(
 	c.syncDB = syncDB
 	c.presenceDB = presenceDB
 	c.roomDB = roomDB
+	c.pushDB = pushDB
 	c.tokenFilter = tokenFilter
 	c.localcache = new(cache.LocalCacheRepo)
 	c.localcache.Start(1, cfg.Cache.DurationDefault)
@@ -134,6 +137,8 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqPostRegister{})
 	apiconsumer.SetAPIProcessor(ReqPostRegisterLegacy{})
 	apiconsumer.SetAPIProcessor(ReqGetRegitsterAvailable{})
+	apiconsumer.SetAPIProcessor(ReqPostAccountPassword{})
+	apiconsumer.SetAPIProcessor(ReqPostAccountDeactivate{})
 	apiconsumer.SetAPIProcessor(ReqGetDirectoryRoomAlias{})
 	apiconsumer.SetAPIProcessor(ReqPutDirectoryRoomAlias{})
 	apiconsumer.SetAPIProcessor(ReqDelDirectoryRoomAlias{})
@@ -143,6 +148,8 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqPostLogin{})
 	apiconsumer.SetAPIProcessor(ReqGetLoginAdmin{})
 	apiconsumer.SetAPIProcessor(ReqPostLoginAdmin{})
+	apiconsumer.SetAPIProcessor(ReqPostLoginToken{})
+	apiconsumer.SetAPIProcessor(ReqPostRefresh{})
 	apiconsumer.SetAPIProcessor(ReqPostUserFilter{})
 	apiconsumer.SetAPIProcessor(ReqGetUserFilterWithID{})
 	apiconsumer.SetAPIProcessor(ReqGetProfile{})
@@ -156,7 +163,6 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqPostAssociated3PIDsDel{})
 	apiconsumer.SetAPIProcessor(ReqPostAccount3PIDEmail{})
 	apiconsumer.SetAPIProcessor(ReqGetVoipTurnServer{})
-	apiconsumer.SetAPIProcessor(ReqGetThirdpartyProtos{})
 	apiconsumer.SetAPIProcessor(ReqGetDevicesByUserID{})
 	apiconsumer.SetAPIProcessor(ReqGetDeviceByID{})
 	apiconsumer.SetAPIProcessor(ReqPutDevice{})
@@ -187,6 +193,7 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqPostUserInfo{})
 	apiconsumer.SetAPIProcessor(ReqDeleteUserInfo{})
 	apiconsumer.SetAPIProcessor(ReqDismissRoom{})
+	apiconsumer.SetAPIProcessor(ReqPostRoomUpgrade{})
 }
 
 type ReqPostCreateRoom struct{}
@@ -680,7 +687,7 @@ func (ReqPostRegister) Process(ctx context.Context, consumer interface{}, msg co
 	c := consumer.(*InternalMsgConsumer)
 	req := msg.(*external.PostRegisterRequest)
 	return routing.Register(
-		ctx, req, c.accountDB, c.deviceDB, &c.Cfg, c.idg,
+		ctx, req, c.accountDB, c.deviceDB, c.cacheIn, &c.Cfg, c.idg,
 	)
 }
 
@@ -719,6 +726,81 @@ func (ReqPostRegisterLegacy) Process(ctx context.Context, consumer interface{},
 	)
 }
 
+type ReqPostAccountPassword struct{}
+
+func (ReqPostAccountPassword) GetRoute() string       { return "/account/password" }
+func (ReqPostAccountPassword) GetMetricsName() string { return "account_password" }
+func (ReqPostAccountPassword) GetMsgType() int32      { return internals.MSG_POST_ACCOUT_PASS }
+func (ReqPostAccountPassword) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostAccountPassword) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostAccountPassword) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostAccountPassword) NewRequest() core.Coder {
+	return new(external.PostAccountPasswordRequest)
+}
+func (ReqPostAccountPassword) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostAccountPasswordRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostAccountPassword) NewResponse(code int) core.Coder {
+	if code == http.StatusUnauthorized {
+		return new(external.UserInteractiveResponse)
+	}
+	return nil
+}
+func (ReqPostAccountPassword) GetPrefix() []string { return []string{"r0"} }
+func (ReqPostAccountPassword) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostAccountPasswordRequest)
+	return routing.ChangePassword(
+		ctx, req, device, &c.Cfg, c.accountDB, c.deviceDB, c.cacheIn,
+		c.encryptDB, c.syncDB, c.tokenFilter, c.RpcCli,
+	)
+}
+
+type ReqPostAccountDeactivate struct{}
+
+func (ReqPostAccountDeactivate) GetRoute() string       { return "/account/deactivate" }
+func (ReqPostAccountDeactivate) GetMetricsName() string { return "account_deactivate" }
+func (ReqPostAccountDeactivate) GetMsgType() int32      { return internals.MSG_POST_ACCOUNT_DEACTIVATE }
+func (ReqPostAccountDeactivate) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostAccountDeactivate) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostAccountDeactivate) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostAccountDeactivate) NewRequest() core.Coder {
+	return new(external.PostAccountDeactivateRequest)
+}
+func (ReqPostAccountDeactivate) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostAccountDeactivateRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostAccountDeactivate) NewResponse(code int) core.Coder {
+	if code == http.StatusUnauthorized {
+		return new(external.UserInteractiveResponse)
+	}
+	return new(external.PostAccountDeactivateResponse)
+}
+func (ReqPostAccountDeactivate) GetPrefix() []string { return []string{"r0"} }
+func (ReqPostAccountDeactivate) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostAccountDeactivateRequest)
+	return routing.DeactivateAccount(
+		ctx, req, device, &c.Cfg, c.accountDB, c.deviceDB, c.roomDB, c.pushDB,
+		c.cacheIn, c.encryptDB, c.syncDB, c.tokenFilter, c.RpcCli,
+		c.rsRpcCli, c.federation, c.idg, c.complexCache,
+	)
+}
+
 type ReqGetRegitsterAvailable struct{}
 
 func (ReqGetRegitsterAvailable) GetRoute() string       { return "/register/available" }
@@ -936,7 +1018,7 @@ func (ReqPostLogin) Process(ctx context.Context, consumer interface{}, msg core.
 	req := msg.(*external.PostLoginRequest)
 	return routing.LoginPost(
 		ctx, req, c.accountDB, c.deviceDB, c.encryptDB,
-		c.syncDB, c.Cfg, false, c.idg, c.tokenFilter, c.RpcCli,
+		c.syncDB, c.cacheIn, c.Cfg, false, c.idg, c.tokenFilter, c.RpcCli,
 	)
 }
 
@@ -991,10 +1073,65 @@ func (ReqPostLoginAdmin) Process(ctx context.Context, consumer interface{}, msg
 	req := msg.(*external.PostLoginRequest)
 	return routing.LoginPost(
 		ctx, req, c.accountDB, c.deviceDB, c.encryptDB,
-		c.syncDB, c.Cfg, true, c.idg, c.tokenFilter, c.RpcCli,
+		c.syncDB, c.cacheIn, c.Cfg, true, c.idg, c.tokenFilter, c.RpcCli,
 	)
 }
 
+type ReqPostLoginToken struct{}
+
+func (ReqPostLoginToken) GetRoute() string                     { return "/login/get_token" }
+func (ReqPostLoginToken) GetMetricsName() string               { return "login_token" }
+func (ReqPostLoginToken) GetMsgType() int32                    { return internals.MSG_POST_LOGIN_TOKEN }
+func (ReqPostLoginToken) GetAPIType() int8                     { return apiconsumer.APITypeAuth }
+func (ReqPostLoginToken) GetMethod() []string                  { return []string{http.MethodPost, http.MethodOptions} }
+func (ReqPostLoginToken) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostLoginToken) NewRequest() core.Coder {
+	return new(external.PostLoginTokenRequest)
+}
+func (ReqPostLoginToken) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostLoginTokenRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostLoginToken) NewResponse(code int) core.Coder {
+	if code == http.StatusUnauthorized {
+		return new(external.UserInteractiveResponse)
+	}
+	return new(external.PostLoginTokenResponse)
+}
+func (ReqPostLoginToken) GetPrefix() []string { return []string{"r0", "unstable"} }
+func (ReqPostLoginToken) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostLoginTokenRequest)
+	return routing.CreateLoginToken(ctx, req, device, &c.Cfg, c.accountDB, c.cacheIn)
+}
+
+type ReqPostRefresh struct{}
+
+func (ReqPostRefresh) GetRoute() string                     { return "/refresh" }
+func (ReqPostRefresh) GetMetricsName() string               { return "refresh" }
+func (ReqPostRefresh) GetMsgType() int32                    { return internals.MSG_POST_REFRESH }
+func (ReqPostRefresh) GetAPIType() int8                     { return apiconsumer.APITypeExternal }
+func (ReqPostRefresh) GetMethod() []string                  { return []string{http.MethodPost, http.MethodOptions} }
+func (ReqPostRefresh) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostRefresh) NewRequest() core.Coder {
+	return new(external.PostRefreshRequest)
+}
+func (ReqPostRefresh) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostRefreshRequest)
+	return common.UnmarshalJSON(req, msg)
+}
+func (ReqPostRefresh) NewResponse(code int) core.Coder { return new(external.PostRefreshResponse) }
+func (ReqPostRefresh) GetPrefix() []string             { return []string{"r0"} }
+func (ReqPostRefresh) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostRefreshRequest)
+	return routing.Refresh(ctx, req, &c.Cfg, c.cacheIn)
+}
+
 type ReqPostUserFilter struct{}
 
 func (ReqPostUserFilter) GetRoute() string                     { return "/user/{userId}/filter" }
@@ -1376,27 +1513,6 @@ func (ReqGetVoipTurnServer) Process(ctx context.Context, consumer interface{}, m
 	return routing.RequestTurnServer(ctx, device.UserID, c.Cfg)
 }
 
-type ReqGetThirdpartyProtos struct{}
-
-func (ReqGetThirdpartyProtos) GetRoute() string       { return "/thirdparty/protocols" }
-func (ReqGetThirdpartyProtos) GetMetricsName() string { return "thirdparty_protocols" }
-func (ReqGetThirdpartyProtos) GetMsgType() int32      { return internals.MSG_GET_THIRDPARTY_PROTOS }
-func (ReqGetThirdpartyProtos) GetAPIType() int8       { return apiconsumer.APITypeAuth }
-func (ReqGetThirdpartyProtos) GetMethod() []string {
-	return []string{http.MethodGet, http.MethodOptions}
-}
-func (ReqGetThirdpartyProtos) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
-func (ReqGetThirdpartyProtos) NewRequest() core.Coder               { return nil }
-func (ReqGetThirdpartyProtos) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
-	return nil
-}
-func (ReqGetThirdpartyProtos) NewResponse(code int) core.Coder { return nil }
-func (ReqGetThirdpartyProtos) GetPrefix() []string             { return []string{"r0"} }
-func (ReqGetThirdpartyProtos) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
-	// TODO: Return the third party protcols
-	return http.StatusOK, nil
-}
-
 type ReqGetDevicesByUserID struct{}
 
 func (ReqGetDevicesByUserID) GetRoute() string       { return "/devices" }
@@ -2331,3 +2447,37 @@ func (ReqDismissRoom) Process(ctx context.Context, consumer interface{}, msg cor
 		c.Cfg, c.rsRpcCli, c.federation, c.cacheIn, c.idg, c.complexCache,
 	)
 }
+
+type ReqPostRoomUpgrade struct{}
+
+func (ReqPostRoomUpgrade) GetRoute() string                     { return "/rooms/{roomID}/upgrade" }
+func (ReqPostRoomUpgrade) GetMetricsName() string               { return "upgrade_room" }
+func (ReqPostRoomUpgrade) GetMsgType() int32                    { return internals.MSG_POST_ROOM_UPGRADE }
+func (ReqPostRoomUpgrade) GetAPIType() int8                     { return apiconsumer.APITypeAuth }
+func (ReqPostRoomUpgrade) GetMethod() []string                  { return []string{http.MethodPost, http.MethodOptions} }
+func (ReqPostRoomUpgrade) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostRoomUpgrade) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqPostRoomUpgrade) NewRequest() core.Coder {
+	return new(external.PostRoomUpgradeRequest)
+}
+func (ReqPostRoomUpgrade) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostRoomUpgradeRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+	}
+	return nil
+}
+func (ReqPostRoomUpgrade) NewResponse(code int) core.Coder {
+	return new(external.PostRoomUpgradeResponse)
+}
+func (ReqPostRoomUpgrade) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostRoomUpgradeRequest)
+	return routing.UpgradeRoom(
+		ctx, req, device.UserID, c.Cfg, c.rsRpcCli, c.syncDB, c.idg, c.complexCache,
+	)
+}
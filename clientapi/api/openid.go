@@ -0,0 +1,93 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostUserOpenID{})
+	apiconsumer.SetAPIProcessor(ReqGetFedOpenIDUserInfo{})
+}
+
+type ReqPostUserOpenID struct{}
+
+func (ReqPostUserOpenID) GetRoute() string       { return "/user/{userID}/openid/request_token" }
+func (ReqPostUserOpenID) GetMetricsName() string { return "user_openid" }
+func (ReqPostUserOpenID) GetMsgType() int32      { return internals.MSG_POST_USER_OPENID }
+func (ReqPostUserOpenID) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostUserOpenID) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostUserOpenID) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostUserOpenID) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqPostUserOpenID) NewRequest() core.Coder {
+	return new(external.PostUserOpenIDRequest)
+}
+func (ReqPostUserOpenID) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostUserOpenIDRequest)
+	if vars != nil {
+		msg.UserID = vars["userID"]
+	}
+	return nil
+}
+func (ReqPostUserOpenID) NewResponse(code int) core.Coder {
+	return new(external.PostUserOpenIDResponse)
+}
+func (ReqPostUserOpenID) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostUserOpenIDRequest)
+	return routing.CreateOpenIDToken(ctx, req, device, c.accountDB)
+}
+
+// ReqGetFedOpenIDUserInfo isn't signed by a server, the remote third party
+// only holds the OpenID token, so it is an external API of the fed prefix
+type ReqGetFedOpenIDUserInfo struct{}
+
+func (ReqGetFedOpenIDUserInfo) GetRoute() string       { return "/openid/userinfo" }
+func (ReqGetFedOpenIDUserInfo) GetMetricsName() string { return "federation_openid_userinfo" }
+func (ReqGetFedOpenIDUserInfo) GetMsgType() int32      { return internals.MSG_GET_FED_OPENID_USERINFO }
+func (ReqGetFedOpenIDUserInfo) GetAPIType() int8       { return apiconsumer.APITypeExternal }
+func (ReqGetFedOpenIDUserInfo) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetFedOpenIDUserInfo) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetFedOpenIDUserInfo) GetPrefix() []string                  { return []string{"fedV1"} }
+func (ReqGetFedOpenIDUserInfo) NewRequest() core.Coder {
+	return new(external.GetFedOpenIDUserInfoRequest)
+}
+func (ReqGetFedOpenIDUserInfo) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetFedOpenIDUserInfoRequest)
+	msg.AccessToken = req.URL.Query().Get("access_token")
+	return nil
+}
+func (ReqGetFedOpenIDUserInfo) NewResponse(code int) core.Coder {
+	return new(external.GetFedOpenIDUserInfoResponse)
+}
+func (ReqGetFedOpenIDUserInfo) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetFedOpenIDUserInfoRequest)
+	return routing.GetOpenIDUserInfo(ctx, req, c.accountDB)
+}
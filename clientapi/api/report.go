@@ -0,0 +1,208 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostRoomReport{})
+	apiconsumer.SetAPIProcessor(ReqGetEventReports{})
+	apiconsumer.SetAPIProcessor(ReqGetEventReport{})
+	apiconsumer.SetAPIProcessor(ReqPutEventReportAssign{})
+	apiconsumer.SetAPIProcessor(ReqPostEventReportResolve{})
+}
+
+type ReqPostRoomReport struct{}
+
+func (ReqPostRoomReport) GetRoute() string       { return "/rooms/{roomID}/report/{eventID}" }
+func (ReqPostRoomReport) GetMetricsName() string { return "room_report" }
+func (ReqPostRoomReport) GetMsgType() int32      { return internals.MSG_POST_ROOM_REPORT }
+func (ReqPostRoomReport) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostRoomReport) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostRoomReport) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostRoomReport) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqPostRoomReport) NewRequest() core.Coder {
+	return new(external.PostRoomReportRequest)
+}
+func (ReqPostRoomReport) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostRoomReportRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+		msg.EventID = vars["eventID"]
+	}
+	return nil
+}
+func (ReqPostRoomReport) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPostRoomReport) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostRoomReportRequest)
+	return routing.ReportEvent(ctx, req, device.UserID, c.rsRpcCli, c.syncDB, c.idg)
+}
+
+type ReqGetEventReports struct{}
+
+func (ReqGetEventReports) GetRoute() string       { return "/event_reports" }
+func (ReqGetEventReports) GetMetricsName() string { return "get_event_reports" }
+func (ReqGetEventReports) GetMsgType() int32      { return internals.MSG_GET_EVENT_REPORTS }
+func (ReqGetEventReports) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetEventReports) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetEventReports) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetEventReports) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqGetEventReports) NewRequest() core.Coder {
+	return new(external.GetEventReportsRequest)
+}
+func (ReqGetEventReports) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetEventReportsRequest)
+	query := req.URL.Query()
+	msg.RoomID = query.Get("room_id")
+	msg.UserID = query.Get("user_id")
+	msg.Sender = query.Get("sender")
+	msg.Status = query.Get("status")
+	msg.Assignee = query.Get("assignee")
+	msg.From = query.Get("from")
+	if limit := query.Get("limit"); limit != "" {
+		msg.Limit, _ = strconv.Atoi(limit)
+	}
+	return nil
+}
+func (ReqGetEventReports) NewResponse(code int) core.Coder {
+	return new(external.GetEventReportsResponse)
+}
+func (ReqGetEventReports) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetEventReportsRequest)
+	return routing.GetEventReports(ctx, req, device, &c.Cfg, c.syncDB)
+}
+
+type ReqGetEventReport struct{}
+
+func (ReqGetEventReport) GetRoute() string       { return "/event_reports/{reportID}" }
+func (ReqGetEventReport) GetMetricsName() string { return "get_event_report" }
+func (ReqGetEventReport) GetMsgType() int32      { return internals.MSG_GET_EVENT_REPORT }
+func (ReqGetEventReport) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetEventReport) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetEventReport) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetEventReport) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqGetEventReport) NewRequest() core.Coder {
+	return new(external.GetEventReportRequest)
+}
+func (ReqGetEventReport) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetEventReportRequest)
+	if vars != nil {
+		msg.ReportID = vars["reportID"]
+	}
+	return nil
+}
+func (ReqGetEventReport) NewResponse(code int) core.Coder {
+	return new(external.EventReport)
+}
+func (ReqGetEventReport) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetEventReportRequest)
+	return routing.GetEventReport(ctx, req, device, &c.Cfg, c.syncDB)
+}
+
+type ReqPutEventReportAssign struct{}
+
+func (ReqPutEventReportAssign) GetRoute() string       { return "/event_reports/{reportID}/assign" }
+func (ReqPutEventReportAssign) GetMetricsName() string { return "assign_event_report" }
+func (ReqPutEventReportAssign) GetMsgType() int32      { return internals.MSG_PUT_EVENT_REPORT_ASSIGN }
+func (ReqPutEventReportAssign) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutEventReportAssign) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutEventReportAssign) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutEventReportAssign) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPutEventReportAssign) NewRequest() core.Coder {
+	return new(external.PutEventReportAssignRequest)
+}
+func (ReqPutEventReportAssign) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutEventReportAssignRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.ReportID = vars["reportID"]
+	}
+	return nil
+}
+func (ReqPutEventReportAssign) NewResponse(code int) core.Coder {
+	return new(external.EventReport)
+}
+func (ReqPutEventReportAssign) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutEventReportAssignRequest)
+	return routing.AssignEventReport(ctx, req, device, &c.Cfg, c.syncDB)
+}
+
+type ReqPostEventReportResolve struct{}
+
+func (ReqPostEventReportResolve) GetRoute() string       { return "/event_reports/{reportID}/resolve" }
+func (ReqPostEventReportResolve) GetMetricsName() string { return "resolve_event_report" }
+func (ReqPostEventReportResolve) GetMsgType() int32      { return internals.MSG_POST_EVENT_REPORT_RESOLVE }
+func (ReqPostEventReportResolve) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostEventReportResolve) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostEventReportResolve) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostEventReportResolve) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPostEventReportResolve) NewRequest() core.Coder {
+	return new(external.PostEventReportResolveRequest)
+}
+func (ReqPostEventReportResolve) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostEventReportResolveRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.ReportID = vars["reportID"]
+	}
+	return nil
+}
+func (ReqPostEventReportResolve) NewResponse(code int) core.Coder {
+	return new(external.EventReport)
+}
+func (ReqPostEventReportResolve) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostEventReportResolveRequest)
+	return routing.ResolveEventReport(
+		ctx, req, device, c.Cfg, c.accountDB, c.rsRpcCli, c.syncDB,
+		c.federation, c.cacheIn, c.idg, c.complexCache,
+	)
+}
@@ -0,0 +1,152 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqGetCasLoginRedirect{})
+	apiconsumer.SetAPIProcessor(ReqGetCasLoginTicket{})
+	apiconsumer.SetAPIProcessor(ReqGetSSOLoginRedirect{})
+	apiconsumer.SetAPIProcessor(ReqGetSSOLoginCallback{})
+}
+
+type ReqGetCasLoginRedirect struct{}
+
+func (ReqGetCasLoginRedirect) GetRoute() string       { return "/login/cas/redirect" }
+func (ReqGetCasLoginRedirect) GetMetricsName() string { return "cas_redirect" }
+func (ReqGetCasLoginRedirect) GetMsgType() int32      { return internals.MSG_GET_CAS_LOGIN_REDIRECT }
+func (ReqGetCasLoginRedirect) GetAPIType() int8       { return apiconsumer.APITypeExternal }
+func (ReqGetCasLoginRedirect) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetCasLoginRedirect) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetCasLoginRedirect) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetCasLoginRedirect) NewRequest() core.Coder {
+	return new(external.GetCasLoginRedirectRequest)
+}
+func (ReqGetCasLoginRedirect) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetCasLoginRedirectRequest)
+	msg.RedirectURL = req.URL.Query().Get("redirectUrl")
+	return nil
+}
+func (ReqGetCasLoginRedirect) NewResponse(code int) core.Coder {
+	return new(external.LoginRedirectResponse)
+}
+func (ReqGetCasLoginRedirect) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetCasLoginRedirectRequest)
+	return routing.CASLoginRedirect(ctx, req, &c.Cfg)
+}
+
+type ReqGetCasLoginTicket struct{}
+
+func (ReqGetCasLoginTicket) GetRoute() string       { return "/login/cas/ticket" }
+func (ReqGetCasLoginTicket) GetMetricsName() string { return "cas_ticket" }
+func (ReqGetCasLoginTicket) GetMsgType() int32      { return internals.MSG_GET_CAS_LOGIN_TICKET }
+func (ReqGetCasLoginTicket) GetAPIType() int8       { return apiconsumer.APITypeExternal }
+func (ReqGetCasLoginTicket) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetCasLoginTicket) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetCasLoginTicket) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetCasLoginTicket) NewRequest() core.Coder {
+	return new(external.GetCasLoginTickerRequest)
+}
+func (ReqGetCasLoginTicket) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetCasLoginTickerRequest)
+	query := req.URL.Query()
+	msg.RedirectURL = query.Get("redirectUrl")
+	msg.Ticket = query.Get("ticket")
+	return nil
+}
+func (ReqGetCasLoginTicket) NewResponse(code int) core.Coder {
+	return new(external.LoginRedirectResponse)
+}
+func (ReqGetCasLoginTicket) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetCasLoginTickerRequest)
+	return routing.CASLoginTicket(ctx, req, &c.Cfg, c.accountDB, c.cacheIn)
+}
+
+type ReqGetSSOLoginRedirect struct{}
+
+func (ReqGetSSOLoginRedirect) GetRoute() string       { return "/login/sso/redirect" }
+func (ReqGetSSOLoginRedirect) GetMetricsName() string { return "sso_redirect" }
+func (ReqGetSSOLoginRedirect) GetMsgType() int32      { return internals.MSG_GET_SSO_LOGIN_REDIRECT }
+func (ReqGetSSOLoginRedirect) GetAPIType() int8       { return apiconsumer.APITypeExternal }
+func (ReqGetSSOLoginRedirect) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetSSOLoginRedirect) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetSSOLoginRedirect) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetSSOLoginRedirect) NewRequest() core.Coder {
+	return new(external.GetSSOLoginRedirectRequest)
+}
+func (ReqGetSSOLoginRedirect) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetSSOLoginRedirectRequest)
+	msg.RedirectURL = req.URL.Query().Get("redirectUrl")
+	return nil
+}
+func (ReqGetSSOLoginRedirect) NewResponse(code int) core.Coder {
+	return new(external.LoginRedirectResponse)
+}
+func (ReqGetSSOLoginRedirect) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetSSOLoginRedirectRequest)
+	return routing.SSOLoginRedirect(ctx, req, &c.Cfg, c.cacheIn)
+}
+
+type ReqGetSSOLoginCallback struct{}
+
+func (ReqGetSSOLoginCallback) GetRoute() string       { return "/login/sso/callback" }
+func (ReqGetSSOLoginCallback) GetMetricsName() string { return "sso_callback" }
+func (ReqGetSSOLoginCallback) GetMsgType() int32      { return internals.MSG_GET_SSO_LOGIN_CALLBACK }
+func (ReqGetSSOLoginCallback) GetAPIType() int8       { return apiconsumer.APITypeExternal }
+func (ReqGetSSOLoginCallback) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetSSOLoginCallback) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetSSOLoginCallback) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetSSOLoginCallback) NewRequest() core.Coder {
+	return new(external.GetSSOLoginCallbackRequest)
+}
+func (ReqGetSSOLoginCallback) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetSSOLoginCallbackRequest)
+	query := req.URL.Query()
+	msg.Code = query.Get("code")
+	msg.State = query.Get("state")
+	msg.Error = query.Get("error")
+	return nil
+}
+func (ReqGetSSOLoginCallback) NewResponse(code int) core.Coder {
+	return new(external.LoginRedirectResponse)
+}
+func (ReqGetSSOLoginCallback) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetSSOLoginCallbackRequest)
+	return routing.SSOLoginCallback(ctx, req, &c.Cfg, c.accountDB, c.cacheIn)
+}
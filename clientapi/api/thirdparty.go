@@ -0,0 +1,235 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyProtos{})
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyProtoByName{})
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyLocationByProto{})
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyUserByProto{})
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyLocation{})
+	apiconsumer.SetAPIProcessor(ReqGetThirdpartyUser{})
+}
+
+// thirdPartyFields returns the query parameters of the request, they are the
+// fields the application services look the protocol up with
+func thirdPartyFields(req *http.Request) map[string]string {
+	fields := make(map[string]string)
+	for k, v := range req.URL.Query() {
+		if k == "access_token" || len(v) == 0 {
+			continue
+		}
+		fields[k] = v[0]
+	}
+	return fields
+}
+
+type ReqGetThirdpartyProtos struct{}
+
+func (ReqGetThirdpartyProtos) GetRoute() string       { return "/thirdparty/protocols" }
+func (ReqGetThirdpartyProtos) GetMetricsName() string { return "thirdparty_protocols" }
+func (ReqGetThirdpartyProtos) GetMsgType() int32      { return internals.MSG_GET_THIRDPARTY_PROTOS }
+func (ReqGetThirdpartyProtos) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyProtos) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyProtos) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetThirdpartyProtos) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetThirdpartyProtos) NewRequest() core.Coder               { return nil }
+func (ReqGetThirdpartyProtos) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	return nil
+}
+func (ReqGetThirdpartyProtos) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyProtocalsResponse)
+}
+func (ReqGetThirdpartyProtos) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	return routing.GetThirdPartyProtocols(ctx, &c.Cfg)
+}
+
+type ReqGetThirdpartyProtoByName struct{}
+
+func (ReqGetThirdpartyProtoByName) GetRoute() string       { return "/thirdparty/protocol/{protocol}" }
+func (ReqGetThirdpartyProtoByName) GetMetricsName() string { return "thirdparty_by_name" }
+func (ReqGetThirdpartyProtoByName) GetMsgType() int32 {
+	return internals.MSG_GET_THIRDPARTY_PROTO_BY_NAME
+}
+func (ReqGetThirdpartyProtoByName) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyProtoByName) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyProtoByName) GetTopic(cfg *config.Dendrite) string {
+	return getProxyRpcTopic(cfg)
+}
+func (ReqGetThirdpartyProtoByName) GetPrefix() []string { return []string{"r0"} }
+func (ReqGetThirdpartyProtoByName) NewRequest() core.Coder {
+	return new(external.GetThirdPartyProtocalByNameRequest)
+}
+func (ReqGetThirdpartyProtoByName) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetThirdPartyProtocalByNameRequest)
+	if vars != nil {
+		msg.Protocol = vars["protocol"]
+	}
+	return nil
+}
+func (ReqGetThirdpartyProtoByName) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyProtocalByNameResponse)
+}
+func (ReqGetThirdpartyProtoByName) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetThirdPartyProtocalByNameRequest)
+	return routing.GetThirdPartyProtocol(ctx, req, &c.Cfg)
+}
+
+type ReqGetThirdpartyLocationByProto struct{}
+
+func (ReqGetThirdpartyLocationByProto) GetRoute() string {
+	return "/thirdparty/location/{protocol}"
+}
+func (ReqGetThirdpartyLocationByProto) GetMetricsName() string { return "thirdparty_location_by_name" }
+func (ReqGetThirdpartyLocationByProto) GetMsgType() int32 {
+	return internals.MSG_GET_THIRDPARTY_LOCATION_BY_PROTO
+}
+func (ReqGetThirdpartyLocationByProto) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyLocationByProto) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyLocationByProto) GetTopic(cfg *config.Dendrite) string {
+	return getProxyRpcTopic(cfg)
+}
+func (ReqGetThirdpartyLocationByProto) GetPrefix() []string { return []string{"r0"} }
+func (ReqGetThirdpartyLocationByProto) NewRequest() core.Coder {
+	return new(external.GetThirdPartyLocationByProtocolRequest)
+}
+func (ReqGetThirdpartyLocationByProto) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetThirdPartyLocationByProtocolRequest)
+	if vars != nil {
+		msg.Protocol = vars["protocol"]
+	}
+	msg.Fields = thirdPartyFields(req)
+	return nil
+}
+func (ReqGetThirdpartyLocationByProto) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyLocationByProtocolResponse)
+}
+func (ReqGetThirdpartyLocationByProto) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetThirdPartyLocationByProtocolRequest)
+	return routing.GetThirdPartyLocations(ctx, req, &c.Cfg)
+}
+
+type ReqGetThirdpartyUserByProto struct{}
+
+func (ReqGetThirdpartyUserByProto) GetRoute() string       { return "/thirdparty/user/{protocol}" }
+func (ReqGetThirdpartyUserByProto) GetMetricsName() string { return "thirdparty_user_by_name" }
+func (ReqGetThirdpartyUserByProto) GetMsgType() int32 {
+	return internals.MSG_GET_THIRDPARTY_USER_BY_PROTO
+}
+func (ReqGetThirdpartyUserByProto) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyUserByProto) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyUserByProto) GetTopic(cfg *config.Dendrite) string {
+	return getProxyRpcTopic(cfg)
+}
+func (ReqGetThirdpartyUserByProto) GetPrefix() []string { return []string{"r0"} }
+func (ReqGetThirdpartyUserByProto) NewRequest() core.Coder {
+	return new(external.GetThirdPartyUserByProtocolRequest)
+}
+func (ReqGetThirdpartyUserByProto) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetThirdPartyUserByProtocolRequest)
+	if vars != nil {
+		msg.Protocol = vars["protocol"]
+	}
+	msg.Fields = thirdPartyFields(req)
+	return nil
+}
+func (ReqGetThirdpartyUserByProto) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyUserByProtocolResponse)
+}
+func (ReqGetThirdpartyUserByProto) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetThirdPartyUserByProtocolRequest)
+	return routing.GetThirdPartyUsers(ctx, req, &c.Cfg)
+}
+
+type ReqGetThirdpartyLocation struct{}
+
+func (ReqGetThirdpartyLocation) GetRoute() string       { return "/thirdparty/location" }
+func (ReqGetThirdpartyLocation) GetMetricsName() string { return "thirdparty_location" }
+func (ReqGetThirdpartyLocation) GetMsgType() int32      { return internals.MSG_GET_THIRDPARTY_LOCATION }
+func (ReqGetThirdpartyLocation) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyLocation) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyLocation) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetThirdpartyLocation) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetThirdpartyLocation) NewRequest() core.Coder {
+	return new(external.GetThirdPartyLocationRequest)
+}
+func (ReqGetThirdpartyLocation) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetThirdPartyLocationRequest)
+	msg.Alias = req.URL.Query().Get("alias")
+	return nil
+}
+func (ReqGetThirdpartyLocation) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyLocationResponse)
+}
+func (ReqGetThirdpartyLocation) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetThirdPartyLocationRequest)
+	return routing.GetThirdPartyLocationsByAlias(ctx, req, &c.Cfg)
+}
+
+type ReqGetThirdpartyUser struct{}
+
+func (ReqGetThirdpartyUser) GetRoute() string       { return "/thirdparty/user" }
+func (ReqGetThirdpartyUser) GetMetricsName() string { return "thirdparty_user" }
+func (ReqGetThirdpartyUser) GetMsgType() int32      { return internals.MSG_GET_THIRDPARTY_USER }
+func (ReqGetThirdpartyUser) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetThirdpartyUser) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetThirdpartyUser) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetThirdpartyUser) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqGetThirdpartyUser) NewRequest() core.Coder {
+	return new(external.GetThirdPartyUserRequest)
+}
+func (ReqGetThirdpartyUser) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetThirdPartyUserRequest)
+	msg.UserID = req.URL.Query().Get("userid")
+	return nil
+}
+func (ReqGetThirdpartyUser) NewResponse(code int) core.Coder {
+	return new(external.GetThirdPartyUserResponse)
+}
+func (ReqGetThirdpartyUser) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetThirdPartyUserRequest)
+	return routing.GetThirdPartyUsersByID(ctx, req, &c.Cfg)
+}
@@ -56,12 +56,14 @@ func SetupClientAPIComponent(
 	profileRpcConsumer := rpc.NewProfileRpcConsumer(rpcCli, base.Cfg, rsRpcCli, idg, accountsDB, presenceDB, cache, complexCache)
 	profileRpcConsumer.Start()
 
+	pushDB := base.CreatePushApiDB()
+
 	apiConsumer := api.NewInternalMsgConsumer(
 		base.APIMux, *base.Cfg,
 		rsRpcCli, accountsDB, deviceDB,
 		federation, *keyRing,
 		cache, encryptDB, syncDB, presenceDB,
-		roomDB, rpcCli, tokenFilter, complexCache, serverConfDB,
+		roomDB, pushDB, rpcCli, tokenFilter, complexCache, serverConfDB,
 	)
 	apiConsumer.Start()
 }
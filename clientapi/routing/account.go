@@ -0,0 +1,216 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/filter"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/core"
+	fed "github.com/finogeeks/ligase/federation/fedreq"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// accountAuthFlows are the user-interactive auth flows that confirm a
+// logged in user before the account is changed
+var accountAuthFlows = []external.AuthFlow{{Stages: []string{"m.login.password"}}}
+
+// CheckAccountAuth returns a response when the auth of the request doesn't
+// complete a flow of accountAuthFlows, nil otherwise. The provider login mode
+// keeps no credentials to confirm the user with, an access token alone must
+// not change the account so the request is refused there.
+func CheckAccountAuth(
+	ctx context.Context,
+	auth *external.AuthData,
+	userID string,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+) (int, core.Coder) {
+	if strings.EqualFold(cfg.Authorization.AuthorizeMode, "provider") {
+		return http.StatusForbidden, jsonerror.Forbidden("the login provider manages this account")
+	}
+	sessionID := auth.Session
+	if sessionID == "" {
+		sessionID = util.RandomString(sessionIDLength)
+	}
+	flows := accountAuthFlows
+
+	stage := ""
+	switch auth.Type {
+	case "m.login.password":
+		user := auth.User
+		if auth.Identifier != nil && auth.Identifier.User != "" {
+			user = auth.Identifier.User
+		}
+		if user != "" && user != userID {
+			localpart, _, err := gomatrixserverlib.SplitID('@', userID)
+			if err != nil || user != localpart {
+				return http.StatusForbidden, jsonerror.Forbidden("auth user doesn't match the access token")
+			}
+		}
+		if _, err := accountDB.GetAccountByPassword(ctx, userID, auth.Password); err != nil {
+			log.Warnf("account auth of user %s failed: %v", userID, err)
+			return http.StatusForbidden, jsonerror.Forbidden("invalid password")
+		}
+		stage = auth.Type
+	}
+
+	if stage != "" && checkFlowCompleted([]string{stage}, flows) {
+		return 0, nil
+	}
+	return http.StatusUnauthorized, &external.UserInteractiveResponse{
+		Flows:     flows,
+		Completed: []string{},
+		Params:    map[string]interface{}{},
+		Session:   sessionID,
+	}
+}
+
+// ChangePassword implements POST /account/password
+func ChangePassword(
+	ctx context.Context,
+	req *external.PostAccountPasswordRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	deviceDB model.DeviceDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+) (int, core.Coder) {
+	if code, resp := CheckAccountAuth(ctx, &req.Auth, device.UserID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+
+	if req.NewPassword == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("new_password is required")
+	}
+	if code, err := validatePassword(req.NewPassword); err != nil {
+		return code, err
+	}
+	if err := accountDB.SetPassword(ctx, device.UserID, req.NewPassword); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("change password user %s device %s", device.UserID, device.ID)
+
+	if req.LogoutDevices == nil || *req.LogoutDevices {
+		// the kicked devices learn why on their next request
+		hasPwdDevice := false
+		for _, dev := range *cache.GetDevicesByUserID(device.UserID) {
+			if dev.ID == device.ID {
+				continue
+			}
+			cache.SetPwdChangeDevcie(dev.ID, device.UserID)
+			hasPwdDevice = true
+			LogoutDevice(ctx, dev.UserID, dev.ID, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+		}
+		if hasPwdDevice {
+			cache.ExpirePwdChangeDevice(device.UserID)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// DeactivateAccount implements POST /account/deactivate
+func DeactivateAccount(
+	ctx context.Context,
+	req *external.PostAccountDeactivateRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	deviceDB model.DeviceDatabase,
+	roomDB model.RoomServerDatabase,
+	pushDB model.PushAPIDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+	rsRpcCli roomserverapi.RoomserverRPCAPI,
+	federation *fed.Federation,
+	idg *uid.UidGenerator,
+	complexCache *common.ComplexCache,
+) (int, core.Coder) {
+	userID := device.UserID
+	if code, resp := CheckAccountAuth(ctx, &req.Auth, userID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+
+	// mark the account first, no new login may race the clean up below
+	if err := accountDB.DeactivateAccount(ctx, userID); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("deactivate account user %s device %s", userID, device.ID)
+
+	join, invite, _, err := roomDB.GetUserRooms(ctx, userID)
+	if err != nil {
+		log.Errorf("deactivate account user %s get rooms error %v", userID, err)
+	}
+	for _, roomID := range append(join, invite...) {
+		r := &external.PostRoomsMembershipRequest{RoomID: roomID, Membership: "leave"}
+		code, resp := SendMembership(
+			ctx, r, accountDB, userID, device.ID, roomID, "leave",
+			*cfg, rsRpcCli, federation, cache, idg, complexCache,
+		)
+		if code != http.StatusOK {
+			log.Errorf("deactivate account user %s leave room %s error %d %v", userID, roomID, code, resp)
+		}
+	}
+
+	// the email and mobile of the user info are the 3pids of the account
+	if err := cache.DeleteUserInfo(userID); err != nil {
+		log.Errorf("deactivate account user %s delete user_info cache error %v", userID, err)
+	}
+	if err := accountDB.DeleteUserInfo(ctx, userID); err != nil {
+		log.Errorf("deactivate account user %s delete user_info error %v", userID, err)
+	}
+
+	if pusherIDs, ok := cache.GetUserPusherIds(userID); ok {
+		for _, pusherID := range pusherIDs {
+			data, _ := cache.GetPusherCacheData(pusherID)
+			if data == nil {
+				continue
+			}
+			if err := pushDB.DeleteUserPushers(ctx, userID, data.AppId, data.PushKey); err != nil {
+				log.Errorf("deactivate account user %s delete pusher %s error %v", userID, pusherID, err)
+			}
+		}
+	}
+
+	for _, dev := range *cache.GetDevicesByUserID(userID) {
+		LogoutDevice(ctx, dev.UserID, dev.ID, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+	}
+
+	return http.StatusOK, &external.PostAccountDeactivateResponse{
+		IDServerUnbindResult: "no-support",
+	}
+}
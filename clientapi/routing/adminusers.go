@@ -0,0 +1,304 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/filter"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+const (
+	defaultAdminUsersLimit = 10
+	maxAdminUsersLimit     = 100
+)
+
+// checkAdminTarget returns a response when the device is not an admin or
+// the user isn't an account of this server, nil otherwise
+func checkAdminTarget(
+	ctx context.Context,
+	device *authtypes.Device,
+	userID string,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+) (*authtypes.Account, int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return nil, http.StatusForbidden, jsonerror.Forbidden("only server admins can manage users")
+	}
+	domain, err := common.DomainFromID(userID)
+	if err != nil {
+		return nil, http.StatusBadRequest, jsonerror.InvalidUsername("User ID must be @localpart:domain")
+	}
+	if !common.CheckValidDomain(domain, cfg.Matrix.ServerName) {
+		return nil, http.StatusBadRequest, jsonerror.InvalidUsername("User ID not ours")
+	}
+	account, err := accountDB.GetAccount(ctx, userID)
+	if err != nil {
+		code, resp := httputil.LogThenErrorCtx(ctx, err)
+		return nil, code, resp
+	}
+	if account == nil || account.UserID == "" {
+		return nil, http.StatusNotFound, jsonerror.NotFound("user not found")
+	}
+	return account, http.StatusOK, nil
+}
+
+// logoutUserDevices logs out every device of the user, the devices learn
+// the reason on their next request when pwdChange is set
+func logoutUserDevices(
+	ctx context.Context,
+	userID string,
+	pwdChange bool,
+	deviceDB model.DeviceDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+) {
+	devices := *cache.GetDevicesByUserID(userID)
+	for _, dev := range devices {
+		if pwdChange {
+			cache.SetPwdChangeDevcie(dev.ID, userID)
+		}
+		LogoutDevice(ctx, dev.UserID, dev.ID, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+	}
+	if pwdChange && len(devices) > 0 {
+		cache.ExpirePwdChangeDevice(userID)
+	}
+}
+
+// WhoIs implements GET /admin/whois/{userId}, users may look themselves up
+func WhoIs(
+	ctx context.Context,
+	req *external.GetWhoIsRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	cache service.Cache,
+) (int, core.Coder) {
+	if req.UserID != device.UserID && !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can look up other users")
+	}
+
+	lastSeens, err := cache.GetDevicesLastSeen(req.UserID)
+	if err != nil {
+		log.Warnf("whois user %s get last seen error %v", req.UserID, err)
+	}
+	resp := &external.GetWhoIsResponse{
+		UserID:  req.UserID,
+		Devices: make(map[string]external.DeviceInfo),
+	}
+	for _, dev := range *cache.GetDevicesByUserID(req.UserID) {
+		connections := []external.ConnectionInfo{}
+		if lastSeen, ok := lastSeens[dev.ID]; ok {
+			connections = append(connections, external.ConnectionInfo{
+				Ip:        lastSeen.IP,
+				LastSeen:  lastSeen.Ts,
+				UserAgent: lastSeen.UserAgent,
+			})
+		}
+		resp.Devices[dev.ID] = external.DeviceInfo{
+			Sessions: []external.SessionInfo{{Connections: connections}},
+		}
+	}
+	return http.StatusOK, resp
+}
+
+// GetAdminUsers implements GET /system/manager/users
+func GetAdminUsers(
+	ctx context.Context,
+	req *external.GetAdminUsersRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+) (int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can manage users")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultAdminUsersLimit
+	}
+	if limit > maxAdminUsersLimit {
+		limit = maxAdminUsersLimit
+	}
+
+	accounts, err := accountDB.GetAccounts(ctx, req.Search, req.From, limit)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	resp := &external.GetAdminUsersResponse{Users: []external.AdminUser{}}
+	for _, account := range accounts {
+		resp.Users = append(resp.Users, external.AdminUser{
+			UserID:      account.UserID,
+			CreatedTs:   account.CreatedTs,
+			Deactivated: account.Deactivated,
+			Suspended:   account.Suspended,
+		})
+	}
+	if len(accounts) == limit {
+		resp.NextToken = accounts[len(accounts)-1].UserID
+	}
+	return http.StatusOK, resp
+}
+
+// GetAdminUserDevices implements GET /system/manager/users/{userID}/devices
+func GetAdminUserDevices(
+	ctx context.Context,
+	req *external.GetAdminUserDevicesRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if _, code, resp := checkAdminTarget(ctx, device, req.UserID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+
+	lastSeens, err := cache.GetDevicesLastSeen(req.UserID)
+	if err != nil {
+		log.Warnf("admin get devices of user %s get last seen error %v", req.UserID, err)
+	}
+	resp := &external.GetAdminUserDevicesResponse{Devices: []external.AdminUserDevice{}}
+	for _, dev := range *cache.GetDevicesByUserID(req.UserID) {
+		adminDev := external.AdminUserDevice{
+			DeviceID:    dev.ID,
+			DisplayName: dev.DisplayName,
+		}
+		if lastSeen, ok := lastSeens[dev.ID]; ok {
+			adminDev.LastSeenIP = lastSeen.IP
+			adminDev.LastSeenUserAgent = lastSeen.UserAgent
+			adminDev.LastSeenTs = lastSeen.Ts
+		}
+		resp.Devices = append(resp.Devices, adminDev)
+	}
+	return http.StatusOK, resp
+}
+
+// ResetUserPassword implements POST /system/manager/users/{userID}/password
+func ResetUserPassword(
+	ctx context.Context,
+	req *external.PostAdminUserPasswordRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	deviceDB model.DeviceDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+) (int, core.Coder) {
+	account, code, resp := checkAdminTarget(ctx, device, req.UserID, cfg, accountDB)
+	if resp != nil {
+		return code, resp
+	}
+	if account.Deactivated {
+		return http.StatusBadRequest, jsonerror.UserDeactivated("account has been deactivated")
+	}
+	if req.NewPassword == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("new_password is required")
+	}
+	if code, err := validatePassword(req.NewPassword); err != nil {
+		return code, err
+	}
+
+	if err := accountDB.SetPassword(ctx, req.UserID, req.NewPassword); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("admin %s reset password of user %s", device.UserID, req.UserID)
+
+	if req.LogoutDevices == nil || *req.LogoutDevices {
+		logoutUserDevices(ctx, req.UserID, true, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+	}
+	return http.StatusOK, nil
+}
+
+// SuspendUser implements PUT /system/manager/users/{userID}/suspend, the
+// sessions of a suspended user are logged out
+func SuspendUser(
+	ctx context.Context,
+	req *external.PutAdminUserSuspendRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	deviceDB model.DeviceDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+) (int, core.Coder) {
+	if _, code, resp := checkAdminTarget(ctx, device, req.UserID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+	if cfg.IsServerAdmin(req.UserID) {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("server admins can't be suspended")
+	}
+
+	if err := accountDB.SetAccountSuspended(ctx, req.UserID, req.Suspend); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("admin %s set suspended %t of user %s", device.UserID, req.Suspend, req.UserID)
+
+	if req.Suspend {
+		logoutUserDevices(ctx, req.UserID, false, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+	}
+	return http.StatusOK, nil
+}
+
+// LogoutUser implements POST /system/manager/users/{userID}/logout, all the
+// devices are logged out when no device_id is given
+func LogoutUser(
+	ctx context.Context,
+	req *external.PostAdminUserLogoutRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	deviceDB model.DeviceDatabase,
+	cache service.Cache,
+	encryptDB model.EncryptorAPIDatabase,
+	syncDB model.SyncAPIDatabase,
+	tokenFilter *filter.Filter,
+	rpcClient *common.RpcClient,
+) (int, core.Coder) {
+	if _, code, resp := checkAdminTarget(ctx, device, req.UserID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+
+	log.Infof("admin %s logout user %s device %s", device.UserID, req.UserID, req.DeviceID)
+	if req.DeviceID == "" {
+		logoutUserDevices(ctx, req.UserID, false, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+		return http.StatusOK, nil
+	}
+
+	dev := cache.GetDeviceByDeviceID(req.DeviceID, req.UserID)
+	if dev == nil || dev.UserID != req.UserID {
+		return http.StatusNotFound, jsonerror.NotFound("device not found")
+	}
+	LogoutDevice(ctx, req.UserID, req.DeviceID, deviceDB, cache, encryptDB, syncDB, tokenFilter, rpcClient)
+	return http.StatusOK, nil
+}
@@ -31,13 +31,23 @@ import (
 	"github.com/finogeeks/ligase/common/jsonerror"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/service"
 	"github.com/finogeeks/ligase/model/types"
 	"github.com/finogeeks/ligase/plugins/message/external"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/finogeeks/ligase/storage/model"
 )
 
+const (
+	loginTypeToken   = "m.login.token"
+	loginTokenLength = 32
+	// loginTokenLifetime is how long in seconds a login token can be
+	// exchanged for an access token, it is revoked once used
+	loginTokenLifetime = 120
+)
+
 func passwordLogin() *external.GetLoginResponse {
 	f := &external.GetLoginResponse{}
 	s := external.Flow{"m.login.password", []string{"m.login.password"}}
@@ -45,6 +55,19 @@ func passwordLogin() *external.GetLoginResponse {
 	return f
 }
 
+// ssoLogin appends the flows of the enabled identity providers, they all
+// end with an m.login.token login
+func ssoLogin(f *external.GetLoginResponse, cfg *config.Dendrite) *external.GetLoginResponse {
+	if cfg.SSO.CAS.Enabled {
+		f.Flows = append(f.Flows, external.Flow{Type: "m.login.cas", Stages: []string{"m.login.cas"}})
+	}
+	if cfg.SSO.OIDC.Enabled || cfg.SSO.CAS.Enabled {
+		f.Flows = append(f.Flows, external.Flow{Type: "m.login.sso", Stages: []string{"m.login.sso"}})
+	}
+	f.Flows = append(f.Flows, external.Flow{Type: loginTypeToken, Stages: []string{loginTypeToken}})
+	return f
+}
+
 func providerLogin(
 	userID string,
 	ctx context.Context,
@@ -54,6 +77,7 @@ func providerLogin(
 	accountDB model.AccountsDatabase,
 	encryptDB model.EncryptorAPIDatabase,
 	syncDB model.SyncAPIDatabase,
+	cache service.Cache,
 	admin bool,
 	idg *uid.UidGenerator,
 	tokenFilter *filter.Filter,
@@ -72,6 +96,12 @@ func providerLogin(
 	if !allow {
 		return http.StatusUnauthorized, jsonerror.Unknown(fmt.Sprintf("account has to max count: %d", cfg.LicenseItem.TotalUsers))
 	}
+	if account != nil && account.Deactivated {
+		return http.StatusForbidden, jsonerror.UserDeactivated("account has been deactivated")
+	}
+	if account != nil && account.Suspended {
+		return http.StatusForbidden, jsonerror.UserSuspended("account has been suspended")
+	}
 	appServiceID := "virtual"
 	if (account != nil && account.AppServiceID == "actual") || *devID != "" {
 		appServiceID = "actual"
@@ -109,10 +139,11 @@ func providerLogin(
 		log.Errorf("Login remove std message error, device: %s ,  user: %s , error: %v", deviceID, userID, err)
 	}
 
-	token, err := common.BuildToken(cfg.Macaroon.Key, r.User, domain, r.User, r.DeviceID, false, deviceID, deviceType, human)
+	tokens, err := buildSessionTokens(&cfg, cache, userID, r.DeviceID, deviceID, deviceType, human, r.RefreshToken)
 	if err != nil {
-		httputil.LogThenErrorCtx(ctx, err)
+		return httputil.LogThenErrorCtx(ctx, err)
 	}
+	token := tokens.accessToken
 
 	dev, err := deviceDB.CreateDevice(
 		ctx, userID, deviceID, deviceType, r.InitialDisplayName, human, devID, -1,
@@ -143,10 +174,12 @@ func providerLogin(
 	}
 	pubLoginToken(userID, deviceID, rpcClient)
 	return http.StatusOK, &external.PostLoginResponse{
-		UserID:      dev.UserID,
-		AccessToken: token,
-		HomeServer:  domain,
-		DeviceID:    dev.ID,
+		UserID:       dev.UserID,
+		AccessToken:  token,
+		HomeServer:   domain,
+		DeviceID:     dev.ID,
+		RefreshToken: tokens.refreshToken,
+		ExpiresInMs:  tokens.expiresInMs,
 	}
 }
 
@@ -188,6 +221,48 @@ func pubLoginToken(userID string, deviceID string, rpcClient *common.RpcClient)
 	}
 }
 
+// mintLoginToken hands out a single use m.login.token of the user
+func mintLoginToken(userID string, cache service.Cache) (string, error) {
+	token := util.RandomString(loginTokenLength)
+	if err := cache.SetLoginToken(token, userID, loginTokenLifetime); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// CreateLoginToken implements POST /login/get_token, a logged in device uses
+// it to sign in a new device, e.g. by showing the token as a QR code. The
+// user has to re-authenticate first, as the token grants a full login
+func CreateLoginToken(
+	ctx context.Context,
+	req *external.PostLoginTokenRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if code, resp := CheckAccountAuth(ctx, &req.Auth, device.UserID, cfg, accountDB); resp != nil {
+		return code, resp
+	}
+	account, err := accountDB.GetAccount(ctx, device.UserID)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if account != nil && account.Suspended {
+		return http.StatusForbidden, jsonerror.UserSuspended("account has been suspended")
+	}
+
+	token, err := mintLoginToken(device.UserID, cache)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("create login token for user %s device %s", device.UserID, device.ID)
+	return http.StatusOK, &external.PostLoginTokenResponse{
+		LoginToken:  token,
+		ExpiresInMs: loginTokenLifetime * 1000,
+	}
+}
+
 // Login implements GET and POST /login
 func LoginPost(
 	ctx context.Context,
@@ -196,12 +271,27 @@ func LoginPost(
 	deviceDB model.DeviceDatabase,
 	encryptDB model.EncryptorAPIDatabase,
 	syncDB model.SyncAPIDatabase,
+	cache service.Cache,
 	cfg config.Dendrite,
 	admin bool,
 	idg *uid.UidGenerator,
 	tokenFilter *filter.Filter,
 	rpcClient *common.RpcClient,
 ) (int, core.Coder) {
+	if !admin && req.RequestType == loginTypeToken {
+		if req.Token == "" {
+			return http.StatusBadRequest, jsonerror.MissingArgument("'token' must be supplied.")
+		}
+		userID, err := cache.TakeLoginToken(req.Token)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		if userID == "" {
+			return http.StatusForbidden, jsonerror.Forbidden("invalid login token")
+		}
+		req.User = userID
+	}
+
 	// r.User can either be a user ID or just the userID... or other things maybe.
 	localPart, domain, err := gomatrixserverlib.SplitID('@', req.User)
 	if err != nil {
@@ -217,7 +307,7 @@ func LoginPost(
 	}
 
 	if strings.EqualFold(cfg.Authorization.AuthorizeMode, "provider") {
-		return providerLogin(req.User, ctx, *req, cfg, deviceDB, accountDB, encryptDB, syncDB, admin, idg, tokenFilter, rpcClient)
+		return providerLogin(req.User, ctx, *req, cfg, deviceDB, accountDB, encryptDB, syncDB, cache, admin, idg, tokenFilter, rpcClient)
 	}
 
 	return http.StatusServiceUnavailable, jsonerror.Unknown("Internal Server Error")
@@ -236,6 +326,8 @@ func LoginGet(
 	tokenFilter *filter.Filter,
 	rpcClient *common.RpcClient,
 ) (int, core.Coder) {
-	// TODO: support other forms of login other than password, depending on config options
-	return http.StatusOK, passwordLogin()
+	if admin {
+		return http.StatusOK, passwordLogin()
+	}
+	return http.StatusOK, ssoLogin(passwordLogin(), &cfg)
 }
@@ -0,0 +1,152 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/plugins/message/external"
+)
+
+// standInTokenCache keeps the login tokens in memory and, like redis, drops
+// them once taken or past their ttl
+type standInTokenCache struct {
+	service.Cache
+	users     map[string]string
+	deadlines map[string]time.Time
+	ttl       int64
+}
+
+func newStandInTokenCache() *standInTokenCache {
+	return &standInTokenCache{users: map[string]string{}, deadlines: map[string]time.Time{}}
+}
+
+func (c *standInTokenCache) SetLoginToken(token, userID string, expire int64) error {
+	c.users[token] = userID
+	c.deadlines[token] = time.Now().Add(time.Duration(expire) * time.Second)
+	c.ttl = expire
+	return nil
+}
+
+func (c *standInTokenCache) TakeLoginToken(token string) (string, error) {
+	userID, deadline := c.users[token], c.deadlines[token]
+	delete(c.users, token)
+	delete(c.deadlines, token)
+	if userID == "" || time.Now().After(deadline) {
+		return "", nil
+	}
+	return userID, nil
+}
+
+func newLoginTokenConfig() *config.Dendrite {
+	cfg := &config.Dendrite{}
+	cfg.Matrix.ServerName = []string{"test"}
+	return cfg
+}
+
+func mintTestLoginToken(t *testing.T, cfg *config.Dendrite, cache *standInTokenCache) string {
+	accountDB, cleanup := newSQLiteAccountDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	device := &authtypes.Device{UserID: "@alice:test", ID: "DEVICE"}
+	if _, err := accountDB.CreateAccount(ctx, device.UserID, "secret", "", "alice"); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	req := &external.PostLoginTokenRequest{Auth: external.AuthData{Type: "m.login.password", Password: "secret"}}
+	code, res := CreateLoginToken(ctx, req, device, cfg, accountDB, cache)
+	if code != http.StatusOK {
+		t.Fatalf("create: want 200, got %d %+v", code, res)
+	}
+	token := res.(*external.PostLoginTokenResponse)
+	if token.LoginToken == "" || token.ExpiresInMs != loginTokenLifetime*1000 || cache.ttl != loginTokenLifetime {
+		t.Fatalf("create: unexpected token %+v with ttl %d", token, cache.ttl)
+	}
+	return token.LoginToken
+}
+
+func loginWithToken(cfg *config.Dendrite, cache *standInTokenCache, token string) (int, *external.PostLoginRequest) {
+	req := &external.PostLoginRequest{RequestType: loginTypeToken, Token: token}
+	code, _ := LoginPost(context.Background(), req, nil, nil, nil, nil, cache, *cfg, false, nil, nil, nil)
+	return code, req
+}
+
+func TestCreateLoginTokenRequiresAuth(t *testing.T) {
+	accountDB, cleanup := newSQLiteAccountDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	cfg := newLoginTokenConfig()
+	cache := newStandInTokenCache()
+	device := &authtypes.Device{UserID: "@alice:test", ID: "DEVICE"}
+	if _, err := accountDB.CreateAccount(ctx, device.UserID, "secret", "", "alice"); err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	code, res := CreateLoginToken(ctx, &external.PostLoginTokenRequest{}, device, cfg, accountDB, cache)
+	if code != http.StatusUnauthorized {
+		t.Fatalf("no auth: want 401, got %d", code)
+	}
+	if uia, ok := res.(*external.UserInteractiveResponse); !ok || len(uia.Flows) == 0 || uia.Session == "" {
+		t.Errorf("no auth: want the auth flows, got %+v", res)
+	}
+
+	req := &external.PostLoginTokenRequest{Auth: external.AuthData{Type: "m.login.password", Password: "wrong"}}
+	if code, _ := CreateLoginToken(ctx, req, device, cfg, accountDB, cache); code != http.StatusForbidden {
+		t.Errorf("wrong password: want 403, got %d", code)
+	}
+
+	cfg.Authorization.AuthorizeMode = "provider"
+	req.Auth.Password = "secret"
+	if code, _ := CreateLoginToken(ctx, req, device, cfg, accountDB, cache); code != http.StatusForbidden {
+		t.Errorf("provider mode: want 403, got %d", code)
+	}
+	if len(cache.users) != 0 {
+		t.Errorf("want no token minted without auth, got %d", len(cache.users))
+	}
+}
+
+func TestLoginTokenIsSingleUse(t *testing.T) {
+	cfg := newLoginTokenConfig()
+	cache := newStandInTokenCache()
+	token := mintTestLoginToken(t, cfg, cache)
+
+	code, req := loginWithToken(cfg, cache, token)
+	if code == http.StatusForbidden || req.User != "@alice:test" {
+		t.Fatalf("first use: want the user of the token, got %d %q", code, req.User)
+	}
+	if code, _ := loginWithToken(cfg, cache, token); code != http.StatusForbidden {
+		t.Errorf("second use: want 403, got %d", code)
+	}
+}
+
+func TestLoginTokenExpires(t *testing.T) {
+	cfg := newLoginTokenConfig()
+	cache := newStandInTokenCache()
+	token := mintTestLoginToken(t, cfg, cache)
+	cache.deadlines[token] = time.Now().Add(-time.Second)
+
+	if code, req := loginWithToken(cfg, cache, token); code != http.StatusForbidden || req.User != "" {
+		t.Errorf("expired: want 403, got %d %q", code, req.User)
+	}
+	if code, _ := loginWithToken(cfg, cache, "unknown"); code != http.StatusForbidden {
+		t.Errorf("unknown: want 403, got %d", code)
+	}
+}
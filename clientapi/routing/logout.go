@@ -73,6 +73,10 @@ func LogoutDevice(
 
 	cache.DeleteDeviceKey(userID, deviceID)
 
+	if err := cache.DelDeviceRefreshToken(userID, deviceID); err != nil {
+		log.Errorf("Log out remove refresh token error, device: %s ,  user: %s , error: %v", deviceID, userID, err)
+	}
+
 	err = syncDB.DeleteDeviceStdMessage(ctx, userID, deviceID)
 	if err != nil {
 		log.Errorf("Log out remove device std message, device: %s ,  user: %s , error: %v", deviceID, userID, err)
@@ -91,9 +95,26 @@ func LogoutDevice(
 		log.Errorf("Log out pub key update, device: %s ,  user: %s , error: %v", deviceID, userID, err)
 	}
 
+	pubDeviceDeleted(userID, deviceID, rpcClient)
 	pubLogoutToken(userID, deviceID, rpcClient)
 }
 
+// pubDeviceDeleted has the encryption api record the deletion of the device,
+// the servers sharing a room with the user get a deleted device list update
+func pubDeviceDeleted(userID string, deviceID string, rpcClient *common.RpcClient) {
+	content := types.DeviceListUpdateContent{
+		UserID:   userID,
+		DeviceID: deviceID,
+		Deleted:  true,
+	}
+	bytes, err := json.Marshal(content)
+	if err == nil {
+		rpcClient.Pub(types.DeviceListUpdateTopicDef, bytes)
+	} else {
+		log.Errorf("pub device deleted Marshal err %v", err)
+	}
+}
+
 func pubLogoutToken(userID string, deviceID string, rpcClient *common.RpcClient) {
 	content := types.FilterTokenContent{
 		UserID:     userID,
@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+const (
+	openIDTokenLength = 32
+	// openIDTokenLifetime is how long in seconds an OpenID token proves the
+	// identity of the user
+	openIDTokenLifetime = 3600
+)
+
+// CreateOpenIDToken implements POST /user/{userId}/openid/request_token
+func CreateOpenIDToken(
+	ctx context.Context,
+	req *external.PostUserOpenIDRequest,
+	device *authtypes.Device,
+	accountDB model.AccountsDatabase,
+) (int, core.Coder) {
+	if req.UserID != device.UserID {
+		return http.StatusForbidden, jsonerror.Forbidden("cannot request an openid token for another user")
+	}
+
+	token := util.RandomString(openIDTokenLength)
+	expiresTs := time.Now().Add(openIDTokenLifetime*time.Second).UnixNano() / 1000000
+	if err := accountDB.InsertOpenIDToken(ctx, token, device.UserID, expiresTs); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("create openid token for user %s device %s", device.UserID, device.ID)
+
+	domain, _ := common.DomainFromID(device.UserID)
+	return http.StatusOK, &external.PostUserOpenIDResponse{
+		AccessToken:      token,
+		TokenType:        "Bearer",
+		MatrixServerName: domain,
+		ExpiresIn:        openIDTokenLifetime,
+	}
+}
+
+// GetOpenIDUserInfo implements GET /_matrix/federation/v1/openid/userinfo
+func GetOpenIDUserInfo(
+	ctx context.Context,
+	req *external.GetFedOpenIDUserInfoRequest,
+	accountDB model.AccountsDatabase,
+) (int, core.Coder) {
+	if req.AccessToken == "" {
+		return http.StatusUnauthorized, jsonerror.MissingToken("missing access token")
+	}
+	userID, err := accountDB.GetOpenIDTokenUser(ctx, req.AccessToken)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if userID == "" {
+		return http.StatusUnauthorized, jsonerror.UnknownToken("access token unknown or expired")
+	}
+	return http.StatusOK, &external.GetFedOpenIDUserInfoResponse{Sub: userID}
+}
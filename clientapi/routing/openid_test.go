@@ -0,0 +1,93 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/finogeeks/ligase/common/sqlite"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/storage/implements/accounts"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// newSQLiteAccountDB opens an accounts database in a sqlite file
+func newSQLiteAccountDB(t *testing.T) (model.AccountsDatabase, func()) {
+	dir, err := ioutil.TempDir("", "accounts")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	db, err := accounts.NewDatabase(sqlite.DriverName, "", "file:"+filepath.Join(dir, "accounts.db"), "", "", false)
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("open accounts db: %v", err)
+	}
+	return db.(model.AccountsDatabase), func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestOpenIDToken(t *testing.T) {
+	accountDB, cleanup := newSQLiteAccountDB(t)
+	defer cleanup()
+	ctx := context.Background()
+	device := &authtypes.Device{UserID: "@alice:test", ID: "DEVICE"}
+
+	code, _ := CreateOpenIDToken(ctx, &external.PostUserOpenIDRequest{UserID: "@bob:test"}, device, accountDB)
+	if code != http.StatusForbidden {
+		t.Errorf("want 403 for a token of another user, got %d", code)
+	}
+
+	code, res := CreateOpenIDToken(ctx, &external.PostUserOpenIDRequest{UserID: device.UserID}, device, accountDB)
+	if code != http.StatusOK {
+		t.Fatalf("create: want 200, got %d", code)
+	}
+	token := res.(*external.PostUserOpenIDResponse)
+	if token.AccessToken == "" || token.ExpiresIn != openIDTokenLifetime || token.MatrixServerName != "test" {
+		t.Fatalf("create: unexpected token %+v", token)
+	}
+
+	code, res = GetOpenIDUserInfo(ctx, &external.GetFedOpenIDUserInfoRequest{AccessToken: token.AccessToken}, accountDB)
+	if code != http.StatusOK || res.(*external.GetFedOpenIDUserInfoResponse).Sub != device.UserID {
+		t.Fatalf("userinfo: want the user of the token, got %d %+v", code, res)
+	}
+}
+
+func TestOpenIDUserInfoRejectsTokens(t *testing.T) {
+	accountDB, cleanup := newSQLiteAccountDB(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	expiredTs := time.Now().Add(-time.Minute).UnixNano() / 1000000
+	if err := accountDB.InsertOpenIDToken(ctx, "expired", "@alice:test", expiredTs); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	for _, token := range []string{"expired", "unknown"} {
+		code, _ := GetOpenIDUserInfo(ctx, &external.GetFedOpenIDUserInfoRequest{AccessToken: token}, accountDB)
+		if code != http.StatusUnauthorized {
+			t.Errorf("userinfo: want 401 for the %s token, got %d", token, code)
+		}
+	}
+	if code, _ := GetOpenIDUserInfo(ctx, &external.GetFedOpenIDUserInfoRequest{}, accountDB); code != http.StatusUnauthorized {
+		t.Errorf("userinfo: want 401 without a token, got %d", code)
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const refreshTokenLength = 32
+
+type sessionTokens struct {
+	accessToken  string
+	refreshToken string
+	expiresInMs  int64
+}
+
+// buildSessionTokens builds the access token of the device. When the client
+// supports refresh tokens and they are enabled the access token expires and
+// comes with a refresh token, otherwise it never expires.
+func buildSessionTokens(
+	cfg *config.Dendrite,
+	cache service.Cache,
+	userID, deviceIdentifier, deviceID, deviceType string,
+	human, refresh bool,
+) (*sessionTokens, error) {
+	domain, _ := common.DomainFromID(userID)
+	lifetime := cfg.Authorization.AccessTokenLifetime
+	if !refresh || lifetime <= 0 {
+		token, err := common.BuildToken(cfg.Macaroon.Key, userID, domain, userID, deviceIdentifier, false, deviceID, deviceType, human)
+		if err != nil {
+			return nil, err
+		}
+		return &sessionTokens{accessToken: token}, nil
+	}
+
+	expireTs := time.Now().Add(time.Duration(lifetime)*time.Second).UnixNano() / 1000000
+	token, err := common.BuildExpiringToken(cfg.Macaroon.Key, userID, domain, userID, deviceIdentifier, false, deviceID, deviceType, human, expireTs)
+	if err != nil {
+		return nil, err
+	}
+	refreshToken := util.RandomString(refreshTokenLength)
+	if err := cache.SetRefreshToken(refreshToken, userID, deviceID, cfg.Authorization.RefreshTokenLifetime); err != nil {
+		return nil, err
+	}
+	return &sessionTokens{
+		accessToken:  token,
+		refreshToken: refreshToken,
+		expiresInMs:  lifetime * 1000,
+	}, nil
+}
+
+// Refresh implements POST /refresh, it rotates the access token and the
+// refresh token of the device
+func Refresh(
+	ctx context.Context,
+	req *external.PostRefreshRequest,
+	cfg *config.Dendrite,
+	cache service.Cache,
+) (int, core.Coder) {
+	if req.RefreshToken == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("'refresh_token' must be supplied.")
+	}
+	refreshToken, err := cache.TakeRefreshToken(req.RefreshToken)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if refreshToken == nil {
+		return http.StatusUnauthorized, jsonerror.UnknownToken("Unknown refresh token")
+	}
+	dev := cache.GetDeviceByDeviceID(refreshToken.DeviceID, refreshToken.UserID)
+	if dev == nil || dev.UserID != refreshToken.UserID {
+		return http.StatusUnauthorized, jsonerror.UnknownToken("Device has been logged out")
+	}
+
+	tokens, err := buildSessionTokens(cfg, cache, dev.UserID, dev.Identifier, dev.ID, dev.DeviceType, dev.IsHuman, true)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("refresh token of user %s device %s", dev.UserID, dev.ID)
+	return http.StatusOK, &external.PostRefreshResponse{
+		AccessToken:  tokens.accessToken,
+		RefreshToken: tokens.refreshToken,
+		ExpiresInMs:  tokens.expiresInMs,
+	}
+}
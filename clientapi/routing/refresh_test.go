@@ -0,0 +1,101 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/plugins/message/external"
+)
+
+// standInRefreshCache keeps the refresh tokens in memory and, like redis,
+// keeps one per device and drops them once taken
+type standInRefreshCache struct {
+	service.Cache
+	device  *authtypes.Device
+	tokens  map[string]*authtypes.RefreshToken
+	devices map[string]string
+}
+
+func (c *standInRefreshCache) SetRefreshToken(token, userID, deviceID string, expire int64) error {
+	c.DelDeviceRefreshToken(userID, deviceID)
+	c.tokens[token] = &authtypes.RefreshToken{UserID: userID, DeviceID: deviceID}
+	c.devices[userID+":"+deviceID] = token
+	return nil
+}
+
+func (c *standInRefreshCache) TakeRefreshToken(token string) (*authtypes.RefreshToken, error) {
+	refreshToken := c.tokens[token]
+	delete(c.tokens, token)
+	return refreshToken, nil
+}
+
+func (c *standInRefreshCache) DelDeviceRefreshToken(userID, deviceID string) error {
+	delete(c.tokens, c.devices[userID+":"+deviceID])
+	delete(c.devices, userID+":"+deviceID)
+	return nil
+}
+
+func (c *standInRefreshCache) GetDeviceByDeviceID(deviceID string, userID string) *authtypes.Device {
+	if c.device == nil || c.device.ID != deviceID || c.device.UserID != userID {
+		return nil
+	}
+	return c.device
+}
+
+func TestRefreshTokenIsSingleUse(t *testing.T) {
+	cfg := &config.Dendrite{}
+	cfg.Macaroon.Key = "key"
+	cfg.Authorization.AccessTokenLifetime = 300
+	cache := &standInRefreshCache{
+		device:  &authtypes.Device{UserID: "@alice:test", ID: "DEVICE", DeviceType: "mobile", IsHuman: true},
+		tokens:  map[string]*authtypes.RefreshToken{},
+		devices: map[string]string{},
+	}
+	ctx := context.Background()
+
+	tokens, err := buildSessionTokens(cfg, cache, "@alice:test", "", "DEVICE", "mobile", true, true)
+	if err != nil || tokens.refreshToken == "" || tokens.expiresInMs != 300*1000 {
+		t.Fatalf("login: want a refresh token, got %+v %v", tokens, err)
+	}
+
+	code, res := Refresh(ctx, &external.PostRefreshRequest{RefreshToken: tokens.refreshToken}, cfg, cache)
+	if code != http.StatusOK {
+		t.Fatalf("refresh: want 200, got %d %+v", code, res)
+	}
+	refreshed := res.(*external.PostRefreshResponse)
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == tokens.refreshToken || refreshed.ExpiresInMs != 300*1000 {
+		t.Fatalf("refresh: want new tokens, got %+v", refreshed)
+	}
+	device, resErr := common.VerifyToken(refreshed.AccessToken, "/_matrix/client/r0/sync", cache, *cfg, nil)
+	if resErr != nil || device.ID != "DEVICE" || device.ExpireTs == 0 {
+		t.Fatalf("refresh: want an expiring access token of the device, got %+v %+v", device, resErr)
+	}
+
+	if code, _ := Refresh(ctx, &external.PostRefreshRequest{RefreshToken: tokens.refreshToken}, cfg, cache); code != http.StatusUnauthorized {
+		t.Errorf("reused: want 401, got %d", code)
+	}
+
+	cache.device = nil
+	if code, _ := Refresh(ctx, &external.PostRefreshRequest{RefreshToken: refreshed.RefreshToken}, cfg, cache); code != http.StatusUnauthorized {
+		t.Errorf("logged out device: want 401, got %d", code)
+	}
+}
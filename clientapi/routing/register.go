@@ -38,6 +38,7 @@ import (
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
 	"github.com/finogeeks/ligase/plugins/message/external"
 	"github.com/finogeeks/ligase/plugins/message/internals"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
@@ -288,6 +289,7 @@ func Register(
 	req *external.PostRegisterRequest,
 	accountDB model.AccountsDatabase,
 	deviceDB model.DeviceDatabase,
+	cache service.Cache,
 	cfg *config.Dendrite,
 	idg *uid.UidGenerator,
 ) (int, core.Coder) {
@@ -348,7 +350,7 @@ func Register(
 	}...)
 	log.Infow("Processing registration request", fields)
 
-	return handleRegistrationFlow(ctx, req, sessionID, cfg, accountDB, deviceDB, idg)
+	return handleRegistrationFlow(ctx, req, sessionID, cfg, accountDB, deviceDB, cache, idg)
 }
 
 func handleGuestRegister(idg *uid.UidGenerator, cfg *config.Dendrite, domain string) (int, core.Coder) {
@@ -390,6 +392,7 @@ func handleRegistrationFlow(
 	cfg *config.Dendrite,
 	accountDB model.AccountsDatabase,
 	deviceDB model.DeviceDatabase,
+	cache service.Cache,
 	idg *uid.UidGenerator,
 ) (int, core.Coder) {
 	// TODO: Shared secret registration (create new user scripts)
@@ -440,8 +443,8 @@ func handleRegistrationFlow(
 		// If no error, application service was successfully validated.
 		// Don't need to worry about appending to registration stages as
 		// application service registration is entirely separate.
-		return completeRegistration(ctx, cfg, accountDB, deviceDB,
-			req.Username, "", appServiceID, req.InitialDisplayName, idg)
+		return completeRegistration(ctx, cfg, accountDB, deviceDB, cache,
+			req.Username, "", appServiceID, req.InitialDisplayName, req.RefreshToken, idg)
 
 	case authtypes.LoginTypeDummy:
 		// there is nothing to do
@@ -456,7 +459,7 @@ func handleRegistrationFlow(
 	// A response with current registration flow and remaining available methods
 	// will be returned if a flow has not been successfully completed yet
 	return checkAndCompleteFlow(sessions.GetCompletedStages(sessionID),
-		ctx, req, sessionID, cfg, accountDB, deviceDB, idg)
+		ctx, req, sessionID, cfg, accountDB, deviceDB, cache, idg)
 }
 
 // checkAndCompleteFlow checks if a given registration flow is completed given
@@ -470,12 +473,13 @@ func checkAndCompleteFlow(
 	cfg *config.Dendrite,
 	accountDB model.AccountsDatabase,
 	deviceDB model.DeviceDatabase,
+	cache service.Cache,
 	idg *uid.UidGenerator,
 ) (int, core.Coder) {
 	if checkFlowCompleted(flow, cfg.Derived.Registration.Flows) {
 		// This flow was completed, registration can continue
-		return completeRegistration(ctx, cfg, accountDB, deviceDB,
-			r.Username, r.Password, "", r.InitialDisplayName, idg)
+		return completeRegistration(ctx, cfg, accountDB, deviceDB, cache,
+			r.Username, r.Password, "", r.InitialDisplayName, r.RefreshToken, idg)
 	}
 
 	// There are still more stages to complete.
@@ -522,10 +526,10 @@ func LegacyRegister(
 			return http.StatusForbidden, &internals.RespMessage{Message: "HMAC incorrect"}
 		}
 
-		return completeRegistration(ctx, cfg, accountDB, deviceDB, req.Username, req.Password, "", "", idg)
+		return completeRegistration(ctx, cfg, accountDB, deviceDB, nil, req.Username, req.Password, "", "", false, idg)
 	case authtypes.LoginTypeDummy:
 		// there is nothing to do
-		return completeRegistration(ctx, cfg, accountDB, deviceDB, req.Username, req.Password, "", "", idg)
+		return completeRegistration(ctx, cfg, accountDB, deviceDB, nil, req.Username, req.Password, "", "", false, idg)
 	default:
 		return http.StatusNotImplemented, jsonerror.Unknown("unknown/unimplemented auth type")
 	}
@@ -562,8 +566,10 @@ func completeRegistration(
 	cfg *config.Dendrite,
 	accountDB model.AccountsDatabase,
 	deviceDB model.DeviceDatabase,
+	cache service.Cache,
 	username, password, appServiceID string,
 	displayName string,
+	refresh bool,
 	idg *uid.UidGenerator,
 ) (int, core.Coder) {
 	if username == "" {
@@ -588,7 +594,7 @@ func completeRegistration(
 	}
 
 	domain, _ := common.DomainFromID(username)
-	token, err := common.BuildToken(cfg.Macaroon.Key, username, domain, username, "", false, deviceID, deviceType, true)
+	tokens, err := buildSessionTokens(cfg, cache, username, "", deviceID, deviceType, true, refresh)
 	if err != nil {
 		return http.StatusInternalServerError, jsonerror.Unknown("Failed to generate access token")
 	}
@@ -599,10 +605,12 @@ func completeRegistration(
 	}
 
 	return http.StatusOK, &external.RegisterResponse{
-		UserID:      dev.UserID,
-		AccessToken: token,
-		HomeServer:  domain,
-		DeviceID:    dev.ID,
+		UserID:       dev.UserID,
+		AccessToken:  tokens.accessToken,
+		HomeServer:   domain,
+		DeviceID:     dev.ID,
+		RefreshToken: tokens.refreshToken,
+		ExpiresInMs:  tokens.expiresInMs,
 	}
 }
 
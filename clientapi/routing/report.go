@@ -0,0 +1,366 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/clientapi/threepid"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/core"
+	fed "github.com/finogeeks/ligase/federation/fedreq"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+const (
+	eventReportStatusOpen     = "open"
+	eventReportStatusAssigned = "assigned"
+	eventReportStatusResolved = "resolved"
+
+	eventReportActionRedact   = "redact"
+	eventReportActionKick     = "kick"
+	eventReportActionBan      = "ban"
+	eventReportActionShutdown = "shutdown_room"
+
+	defaultEventReportsLimit = 10
+	maxEventReportsLimit     = 100
+)
+
+// ReportEvent implements POST /rooms/{roomId}/report/{eventId}
+func ReportEvent(
+	ctx context.Context,
+	req *external.PostRoomReportRequest,
+	userID string,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+) (int, core.Coder) {
+	if req.Score < -100 || req.Score > 0 {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("score must be between -100 and 0")
+	}
+
+	var queryRes roomserverapi.QueryRoomStateResponse
+	queryReq := roomserverapi.QueryRoomStateRequest{RoomID: req.RoomID}
+	if err := rpcCli.QueryRoomState(ctx, &queryReq, &queryRes); err != nil {
+		return http.StatusNotFound, jsonerror.NotFound("room not found")
+	}
+	if _, ok := queryRes.Join[userID]; !ok {
+		return http.StatusNotFound, jsonerror.NotFound("room not found")
+	}
+
+	var eventRes roomserverapi.QueryRoomEventByIDResponse
+	eventReq := roomserverapi.QueryRoomEventByIDRequest{EventID: req.EventID, RoomID: req.RoomID}
+	if err := rpcCli.QueryRoomEventByID(ctx, &eventReq, &eventRes); err != nil || eventRes.Event == nil {
+		return http.StatusNotFound, jsonerror.NotFound("event not found")
+	}
+
+	id, err := idg.Next()
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	report := types.EventReport{
+		ID:         id,
+		RoomID:     req.RoomID,
+		EventID:    req.EventID,
+		Reporter:   userID,
+		Sender:     eventRes.Event.Sender(),
+		Reason:     req.Reason,
+		Score:      req.Score,
+		ReceivedTs: time.Now().UnixNano() / int64(time.Millisecond),
+		Status:     eventReportStatusOpen,
+	}
+	if err := syncDB.InsertEventReport(ctx, &report); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("ReportEvent user %s reported event %s in room %s", userID, req.EventID, req.RoomID)
+	return http.StatusOK, nil
+}
+
+// GetEventReports implements GET /system/manager/event_reports
+func GetEventReports(
+	ctx context.Context,
+	req *external.GetEventReportsRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	syncDB model.SyncAPIDatabase,
+) (int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can manage event reports")
+	}
+	filter := types.EventReportFilter{
+		RoomID:   req.RoomID,
+		Reporter: req.UserID,
+		Sender:   req.Sender,
+		Status:   req.Status,
+		Assignee: req.Assignee,
+		FromID:   math.MaxInt64,
+		Limit:    req.Limit,
+	}
+	if req.From != "" {
+		from, err := strconv.ParseInt(req.From, 10, 64)
+		if err != nil {
+			return http.StatusBadRequest, jsonerror.InvalidArgumentValue("from is invalid")
+		}
+		filter.FromID = from
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultEventReportsLimit
+	}
+	if filter.Limit > maxEventReportsLimit {
+		filter.Limit = maxEventReportsLimit
+	}
+
+	reports, err := syncDB.GetEventReports(ctx, &filter)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	resp := &external.GetEventReportsResponse{EventReports: []external.EventReport{}}
+	for i := range reports {
+		resp.EventReports = append(resp.EventReports, toExternalEventReport(&reports[i]))
+	}
+	if len(reports) == filter.Limit {
+		resp.NextToken = strconv.FormatInt(reports[len(reports)-1].ID, 10)
+	}
+	return http.StatusOK, resp
+}
+
+// GetEventReport implements GET /system/manager/event_reports/{reportID}
+func GetEventReport(
+	ctx context.Context,
+	req *external.GetEventReportRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	syncDB model.SyncAPIDatabase,
+) (int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can manage event reports")
+	}
+	report, code, errRes := getEventReport(ctx, req.ReportID, syncDB)
+	if report == nil {
+		return code, errRes
+	}
+	resp := toExternalEventReport(report)
+	return http.StatusOK, &resp
+}
+
+// AssignEventReport implements PUT /system/manager/event_reports/{reportID}/assign,
+// an empty assignee puts the report back to the open queue
+func AssignEventReport(
+	ctx context.Context,
+	req *external.PutEventReportAssignRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	syncDB model.SyncAPIDatabase,
+) (int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can manage event reports")
+	}
+	report, code, errRes := getEventReport(ctx, req.ReportID, syncDB)
+	if report == nil {
+		return code, errRes
+	}
+	if report.Status == eventReportStatusResolved {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("report has already been resolved")
+	}
+
+	status := eventReportStatusAssigned
+	if req.Assignee == "" {
+		status = eventReportStatusOpen
+	}
+	if err := syncDB.UpdateEventReportAssignee(ctx, report.ID, req.Assignee, status); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	report.Assignee = req.Assignee
+	report.Status = status
+	resp := toExternalEventReport(report)
+	return http.StatusOK, &resp
+}
+
+// ResolveEventReport implements POST /system/manager/event_reports/{reportID}/resolve,
+// the requested actions are sent as the most powerful local member of the room
+// and the admin resolving the report is recorded
+func ResolveEventReport(
+	ctx context.Context,
+	req *external.PostEventReportResolveRequest,
+	device *authtypes.Device,
+	cfg config.Dendrite,
+	accountDB model.AccountsDatabase,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	syncDB model.SyncAPIDatabase,
+	federation *fed.Federation,
+	cache service.Cache,
+	idg *uid.UidGenerator,
+	complexCache *common.ComplexCache,
+) (int, core.Coder) {
+	if !cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can manage event reports")
+	}
+	for _, action := range req.Actions {
+		switch action {
+		case eventReportActionRedact, eventReportActionKick, eventReportActionBan, eventReportActionShutdown:
+		default:
+			return http.StatusBadRequest, jsonerror.InvalidArgumentValue("unknown action " + action)
+		}
+	}
+
+	report, code, errRes := getEventReport(ctx, req.ReportID, syncDB)
+	if report == nil {
+		return code, errRes
+	}
+	if report.Status == eventReportStatusResolved {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("report has already been resolved")
+	}
+
+	if len(req.Actions) > 0 {
+		moderator, err := getRoomModerator(ctx, report.RoomID, cfg, rpcCli)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		if moderator == "" {
+			return http.StatusForbidden, jsonerror.Forbidden("no local member is able to moderate the room")
+		}
+
+		for _, action := range req.Actions {
+			var code int
+			var res core.Coder
+			switch action {
+			case eventReportActionRedact:
+				content, _ := json.Marshal(map[string]string{"reason": req.Resolution})
+				code, res = RedactEvent(
+					ctx, content, moderator, "", report.RoomID, nil, nil,
+					cfg, cache, rpcCli, report.EventID, "m.room.redaction", idg,
+				)
+			case eventReportActionKick, eventReportActionBan:
+				content, _ := json.Marshal(threepid.MembershipRequest{UserID: report.Sender, Reason: req.Resolution})
+				msg := external.PostRoomsMembershipRequest{
+					RoomID:     report.RoomID,
+					Membership: action,
+					Content:    content,
+				}
+				code, res = SendMembership(
+					ctx, &msg, accountDB, moderator, "", report.RoomID, action,
+					cfg, rpcCli, federation, cache, idg, complexCache,
+				)
+			case eventReportActionShutdown:
+				code, res = DismissRoom(
+					ctx, &external.DismissRoomRequest{RoomID: report.RoomID}, accountDB, moderator, "", report.RoomID,
+					cfg, rpcCli, federation, cache, idg, complexCache,
+				)
+			}
+			if code != http.StatusOK {
+				log.Errorf("ResolveEventReport report %d action %s failed with code %d", report.ID, action, code)
+				return code, res
+			}
+		}
+	}
+
+	report.Status = eventReportStatusResolved
+	report.ResolvedBy = device.UserID
+	report.ResolvedTs = time.Now().UnixNano() / int64(time.Millisecond)
+	report.Resolution = req.Resolution
+	report.Actions = req.Actions
+	if err := syncDB.UpdateEventReportResolved(ctx, report); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	resp := toExternalEventReport(report)
+	return http.StatusOK, &resp
+}
+
+func getEventReport(
+	ctx context.Context, reportID string, syncDB model.SyncAPIDatabase,
+) (*types.EventReport, int, core.Coder) {
+	id, err := strconv.ParseInt(reportID, 10, 64)
+	if err != nil {
+		return nil, http.StatusNotFound, jsonerror.NotFound("report not found")
+	}
+	report, err := syncDB.GetEventReport(ctx, id)
+	if err != nil {
+		code, res := httputil.LogThenErrorCtx(ctx, err)
+		return nil, code, res
+	}
+	if report == nil {
+		return nil, http.StatusNotFound, jsonerror.NotFound("report not found")
+	}
+	return report, http.StatusOK, nil
+}
+
+// getRoomModerator returns the joined local member with the highest power level
+func getRoomModerator(
+	ctx context.Context, roomID string, cfg config.Dendrite, rpcCli roomserverapi.RoomserverRPCAPI,
+) (string, error) {
+	var queryRes roomserverapi.QueryRoomStateResponse
+	queryReq := roomserverapi.QueryRoomStateRequest{RoomID: roomID}
+	if err := rpcCli.QueryRoomState(ctx, &queryReq, &queryRes); err != nil {
+		return "", err
+	}
+	plEvent, err := queryRes.PowerLevels()
+	if err != nil || plEvent == nil {
+		return "", err
+	}
+	plContent := common.PowerLevelContent{}
+	if err := json.Unmarshal(plEvent.Content(), &plContent); err != nil {
+		return "", err
+	}
+
+	moderator := ""
+	maxPower := math.MinInt32
+	for userID, power := range plContent.Users {
+		if _, ok := queryRes.Join[userID]; !ok {
+			continue
+		}
+		domain, err := common.DomainFromID(userID)
+		if err != nil || !common.CheckValidDomain(domain, cfg.Matrix.ServerName) {
+			continue
+		}
+		if power > maxPower || (power == maxPower && userID < moderator) {
+			moderator = userID
+			maxPower = power
+		}
+	}
+	return moderator, nil
+}
+
+func toExternalEventReport(report *types.EventReport) external.EventReport {
+	return external.EventReport{
+		ID:         strconv.FormatInt(report.ID, 10),
+		RoomID:     report.RoomID,
+		EventID:    report.EventID,
+		UserID:     report.Reporter,
+		Sender:     report.Sender,
+		Reason:     report.Reason,
+		Score:      report.Score,
+		ReceivedTs: report.ReceivedTs,
+		Status:     report.Status,
+		Assignee:   report.Assignee,
+		ResolvedBy: report.ResolvedBy,
+		ResolvedTs: report.ResolvedTs,
+		Resolution: report.Resolution,
+		Actions:    report.Actions,
+	}
+}
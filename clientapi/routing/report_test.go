@@ -0,0 +1,165 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// standInReportDB keeps the event reports in memory, the other methods of
+// the sync database are not used by the report endpoints and panic
+type standInReportDB struct {
+	model.SyncAPIDatabase
+	reports map[int64]*types.EventReport
+}
+
+func (db *standInReportDB) GetEventReport(ctx context.Context, id int64) (*types.EventReport, error) {
+	report, ok := db.reports[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *report
+	return &copied, nil
+}
+
+func (db *standInReportDB) GetEventReports(ctx context.Context, filter *types.EventReportFilter) ([]types.EventReport, error) {
+	reports := []types.EventReport{}
+	for _, report := range db.reports {
+		if report.ID < filter.FromID && (filter.Status == "" || report.Status == filter.Status) {
+			reports = append(reports, *report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].ID > reports[j].ID })
+	if len(reports) > filter.Limit {
+		reports = reports[:filter.Limit]
+	}
+	return reports, nil
+}
+
+func (db *standInReportDB) UpdateEventReportAssignee(ctx context.Context, id int64, assignee, status string) error {
+	db.reports[id].Assignee = assignee
+	db.reports[id].Status = status
+	return nil
+}
+
+func (db *standInReportDB) UpdateEventReportResolved(ctx context.Context, report *types.EventReport) error {
+	copied := *report
+	db.reports[report.ID] = &copied
+	return nil
+}
+
+func newReportTest() (*config.Dendrite, *standInReportDB) {
+	cfg := &config.Dendrite{}
+	cfg.Authorization.AdminUsers = []string{"@admin:test"}
+	db := &standInReportDB{reports: map[int64]*types.EventReport{}}
+	for id := int64(1); id <= 3; id++ {
+		db.reports[id] = &types.EventReport{
+			ID:       id,
+			RoomID:   "!room:test",
+			EventID:  "$event:test",
+			Reporter: "@alice:test",
+			Sender:   "@spammer:test",
+			Status:   eventReportStatusOpen,
+		}
+	}
+	return cfg, db
+}
+
+func TestEventReportsRequireAdmin(t *testing.T) {
+	cfg, db := newReportTest()
+	ctx := context.Background()
+	device := &authtypes.Device{UserID: "@alice:test"}
+
+	if code, _ := GetEventReports(ctx, &external.GetEventReportsRequest{}, device, cfg, db); code != http.StatusForbidden {
+		t.Errorf("list: want 403 for a non admin, got %d", code)
+	}
+	if code, _ := GetEventReport(ctx, &external.GetEventReportRequest{ReportID: "1"}, device, cfg, db); code != http.StatusForbidden {
+		t.Errorf("get: want 403 for a non admin, got %d", code)
+	}
+	if code, _ := AssignEventReport(ctx, &external.PutEventReportAssignRequest{ReportID: "1", Assignee: "@alice:test"}, device, cfg, db); code != http.StatusForbidden {
+		t.Errorf("assign: want 403 for a non admin, got %d", code)
+	}
+	code, _ := ResolveEventReport(ctx, &external.PostEventReportResolveRequest{ReportID: "1"}, device, *cfg,
+		nil, nil, db, nil, nil, nil, nil)
+	if code != http.StatusForbidden {
+		t.Errorf("resolve: want 403 for a non admin, got %d", code)
+	}
+	for id, report := range db.reports {
+		if report.Status != eventReportStatusOpen || report.Assignee != "" {
+			t.Errorf("report %d changed by a non admin: %+v", id, report)
+		}
+	}
+}
+
+func TestEventReportsAdmin(t *testing.T) {
+	cfg, db := newReportTest()
+	ctx := context.Background()
+	device := &authtypes.Device{UserID: "@admin:test"}
+
+	code, res := GetEventReports(ctx, &external.GetEventReportsRequest{Limit: 2}, device, cfg, db)
+	if code != http.StatusOK {
+		t.Fatalf("list: want 200, got %d", code)
+	}
+	list := res.(*external.GetEventReportsResponse)
+	if len(list.EventReports) != 2 || list.EventReports[0].ID != "3" || list.NextToken != "2" {
+		t.Fatalf("list: unexpected page %+v", list)
+	}
+	code, res = GetEventReports(ctx, &external.GetEventReportsRequest{From: list.NextToken, Limit: 2}, device, cfg, db)
+	if code != http.StatusOK || len(res.(*external.GetEventReportsResponse).EventReports) != 1 {
+		t.Fatalf("list: want the last report on the second page, got %d %+v", code, res)
+	}
+
+	if code, _ = GetEventReport(ctx, &external.GetEventReportRequest{ReportID: "42"}, device, cfg, db); code != http.StatusNotFound {
+		t.Errorf("get: want 404 for an unknown report, got %d", code)
+	}
+
+	code, _ = AssignEventReport(ctx, &external.PutEventReportAssignRequest{ReportID: "1", Assignee: "@admin:test"}, device, cfg, db)
+	if code != http.StatusOK || db.reports[1].Status != eventReportStatusAssigned || db.reports[1].Assignee != "@admin:test" {
+		t.Fatalf("assign: want the report assigned, got %d %+v", code, db.reports[1])
+	}
+
+	code, _ = ResolveEventReport(ctx, &external.PostEventReportResolveRequest{ReportID: "1", Resolution: "not spam"}, device, *cfg,
+		nil, nil, db, nil, nil, nil, nil)
+	if code != http.StatusOK {
+		t.Fatalf("resolve: want 200, got %d", code)
+	}
+	resolved := db.reports[1]
+	if resolved.Status != eventReportStatusResolved || resolved.ResolvedBy != "@admin:test" || resolved.Resolution != "not spam" || resolved.ResolvedTs == 0 {
+		t.Fatalf("resolve: unexpected report %+v", resolved)
+	}
+
+	code, _ = ResolveEventReport(ctx, &external.PostEventReportResolveRequest{ReportID: "1"}, device, *cfg,
+		nil, nil, db, nil, nil, nil, nil)
+	if code != http.StatusBadRequest {
+		t.Errorf("resolve: want 400 for a resolved report, got %d", code)
+	}
+	code, _ = ResolveEventReport(ctx, &external.PostEventReportResolveRequest{ReportID: "2", Actions: []string{"delete_everything"}}, device, *cfg,
+		nil, nil, db, nil, nil, nil, nil)
+	if code != http.StatusBadRequest {
+		t.Errorf("resolve: want 400 for an unknown action, got %d", code)
+	}
+	if code, _ = AssignEventReport(ctx, &external.PutEventReportAssignRequest{ReportID: "1", Assignee: "@admin:test"}, device, cfg, db); code != http.StatusBadRequest {
+		t.Errorf("assign: want 400 for a resolved report, got %d", code)
+	}
+}
@@ -0,0 +1,386 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+const (
+	// ssoSessionLifetime is how long in seconds the user has to log in at
+	// the identity provider
+	ssoSessionLifetime = 600
+	ssoStateLength     = 32
+
+	casTicketPath   = "/_matrix/client/r0/login/cas/ticket"
+	ssoCallbackPath = "/_matrix/client/r0/login/sso/callback"
+)
+
+var ssoHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+type casServiceResponse struct {
+	Success *struct {
+		User string `xml:"user"`
+	} `xml:"authenticationSuccess"`
+	Failure *struct {
+		Code    string `xml:"code,attr"`
+		Message string `xml:",chardata"`
+	} `xml:"authenticationFailure"`
+}
+
+type oidcTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// checkSSORedirect returns a response when the client url can't be
+// redirected to, nil otherwise. The login token is handed to the client url,
+// so only whitelisted urls are allowed, the public base url of the server
+// when no whitelist is configured.
+func checkSSORedirect(redirectURL string, cfg *config.Dendrite) (int, core.Coder) {
+	if redirectURL == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("redirectUrl is required")
+	}
+	u, err := url.Parse(redirectURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("redirectUrl must be an absolute url")
+	}
+	whitelist := cfg.SSO.ClientWhitelist
+	if len(whitelist) == 0 {
+		whitelist = []string{cfg.SSO.PublicBaseURL}
+	}
+	for _, prefix := range whitelist {
+		if ssoRedirectMatches(u, prefix) {
+			return http.StatusOK, nil
+		}
+	}
+	return http.StatusForbidden, jsonerror.Forbidden("redirectUrl is not whitelisted")
+}
+
+// ssoRedirectMatches reports whether the url is under the whitelisted url
+// prefix, scheme and host must be the same so a prefix can't be extended to
+// another host, and the path must be the prefix path or below it
+func ssoRedirectMatches(u *url.URL, prefix string) bool {
+	p, err := url.Parse(prefix)
+	if err != nil || p.Scheme == "" || p.Host == "" {
+		return false
+	}
+	if !strings.EqualFold(u.Scheme, p.Scheme) || !strings.EqualFold(u.Host, p.Host) {
+		return false
+	}
+	// "/app" allows "/app" and "/app/login" but not "/application"
+	prefixPath := strings.TrimRight(p.EscapedPath(), "/")
+	path := u.EscapedPath()
+	return prefixPath == "" || path == prefixPath || strings.HasPrefix(path, prefixPath+"/")
+}
+
+func ssoPublicURL(cfg *config.Dendrite, path string) string {
+	return strings.TrimRight(cfg.SSO.PublicBaseURL, "/") + path
+}
+
+func casServiceURL(cfg *config.Dendrite, redirectURL string) string {
+	return ssoPublicURL(cfg, casTicketPath) + "?redirectUrl=" + url.QueryEscape(redirectURL)
+}
+
+// mapSSOLocalpart maps the identity of the user at the identity provider to
+// a localpart, like the matrix user id mapping: upper case letters become '_'
+// and the lower case letter, '_' is doubled and the other characters not
+// allowed in a localpart are hex escaped behind '='. Different identities
+// never map to the same localpart.
+func mapSSOLocalpart(remoteID string) string {
+	var b strings.Builder
+	for _, c := range []byte(remoteID) {
+		switch {
+		case c >= 'a' && c <= 'z', c >= '0' && c <= '9', c == '.', c == '-', c == '/':
+			b.WriteByte(c)
+		case c >= 'A' && c <= 'Z':
+			b.WriteByte('_')
+			b.WriteByte(c - 'A' + 'a')
+		case c == '_':
+			b.WriteString("__")
+		default:
+			fmt.Fprintf(&b, "=%02x", c)
+		}
+	}
+	return b.String()
+}
+
+// validateCASTicket returns the CAS user of the ticket, an empty user when
+// the CAS server rejects the ticket
+func validateCASTicket(ctx context.Context, cfg *config.Dendrite, ticket, redirectURL string) (string, error) {
+	query := url.Values{}
+	query.Set("ticket", ticket)
+	query.Set("service", casServiceURL(cfg, redirectURL))
+	validateURL := strings.TrimRight(cfg.SSO.CAS.ServerURL, "/") + "/proxyValidate?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, validateURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := ssoHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cas server returned %d", resp.StatusCode)
+	}
+
+	var casResp casServiceResponse
+	if err := xml.NewDecoder(resp.Body).Decode(&casResp); err != nil {
+		return "", err
+	}
+	if casResp.Success == nil {
+		if casResp.Failure != nil {
+			log.Warnf("cas ticket rejected, code: %s message: %s", casResp.Failure.Code, strings.TrimSpace(casResp.Failure.Message))
+		}
+		return "", nil
+	}
+	return strings.TrimSpace(casResp.Success.User), nil
+}
+
+// fetchOIDCIdentity exchanges the authorization code for an access token
+// and returns the localpart claim of the userinfo
+func fetchOIDCIdentity(ctx context.Context, cfg *config.Dendrite, code string) (string, error) {
+	oidc := &cfg.SSO.OIDC
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", ssoPublicURL(cfg, ssoCallbackPath))
+	form.Set("client_id", oidc.ClientID)
+	form.Set("client_secret", oidc.ClientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, oidc.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	resp, err := ssoHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %d", resp.StatusCode)
+	}
+	var tokenResp oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.AccessToken == "" {
+		return "", fmt.Errorf("token endpoint returned no access token")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, http.MethodGet, oidc.UserInfoEndpoint, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+tokenResp.AccessToken)
+	req.Header.Set("Accept", "application/json")
+	userInfoResp, err := ssoHTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer userInfoResp.Body.Close()
+	if userInfoResp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("userinfo endpoint returned %d", userInfoResp.StatusCode)
+	}
+	userInfo := make(map[string]interface{})
+	if err := json.NewDecoder(userInfoResp.Body).Decode(&userInfo); err != nil {
+		return "", err
+	}
+
+	claim := oidc.LocalpartClaim
+	if claim == "" {
+		claim = "sub"
+	}
+	remoteID, ok := userInfo[claim].(string)
+	if !ok || remoteID == "" {
+		return "", fmt.Errorf("userinfo has no %s claim", claim)
+	}
+	return remoteID, nil
+}
+
+// finishSSOLogin maps the identity to a user of this server and redirects
+// back to the client with a login token. The account of a new user is
+// created by the m.login.token login like any provider login.
+func finishSSOLogin(
+	ctx context.Context,
+	remoteID, redirectURL string,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	localpart := mapSSOLocalpart(remoteID)
+	if localpart == "" {
+		return http.StatusUnauthorized, jsonerror.Forbidden("identity provider returned no user")
+	}
+	userID := fmt.Sprintf("@%s:%s", localpart, cfg.Matrix.ServerName[0])
+
+	account, err := accountDB.GetAccount(ctx, userID)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if account == nil || account.UserID == "" {
+		if !cfg.SSO.AllowRegistration {
+			return http.StatusForbidden, jsonerror.Forbidden("registration through the identity provider is disabled")
+		}
+	} else if account.Deactivated {
+		return http.StatusForbidden, jsonerror.UserDeactivated("account has been deactivated")
+	}
+
+	token, err := mintLoginToken(userID, cache)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("sso login of %s as user %s", remoteID, userID)
+
+	u, _ := url.Parse(redirectURL)
+	query := u.Query()
+	query.Set("loginToken", token)
+	u.RawQuery = query.Encode()
+	return http.StatusFound, &external.LoginRedirectResponse{Location: u.String()}
+}
+
+// CASLoginRedirect implements GET /login/cas/redirect
+func CASLoginRedirect(
+	ctx context.Context,
+	req *external.GetCasLoginRedirectRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	if !cfg.SSO.CAS.Enabled {
+		return http.StatusNotFound, jsonerror.NotFound("cas login is not enabled")
+	}
+	if code, resp := checkSSORedirect(req.RedirectURL, cfg); resp != nil {
+		return code, resp
+	}
+	location := strings.TrimRight(cfg.SSO.CAS.ServerURL, "/") + "/login?service=" +
+		url.QueryEscape(casServiceURL(cfg, req.RedirectURL))
+	return http.StatusFound, &external.LoginRedirectResponse{Location: location}
+}
+
+// CASLoginTicket implements GET /login/cas/ticket, the CAS server sends the
+// browser here after the user logged in
+func CASLoginTicket(
+	ctx context.Context,
+	req *external.GetCasLoginTickerRequest,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if !cfg.SSO.CAS.Enabled {
+		return http.StatusNotFound, jsonerror.NotFound("cas login is not enabled")
+	}
+	if req.Ticket == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("ticket is required")
+	}
+	if code, resp := checkSSORedirect(req.RedirectURL, cfg); resp != nil {
+		return code, resp
+	}
+
+	remoteID, err := validateCASTicket(ctx, cfg, req.Ticket, req.RedirectURL)
+	if err != nil {
+		log.Errorf("validate cas ticket error %v", err)
+		return http.StatusBadGateway, jsonerror.Unknown("failed to validate cas ticket")
+	}
+	if remoteID == "" {
+		return http.StatusUnauthorized, jsonerror.Forbidden("invalid cas ticket")
+	}
+	return finishSSOLogin(ctx, remoteID, req.RedirectURL, cfg, accountDB, cache)
+}
+
+// SSOLoginRedirect implements GET /login/sso/redirect, it logs in through
+// OpenID Connect, or CAS when that is the only identity provider
+func SSOLoginRedirect(
+	ctx context.Context,
+	req *external.GetSSOLoginRedirectRequest,
+	cfg *config.Dendrite,
+	cache service.Cache,
+) (int, core.Coder) {
+	if !cfg.SSO.OIDC.Enabled {
+		return CASLoginRedirect(ctx, &external.GetCasLoginRedirectRequest{RedirectURL: req.RedirectURL}, cfg)
+	}
+	if code, resp := checkSSORedirect(req.RedirectURL, cfg); resp != nil {
+		return code, resp
+	}
+
+	state := util.RandomString(ssoStateLength)
+	if err := cache.SetSSOSession(state, req.RedirectURL, ssoSessionLifetime); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	oidc := &cfg.SSO.OIDC
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", oidc.ClientID)
+	query.Set("redirect_uri", ssoPublicURL(cfg, ssoCallbackPath))
+	query.Set("scope", strings.Join(oidc.Scopes, " "))
+	query.Set("state", state)
+	location := oidc.AuthorizationEndpoint + "?" + query.Encode()
+	return http.StatusFound, &external.LoginRedirectResponse{Location: location}
+}
+
+// SSOLoginCallback implements GET /login/sso/callback, the OpenID Connect
+// provider sends the browser here after the user logged in
+func SSOLoginCallback(
+	ctx context.Context,
+	req *external.GetSSOLoginCallbackRequest,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if !cfg.SSO.OIDC.Enabled {
+		return http.StatusNotFound, jsonerror.NotFound("sso login is not enabled")
+	}
+	if req.State == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("state is required")
+	}
+	redirectURL, err := cache.TakeSSOSession(req.State)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if redirectURL == "" {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("unknown or expired sso session")
+	}
+	if req.Error != "" {
+		return http.StatusUnauthorized, jsonerror.Forbidden("identity provider error: " + req.Error)
+	}
+	if req.Code == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("code is required")
+	}
+
+	remoteID, err := fetchOIDCIdentity(ctx, cfg, req.Code)
+	if err != nil {
+		log.Errorf("sso login fetch identity error %v", err)
+		return http.StatusUnauthorized, jsonerror.Forbidden("failed to authenticate with the identity provider")
+	}
+	return finishSSOLogin(ctx, remoteID, redirectURL, cfg, accountDB, cache)
+}
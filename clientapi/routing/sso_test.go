@@ -0,0 +1,172 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/finogeeks/ligase/common/config"
+)
+
+// newStandInIdP serves the CAS and OpenID Connect endpoints, it knows the
+// ticket "ST-1" and the code "code-1" of the user "Alice Smith"
+func newStandInIdP(t *testing.T, cfg *config.Dendrite) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/cas/proxyValidate", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("service") != casServiceURL(cfg, "https://client.example.com/") {
+			t.Errorf("unexpected cas service %s", r.URL.Query().Get("service"))
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Query().Get("ticket") != "ST-1" {
+			fmt.Fprint(w, `<cas:serviceResponse xmlns:cas="http://www.yale.edu/tp/cas">
+  <cas:authenticationFailure code="INVALID_TICKET">ticket not recognized</cas:authenticationFailure>
+</cas:serviceResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<cas:serviceResponse xmlns:cas="http://www.yale.edu/tp/cas">
+  <cas:authenticationSuccess><cas:user>Alice Smith</cas:user></cas:authenticationSuccess>
+</cas:serviceResponse>`)
+	})
+	mux.HandleFunc("/oidc/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != "code-1" || r.PostFormValue("client_secret") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"access_token":"at-1","token_type":"Bearer"}`)
+	})
+	mux.HandleFunc("/oidc/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer at-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"sub":"1234","preferred_username":"Alice Smith"}`)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newSSOConfig() *config.Dendrite {
+	cfg := &config.Dendrite{}
+	cfg.SSO.PublicBaseURL = "https://matrix.example.com/"
+	cfg.SSO.CAS.Enabled = true
+	cfg.SSO.OIDC.Enabled = true
+	cfg.SSO.OIDC.ClientID = "ligase"
+	cfg.SSO.OIDC.ClientSecret = "secret"
+	return cfg
+}
+
+func TestValidateCASTicket(t *testing.T) {
+	cfg := newSSOConfig()
+	idp := newStandInIdP(t, cfg)
+	defer idp.Close()
+	cfg.SSO.CAS.ServerURL = idp.URL + "/cas"
+
+	user, err := validateCASTicket(context.Background(), cfg, "ST-1", "https://client.example.com/")
+	if err != nil {
+		t.Fatalf("validate ticket: %v", err)
+	}
+	if user != "Alice Smith" {
+		t.Errorf("want user Alice Smith, got %q", user)
+	}
+
+	user, err = validateCASTicket(context.Background(), cfg, "ST-2", "https://client.example.com/")
+	if err != nil {
+		t.Fatalf("validate invalid ticket: %v", err)
+	}
+	if user != "" {
+		t.Errorf("want no user for an invalid ticket, got %q", user)
+	}
+}
+
+func TestFetchOIDCIdentity(t *testing.T) {
+	cfg := newSSOConfig()
+	idp := newStandInIdP(t, cfg)
+	defer idp.Close()
+	cfg.SSO.OIDC.TokenEndpoint = idp.URL + "/oidc/token"
+	cfg.SSO.OIDC.UserInfoEndpoint = idp.URL + "/oidc/userinfo"
+
+	user, err := fetchOIDCIdentity(context.Background(), cfg, "code-1")
+	if err != nil {
+		t.Fatalf("fetch identity: %v", err)
+	}
+	if user != "1234" {
+		t.Errorf("want sub 1234, got %q", user)
+	}
+
+	cfg.SSO.OIDC.LocalpartClaim = "preferred_username"
+	if user, _ = fetchOIDCIdentity(context.Background(), cfg, "code-1"); user != "Alice Smith" {
+		t.Errorf("want preferred_username Alice Smith, got %q", user)
+	}
+
+	if _, err = fetchOIDCIdentity(context.Background(), cfg, "code-2"); err == nil {
+		t.Errorf("want an error for an invalid code")
+	}
+}
+
+func TestMapSSOLocalpart(t *testing.T) {
+	tests := map[string]string{
+		"alice":       "alice",
+		"Alice Smith": "_alice=20_smith",
+		"bob@corp":    "bob=40corp",
+		"ALICE":       "_a_l_i_c_e",
+		"_alice":      "__alice",
+		"a=b":         "a=3db",
+	}
+	for remoteID, want := range tests {
+		if got := mapSSOLocalpart(remoteID); got != want {
+			t.Errorf("map %q: want %q, got %q", remoteID, want, got)
+		}
+	}
+	if mapSSOLocalpart("alice") == mapSSOLocalpart("Alice") {
+		t.Errorf("identities differing in case must not collide")
+	}
+}
+
+func TestCheckSSORedirect(t *testing.T) {
+	cfg := newSSOConfig()
+	cfg.SSO.PublicBaseURL = "https://matrix.example.com"
+	if _, resp := checkSSORedirect("https://matrix.example.com/client/", cfg); resp != nil {
+		t.Errorf("want the public base url allowed without a whitelist")
+	}
+	if code, _ := checkSSORedirect("https://client.example.com/", cfg); code != http.StatusForbidden {
+		t.Errorf("want 403 for another host without a whitelist, got %d", code)
+	}
+	if _, resp := checkSSORedirect("/relative", cfg); resp == nil {
+		t.Errorf("want relative url rejected")
+	}
+	cfg.SSO.ClientWhitelist = []string{"https://client.example.com/"}
+	if _, resp := checkSSORedirect("https://client.example.com/login", cfg); resp != nil {
+		t.Errorf("want whitelisted url allowed")
+	}
+	if code, _ := checkSSORedirect("https://evil.example.com/", cfg); code != http.StatusForbidden {
+		t.Errorf("want 403 for a url not whitelisted, got %d", code)
+	}
+	cfg.SSO.ClientWhitelist = []string{"https://client.example.com"}
+	if code, _ := checkSSORedirect("https://client.example.com.evil.com/", cfg); code != http.StatusForbidden {
+		t.Errorf("want 403 for a host extending a whitelisted one, got %d", code)
+	}
+	cfg.SSO.ClientWhitelist = []string{"https://client.example.com/app"}
+	for _, allowed := range []string{"https://client.example.com/app", "https://client.example.com/app/", "https://client.example.com/app/login?x=1"} {
+		if _, resp := checkSSORedirect(allowed, cfg); resp != nil {
+			t.Errorf("want %s allowed under /app", allowed)
+		}
+	}
+	if code, _ := checkSSORedirect("https://client.example.com/application", cfg); code != http.StatusForbidden {
+		t.Errorf("want 403 for a path extending the whitelisted segment, got %d", code)
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+// appServiceThirdPartyPrefix is where the application services serve the
+// third party lookups
+const appServiceThirdPartyPrefix = "/_matrix/app/v1/thirdparty"
+
+var appServiceHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// protocolAppServices returns the application services bridging the
+// protocol, or bridging any protocol when protocol is empty
+func protocolAppServices(cfg *config.Dendrite, protocol string) []config.ApplicationService {
+	var appServices []config.ApplicationService
+	for _, as := range cfg.Derived.ApplicationServices {
+		for _, p := range as.Protocols {
+			if protocol == "" || p == protocol {
+				appServices = append(appServices, as)
+				break
+			}
+		}
+	}
+	return appServices
+}
+
+// queryAppService does a third party lookup on the application service and
+// decodes the response into result
+func queryAppService(
+	ctx context.Context,
+	as *config.ApplicationService,
+	path string,
+	query url.Values,
+	result interface{},
+) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("access_token", as.HSToken)
+	address := strings.TrimRight(as.URL, "/") + appServiceThirdPartyPrefix + path + "?" + query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, address, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := appServiceHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("application service %s returned %d", as.ID, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+// getProtocol merges the metadata of the protocol from the application
+// services bridging it, nil when none of them answered
+func getProtocol(ctx context.Context, cfg *config.Dendrite, protocol string) *external.ThirdPartyProtocol {
+	var merged *external.ThirdPartyProtocol
+	for _, as := range protocolAppServices(cfg, protocol) {
+		var p external.ThirdPartyProtocol
+		if err := queryAppService(ctx, &as, "/protocol/"+url.PathEscape(protocol), nil, &p); err != nil {
+			log.Warnf("query protocol %s from application service %s error %v", protocol, as.ID, err)
+			continue
+		}
+		if merged == nil {
+			merged = &p
+			continue
+		}
+		merged.Instances = append(merged.Instances, p.Instances...)
+	}
+	if merged != nil && merged.Instances == nil {
+		merged.Instances = []external.ProtocolInstance{}
+	}
+	return merged
+}
+
+// GetThirdPartyProtocols implements GET /thirdparty/protocols
+func GetThirdPartyProtocols(
+	ctx context.Context,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	resp := external.GetThirdPartyProtocalsResponse{}
+	for _, as := range protocolAppServices(cfg, "") {
+		for _, protocol := range as.Protocols {
+			if _, ok := resp[protocol]; ok {
+				continue
+			}
+			if p := getProtocol(ctx, cfg, protocol); p != nil {
+				resp[protocol] = *p
+			}
+		}
+	}
+	return http.StatusOK, &resp
+}
+
+// GetThirdPartyProtocol implements GET /thirdparty/protocol/{protocol}
+func GetThirdPartyProtocol(
+	ctx context.Context,
+	req *external.GetThirdPartyProtocalByNameRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	p := getProtocol(ctx, cfg, req.Protocol)
+	if p == nil {
+		return http.StatusNotFound, jsonerror.NotFound("unknown protocol")
+	}
+	resp := external.GetThirdPartyProtocalByNameResponse(*p)
+	return http.StatusOK, &resp
+}
+
+func fieldsQuery(fields map[string]string) url.Values {
+	query := url.Values{}
+	for k, v := range fields {
+		query.Set(k, v)
+	}
+	return query
+}
+
+// GetThirdPartyLocations implements GET /thirdparty/location/{protocol}
+func GetThirdPartyLocations(
+	ctx context.Context,
+	req *external.GetThirdPartyLocationByProtocolRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	appServices := protocolAppServices(cfg, req.Protocol)
+	if len(appServices) == 0 {
+		return http.StatusNotFound, jsonerror.NotFound("unknown protocol")
+	}
+	resp := external.GetThirdPartyLocationByProtocolResponse{}
+	for _, as := range appServices {
+		var locations []external.Location
+		err := queryAppService(ctx, &as, "/location/"+url.PathEscape(req.Protocol), fieldsQuery(req.Fields), &locations)
+		if err != nil {
+			log.Warnf("query %s locations from application service %s error %v", req.Protocol, as.ID, err)
+			continue
+		}
+		resp = append(resp, locations...)
+	}
+	return http.StatusOK, &resp
+}
+
+// GetThirdPartyUsers implements GET /thirdparty/user/{protocol}
+func GetThirdPartyUsers(
+	ctx context.Context,
+	req *external.GetThirdPartyUserByProtocolRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	appServices := protocolAppServices(cfg, req.Protocol)
+	if len(appServices) == 0 {
+		return http.StatusNotFound, jsonerror.NotFound("unknown protocol")
+	}
+	resp := external.GetThirdPartyUserByProtocolResponse{}
+	for _, as := range appServices {
+		var users []external.ThirdPartyUser
+		err := queryAppService(ctx, &as, "/user/"+url.PathEscape(req.Protocol), fieldsQuery(req.Fields), &users)
+		if err != nil {
+			log.Warnf("query %s users from application service %s error %v", req.Protocol, as.ID, err)
+			continue
+		}
+		resp = append(resp, users...)
+	}
+	return http.StatusOK, &resp
+}
+
+// GetThirdPartyLocationsByAlias implements GET /thirdparty/location
+func GetThirdPartyLocationsByAlias(
+	ctx context.Context,
+	req *external.GetThirdPartyLocationRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	if req.Alias == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("alias is required")
+	}
+	resp := external.GetThirdPartyLocationResponse{}
+	for _, as := range protocolAppServices(cfg, "") {
+		var locations []external.Location
+		err := queryAppService(ctx, &as, "/location", url.Values{"alias": {req.Alias}}, &locations)
+		if err != nil {
+			log.Warnf("query locations of alias %s from application service %s error %v", req.Alias, as.ID, err)
+			continue
+		}
+		resp = append(resp, locations...)
+	}
+	return http.StatusOK, &resp
+}
+
+// GetThirdPartyUsersByID implements GET /thirdparty/user
+func GetThirdPartyUsersByID(
+	ctx context.Context,
+	req *external.GetThirdPartyUserRequest,
+	cfg *config.Dendrite,
+) (int, core.Coder) {
+	if req.UserID == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("userid is required")
+	}
+	resp := external.GetThirdPartyUserResponse{}
+	for _, as := range protocolAppServices(cfg, "") {
+		var users []external.ThirdPartyUser
+		err := queryAppService(ctx, &as, "/user", url.Values{"userid": {req.UserID}}, &users)
+		if err != nil {
+			log.Warnf("query third party users of %s from application service %s error %v", req.UserID, as.ID, err)
+			continue
+		}
+		resp = append(resp, users...)
+	}
+	return http.StatusOK, &resp
+}
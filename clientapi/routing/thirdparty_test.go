@@ -0,0 +1,105 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/plugins/message/external"
+)
+
+// newStandInAppService bridges the protocol "irc" with a single instance
+// named after the network, it refuses requests without the hs_token
+func newStandInAppService(t *testing.T, network string) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/_matrix/app/v1/thirdparty/protocol/irc", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("access_token") != "hs-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, `{"user_fields":["nick"],"location_fields":["channel"],"icon":"mxc://example.com/irc",
+"field_types":{},"instances":[{"desc":"%s","fields":{},"network_id":"%s"}]}`, network, network)
+	})
+	mux.HandleFunc("/_matrix/app/v1/thirdparty/user/irc", func(w http.ResponseWriter, r *http.Request) {
+		nick := r.URL.Query().Get("nick")
+		fmt.Fprintf(w, `[{"userid":"@irc_%s:example.com","protocol":"irc","fields":{"nick":"%s"}}]`, nick, nick)
+	})
+	return httptest.NewServer(mux)
+}
+
+func newThirdPartyConfig(urls ...string) *config.Dendrite {
+	cfg := &config.Dendrite{}
+	for i, url := range urls {
+		cfg.Derived.ApplicationServices = append(cfg.Derived.ApplicationServices, config.ApplicationService{
+			ID:        fmt.Sprintf("as%d", i),
+			URL:       url,
+			HSToken:   "hs-token",
+			Protocols: []string{"irc"},
+		})
+	}
+	return cfg
+}
+
+func TestGetThirdPartyProtocolsMergesInstances(t *testing.T) {
+	freenode := newStandInAppService(t, "freenode")
+	defer freenode.Close()
+	oftc := newStandInAppService(t, "oftc")
+	defer oftc.Close()
+	down := httptest.NewServer(http.NotFoundHandler())
+	defer down.Close()
+
+	cfg := newThirdPartyConfig(freenode.URL, down.URL, oftc.URL)
+	code, resp := GetThirdPartyProtocols(context.Background(), cfg)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected code %d", code)
+	}
+	protocols := *resp.(*external.GetThirdPartyProtocalsResponse)
+	irc, ok := protocols["irc"]
+	if !ok {
+		t.Fatalf("irc missing from %v", protocols)
+	}
+	if len(irc.Instances) != 2 || irc.Instances[0].NetworkID != "freenode" || irc.Instances[1].NetworkID != "oftc" {
+		t.Fatalf("unexpected instances %v", irc.Instances)
+	}
+}
+
+func TestGetThirdPartyProtocolUnknown(t *testing.T) {
+	cfg := newThirdPartyConfig()
+	code, _ := GetThirdPartyProtocol(context.Background(), &external.GetThirdPartyProtocalByNameRequest{Protocol: "irc"}, cfg)
+	if code != http.StatusNotFound {
+		t.Fatalf("unexpected code %d", code)
+	}
+}
+
+func TestGetThirdPartyUsersPassesFields(t *testing.T) {
+	as := newStandInAppService(t, "freenode")
+	defer as.Close()
+
+	cfg := newThirdPartyConfig(as.URL)
+	req := &external.GetThirdPartyUserByProtocolRequest{Protocol: "irc", Fields: map[string]string{"nick": "alice"}}
+	code, resp := GetThirdPartyUsers(context.Background(), req, cfg)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected code %d", code)
+	}
+	users := *resp.(*external.GetThirdPartyUserByProtocolResponse)
+	if len(users) != 1 || users[0].UserID != "@irc_alice:example.com" {
+		t.Fatalf("unexpected users %v", users)
+	}
+}
@@ -0,0 +1,445 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	jsonRaw "encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/roomservertypes"
+	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// supportedRoomVersions are the versions a room can be upgraded to
+var supportedRoomVersions = map[string]bool{"1": true}
+
+// upgradeRoomStateTypes is the state the roomserver doesn't keep, the
+// replacement room copies it from the sync events of the old room
+var upgradeRoomStateTypes = []string{"m.room.encryption", "m.room.retention", "m.room.archive"}
+
+// UpgradeRoom implements POST /rooms/{roomId}/upgrade
+func UpgradeRoom(
+	ctx context.Context,
+	req *external.PostRoomUpgradeRequest,
+	userID string,
+	cfg config.Dendrite,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+	complexCache *common.ComplexCache,
+) (int, core.Coder) {
+	if req.NewVersion == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("new_version is required")
+	}
+	if !supportedRoomVersions[req.NewVersion] {
+		return http.StatusBadRequest, jsonerror.UnsupportedRoomVersion(fmt.Sprintf("room version %s is not supported", req.NewVersion))
+	}
+
+	roomID := req.RoomID
+	var queryRes roomserverapi.QueryRoomStateResponse
+	queryReq := roomserverapi.QueryRoomStateRequest{RoomID: roomID}
+	if err := rpcCli.QueryRoomState(ctx, &queryReq, &queryRes); err != nil {
+		return http.StatusNotFound, jsonerror.NotFound(err.Error())
+	}
+	if _, ok := queryRes.Join[userID]; !ok {
+		return http.StatusForbidden, jsonerror.Forbidden("user is not in the room")
+	}
+	if queryRes.Tombstone != nil {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("room has already been upgraded")
+	}
+
+	domainID, _ := common.DomainFromID(userID)
+	nid, _ := idg.Next()
+	newRoomID := fmt.Sprintf("!%d:%s", nid, domainID)
+
+	// the tombstone is built first, the predecessor of the new room points at it
+	stateKey := ""
+	builder := gomatrixserverlib.EventBuilder{
+		Sender:   userID,
+		RoomID:   roomID,
+		Type:     "m.room.tombstone",
+		StateKey: &stateKey,
+	}
+	err := builder.SetContent(common.TombstoneContent{
+		Body:            "This room has been replaced",
+		ReplacementRoom: newRoomID,
+	})
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	tombstone, err := common.BuildEvent(&builder, domainID, cfg, idg)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if err = gomatrixserverlib.Allowed(*tombstone, &queryRes); err != nil {
+		return http.StatusForbidden, jsonerror.Forbidden(err.Error())
+	}
+
+	createContent := common.CreateContent{}
+	if err = json.Unmarshal(queryRes.Creator.Content(), &createContent); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	createContent.Creator = userID
+	createContent.RoomVersion = req.NewVersion
+	createContent.Predecessor = &common.PreviousRoom{RoomID: roomID, EventID: tombstone.EventID()}
+
+	displayName, avatarURL, _ := complexCache.GetProfileByUserID(ctx, userID)
+	eventsToMake := []external.StateEvent{
+		{Type: "m.room.create", Content: createContent},
+		{Type: "m.room.member", StateKey: userID, Content: external.MemberContent{
+			Membership:  "join",
+			DisplayName: displayName,
+			AvatarURL:   avatarURL,
+		}},
+	}
+
+	// the upgrader may not be allowed to send all the copied state, it is
+	// raised while the room is set up and put back at the end
+	var restorePower interface{}
+	if queryRes.Power != nil {
+		power, restore, err := upgradePowerLevels(queryRes.Power.Content(), userID)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		eventsToMake = append(eventsToMake, external.StateEvent{Type: "m.room.power_levels", Content: power})
+		restorePower = restore
+	} else {
+		eventsToMake = append(eventsToMake, external.StateEvent{Type: "m.room.power_levels", Content: common.InitialPowerLevelsContent(userID)})
+	}
+
+	for _, ev := range []*gomatrixserverlib.Event{
+		queryRes.JoinRule, queryRes.HistoryVisibility, queryRes.Visibility, queryRes.GuestAccess,
+		queryRes.Name, queryRes.Topic, queryRes.Desc, queryRes.Avatar, queryRes.CanonicalAlias,
+	} {
+		if ev != nil {
+			eventsToMake = append(eventsToMake, external.StateEvent{
+				Type:     ev.Type(),
+				StateKey: *ev.StateKey(),
+				Content:  jsonRaw.RawMessage(ev.Content()),
+			})
+		}
+	}
+
+	evs, _, err := syncDB.SelectTypeEventForward(ctx, upgradeRoomStateTypes, roomID)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	latest := make(map[string]gomatrixserverlib.ClientEvent)
+	for _, ev := range evs {
+		latest[ev.Type] = ev
+	}
+	for _, typ := range upgradeRoomStateTypes {
+		if ev, ok := latest[typ]; ok {
+			eventsToMake = append(eventsToMake, external.StateEvent{Type: typ, Content: jsonRaw.RawMessage(ev.Content)})
+		}
+	}
+
+	// nobody else can join an invite only room by following the tombstone,
+	// remote members are invited once the room exists so the invites go out
+	// over federation
+	joinRule := common.JoinRulesContent{}
+	if queryRes.JoinRule != nil {
+		json.Unmarshal(queryRes.JoinRule.Content(), &joinRule)
+	}
+	remoteInvites := []string{}
+	if joinRule.JoinRule != "public" {
+		for member := range queryRes.Join {
+			if member == userID {
+				continue
+			}
+			memberDomain, _ := common.DomainFromID(member)
+			if !common.CheckValidDomain(memberDomain, cfg.Matrix.ServerName) {
+				remoteInvites = append(remoteInvites, member)
+				continue
+			}
+			displayName, avatarURL, _ := complexCache.GetProfileByUserID(ctx, member)
+			eventsToMake = append(eventsToMake, external.StateEvent{Type: "m.room.member", StateKey: member, Content: external.MemberContent{
+				Membership:  "invite",
+				DisplayName: displayName,
+				AvatarURL:   avatarURL,
+			}})
+		}
+	}
+
+	if restorePower != nil && len(remoteInvites) == 0 {
+		eventsToMake = append(eventsToMake, external.StateEvent{Type: "m.room.power_levels", Content: restorePower})
+	}
+
+	builtEvents := []gomatrixserverlib.Event{}
+	for i, e := range eventsToMake {
+		builder := gomatrixserverlib.EventBuilder{
+			Sender:   userID,
+			RoomID:   newRoomID,
+			Type:     e.Type,
+			StateKey: &eventsToMake[i].StateKey,
+			Depth:    int64(i + 1),
+		}
+		if err := builder.SetContent(e.Content); err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		ev, err := common.BuildEvent(&builder, domainID, cfg, idg)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		builtEvents = append(builtEvents, *ev)
+	}
+
+	txnAndDeviceID := &roomservertypes.TransactionID{
+		DeviceID: userID,
+	}
+	rawEvent := roomserverapi.RawEvent{
+		RoomID: newRoomID,
+		Kind:   roomserverapi.KindNew,
+		TxnID:  txnAndDeviceID,
+		Trust:  false,
+		BulkEvents: roomserverapi.BulkEvent{
+			Events:  builtEvents,
+			SvrName: domainID,
+		},
+		Query: []string{"upgrade_room", ""},
+	}
+	if _, err = rpcCli.InputRoomEvents(ctx, &rawEvent); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	log.Infof("upgrade room %s to %s user %s", roomID, newRoomID, userID)
+
+	// a remote server may refuse the invite, that doesn't undo the upgrade
+	for _, member := range remoteInvites {
+		if err = sendRemoteInvite(ctx, rpcCli, newRoomID, userID, member, domainID, cfg, idg, txnAndDeviceID); err != nil {
+			log.Errorf("upgrade room %s invite %s to %s error %v", roomID, member, newRoomID, err)
+		}
+	}
+	// the upgrader must not keep the raised level, the old room isn't
+	// tombstoned then and the upgrade can be tried again
+	if restorePower != nil && len(remoteInvites) > 0 {
+		if err = restorePowerLevels(ctx, rpcCli, newRoomID, userID, restorePower, domainID, cfg, idg, txnAndDeviceID); err != nil {
+			log.Errorf("upgrade room %s restore power levels of %s error %v", roomID, newRoomID, err)
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+	}
+
+	aliasReq := roomserverapi.MoveRoomAliasesRequest{
+		UserID:    userID,
+		OldRoomID: roomID,
+		NewRoomID: newRoomID,
+	}
+	var aliasResp roomserverapi.MoveRoomAliasesResponse
+	if err = rpcCli.MoveRoomAliases(ctx, &aliasReq, &aliasResp); err != nil {
+		log.Errorf("upgrade room %s move aliases to %s error %v", roomID, newRoomID, err)
+	}
+
+	if err = sendUpgradeEvent(ctx, rpcCli, tombstone, txnAndDeviceID, domainID); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+
+	// the old room stays readable, but only moderators may still talk there
+	if queryRes.Power != nil {
+		if err = restrictOldRoom(ctx, &queryRes, userID, domainID, cfg, rpcCli, idg, txnAndDeviceID); err != nil {
+			log.Errorf("upgrade room %s restrict power levels error %v", roomID, err)
+		}
+	}
+
+	return http.StatusOK, &external.PostRoomUpgradeResponse{ReplacementRoom: newRoomID}
+}
+
+// upgradePowerLevels returns the power levels the replacement room starts
+// with, and the copied ones to put back when the upgrader had to be raised
+func upgradePowerLevels(content []byte, userID string) (interface{}, interface{}, error) {
+	power := make(map[string]interface{})
+	if err := json.Unmarshal(content, &power); err != nil {
+		return nil, nil, err
+	}
+	users, _ := power["users"].(map[string]interface{})
+
+	needed := float64(100)
+	for _, v := range power {
+		if level, ok := v.(float64); ok && level > needed {
+			needed = level
+		}
+	}
+	if events, ok := power["events"].(map[string]interface{}); ok {
+		for _, v := range events {
+			if level, ok := v.(float64); ok && level > needed {
+				needed = level
+			}
+		}
+	}
+	if level, ok := users[userID].(float64); ok && level >= needed {
+		return power, nil, nil
+	}
+
+	raised := make(map[string]interface{}, len(power))
+	for k, v := range power {
+		raised[k] = v
+	}
+	raisedUsers := make(map[string]interface{}, len(users)+1)
+	for k, v := range users {
+		raisedUsers[k] = v
+	}
+	raisedUsers[userID] = needed
+	raised["users"] = raisedUsers
+	return raised, power, nil
+}
+
+// restrictOldRoom raises the power needed to talk or invite in the old room
+func restrictOldRoom(
+	ctx context.Context,
+	queryRes *roomserverapi.QueryRoomStateResponse,
+	userID, domainID string,
+	cfg config.Dendrite,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	idg *uid.UidGenerator,
+	txnAndDeviceID *roomservertypes.TransactionID,
+) error {
+	power := make(map[string]interface{})
+	if err := json.Unmarshal(queryRes.Power.Content(), &power); err != nil {
+		return err
+	}
+	usersDefault, _ := power["users_default"].(float64)
+	restricted := usersDefault + 1
+	if restricted < 50 {
+		restricted = 50
+	}
+	changed := false
+	for _, key := range []string{"events_default", "invite"} {
+		if level, _ := power[key].(float64); level < restricted {
+			power[key] = restricted
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	stateKey := ""
+	builder := gomatrixserverlib.EventBuilder{
+		Sender:   userID,
+		RoomID:   queryRes.RoomID,
+		Type:     "m.room.power_levels",
+		StateKey: &stateKey,
+	}
+	if err := builder.SetContent(power); err != nil {
+		return err
+	}
+	ev, err := common.BuildEvent(&builder, domainID, cfg, idg)
+	if err != nil {
+		return err
+	}
+	if err = gomatrixserverlib.Allowed(*ev, queryRes); err != nil {
+		return err
+	}
+	return sendUpgradeEvent(ctx, rpcCli, ev, txnAndDeviceID, domainID)
+}
+
+// sendRemoteInvite invites a remote member of the old room the way
+// SendMembership does, the roomserver sends it to the invitee's server
+func sendRemoteInvite(
+	ctx context.Context,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	roomID, userID, invitee, domainID string,
+	cfg config.Dendrite,
+	idg *uid.UidGenerator,
+	txnAndDeviceID *roomservertypes.TransactionID,
+) error {
+	builder := gomatrixserverlib.EventBuilder{
+		Sender:   userID,
+		RoomID:   roomID,
+		Type:     "m.room.member",
+		StateKey: &invitee,
+	}
+	if err := builder.SetContent(external.MemberContent{Membership: "invite"}); err != nil {
+		return err
+	}
+	ev, err := common.BuildEvent(&builder, domainID, cfg, idg)
+	if err != nil {
+		return err
+	}
+	rawEvent := roomserverapi.RawEvent{
+		RoomID: roomID,
+		Kind:   roomserverapi.KindNew,
+		TxnID:  txnAndDeviceID,
+		Trust:  true,
+		BulkEvents: roomserverapi.BulkEvent{
+			Events:  []gomatrixserverlib.Event{*ev},
+			SvrName: domainID,
+		},
+		Query: []string{"membership", "invite"},
+	}
+	_, err = rpcCli.InputRoomEvents(ctx, &rawEvent)
+	return err
+}
+
+// restorePowerLevels puts back the copied power levels once the remote
+// members are invited, the upgrader may need the raised level to invite
+func restorePowerLevels(
+	ctx context.Context,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	roomID, userID string,
+	power interface{},
+	domainID string,
+	cfg config.Dendrite,
+	idg *uid.UidGenerator,
+	txnAndDeviceID *roomservertypes.TransactionID,
+) error {
+	stateKey := ""
+	builder := gomatrixserverlib.EventBuilder{
+		Sender:   userID,
+		RoomID:   roomID,
+		Type:     "m.room.power_levels",
+		StateKey: &stateKey,
+	}
+	if err := builder.SetContent(power); err != nil {
+		return err
+	}
+	ev, err := common.BuildEvent(&builder, domainID, cfg, idg)
+	if err != nil {
+		return err
+	}
+	return sendUpgradeEvent(ctx, rpcCli, ev, txnAndDeviceID, domainID)
+}
+
+func sendUpgradeEvent(
+	ctx context.Context,
+	rpcCli roomserverapi.RoomserverRPCAPI,
+	ev *gomatrixserverlib.Event,
+	txnAndDeviceID *roomservertypes.TransactionID,
+	domainID string,
+) error {
+	rawEvent := roomserverapi.RawEvent{
+		RoomID: ev.RoomID(),
+		Kind:   roomserverapi.KindNew,
+		TxnID:  txnAndDeviceID,
+		Trust:  true,
+		BulkEvents: roomserverapi.BulkEvent{
+			Events:  []gomatrixserverlib.Event{*ev},
+			SvrName: domainID,
+		},
+		Query: []string{"upgrade_room", ev.Type()},
+	}
+	_, err := rpcCli.InputRoomEvents(ctx, &rawEvent)
+	return err
+}
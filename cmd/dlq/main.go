@@ -0,0 +1,207 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// dlq lists, inspects and replays the messages parked in the kafka dead
+// letter topic of a consumer topic:
+//
+//	dlq -broker kafka:9092 -topic roomserverOutput -cmd list
+//	dlq -broker kafka:9092 -topic roomserverOutput -cmd show -partition 0 -offset 12
+//	dlq -broker kafka:9092 -topic roomserverOutput -cmd replay -partition 0 -offset 12
+//
+// replay publishes the messages back to their original topic, the dead letter
+// topic itself is never modified. Messages parked by a nats channel have no
+// dead letter headers and are not handled here.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/finogeeks/ligase/plugins/channel"
+	"gopkg.in/confluentinc/confluent-kafka-go.v1/kafka"
+)
+
+var broker = flag.String("broker", "localhost:9092", "kafka bootstrap servers")
+var topic = flag.String("topic", "", "consumer topic, its dead letter topic is <topic>.dlq")
+var cmd = flag.String("cmd", "list", "list, show or replay")
+var partition = flag.Int("partition", -1, "dead letter partition, -1 for all partitions")
+var offset = flag.Int64("offset", -1, "dead letter offset, -1 for all messages")
+var limit = flag.Int("limit", 100, "max messages to list or replay")
+var timeout = flag.Int("timeout", 10, "seconds to wait for kafka")
+
+func main() {
+	flag.Parse()
+	if *topic == "" {
+		fmt.Fprintln(os.Stderr, "dlq: -topic is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch *cmd {
+	case "list":
+		err = scan(func(msg *kafka.Message) error {
+			h := headers(msg)
+			fmt.Printf("%d/%d topic:%s partition:%s offset:%s group:%s retries:%s ts:%s error:%s\n",
+				msg.TopicPartition.Partition, msg.TopicPartition.Offset, h[channel.HeaderDLQTopic],
+				h[channel.HeaderDLQPartition], h[channel.HeaderDLQOffset], h[channel.HeaderDLQGroup],
+				h[channel.HeaderDLQRetries], h[channel.HeaderDLQTimestamp], h[channel.HeaderDLQError])
+			return nil
+		})
+	case "show":
+		if *partition < 0 || *offset < 0 {
+			err = errors.New("show needs -partition and -offset")
+			break
+		}
+		*limit = 1
+		err = scan(func(msg *kafka.Message) error {
+			h := headers(msg)
+			keys := make([]string, 0, len(h))
+			for k := range h {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			fmt.Printf("partition: %d\noffset: %d\nkey: %s\n", msg.TopicPartition.Partition, msg.TopicPartition.Offset, msg.Key)
+			for _, k := range keys {
+				fmt.Printf("header %s: %s\n", k, h[k])
+			}
+			fmt.Printf("value: %s\n", msg.Value)
+			return nil
+		})
+	case "replay":
+		err = replay()
+	default:
+		err = fmt.Errorf("unknown cmd %s", *cmd)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "dlq: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func headers(msg *kafka.Message) map[string]string {
+	h := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		h[header.Key] = string(header.Value)
+	}
+	return h
+}
+
+// scan reads the selected dead letter messages without committing offsets,
+// it stops at the end of every partition or after limit messages
+func scan(f func(msg *kafka.Message) error) error {
+	dlqTopic := channel.DeadLetterTopic(*topic)
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":    *broker,
+		"group.id":             "dlq-admin",
+		"enable.auto.commit":   false,
+		"enable.partition.eof": true,
+	})
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	meta, err := c.GetMetadata(&dlqTopic, false, *timeout*1000)
+	if err != nil {
+		return err
+	}
+	topicMeta, ok := meta.Topics[dlqTopic]
+	if !ok || topicMeta.Error.Code() != kafka.ErrNoError {
+		return fmt.Errorf("dead letter topic %s not found", dlqTopic)
+	}
+	start := kafka.OffsetBeginning
+	if *offset >= 0 {
+		start = kafka.Offset(*offset)
+	}
+	var assign []kafka.TopicPartition
+	for _, p := range topicMeta.Partitions {
+		if *partition < 0 || int32(*partition) == p.ID {
+			assign = append(assign, kafka.TopicPartition{Topic: &dlqTopic, Partition: p.ID, Offset: start})
+		}
+	}
+	if len(assign) == 0 {
+		return fmt.Errorf("partition %d not found in %s", *partition, dlqTopic)
+	}
+	if err = c.Assign(assign); err != nil {
+		return err
+	}
+
+	count := 0
+	pending := len(assign)
+	deadline := time.Now().Add(time.Duration(*timeout) * time.Second)
+	for pending > 0 && count < *limit {
+		if time.Now().After(deadline) {
+			return errors.New("timeout reading " + dlqTopic)
+		}
+		switch e := c.Poll(100).(type) {
+		case *kafka.Message:
+			if *cmd == "show" && int64(e.TopicPartition.Offset) != *offset {
+				return fmt.Errorf("offset %d not found in %s", *offset, dlqTopic)
+			}
+			if err = f(e); err != nil {
+				return err
+			}
+			count++
+			deadline = time.Now().Add(time.Duration(*timeout) * time.Second)
+		case kafka.PartitionEOF:
+			pending--
+		case kafka.Error:
+			return e
+		}
+	}
+	if *cmd == "show" && count == 0 {
+		return fmt.Errorf("offset %d not found in %s", *offset, dlqTopic)
+	}
+	return nil
+}
+
+// replay publishes the selected dead letter messages back to the topic they
+// failed on, with the dead letter headers removed
+func replay() error {
+	p, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": *broker})
+	if err != nil {
+		return err
+	}
+	defer p.Close()
+
+	deliveryChan := make(chan kafka.Event, 1)
+	return scan(func(msg *kafka.Message) error {
+		h := headers(msg)
+		origin := h[channel.HeaderDLQTopic]
+		if origin == "" {
+			origin = *topic
+		}
+		out := kafka.Message{
+			TopicPartition: kafka.TopicPartition{Topic: &origin, Partition: kafka.PartitionAny},
+			Key:            msg.Key,
+			Value:          msg.Value,
+		}
+		for k, v := range channel.ReplayHeaders(h) {
+			out.Headers = append(out.Headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		if err := p.Produce(&out, deliveryChan); err != nil {
+			return err
+		}
+		if err := (<-deliveryChan).(*kafka.Message).TopicPartition.Error; err != nil {
+			return err
+		}
+		fmt.Printf("replayed %d/%d to %s\n", msg.TopicPartition.Partition, msg.TopicPartition.Offset, origin)
+		return nil
+	})
+}
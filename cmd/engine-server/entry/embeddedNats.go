@@ -0,0 +1,57 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package entry
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/nats-io/gnatsd/server"
+)
+
+// startEmbeddedNats runs the nats server of the rpc between the components
+// inside the process, so a single process with the memory transport needs no
+// broker at all. It listens on the address of nats.uri, loopback by default.
+func startEmbeddedNats(cfg *config.Dendrite) *server.Server {
+	u, err := url.Parse(cfg.Nats.Uri)
+	if err != nil {
+		log.Fatalf("embedded nats invalid uri %s: %v", cfg.Nats.Uri, err)
+	}
+	opts := &server.Options{
+		Host:   u.Hostname(),
+		Port:   server.DEFAULT_PORT,
+		NoLog:  true,
+		NoSigs: true,
+	}
+	if opts.Host == "" {
+		opts.Host = "127.0.0.1"
+	}
+	if u.Port() != "" {
+		if opts.Port, err = strconv.Atoi(u.Port()); err != nil {
+			log.Fatalf("embedded nats invalid port %s: %v", cfg.Nats.Uri, err)
+		}
+	}
+
+	s := server.New(opts)
+	go s.Start()
+	if !s.ReadyForConnections(10 * time.Second) {
+		log.Fatalf("embedded nats not ready on %s:%d", opts.Host, opts.Port)
+	}
+	log.Infof("embedded nats listening on %s:%d", opts.Host, opts.Port)
+	return s
+}
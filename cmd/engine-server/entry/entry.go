@@ -55,6 +55,7 @@ import (
 type serverCmdPar struct {
 	maxCore        *bool
 	useSQLHook     *bool
+	migrateOnly    *bool
 	logPorf        *bool
 	procName       *string
 	httpBindAddr   *string
@@ -75,6 +76,7 @@ var cmdLine = &serverCmdPar{
 	httpBindAddr:   flag.String("http-address", "", "The HTTP listening port for the server"),
 	httpsBindAddr:  flag.String("https-address", "", "The HTTPS listening port for the server"),
 	useSQLHook:     flag.Bool("use-sql-hook", false, "use sql porfmance hook"),
+	migrateOnly:    flag.Bool("migrate-only", false, "apply the schema migrations of every database then exit"),
 	certFile:       flag.String("tls-cert", "", "The PEM formatted X509 certificate to use for TLS"),
 	keyFile:        flag.String("tls-key", "", "The PEM private key to use for TLS"),
 	logPorf:        flag.Bool("log-porf", true, "log server porfmance"),
@@ -98,6 +100,7 @@ Flags
 		--tls-key   			key file for ssl
 		--https-address			https listening port, default 8448
 		--log-porf				check log server porformance, default true
+		--migrate-only			apply the schema migrations of every database then exit, default false
 
 		--ev-recover-start		for events-recover service, events recover start time stamp
 		--ev-recover-end		for events-recover service, events recover end time stamp
@@ -119,6 +122,15 @@ func useSQLHook() {
 	sql.Register("postgres_hook", sqlhooks.Wrap(&pq.Driver{}, &common.Hooks{}))
 }
 
+func migrateDatabases(base *basecomponent.BaseDendrite) {
+	for _, name := range common.RegisteredDBs() {
+		if _, err := common.GetDBInstance(name, base.Cfg); err != nil {
+			log.Fatalf("migrate db %s error %v", name, err)
+		}
+		log.Infof("migrate db %s finished", name)
+	}
+}
+
 func myNotFound(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.Header().Set("X-Content-Type-Options", "nosniff")
@@ -424,10 +436,18 @@ func Entry() {
 		initPanicFile(cfg.Log.Files[0])
 	}
 
+	if *cmdLine.migrateOnly == true {
+		migrateDatabases(base)
+		return
+	}
+
 	handleSignal()
 	loadDefault(base, cmdLine)
 	decodeLicense(base.Cfg)
 	encryption.Init(base.Cfg.LicenseItem.Encryption, base.Cfg.LicenseItem.Secret, base.Cfg.Encryption.Mirror)
+	if base.Cfg.Nats.Embedded {
+		defer startEmbeddedNats(base.Cfg).Shutdown()
+	}
 	setUpTransport(base, cmdLine)
 	checkProcName(base, cmdLine)
 
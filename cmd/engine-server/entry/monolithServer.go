@@ -53,8 +53,13 @@ var dbUpdateProducerName = []string{
 	"device_devices",
 	"mig_device_devices",
 	"encrypt_algorithm",
+	"encrypt_cross_signing_key",
+	"encrypt_cross_signing_sig",
+	"encrypt_key_backup_version",
+	"encrypt_key_backup",
 	"encrypt_device_key",
 	"encrypt_onetime_key",
+	"encrypt_fallback_key",
 	"presence_presences",
 	"publicroomsapi_public_rooms",
 	"push_rules_enable",
@@ -117,6 +122,7 @@ func StartMonolithServer(base *basecomponent.BaseDendrite, cmd *serverCmdPar) {
 	addConsumer(transportMultiplexer, kafka.Consumer.OutputRoomEventSyncAggregate, base.Cfg.MultiInstance.Instance)
 	addConsumer(transportMultiplexer, kafka.Consumer.OutputClientData, base.Cfg.MultiInstance.Instance)
 	addConsumer(transportMultiplexer, kafka.Consumer.OutputProfileSyncServer, base.Cfg.MultiInstance.Instance)
+	addConsumer(transportMultiplexer, kafka.Consumer.OutputProfileSyncWriter, base.Cfg.MultiInstance.Instance)
 	addConsumer(transportMultiplexer, kafka.Consumer.OutputProfileSyncAggregate, base.Cfg.MultiInstance.Instance)
 	addConsumer(transportMultiplexer, kafka.Consumer.CacheUpdates, base.Cfg.MultiInstance.Instance)
 	addConsumer(transportMultiplexer, kafka.Consumer.DBUpdates, base.Cfg.MultiInstance.Instance)
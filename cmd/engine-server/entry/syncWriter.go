@@ -34,6 +34,7 @@ func StartSyncWriter(base *basecomponent.BaseDendrite, cmd *serverCmdPar) {
 	}
 
 	addConsumer(transportMultiplexer, kafka.Consumer.OutputRoomEventSyncWriter, base.Cfg.MultiInstance.Instance)
+	addConsumer(transportMultiplexer, kafka.Consumer.OutputProfileSyncWriter, base.Cfg.MultiInstance.Instance)
 
 	transportMultiplexer.PreStart()
 
@@ -104,6 +104,13 @@ type APIProcessor interface {
 	Process(ctx context.Context, ud interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder)
 }
 
+// HeaderCoder is a response which also sets http headers, e.g. the Location
+// of a redirect
+type HeaderCoder interface {
+	core.Coder
+	Headers() map[string]string
+}
+
 type APIEvent struct {
 	reply     string
 	topic     string
@@ -329,6 +336,12 @@ func (c *APIConsumer) process(ctx context.Context, input *internals.InputMsg) (*
 	var err error
 	if resp != nil {
 		output.Body, err = resp.Encode()
+		if err != nil {
+			return output, err
+		}
+		if hc, ok := resp.(HeaderCoder); ok {
+			output.Headers, err = json.Marshal(hc.Headers())
+		}
 	}
 	return output, err
 }
@@ -23,6 +23,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	util "github.com/finogeeks/ligase/skunkworks/gomatrixutil"
 	"github.com/finogeeks/ligase/skunkworks/log"
@@ -77,6 +79,14 @@ func VerifyToken(token string, requestURI string, cache service.Cache, cfg confi
 		}
 		return nil, resErr
 	}
+	if device.ExpireTs > 0 && device.ExpireTs <= time.Now().UnixNano()/1000000 {
+		log.Infof("expired token user %s device %s, req: %s", device.UserID, device.ID, requestURI)
+		resErr := &util.JSONResponse{
+			Code: 401,
+			JSON: jsonerror.SoftLogout("Access token has expired"),
+		}
+		return nil, resErr
+	}
 	if guest == false && devFilter != nil && !filterTokenCheck(device.UserID) {
 		key := device.UserID + ":" + device.ID
 		if !devFilter.Lookup(device.UserID, device.ID) {
@@ -106,6 +116,37 @@ func VerifyToken(token string, requestURI string, cache service.Cache, cfg confi
 	return device, nil
 }
 
+// lastSeenInterval is how often an unchanged last seen of a device is
+// written to the cache
+const lastSeenInterval = int64(60 * 1000)
+
+// lastSeens keeps the last seen written by this proxy for each device
+var lastSeens sync.Map
+
+// recordLastSeen writes where the device made the request from, so admins
+// can tell the connections of a user
+func recordLastSeen(req *http.Request, device *authtypes.Device, cache service.Cache) {
+	if device == nil || device.ID == "" {
+		return
+	}
+	lastSeen := &authtypes.DeviceLastSeen{
+		IP:        GetRemoteIP(req),
+		UserAgent: req.UserAgent(),
+		Ts:        time.Now().UnixNano() / 1000000,
+	}
+	key := device.UserID + ":" + device.ID
+	if val, ok := lastSeens.Load(key); ok {
+		last := val.(*authtypes.DeviceLastSeen)
+		if last.IP == lastSeen.IP && last.UserAgent == lastSeen.UserAgent && lastSeen.Ts-last.Ts < lastSeenInterval {
+			return
+		}
+	}
+	lastSeens.Store(key, lastSeen)
+	if err := cache.SetDeviceLastSeen(device.UserID, device.ID, lastSeen); err != nil {
+		log.Warnf("set last seen of user %s device %s error %v", device.UserID, device.ID, err)
+	}
+}
+
 func filterTokenCheck(userId string) bool {
 	return strings.Contains(userId, "-qq:") || strings.Contains(userId, "-bot:") || strings.Contains(userId, "@qq_")
 }
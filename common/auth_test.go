@@ -0,0 +1,74 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+)
+
+func newTokenConfig() config.Dendrite {
+	cfg := config.Dendrite{}
+	cfg.Macaroon.Key = "key"
+	cfg.Macaroon.Id = "id"
+	cfg.Macaroon.Loc = "test"
+	return cfg
+}
+
+func buildTestToken(t *testing.T, cfg config.Dendrite, expireTs int64) string {
+	token, err := BuildExpiringToken(cfg.Macaroon.Key, cfg.Macaroon.Id, cfg.Macaroon.Loc, "@alice:test", "identifier", false, "DEVICE", "mobile", true, expireTs)
+	if err != nil {
+		t.Fatalf("build token: %v", err)
+	}
+	return token
+}
+
+func TestVerifyTokenExpired(t *testing.T) {
+	cfg := newTokenConfig()
+	token := buildTestToken(t, cfg, time.Now().Add(-time.Second).UnixNano()/1000000)
+
+	device, resErr := VerifyToken(token, "/_matrix/client/r0/sync", nil, cfg, nil)
+	if device != nil || resErr == nil || resErr.Code != http.StatusUnauthorized {
+		t.Fatalf("want 401, got %+v %+v", device, resErr)
+	}
+	if err, ok := resErr.JSON.(*jsonerror.SoftLogoutError); !ok || !err.SoftLogout || err.ErrCode != "M_UNKNOWN_TOKEN" {
+		t.Errorf("want a soft logout, got %+v", resErr.JSON)
+	}
+}
+
+func TestVerifyTokenValid(t *testing.T) {
+	cfg := newTokenConfig()
+	expireTs := time.Now().Add(time.Minute).UnixNano() / 1000000
+
+	for _, ts := range []int64{expireTs, 0} {
+		device, resErr := VerifyToken(buildTestToken(t, cfg, ts), "/_matrix/client/r0/sync", nil, cfg, nil)
+		if resErr != nil {
+			t.Fatalf("expire %d: want the device, got %d %+v", ts, resErr.Code, resErr.JSON)
+		}
+		if device.UserID != "@alice:test" || device.ID != "DEVICE" || device.ExpireTs != ts {
+			t.Errorf("expire %d: unexpected device %+v", ts, device)
+		}
+	}
+
+	other := cfg
+	other.Macaroon.Key = "other"
+	if device, resErr := VerifyToken(buildTestToken(t, other, expireTs), "/_matrix/client/r0/sync", nil, cfg, nil); device != nil || resErr == nil || resErr.Code != http.StatusUnauthorized {
+		t.Errorf("foreign key: want 401, got %+v %+v", device, resErr)
+	}
+}
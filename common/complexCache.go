@@ -17,6 +17,7 @@ package common
 import (
 	"context"
 
+	"github.com/finogeeks/ligase/common/replica"
 	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/finogeeks/ligase/storage/model"
@@ -139,8 +140,8 @@ func (c *ComplexCache) GetProfileByUserID(
 
 	// trylock
 
-	// get from DB
-	profile, err := c.accountDB.GetProfileByUserID(ctx, userID)
+	// get from DB, the primary has the profile just set by the user
+	profile, err := c.accountDB.GetProfileByUserID(replica.WithPrimary(ctx), userID)
 	if err != nil {
 		// set empty cache for nonexistent user
 		profile.DisplayName = ""
@@ -171,8 +172,8 @@ func (c *ComplexCache) GetAvatarURL(
 
 	// trylock
 
-	// get from DB
-	profile, err := c.accountDB.GetProfileByUserID(ctx, userID)
+	// get from DB, the primary has the profile just set by the user
+	profile, err := c.accountDB.GetProfileByUserID(replica.WithPrimary(ctx), userID)
 	if err != nil {
 		// set empty cache for nonexistent user
 		profile.DisplayName = ""
@@ -203,8 +204,8 @@ func (c *ComplexCache) GetDisplayName(
 
 	// trylock
 
-	// get from DB
-	profile, err := c.accountDB.GetProfileByUserID(ctx, userID)
+	// get from DB, the primary has the profile just set by the user
+	profile, err := c.accountDB.GetProfileByUserID(replica.WithPrimary(ctx), userID)
 	if err != nil {
 		// set empty cache for nonexistent user
 		profile.DisplayName = ""
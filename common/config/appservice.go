@@ -54,6 +54,8 @@ type ApplicationService struct {
 	InterestedAll   bool   `yaml:"interested_all"`
 	// Information about an application service's namespaces
 	NamespaceMap map[string][]ApplicationServiceNamespace `yaml:"namespaces"`
+	// The third party protocols the application service bridges to
+	Protocols []string `yaml:"protocols"`
 }
 
 // loadAppservices iterates through all application service config files
@@ -174,6 +174,7 @@ type Dendrite struct {
 			OutputClientData           ConsumerConf `yaml:"output_client_data"`           // OutputClientData "sync-api"
 			OutputProfileSyncAggregate ConsumerConf `yaml:"output_profile_syncaggregate"` // OutputClientData "sync-api"
 			OutputProfileSyncServer    ConsumerConf `yaml:"output_profile_syncserver"`    // OutputClientData "sync-api"
+			OutputProfileSyncWriter    ConsumerConf `yaml:"output_profile_syncwriter"`    // OutputClientData "sync-writer"
 			CacheUpdates               ConsumerConf `yaml:"cache_updates"`                // DBUpdates persist-cache
 			DBUpdates                  ConsumerConf `yaml:"db_updates"`                   // DBUpdates persist-db
 			FedBridgeOut               ConsumerConf `yaml:"fed_bridge_out"`
@@ -221,6 +222,8 @@ type Dendrite struct {
 	} `yaml:"redis"`
 	Nats struct {
 		Uri string `yaml:"uri"`
+		// Run the nats server in the process, listening on the address of uri
+		Embedded bool `yaml:"embedded"`
 	} `yaml:"nats"`
 	// Postgres Config
 	Database struct {
@@ -313,8 +316,40 @@ type Dendrite struct {
 		// Configuration for login authorize mode
 		AuthorizeMode string `yaml:"login_authorize_mode"`
 		AuthorizeCode string `yaml:"login_authorize_code"`
+		// The users allowed to call the admin APIs
+		AdminUsers []string `yaml:"admin_users"`
+		// How long in seconds the access tokens of clients supporting refresh
+		// tokens are valid, 0 disables refresh tokens
+		AccessTokenLifetime int64 `yaml:"access_token_lifetime"`
+		// How long in seconds an unused refresh token is valid, 0 never expires
+		RefreshTokenLifetime int64 `yaml:"refresh_token_lifetime"`
 	} `yaml:"authorization"`
 
+	// Configuration for the m.login.cas and m.login.sso login flows
+	SSO struct {
+		// The public url of the homeserver the identity provider redirects back to
+		PublicBaseURL string `yaml:"public_baseurl"`
+		// Whether to create the users which log in through the identity provider for the first time
+		AllowRegistration bool `yaml:"allow_registration"`
+		// The url prefixes clients may ask to be redirected to, only the public base url is allowed when empty
+		ClientWhitelist []string `yaml:"client_whitelist"`
+		CAS             struct {
+			Enabled   bool   `yaml:"enabled"`
+			ServerURL string `yaml:"server_url"`
+		} `yaml:"cas"`
+		OIDC struct {
+			Enabled               bool     `yaml:"enabled"`
+			AuthorizationEndpoint string   `yaml:"authorization_endpoint"`
+			TokenEndpoint         string   `yaml:"token_endpoint"`
+			UserInfoEndpoint      string   `yaml:"userinfo_endpoint"`
+			ClientID              string   `yaml:"client_id"`
+			ClientSecret          string   `yaml:"client_secret"`
+			Scopes                []string `yaml:"scopes"`
+			// The userinfo claim the localpart of the user is built from, default sub
+			LocalpartClaim string `yaml:"localpart_claim"`
+		} `yaml:"oidc"`
+	} `yaml:"sso"`
+
 	PushService struct {
 		// Configuration for push service
 		RemoveFailTimes      int    `yaml:"remove_fail_times"`
@@ -456,6 +491,26 @@ type Dendrite struct {
 		Mirror bool `yaml:"mirror"`
 	} `yaml:"encryption"`
 
+	// Retention of room history, see m.room.retention. All lifetimes are in
+	// milliseconds, 0 means unlimited.
+	Retention struct {
+		Enable bool `yaml:"enable"`
+		// Lifetime of the events of rooms without a max_lifetime of their own
+		DefaultMaxLifetime int64 `yaml:"default_max_lifetime_ms"`
+		// Bounds applied to the max_lifetime of a room
+		MinLifetime int64 `yaml:"min_lifetime_ms"`
+		MaxLifetime int64 `yaml:"max_lifetime_ms"`
+		// Seconds between two runs of the purger
+		PurgeInterval int `yaml:"purge_interval"`
+	} `yaml:"retention"`
+
+	// Visibility of the users found by /user_directory/search, users who
+	// share a room with the searcher are always visible
+	UserDirectory struct {
+		// Also show every local user
+		SearchAllUsers bool `yaml:"search_all_users"`
+	} `yaml:"user_directory"`
+
 	NotaryService struct {
 		CliHttpsEnable bool   `yaml:"cli_https_enable"`
 		SrvHttpsEnable bool   `yaml:"srv_https_enable"`
@@ -528,6 +583,12 @@ type ConsumerConf struct {
 	CommitIntervalMS *int    `yaml:"auto_commit_interval_ms,omitempty"`
 	AutoOffsetReset  *string `yaml:"topic_auto_offset_reset,omitempty"`
 	EnableGoChannel  *bool   `yaml:"go_channel_enable,omitempty"`
+	Format           string  `yaml:"format,omitempty"` //json(default), gob, capn or protobuf
+
+	Retries         *int  `yaml:"retries,omitempty"` //retries of a failed message, default 0
+	RetryBackoff    *int  `yaml:"retry_backoff_ms,omitempty"`
+	RetryMaxBackoff *int  `yaml:"retry_max_backoff_ms,omitempty"`
+	DeadLetter      *bool `yaml:"dead_letter_enable,omitempty"` //park failed messages in <topic>.dlq
 }
 
 func (c *ConsumerConf) EnableAutoCommit() *bool {
@@ -546,6 +607,26 @@ func (c *ConsumerConf) GoChannelEnable() *bool {
 	return c.EnableGoChannel
 }
 
+func (c *ConsumerConf) GetFormat() string {
+	return c.Format
+}
+
+func (c *ConsumerConf) RetryTimes() *int {
+	return c.Retries
+}
+
+func (c *ConsumerConf) RetryBackoffMS() *int {
+	return c.RetryBackoff
+}
+
+func (c *ConsumerConf) RetryMaxBackoffMS() *int {
+	return c.RetryMaxBackoff
+}
+
+func (c *ConsumerConf) DeadLetterEnable() *bool {
+	return c.DeadLetter
+}
+
 type ProducerConf struct {
 	Topic      string `yaml:"topic"`
 	Underlying string `yaml:"underlying"`
@@ -553,15 +634,24 @@ type ProducerConf struct {
 	Inst       int    `yaml:"inst"` //producer instance number, default one instance
 
 	LingerMs *string `yaml:"linger_ms,omitempty"`
+	Format   string  `yaml:"format,omitempty"` //json(default), gob, capn or protobuf
 }
 
 func (p *ProducerConf) LingerMsConf() *string {
 	return p.LingerMs
 }
 
+func (p *ProducerConf) GetFormat() string {
+	return p.Format
+}
+
 type DataBaseConf struct {
 	Driver    string `yaml:"driver"`
 	Addresses string `yaml:"addresses"`
+	// read replicas, plain reads are routed to them while they lag less
+	// than MaxReplicaLag milliseconds behind the primary
+	Replicas      []string `yaml:"replicas,omitempty"`
+	MaxReplicaLag int      `yaml:"max_replica_lag_ms,omitempty"`
 }
 
 type DistLockConf struct {
@@ -746,6 +836,41 @@ func (config *Dendrite) GetDBConfig(name string) (driver string, createAddr stri
 	}
 }
 
+func (config *Dendrite) GetDBReplicaConfig(name string) (replicas []string, maxLagMS int) {
+	var conf *DataBaseConf
+	switch name {
+	case "accounts":
+		conf = &config.Database.Account
+	case "devices":
+		conf = &config.Database.Device
+	case "serverkey":
+		conf = &config.Database.ServerKey
+	case "syncapi":
+		conf = &config.Database.SyncAPI
+	case "roomserver":
+		conf = &config.Database.RoomServer
+	case "publicroomapi":
+		conf = &config.Database.PublicRoomsAPI
+	case "presence":
+		conf = &config.Database.Presence
+	case "encryptoapi":
+		conf = &config.Database.EncryptAPI
+	case "appservice":
+		conf = &config.Database.ApplicationService
+	case "pushapi":
+		conf = &config.Database.PushAPI
+	case "server_conf":
+		conf = &config.Database.ServerConf
+	case "content":
+		conf = &config.Database.Content
+	case "rcsserver":
+		conf = &config.Database.RCSServer
+	default:
+		return nil, 0
+	}
+	return conf.Replicas, conf.MaxReplicaLag
+}
+
 // derive generates data that is derived from various values provided in
 // the config file.
 func (config *Dendrite) derive() error {
@@ -916,6 +1041,16 @@ func fingerprintPEM(data []byte) *gomatrixserverlib.TLSFingerprint {
 	}
 }
 
+// IsServerAdmin reports whether the user may call the admin APIs
+func (config *Dendrite) IsServerAdmin(userID string) bool {
+	for _, admin := range config.Authorization.AdminUsers {
+		if admin == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // RoomServerURL returns an HTTP URL for where the roomserver is listening.
 func (config *Dendrite) RoomServerURL() string {
 	// Hard code the roomserver to talk HTTP for now.
@@ -17,8 +17,11 @@ package common
 import (
 	"errors"
 	"log"
+	"sort"
 	"sync"
 
+	"github.com/finogeeks/ligase/common/replica"
+	"github.com/finogeeks/ligase/common/sqlite"
 	"github.com/finogeeks/ligase/core"
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 	"github.com/finogeeks/ligase/storage/model"
@@ -49,6 +52,19 @@ func Register(name string, f func(string, string, string, string, string, bool)
 	newHandler[name] = f
 }
 
+// RegisteredDBs returns the names of the databases registered in this process
+func RegisteredDBs() []string {
+	regMu.RLock()
+	defer regMu.RUnlock()
+
+	names := make([]string, 0, len(newHandler))
+	for name := range newHandler {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
 func GetDBInstance(name string, cfg core.IConfig) (interface{}, error) {
 	driver, createAddr, address, underlying, topic, useSync := cfg.GetDBConfig(name)
 	f := newHandler[name]
@@ -61,6 +77,16 @@ func GetDBInstance(name string, cfg core.IConfig) (interface{}, error) {
 		return val, nil
 	}
 
+	if replicaCfg, ok := cfg.(core.IReplicaConfig); ok && !sqlite.IsDriver(driver) {
+		if replicas, maxLagMS := replicaCfg.GetDBReplicaConfig(name); len(replicas) > 0 {
+			wrapped, err := replica.Wrap(name, driver, address, replicas, maxLagMS)
+			if err != nil {
+				return nil, err
+			}
+			driver = wrapped
+		}
+	}
+
 	val, err := f(driver, createAddr, address, underlying, topic, useSync)
 	if err != nil {
 		return nil, err
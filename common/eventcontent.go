@@ -34,6 +34,15 @@ type CreateContent struct {
 	IsOrganizationRoom *bool `json:"is_organization_room,omitempty"`
 	IsGroupRoom        *bool `json:"is_group_room,omitempty"`
 	RoomType           *int  `json:"room_type,omitempty"`
+
+	RoomVersion string        `json:"room_version,omitempty"`
+	Predecessor *PreviousRoom `json:"predecessor,omitempty"`
+}
+
+// PreviousRoom is the predecessor of an upgraded room, the last event of it is the m.room.tombstone
+type PreviousRoom struct {
+	RoomID  string `json:"room_id"`
+	EventID string `json:"event_id"`
 }
 
 //type CreateContent map[string]interface{}
@@ -71,6 +80,19 @@ type GuestAccessContent struct {
 	GuestAccess string `json:"guest_access"`
 }
 
+// RetentionContent is the event content for https://github.com/matrix-org/matrix-doc/pull/1763
+// lifetimes are in milliseconds
+type RetentionContent struct {
+	MinLifetime *int64 `json:"min_lifetime,omitempty"`
+	MaxLifetime *int64 `json:"max_lifetime,omitempty"`
+}
+
+// TombstoneContent is the event content for https://matrix.org/docs/spec/client_server/r0.6.0#m-room-tombstone
+type TombstoneContent struct {
+	Body            string `json:"body"`
+	ReplacementRoom string `json:"replacement_room"`
+}
+
 // JoinRulesContent is the event content for http://matrix.org/docs/spec/client_server/r0.2.0.html#m-room-join-rules
 type JoinRulesContent struct {
 	JoinRule string `json:"join_rule"`
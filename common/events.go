@@ -80,7 +80,9 @@ func IsStateEv(ev *gomatrixserverlib.Event) bool {
 		return true
 	case "m.room.encryption":
 		return true
-	case "m.room.third_party_invite", "m.room.guest_access":
+	case "m.room.third_party_invite", "m.room.guest_access", "m.room.retention":
+		return true
+	case "m.room.tombstone":
 		return true
 	default:
 		return false
@@ -99,7 +101,9 @@ func IsStateClientEv(ev *gomatrixserverlib.ClientEvent) bool {
 		return true
 	case "m.room.encryption":
 		return true
-	case "m.room.third_party_invite", "m.room.guest_access":
+	case "m.room.third_party_invite", "m.room.guest_access", "m.room.retention":
+		return true
+	case "m.room.tombstone":
 		return true
 	default:
 		return false
@@ -78,6 +78,7 @@ func MakeAuthAPI(
 		if resErr != nil {
 			return *resErr
 		}
+		recordLastSeen(req, device, cache)
 
 		res := f(req, device)
 
@@ -18,6 +18,7 @@
 package jsonerror
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 
@@ -87,6 +88,12 @@ func InvalidArgumentValue(msg string) *MatrixError {
 	return &MatrixError{ErrCode: "M_INVALID_ARGUMENT_VALUE", Err: msg}
 }
 
+// InvalidSignature is an error when a signature supplied by the client
+// doesn't verify
+func InvalidSignature(msg string) *MatrixError {
+	return &MatrixError{ErrCode: "M_INVALID_SIGNATURE", Err: msg}
+}
+
 // MissingToken is an error when the client tries to access a resource which
 // requires authentication without supplying credentials.
 func MissingToken(msg string) *MatrixError {
@@ -103,6 +110,21 @@ func UnknownToken(msg string) *MatrixError {
 	return &MatrixError{ErrCode: "M_UNKNOWN_TOKEN", Err: msg}
 }
 
+// SoftLogoutError is an M_UNKNOWN_TOKEN error telling the client the session
+// may be resumed, e.g. by refreshing an expired access token
+type SoftLogoutError struct {
+	MatrixError
+	SoftLogout bool `json:"soft_logout"`
+}
+
+// SoftLogout is an error when the client supplies an expired access token
+func SoftLogout(msg string) *SoftLogoutError {
+	return &SoftLogoutError{
+		MatrixError: MatrixError{ErrCode: "M_UNKNOWN_TOKEN", Err: msg},
+		SoftLogout:  true,
+	}
+}
+
 func PwdChangeKick(msg string) *MatrixError {
 	return &MatrixError{ErrCode: "M_PWD_CHANGE_KICK", Err: msg}
 }
@@ -113,6 +135,18 @@ func WeakPassword(msg string) *MatrixError {
 	return &MatrixError{ErrCode: "M_WEAK_PASSWORD", Err: msg}
 }
 
+// UserDeactivated is an error returned when the client tries to log in to
+// a deactivated account
+func UserDeactivated(msg string) *MatrixError {
+	return &MatrixError{ErrCode: "M_USER_DEACTIVATED", Err: msg}
+}
+
+// UserSuspended is an error returned when the client tries to log in to
+// an account suspended by an admin
+func UserSuspended(msg string) *MatrixError {
+	return &MatrixError{ErrCode: "M_USER_SUSPENDED", Err: msg}
+}
+
 // InvalidUsername is an error returned when the client tries to register an
 // invalid username
 func InvalidUsername(msg string) *MatrixError {
@@ -138,6 +172,12 @@ func GuestAccessForbidden(msg string) *MatrixError {
 	return &MatrixError{ErrCode: "M_GUEST_ACCESS_FORBIDDEN", Err: msg}
 }
 
+// UnsupportedRoomVersion is an error returned when the client asks for a room
+// version the server doesn't support
+func UnsupportedRoomVersion(msg string) *MatrixError {
+	return &MatrixError{ErrCode: "M_UNSUPPORTED_ROOM_VERSION", Err: msg}
+}
+
 // LimitExceededError is a rate-limiting error.
 type LimitExceededError struct {
 	MatrixError
@@ -152,6 +192,30 @@ func LimitExceeded(msg string, retryAfterMS int64) *LimitExceededError {
 	}
 }
 
+// WrongRoomKeysVersionError tells the client the room key backup version it
+// uploads to isn't the current one
+type WrongRoomKeysVersionError struct {
+	MatrixError
+	CurrentVersion string `json:"current_version"`
+}
+
+func (e *WrongRoomKeysVersionError) Encode() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+func (e *WrongRoomKeysVersionError) Decode(input []byte) error {
+	return json.Unmarshal(input, e)
+}
+
+// WrongRoomKeysVersion is an error when the client uploads room keys to a
+// backup version which has been replaced
+func WrongRoomKeysVersion(msg, currentVersion string) *WrongRoomKeysVersionError {
+	return &WrongRoomKeysVersionError{
+		MatrixError:    MatrixError{ErrCode: "M_WRONG_ROOM_KEYS_VERSION", Err: msg},
+		CurrentVersion: currentVersion,
+	}
+}
+
 // NotTrusted is an error which is returned when the client asks the server to
 // proxy a request (e.g. 3PID association) to a server that isn't trusted
 func NotTrusted(serverName string) *MatrixError {
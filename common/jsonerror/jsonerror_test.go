@@ -45,3 +45,15 @@ func TestForbidden(t *testing.T) {
 		t.Errorf("TestForbidden: want %s, got %s", want, string(jsonBytes))
 	}
 }
+
+func TestWrongRoomKeysVersion(t *testing.T) {
+	e := WrongRoomKeysVersion("wrong backup version", "2")
+	jsonBytes, err := e.Encode()
+	if err != nil {
+		t.Fatalf("TestWrongRoomKeysVersion: Failed to encode WrongRoomKeysVersion error. %s", err.Error())
+	}
+	want := `{"errcode":"M_WRONG_ROOM_KEYS_VERSION","error":"wrong backup version","current_version":"2"}`
+	if string(jsonBytes) != want {
+		t.Errorf("TestWrongRoomKeysVersion: want %s, got %s", want, string(jsonBytes))
+	}
+}
@@ -0,0 +1,198 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package migration keeps the numbered schema migrations of every logical
+// database. The CREATE TABLE IF NOT EXISTS schemas of the table files are
+// the baseline, migrations registered here alter them on upgrade and the
+// applied versions are recorded in the schema_versions table.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/sqlite"
+)
+
+const schemaVersionsSchema = `
+-- Stores the migrations applied to each logical database.
+CREATE TABLE IF NOT EXISTS schema_versions (
+	-- The logical database, as registered with common.Register.
+	db_name TEXT NOT NULL,
+	-- The migration version.
+	version BIGINT NOT NULL,
+	-- The migration name.
+	name TEXT NOT NULL,
+	-- When the migration was applied, as a unix timestamp (ms resolution).
+	applied_ts BIGINT NOT NULL,
+	CONSTRAINT schema_versions_unique UNIQUE (db_name, version)
+);
+`
+
+const selectVersionsSQL = "" +
+	"SELECT version FROM schema_versions WHERE db_name = $1"
+
+const insertVersionSQL = "" +
+	"INSERT INTO schema_versions(db_name, version, name, applied_ts) VALUES ($1, $2, $3, $4)"
+
+const lockSQL = "SELECT pg_advisory_lock($1)"
+
+const unlockSQL = "SELECT pg_advisory_unlock($1)"
+
+// Migration is one numbered schema change of a logical database. Up may hold
+// several statements, it runs in a transaction together with the version
+// record.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+}
+
+var (
+	regMu      sync.RWMutex
+	migrations = make(map[string][]Migration)
+	// serializes migrations of the sqlite databases, which have no create_db
+	localMu sync.Mutex
+)
+
+// Register adds migrations of a logical database, it is called from init
+// like common.Register so can't use skunkworks log.
+func Register(dbName string, ms ...Migration) {
+	regMu.Lock()
+	defer regMu.Unlock()
+
+	list := migrations[dbName]
+	for _, m := range ms {
+		if m.Version <= 0 {
+			log.Panicf("Migration Register: %s version %d must be positive\n", dbName, m.Version)
+		}
+		for _, v := range list {
+			if v.Version == m.Version {
+				log.Panicf("Migration Register: %s version %d already registered\n", dbName, m.Version)
+			}
+		}
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Version < list[j].Version })
+	migrations[dbName] = list
+}
+
+// Migrations returns the registered migrations of a database in version order.
+func Migrations(dbName string) []Migration {
+	regMu.RLock()
+	defer regMu.RUnlock()
+
+	return append([]Migration(nil), migrations[dbName]...)
+}
+
+// Apply runs the pending migrations of dbName against db. The migration is
+// locked with an advisory lock taken on the create_db connection, so only
+// one instance upgrades a database when several start at the same time.
+func Apply(dbName, driver, createAddr string, db *sql.DB) error {
+	ctx := context.Background()
+	if sqlite.IsDriver(driver) {
+		localMu.Lock()
+		defer localMu.Unlock()
+	} else {
+		unlock, err := lock(ctx, dbName, driver, createAddr)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+	}
+
+	if _, err := db.ExecContext(ctx, schemaVersionsSchema); err != nil {
+		return err
+	}
+	applied, err := selectVersions(ctx, dbName, db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range Migrations(dbName) {
+		if applied[m.Version] {
+			continue
+		}
+		if err := apply(ctx, dbName, db, m); err != nil {
+			return fmt.Errorf("migration %s version %d %s: %v", dbName, m.Version, m.Name, err)
+		}
+		log.Printf("Migration %s applied version %d %s\n", dbName, m.Version, m.Name)
+	}
+	return nil
+}
+
+func lock(ctx context.Context, dbName, driver, createAddr string) (func(), error) {
+	createDB, err := sql.Open(driver, createAddr)
+	if err != nil {
+		return nil, err
+	}
+	// advisory locks belong to the session, lock and unlock on one connection
+	conn, err := createDB.Conn(ctx)
+	if err != nil {
+		createDB.Close()
+		return nil, err
+	}
+	key := lockKey(dbName)
+	if _, err = conn.ExecContext(ctx, lockSQL, key); err != nil {
+		conn.Close()
+		createDB.Close()
+		return nil, err
+	}
+	return func() {
+		conn.ExecContext(ctx, unlockSQL, key) // nolint: errcheck
+		conn.Close()
+		createDB.Close()
+	}, nil
+}
+
+func lockKey(dbName string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("schema_versions:" + dbName))
+	return int64(h.Sum64())
+}
+
+func selectVersions(ctx context.Context, dbName string, db *sql.DB) (map[int64]bool, error) {
+	rows, err := db.QueryContext(ctx, selectVersionsSQL, dbName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]bool)
+	for rows.Next() {
+		var version int64
+		if err = rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+func apply(ctx context.Context, dbName string, db *sql.DB, m Migration) error {
+	return common.WithTransaction(db, func(txn *sql.Tx) error {
+		if _, err := txn.ExecContext(ctx, m.Up); err != nil {
+			return err
+		}
+		_, err := txn.ExecContext(ctx, insertVersionSQL, dbName, m.Version, m.Name, time.Now().UnixNano()/1000000)
+		return err
+	})
+}
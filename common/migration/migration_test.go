@@ -0,0 +1,78 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migration
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/finogeeks/ligase/common/sqlite"
+)
+
+func TestApply(t *testing.T) {
+	dir, err := ioutil.TempDir("", "migration")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	db, err := sql.Open(sqlite.DriverName, "file:"+filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE IF NOT EXISTS test_users (user_id TEXT NOT NULL)"); err != nil {
+		t.Fatalf("baseline: %v", err)
+	}
+
+	// registered out of order on purpose
+	Register("test",
+		Migration{Version: 2, Name: "user display name", Up: "ALTER TABLE test_users ADD COLUMN display_name TEXT NOT NULL DEFAULT ''"},
+		Migration{Version: 1, Name: "user created ts", Up: "ALTER TABLE test_users ADD COLUMN created_ts BIGINT NOT NULL DEFAULT 0"},
+	)
+	for i := 0; i < 2; i++ {
+		if err = Apply("test", sqlite.DriverName, "", db); err != nil {
+			t.Fatalf("apply %d: %v", i, err)
+		}
+	}
+	if _, err = db.Exec("INSERT INTO test_users (user_id, created_ts, display_name) VALUES ($1, $2, $3)", "@a", 1, "a"); err != nil {
+		t.Fatalf("migrated columns missing: %v", err)
+	}
+
+	Register("test", Migration{Version: 3, Name: "broken", Up: "ALTER TABLE test_users ADD COLUMN extra TEXT; ALTER TABLE missing ADD COLUMN x TEXT"})
+	if err = Apply("test", sqlite.DriverName, "", db); err == nil {
+		t.Fatalf("expected broken migration to fail")
+	}
+
+	var versions []int64
+	rows, err := db.Query("SELECT version FROM schema_versions WHERE db_name = $1 ORDER BY version", "test")
+	if err != nil {
+		t.Fatalf("select versions: %v", err)
+	}
+	for rows.Next() {
+		var version int64
+		rows.Scan(&version)
+		versions = append(versions, version)
+	}
+	rows.Close()
+	if len(versions) != 2 || versions[0] != 1 || versions[1] != 2 {
+		t.Fatalf("unexpected versions %v", versions)
+	}
+	if _, err = db.Exec("SELECT extra FROM test_users"); err == nil {
+		t.Fatalf("broken migration was not rolled back")
+	}
+}
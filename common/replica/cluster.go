@@ -0,0 +1,134 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	log "github.com/finogeeks/ligase/skunkworks/log"
+)
+
+// CheckInterval is the interval of the replica health and lag checks
+var CheckInterval = time.Second
+
+// lagQuery returns the replay lag of a postgres standby in seconds, a standby
+// which replayed everything it received has no lag even if the primary is idle
+const lagQuery = `SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END`
+
+type replica struct {
+	name    string
+	addr    string
+	db      *sql.DB
+	healthy int32
+}
+
+func (r *replica) isHealthy() bool {
+	return atomic.LoadInt32(&r.healthy) == 1
+}
+
+func (r *replica) setHealthy(healthy bool, reason string) {
+	val := int32(0)
+	if healthy {
+		val = 1
+	}
+	if atomic.SwapInt32(&r.healthy, val) != val {
+		if healthy {
+			log.Infof("replica %s is back in service", r.name)
+		} else {
+			log.Warnf("replica %s is out of service: %s", r.name, reason)
+		}
+	}
+}
+
+// cluster is a primary database with its read replicas
+type cluster struct {
+	driverName string
+	postgres   bool
+	primary    string
+	replicas   []*replica
+	maxLag     time.Duration
+	next       uint32
+	once       sync.Once
+}
+
+// pick returns a healthy replica in round robin, nil when reads must go to
+// the primary
+func (c *cluster) pick() *replica {
+	n := uint32(len(c.replicas))
+	start := atomic.AddUint32(&c.next, 1)
+	for i := uint32(0); i < n; i++ {
+		r := c.replicas[(start+i)%n]
+		if r.isHealthy() {
+			return r
+		}
+	}
+	return nil
+}
+
+func (c *cluster) startMonitor() {
+	c.once.Do(func() {
+		for _, r := range c.replicas {
+			db, err := sql.Open(c.driverName, r.addr)
+			if err != nil {
+				log.Errorf("replica %s open err: %v", r.name, err)
+				continue
+			}
+			db.SetMaxOpenConns(1)
+			r.db = db
+		}
+		// the first check decides whether reads can use the replicas at all
+		c.check()
+		go func() {
+			for {
+				time.Sleep(CheckInterval)
+				c.check()
+			}
+		}()
+	})
+}
+
+// check marks the replicas which can't be reached or lag more than maxLag
+// behind the primary as unhealthy
+func (c *cluster) check() {
+	for _, r := range c.replicas {
+		if r.db == nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), CheckInterval)
+		if !c.postgres {
+			if err := r.db.PingContext(ctx); err != nil {
+				r.setHealthy(false, err.Error())
+			} else {
+				r.setHealthy(true, "")
+			}
+			cancel()
+			continue
+		}
+		var lag float64
+		if err := r.db.QueryRowContext(ctx, lagQuery).Scan(&lag); err != nil {
+			r.setHealthy(false, err.Error())
+		} else if c.maxLag > 0 && time.Duration(lag*float64(time.Second)) > c.maxLag {
+			r.setHealthy(false, "lag "+time.Duration(lag*float64(time.Second)).String())
+		} else {
+			r.setHealthy(true, "")
+		}
+		cancel()
+	}
+}
@@ -0,0 +1,387 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package replica routes the reads of a database to its read replicas. Wrap
+// registers a "<driver>+replica" database/sql driver, connections opened with
+// it send plain SELECT statements outside of transactions to a healthy
+// replica and everything else to the primary. Queries which must see their
+// own writes are marked with WithPrimary.
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gchaincl/sqlhooks"
+	"github.com/lib/pq"
+)
+
+const driverSuffix = "+replica"
+
+var (
+	mu       sync.Mutex
+	clusters sync.Map
+)
+
+type primaryKey struct{}
+
+// WithPrimary marks the queries run with ctx as read-your-writes queries,
+// they are always sent to the primary
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+func usePrimary(ctx context.Context) bool {
+	val, _ := ctx.Value(primaryKey{}).(bool)
+	return val
+}
+
+// Wrap registers the read replicas of the database at address and returns
+// the driver name to open address with. Replicas lagging more than maxLagMS
+// behind the primary are skipped, 0 disables the lag check.
+func Wrap(name, driverName, address string, replicas []string, maxLagMS int) (string, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	// sql.Open doesn't connect, it only looks the driver up
+	db, err := sql.Open(driverName, "")
+	if err != nil {
+		return "", err
+	}
+	under := db.Driver()
+	db.Close()
+
+	wrapped := driverName + driverSuffix
+	if !isRegistered(wrapped) {
+		sql.Register(wrapped, &Driver{under: under})
+	}
+
+	if _, ok := clusters.Load(address); ok {
+		return wrapped, nil
+	}
+	c := &cluster{
+		driverName: driverName,
+		postgres:   isPostgres(under),
+		primary:    address,
+		maxLag:     time.Duration(maxLagMS) * time.Millisecond,
+	}
+	for i, addr := range replicas {
+		c.replicas = append(c.replicas, &replica{name: fmt.Sprintf("%s#%d", name, i), addr: addr})
+	}
+	clusters.Store(address, c)
+	c.startMonitor()
+	return wrapped, nil
+}
+
+// isPostgres reports whether d is the postgres driver, also when it is
+// wrapped by sqlhooks like postgres_hook
+func isPostgres(d driver.Driver) bool {
+	if hooked, ok := d.(*sqlhooks.Driver); ok {
+		d = hooked.Driver
+	}
+	_, ok := d.(*pq.Driver)
+	return ok
+}
+
+func isRegistered(name string) bool {
+	for _, d := range sql.Drivers() {
+		if d == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isRead reports whether a statement only reads and can be served by a replica
+func isRead(query string) bool {
+	q := strings.TrimLeft(query, " \t\r\n(")
+	if len(q) < 6 || !strings.EqualFold(q[:6], "select") {
+		return false
+	}
+	lower := strings.ToLower(q)
+	for _, marker := range []string{"nextval(", "setval(", "pg_advisory", " for update", " for share", " into "} {
+		if strings.Contains(lower, marker) {
+			return false
+		}
+	}
+	return true
+}
+
+type Driver struct {
+	under driver.Driver
+}
+
+// Open opens a connection to the primary, the connection to a replica is
+// opened on the first routed read. Addresses without replicas are opened
+// with the underlying driver directly.
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	primary, err := d.under.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	val, ok := clusters.Load(dsn)
+	if !ok {
+		return primary, nil
+	}
+	return &conn{cluster: val.(*cluster), under: d.under, primary: primary}, nil
+}
+
+type conn struct {
+	cluster     *cluster
+	under       driver.Driver
+	primary     driver.Conn
+	replica     *replica
+	replicaConn driver.Conn
+	inTx        bool
+}
+
+// route returns the connection a statement runs on
+func (c *conn) route(ctx context.Context, query string) driver.Conn {
+	if c.inTx || usePrimary(ctx) || !isRead(query) {
+		return c.primary
+	}
+	if c.replica != nil && c.replica.isHealthy() {
+		return c.replicaConn
+	}
+	c.closeReplica()
+	r := c.cluster.pick()
+	if r == nil {
+		return c.primary
+	}
+	replicaConn, err := c.under.Open(r.addr)
+	if err != nil {
+		r.setHealthy(false, err.Error())
+		return c.primary
+	}
+	c.replica = r
+	c.replicaConn = replicaConn
+	return replicaConn
+}
+
+// replicaFailed drops the replica connection after a failed read, the read
+// is retried on the primary
+func (c *conn) replicaFailed(err error) {
+	if err == driver.ErrBadConn && c.replica != nil {
+		c.replica.setHealthy(false, err.Error())
+	}
+	c.closeReplica()
+}
+
+func (c *conn) closeReplica() {
+	if c.replicaConn != nil {
+		c.replicaConn.Close()
+	}
+	c.replica = nil
+	c.replicaConn = nil
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if !isRead(query) {
+		return prepare(ctx, c.primary, query)
+	}
+	return &stmt{conn: c, query: query}, nil
+}
+
+func (c *conn) Close() error {
+	c.closeReplica()
+	return c.primary.Close()
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	var t driver.Tx
+	var err error
+	if beginner, ok := c.primary.(driver.ConnBeginTx); ok {
+		t, err = beginner.BeginTx(ctx, opts)
+	} else {
+		t, err = c.primary.Begin()
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.inTx = true
+	return &tx{conn: c, tx: t}, nil
+}
+
+func (c *conn) Ping(ctx context.Context) error {
+	if pinger, ok := c.primary.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := c.primary.(driver.ExecerContext); ok {
+		return execer.ExecContext(ctx, query, args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	target := c.route(ctx, query)
+	queryer, ok := target.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil && err != driver.ErrSkip && target != c.primary {
+		c.replicaFailed(err)
+		if queryer, ok = c.primary.(driver.QueryerContext); ok {
+			return queryer.QueryContext(ctx, query, args)
+		}
+		return nil, driver.ErrSkip
+	}
+	return rows, err
+}
+
+type tx struct {
+	conn *conn
+	tx   driver.Tx
+}
+
+func (t *tx) Commit() error {
+	t.conn.inTx = false
+	return t.tx.Commit()
+}
+
+func (t *tx) Rollback() error {
+	t.conn.inTx = false
+	return t.tx.Rollback()
+}
+
+// stmt is a read statement, it is prepared lazily on the connection the
+// statement is routed to on every query
+type stmt struct {
+	conn        *conn
+	query       string
+	primary     driver.Stmt
+	replica     driver.Stmt
+	replicaConn driver.Conn
+}
+
+func (s *stmt) on(ctx context.Context, target driver.Conn) (driver.Stmt, error) {
+	var err error
+	if target == s.conn.primary {
+		if s.primary == nil {
+			s.primary, err = prepare(ctx, target, s.query)
+		}
+		return s.primary, err
+	}
+	if s.replicaConn != target {
+		// statements of a closed replica connection are gone with it
+		s.replica, err = prepare(ctx, target, s.query)
+		if err != nil {
+			return nil, err
+		}
+		s.replicaConn = target
+	}
+	return s.replica, nil
+}
+
+func (s *stmt) Close() error {
+	if s.replica != nil && s.replicaConn == s.conn.replicaConn {
+		s.replica.Close()
+	}
+	if s.primary != nil {
+		return s.primary.Close()
+	}
+	return nil
+}
+
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), namedValues(args))
+}
+
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	st, err := s.on(ctx, s.conn.primary)
+	if err != nil {
+		return nil, err
+	}
+	return stmtExec(ctx, st, args)
+}
+
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	target := s.conn.route(ctx, s.query)
+	st, err := s.on(ctx, target)
+	if err == nil {
+		var rows driver.Rows
+		if rows, err = stmtQuery(ctx, st, args); err == nil || target == s.conn.primary {
+			return rows, err
+		}
+	} else if target == s.conn.primary {
+		return nil, err
+	}
+	s.conn.replicaFailed(err)
+	if st, err = s.on(ctx, s.conn.primary); err != nil {
+		return nil, err
+	}
+	return stmtQuery(ctx, st, args)
+}
+
+func prepare(ctx context.Context, c driver.Conn, query string) (driver.Stmt, error) {
+	if preparer, ok := c.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Prepare(query)
+}
+
+func stmtExec(ctx context.Context, s driver.Stmt, args []driver.NamedValue) (driver.Result, error) {
+	if execer, ok := s.(driver.StmtExecContext); ok {
+		return execer.ExecContext(ctx, args)
+	}
+	return s.Exec(values(args))
+}
+
+func stmtQuery(ctx context.Context, s driver.Stmt, args []driver.NamedValue) (driver.Rows, error) {
+	if queryer, ok := s.(driver.StmtQueryContext); ok {
+		return queryer.QueryContext(ctx, args)
+	}
+	return s.Query(values(args))
+}
+
+func namedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return named
+}
+
+func values(args []driver.NamedValue) []driver.Value {
+	vals := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vals[i] = arg.Value
+	}
+	return vals
+}
@@ -0,0 +1,146 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package replica
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/finogeeks/ligase/common/sqlite"
+	"github.com/gchaincl/sqlhooks"
+	"github.com/lib/pq"
+)
+
+func openFile(t *testing.T, dsn, value string) {
+	db, err := sql.Open(sqlite.DriverName, dsn)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	if _, err = db.Exec("CREATE TABLE IF NOT EXISTS test (value TEXT)"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err = db.Exec("INSERT INTO test (value) VALUES ($1)", value); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+}
+
+func TestRouting(t *testing.T) {
+	dir, err := ioutil.TempDir("", "replica")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	primary := "file:" + filepath.Join(dir, "primary.db")
+	replicaAddr := "file:" + filepath.Join(dir, "replica.db")
+	openFile(t, primary, "primary")
+	openFile(t, replicaAddr, "replica")
+	CheckInterval = time.Hour
+
+	driverName, err := Wrap("test", sqlite.DriverName, primary, []string{replicaAddr}, 0)
+	if err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+	val, _ := clusters.Load(primary)
+
+	db, err := sql.Open(driverName, primary)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	stmt, err := db.Prepare("SELECT value FROM test LIMIT 1")
+	if err != nil {
+		t.Fatalf("prepare: %v", err)
+	}
+
+	var value string
+	if err = stmt.QueryRow().Scan(&value); err != nil || value != "replica" {
+		t.Fatalf("read routed to %s %v", value, err)
+	}
+	if err = stmt.QueryRowContext(WithPrimary(context.Background())).Scan(&value); err != nil || value != "primary" {
+		t.Fatalf("read your writes routed to %s %v", value, err)
+	}
+	txn, _ := db.Begin()
+	if err = txn.Stmt(stmt).QueryRow().Scan(&value); err != nil || value != "primary" {
+		t.Fatalf("transaction routed to %s %v", value, err)
+	}
+	txn.Rollback()
+
+	if _, err = db.Exec("UPDATE test SET value = $1", "written"); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err = db.QueryRowContext(WithPrimary(context.Background()), "SELECT value FROM test").Scan(&value); err != nil || value != "written" {
+		t.Fatalf("write routed to replica: %s %v", value, err)
+	}
+
+	// reads fall back to the primary while the replica is out of service
+	val.(*cluster).replicas[0].setHealthy(false, "test")
+	if err = stmt.QueryRow().Scan(&value); err != nil || value != "written" {
+		t.Fatalf("fallback routed to %s %v", value, err)
+	}
+}
+
+func TestIsRead(t *testing.T) {
+	for query, expect := range map[string]bool{
+		"SELECT a FROM t WHERE b = $1":         true,
+		"\n\t select a from t":                 true,
+		"(SELECT a FROM t) UNION (SELECT 1)":   true,
+		"SELECT nextval('seq')":                false,
+		"SELECT a FROM t FOR UPDATE":           false,
+		"INSERT INTO t (a) VALUES ($1)":        false,
+		"WITH x AS (DELETE FROM t) SELECT 1":   false,
+		"SELECT pg_advisory_lock($1)":          false,
+		"UPDATE t SET a = 1 RETURNING a":       false,
+		"SELECT a INTO t2 FROM t":              false,
+		"SELECT count(1) FROM account_devices": true,
+	} {
+		if isRead(query) != expect {
+			t.Fatalf("isRead(%q) != %v", query, expect)
+		}
+	}
+}
+
+type nopHooks struct{}
+
+func (nopHooks) Before(ctx context.Context, query string, args ...interface{}) (context.Context, error) {
+	return ctx, nil
+}
+
+func (nopHooks) After(ctx context.Context, query string, args ...interface{}) (context.Context, error) {
+	return ctx, nil
+}
+
+func TestIsPostgres(t *testing.T) {
+	if !isPostgres(&pq.Driver{}) {
+		t.Fatalf("want postgres driver detected")
+	}
+	if !isPostgres(sqlhooks.Wrap(&pq.Driver{}, nopHooks{})) {
+		t.Fatalf("want hooked postgres driver detected")
+	}
+	db, err := sql.Open(sqlite.DriverName, "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	if isPostgres(db.Driver()) {
+		t.Fatalf("want sqlite driver not detected as postgres")
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"github.com/finogeeks/ligase/common/config"
+)
+
+// RetentionMaxLifetime returns how many milliseconds the events of a room
+// with the retention policy content are kept, 0 when they never expire
+func RetentionMaxLifetime(cfg *config.Dendrite, content *RetentionContent) int64 {
+	if !cfg.Retention.Enable {
+		return 0
+	}
+	lifetime := cfg.Retention.DefaultMaxLifetime
+	if content != nil && content.MaxLifetime != nil && *content.MaxLifetime > 0 {
+		lifetime = *content.MaxLifetime
+		// a room may not ask to keep its events shorter than its own min_lifetime
+		if content.MinLifetime != nil && *content.MinLifetime > lifetime {
+			lifetime = *content.MinLifetime
+		}
+	}
+	if lifetime <= 0 {
+		return 0
+	}
+	if cfg.Retention.MinLifetime > 0 && lifetime < cfg.Retention.MinLifetime {
+		lifetime = cfg.Retention.MinLifetime
+	}
+	if cfg.Retention.MaxLifetime > 0 && lifetime > cfg.Retention.MaxLifetime {
+		lifetime = cfg.Retention.MaxLifetime
+	}
+	return lifetime
+}
+
+// RoomVisibilityTime returns how many seconds the non state events of a room
+// stay visible to clients, 0 when they never expire. It is the shorter of
+// im.setting.messageVisibilityTime and the retention lifetime of the room.
+func RoomVisibilityTime(cfg *config.Dendrite, settings *Settings, content *RetentionContent) int64 {
+	visibilityTime := settings.GetMessageVisilibityTime()
+	lifetime := (RetentionMaxLifetime(cfg, content) + 999) / 1000
+	if lifetime > 0 && (visibilityTime <= 0 || lifetime < visibilityTime) {
+		visibilityTime = lifetime
+	}
+	return visibilityTime
+}
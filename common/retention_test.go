@@ -0,0 +1,67 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package common
+
+import (
+	"testing"
+
+	"github.com/finogeeks/ligase/common/config"
+)
+
+func retentionContent(min, max int64) *RetentionContent {
+	content := &RetentionContent{}
+	if min > 0 {
+		content.MinLifetime = &min
+	}
+	if max > 0 {
+		content.MaxLifetime = &max
+	}
+	return content
+}
+
+func TestRetentionMaxLifetime(t *testing.T) {
+	cfg := &config.Dendrite{}
+	cfg.Retention.Enable = true
+	cfg.Retention.DefaultMaxLifetime = 5000
+	cfg.Retention.MinLifetime = 1000
+	cfg.Retention.MaxLifetime = 10000
+
+	for _, tc := range []struct {
+		name    string
+		content *RetentionContent
+		want    int64
+	}{
+		{"no retention event", nil, 5000},
+		{"no max_lifetime", retentionContent(0, 0), 5000},
+		{"room max_lifetime", retentionContent(0, 3000), 3000},
+		{"below server min", retentionContent(0, 500), 1000},
+		{"above server max", retentionContent(0, 20000), 10000},
+		{"room min above room max", retentionContent(4000, 2000), 4000},
+		{"room min above server max", retentionContent(30000, 2000), 10000},
+	} {
+		if got := RetentionMaxLifetime(cfg, tc.content); got != tc.want {
+			t.Errorf("%s: lifetime %d, want %d", tc.name, got, tc.want)
+		}
+	}
+
+	cfg.Retention.DefaultMaxLifetime = 0
+	if got := RetentionMaxLifetime(cfg, nil); got != 0 {
+		t.Errorf("rooms without retention should not expire without a default, got %d", got)
+	}
+	cfg.Retention.Enable = false
+	if got := RetentionMaxLifetime(cfg, retentionContent(0, 3000)); got != 0 {
+		t.Errorf("retention disabled, got %d", got)
+	}
+}
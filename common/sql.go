@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/finogeeks/ligase/common/sqlite"
 	log "github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/lib/pq"
 )
@@ -79,9 +80,10 @@ func TxStmt(transaction *sql.Tx, statement *sql.Stmt) *sql.Stmt {
 }
 
 // IsUniqueConstraintViolationErr returns true if the error is a postgresql unique_violation error
+// or the equivalent sqlite constraint error
 func IsUniqueConstraintViolationErr(err error) bool {
 	pqErr, ok := err.(*pq.Error)
-	return ok && pqErr.Code == "23505"
+	return ok && pqErr.Code == "23505" || sqlite.IsUniqueConstraintViolationErr(err)
 }
 
 // Hooks satisfies the sqlhook.Hooks interface
@@ -106,6 +108,11 @@ func (h *Hooks) After(ctx context.Context, query string, args ...interface{}) (c
 }
 
 func CreateDatabase(driver, addr, name string) error {
+	if sqlite.IsDriver(driver) {
+		// the sqlite database file is created on open
+		return nil
+	}
+
 	db, err := sql.Open(driver, addr)
 	if err != nil {
 		return err
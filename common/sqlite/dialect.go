@@ -0,0 +1,228 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	createSequenceRegex = regexp.MustCompile(`(?i)CREATE\s+SEQUENCE\s+IF\s+NOT\s+EXISTS\s+(\w+)(?:\s+START\s+(\d+))?\s*;?`)
+	nextvalRegex        = regexp.MustCompile(`(?i)SELECT\s+nextval\s*\(\s*'(\w+)'\s*\)`)
+	serialRegex         = regexp.MustCompile(`(?i)\b(?:BIG)?SERIAL\b`)
+	castRegex           = regexp.MustCompile(`::\w+(?:\[\])?`)
+	arrayTypeRegex      = regexp.MustCompile(`\b(\w+)\[\]`)
+	anyRegex            = regexp.MustCompile(`(?i)=\s*ANY\s*\(\s*(\?\d+|'[^']*')\s*\)`)
+	anySelectRegex      = regexp.MustCompile(`(?i)=\s*ANY\s*\(\s*SELECT\b`)
+	onConstraintRegex   = regexp.MustCompile(`(?i)ON\s+CONFLICT\s+ON\s+CONSTRAINT\s+(\w+)`)
+	tableConstraint     = regexp.MustCompile(`(?i)CONSTRAINT\s+(\w+)\s+(?:PRIMARY\s+KEY|UNIQUE)\s*\(([^)]*)\)`)
+	columnConstraint    = regexp.MustCompile(`(?i)(\w+)\s+\w+(?:\s+NOT\s+NULL)?\s+CONSTRAINT\s+(\w+)\s+(?:PRIMARY\s+KEY|UNIQUE)`)
+	schemaRegex         = regexp.MustCompile(`\bpublic\.`)
+	indexNullsRegex     = regexp.MustCompile(`(?is)(CREATE\s+(?:UNIQUE\s+)?INDEX[^;]*?)\s+NULLS\s+(?:FIRST|LAST)`)
+	offsetLimitRegex    = regexp.MustCompile(`(?i)OFFSET\s+(\?\d+)\s+LIMIT\s+(\?\d+)`)
+	limitRegex          = regexp.MustCompile(`(?i)\bLIMIT\b`)
+	offsetRegex         = regexp.MustCompile(`(?i)\bOFFSET\b`)
+	copyInRegex         = regexp.MustCompile(`^COPY\s+((?:"[^"]+"\.)?"[^"]+")\s+\(([^)]*)\)\s+FROM\s+STDIN$`)
+	usingGinRegex       = regexp.MustCompile(`(?i)\s+USING\s+GIN\b`)
+	tsMatchRegex        = regexp.MustCompile(`(?i)(\w+)\s*@@\s*(plainto_tsquery\s*\([^)]*\))`)
+)
+
+// constraints remembers the columns of every named unique constraint seen in
+// a schema, sqlite only accepts column lists as upsert conflict targets.
+var constraints sync.Map
+
+// translate rewrites a postgres statement into the sqlite dialect.
+func translate(query string) (string, error) {
+	query = rewriteTokens(query)
+
+	for _, m := range tableConstraint.FindAllStringSubmatch(query, -1) {
+		constraints.Store(m[1], strings.TrimSpace(m[2]))
+	}
+	for _, m := range columnConstraint.FindAllStringSubmatch(query, -1) {
+		if _, ok := constraints.Load(m[2]); !ok {
+			constraints.Store(m[2], m[1])
+		}
+	}
+
+	query = createSequenceRegex.ReplaceAllStringFunc(query, func(s string) string {
+		m := createSequenceRegex.FindStringSubmatch(s)
+		start := int64(1)
+		if m[2] != "" {
+			start, _ = strconv.ParseInt(m[2], 10, 64)
+		}
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (value BIGINT NOT NULL);"+
+			" INSERT INTO %s (value) SELECT %d WHERE NOT EXISTS (SELECT 1 FROM %s);", m[1], m[1], start-1, m[1])
+	})
+	query = nextvalRegex.ReplaceAllString(query, "UPDATE $1 SET value = value + 1 RETURNING value")
+	query = serialRegex.ReplaceAllString(query, "INTEGER")
+	query = castRegex.ReplaceAllString(query, "")
+	query = arrayTypeRegex.ReplaceAllString(query, "$1")
+	query = anyRegex.ReplaceAllString(query, " IN (SELECT value FROM json_each(pg_array($1)))")
+	query = anySelectRegex.ReplaceAllString(query, " IN (SELECT")
+	query = schemaRegex.ReplaceAllString(query, "")
+	query = usingGinRegex.ReplaceAllString(query, "")
+	query = tsMatchRegex.ReplaceAllString(query, "ts_match($1, $2)")
+	for indexNullsRegex.MatchString(query) {
+		query = indexNullsRegex.ReplaceAllString(query, "$1")
+	}
+	// sqlite wants LIMIT before OFFSET and no OFFSET without LIMIT
+	query = offsetLimitRegex.ReplaceAllString(query, "LIMIT $2 OFFSET $1")
+	if !limitRegex.MatchString(query) {
+		query = offsetRegex.ReplaceAllString(query, "LIMIT -1 OFFSET")
+	}
+
+	var err error
+	query = onConstraintRegex.ReplaceAllStringFunc(query, func(s string) string {
+		name := onConstraintRegex.FindStringSubmatch(s)[1]
+		cols, ok := constraints.Load(name)
+		if !ok {
+			err = fmt.Errorf("sqlite: unknown constraint %s, the table schema must be created first", name)
+			return s
+		}
+		return "ON CONFLICT (" + cols.(string) + ")"
+	})
+	return query, err
+}
+
+// rewriteTokens drops comments and turns postgres $N placeholders into
+// sqlite ?N ones, string literals and quoted identifiers are left alone.
+func rewriteTokens(query string) string {
+	var sb strings.Builder
+	sb.Grow(len(query))
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				sb.WriteString(query[i:])
+				return sb.String()
+			}
+			sb.WriteString(query[i : i+end+2])
+			i += end + 1
+		case c == '-' && i+1 < len(query) && query[i+1] == '-':
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return sb.String()
+			}
+			i += end - 1
+		case c == '$' && i+1 < len(query) && query[i+1] >= '0' && query[i+1] <= '9':
+			sb.WriteByte('?')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// parseCopyIn parses the statement built by pq.CopyIn, it returns the
+// insert statement used for every row instead.
+func parseCopyIn(query string) (table string, columns []string, ok bool) {
+	m := copyInRegex.FindStringSubmatch(strings.TrimSpace(query))
+	if m == nil {
+		return "", nil, false
+	}
+	for _, col := range strings.Split(m[2], ",") {
+		columns = append(columns, strings.TrimSpace(col))
+	}
+	return m[1], columns, true
+}
+
+func copyInInsert(table string, columns []string, n int) string {
+	params := make([]string, n)
+	for i := range params {
+		params[i] = "?" + strconv.Itoa(i+1)
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", table, strings.Join(columns, ", "), strings.Join(params, ", "))
+}
+
+// pgArray converts a postgres array literal, as produced by pq.Array, to a
+// json array so that it can be expanded with json_each.
+func pgArray(literal string) (string, error) {
+	elems, err := parsePgArray(literal)
+	if err != nil {
+		return "", err
+	}
+	bytes, err := json.Marshal(elems)
+	return string(bytes), err
+}
+
+// arrayToString implements the postgres function of the same name, NULL
+// elements are skipped.
+func arrayToString(literal, sep string) (string, error) {
+	elems, err := parsePgArray(literal)
+	if err != nil {
+		return "", err
+	}
+	strs := make([]string, 0, len(elems))
+	for _, elem := range elems {
+		if elem != nil {
+			strs = append(strs, fmt.Sprint(elem))
+		}
+	}
+	return strings.Join(strs, sep), nil
+}
+
+func parsePgArray(literal string) ([]interface{}, error) {
+	literal = strings.TrimSpace(literal)
+	if len(literal) < 2 || literal[0] != '{' || literal[len(literal)-1] != '}' {
+		return nil, fmt.Errorf("sqlite: invalid array literal %q", literal)
+	}
+	body := literal[1 : len(literal)-1]
+	elems := []interface{}{}
+	for i := 0; i < len(body); {
+		for i < len(body) && body[i] == ' ' {
+			i++
+		}
+		if i < len(body) && body[i] == '"' {
+			var sb strings.Builder
+			i++
+			for i < len(body) && body[i] != '"' {
+				if body[i] == '\\' && i+1 < len(body) {
+					i++
+				}
+				sb.WriteByte(body[i])
+				i++
+			}
+			elems = append(elems, sb.String())
+			i++
+		} else {
+			end := strings.IndexByte(body[i:], ',')
+			if end < 0 {
+				end = len(body) - i
+			}
+			elem := strings.TrimSpace(body[i : i+end])
+			if strings.EqualFold(elem, "NULL") {
+				elems = append(elems, nil)
+			} else if v, err := strconv.ParseInt(elem, 10, 64); err == nil {
+				elems = append(elems, v)
+			} else {
+				elems = append(elems, elem)
+			}
+			i += end
+		}
+		for i < len(body) && body[i] != ',' {
+			i++
+		}
+		// skip the delimiter
+		i++
+	}
+	return elems, nil
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package sqlite registers the "sqlite" database/sql driver. It wraps
+// go-sqlite3 and translates the postgres statements used by the storage
+// implementations, so every database can be switched to sqlite with the
+// driver field of its DataBaseConf.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+const DriverName = "sqlite"
+
+func init() {
+	sql.Register(DriverName, &Driver{sqlite3.SQLiteDriver{ConnectHook: connectHook}})
+}
+
+func connectHook(c *sqlite3.SQLiteConn) error {
+	if err := c.RegisterFunc("pg_array", pgArray, true); err != nil {
+		return err
+	}
+	if err := c.RegisterFunc("array_to_string", arrayToString, true); err != nil {
+		return err
+	}
+	if err := c.RegisterFunc("to_tsvector", toTsvector, true); err != nil {
+		return err
+	}
+	if err := c.RegisterFunc("plainto_tsquery", plainToTsquery, true); err != nil {
+		return err
+	}
+	if err := c.RegisterFunc("ts_match", tsMatch, true); err != nil {
+		return err
+	}
+	return c.RegisterFunc("ts_rank", tsRank, true)
+}
+
+// IsDriver reports whether the configured driver is served by this package.
+func IsDriver(name string) bool {
+	return name == DriverName
+}
+
+// IsUniqueConstraintViolationErr returns true if the error is a sqlite unique or primary key violation
+func IsUniqueConstraintViolationErr(err error) bool {
+	sqliteErr, ok := err.(sqlite3.Error)
+	return ok && (sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey)
+}
+
+type Driver struct {
+	sqlite3.SQLiteDriver
+}
+
+// Open opens a sqlite database, dsn is a go-sqlite3 data source name such as
+// "file:/var/lib/ligase/device.db?_journal_mode=WAL&_busy_timeout=5000".
+func (d *Driver) Open(dsn string) (driver.Conn, error) {
+	c, err := d.SQLiteDriver.Open(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{c.(*sqlite3.SQLiteConn)}, nil
+}
+
+type conn struct {
+	*sqlite3.SQLiteConn
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if table, columns, ok := parseCopyIn(query); ok {
+		return &copyStmt{conn: c, table: table, columns: columns}, nil
+	}
+	query, err := translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.PrepareContext(ctx, query)
+}
+
+func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query, err := translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.ExecContext(ctx, query, args)
+}
+
+func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, err := translate(query)
+	if err != nil {
+		return nil, err
+	}
+	return c.SQLiteConn.QueryContext(ctx, query, args)
+}
+
+// copyStmt emulates the bulk insert of pq.CopyIn: every Exec with arguments
+// inserts one row and the final Exec without arguments is a no-op.
+type copyStmt struct {
+	conn    *conn
+	table   string
+	columns []string
+}
+
+func (s *copyStmt) Close() error {
+	return nil
+}
+
+func (s *copyStmt) NumInput() int {
+	return -1
+}
+
+func (s *copyStmt) Exec(args []driver.Value) (driver.Result, error) {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+	return s.ExecContext(context.Background(), named)
+}
+
+func (s *copyStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	if len(args) == 0 {
+		return driver.RowsAffected(0), nil
+	}
+	if len(args) != len(s.columns) {
+		return nil, errors.New("sqlite: copy row does not match the columns")
+	}
+	return s.conn.SQLiteConn.ExecContext(ctx, copyInInsert(s.table, s.columns, len(args)), args)
+}
+
+func (s *copyStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return nil, errors.New("sqlite: copy statement can not be queried")
+}
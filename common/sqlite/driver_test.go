@@ -0,0 +1,219 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sqlite
+
+import (
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lib/pq"
+)
+
+const testSchema = `
+-- sequence shared by the test tables
+CREATE SEQUENCE IF NOT EXISTS test_seq START 10;
+
+CREATE TABLE IF NOT EXISTS public.test_rooms (
+	room_nid BIGSERIAL NOT NULL PRIMARY KEY,
+	room_id TEXT NOT NULL CONSTRAINT test_room_id_unique UNIQUE,
+	latest_event_nids BIGINT[] DEFAULT '{}'::BIGINT[],
+	version BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS test_members (
+	room_id TEXT NOT NULL,
+	user_id TEXT NOT NULL,
+	membership TEXT NOT NULL,
+	CONSTRAINT test_members_unique UNIQUE (room_id, user_id)
+);
+CREATE INDEX IF NOT EXISTS test_members_user_idx ON test_members(user_id DESC NULLS LAST);
+`
+
+func openTestDB(t *testing.T) (*sql.DB, func()) {
+	dir, err := ioutil.TempDir("", "sqlite")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	db, err := sql.Open(DriverName, "file:"+filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err = db.Exec(testSchema); err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	query, err := translate("UPDATE test SET a = $2, b = '$1' WHERE id = $1 OFFSET $3 -- $4")
+	if err != nil {
+		t.Fatalf("translate: %v", err)
+	}
+	if query != "UPDATE test SET a = ?2, b = '$1' WHERE id = ?1 LIMIT -1 OFFSET ?3 " {
+		t.Fatalf("unexpected query %q", query)
+	}
+	if _, err = translate("INSERT INTO t (a) VALUES ($1) ON CONFLICT ON CONSTRAINT missing_unique DO NOTHING"); err == nil {
+		t.Fatalf("expected unknown constraint error")
+	}
+}
+
+func TestPgArray(t *testing.T) {
+	for literal, expect := range map[string]string{
+		`{}`:                  `[]`,
+		`{1,2,3}`:             `[1,2,3]`,
+		`{"a", "b,c","d\"e"}`: `["a","b,c","d\"e"]`,
+		`{x,NULL}`:            `["x",null]`,
+	} {
+		got, err := pgArray(literal)
+		if err != nil || got != expect {
+			t.Fatalf("pgArray(%s) = %s, %v", literal, got, err)
+		}
+	}
+}
+
+func TestPostgresStatements(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+
+	upsert := "INSERT INTO test_members (room_id, user_id, membership) VALUES ($1, $2, $3)" +
+		" ON CONFLICT ON CONSTRAINT test_members_unique DO UPDATE SET membership = EXCLUDED.membership"
+	for _, membership := range []string{"invite", "join"} {
+		if _, err := db.Exec(upsert, "!r", "@a", membership); err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+	if _, err := db.Exec(upsert, "!r", "@b", "leave"); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	rows, err := db.Query("SELECT user_id FROM test_members WHERE membership = ANY($1) ORDER BY user_id", pq.StringArray([]string{"join", "invite"}))
+	if err != nil {
+		t.Fatalf("select any: %v", err)
+	}
+	var users []string
+	for rows.Next() {
+		var user string
+		rows.Scan(&user)
+		users = append(users, user)
+	}
+	rows.Close()
+	if len(users) != 1 || users[0] != "@a" {
+		t.Fatalf("unexpected users %v", users)
+	}
+
+	var roomNID int64
+	err = db.QueryRow("INSERT INTO test_rooms (room_id, latest_event_nids) VALUES ($1, $2) RETURNING room_nid", "!r", pq.Int64Array([]int64{3, 4})).Scan(&roomNID)
+	if err != nil || roomNID != 1 {
+		t.Fatalf("insert returning: %d %v", roomNID, err)
+	}
+	if _, err = db.Exec("INSERT INTO test_rooms (room_id) VALUES ($1) ON CONFLICT ON CONSTRAINT test_room_id_unique DO NOTHING", "!r"); err != nil {
+		t.Fatalf("insert conflict: %v", err)
+	}
+	var nids pq.Int64Array
+	if err = db.QueryRow("SELECT latest_event_nids FROM test_rooms WHERE room_nid = $1", roomNID).Scan(&nids); err != nil {
+		t.Fatalf("select array: %v", err)
+	}
+	if len(nids) != 2 || nids[0] != 3 || nids[1] != 4 {
+		t.Fatalf("unexpected nids %v", nids)
+	}
+
+	for _, expect := range []int64{10, 11} {
+		var seq int64
+		if err = db.QueryRow("SELECT nextval('test_seq')").Scan(&seq); err != nil || seq != expect {
+			t.Fatalf("nextval: %d %v", seq, err)
+		}
+	}
+}
+
+func TestCopyIn(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+
+	txn, err := db.Begin()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	stmt, err := txn.Prepare(pq.CopyIn("test_members", "room_id", "user_id", "membership"))
+	if err != nil {
+		t.Fatalf("prepare copy: %v", err)
+	}
+	for _, user := range []string{"@a", "@b", "@c"} {
+		if _, err = stmt.Exec("!r", user, "join"); err != nil {
+			t.Fatalf("copy row: %v", err)
+		}
+	}
+	if _, err = stmt.Exec(); err != nil {
+		t.Fatalf("copy flush: %v", err)
+	}
+	stmt.Close()
+	if err = txn.Commit(); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+
+	var count int
+	db.QueryRow("SELECT count(1) FROM test_members").Scan(&count)
+	if count != 3 {
+		t.Fatalf("unexpected count %d", count)
+	}
+
+	_, err = db.Exec("INSERT INTO test_members (room_id, user_id, membership) VALUES ($1, $2, $3)", "!r", "@a", "join")
+	if !IsUniqueConstraintViolationErr(err) {
+		t.Fatalf("expected unique violation, got %v", err)
+	}
+}
+
+func TestTextSearch(t *testing.T) {
+	db, cleanup := openTestDB(t)
+	defer cleanup()
+
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS test_search (id BIGINT PRIMARY KEY, room_id TEXT NOT NULL, vector TSVECTOR NOT NULL);
+CREATE INDEX IF NOT EXISTS test_search_vector_idx ON test_search USING GIN (vector);`)
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	for id, body := range []string{"Hello World", "hello there", "goodbye world"} {
+		if _, err = db.Exec("INSERT INTO test_search (id, room_id, vector) VALUES ($1, $2, to_tsvector('simple', $3))", id, "!r", body); err != nil {
+			t.Fatalf("insert: %v", err)
+		}
+	}
+
+	rows, err := db.Query("SELECT id, ts_rank(vector, plainto_tsquery('simple', $1)) AS rank FROM test_search"+
+		" WHERE vector @@ plainto_tsquery('simple', $1) AND room_id = ANY($2) ORDER BY rank DESC, id DESC", "hello", pq.StringArray{"!r"})
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		var rank float64
+		if err = rows.Scan(&id, &rank); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		if rank <= 0 {
+			t.Fatalf("unexpected rank %f of %d", rank, id)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 0 {
+		t.Fatalf("unexpected matches %v", ids)
+	}
+}
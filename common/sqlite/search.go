@@ -0,0 +1,69 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package sqlite
+
+import (
+	"strings"
+)
+
+// The functions below emulate the part of the postgres text search used by
+// the search index. The callers tokenize documents and queries themselves,
+// so a tsvector and a tsquery are both lower cased space separated tokens,
+// the text search configuration argument is ignored.
+
+func toTsvector(config, text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
+
+func plainToTsquery(config, text string) string {
+	return toTsvector(config, text)
+}
+
+// tsMatch implements vector @@ query, every token of the query must be found
+func tsMatch(vector, query string) bool {
+	tokens := strings.Fields(query)
+	if len(tokens) == 0 {
+		return false
+	}
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(vector) {
+		words[w] = true
+	}
+	for _, t := range tokens {
+		if !words[t] {
+			return false
+		}
+	}
+	return true
+}
+
+// tsRank ranks a document by the share of its tokens matching the query
+func tsRank(vector, query string) float64 {
+	words := strings.Fields(vector)
+	if len(words) == 0 {
+		return 0
+	}
+	tokens := make(map[string]bool)
+	for _, t := range strings.Fields(query) {
+		tokens[t] = true
+	}
+	hits := 0
+	for _, w := range words {
+		if tokens[w] {
+			hits++
+		}
+	}
+	return float64(hits) / float64(len(words))
+}
@@ -113,26 +113,35 @@ func ExportMetricsBeforeSending(span opentracing.Span, metricName string, protoc
 	}
 }
 
+// HeaderMsg is implemented by transport messages which carry their headers
+// as a plain map, such as the in-process memory channel messages.
+type HeaderMsg interface {
+	GetHeaders() map[string]string
+}
+
+func extractSpanFromHeader(value []byte) (opentracing.SpanContext, error) {
+	var carrier opentracing.HTTPHeadersCarrier
+	err := json.Unmarshal(value, &carrier)
+	if err != nil {
+		return nil, err
+	}
+	tracer := opentracing.GlobalTracer()
+	// extract span from header
+	return tracer.Extract(opentracing.HTTPHeaders, carrier)
+}
+
 func extractSpanFromMsg(msg interface{}) (opentracing.SpanContext, error) {
 	switch e := msg.(type) {
 	case *kafka.Message:
 		for _, header := range e.Headers {
 			if header.Key == SpanKey {
-				var carrier opentracing.HTTPHeadersCarrier
-				err := json.Unmarshal(header.Value, &carrier)
-				if err != nil {
-					return nil, err
-				}
-				tracer := opentracing.GlobalTracer()
-				// extract span from header
-				clientContext, err := tracer.Extract(opentracing.HTTPHeaders, carrier)
-				if err != nil {
-					return nil, err
-				} else {
-					return clientContext, nil
-				}
+				return extractSpanFromHeader(header.Value)
 			}
 		}
+	case HeaderMsg:
+		if value, ok := e.GetHeaders()[SpanKey]; ok {
+			return extractSpanFromHeader([]byte(value))
+		}
 	}
 	return nil, errors.New("no span")
 }
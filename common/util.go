@@ -57,6 +57,15 @@ func CalcStringHashCode64(str string) uint64 {
 func BuildToken(key, id, loc, userId, deviceIdentifier string,
 	guest bool, deviceID, deviceType string,
 	human bool,
+) (string, error) {
+	return BuildExpiringToken(key, id, loc, userId, deviceIdentifier, guest, deviceID, deviceType, human, 0)
+}
+
+// BuildExpiringToken builds an access token which is rejected after
+// expireTs in ms, the token never expires when expireTs is 0
+func BuildExpiringToken(key, id, loc, userId, deviceIdentifier string,
+	guest bool, deviceID, deviceType string,
+	human bool, expireTs int64,
 ) (string, error) {
 	mac, err := macaroon.New([]byte(key), []byte(id), loc, macaroon.V1)
 	if err != nil {
@@ -85,6 +94,9 @@ func BuildToken(key, id, loc, userId, deviceIdentifier string,
 	if guest == true {
 		mac.AddFirstPartyCaveat([]byte("guest = true"))
 	}
+	if expireTs > 0 {
+		mac.AddFirstPartyCaveat([]byte("expires = " + strconv.FormatInt(expireTs, 10)))
+	}
 	bytes, err := mac.MarshalBinary()
 	res := base64.RawURLEncoding.EncodeToString(bytes)
 	// log.Infof("BuildToken token:%s sig:%s\n", res, base64.RawURLEncoding.EncodeToString(mac.Signature()))
@@ -170,6 +182,8 @@ func ExtractToken(key, id, loc, token string) (*authtypes.Device, bool, error) {
 		} else if res[0] == "guest" {
 			guest = true
 			dev.IsHuman = true
+		} else if res[0] == "expires" {
+			dev.ExpireTs, _ = strconv.ParseInt(res[2], 10, 64)
 		}
 	}
 	return &dev, guest, nil
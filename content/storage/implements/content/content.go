@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 )
 
@@ -50,6 +51,12 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	if result.db, err = sql.Open(driver, address); err != nil {
 		return nil, err
 	}
+	if _, err = result.db.Exec(result.mediaDownloadStatements.getSchema()); err != nil {
+		return nil, err
+	}
+	if err = migration.Apply("content", driver, createAddr, result.db); err != nil {
+		return nil, err
+	}
 	if err = result.prepare(); err != nil {
 		return nil, err
 	}
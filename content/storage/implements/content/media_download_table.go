@@ -45,11 +45,11 @@ type mediaDownloadStatements struct {
 	selectMediaDownloadStmt *sql.Stmt
 }
 
+func (s *mediaDownloadStatements) getSchema() string {
+	return mediaDownloadSchema
+}
+
 func (s *mediaDownloadStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(mediaDownloadSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertMediaDownloadStmt, err = db.Prepare(insertMediaDownloadSQL); err != nil {
 		return
 	}
@@ -24,6 +24,7 @@ import (
 type IChannel interface {
 	Init(logProf bool)
 	SetTopic(topic string)
+	GetTopic() string
 	SetGroup(group string)
 	SetID(id string)
 	GetID() string
@@ -47,6 +48,23 @@ type IChannelConsumer interface {
 	OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{})
 }
 
+// IChannelErrConsumer is implemented by consumers which report the failure of
+// a message, channels call OnMessageErr instead of OnMessage and retry the
+// failed message according to their retry policy
+type IChannelErrConsumer interface {
+	IChannelConsumer
+	OnMessageErr(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) error
+}
+
+// IChannelAsyncConsumer is implemented by consumers which process messages in
+// their own workers, channels call OnMessageAsync and the consumer calls done
+// once the message is processed. A failure is retried and parked like the one
+// of an IChannelErrConsumer without holding up the following messages.
+type IChannelAsyncConsumer interface {
+	IChannelConsumer
+	OnMessageAsync(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}, done func(error))
+}
+
 const CHANNEL_PUB = 0
 const CHANNEL_SUB = 1
 
@@ -17,3 +17,9 @@ package core
 type IConfig interface {
 	GetDBConfig(name string) (driver string, createAddr string, addr string, persistUnderlying string, persistName string, async bool)
 }
+
+// IReplicaConfig is implemented by configs which have read replicas for
+// their databases
+type IReplicaConfig interface {
+	GetDBReplicaConfig(name string) (replicas []string, maxLagMS int)
+}
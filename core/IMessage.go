@@ -14,6 +14,15 @@
 
 package core
 
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
 type InputMsg interface {
 	InitFromBytes([]byte)
 	GetMsgType() int32
@@ -28,4 +37,117 @@ const (
 	FORMAT_JSON = int8(0)
 	FORMAT_GOB  = int8(1)
 	FORMAT_CAPN = int8(2)
+	// capn and protobuf messages are encoded through Coder and ProtoMessage
+	FORMAT_PROTOBUF = int8(3)
 )
+
+// ProtoMessage is implemented by protobuf generated messages
+type ProtoMessage interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// FormatConf is implemented by producer and consumer configs which choose
+// the wire format of their topic
+type FormatConf interface {
+	GetFormat() string
+}
+
+var topicFormats sync.Map
+
+func ParseFormat(name string) (int8, error) {
+	switch strings.ToLower(name) {
+	case "", "json":
+		return FORMAT_JSON, nil
+	case "gob":
+		return FORMAT_GOB, nil
+	case "capn", "capnp":
+		return FORMAT_CAPN, nil
+	case "protobuf", "proto":
+		return FORMAT_PROTOBUF, nil
+	default:
+		return FORMAT_JSON, errors.New("unknown format " + name)
+	}
+}
+
+// SetTopicFormat sets the wire format of the messages of a topic, producers
+// and consumers of the topic must agree on it
+func SetTopicFormat(topic string, format int8) {
+	topicFormats.Store(topic, format)
+}
+
+// SetTopicFormatConf sets the wire format of a topic from the producer or
+// consumer config of the topic, the topic keeps json when none is set
+func SetTopicFormatConf(topic string, conf interface{}) error {
+	formatConf, ok := conf.(FormatConf)
+	if !ok || formatConf.GetFormat() == "" {
+		return nil
+	}
+	format, err := ParseFormat(formatConf.GetFormat())
+	if err != nil {
+		return err
+	}
+	SetTopicFormat(topic, format)
+	return nil
+}
+
+// GetTopicFormat returns the wire format of a topic, json by default
+func GetTopicFormat(topic string) int8 {
+	if val, ok := topicFormats.Load(topic); ok {
+		return val.(int8)
+	}
+	return FORMAT_JSON
+}
+
+func EncodeMsg(format int8, obj interface{}) ([]byte, error) {
+	switch format {
+	case FORMAT_JSON:
+		return json.Marshal(obj)
+	case FORMAT_GOB:
+		var buf bytes.Buffer
+		err := gob.NewEncoder(&buf).Encode(obj)
+		return buf.Bytes(), err
+	case FORMAT_CAPN:
+		coder, ok := obj.(Coder)
+		if !ok {
+			return nil, fmt.Errorf("%T has no capn coder", obj)
+		}
+		return coder.Encode()
+	case FORMAT_PROTOBUF:
+		pb, ok := obj.(ProtoMessage)
+		if !ok {
+			return nil, fmt.Errorf("%T is not a protobuf message", obj)
+		}
+		return pb.Marshal()
+	default:
+		return nil, errors.New("un supported format")
+	}
+}
+
+func DecodeMsg(format int8, data []byte, obj interface{}) error {
+	switch format {
+	case FORMAT_JSON:
+		return json.Unmarshal(data, obj)
+	case FORMAT_GOB:
+		return gob.NewDecoder(bytes.NewReader(data)).Decode(obj)
+	case FORMAT_CAPN:
+		coder, ok := obj.(Coder)
+		if !ok {
+			return fmt.Errorf("%T has no capn coder", obj)
+		}
+		return coder.Decode(data)
+	case FORMAT_PROTOBUF:
+		pb, ok := obj.(ProtoMessage)
+		if !ok {
+			return fmt.Errorf("%T is not a protobuf message", obj)
+		}
+		return pb.Unmarshal(data)
+	default:
+		return errors.New("un supported format")
+	}
+}
+
+// DecodeTopicMsg decodes a message received from topic with the format of the topic
+func DecodeTopicMsg(topic string, data []byte, obj interface{}) error {
+	return DecodeMsg(GetTopicFormat(topic), data, obj)
+}
@@ -0,0 +1,65 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+type testCoderMsg struct {
+	Value string
+}
+
+func (m *testCoderMsg) Encode() ([]byte, error) {
+	return []byte("capn:" + m.Value), nil
+}
+
+func (m *testCoderMsg) Decode(data []byte) error {
+	m.Value = strings.TrimPrefix(string(data), "capn:")
+	return nil
+}
+
+func (m *testCoderMsg) Marshal() ([]byte, error) {
+	return []byte("pb:" + m.Value), nil
+}
+
+func (m *testCoderMsg) Unmarshal(data []byte) error {
+	m.Value = strings.TrimPrefix(string(data), "pb:")
+	return nil
+}
+
+func TestTopicFormat(t *testing.T) {
+	for name, format := range map[string]int8{"json": FORMAT_JSON, "capn": FORMAT_CAPN, "protobuf": FORMAT_PROTOBUF, "gob": FORMAT_GOB} {
+		SetTopicFormat("test-"+name, format)
+		data, err := EncodeMsg(GetTopicFormat("test-"+name), &testCoderMsg{Value: "v"})
+		if err != nil {
+			t.Fatalf("%s encode: %v", name, err)
+		}
+		var msg testCoderMsg
+		if err = DecodeTopicMsg("test-"+name, data, &msg); err != nil || msg.Value != "v" {
+			t.Fatalf("%s decode %s: %v", name, data, err)
+		}
+	}
+	if GetTopicFormat("unknown") != FORMAT_JSON {
+		t.Fatalf("unknown topic should default to json")
+	}
+	if _, err := EncodeMsg(FORMAT_CAPN, struct{}{}); err == nil {
+		t.Fatalf("expected error for type without capn coder")
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+}
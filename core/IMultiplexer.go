@@ -15,8 +15,6 @@
 package core
 
 import (
-	"bytes"
-	"encoding/gob"
 	"errors"
 	"log"
 	"sync"
@@ -92,14 +90,5 @@ type TransportPubMsg struct {
 }
 
 func (msg *TransportPubMsg) Encode() ([]byte, error) {
-	switch msg.Format {
-	case FORMAT_JSON:
-		return json.Marshal(msg.Obj)
-	case FORMAT_GOB:
-		var buf bytes.Buffer
-		err := gob.NewEncoder(&buf).Encode(msg.Obj)
-		return buf.Bytes(), err
-	default:
-		return nil, errors.New("un supported format")
-	}
+	return EncodeMsg(msg.Format, msg.Obj)
 }
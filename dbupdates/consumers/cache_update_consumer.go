@@ -16,9 +16,9 @@ package consumers
 
 import (
 	"context"
-	"encoding/json"
 
 	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/dbupdates/dbupdatetypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	log "github.com/finogeeks/ligase/skunkworks/log"
@@ -49,7 +49,7 @@ func (c *CacheUpdateConsumer) Start() error {
 
 func (c *CacheUpdateConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output dbtypes.DBEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("dbevent: message parse failure", log.KeysAndValues{"error", err})
 		return
 	}
@@ -88,7 +88,7 @@ func (m *CacheUpdateManager) Start() error {
 				needAddConsumer = true
 				cfg.Name = m.cfg.Kafka.Consumer.CacheUpdates.Name + "_" + key
 			} else {
-				err := channelSub.SubscribeTopic(cfg.Topic)
+				err := subscribeTopic(channelSub, &cfg)
 				if err != nil {
 					log.Errorf("kafka sub on exists consumer erro %s", err.Error())
 					return err
@@ -16,11 +16,11 @@ package consumers
 
 import (
 	"context"
-	"encoding/json"
 	"github.com/finogeeks/ligase/common"
 	"time"
 
 	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/dbupdates/dbupdatetypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	log "github.com/finogeeks/ligase/skunkworks/log"
@@ -30,6 +30,15 @@ type IKafkaChannelSub interface {
 	SubscribeTopic(topic string) error
 }
 
+// subscribeTopic subscribes an existing consumer channel to the topic of cfg,
+// the topic format is registered here as AddChannel only does it for new channels
+func subscribeTopic(channelSub IKafkaChannelSub, cfg *config.ConsumerConf) error {
+	if err := core.SetTopicFormatConf(cfg.Topic, cfg); err != nil {
+		return err
+	}
+	return channelSub.SubscribeTopic(cfg.Topic)
+}
+
 type DBEventSeqConsumer struct {
 	name      string
 	Cfg       *config.Dendrite
@@ -127,7 +136,7 @@ func (c *DBEventSeqConsumer) processInputs(ctx context.Context, inputs []dbupdat
 
 func (c *DBEventSeqConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output dbtypes.DBEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("dbevent: message parse failure", log.KeysAndValues{"error", err})
 		return
 	}
@@ -0,0 +1,53 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package consumers
+
+import (
+	"testing"
+
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+)
+
+type testChannelSub struct {
+	topics []string
+}
+
+func (c *testChannelSub) SubscribeTopic(topic string) error {
+	c.topics = append(c.topics, topic)
+	return nil
+}
+
+func TestSubscribeTopicFormat(t *testing.T) {
+	sub := &testChannelSub{}
+	cfg := config.ConsumerConf{Topic: "test_dbupdates_format", Format: "protobuf"}
+	if err := subscribeTopic(sub, &cfg); err != nil {
+		t.Fatalf("subscribe: %v", err)
+	}
+	if len(sub.topics) != 1 || sub.topics[0] != cfg.Topic {
+		t.Fatalf("subscribed topics %v", sub.topics)
+	}
+	if core.GetTopicFormat(cfg.Topic) != core.FORMAT_PROTOBUF {
+		t.Fatalf("topic format %d, want protobuf", core.GetTopicFormat(cfg.Topic))
+	}
+
+	cfg = config.ConsumerConf{Topic: "test_dbupdates_bad_format", Format: "xml"}
+	if err := subscribeTopic(sub, &cfg); err == nil {
+		t.Fatalf("expected error for unknown format")
+	}
+	if len(sub.topics) != 1 {
+		t.Fatalf("topic with unknown format should not be subscribed")
+	}
+}
@@ -73,7 +73,7 @@ func (m *DBEventSeqManager) Start() error {
 				needAddConsumer = true
 				cfg.Name = m.cfg.Kafka.Consumer.DBUpdates.Name + "_" + key
 			} else {
-				err := channelSub.SubscribeTopic(cfg.Topic)
+				err := subscribeTopic(channelSub, &cfg)
 				if err != nil {
 					log.Errorf("kafka sub on exists consumer erro %s", err.Error())
 					return err
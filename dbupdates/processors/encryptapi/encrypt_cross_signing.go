@@ -0,0 +1,161 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/dbupdates/dbregistry"
+	"github.com/finogeeks/ligase/dbupdates/dbupdatetypes"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+func init() {
+	dbregistry.Register("encrypt_cross_signing_key", NewDBEncryptCrossSigningProcessor, NewCacheEncryptCrossSigningProcessor)
+	dbregistry.Register("encrypt_cross_signing_sig", NewDBEncryptCrossSigningProcessor, NewCacheEncryptCrossSigningProcessor)
+}
+
+type DBEncryptCrossSigningProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	db   model.EncryptorAPIDatabase
+}
+
+func NewDBEncryptCrossSigningProcessor(
+	name string,
+	cfg *config.Dendrite,
+) dbupdatetypes.DBEventSeqProcessor {
+	p := new(DBEncryptCrossSigningProcessor)
+	p.name = name
+	p.cfg = cfg
+
+	return p
+}
+
+func (p *DBEncryptCrossSigningProcessor) Start() {
+	db, err := common.GetDBInstance("encryptoapi", p.cfg)
+	if err != nil {
+		log.Panicf("failed to connect to encryptoapi db")
+	}
+	p.db = db.(model.EncryptorAPIDatabase)
+}
+
+func (p *DBEncryptCrossSigningProcessor) Process(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	switch inputs[0].Event.Key {
+	case dbtypes.CrossSigningKeyInsertKey:
+		p.processKeyUpsert(ctx, inputs)
+	case dbtypes.CrossSigningSigInsertKey:
+		p.processSigUpsert(ctx, inputs)
+	default:
+		log.Errorf("invalid %s event key %d", p.name, inputs[0].Event.Key)
+	}
+
+	return nil
+}
+
+func (p *DBEncryptCrossSigningProcessor) processKeyUpsert(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.CrossSigningKeyInsert
+		err := p.db.OnInsertCrossSigningKey(ctx, msg.UserID, msg.KeyType, msg.KeyInfo)
+		if err != nil {
+			log.Error(p.name, "upsert err", err, msg.UserID, msg.KeyType, msg.KeyInfo)
+		}
+	}
+	return nil
+}
+
+func (p *DBEncryptCrossSigningProcessor) processSigUpsert(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.CrossSigningSigInsert
+		err := p.db.OnInsertCrossSigningSig(ctx, msg.OriginUserID, msg.OriginKeyID, msg.TargetUserID, msg.TargetKeyID, msg.Signature)
+		if err != nil {
+			log.Error(p.name, "upsert err", err, msg.OriginUserID, msg.OriginKeyID, msg.TargetUserID, msg.TargetKeyID)
+		}
+	}
+	return nil
+}
+
+type CacheEncryptCrossSigningProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	pool dbupdatetypes.Pool
+}
+
+func NewCacheEncryptCrossSigningProcessor(name string, cfg *config.Dendrite, pool dbupdatetypes.Pool) dbupdatetypes.CacheProcessor {
+	p := new(CacheEncryptCrossSigningProcessor)
+	p.name = name
+	p.cfg = cfg
+	p.pool = pool
+	return p
+}
+
+func (p *CacheEncryptCrossSigningProcessor) Start() {
+}
+
+func (p *CacheEncryptCrossSigningProcessor) Process(ctx context.Context, input dbupdatetypes.CacheInput) error {
+	key := input.Event.Key
+	data := input.Event.E2EDBEvents
+	switch key {
+	case dbtypes.CrossSigningKeyInsertKey:
+		return p.onCrossSigningKeyInsert(ctx, data.CrossSigningKeyInsert)
+	case dbtypes.CrossSigningSigInsertKey:
+		return p.onCrossSigningSigInsert(ctx, data.CrossSigningSigInsert)
+	}
+	return nil
+}
+
+func (p *CacheEncryptCrossSigningProcessor) onCrossSigningKeyInsert(ctx context.Context, msg *dbtypes.CrossSigningKeyInsert) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	err := conn.Send("hset", fmt.Sprintf("%s:%s", "cross_signing_key", msg.UserID), msg.KeyType, msg.KeyInfo)
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (p *CacheEncryptCrossSigningProcessor) onCrossSigningSigInsert(ctx context.Context, msg *dbtypes.CrossSigningSigInsert) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	sig, err := json.Marshal(&types.CrossSigningSig{
+		OriginUserID: msg.OriginUserID,
+		OriginKeyID:  msg.OriginKeyID,
+		Signature:    msg.Signature,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%s", "cross_signing_sig", msg.TargetUserID, msg.TargetKeyID),
+		fmt.Sprintf("%s:%s", msg.OriginUserID, msg.OriginKeyID), string(sig))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
@@ -0,0 +1,135 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/dbupdates/dbregistry"
+	"github.com/finogeeks/ligase/dbupdates/dbupdatetypes"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+func init() {
+	dbregistry.Register("encrypt_fallback_key", NewDBEncryptFallbackKeyProcessor, NewCacheEncryptFallbackKeyProcessor)
+}
+
+type DBEncryptFallbackKeyProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	db   model.EncryptorAPIDatabase
+}
+
+func NewDBEncryptFallbackKeyProcessor(
+	name string,
+	cfg *config.Dendrite,
+) dbupdatetypes.DBEventSeqProcessor {
+	p := new(DBEncryptFallbackKeyProcessor)
+	p.name = name
+	p.cfg = cfg
+
+	return p
+}
+
+func (p *DBEncryptFallbackKeyProcessor) Start() {
+	db, err := common.GetDBInstance("encryptoapi", p.cfg)
+	if err != nil {
+		log.Panicf("failed to connect to encryptoapi db")
+	}
+	p.db = db.(model.EncryptorAPIDatabase)
+}
+
+func (p *DBEncryptFallbackKeyProcessor) Process(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	switch inputs[0].Event.Key {
+	case dbtypes.FallbackKeyInsertKey:
+		p.processUpsert(ctx, inputs)
+	default:
+		log.Errorf("invalid %s event key %d", p.name, inputs[0].Event.Key)
+	}
+
+	return nil
+}
+
+func (p *DBEncryptFallbackKeyProcessor) processUpsert(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.FallbackKeyInsert
+		err := p.db.OnInsertFallbackKey(ctx, msg.DeviceID, msg.UserID, msg.KeyID, msg.KeyInfo,
+			msg.Algorithm, msg.Signature, msg.Identifier, msg.Used)
+		if err != nil {
+			log.Error(p.name, "upsert err", err, msg.UserID, msg.DeviceID, msg.Algorithm)
+		}
+	}
+	return nil
+}
+
+type CacheEncryptFallbackKeyProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	pool dbupdatetypes.Pool
+}
+
+func NewCacheEncryptFallbackKeyProcessor(name string, cfg *config.Dendrite, pool dbupdatetypes.Pool) dbupdatetypes.CacheProcessor {
+	p := new(CacheEncryptFallbackKeyProcessor)
+	p.name = name
+	p.cfg = cfg
+	p.pool = pool
+	return p
+}
+
+func (p *CacheEncryptFallbackKeyProcessor) Start() {
+}
+
+func (p *CacheEncryptFallbackKeyProcessor) Process(ctx context.Context, input dbupdatetypes.CacheInput) error {
+	key := input.Event.Key
+	data := input.Event.E2EDBEvents
+	switch key {
+	case dbtypes.FallbackKeyInsertKey:
+		return p.onFallbackKeyInsert(ctx, data.FallbackKeyInsert)
+	}
+	return nil
+}
+
+func (p *CacheEncryptFallbackKeyProcessor) onFallbackKeyInsert(ctx context.Context, msg *dbtypes.FallbackKeyInsert) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	key, err := json.Marshal(&types.FallbackKey{
+		KeyID:     msg.KeyID,
+		Key:       msg.KeyInfo,
+		Algorithm: msg.Algorithm,
+		Signature: msg.Signature,
+		Used:      msg.Used,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%s", "fallback_key", msg.UserID, msg.DeviceID), msg.Algorithm, string(key))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
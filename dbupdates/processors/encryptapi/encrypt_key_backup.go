@@ -0,0 +1,225 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package processors
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/dbupdates/dbregistry"
+	"github.com/finogeeks/ligase/dbupdates/dbupdatetypes"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+	"github.com/gomodule/redigo/redis"
+)
+
+func init() {
+	dbregistry.Register("encrypt_key_backup_version", NewDBEncryptKeyBackupProcessor, NewCacheEncryptKeyBackupProcessor)
+	dbregistry.Register("encrypt_key_backup", NewDBEncryptKeyBackupProcessor, NewCacheEncryptKeyBackupProcessor)
+}
+
+type DBEncryptKeyBackupProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	db   model.EncryptorAPIDatabase
+}
+
+func NewDBEncryptKeyBackupProcessor(
+	name string,
+	cfg *config.Dendrite,
+) dbupdatetypes.DBEventSeqProcessor {
+	p := new(DBEncryptKeyBackupProcessor)
+	p.name = name
+	p.cfg = cfg
+
+	return p
+}
+
+func (p *DBEncryptKeyBackupProcessor) Start() {
+	db, err := common.GetDBInstance("encryptoapi", p.cfg)
+	if err != nil {
+		log.Panicf("failed to connect to encryptoapi db")
+	}
+	p.db = db.(model.EncryptorAPIDatabase)
+}
+
+func (p *DBEncryptKeyBackupProcessor) Process(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+
+	switch inputs[0].Event.Key {
+	case dbtypes.KeyBackupVersionInsertKey:
+		p.processVersionUpsert(ctx, inputs)
+	case dbtypes.KeyBackupInsertKey:
+		p.processUpsert(ctx, inputs)
+	case dbtypes.KeyBackupDeleteKey:
+		p.processDelete(ctx, inputs)
+	default:
+		log.Errorf("invalid %s event key %d", p.name, inputs[0].Event.Key)
+	}
+
+	return nil
+}
+
+func (p *DBEncryptKeyBackupProcessor) processVersionUpsert(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.KeyBackupVersionInsert
+		err := p.db.OnInsertKeyBackupVersion(ctx, msg.UserID, msg.Version, msg.Algorithm, msg.AuthData, msg.Etag, msg.Deleted)
+		if err != nil {
+			log.Error(p.name, "upsert err", err, msg.UserID, msg.Version)
+		}
+	}
+	return nil
+}
+
+func (p *DBEncryptKeyBackupProcessor) processUpsert(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.KeyBackupInsert
+		err := p.db.OnInsertKeyBackup(ctx, msg.UserID, msg.Version, msg.RoomID, msg.SessionID,
+			msg.FirstMessageIndex, msg.ForwardedCount, msg.IsVerified, msg.SessionData)
+		if err != nil {
+			log.Error(p.name, "upsert err", err, msg.UserID, msg.Version, msg.RoomID, msg.SessionID)
+		}
+	}
+	return nil
+}
+
+func (p *DBEncryptKeyBackupProcessor) processDelete(ctx context.Context, inputs []dbupdatetypes.DBEventDataInput) error {
+	for _, v := range inputs {
+		msg := v.Event.E2EDBEvents.KeyBackupDelete
+		err := p.db.OnDeleteKeyBackup(ctx, msg.UserID, msg.Version, msg.RoomID, msg.SessionID)
+		if err != nil {
+			log.Error(p.name, "delete err", err, msg.UserID, msg.Version, msg.RoomID, msg.SessionID)
+		}
+	}
+	return nil
+}
+
+type CacheEncryptKeyBackupProcessor struct {
+	name string
+	cfg  *config.Dendrite
+	pool dbupdatetypes.Pool
+}
+
+func NewCacheEncryptKeyBackupProcessor(name string, cfg *config.Dendrite, pool dbupdatetypes.Pool) dbupdatetypes.CacheProcessor {
+	p := new(CacheEncryptKeyBackupProcessor)
+	p.name = name
+	p.cfg = cfg
+	p.pool = pool
+	return p
+}
+
+func (p *CacheEncryptKeyBackupProcessor) Start() {
+}
+
+func (p *CacheEncryptKeyBackupProcessor) Process(ctx context.Context, input dbupdatetypes.CacheInput) error {
+	key := input.Event.Key
+	data := input.Event.E2EDBEvents
+	switch key {
+	case dbtypes.KeyBackupVersionInsertKey:
+		return p.onKeyBackupVersionInsert(ctx, data.KeyBackupVersionInsert)
+	case dbtypes.KeyBackupInsertKey:
+		return p.onKeyBackupInsert(ctx, data.KeyBackupInsert)
+	case dbtypes.KeyBackupDeleteKey:
+		return p.onKeyBackupDelete(ctx, data.KeyBackupDelete)
+	}
+	return nil
+}
+
+func (p *CacheEncryptKeyBackupProcessor) onKeyBackupVersionInsert(ctx context.Context, msg *dbtypes.KeyBackupVersionInsert) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	version, err := json.Marshal(&types.KeyBackupVersion{
+		Version:   msg.Version,
+		Algorithm: msg.Algorithm,
+		AuthData:  msg.AuthData,
+		Etag:      msg.Etag,
+		Deleted:   msg.Deleted,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s", "key_backup_version", msg.UserID), fmt.Sprintf("%d", msg.Version), string(version))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (p *CacheEncryptKeyBackupProcessor) onKeyBackupInsert(ctx context.Context, msg *dbtypes.KeyBackupInsert) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	session, err := json.Marshal(&types.KeyBackupSession{
+		RoomID:            msg.RoomID,
+		SessionID:         msg.SessionID,
+		FirstMessageIndex: msg.FirstMessageIndex,
+		ForwardedCount:    msg.ForwardedCount,
+		IsVerified:        msg.IsVerified,
+		SessionData:       msg.SessionData,
+	})
+	if err != nil {
+		return err
+	}
+	err = conn.Send("hset", fmt.Sprintf("%s:%s:%d", "key_backup", msg.UserID, msg.Version),
+		fmt.Sprintf("%s:%s", msg.RoomID, msg.SessionID), string(session))
+	if err != nil {
+		return err
+	}
+
+	return conn.Flush()
+}
+
+func (p *CacheEncryptKeyBackupProcessor) onKeyBackupDelete(ctx context.Context, msg *dbtypes.KeyBackupDelete) error {
+	conn := p.pool.Pool().Get()
+	defer conn.Close()
+
+	key := fmt.Sprintf("%s:%s:%d", "key_backup", msg.UserID, msg.Version)
+	if msg.RoomID == "" {
+		if err := conn.Send("del", key); err != nil {
+			return err
+		}
+		return conn.Flush()
+	}
+	if msg.SessionID != "" {
+		if err := conn.Send("hdel", key, fmt.Sprintf("%s:%s", msg.RoomID, msg.SessionID)); err != nil {
+			return err
+		}
+		return conn.Flush()
+	}
+
+	result, err := redis.StringMap(conn.Do("hgetall", key))
+	if err != nil {
+		return err
+	}
+	for field, val := range result {
+		var session types.KeyBackupSession
+		if err := json.Unmarshal([]byte(val), &session); err != nil || session.RoomID != msg.RoomID {
+			continue
+		}
+		if err := conn.Send("hdel", key, field); err != nil {
+			return err
+		}
+	}
+	return conn.Flush()
+}
@@ -46,9 +46,8 @@ const (
 )
 
 type AccountDBEVConsumer struct {
-	db model.AccountsDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.AccountsDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -59,70 +58,71 @@ type AccountDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *AccountDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *AccountDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		// start := time.Now().UnixNano() / 1000000
-		start := time.Now()
-
-		key := output.Key
-		data := output.AccountDBEvents
-		switch key {
-		case dbtypes.AccountDataInsertKey:
-			res = s.OnInsertAccountData(ctx, data.AccountDataInsert)
-		case dbtypes.AccountInsertKey:
-			res = s.OnInsertAccount(ctx, data.AccountInsert)
-		case dbtypes.FilterInsertKey:
-			res = s.OnInsertFilter(ctx, data.FilterInsert)
-		case dbtypes.ProfileInsertKey:
-			res = s.OnUpsertProfile(ctx, data.ProfileInsert)
-		case dbtypes.ProfileInitKey:
-			res = s.OnInitProfile(ctx, data.ProfileInsert)
-		case dbtypes.RoomTagInsertKey:
-			res = s.OnInsertRoomTag(ctx, data.RoomTagInsert)
-		case dbtypes.RoomTagDeleteKey:
-			res = s.OnDeleteRoomTag(ctx, data.RoomTagDelete)
-		case dbtypes.DisplayNameInsertKey:
-			res = s.OnUpsertDisplayName(ctx, data.ProfileInsert)
-		case dbtypes.AvatarInsertKey:
-			res = s.OnUpsertAvatar(ctx, data.ProfileInsert)
-		case dbtypes.UserInfoInsertKey:
-			res = s.OnUpsertUserInfo(ctx, data.UserInfoInsert)
-		case dbtypes.UserInfoInitKey:
-			res = s.OnInitUserInfo(ctx, data.UserInfoInsert)
-		case dbtypes.UserInfoDeleteKey:
-			res = s.OnDeleteUserInfo(ctx, data.UserInfoDelete)
-		default:
-			res = nil
-			log.Infow("account db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	// start := time.Now().UnixNano() / 1000000
+	start := time.Now()
+
+	key := output.Key
+	data := output.AccountDBEvents
+	switch key {
+	case dbtypes.AccountDataInsertKey:
+		res = s.OnInsertAccountData(ctx, data.AccountDataInsert)
+	case dbtypes.AccountInsertKey:
+		res = s.OnInsertAccount(ctx, data.AccountInsert)
+	case dbtypes.FilterInsertKey:
+		res = s.OnInsertFilter(ctx, data.FilterInsert)
+	case dbtypes.ProfileInsertKey:
+		res = s.OnUpsertProfile(ctx, data.ProfileInsert)
+	case dbtypes.ProfileInitKey:
+		res = s.OnInitProfile(ctx, data.ProfileInsert)
+	case dbtypes.RoomTagInsertKey:
+		res = s.OnInsertRoomTag(ctx, data.RoomTagInsert)
+	case dbtypes.RoomTagDeleteKey:
+		res = s.OnDeleteRoomTag(ctx, data.RoomTagDelete)
+	case dbtypes.DisplayNameInsertKey:
+		res = s.OnUpsertDisplayName(ctx, data.ProfileInsert)
+	case dbtypes.AvatarInsertKey:
+		res = s.OnUpsertAvatar(ctx, data.ProfileInsert)
+	case dbtypes.UserInfoInsertKey:
+		res = s.OnUpsertUserInfo(ctx, data.UserInfoInsert)
+	case dbtypes.UserInfoInitKey:
+		res = s.OnInitUserInfo(ctx, data.UserInfoInsert)
+	case dbtypes.UserInfoDeleteKey:
+		res = s.OnDeleteUserInfo(ctx, data.UserInfoDelete)
+	default:
+		log.Infow("account db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.StoreInt64(&item.duration, int64(time.Since(start))/int64(time.Millisecond))
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.StoreInt64(&item.duration, int64(time.Since(start))/int64(time.Millisecond))
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write account db event to db warn %v key: %s event:%s", res, dbtypes.AccountDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write account db event to db warn %v key: %s event:%s", res, dbtypes.AccountDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Errorf("write account db event to db error %v key: %s", res, dbtypes.AccountDBEventKeyToStr(key))
-			}
+			log.Errorf("write account db event to db error %v key: %s", res, dbtypes.AccountDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	// now := time.Now().UnixNano() / 1000000
+	log.Infof("AccountDBEVConsumer process %s takes %d", dbtypes.AccountDBEventKeyToStr(key), item.duration)
 
-		// now := time.Now().UnixNano() / 1000000
-		log.Infof("AccountDBEVConsumer process %s takes %d", dbtypes.AccountDBEventKeyToStr(key), item.duration)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -143,17 +143,12 @@ func NewAccountDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, accDBWorkerCount)
-	for i := uint64(0); i < accDBWorkerCount; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "accountDbEvErrs.txt"
 	s.recoverName = "accountDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(accDBWorkerCount, s.processEvent)
 	return s
 }
 
@@ -169,10 +164,7 @@ func (s *AccountDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *AccountDBEVConsumer) Start() {
-	for i := uint64(0); i < accDBWorkerCount; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -190,28 +182,8 @@ func (s *AccountDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *AccountDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.AccountDataInsertKey:
-		chanID = 0
-	case dbtypes.AccountInsertKey:
-		chanID = 1
-	case dbtypes.FilterInsertKey:
-		chanID = 2
-	case dbtypes.ProfileInsertKey, dbtypes.ProfileInitKey, dbtypes.AvatarInsertKey, dbtypes.DisplayNameInsertKey:
-		chanID = 3
-	case dbtypes.RoomTagInsertKey, dbtypes.RoomTagDeleteKey:
-		chanID = 4
-	case dbtypes.UserInfoInsertKey, dbtypes.UserInfoInitKey, dbtypes.UserInfoDeleteKey:
-		chanID = 5
-	default:
-		log.Infow("device db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *AccountDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *AccountDBEVConsumer) Report(mon monitor.LabeledGauge) {
@@ -371,7 +343,7 @@ func (s *AccountDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
@@ -119,14 +119,27 @@ func (s *DBEventDataConsumer) Start() error {
 }
 
 func (s *DBEventDataConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
+	s.OnMessageAsync(ctx, topic, partition, data, rawMsg, func(err error) {
+		if err != nil {
+			log.Errorw("dbevent: message process failure", log.KeysAndValues{"error", err})
+		}
+	})
+}
+
+// OnMessageAsync queues the dbevent to the workers of its category and
+// reports malformed dbevents and failed db writes to the channel, so they are
+// retried and parked in the dead letter topic instead of being dropped
+func (s *DBEventDataConsumer) OnMessageAsync(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}, done func(error)) {
 	var output dbtypes.DBEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("dbevent: message parse failure", log.KeysAndValues{"error", err})
+		done(err)
 		return
 	}
 
 	if output.IsRecovery {
-		return //recover from db, just need to write cache
+		done(nil) //recover from db, just need to write cache
+		return
 	}
 
 	atomic.AddInt32(&s.monItem.recv, 1)
@@ -136,8 +149,10 @@ func (s *DBEventDataConsumer) OnMessage(ctx context.Context, topic string, parti
 	val, ok := s.consumerRepo.Load(category)
 	if ok {
 		consumer := val.(ConsumerInterface)
-		consumer.OnMessage(ctx, &output)
+		consumer.OnMessage(ctx, &output, done)
+		return
 	}
+	done(nil)
 }
 
 func (s *DBEventDataConsumer) CommitMessage(rawMsg []interface{}) {
@@ -28,7 +28,7 @@ var regMu sync.RWMutex
 var newHandler = make(map[int64]func() ConsumerInterface)
 
 type ConsumerInterface interface {
-	OnMessage(context.Context, *dbtypes.DBEvent) error
+	OnMessage(context.Context, *dbtypes.DBEvent, func(error))
 	Prepare(*config.Dendrite)
 	Report(monitor.LabeledGauge)
 	Start()
@@ -42,9 +42,8 @@ func init() {
 }
 
 type DeviceDBEVConsumer struct {
-	db model.DeviceDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.DeviceDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -55,52 +54,53 @@ type DeviceDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *DeviceDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *DeviceDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.DeviceDBEvents
-		switch key {
-		case dbtypes.DeviceInsertKey:
-			res = s.onDeviceInsert(ctx, data.DeviceInsert)
-		case dbtypes.DeviceDeleteKey:
-			res = s.onDeviceDelete(ctx, data.DeviceDelete)
-		case dbtypes.MigDeviceInsertKey:
-			res = s.onMigDeviceInsert(ctx, data.MigDeviceInsert)
-		case dbtypes.DeviceUpdateTsKey:
-			res = s.onUpdateDeviceActiveTs(ctx, data.DeviceUpdateTs)
-		default:
-			res = nil
-			log.Infow("device db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.DeviceDBEvents
+	switch key {
+	case dbtypes.DeviceInsertKey:
+		res = s.onDeviceInsert(ctx, data.DeviceInsert)
+	case dbtypes.DeviceDeleteKey:
+		res = s.onDeviceDelete(ctx, data.DeviceDelete)
+	case dbtypes.MigDeviceInsertKey:
+		res = s.onMigDeviceInsert(ctx, data.MigDeviceInsert)
+	case dbtypes.DeviceUpdateTsKey:
+		res = s.onUpdateDeviceActiveTs(ctx, data.DeviceUpdateTs)
+	default:
+		log.Infow("device db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write device db event to db warn %v key: %s event:%s", res, dbtypes.DeviceDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write device db event to db warn %v key: %s event:%s", res, dbtypes.DeviceDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Errorf("write device db event to db error %v key: %s", res, dbtypes.DeviceDBEventKeyToStr(key))
-			}
+			log.Errorf("write device db event to db error %v key: %s", res, dbtypes.DeviceDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("DeviceDBEVConsumer process %s takes %d", dbtypes.DeviceDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("DeviceDBEVConsumer process %s takes %d", dbtypes.DeviceDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -121,17 +121,12 @@ func NewDeviceDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 1)
-	for i := uint64(0); i < 1; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "deviceDbEvErrs.txt"
 	s.recoverName = "deviceDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(1, s.processEvent)
 	return s
 }
 
@@ -147,10 +142,7 @@ func (s *DeviceDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *DeviceDBEVConsumer) Start() {
-	for i := uint64(0); i < 1; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -168,18 +160,8 @@ func (s *DeviceDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *DeviceDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.DeviceInsertKey, dbtypes.DeviceDeleteKey, dbtypes.MigDeviceInsertKey, dbtypes.DeviceUpdateTsKey:
-		chanID = 0
-	default:
-		log.Infow("device db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *DeviceDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *DeviceDBEVConsumer) onDeviceInsert(
@@ -295,7 +277,7 @@ func (s *DeviceDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
@@ -19,6 +19,7 @@ package consumers
 
 import (
 	"context"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -36,62 +37,78 @@ func init() {
 }
 
 type E2EDBEVConsumer struct {
-	db model.EncryptorAPIDatabase
-	//msgChan  []chan *dbtypes.DBEvent
-	msgChan  []chan common.ContextMsg
+	db       model.EncryptorAPIDatabase
+	workers  *dbEventWorkers
 	monState []*DBMonItem
 }
 
-func (s *E2EDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is returned to
+// the channel, which retries it and parks it in the dead letter topic
+func (s *E2EDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.E2EDBEvents
-		switch key {
-		case dbtypes.DeviceKeyInsertKey:
-			res = s.onDeviceKeyInsert(ctx, data.KeyInsert)
-		case dbtypes.OneTimeKeyInsertKey:
-			res = s.onOneTimeKeyInsert(ctx, data.KeyInsert)
-		case dbtypes.OneTimeKeyDeleteKey:
-			res = s.onOneTimeKeyDelete(ctx, data.KeyDelete)
-		case dbtypes.MacOneTimeKeyDeleteKey:
-			res = s.onMacOneTimeKeyDelete(ctx, data.MacKeyDelete)
-		case dbtypes.AlInsertKey:
-			res = s.onAlInsert(ctx, data.AlInsert)
-		case dbtypes.DeviceAlDeleteKey:
-			res = s.onAlDelete(ctx, data.DeviceKeyDelete)
-		case dbtypes.MacDeviceAlDeleteKey:
-			res = s.onMacAlDelete(ctx, data.MacKeyDelete)
-		case dbtypes.DeviceKeyDeleteKey:
-			res = s.onDeviceKeyDelete(ctx, data.DeviceKeyDelete)
-		case dbtypes.MacDeviceKeyDeleteKey:
-			res = s.onMacDeviceKeyDelete(ctx, data.MacKeyDelete)
-		case dbtypes.DeviceOneTimeKeyDeleteKey:
-			res = s.onDeviceOneTimeKeyDelete(ctx, data.DeviceKeyDelete)
-		default:
-			res = nil
-			log.Infow("encrypt api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
-
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
-		} else {
-			log.Error("write encrypt api db event to db error %v key: %s", res, dbtypes.E2EDBEventKeyToStr(key))
-			atomic.AddInt32(&item.fail, 1)
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.E2EDBEvents
+	switch key {
+	case dbtypes.DeviceKeyInsertKey:
+		res = s.onDeviceKeyInsert(ctx, data.KeyInsert)
+	case dbtypes.OneTimeKeyInsertKey:
+		res = s.onOneTimeKeyInsert(ctx, data.KeyInsert)
+	case dbtypes.OneTimeKeyDeleteKey:
+		res = s.onOneTimeKeyDelete(ctx, data.KeyDelete)
+	case dbtypes.MacOneTimeKeyDeleteKey:
+		res = s.onMacOneTimeKeyDelete(ctx, data.MacKeyDelete)
+	case dbtypes.AlInsertKey:
+		res = s.onAlInsert(ctx, data.AlInsert)
+	case dbtypes.DeviceAlDeleteKey:
+		res = s.onAlDelete(ctx, data.DeviceKeyDelete)
+	case dbtypes.MacDeviceAlDeleteKey:
+		res = s.onMacAlDelete(ctx, data.MacKeyDelete)
+	case dbtypes.DeviceKeyDeleteKey:
+		res = s.onDeviceKeyDelete(ctx, data.DeviceKeyDelete)
+	case dbtypes.MacDeviceKeyDeleteKey:
+		res = s.onMacDeviceKeyDelete(ctx, data.MacKeyDelete)
+	case dbtypes.DeviceOneTimeKeyDeleteKey:
+		res = s.onDeviceOneTimeKeyDelete(ctx, data.DeviceKeyDelete)
+	case dbtypes.CrossSigningKeyInsertKey:
+		res = s.onCrossSigningKeyInsert(ctx, data.CrossSigningKeyInsert)
+	case dbtypes.CrossSigningSigInsertKey:
+		res = s.onCrossSigningSigInsert(ctx, data.CrossSigningSigInsert)
+	case dbtypes.KeyBackupVersionInsertKey:
+		res = s.onKeyBackupVersionInsert(ctx, data.KeyBackupVersionInsert)
+	case dbtypes.KeyBackupInsertKey:
+		res = s.onKeyBackupInsert(ctx, data.KeyBackupInsert)
+	case dbtypes.KeyBackupDeleteKey:
+		res = s.onKeyBackupDelete(ctx, data.KeyBackupDelete)
+	case dbtypes.FallbackKeyInsertKey:
+		res = s.onFallbackKeyInsert(ctx, data.FallbackKeyInsert)
+	default:
+		log.Infow("encrypt api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("E2EDBEVConsumer process %s takes %d", dbtypes.E2EDBEventKeyToStr(key), now-start)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		log.Error("write encrypt api db event to db error %v key: %s", res, dbtypes.E2EDBEventKeyToStr(key))
+		atomic.AddInt32(&item.fail, 1)
 	}
 
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("E2EDBEVConsumer process %s takes %d", dbtypes.E2EDBEventKeyToStr(key), now-start)
+
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
+	}
 	return res
 }
 
+func (s *E2EDBEVConsumer) IsDump(errMsg string) bool {
+	return strings.Contains(errMsg, "duplicate key value")
+}
+
 func NewE2EDBEVConsumer() ConsumerInterface {
 	s := new(E2EDBEVConsumer)
 	//init mon
@@ -105,11 +122,7 @@ func NewE2EDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 3)
-	for i := uint64(0); i < 3; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
+	s.workers = newDBEventWorkers(5, s.processEvent)
 	return s
 }
 
@@ -123,27 +136,11 @@ func (s *E2EDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *E2EDBEVConsumer) Start() {
-	for i := uint64(0); i < 3; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
+	s.workers.start()
 }
 
-func (s *E2EDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.DeviceKeyInsertKey, dbtypes.DeviceKeyDeleteKey, dbtypes.MacDeviceKeyDeleteKey:
-		chanID = 0
-	case dbtypes.OneTimeKeyInsertKey, dbtypes.OneTimeKeyDeleteKey, dbtypes.DeviceOneTimeKeyDeleteKey, dbtypes.MacOneTimeKeyDeleteKey:
-		chanID = 1
-	case dbtypes.AlInsertKey, dbtypes.DeviceAlDeleteKey, dbtypes.MacDeviceAlDeleteKey:
-		chanID = 2
-	default:
-		log.Infow("encrypt api db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *E2EDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *E2EDBEVConsumer) onDeviceKeyInsert(
@@ -206,6 +203,43 @@ func (s *E2EDBEVConsumer) onDeviceOneTimeKeyDelete(
 	return s.db.OnDeleteDeviceOneTimeKey(ctx, msg.DeviceID, msg.UserID)
 }
 
+func (s *E2EDBEVConsumer) onCrossSigningKeyInsert(
+	ctx context.Context, msg *dbtypes.CrossSigningKeyInsert,
+) error {
+	return s.db.OnInsertCrossSigningKey(ctx, msg.UserID, msg.KeyType, msg.KeyInfo)
+}
+
+func (s *E2EDBEVConsumer) onCrossSigningSigInsert(
+	ctx context.Context, msg *dbtypes.CrossSigningSigInsert,
+) error {
+	return s.db.OnInsertCrossSigningSig(ctx, msg.OriginUserID, msg.OriginKeyID, msg.TargetUserID, msg.TargetKeyID, msg.Signature)
+}
+
+func (s *E2EDBEVConsumer) onKeyBackupVersionInsert(
+	ctx context.Context, msg *dbtypes.KeyBackupVersionInsert,
+) error {
+	return s.db.OnInsertKeyBackupVersion(ctx, msg.UserID, msg.Version, msg.Algorithm, msg.AuthData, msg.Etag, msg.Deleted)
+}
+
+func (s *E2EDBEVConsumer) onKeyBackupInsert(
+	ctx context.Context, msg *dbtypes.KeyBackupInsert,
+) error {
+	return s.db.OnInsertKeyBackup(ctx, msg.UserID, msg.Version, msg.RoomID, msg.SessionID,
+		msg.FirstMessageIndex, msg.ForwardedCount, msg.IsVerified, msg.SessionData)
+}
+
+func (s *E2EDBEVConsumer) onKeyBackupDelete(
+	ctx context.Context, msg *dbtypes.KeyBackupDelete,
+) error {
+	return s.db.OnDeleteKeyBackup(ctx, msg.UserID, msg.Version, msg.RoomID, msg.SessionID)
+}
+
+func (s *E2EDBEVConsumer) onFallbackKeyInsert(
+	ctx context.Context, msg *dbtypes.FallbackKeyInsert,
+) error {
+	return s.db.OnInsertFallbackKey(ctx, msg.DeviceID, msg.UserID, msg.KeyID, msg.KeyInfo, msg.Algorithm, msg.Signature, msg.Identifier, msg.Used)
+}
+
 func (s *E2EDBEVConsumer) Report(mon monitor.LabeledGauge) {
 	for i := int64(0); i < dbtypes.E2EMaxKey; i++ {
 		item := s.monState[i]
@@ -42,9 +42,8 @@ func init() {
 }
 
 type PresenceDBEVConsumer struct {
-	db model.PresenceDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.PresenceDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -55,46 +54,47 @@ type PresenceDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *PresenceDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *PresenceDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.PresenceDBEvents
-		switch key {
-		case dbtypes.PresencesInsertKey:
-			res = s.OnInsertPresences(ctx, data.PresencesInsert)
-		default:
-			res = nil
-			log.Infow("presence db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.PresenceDBEvents
+	switch key {
+	case dbtypes.PresencesInsertKey:
+		res = s.OnInsertPresences(ctx, data.PresencesInsert)
+	default:
+		log.Infow("presence db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write presence db event to db warn %v key: %s event:%s", res, dbtypes.PresenceDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write presence db event to db warn %v key: %s event:%s", res, dbtypes.PresenceDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Errorf("write presence db event to db error %v key: %s", res, dbtypes.PresenceDBEventKeyToStr(key))
-			}
+			log.Errorf("write presence db event to db error %v key: %s", res, dbtypes.PresenceDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("PresenceDBEVConsumer process %s takes %d", dbtypes.PresenceDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("PresenceDBEVConsumer process %s takes %d", dbtypes.PresenceDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -115,17 +115,12 @@ func NewPresenceDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 1)
-	for i := uint64(0); i < 1; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "presenceDbEvErrs.txt"
 	s.recoverName = "presenceDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(1, s.processEvent)
 	return s
 }
 
@@ -141,10 +136,7 @@ func (s *PresenceDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *PresenceDBEVConsumer) Start() {
-	for i := uint64(0); i < 1; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -162,18 +154,8 @@ func (s *PresenceDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *PresenceDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.PresencesInsertKey:
-		chanID = 0
-	default:
-		log.Infow("presence db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *PresenceDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *PresenceDBEVConsumer) Report(mon monitor.LabeledGauge) {
@@ -265,7 +247,7 @@ func (s *PresenceDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
@@ -42,9 +42,8 @@ func init() {
 }
 
 type PublicRoomDBEVConsumer struct {
-	db model.PublicRoomAPIDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.PublicRoomAPIDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -55,52 +54,53 @@ type PublicRoomDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *PublicRoomDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *PublicRoomDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.PublicRoomDBEvents
-		switch key {
-		case dbtypes.PublicRoomInsertKey:
-			res = s.onInsertNewRoom(ctx, data.PublicRoomInsert)
-		case dbtypes.PublicRoomUpdateKey:
-			res = s.onUpdateRoomAttribute(ctx, data.PublicRoomUpdate)
-		case dbtypes.PublicRoomIncrementJoinedKey:
-			res = s.onIncrementJoinedMembersInRoom(ctx, data.PublicRoomJoined)
-		case dbtypes.PublicRoomDecrementJoinedKey:
-			res = s.onDecrementJoinedMembersInRoom(ctx, data.PublicRoomJoined)
-		default:
-			res = nil
-			log.Infow("public room api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.PublicRoomDBEvents
+	switch key {
+	case dbtypes.PublicRoomInsertKey:
+		res = s.onInsertNewRoom(ctx, data.PublicRoomInsert)
+	case dbtypes.PublicRoomUpdateKey:
+		res = s.onUpdateRoomAttribute(ctx, data.PublicRoomUpdate)
+	case dbtypes.PublicRoomIncrementJoinedKey:
+		res = s.onIncrementJoinedMembersInRoom(ctx, data.PublicRoomJoined)
+	case dbtypes.PublicRoomDecrementJoinedKey:
+		res = s.onDecrementJoinedMembersInRoom(ctx, data.PublicRoomJoined)
+	default:
+		log.Infow("public room api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write public room api db event to db warn %v key: %s event:%s", res, dbtypes.PublicRoomDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write public room api db event to db warn %v key: %s event:%s", res, dbtypes.PublicRoomDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Errorf("write public room api db event to db error %v key: %s", res, dbtypes.PublicRoomDBEventKeyToStr(key))
-			}
+			log.Errorf("write public room api db event to db error %v key: %s", res, dbtypes.PublicRoomDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("PublicRoomDBEVConsumer process %s takes %d", dbtypes.PublicRoomDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("PublicRoomDBEVConsumer process %s takes %d", dbtypes.PublicRoomDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -121,17 +121,12 @@ func NewPublicRoomDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 1)
-	for i := uint64(0); i < 1; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "publicRoomDbEvErrs.txt"
 	s.recoverName = "publicRoomDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(1, s.processEvent)
 	return s
 }
 
@@ -147,10 +142,7 @@ func (s *PublicRoomDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *PublicRoomDBEVConsumer) Start() {
-	for i := uint64(0); i < 1; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -169,18 +161,8 @@ func (s *PublicRoomDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *PublicRoomDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.PublicRoomInsertKey, dbtypes.PublicRoomUpdateKey, dbtypes.PublicRoomIncrementJoinedKey, dbtypes.PublicRoomDecrementJoinedKey:
-		chanID = 0
-	default:
-		log.Infow("public room api db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *PublicRoomDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *PublicRoomDBEVConsumer) onUpdateRoomAttribute(
@@ -291,7 +273,7 @@ func (s *PublicRoomDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
@@ -41,9 +41,8 @@ func init() {
 }
 
 type PushDBEVConsumer struct {
-	db model.PushAPIDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.PushAPIDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -54,58 +53,59 @@ type PushDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *PushDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *PushDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.PushDBEvents
-		switch key {
-		case dbtypes.PusherDeleteKey:
-			res = s.onPusherDelete(ctx, data.PusherDelete)
-		case dbtypes.PusherDeleteByKeyKey:
-			res = s.onPusherDeleteByKey(ctx, data.PusherDeleteByKey)
-		case dbtypes.PusherDeleteByKeyOnlyKey:
-			res = s.onPusherDeleteByKeyOnly(ctx, data.PusherDeleteByKeyOnly)
-		case dbtypes.PusherInsertKey:
-			res = s.onPusherInsert(ctx, data.PusherInsert)
-		case dbtypes.PushRuleUpsertKey:
-			res = s.onPushRuleInsert(ctx, data.PushRuleInert)
-		case dbtypes.PushRuleDeleteKey:
-			res = s.onPushRuleDelete(ctx, data.PushRuleDelete)
-		case dbtypes.PushRuleEnableUpsetKey:
-			res = s.onPushRuleEnableInsert(ctx, data.PushRuleEnableInsert)
-		default:
-			res = nil
-			log.Infow("push db event: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.PushDBEvents
+	switch key {
+	case dbtypes.PusherDeleteKey:
+		res = s.onPusherDelete(ctx, data.PusherDelete)
+	case dbtypes.PusherDeleteByKeyKey:
+		res = s.onPusherDeleteByKey(ctx, data.PusherDeleteByKey)
+	case dbtypes.PusherDeleteByKeyOnlyKey:
+		res = s.onPusherDeleteByKeyOnly(ctx, data.PusherDeleteByKeyOnly)
+	case dbtypes.PusherInsertKey:
+		res = s.onPusherInsert(ctx, data.PusherInsert)
+	case dbtypes.PushRuleUpsertKey:
+		res = s.onPushRuleInsert(ctx, data.PushRuleInert)
+	case dbtypes.PushRuleDeleteKey:
+		res = s.onPushRuleDelete(ctx, data.PushRuleDelete)
+	case dbtypes.PushRuleEnableUpsetKey:
+		res = s.onPushRuleEnableInsert(ctx, data.PushRuleEnableInsert)
+	default:
+		log.Infow("push db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write push db event to db warn %v key: %s event:%s", res, dbtypes.PushDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write push db event to db warn %v key: %s event:%s", res, dbtypes.PushDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Error("write push db event to db error %v key: %s", res, dbtypes.PushDBEventKeyToStr(key))
-			}
+			log.Error("write push db event to db error %v key: %s", res, dbtypes.PushDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("PushDBEVConsumer process %s takes %d", dbtypes.PushDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("PushDBEVConsumer process %s takes %d", dbtypes.PushDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -126,17 +126,12 @@ func NewPushDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 3)
-	for i := uint64(0); i < 3; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "pushDbEvErrs.txt"
 	s.recoverName = "pushDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(3, s.processEvent)
 	return s
 }
 
@@ -152,10 +147,7 @@ func (s *PushDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *PushDBEVConsumer) Start() {
-	for i := uint64(0); i < 3; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -173,22 +165,8 @@ func (s *PushDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *PushDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.PusherDeleteKey, dbtypes.PusherDeleteByKeyKey, dbtypes.PusherDeleteByKeyOnlyKey, dbtypes.PusherInsertKey:
-		chanID = 0
-	case dbtypes.PushRuleUpsertKey, dbtypes.PushRuleDeleteKey:
-		chanID = 1
-	case dbtypes.PushRuleEnableUpsetKey:
-		chanID = 2
-	default:
-		log.Infow("push db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *PushDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *PushDBEVConsumer) onPusherDelete(
@@ -316,7 +294,7 @@ func (s *PushDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
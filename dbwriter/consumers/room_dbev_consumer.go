@@ -42,9 +42,8 @@ func init() {
 }
 
 type RoomDBEVConsumer struct {
-	db model.RoomServerDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.RoomServerDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -55,76 +54,77 @@ type RoomDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *RoomDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *RoomDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.RoomDBEvents
-		switch key {
-		case dbtypes.EventJsonInsertKey:
-			res = s.onEventJsonInsert(ctx, data.EventJsonInsert)
-		case dbtypes.EventInsertKey:
-			res = s.onEventInsert(ctx, data.EventInsert)
-		case dbtypes.EventRoomInsertKey:
-			res = s.onEventRoomInsert(ctx, data.EventRoomInsert)
-		case dbtypes.EventRoomUpdateKey:
-			res = s.onEventRoomUpdate(ctx, data.EventRoomUpdate)
-		case dbtypes.EventStateSnapInsertKey:
-			res = s.onEventStateSnapInsert(ctx, data.EventStateSnapInsert)
-		case dbtypes.EventInviteInsertKey:
-			res = s.onEventInviteInsert(ctx, data.EventInviteInsert)
-		case dbtypes.EventInviteUpdateKey:
-			res = s.onEventInviteUpdate(ctx, data.EventInviteUpdate)
-		case dbtypes.EventMembershipInsertKey:
-			res = s.onEventMembershipInsert(ctx, data.EventMembershipInsert)
-		case dbtypes.EventMembershipUpdateKey:
-			res = s.onEventMembershipUpdate(ctx, data.EventMembershipUpdate)
-		case dbtypes.EventMembershipForgetUpdateKey:
-			res = s.onEventMembershipForgetUpdate(ctx, data.EventMembershipForgetUpdate)
-		case dbtypes.AliasInsertKey:
-			res = s.onAliasInsert(ctx, data.AliaseInsert)
-		case dbtypes.AliasDeleteKey:
-			res = s.onAliasDelete(ctx, data.AliaseDelete)
-		case dbtypes.RoomDomainInsertKey:
-			res = s.onRoomDomainInsert(ctx, data.RoomDomainInsert)
-		case dbtypes.RoomEventUpdateKey:
-			res = s.onRoomEventUpdate(ctx, data.RoomEventUpdate)
-		case dbtypes.RoomDepthUpdateKey:
-			res = s.onRoomDepthUpdate(ctx, data.RoomDepthUpdate)
-		case dbtypes.SettingUpsertKey:
-			res = s.onSettingUpdate(ctx, data.SettingsInsert)
-		default:
-			res = nil
-			log.Infow("room server dbevent: ignoring unknown output type", log.KeysAndValues{"key", key})
-		}
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.RoomDBEvents
+	switch key {
+	case dbtypes.EventJsonInsertKey:
+		res = s.onEventJsonInsert(ctx, data.EventJsonInsert)
+	case dbtypes.EventInsertKey:
+		res = s.onEventInsert(ctx, data.EventInsert)
+	case dbtypes.EventRoomInsertKey:
+		res = s.onEventRoomInsert(ctx, data.EventRoomInsert)
+	case dbtypes.EventRoomUpdateKey:
+		res = s.onEventRoomUpdate(ctx, data.EventRoomUpdate)
+	case dbtypes.EventStateSnapInsertKey:
+		res = s.onEventStateSnapInsert(ctx, data.EventStateSnapInsert)
+	case dbtypes.EventInviteInsertKey:
+		res = s.onEventInviteInsert(ctx, data.EventInviteInsert)
+	case dbtypes.EventInviteUpdateKey:
+		res = s.onEventInviteUpdate(ctx, data.EventInviteUpdate)
+	case dbtypes.EventMembershipInsertKey:
+		res = s.onEventMembershipInsert(ctx, data.EventMembershipInsert)
+	case dbtypes.EventMembershipUpdateKey:
+		res = s.onEventMembershipUpdate(ctx, data.EventMembershipUpdate)
+	case dbtypes.EventMembershipForgetUpdateKey:
+		res = s.onEventMembershipForgetUpdate(ctx, data.EventMembershipForgetUpdate)
+	case dbtypes.AliasInsertKey:
+		res = s.onAliasInsert(ctx, data.AliaseInsert)
+	case dbtypes.AliasDeleteKey:
+		res = s.onAliasDelete(ctx, data.AliaseDelete)
+	case dbtypes.RoomDomainInsertKey:
+		res = s.onRoomDomainInsert(ctx, data.RoomDomainInsert)
+	case dbtypes.RoomEventUpdateKey:
+		res = s.onRoomEventUpdate(ctx, data.RoomEventUpdate)
+	case dbtypes.RoomDepthUpdateKey:
+		res = s.onRoomDepthUpdate(ctx, data.RoomDepthUpdate)
+	case dbtypes.SettingUpsertKey:
+		res = s.onSettingUpdate(ctx, data.SettingsInsert)
+	default:
+		log.Infow("room server dbevent: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write room db event to db cmd %s warn %v event:%s", dbtypes.RoomDBEventKeyToStr(key), res, string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write room db event to db cmd %s warn %v event:%s", dbtypes.RoomDBEventKeyToStr(key), res, string(bytes))
-			} else {
-				log.Errorf("write room db event to db cmd %s error %v", dbtypes.RoomDBEventKeyToStr(key), res)
-			}
+			log.Errorf("write room db event to db cmd %s error %v", dbtypes.RoomDBEventKeyToStr(key), res)
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("RoomDBEVConsumer process %s takes %d", dbtypes.RoomDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("RoomDBEVConsumer process %s takes %d", dbtypes.RoomDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -145,17 +145,12 @@ func NewRoomDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 8)
-	for i := uint64(0); i < 8; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "roomDbEvErrs.txt"
 	s.recoverName = "roomDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(8, s.processEvent)
 	return s
 }
 
@@ -171,10 +166,7 @@ func (s *RoomDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *RoomDBEVConsumer) Start() {
-	for i := uint64(0); i < 8; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -192,32 +184,8 @@ func (s *RoomDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *RoomDBEVConsumer) OnMessage(ctx context.Context, dbev *dbtypes.DBEvent) error {
-	chanid := 0
-	switch dbev.Key {
-	case dbtypes.EventJsonInsertKey:
-		chanid = 0
-	case dbtypes.EventInsertKey, dbtypes.RoomEventUpdateKey:
-		chanid = 1
-	case dbtypes.EventRoomInsertKey, dbtypes.EventRoomUpdateKey, dbtypes.RoomDepthUpdateKey:
-		chanid = 2
-	case dbtypes.EventStateSnapInsertKey, dbtypes.SettingUpsertKey:
-		chanid = 3
-	case dbtypes.EventInviteInsertKey, dbtypes.EventInviteUpdateKey:
-		chanid = 4
-	case dbtypes.EventMembershipInsertKey, dbtypes.EventMembershipUpdateKey, dbtypes.EventMembershipForgetUpdateKey:
-		chanid = 5
-	case dbtypes.AliasInsertKey, dbtypes.AliasDeleteKey:
-		chanid = 6
-	case dbtypes.RoomDomainInsertKey:
-		chanid = 7
-	default:
-		log.Infow("room server dbevent: ignoring unknown output type", log.KeysAndValues{"key", dbev.Key})
-		return nil
-	}
-
-	s.msgChan[chanid] <- common.ContextMsg{Ctx: ctx, Msg: dbev}
-	return nil
+func (s *RoomDBEVConsumer) OnMessage(ctx context.Context, dbev *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbev, done)
 }
 
 func (s *RoomDBEVConsumer) Report(mon monitor.LabeledGauge) {
@@ -472,7 +440,7 @@ func (s *RoomDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
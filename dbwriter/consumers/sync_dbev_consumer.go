@@ -41,9 +41,8 @@ func init() {
 }
 
 type SyncDBEVConsumer struct {
-	db model.SyncAPIDatabase
-	//msgChan     []chan *dbtypes.DBEvent
-	msgChan     []chan common.ContextMsg
+	db          model.SyncAPIDatabase
+	workers     *dbEventWorkers
 	monState    []*DBMonItem
 	path        string
 	fileName    string
@@ -54,100 +53,101 @@ type SyncDBEVConsumer struct {
 	cfg         *config.Dendrite
 }
 
-func (s *SyncDBEVConsumer) startWorker(msgChan chan common.ContextMsg) error {
+// processEvent writes the dbevent to the db, a failed write is kept in the
+// recover file with retry_flush_db or returned to the channel otherwise
+func (s *SyncDBEVConsumer) processEvent(ctx context.Context, output *dbtypes.DBEvent) error {
 	var res error
-	for msg := range msgChan {
-		ctx := msg.Ctx
-		output := msg.Msg.(*dbtypes.DBEvent)
-		start := time.Now().UnixNano() / 1000000
-
-		key := output.Key
-		data := output.SyncDBEvents
-		switch key {
-		case dbtypes.SyncEventInsertKey:
-			if data.SyncEventInsert != nil {
-				res = s.onSyncEventInsert(ctx, data.SyncEventInsert)
-			}
-		case dbtypes.SyncRoomStateUpdateKey:
-			if data.SyncRoomStateUpdate != nil {
-				res = s.onSyncRoomStateUpdate(ctx, data.SyncRoomStateUpdate)
-			}
-		case dbtypes.SyncClientDataInsertKey:
-			if data.SyncClientDataInsert != nil {
-				res = s.onSyncClientDataInsert(ctx, data.SyncClientDataInsert)
-			}
-		case dbtypes.SyncKeyStreamInsertKey:
-			if data.SyncKeyStreamInsert != nil {
-				res = s.onSyncKeyStreamInsert(ctx, data.SyncKeyStreamInsert)
-			}
-		case dbtypes.SyncReceiptInsertKey:
-			if data.SyncReceiptInsert != nil {
-				res = s.onSyncReceiptInsert(ctx, data.SyncReceiptInsert)
-			}
-		case dbtypes.SyncStdEventInertKey:
-			if data.SyncStdEventInsert != nil {
-				res = s.onSyncStdEventInsert(ctx, data.SyncStdEventInsert)
-			}
-		case dbtypes.SyncStdEventDeleteKey:
-			if data.SyncStdEventDelete != nil {
-				res = s.onSyncStdEventDelete(ctx, data.SyncStdEventDelete)
-			}
-		case dbtypes.SyncMacStdEventDeleteKey:
-			if data.SyncMacStdEventDelete != nil {
-				res = s.onSyncMacStdEventDelete(ctx, data.SyncMacStdEventDelete)
-			}
-		case dbtypes.SyncDeviceStdEventDeleteKey:
-			if data.SyncStdEventDelete != nil {
-				res = s.onSyncDeviceStdEventDelete(ctx, data.SyncStdEventDelete)
-			}
-		case dbtypes.SyncPresenceInsertKey:
-			if data.SyncPresenceInsert != nil {
-				res = s.onSyncPresenceInsert(ctx, data.SyncPresenceInsert)
-			}
-		case dbtypes.SyncUserReceiptInsertKey:
-			if data.SyncUserReceiptInsert != nil {
-				res = s.onSyncUserReceiptInsert(ctx, data.SyncUserReceiptInsert)
-			}
-		case dbtypes.SyncUserTimeLineInsertKey:
-			if data.SyncUserTimeLineInsert != nil {
-				res = s.onSyncUserTimeLineInsert(ctx, data.SyncUserTimeLineInsert)
-			}
-		case dbtypes.SyncOutputMinStreamInsertKey:
-			if data.SyncOutputMinStreamInsert != nil {
-				res = s.onSyncOutputMinStreamInsert(ctx, data.SyncOutputMinStreamInsert)
-			}
-		case dbtypes.SyncEventUpdateKey:
-			if data.SyncEventUpdate != nil {
-				res = s.onSyncOutputEventUpdate(ctx, data.SyncEventUpdate)
-			}
-		default:
-			res = nil
-			log.Infow("sync api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+	start := time.Now().UnixNano() / 1000000
+
+	key := output.Key
+	data := output.SyncDBEvents
+	switch key {
+	case dbtypes.SyncEventInsertKey:
+		if data.SyncEventInsert != nil {
+			res = s.onSyncEventInsert(ctx, data.SyncEventInsert)
+		}
+	case dbtypes.SyncRoomStateUpdateKey:
+		if data.SyncRoomStateUpdate != nil {
+			res = s.onSyncRoomStateUpdate(ctx, data.SyncRoomStateUpdate)
+		}
+	case dbtypes.SyncClientDataInsertKey:
+		if data.SyncClientDataInsert != nil {
+			res = s.onSyncClientDataInsert(ctx, data.SyncClientDataInsert)
+		}
+	case dbtypes.SyncKeyStreamInsertKey:
+		if data.SyncKeyStreamInsert != nil {
+			res = s.onSyncKeyStreamInsert(ctx, data.SyncKeyStreamInsert)
+		}
+	case dbtypes.SyncReceiptInsertKey:
+		if data.SyncReceiptInsert != nil {
+			res = s.onSyncReceiptInsert(ctx, data.SyncReceiptInsert)
+		}
+	case dbtypes.SyncStdEventInertKey:
+		if data.SyncStdEventInsert != nil {
+			res = s.onSyncStdEventInsert(ctx, data.SyncStdEventInsert)
+		}
+	case dbtypes.SyncStdEventDeleteKey:
+		if data.SyncStdEventDelete != nil {
+			res = s.onSyncStdEventDelete(ctx, data.SyncStdEventDelete)
+		}
+	case dbtypes.SyncMacStdEventDeleteKey:
+		if data.SyncMacStdEventDelete != nil {
+			res = s.onSyncMacStdEventDelete(ctx, data.SyncMacStdEventDelete)
+		}
+	case dbtypes.SyncDeviceStdEventDeleteKey:
+		if data.SyncStdEventDelete != nil {
+			res = s.onSyncDeviceStdEventDelete(ctx, data.SyncStdEventDelete)
+		}
+	case dbtypes.SyncPresenceInsertKey:
+		if data.SyncPresenceInsert != nil {
+			res = s.onSyncPresenceInsert(ctx, data.SyncPresenceInsert)
+		}
+	case dbtypes.SyncUserReceiptInsertKey:
+		if data.SyncUserReceiptInsert != nil {
+			res = s.onSyncUserReceiptInsert(ctx, data.SyncUserReceiptInsert)
+		}
+	case dbtypes.SyncUserTimeLineInsertKey:
+		if data.SyncUserTimeLineInsert != nil {
+			res = s.onSyncUserTimeLineInsert(ctx, data.SyncUserTimeLineInsert)
 		}
+	case dbtypes.SyncOutputMinStreamInsertKey:
+		if data.SyncOutputMinStreamInsert != nil {
+			res = s.onSyncOutputMinStreamInsert(ctx, data.SyncOutputMinStreamInsert)
+		}
+	case dbtypes.SyncEventUpdateKey:
+		if data.SyncEventUpdate != nil {
+			res = s.onSyncOutputEventUpdate(ctx, data.SyncEventUpdate)
+		}
+	default:
+		log.Infow("sync api db event: ignoring unknown output type", log.KeysAndValues{"key", key})
+		return nil
+	}
 
-		item := s.monState[key]
-		if res == nil {
-			atomic.AddInt32(&item.process, 1)
+	item := s.monState[key]
+	if res == nil {
+		atomic.AddInt32(&item.process, 1)
+	} else {
+		atomic.AddInt32(&item.fail, 1)
+		if s.IsDump(res.Error()) {
+			bytes, _ := json.Marshal(output)
+			log.Warnf("write sync api db event to db warn %v key: %s event:%s", res, dbtypes.SyncDBEventKeyToStr(key), string(bytes))
 		} else {
-			atomic.AddInt32(&item.fail, 1)
-			if s.IsDump(res.Error()) {
-				bytes, _ := json.Marshal(output)
-				log.Warnf("write sync api db event to db warn %v key: %s event:%s", res, dbtypes.SyncDBEventKeyToStr(key), string(bytes))
-			} else {
-				log.Errorf("write sync api db event to db error %v key: %s", res, dbtypes.SyncDBEventKeyToStr(key))
-			}
+			log.Errorf("write sync api db event to db error %v key: %s", res, dbtypes.SyncDBEventKeyToStr(key))
 		}
+	}
 
-		if res != nil {
-			if s.cfg.RetryFlushDB && !s.IsDump(res.Error()) {
-				s.processError(output)
-			}
-		}
+	now := time.Now().UnixNano() / 1000000
+	log.Infof("SyncDBEVConsumer process %s takes %d", dbtypes.SyncDBEventKeyToStr(key), now-start)
 
-		now := time.Now().UnixNano() / 1000000
-		log.Infof("SyncDBEVConsumer process %s takes %d", dbtypes.SyncDBEventKeyToStr(key), now-start)
+	if res == nil || s.IsDump(res.Error()) {
+		return nil
 	}
-
+	if s.cfg.RetryFlushDB {
+		// written again from the recover file
+		s.processError(output)
+		return nil
+	}
+	// retried by the channel and parked in the dead letter topic
 	return res
 }
 
@@ -168,17 +168,12 @@ func NewSyncDBEVConsumer() ConsumerInterface {
 		}
 	}
 
-	//init worker
-	s.msgChan = make([]chan common.ContextMsg, 10)
-	for i := uint64(0); i < 10; i++ {
-		s.msgChan[i] = make(chan common.ContextMsg, 4096)
-	}
-
 	s.mutex = new(sync.Mutex)
 	s.recvMutex = new(sync.Mutex)
 	s.fileName = "syncDbEvErrs.txt"
 	s.recoverName = "syncDbEvRecover.txt"
 	s.ticker = time.NewTimer(600)
+	s.workers = newDBEventWorkers(10, s.processEvent)
 	return s
 }
 
@@ -194,10 +189,7 @@ func (s *SyncDBEVConsumer) Prepare(cfg *config.Dendrite) {
 }
 
 func (s *SyncDBEVConsumer) Start() {
-	for i := uint64(0); i < 10; i++ {
-		go s.startWorker(s.msgChan[i])
-	}
-
+	s.workers.start()
 	go s.startRecover()
 }
 
@@ -215,36 +207,8 @@ func (s *SyncDBEVConsumer) startRecover() {
 	}
 }
 
-func (s *SyncDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent) error {
-	chanID := 0
-	switch dbEv.Key {
-	case dbtypes.SyncEventInsertKey, dbtypes.SyncEventUpdateKey:
-		chanID = 0
-	case dbtypes.SyncRoomStateUpdateKey:
-		chanID = 1
-	case dbtypes.SyncClientDataInsertKey:
-		chanID = 2
-	case dbtypes.SyncKeyStreamInsertKey:
-		chanID = 3
-	case dbtypes.SyncReceiptInsertKey:
-		chanID = 4
-	case dbtypes.SyncStdEventInertKey, dbtypes.SyncStdEventDeleteKey, dbtypes.SyncDeviceStdEventDeleteKey, dbtypes.SyncMacStdEventDeleteKey:
-		chanID = 5
-	case dbtypes.SyncPresenceInsertKey:
-		chanID = 6
-	case dbtypes.SyncUserReceiptInsertKey:
-		chanID = 7
-	case dbtypes.SyncUserTimeLineInsertKey:
-		chanID = 8
-	case dbtypes.SyncOutputMinStreamInsertKey:
-		chanID = 9
-	default:
-		log.Infow("sync api db event: ignoring unknown output type", log.KeysAndValues{"key", dbEv.Key})
-		return nil
-	}
-
-	s.msgChan[chanID] <- common.ContextMsg{Ctx: ctx, Msg: dbEv}
-	return nil
+func (s *SyncDBEVConsumer) OnMessage(ctx context.Context, dbEv *dbtypes.DBEvent, done func(error)) {
+	s.workers.push(ctx, dbEv, done)
 }
 
 func (s *SyncDBEVConsumer) onSyncEventInsert(
@@ -338,7 +302,7 @@ func (s *SyncDBEVConsumer) onSyncOutputEventUpdate(
 }
 
 func (s *SyncDBEVConsumer) Report(mon monitor.LabeledGauge) {
-	for i := int64(0); i < dbtypes.E2EMaxKey; i++ {
+	for i := int64(0); i < dbtypes.SyncMaxKey; i++ {
 		item := s.monState[i]
 		if item != nil {
 			mon.WithLabelValues("monolith", item.tablenamse, item.method, "process").Set(float64(atomic.LoadInt32(&item.process)))
@@ -420,7 +384,7 @@ func (s *SyncDBEVConsumer) recover(ctx context.Context) {
 				continue
 			}
 
-			s.OnMessage(ctx, &dbEv)
+			s.OnMessage(ctx, &dbEv, nil)
 		}
 
 		f.Close()
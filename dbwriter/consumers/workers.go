@@ -0,0 +1,68 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package consumers
+
+import (
+	"context"
+
+	"github.com/finogeeks/ligase/model/dbtypes"
+)
+
+const dbEventQueueSize = 4096
+
+type dbEventMsg struct {
+	ctx    context.Context
+	output *dbtypes.DBEvent
+	done   func(error)
+}
+
+// dbEventWorkers writes the dbevents of a category in parallel, the dbevents
+// of a room or user (DBEvent.Uid) are queued to the same worker and keep
+// their order. done reports the result of a write when it is not nil.
+type dbEventWorkers struct {
+	queues  []chan dbEventMsg
+	process func(context.Context, *dbtypes.DBEvent) error
+}
+
+func newDBEventWorkers(count int, process func(context.Context, *dbtypes.DBEvent) error) *dbEventWorkers {
+	w := &dbEventWorkers{
+		queues:  make([]chan dbEventMsg, count),
+		process: process,
+	}
+	for i := range w.queues {
+		w.queues[i] = make(chan dbEventMsg, dbEventQueueSize)
+	}
+	return w
+}
+
+func (w *dbEventWorkers) start() {
+	for i := range w.queues {
+		go w.startWorker(w.queues[i])
+	}
+}
+
+func (w *dbEventWorkers) startWorker(queue chan dbEventMsg) {
+	for msg := range queue {
+		err := w.process(msg.ctx, msg.output)
+		if msg.done != nil {
+			msg.done(err)
+		}
+	}
+}
+
+func (w *dbEventWorkers) push(ctx context.Context, output *dbtypes.DBEvent, done func(error)) {
+	idx := uint64(output.Uid) % uint64(len(w.queues))
+	w.queues[idx] <- dbEventMsg{ctx: ctx, output: output, done: done}
+}
@@ -41,6 +41,7 @@ type InternalMsgConsumer struct {
 
 	cache        service.Cache
 	encryptionDB model.EncryptorAPIDatabase
+	accountDB    model.AccountsDatabase
 	syncDB       model.SyncAPIDatabase
 	idg          *uid.UidGenerator
 	federation   *gomatrixserverlib.FederationClient
@@ -51,6 +52,7 @@ func NewInternalMsgConsumer(
 	cfg config.Dendrite,
 	encryptionDB model.EncryptorAPIDatabase,
 	syncDB model.SyncAPIDatabase,
+	accountDB model.AccountsDatabase,
 	idg *uid.UidGenerator,
 	cache service.Cache,
 	rpcCli *common.RpcClient,
@@ -62,6 +64,7 @@ func NewInternalMsgConsumer(
 	c.RpcCli = rpcCli
 	c.encryptionDB = encryptionDB
 	c.syncDB = syncDB
+	c.accountDB = accountDB
 	c.idg = idg
 	c.cache = cache
 	c.federation = federation
@@ -83,6 +86,8 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqPostUploadKey{})
 	apiconsumer.SetAPIProcessor(ReqPostQueryKey{})
 	apiconsumer.SetAPIProcessor(ReqPostClaimKey{})
+	apiconsumer.SetAPIProcessor(ReqPostDeviceSigningUpload{})
+	apiconsumer.SetAPIProcessor(ReqPostSignaturesUpload{})
 }
 
 type ReqPostUploadKeyByDeviceID struct{}
@@ -180,7 +185,7 @@ func (ReqPostQueryKey) Process(ctx context.Context, consumer interface{}, msg co
 	c := consumer.(*InternalMsgConsumer)
 	req := msg.(*external.PostQueryKeysRequest)
 	return routing.QueryPKeys(
-		ctx, req, device.ID, c.cache, c.federation, c.serverName,
+		ctx, req, device, c.cache, c.federation, c.serverName,
 	)
 }
 
@@ -214,3 +219,75 @@ func (ReqPostClaimKey) Process(ctx context.Context, consumer interface{}, msg co
 		ctx, req, c.cache, c.encryptionDB, c.RpcCli,
 	)
 }
+
+type ReqPostDeviceSigningUpload struct{}
+
+func (ReqPostDeviceSigningUpload) GetRoute() string       { return "/keys/device_signing/upload" }
+func (ReqPostDeviceSigningUpload) GetMetricsName() string { return "upload cross-signing keys" }
+func (ReqPostDeviceSigningUpload) GetMsgType() int32 {
+	return internals.MSG_POST_KEYS_DEVICE_SIGNING
+}
+func (ReqPostDeviceSigningUpload) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqPostDeviceSigningUpload) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostDeviceSigningUpload) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostDeviceSigningUpload) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPostDeviceSigningUpload) NewRequest() core.Coder {
+	return new(external.PostDeviceSigningKeysUploadRequest)
+}
+func (ReqPostDeviceSigningUpload) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostDeviceSigningKeysUploadRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostDeviceSigningUpload) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPostDeviceSigningUpload) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostDeviceSigningKeysUploadRequest)
+	return routing.UploadCrossSigningKeys(
+		ctx, req, device, &c.Cfg, c.accountDB, c.encryptionDB,
+		c.cache, c.RpcCli, c.syncDB, c.idg,
+	)
+}
+
+type ReqPostSignaturesUpload struct{}
+
+func (ReqPostSignaturesUpload) GetRoute() string       { return "/keys/signatures/upload" }
+func (ReqPostSignaturesUpload) GetMetricsName() string { return "upload signatures" }
+func (ReqPostSignaturesUpload) GetMsgType() int32 {
+	return internals.MSG_POST_KEYS_SIGNATURES
+}
+func (ReqPostSignaturesUpload) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqPostSignaturesUpload) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostSignaturesUpload) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostSignaturesUpload) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPostSignaturesUpload) NewRequest() core.Coder {
+	return new(external.PostSignaturesUploadRequest)
+}
+func (ReqPostSignaturesUpload) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostSignaturesUploadRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostSignaturesUpload) NewResponse(code int) core.Coder {
+	return new(external.PostSignaturesUploadResponse)
+}
+func (ReqPostSignaturesUpload) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostSignaturesUploadRequest)
+	return routing.UploadSignatures(
+		ctx, req, device, c.encryptionDB, c.cache,
+		c.RpcCli, c.syncDB, c.idg,
+	)
+}
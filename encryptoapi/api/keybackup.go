@@ -0,0 +1,503 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/encryptoapi/routing"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostRoomKeysVersion{})
+	apiconsumer.SetAPIProcessor(ReqGetRoomKeysVersion{})
+	apiconsumer.SetAPIProcessor(ReqGetRoomKeysVersionByID{})
+	apiconsumer.SetAPIProcessor(ReqPutRoomKeysVersionByID{})
+	apiconsumer.SetAPIProcessor(ReqDelRoomKeysVersionByID{})
+	apiconsumer.SetAPIProcessor(ReqGetRoomKeys{})
+	apiconsumer.SetAPIProcessor(ReqPutRoomKeys{})
+	apiconsumer.SetAPIProcessor(ReqDelRoomKeys{})
+	apiconsumer.SetAPIProcessor(ReqGetRoomKeysByRoom{})
+	apiconsumer.SetAPIProcessor(ReqPutRoomKeysByRoom{})
+	apiconsumer.SetAPIProcessor(ReqDelRoomKeysByRoom{})
+	apiconsumer.SetAPIProcessor(ReqGetRoomKeysBySession{})
+	apiconsumer.SetAPIProcessor(ReqPutRoomKeysBySession{})
+	apiconsumer.SetAPIProcessor(ReqDelRoomKeysBySession{})
+}
+
+type ReqPostRoomKeysVersion struct{}
+
+func (ReqPostRoomKeysVersion) GetRoute() string       { return "/room_keys/version" }
+func (ReqPostRoomKeysVersion) GetMetricsName() string { return "create room keys backup" }
+func (ReqPostRoomKeysVersion) GetMsgType() int32      { return internals.MSG_POST_ROOM_KEYS_VERSION }
+func (ReqPostRoomKeysVersion) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostRoomKeysVersion) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostRoomKeysVersion) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostRoomKeysVersion) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPostRoomKeysVersion) NewRequest() core.Coder {
+	return new(external.PostRoomKeysVersionRequest)
+}
+func (ReqPostRoomKeysVersion) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostRoomKeysVersionRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+func (ReqPostRoomKeysVersion) NewResponse(code int) core.Coder {
+	return new(external.PostRoomKeysVersionResponse)
+}
+func (ReqPostRoomKeysVersion) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostRoomKeysVersionRequest)
+	return routing.CreateKeyBackupVersion(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
+
+type ReqGetRoomKeysVersion struct{}
+
+func (ReqGetRoomKeysVersion) GetRoute() string       { return "/room_keys/version" }
+func (ReqGetRoomKeysVersion) GetMetricsName() string { return "get room keys backup" }
+func (ReqGetRoomKeysVersion) GetMsgType() int32      { return internals.MSG_GET_ROOM_KEYS_VERSION }
+func (ReqGetRoomKeysVersion) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetRoomKeysVersion) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetRoomKeysVersion) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetRoomKeysVersion) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqGetRoomKeysVersion) NewRequest() core.Coder {
+	return new(external.GetRoomKeysVersionRequest)
+}
+func (ReqGetRoomKeysVersion) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	return nil
+}
+func (ReqGetRoomKeysVersion) NewResponse(code int) core.Coder {
+	return new(external.GetRoomKeysVersionResponse)
+}
+func (ReqGetRoomKeysVersion) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetRoomKeysVersionRequest)
+	return routing.GetKeyBackupVersion(ctx, req, device.UserID, c.cache)
+}
+
+type ReqGetRoomKeysVersionByID struct{}
+
+func (ReqGetRoomKeysVersionByID) GetRoute() string       { return "/room_keys/version/{version}" }
+func (ReqGetRoomKeysVersionByID) GetMetricsName() string { return "get room keys backup" }
+func (ReqGetRoomKeysVersionByID) GetMsgType() int32      { return internals.MSG_GET_ROOM_KEYS_VERSION_BY_ID }
+func (ReqGetRoomKeysVersionByID) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetRoomKeysVersionByID) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetRoomKeysVersionByID) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetRoomKeysVersionByID) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqGetRoomKeysVersionByID) NewRequest() core.Coder {
+	return new(external.GetRoomKeysVersionRequest)
+}
+func (ReqGetRoomKeysVersionByID) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetRoomKeysVersionRequest)
+	if vars != nil {
+		msg.Version = vars["version"]
+	}
+	return nil
+}
+func (ReqGetRoomKeysVersionByID) NewResponse(code int) core.Coder {
+	return new(external.GetRoomKeysVersionResponse)
+}
+func (ReqGetRoomKeysVersionByID) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetRoomKeysVersionRequest)
+	return routing.GetKeyBackupVersion(ctx, req, device.UserID, c.cache)
+}
+
+type ReqPutRoomKeysVersionByID struct{}
+
+func (ReqPutRoomKeysVersionByID) GetRoute() string       { return "/room_keys/version/{version}" }
+func (ReqPutRoomKeysVersionByID) GetMetricsName() string { return "update room keys backup" }
+func (ReqPutRoomKeysVersionByID) GetMsgType() int32      { return internals.MSG_PUT_ROOM_KEYS_VERSION_BY_ID }
+func (ReqPutRoomKeysVersionByID) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutRoomKeysVersionByID) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutRoomKeysVersionByID) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutRoomKeysVersionByID) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPutRoomKeysVersionByID) NewRequest() core.Coder {
+	return new(external.PutRoomKeysVersionRequest)
+}
+func (ReqPutRoomKeysVersionByID) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutRoomKeysVersionRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	msg.BodyVersion = msg.Version
+	if vars != nil {
+		msg.Version = vars["version"]
+	}
+	return nil
+}
+func (ReqPutRoomKeysVersionByID) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqPutRoomKeysVersionByID) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutRoomKeysVersionRequest)
+	return routing.UpdateKeyBackupVersion(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
+
+type ReqDelRoomKeysVersionByID struct{}
+
+func (ReqDelRoomKeysVersionByID) GetRoute() string       { return "/room_keys/version/{version}" }
+func (ReqDelRoomKeysVersionByID) GetMetricsName() string { return "delete room keys backup" }
+func (ReqDelRoomKeysVersionByID) GetMsgType() int32      { return internals.MSG_DEL_ROOM_KEYS_VERSION_BY_ID }
+func (ReqDelRoomKeysVersionByID) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqDelRoomKeysVersionByID) GetMethod() []string {
+	return []string{http.MethodDelete, http.MethodOptions}
+}
+func (ReqDelRoomKeysVersionByID) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqDelRoomKeysVersionByID) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqDelRoomKeysVersionByID) NewRequest() core.Coder {
+	return new(external.DelRoomKeysVersionRequest)
+}
+func (ReqDelRoomKeysVersionByID) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.DelRoomKeysVersionRequest)
+	if vars != nil {
+		msg.Version = vars["version"]
+	}
+	return nil
+}
+func (ReqDelRoomKeysVersionByID) NewResponse(code int) core.Coder {
+	return nil
+}
+func (ReqDelRoomKeysVersionByID) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.DelRoomKeysVersionRequest)
+	return routing.DeleteKeyBackupVersion(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
+
+type ReqGetRoomKeys struct{}
+
+func (ReqGetRoomKeys) GetRoute() string       { return "/room_keys/keys" }
+func (ReqGetRoomKeys) GetMetricsName() string { return "get room keys" }
+func (ReqGetRoomKeys) GetMsgType() int32      { return internals.MSG_GET_ROOM_KEYS }
+func (ReqGetRoomKeys) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetRoomKeys) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetRoomKeys) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetRoomKeys) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqGetRoomKeys) NewRequest() core.Coder {
+	return new(external.GetRoomKeysRequest)
+}
+func (ReqGetRoomKeys) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	return nil
+}
+func (ReqGetRoomKeys) NewResponse(code int) core.Coder {
+	return new(external.GetRoomKeysResponse)
+}
+func (ReqGetRoomKeys) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetRoomKeysRequest)
+	return routing.GetRoomKeys(ctx, req, device.UserID, c.cache)
+}
+
+type ReqPutRoomKeys struct{}
+
+func (ReqPutRoomKeys) GetRoute() string       { return "/room_keys/keys" }
+func (ReqPutRoomKeys) GetMetricsName() string { return "upload room keys" }
+func (ReqPutRoomKeys) GetMsgType() int32      { return internals.MSG_PUT_ROOM_KEYS }
+func (ReqPutRoomKeys) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutRoomKeys) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutRoomKeys) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutRoomKeys) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPutRoomKeys) NewRequest() core.Coder {
+	return new(external.PutRoomKeysRequest)
+}
+func (ReqPutRoomKeys) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutRoomKeysRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	msg.Version = req.URL.Query().Get("version")
+	return nil
+}
+func (ReqPutRoomKeys) NewResponse(code int) core.Coder {
+	if code == http.StatusForbidden {
+		return new(jsonerror.WrongRoomKeysVersionError)
+	}
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqPutRoomKeys) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutRoomKeysRequest)
+	return routing.UploadRoomKeys(ctx, device.UserID, req.Version, req.Rooms, c.encryptionDB, c.cache)
+}
+
+type ReqDelRoomKeys struct{}
+
+func (ReqDelRoomKeys) GetRoute() string       { return "/room_keys/keys" }
+func (ReqDelRoomKeys) GetMetricsName() string { return "delete room keys" }
+func (ReqDelRoomKeys) GetMsgType() int32      { return internals.MSG_DEL_ROOM_KEYS }
+func (ReqDelRoomKeys) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqDelRoomKeys) GetMethod() []string {
+	return []string{http.MethodDelete, http.MethodOptions}
+}
+func (ReqDelRoomKeys) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqDelRoomKeys) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqDelRoomKeys) NewRequest() core.Coder {
+	return new(external.DelRoomKeysRequest)
+}
+func (ReqDelRoomKeys) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.DelRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	return nil
+}
+func (ReqDelRoomKeys) NewResponse(code int) core.Coder {
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqDelRoomKeys) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.DelRoomKeysRequest)
+	return routing.DeleteRoomKeys(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
+
+type ReqGetRoomKeysByRoom struct{}
+
+func (ReqGetRoomKeysByRoom) GetRoute() string       { return "/room_keys/keys/{roomID}" }
+func (ReqGetRoomKeysByRoom) GetMetricsName() string { return "get room keys" }
+func (ReqGetRoomKeysByRoom) GetMsgType() int32      { return internals.MSG_GET_ROOM_KEYS_BY_ROOM }
+func (ReqGetRoomKeysByRoom) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetRoomKeysByRoom) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetRoomKeysByRoom) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetRoomKeysByRoom) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqGetRoomKeysByRoom) NewRequest() core.Coder {
+	return new(external.GetRoomKeysRequest)
+}
+func (ReqGetRoomKeysByRoom) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+	}
+	return nil
+}
+func (ReqGetRoomKeysByRoom) NewResponse(code int) core.Coder {
+	return new(external.GetRoomKeysByRoomResponse)
+}
+func (ReqGetRoomKeysByRoom) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetRoomKeysRequest)
+	return routing.GetRoomKeysByRoom(ctx, req, device.UserID, c.cache)
+}
+
+type ReqPutRoomKeysByRoom struct{}
+
+func (ReqPutRoomKeysByRoom) GetRoute() string       { return "/room_keys/keys/{roomID}" }
+func (ReqPutRoomKeysByRoom) GetMetricsName() string { return "upload room keys" }
+func (ReqPutRoomKeysByRoom) GetMsgType() int32      { return internals.MSG_PUT_ROOM_KEYS_BY_ROOM }
+func (ReqPutRoomKeysByRoom) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutRoomKeysByRoom) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutRoomKeysByRoom) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutRoomKeysByRoom) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPutRoomKeysByRoom) NewRequest() core.Coder {
+	return new(external.PutRoomKeysByRoomRequest)
+}
+func (ReqPutRoomKeysByRoom) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutRoomKeysByRoomRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+	}
+	return nil
+}
+func (ReqPutRoomKeysByRoom) NewResponse(code int) core.Coder {
+	if code == http.StatusForbidden {
+		return new(jsonerror.WrongRoomKeysVersionError)
+	}
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqPutRoomKeysByRoom) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutRoomKeysByRoomRequest)
+	return routing.UploadRoomKeys(
+		ctx, device.UserID, req.Version,
+		map[string]external.RoomKeyBackup{req.RoomID: {Sessions: req.Sessions}},
+		c.encryptionDB, c.cache,
+	)
+}
+
+type ReqDelRoomKeysByRoom struct{}
+
+func (ReqDelRoomKeysByRoom) GetRoute() string       { return "/room_keys/keys/{roomID}" }
+func (ReqDelRoomKeysByRoom) GetMetricsName() string { return "delete room keys" }
+func (ReqDelRoomKeysByRoom) GetMsgType() int32      { return internals.MSG_DEL_ROOM_KEYS_BY_ROOM }
+func (ReqDelRoomKeysByRoom) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqDelRoomKeysByRoom) GetMethod() []string {
+	return []string{http.MethodDelete, http.MethodOptions}
+}
+func (ReqDelRoomKeysByRoom) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqDelRoomKeysByRoom) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqDelRoomKeysByRoom) NewRequest() core.Coder {
+	return new(external.DelRoomKeysRequest)
+}
+func (ReqDelRoomKeysByRoom) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.DelRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+	}
+	return nil
+}
+func (ReqDelRoomKeysByRoom) NewResponse(code int) core.Coder {
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqDelRoomKeysByRoom) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.DelRoomKeysRequest)
+	return routing.DeleteRoomKeys(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
+
+type ReqGetRoomKeysBySession struct{}
+
+func (ReqGetRoomKeysBySession) GetRoute() string       { return "/room_keys/keys/{roomID}/{sessionID}" }
+func (ReqGetRoomKeysBySession) GetMetricsName() string { return "get room keys" }
+func (ReqGetRoomKeysBySession) GetMsgType() int32      { return internals.MSG_GET_ROOM_KEYS_BY_SESSION }
+func (ReqGetRoomKeysBySession) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetRoomKeysBySession) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetRoomKeysBySession) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetRoomKeysBySession) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqGetRoomKeysBySession) NewRequest() core.Coder {
+	return new(external.GetRoomKeysRequest)
+}
+func (ReqGetRoomKeysBySession) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+		msg.SessionID = vars["sessionID"]
+	}
+	return nil
+}
+func (ReqGetRoomKeysBySession) NewResponse(code int) core.Coder {
+	return new(external.GetRoomKeysBySessionResponse)
+}
+func (ReqGetRoomKeysBySession) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetRoomKeysRequest)
+	return routing.GetRoomKeysBySession(ctx, req, device.UserID, c.cache)
+}
+
+type ReqPutRoomKeysBySession struct{}
+
+func (ReqPutRoomKeysBySession) GetRoute() string       { return "/room_keys/keys/{roomID}/{sessionID}" }
+func (ReqPutRoomKeysBySession) GetMetricsName() string { return "upload room keys" }
+func (ReqPutRoomKeysBySession) GetMsgType() int32      { return internals.MSG_PUT_ROOM_KEYS_BY_SESSION }
+func (ReqPutRoomKeysBySession) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPutRoomKeysBySession) GetMethod() []string {
+	return []string{http.MethodPut, http.MethodOptions}
+}
+func (ReqPutRoomKeysBySession) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPutRoomKeysBySession) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqPutRoomKeysBySession) NewRequest() core.Coder {
+	return new(external.PutRoomKeysBySessionRequest)
+}
+func (ReqPutRoomKeysBySession) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PutRoomKeysBySessionRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+		msg.SessionID = vars["sessionID"]
+	}
+	return nil
+}
+func (ReqPutRoomKeysBySession) NewResponse(code int) core.Coder {
+	if code == http.StatusForbidden {
+		return new(jsonerror.WrongRoomKeysVersionError)
+	}
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqPutRoomKeysBySession) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PutRoomKeysBySessionRequest)
+	return routing.UploadRoomKeys(
+		ctx, device.UserID, req.Version,
+		map[string]external.RoomKeyBackup{
+			req.RoomID: {Sessions: map[string]external.KeyBackupData{req.SessionID: req.KeyBackupData}},
+		},
+		c.encryptionDB, c.cache,
+	)
+}
+
+type ReqDelRoomKeysBySession struct{}
+
+func (ReqDelRoomKeysBySession) GetRoute() string       { return "/room_keys/keys/{roomID}/{sessionID}" }
+func (ReqDelRoomKeysBySession) GetMetricsName() string { return "delete room keys" }
+func (ReqDelRoomKeysBySession) GetMsgType() int32      { return internals.MSG_DEL_ROOM_KEYS_BY_SESSION }
+func (ReqDelRoomKeysBySession) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqDelRoomKeysBySession) GetMethod() []string {
+	return []string{http.MethodDelete, http.MethodOptions}
+}
+func (ReqDelRoomKeysBySession) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqDelRoomKeysBySession) GetPrefix() []string                  { return []string{"r0", "unstable"} }
+func (ReqDelRoomKeysBySession) NewRequest() core.Coder {
+	return new(external.DelRoomKeysRequest)
+}
+func (ReqDelRoomKeysBySession) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.DelRoomKeysRequest)
+	msg.Version = req.URL.Query().Get("version")
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+		msg.SessionID = vars["sessionID"]
+	}
+	return nil
+}
+func (ReqDelRoomKeysBySession) NewResponse(code int) core.Coder {
+	return new(external.PutRoomKeysResponse)
+}
+func (ReqDelRoomKeysBySession) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.DelRoomKeysRequest)
+	return routing.DeleteRoomKeys(ctx, req, device.UserID, c.encryptionDB, c.cache)
+}
@@ -22,8 +22,10 @@ import (
 	"github.com/finogeeks/ligase/common/basecomponent"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/encryptoapi/api"
+	"github.com/finogeeks/ligase/encryptoapi/rpc"
 	"github.com/finogeeks/ligase/model/service"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/finogeeks/ligase/storage/model"
 )
 
@@ -42,13 +44,19 @@ func SetupEncryptApi(
 ) model.EncryptorAPIDatabase {
 	encryptionDB := base.CreateEncryptApiDB()
 	syncDB := base.CreateSyncDB()
+	accountDB := base.CreateAccountsDB()
 	serverName := base.Cfg.Matrix.ServerName
 
 	apiConsumer := api.NewInternalMsgConsumer(
-		*base.Cfg, encryptionDB, syncDB,
+		*base.Cfg, encryptionDB, syncDB, accountDB,
 		idg, cache, rpcClient, federation, serverName,
 	)
 	apiConsumer.Start()
 
+	deviceListRpcConsumer := rpc.NewDeviceListRpcConsumer(rpcClient, cache, federation, syncDB, idg, serverName)
+	if err := deviceListRpcConsumer.Start(); err != nil {
+		log.Panicf("failed to start device list rpc consumer err:%v", err)
+	}
+
 	return encryptionDB
 }
@@ -0,0 +1,448 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	clientrouting "github.com/finogeeks/ligase/clientapi/routing"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	log "github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+	"golang.org/x/crypto/ed25519"
+)
+
+// notifyKeyChange records a key change of the user and tells the sync
+// servers, the user turns up in device_lists.changed of the users sharing a
+// room with the user. deviceID is set when the keys of a device changed, the
+// other servers sharing a room are sent a device list update then, the offset
+// becomes the stream id of the device list served over /user/devices. The
+// update carries the current keys of the device, or marks it deleted.
+func notifyKeyChange(
+	ctx context.Context,
+	userID, deviceID string,
+	deleted bool,
+	cache service.Cache,
+	rpcClient *common.RpcClient,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+) error {
+	var prevOffset int64
+	if deviceID != "" {
+		prevOffset = deviceListStreamID(ctx, userID, cache, syncDB)
+	}
+
+	offset, _ := idg.Next()
+	if err := syncDB.InsertKeyChange(ctx, userID, offset); err != nil {
+		return err
+	}
+	if deviceID != "" {
+		if err := cache.SetDeviceListStreamID(userID, offset); err != nil {
+			return err
+		}
+	}
+
+	changed := types.DeviceKeyChanges{
+		ChangedUserID:   userID,
+		ChangedDeviceID: deviceID,
+		Offset:          offset,
+		PrevOffset:      prevOffset,
+		Deleted:         deleted,
+	}
+	if deviceID != "" && !deleted {
+		deviceKeysQueryMap := make(map[string]external.DeviceKeys)
+		loadDeviceKeys(deviceKeysQueryMap, userID, deviceID, cache)
+		if key, ok := deviceKeysQueryMap[deviceID]; ok {
+			changed.DeviceDisplayName = key.Unsigned.DeviceDisplayName
+			changed.Keys = localDeviceKeys(&key)
+		} else if device := cache.GetDeviceByDeviceID(deviceID, userID); device != nil {
+			changed.DeviceDisplayName = device.DisplayName
+		}
+	}
+	content := types.KeyUpdateContent{
+		Type:             types.DEVICEKEYUPDATE,
+		DeviceKeyChanges: []types.DeviceKeyChanges{changed},
+	}
+	bytes, err := json.Marshal(content)
+	if err != nil {
+		return err
+	}
+	rpcClient.Pub(types.KeyUpdateTopicDef, bytes)
+	return nil
+}
+
+// deviceListStreamID returns the stream id of the last device list change of
+// the user. It lives in the cache, when the cache lost it the last key change
+// recorded in the sync db stands in, the servers which didn't see that one
+// as a stream id resync the device list over /user/devices.
+func deviceListStreamID(ctx context.Context, userID string, cache service.Cache, syncDB model.SyncAPIDatabase) int64 {
+	if streamID, ok := cache.GetDeviceListStreamID(userID); ok {
+		return streamID
+	}
+	_, offsets, err := syncDB.GetHistoryKeyChangeStream(ctx, []string{userID})
+	if err != nil || len(offsets) == 0 {
+		return 0
+	}
+	return offsets[len(offsets)-1]
+}
+
+// crossSigningPublicKey returns the key id and the public key of a
+// cross-signing key, it holds a single ed25519 key named after itself
+func crossSigningPublicKey(keys map[string]string) (string, ed25519.PublicKey, error) {
+	if len(keys) != 1 {
+		return "", nil, fmt.Errorf("cross-signing key must hold a single key")
+	}
+	for keyID, key := range keys {
+		if keyID != "ed25519:"+key {
+			return "", nil, fmt.Errorf("invalid cross-signing key id %s", keyID)
+		}
+		var pub gomatrixserverlib.Base64String
+		if err := pub.Decode(key); err != nil || len(pub) != ed25519.PublicKeySize {
+			return "", nil, fmt.Errorf("invalid cross-signing key %s", key)
+		}
+		return keyID, ed25519.PublicKey(pub), nil
+	}
+	return "", nil, nil
+}
+
+func checkCrossSigningKey(key *external.CrossSigningKey, userID, usage string) error {
+	if key.UserID != userID {
+		return fmt.Errorf("%s key of another user", usage)
+	}
+	hasUsage := false
+	for _, u := range key.Usage {
+		if u == usage {
+			hasUsage = true
+		}
+	}
+	if !hasUsage {
+		return fmt.Errorf("%s key lacks the %s usage", usage, usage)
+	}
+	_, _, err := crossSigningPublicKey(key.Keys)
+	return err
+}
+
+// verifyCrossSigningKey checks the key is signed by the master key
+func verifyCrossSigningKey(key *external.CrossSigningKey, userID, masterKeyID string, masterKey ed25519.PublicKey) error {
+	bytes, err := json.Marshal(key)
+	if err != nil {
+		return err
+	}
+	return gomatrixserverlib.VerifyJSON(userID, gomatrixserverlib.KeyID(masterKeyID), masterKey, bytes)
+}
+
+// deviceSigningKey returns the ed25519 key of the device, empty when the
+// device hasn't uploaded its keys
+func deviceSigningKey(cache service.Cache, userID, deviceID string) string {
+	keyIDs, _ := cache.GetDeviceKeyIDs(userID, deviceID)
+	for _, keyID := range keyIDs {
+		key, exists := cache.GetDeviceKey(keyID)
+		if exists && key.UserID != "" && key.KeyAlgorithm == "ed25519" {
+			return key.Key
+		}
+	}
+	return ""
+}
+
+func sameCrossSigningKey(key *external.CrossSigningKey, existing *types.CrossSigningKey) bool {
+	if key == nil {
+		return true
+	}
+	return existing != nil && reflect.DeepEqual(key.Keys, existing.Keys)
+}
+
+// UploadCrossSigningKeys implements POST /keys/device_signing/upload. The
+// first upload of a user needs no auth, replacing the keys does.
+func UploadCrossSigningKeys(
+	ctx context.Context,
+	req *external.PostDeviceSigningKeysUploadRequest,
+	device *authtypes.Device,
+	cfg *config.Dendrite,
+	accountDB model.AccountsDatabase,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+	rpcClient *common.RpcClient,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+) (int, core.Coder) {
+	userID := device.UserID
+	if req.MasterKey == nil && req.SelfSigningKey == nil && req.UserSigningKey == nil {
+		return http.StatusBadRequest, jsonerror.MissingArgument("no cross-signing key to upload")
+	}
+
+	existing, _ := cache.GetCrossSigningKeys(userID)
+	master := existing[types.CrossSigningMasterKey]
+	if master != nil && (!sameCrossSigningKey(req.MasterKey, master) ||
+		!sameCrossSigningKey(req.SelfSigningKey, existing[types.CrossSigningSelfSigningKey]) ||
+		!sameCrossSigningKey(req.UserSigningKey, existing[types.CrossSigningUserSigningKey])) {
+		if code, resp := clientrouting.CheckAccountAuth(ctx, &req.Auth, userID, cfg, accountDB); resp != nil {
+			return code, resp
+		}
+	}
+
+	var masterKeys map[string]string
+	if req.MasterKey != nil {
+		if err := checkCrossSigningKey(req.MasterKey, userID, types.CrossSigningMasterKey); err != nil {
+			return http.StatusBadRequest, jsonerror.InvalidArgumentValue(err.Error())
+		}
+		masterKeys = req.MasterKey.Keys
+	} else if master != nil {
+		masterKeys = master.Keys
+	} else {
+		return http.StatusBadRequest, jsonerror.MissingArgument("master_key must be uploaded first")
+	}
+	masterKeyID, masterKey, err := crossSigningPublicKey(masterKeys)
+	if err != nil {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue(err.Error())
+	}
+
+	uploads := map[string]*external.CrossSigningKey{
+		types.CrossSigningMasterKey:      req.MasterKey,
+		types.CrossSigningSelfSigningKey: req.SelfSigningKey,
+		types.CrossSigningUserSigningKey: req.UserSigningKey,
+	}
+	for keyType, key := range uploads {
+		if key == nil || keyType == types.CrossSigningMasterKey {
+			continue
+		}
+		if err := checkCrossSigningKey(key, userID, keyType); err != nil {
+			return http.StatusBadRequest, jsonerror.InvalidArgumentValue(err.Error())
+		}
+		if err := verifyCrossSigningKey(key, userID, masterKeyID, masterKey); err != nil {
+			return http.StatusBadRequest, jsonerror.InvalidSignature(fmt.Sprintf("%s key isn't signed by the master key: %v", keyType, err))
+		}
+	}
+
+	for keyType, key := range uploads {
+		if key == nil {
+			continue
+		}
+		keyInfo, err := json.Marshal(key)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		if err := encryptionDB.InsertCrossSigningKey(ctx, userID, keyType, string(keyInfo)); err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		if err := cache.SetCrossSigningKey(userID, keyType, string(keyInfo)); err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+	}
+	log.Infof("user %s device %s uploaded cross-signing keys", userID, device.ID)
+
+	if err := notifyKeyChange(ctx, userID, "", false, cache, rpcClient, syncDB, idg); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	return http.StatusOK, nil
+}
+
+// signedKey is the part of a signed device key or cross-signing key the
+// signature upload looks at
+type signedKey struct {
+	UserID     string                       `json:"user_id"`
+	Keys       map[string]string            `json:"keys"`
+	Signatures map[string]map[string]string `json:"signatures"`
+}
+
+// UploadSignatures implements POST /keys/signatures/upload. Users sign their
+// devices with the self-signing key, their master key with their devices and
+// the master key of other users with the user-signing key.
+func UploadSignatures(
+	ctx context.Context,
+	req *external.PostSignaturesUploadRequest,
+	device *authtypes.Device,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+	rpcClient *common.RpcClient,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+) (int, core.Coder) {
+	userID := device.UserID
+	ownKeys, _ := cache.GetCrossSigningKeys(userID)
+	resp := &external.PostSignaturesUploadResponse{Failures: make(map[string]map[string]interface{})}
+
+	stored := false
+	for targetUserID, signedKeys := range *req {
+		for targetKeyID, raw := range signedKeys {
+			sigs, failure := checkSignatures(userID, targetUserID, targetKeyID, raw, ownKeys, cache)
+			if failure == nil {
+				for originKeyID, sig := range sigs {
+					if err := encryptionDB.InsertCrossSigningSig(ctx, userID, originKeyID, targetUserID, targetKeyID, sig); err != nil {
+						return httputil.LogThenErrorCtx(ctx, err)
+					}
+					if err := cache.SetCrossSigningSig(userID, originKeyID, targetUserID, targetKeyID, sig); err != nil {
+						return httputil.LogThenErrorCtx(ctx, err)
+					}
+				}
+				stored = true
+				continue
+			}
+			if resp.Failures[targetUserID] == nil {
+				resp.Failures[targetUserID] = make(map[string]interface{})
+			}
+			resp.Failures[targetUserID][targetKeyID] = failure
+		}
+	}
+
+	if stored {
+		if err := notifyKeyChange(ctx, userID, "", false, cache, rpcClient, syncDB, idg); err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+	}
+	return http.StatusOK, resp
+}
+
+// checkSignatures returns the valid signatures of the user over the target
+// key by origin key id
+func checkSignatures(
+	userID, targetUserID, targetKeyID string,
+	raw []byte,
+	ownKeys map[string]*types.CrossSigningKey,
+	cache service.Cache,
+) (map[string]string, *jsonerror.MatrixError) {
+	var signed signedKey
+	if err := json.Unmarshal(raw, &signed); err != nil {
+		return nil, jsonerror.BadJSON("invalid signed key")
+	}
+	if signed.UserID != targetUserID {
+		return nil, jsonerror.InvalidArgumentValue("user_id of the signed key doesn't match")
+	}
+
+	// the keys which may sign the target key by key id
+	signers := make(map[string]ed25519.PublicKey)
+	addSigner := func(keys map[string]string) {
+		if keyID, pub, err := crossSigningPublicKey(keys); err == nil {
+			signers[keyID] = pub
+		}
+	}
+
+	master := ownKeys[types.CrossSigningMasterKey]
+	if targetUserID == userID {
+		if master != nil && targetKeyID == strings.TrimPrefix(firstKeyID(master.Keys), "ed25519:") {
+			if !reflect.DeepEqual(signed.Keys, master.Keys) {
+				return nil, jsonerror.InvalidArgumentValue("signed key doesn't match the master key")
+			}
+			for signerKeyID := range signed.Signatures[userID] {
+				deviceID := strings.TrimPrefix(signerKeyID, "ed25519:")
+				var pub gomatrixserverlib.Base64String
+				if key := deviceSigningKey(cache, userID, deviceID); key != "" && pub.Decode(key) == nil {
+					signers[signerKeyID] = ed25519.PublicKey(pub)
+				}
+			}
+		} else {
+			key := deviceSigningKey(cache, userID, targetKeyID)
+			if key == "" {
+				return nil, jsonerror.NotFound("unknown device")
+			}
+			if signed.Keys["ed25519:"+targetKeyID] != key {
+				return nil, jsonerror.InvalidArgumentValue("signed key doesn't match the device key")
+			}
+			if selfSigning := ownKeys[types.CrossSigningSelfSigningKey]; selfSigning != nil {
+				addSigner(selfSigning.Keys)
+			}
+		}
+	} else {
+		if _, _, err := crossSigningPublicKey(signed.Keys); err != nil || targetKeyID != strings.TrimPrefix(firstKeyID(signed.Keys), "ed25519:") {
+			return nil, jsonerror.InvalidArgumentValue("only master keys of other users can be signed")
+		}
+		if targetKeys, ok := cache.GetCrossSigningKeys(targetUserID); ok {
+			if targetMaster := targetKeys[types.CrossSigningMasterKey]; targetMaster != nil && !reflect.DeepEqual(signed.Keys, targetMaster.Keys) {
+				return nil, jsonerror.InvalidArgumentValue("signed key doesn't match the master key")
+			}
+		}
+		if userSigning := ownKeys[types.CrossSigningUserSigningKey]; userSigning != nil {
+			addSigner(userSigning.Keys)
+		}
+	}
+
+	sigs := make(map[string]string)
+	for signerKeyID, pub := range signers {
+		sig, ok := signed.Signatures[userID][signerKeyID]
+		if !ok {
+			continue
+		}
+		if err := gomatrixserverlib.VerifyJSON(userID, gomatrixserverlib.KeyID(signerKeyID), pub, raw); err != nil {
+			return nil, jsonerror.InvalidSignature(fmt.Sprintf("invalid signature of %s: %v", signerKeyID, err))
+		}
+		sigs[signerKeyID] = sig
+	}
+	if len(sigs) == 0 {
+		return nil, jsonerror.InvalidSignature("no signature of a key allowed to sign the target key")
+	}
+	return sigs, nil
+}
+
+func firstKeyID(keys map[string]string) string {
+	for keyID := range keys {
+		return keyID
+	}
+	return ""
+}
+
+// queryCrossSigningKeys fills the cross-signing keys of the user, the
+// signatures of other users are only shown to themselves
+func queryCrossSigningKeys(
+	queryRp *external.PostQueryKeysResponse,
+	userID, requester string,
+	cache service.Cache,
+) {
+	keys, ok := cache.GetCrossSigningKeys(userID)
+	if !ok {
+		return
+	}
+	if master := keys[types.CrossSigningMasterKey]; master != nil {
+		key := external.CrossSigningKey(*master)
+		sigs, _ := cache.GetCrossSigningSigs(userID, strings.TrimPrefix(firstKeyID(master.Keys), "ed25519:"))
+		for _, sig := range sigs {
+			if sig.OriginUserID != userID && sig.OriginUserID != requester {
+				continue
+			}
+			key.Signatures = addSignature(key.Signatures, sig)
+		}
+		queryRp.MasterKeys[userID] = key
+	}
+	if selfSigning := keys[types.CrossSigningSelfSigningKey]; selfSigning != nil {
+		queryRp.SelfSigningKeys[userID] = external.CrossSigningKey(*selfSigning)
+	}
+	if userSigning := keys[types.CrossSigningUserSigningKey]; userSigning != nil && userID == requester {
+		queryRp.UserSigningKeys[userID] = external.CrossSigningKey(*userSigning)
+	}
+}
+
+func addSignature(signatures map[string]map[string]string, sig types.CrossSigningSig) map[string]map[string]string {
+	if signatures == nil {
+		signatures = make(map[string]map[string]string)
+	}
+	if signatures[sig.OriginUserID] == nil {
+		signatures[sig.OriginUserID] = make(map[string]string)
+	}
+	signatures[sig.OriginUserID][sig.OriginKeyID] = sig.Signature
+	return signatures
+}
@@ -0,0 +1,55 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"testing"
+
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"golang.org/x/crypto/ed25519"
+)
+
+func newCrossSigningKey(t *testing.T, userID, usage string) *external.CrossSigningKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	encoded := base64.RawStdEncoding.EncodeToString(pub)
+	return &external.CrossSigningKey{
+		UserID: userID,
+		Usage:  []string{usage},
+		Keys:   map[string]string{"ed25519:" + encoded: encoded},
+	}
+}
+
+func TestCheckCrossSigningKey(t *testing.T) {
+	userID := "@alice:example.com"
+	master := newCrossSigningKey(t, userID, "master")
+	if err := checkCrossSigningKey(master, userID, "master"); err != nil {
+		t.Errorf("want valid master key, got %v", err)
+	}
+	if err := checkCrossSigningKey(master, "@bob:example.com", "master"); err == nil {
+		t.Errorf("want key of another user rejected")
+	}
+	if err := checkCrossSigningKey(master, userID, "self_signing"); err == nil {
+		t.Errorf("want key without the usage rejected")
+	}
+	master.Keys["ed25519:other"] = "other"
+	if err := checkCrossSigningKey(master, userID, "master"); err == nil {
+		t.Errorf("want key holding two keys rejected")
+	}
+}
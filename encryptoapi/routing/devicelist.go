@@ -0,0 +1,181 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	log "github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// ProcessDeviceListUpdate records the device list update of a remote user,
+// the local users sharing a room with the user see it in device_lists.changed.
+// A cached device list of the user is patched with the update, or resynced
+// over /user/devices when the update doesn't follow the cached stream id.
+// Updates of local users come from the client api when a device is deleted,
+// the other servers sharing a room with the user are told so.
+func ProcessDeviceListUpdate(
+	ctx context.Context,
+	update *types.DeviceListUpdateContent,
+	cache service.Cache,
+	federation *gomatrixserverlib.FederationClient,
+	rpcClient *common.RpcClient,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+	serverName []string,
+) error {
+	domain, _ := common.DomainFromID(update.UserID)
+	if common.CheckValidDomain(domain, serverName) {
+		return notifyKeyChange(ctx, update.UserID, update.DeviceID, update.Deleted, cache, rpcClient, syncDB, idg)
+	}
+	if devices, ok := cache.GetRemoteDeviceList(update.UserID); ok {
+		if applyDeviceListUpdate(devices, update) {
+			if err := cache.SetRemoteDeviceList(devices); err != nil {
+				return err
+			}
+		} else if _, err := resyncDeviceList(ctx, update.UserID, cache, federation); err != nil {
+			// drop the stale list, the next query fetches it again
+			log.Warnf("resync device list of user %s error %v", update.UserID, err)
+			cache.DelRemoteDeviceList(update.UserID)
+		}
+	}
+	return notifyKeyChange(ctx, update.UserID, "", false, cache, rpcClient, syncDB, idg)
+}
+
+// applyDeviceListUpdate patches the cached device list with the update, it
+// returns false when a prev_id of the update is missing from the list or the
+// update doesn't carry the keys, the list needs a resync then
+func applyDeviceListUpdate(devices *gomatrixserverlib.RespUserDevices, update *types.DeviceListUpdateContent) bool {
+	if update.StreamID <= devices.StreamID {
+		return true
+	}
+	for _, prevID := range update.PrevID {
+		if prevID != devices.StreamID {
+			return false
+		}
+	}
+
+	idx := -1
+	for i := range devices.Devices {
+		if devices.Devices[i].DeviceID == update.DeviceID {
+			idx = i
+			break
+		}
+	}
+	if update.Deleted {
+		if idx >= 0 {
+			devices.Devices = append(devices.Devices[:idx], devices.Devices[idx+1:]...)
+		}
+	} else {
+		if update.Keys == nil {
+			return false
+		}
+		device := gomatrixserverlib.RespUserDevice{
+			DeviceID:    update.DeviceID,
+			DisplayName: update.DeviceDisplayName,
+			Keys:        *update.Keys,
+		}
+		if idx >= 0 {
+			devices.Devices[idx] = device
+		} else {
+			devices.Devices = append(devices.Devices, device)
+		}
+	}
+	devices.StreamID = update.StreamID
+	return true
+}
+
+// resyncDeviceList fetches the device list of a remote user from the server
+// of the user and caches it
+func resyncDeviceList(
+	ctx context.Context,
+	userID string,
+	cache service.Cache,
+	federation *gomatrixserverlib.FederationClient,
+) (*gomatrixserverlib.RespUserDevices, error) {
+	server, err := common.DomainFromID(userID)
+	if err != nil {
+		return nil, err
+	}
+	devices, err := federation.GetUserDevices(ctx, gomatrixserverlib.ServerName(server), userID)
+	if err != nil {
+		return nil, err
+	}
+	devices.UserID = userID
+	if err := cache.SetRemoteDeviceList(&devices); err != nil {
+		log.Warnf("cache device list of user %s error %v", userID, err)
+	}
+	return &devices, nil
+}
+
+// mergeRemoteDeviceList adds the keys of the remote user from the device list,
+// all devices of the user when deviceIDs is empty
+func mergeRemoteDeviceList(queryRp *external.PostQueryKeysResponse, uid string, deviceIDs []string, devices *gomatrixserverlib.RespUserDevices) {
+	wanted := make(map[string]bool, len(deviceIDs))
+	for _, deviceID := range deviceIDs {
+		wanted[deviceID] = true
+	}
+	for _, device := range devices.Devices {
+		if len(wanted) > 0 && !wanted[device.DeviceID] {
+			continue
+		}
+		keys := remoteDeviceKeys(&device.Keys)
+		if device.DisplayName != "" {
+			keys.Unsigned.DeviceDisplayName = device.DisplayName
+		}
+		queryRp.DeviceKeys[uid][device.DeviceID] = keys
+	}
+	if devices.MasterKey != nil {
+		queryRp.MasterKeys[uid] = external.CrossSigningKey(*devices.MasterKey)
+	}
+	if devices.SelfSigningKey != nil {
+		queryRp.SelfSigningKeys[uid] = external.CrossSigningKey(*devices.SelfSigningKey)
+	}
+}
+
+func remoteDeviceKeys(key *gomatrixserverlib.DeviceKeysQuery) external.DeviceKeys {
+	return external.DeviceKeys{
+		UserID:     key.UserID,
+		DeviceID:   key.DeviceID,
+		Algorithms: key.Algorithm,
+		Keys:       key.Keys,
+		Signatures: key.Signature,
+		Unsigned: external.UnsignedDeviceInfo{
+			DeviceDisplayName: key.Unsigned.Info,
+		},
+	}
+}
+
+// localDeviceKeys converts the keys of a local device to the federation form
+// sent in m.device_list_update
+func localDeviceKeys(key *external.DeviceKeys) *gomatrixserverlib.DeviceKeysQuery {
+	return &gomatrixserverlib.DeviceKeysQuery{
+		UserID:    key.UserID,
+		DeviceID:  key.DeviceID,
+		Algorithm: key.Algorithms,
+		Keys:      key.Keys,
+		Signature: key.Signatures,
+		Unsigned: gomatrixserverlib.UnsignedDeviceInfo{
+			Info: key.Unsigned.DeviceDisplayName,
+		},
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+)
+
+func TestApplyDeviceListUpdate(t *testing.T) {
+	devices := &gomatrixserverlib.RespUserDevices{
+		UserID:   "@alice:example.com",
+		StreamID: 5,
+		Devices:  []gomatrixserverlib.RespUserDevice{{DeviceID: "JLAFKJWSCS"}},
+	}
+
+	keys := &gomatrixserverlib.DeviceKeysQuery{UserID: "@alice:example.com", DeviceID: "QBUAZIFURK"}
+	if !applyDeviceListUpdate(devices, &types.DeviceListUpdateContent{
+		UserID: "@alice:example.com", DeviceID: "QBUAZIFURK", StreamID: 6, PrevID: []int64{5}, Keys: keys,
+	}) {
+		t.Fatalf("want update following the stream applied")
+	}
+	if devices.StreamID != 6 || len(devices.Devices) != 2 {
+		t.Fatalf("want device added at stream 6, got %+v", devices)
+	}
+
+	if !applyDeviceListUpdate(devices, &types.DeviceListUpdateContent{
+		UserID: "@alice:example.com", DeviceID: "JLAFKJWSCS", StreamID: 7, PrevID: []int64{6}, Deleted: true,
+	}) {
+		t.Fatalf("want deletion applied")
+	}
+	if len(devices.Devices) != 1 || devices.Devices[0].DeviceID != "QBUAZIFURK" {
+		t.Fatalf("want device deleted, got %+v", devices.Devices)
+	}
+
+	if applyDeviceListUpdate(devices, &types.DeviceListUpdateContent{
+		UserID: "@alice:example.com", DeviceID: "QBUAZIFURK", StreamID: 9, PrevID: []int64{8}, Keys: keys,
+	}) {
+		t.Fatalf("want resync when prev_id is missing")
+	}
+	if applyDeviceListUpdate(devices, &types.DeviceListUpdateContent{
+		UserID: "@alice:example.com", DeviceID: "QBUAZIFURK", StreamID: 8, PrevID: []int64{7},
+	}) {
+		t.Fatalf("want resync when the update has no keys")
+	}
+}
+
+func TestLocalDeviceKeys(t *testing.T) {
+	key := &gomatrixserverlib.DeviceKeysQuery{
+		UserID:    "@alice:example.com",
+		DeviceID:  "JLAFKJWSCS",
+		Algorithm: []string{"m.olm.v1.curve25519-aes-sha2", "m.megolm.v1.aes-sha2"},
+		Keys:      map[string]string{"ed25519:JLAFKJWSCS": "lEuiRJBit0IG6nUf5pUzWTUEsRVVe/HJkoKuEww9ULI"},
+		Signature: map[string]map[string]string{
+			"@alice:example.com": {"ed25519:JLAFKJWSCS": "dSO80A01XiigH3uBiDVx/EjzaoycHcjq9lfQX0uWsqxl2giMIiSPR8a4d291W1ihKJL/a+myXS367WT6NAIcBA"},
+		},
+		Unsigned: gomatrixserverlib.UnsignedDeviceInfo{Info: "Alice's mobile phone"},
+	}
+	local := remoteDeviceKeys(key)
+	if got := localDeviceKeys(&local); !reflect.DeepEqual(got, key) {
+		t.Fatalf("want %+v, got %+v", key, got)
+	}
+}
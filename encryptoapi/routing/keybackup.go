@@ -0,0 +1,387 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/finogeeks/ligase/clientapi/httputil"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+// latestKeyBackupVersion returns the current backup version of the user, nil
+// when the user has none
+func latestKeyBackupVersion(cache service.Cache, userID string) *types.KeyBackupVersion {
+	versions, _ := cache.GetKeyBackupVersions(userID)
+	var latest *types.KeyBackupVersion
+	for _, v := range versions {
+		if !v.Deleted && (latest == nil || v.Version > latest.Version) {
+			latest = v
+		}
+	}
+	return latest
+}
+
+func getKeyBackupVersion(cache service.Cache, userID, version string) (*types.KeyBackupVersion, int, core.Coder) {
+	if version == "" {
+		return nil, http.StatusBadRequest, jsonerror.MissingArgument("version is required")
+	}
+	num, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return nil, http.StatusNotFound, jsonerror.NotFound("Unknown backup version")
+	}
+	versions, _ := cache.GetKeyBackupVersions(userID)
+	for _, v := range versions {
+		if v.Version == num && !v.Deleted {
+			return v, http.StatusOK, nil
+		}
+	}
+	return nil, http.StatusNotFound, jsonerror.NotFound("Unknown backup version")
+}
+
+func saveKeyBackupVersion(
+	ctx context.Context,
+	userID string,
+	v *types.KeyBackupVersion,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) error {
+	if err := encryptionDB.InsertKeyBackupVersion(ctx, userID, v.Version, v.Algorithm, v.AuthData, v.Etag, v.Deleted); err != nil {
+		return err
+	}
+	return cache.SetKeyBackupVersion(userID, v)
+}
+
+func keyBackupCount(cache service.Cache, userID string, version int64) int64 {
+	sessions, _ := cache.GetKeyBackups(userID, version)
+	return int64(len(sessions))
+}
+
+// isBetterKeyBackup tells whether the uploaded session should replace the
+// backed up one: a verified session beats an unverified one, then the session
+// which decrypts more messages, then the one forwarded fewer times
+func isBetterKeyBackup(data *external.KeyBackupData, old *types.KeyBackupSession) bool {
+	if data.IsVerified != old.IsVerified {
+		return data.IsVerified
+	}
+	if data.FirstMessageIndex != old.FirstMessageIndex {
+		return data.FirstMessageIndex < old.FirstMessageIndex
+	}
+	return data.ForwardedCount < old.ForwardedCount
+}
+
+func toKeyBackupData(session *types.KeyBackupSession) external.KeyBackupData {
+	return external.KeyBackupData{
+		FirstMessageIndex: session.FirstMessageIndex,
+		ForwardedCount:    session.ForwardedCount,
+		IsVerified:        session.IsVerified,
+		SessionData:       json.RawMessage(session.SessionData),
+	}
+}
+
+// CreateKeyBackupVersion implements POST /room_keys/version, the new version
+// becomes the current one
+func CreateKeyBackupVersion(
+	ctx context.Context,
+	req *external.PostRoomKeysVersionRequest,
+	userID string,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if req.Algorithm == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("algorithm is required")
+	}
+	if len(req.AuthData) == 0 {
+		return http.StatusBadRequest, jsonerror.MissingArgument("auth_data is required")
+	}
+
+	// numbers of deleted versions aren't handed out again, concurrent
+	// requests get different numbers
+	next, err := cache.NextKeyBackupVersion(userID)
+	if err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+
+	v := &types.KeyBackupVersion{
+		Version:   next,
+		Algorithm: req.Algorithm,
+		AuthData:  string(req.AuthData),
+	}
+	if err := saveKeyBackupVersion(ctx, userID, v, encryptionDB, cache); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	return http.StatusOK, &external.PostRoomKeysVersionResponse{Version: strconv.FormatInt(next, 10)}
+}
+
+// GetKeyBackupVersion implements GET /room_keys/version and
+// GET /room_keys/version/{version}, the current version when none is given
+func GetKeyBackupVersion(
+	ctx context.Context,
+	req *external.GetRoomKeysVersionRequest,
+	userID string,
+	cache service.Cache,
+) (int, core.Coder) {
+	var v *types.KeyBackupVersion
+	if req.Version == "" {
+		if v = latestKeyBackupVersion(cache, userID); v == nil {
+			return http.StatusNotFound, jsonerror.NotFound("No current backup version")
+		}
+	} else {
+		var code int
+		var resp core.Coder
+		if v, code, resp = getKeyBackupVersion(cache, userID, req.Version); v == nil {
+			return code, resp
+		}
+	}
+
+	return http.StatusOK, &external.GetRoomKeysVersionResponse{
+		Algorithm: v.Algorithm,
+		AuthData:  json.RawMessage(v.AuthData),
+		Count:     keyBackupCount(cache, userID, v.Version),
+		Etag:      strconv.FormatInt(v.Etag, 10),
+		Version:   strconv.FormatInt(v.Version, 10),
+	}
+}
+
+// UpdateKeyBackupVersion implements PUT /room_keys/version/{version}, only
+// the auth_data of a version may change
+func UpdateKeyBackupVersion(
+	ctx context.Context,
+	req *external.PutRoomKeysVersionRequest,
+	userID string,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+	if req.BodyVersion != "" && req.BodyVersion != req.Version {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("version in body doesn't match the path")
+	}
+	if req.Algorithm != v.Algorithm {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("algorithm of a backup version can't be changed")
+	}
+	if len(req.AuthData) == 0 {
+		return http.StatusBadRequest, jsonerror.MissingArgument("auth_data is required")
+	}
+
+	v.AuthData = string(req.AuthData)
+	if err := saveKeyBackupVersion(ctx, userID, v, encryptionDB, cache); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	return http.StatusOK, nil
+}
+
+// DeleteKeyBackupVersion implements DELETE /room_keys/version/{version}, the
+// keys of the version are deleted along
+func DeleteKeyBackupVersion(
+	ctx context.Context,
+	req *external.DelRoomKeysVersionRequest,
+	userID string,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+
+	v.Deleted = true
+	if err := saveKeyBackupVersion(ctx, userID, v, encryptionDB, cache); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if err := encryptionDB.DeleteKeyBackup(ctx, userID, v.Version, "", ""); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if err := cache.DeleteKeyBackup(userID, v.Version, "", ""); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	return http.StatusOK, nil
+}
+
+// UploadRoomKeys implements PUT /room_keys/keys and its per room and per
+// session forms. Keys may only be uploaded to the current version and a
+// backed up session is only replaced by a better one.
+func UploadRoomKeys(
+	ctx context.Context,
+	userID, version string,
+	rooms map[string]external.RoomKeyBackup,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	if version == "" {
+		return http.StatusBadRequest, jsonerror.MissingArgument("version is required")
+	}
+	v := latestKeyBackupVersion(cache, userID)
+	if v == nil {
+		return http.StatusNotFound, jsonerror.NotFound("No current backup version")
+	}
+	if current := strconv.FormatInt(v.Version, 10); current != version {
+		return http.StatusForbidden, jsonerror.WrongRoomKeysVersion("Wrong backup version", current)
+	}
+
+	changed := false
+	for roomID, room := range rooms {
+		for sessionID, data := range room.Sessions {
+			if len(data.SessionData) == 0 {
+				return http.StatusBadRequest, jsonerror.MissingArgument("session_data is required")
+			}
+			if old, ok := cache.GetKeyBackup(userID, v.Version, roomID, sessionID); ok && !isBetterKeyBackup(&data, old) {
+				continue
+			}
+
+			session := &types.KeyBackupSession{
+				RoomID:            roomID,
+				SessionID:         sessionID,
+				FirstMessageIndex: data.FirstMessageIndex,
+				ForwardedCount:    data.ForwardedCount,
+				IsVerified:        data.IsVerified,
+				SessionData:       string(data.SessionData),
+			}
+			err := encryptionDB.InsertKeyBackup(ctx, userID, v.Version, roomID, sessionID,
+				session.FirstMessageIndex, session.ForwardedCount, session.IsVerified, session.SessionData)
+			if err != nil {
+				return httputil.LogThenErrorCtx(ctx, err)
+			}
+			if err := cache.SetKeyBackup(userID, v.Version, session); err != nil {
+				return httputil.LogThenErrorCtx(ctx, err)
+			}
+			changed = true
+		}
+	}
+
+	// the etag is bumped in the cache, concurrent uploads each get their own
+	if changed {
+		etag, err := cache.IncrKeyBackupEtag(userID, v.Version)
+		if err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+		v.Etag = etag
+		if err := encryptionDB.InsertKeyBackupVersion(ctx, userID, v.Version, v.Algorithm, v.AuthData, v.Etag, v.Deleted); err != nil {
+			return httputil.LogThenErrorCtx(ctx, err)
+		}
+	}
+	return http.StatusOK, &external.PutRoomKeysResponse{
+		Etag:  strconv.FormatInt(v.Etag, 10),
+		Count: keyBackupCount(cache, userID, v.Version),
+	}
+}
+
+// GetRoomKeys implements GET /room_keys/keys
+func GetRoomKeys(
+	ctx context.Context,
+	req *external.GetRoomKeysRequest,
+	userID string,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+
+	rooms := make(map[string]external.RoomKeyBackup)
+	sessions, _ := cache.GetKeyBackups(userID, v.Version)
+	for _, session := range sessions {
+		room, ok := rooms[session.RoomID]
+		if !ok {
+			room = external.RoomKeyBackup{Sessions: make(map[string]external.KeyBackupData)}
+			rooms[session.RoomID] = room
+		}
+		room.Sessions[session.SessionID] = toKeyBackupData(session)
+	}
+	return http.StatusOK, &external.GetRoomKeysResponse{Rooms: rooms}
+}
+
+// GetRoomKeysByRoom implements GET /room_keys/keys/{roomId}, a room without
+// backed up sessions has empty sessions
+func GetRoomKeysByRoom(
+	ctx context.Context,
+	req *external.GetRoomKeysRequest,
+	userID string,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+
+	room := external.GetRoomKeysByRoomResponse{Sessions: make(map[string]external.KeyBackupData)}
+	sessions, _ := cache.GetKeyBackups(userID, v.Version)
+	for _, session := range sessions {
+		if session.RoomID == req.RoomID {
+			room.Sessions[session.SessionID] = toKeyBackupData(session)
+		}
+	}
+	return http.StatusOK, &room
+}
+
+// GetRoomKeysBySession implements GET /room_keys/keys/{roomId}/{sessionId}
+func GetRoomKeysBySession(
+	ctx context.Context,
+	req *external.GetRoomKeysRequest,
+	userID string,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+
+	session, ok := cache.GetKeyBackup(userID, v.Version, req.RoomID, req.SessionID)
+	if !ok {
+		return http.StatusNotFound, jsonerror.NotFound("No room_keys found")
+	}
+	data := external.GetRoomKeysBySessionResponse(toKeyBackupData(session))
+	return http.StatusOK, &data
+}
+
+// DeleteRoomKeys implements DELETE /room_keys/keys and its per room and per
+// session forms
+func DeleteRoomKeys(
+	ctx context.Context,
+	req *external.DelRoomKeysRequest,
+	userID string,
+	encryptionDB model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (int, core.Coder) {
+	v, code, resp := getKeyBackupVersion(cache, userID, req.Version)
+	if v == nil {
+		return code, resp
+	}
+
+	if err := encryptionDB.DeleteKeyBackup(ctx, userID, v.Version, req.RoomID, req.SessionID); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	if err := cache.DeleteKeyBackup(userID, v.Version, req.RoomID, req.SessionID); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	v.Etag++
+	if err := saveKeyBackupVersion(ctx, userID, v, encryptionDB, cache); err != nil {
+		return httputil.LogThenErrorCtx(ctx, err)
+	}
+	return http.StatusOK, &external.PutRoomKeysResponse{
+		Etag:  strconv.FormatInt(v.Etag, 10),
+		Count: keyBackupCount(cache, userID, v.Version),
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+)
+
+func TestIsBetterKeyBackup(t *testing.T) {
+	old := &types.KeyBackupSession{FirstMessageIndex: 5, ForwardedCount: 1, IsVerified: false}
+	tests := []struct {
+		name string
+		data external.KeyBackupData
+		want bool
+	}{
+		{"verified beats unverified", external.KeyBackupData{FirstMessageIndex: 10, ForwardedCount: 3, IsVerified: true}, true},
+		{"lower first message index", external.KeyBackupData{FirstMessageIndex: 2, ForwardedCount: 3}, true},
+		{"higher first message index", external.KeyBackupData{FirstMessageIndex: 6, ForwardedCount: 0}, false},
+		{"fewer forwards", external.KeyBackupData{FirstMessageIndex: 5, ForwardedCount: 0}, true},
+		{"same session", external.KeyBackupData{FirstMessageIndex: 5, ForwardedCount: 1}, false},
+	}
+	for _, test := range tests {
+		if got := isBetterKeyBackup(&test.data, old); got != test.want {
+			t.Errorf("%s: want %v, got %v", test.name, test.want, got)
+		}
+	}
+
+	verified := &types.KeyBackupSession{FirstMessageIndex: 5, IsVerified: true}
+	if isBetterKeyBackup(&external.KeyBackupData{FirstMessageIndex: 0}, verified) {
+		t.Errorf("want unverified session not replacing a verified one")
+	}
+}
@@ -85,7 +85,7 @@ func UploadPKeys(
 func QueryPKeys(
 	ctx context.Context,
 	queryRq *external.PostQueryKeysRequest,
-	deviceID string,
+	device *authtypes.Device,
 	cache service.Cache,
 	federation *gomatrixserverlib.FederationClient,
 	serverName []string,
@@ -95,6 +95,9 @@ func QueryPKeys(
 	queryRp := &external.PostQueryKeysResponse{}
 	queryRp.Failures = make(map[string]interface{})
 	queryRp.DeviceKeys = make(map[string]map[string]external.DeviceKeys)
+	queryRp.MasterKeys = make(map[string]external.CrossSigningKey)
+	queryRp.SelfSigningKeys = make(map[string]external.CrossSigningKey)
+	queryRp.UserSigningKeys = make(map[string]external.CrossSigningKey)
 	// if reqErr := httputil.UnmarshalJSONRequest(req, &queryRq); reqErr != nil {
 	// 	return *reqErr
 	// }
@@ -127,7 +130,7 @@ func QueryPKeys(
 		}
 	}
 
-	log.Infow("Query Other Users DeviceKey", log.KeysAndValues{"my device", deviceID, "target userIDs", queryRq.DeviceKeys})
+	log.Infow("Query Other Users DeviceKey", log.KeysAndValues{"my device", device.ID, "target userIDs", queryRq.DeviceKeys})
 
 	// query one's device key from user corresponding to uid
 	for uid, arr := range queryRq.DeviceKeys {
@@ -153,42 +156,40 @@ func QueryPKeys(
 
 		/* federation consideration */
 		if common.CheckValidDomain(server, serverName) == false {
+			// answer from the cached device list, fetched over /user/devices
+			// the first time the user is queried
+			devices, ok := cache.GetRemoteDeviceList(uid)
+			if !ok {
+				var resyncErr error
+				if devices, resyncErr = resyncDeviceList(ctx, uid, cache, federation); resyncErr != nil {
+					log.Warnf("QueryPKeys get device list of %s from %s error %v", uid, server, resyncErr)
+				}
+			}
+			if devices != nil {
+				mergeRemoteDeviceList(queryRp, uid, midArr, devices)
+				continue
+			}
+
 			umap := make(map[string][]string)
 			umap[uid] = midArr
 			rq := &gomatrixserverlib.QueryRequest{
-				umap,
+				DeviceKeys: umap,
 			}
-			federation.LookupDeviceKeys(ctx, gomatrixserverlib.ServerName(server), rq)
+			res, err := federation.LookupDeviceKeys(ctx, gomatrixserverlib.ServerName(server), rq)
+			if err != nil {
+				log.Warnf("QueryPKeys lookup device keys of %s from %s error %v", uid, server, err)
+				queryRp.Failures[server] = err.Error()
+				continue
+			}
+			mergeRemoteKeys(queryRp, uid, &res)
+			continue
 		}
 
 		for _, device := range midArr {
 			log.Infof("QueryPKeys for %s", device)
-			deviceKeyIDs, ok := cache.GetDeviceKeyIDs(uid, device)
-			if ok {
-				for _, deviceKeyID := range deviceKeyIDs {
-					log.Infof("QueryPKeys for %s", deviceKeyID)
-					key, exists := cache.GetDeviceKey(deviceKeyID)
-					if exists && key.UserID != "" {
-						log.Infof("QueryPKeys for %s %s %s", key.DeviceID, key.UserID, key.KeyID)
-						deviceKeysQueryMap = presetDeviceKeysQueryMap(deviceKeysQueryMap, uid, *key)
-						// load for accomplishment
-						single := deviceKeysQueryMap[key.DeviceID]
-						resKey := fmt.Sprintf("%s:%s", key.KeyAlgorithm, key.DeviceID)
-						resBody := key.Key
-						single.Keys[resKey] = resBody
-						single.DeviceID = key.DeviceID
-						single.UserID = key.UserID
-						single.Signatures[uid][fmt.Sprintf("%s:%s", "ed25519", key.DeviceID)] = key.Signature
-						single.Algorithms = takeAL(key.UserID, key.DeviceID, cache)
-						device := cache.GetDeviceByDeviceID(key.DeviceID, uid)
-						if device != nil {
-							single.Unsigned.DeviceDisplayName = device.DisplayName
-						}
-						deviceKeysQueryMap[key.DeviceID] = single
-					}
-				}
-			}
+			loadDeviceKeys(deviceKeysQueryMap, uid, device, cache)
 		}
+		queryCrossSigningKeys(queryRp, uid, device.UserID, cache)
 	}
 	if err != nil {
 		return httputil.LogThenErrorCtx(ctx, err)
@@ -196,6 +197,60 @@ func QueryPKeys(
 	return http.StatusOK, queryRp
 }
 
+// loadDeviceKeys adds the keys of a local device, signed by the device and
+// by the self-signing key of the user, to deviceKeysQueryMap
+func loadDeviceKeys(
+	deviceKeysQueryMap map[string]external.DeviceKeys,
+	uid, deviceID string,
+	cache service.Cache,
+) {
+	deviceKeyIDs, ok := cache.GetDeviceKeyIDs(uid, deviceID)
+	if !ok {
+		return
+	}
+	for _, deviceKeyID := range deviceKeyIDs {
+		log.Infof("QueryPKeys for %s", deviceKeyID)
+		key, exists := cache.GetDeviceKey(deviceKeyID)
+		if exists && key.UserID != "" {
+			log.Infof("QueryPKeys for %s %s %s", key.DeviceID, key.UserID, key.KeyID)
+			deviceKeysQueryMap = presetDeviceKeysQueryMap(deviceKeysQueryMap, uid, *key)
+			// load for accomplishment
+			single := deviceKeysQueryMap[key.DeviceID]
+			resKey := fmt.Sprintf("%s:%s", key.KeyAlgorithm, key.DeviceID)
+			resBody := key.Key
+			single.Keys[resKey] = resBody
+			single.DeviceID = key.DeviceID
+			single.UserID = key.UserID
+			single.Signatures[uid][fmt.Sprintf("%s:%s", "ed25519", key.DeviceID)] = key.Signature
+			sigs, _ := cache.GetCrossSigningSigs(uid, key.DeviceID)
+			for _, sig := range sigs {
+				if sig.OriginUserID == uid {
+					single.Signatures[uid][sig.OriginKeyID] = sig.Signature
+				}
+			}
+			single.Algorithms = takeAL(key.UserID, key.DeviceID, cache)
+			device := cache.GetDeviceByDeviceID(key.DeviceID, uid)
+			if device != nil {
+				single.Unsigned.DeviceDisplayName = device.DisplayName
+			}
+			deviceKeysQueryMap[key.DeviceID] = single
+		}
+	}
+}
+
+// mergeRemoteKeys adds the keys of a remote user returned by the user's server
+func mergeRemoteKeys(queryRp *external.PostQueryKeysResponse, uid string, res *gomatrixserverlib.QueryResponse) {
+	for deviceID, key := range res.DeviceKeys[uid] {
+		queryRp.DeviceKeys[uid][deviceID] = remoteDeviceKeys(&key)
+	}
+	if key, ok := res.MasterKeys[uid]; ok {
+		queryRp.MasterKeys[uid] = external.CrossSigningKey(key)
+	}
+	if key, ok := res.SelfSigningKeys[uid]; ok {
+		queryRp.SelfSigningKeys[uid] = external.CrossSigningKey(key)
+	}
+}
+
 // ClaimOneTimeKeys claim for one time key that may be used in session exchange in olm encryption
 func ClaimOneTimeKeys(
 	ctx context.Context,
@@ -248,8 +303,13 @@ func ClaimOneTimeKeys(
 			}
 
 			key := pickOne(ctx, cache, uid, deviceID, encryptionDB)
+			fallback := false
 			if key == nil || key.UserID == "" {
-				continue
+				// the one time keys ran out, hand out the fallback key
+				if key = pickFallback(ctx, cache, uid, deviceID, al, encryptionDB); key == nil {
+					continue
+				}
+				fallback = true
 			}
 
 			keyPreMap := claimRp.DeviceKeys[uid]
@@ -265,7 +325,7 @@ func ClaimOneTimeKeys(
 				sig := make(map[string]map[string]string)
 				sig[uid] = make(map[string]string)
 				sig[uid][fmt.Sprintf("%s:%s", "ed25519", deviceID)] = key.Signature
-				keymap[fmt.Sprintf("%s:%s", al, key.KeyID)] = types.KeyObject{Key: key.Key, Signature: sig}
+				keymap[fmt.Sprintf("%s:%s", al, key.KeyID)] = types.KeyObject{Key: key.Key, Fallback: fallback, Signature: sig}
 			}
 			claimRp.DeviceKeys[uid][deviceID] = keymap
 
@@ -346,6 +406,9 @@ func persistKeys(
 	// situation 2: both device keys and one time keys
 	// situation 3: only one time keys
 	mac := common.GetDeviceMac(deviceID)
+	if err = fallbackKeyProcess(ctx, body, userID, deviceID, database, cache); err != nil {
+		return
+	}
 	if checkUpload(body, types.BODYDEVICEKEY) {
 		err = database.OnDeleteDeviceOneTimeKey(ctx, deviceID, userID)
 		if err != nil {
@@ -380,34 +443,16 @@ func persistKeys(
 			}
 		}
 
-		offset, _ := idg.Next()
-		err := syncDB.InsertKeyChange(ctx, userID, offset)
-		if err != nil {
+		if err = notifyKeyChange(ctx, userID, deviceID, false, cache, rpcClient, syncDB, idg); err != nil {
 			return err
 		}
 
 		content := types.KeyUpdateContent{
-			Type: types.DEVICEKEYUPDATE,
-			DeviceKeyChanges: []types.DeviceKeyChanges{
-				{
-					ChangedUserID: userID,
-					Offset:        offset,
-				},
-			},
-		}
-		bytes, err := json.Marshal(content)
-		if err == nil {
-			rpcClient.Pub(types.KeyUpdateTopicDef, bytes)
-		} else {
-			return err
-		}
-
-		content = types.KeyUpdateContent{
 			Type:                     types.ONETIMEKEYUPDATE,
 			OneTimeKeyChangeUserId:   userID,
 			OneTimeKeyChangeDeviceId: deviceID,
 		}
-		bytes, err = json.Marshal(content)
+		bytes, err := json.Marshal(content)
 		if err == nil {
 			rpcClient.Pub(types.KeyUpdateTopicDef, bytes)
 		} else {
@@ -442,13 +487,20 @@ func turnSpecific(
 ) (spec types.UploadEncryptSpecific) {
 	// both device keys are coordinate
 	spec.DeviceKeys = cont.DeviceKeys
-	spec.OneTimeKey.KeyString = make(map[string]string)
-	spec.OneTimeKey.KeyObject = make(map[string]types.KeyObject)
-	mapStringInterface := cont.OneTimeKey
+	spec.OneTimeKey = turnKeySpecific(cont.OneTimeKey)
+	spec.FallbackKey = turnKeySpecific(cont.FallbackKeys)
+	return
+}
+
+func turnKeySpecific(
+	mapStringInterface map[string]interface{},
+) (spec types.OneTimeKeySpecific) {
+	spec.KeyString = make(map[string]string)
+	spec.KeyObject = make(map[string]types.KeyObject)
 	for key, val := range mapStringInterface {
 		value, ok := val.(string)
 		if ok {
-			spec.OneTimeKey.KeyString[key] = value
+			spec.KeyString[key] = value
 		} else {
 			valueObject := types.KeyObject{}
 			target, _ := json.Marshal(val)
@@ -456,7 +508,7 @@ func turnSpecific(
 			if err != nil {
 				continue
 			}
-			spec.OneTimeKey.KeyObject[key] = valueObject
+			spec.KeyObject[key] = valueObject
 		}
 	}
 	return
@@ -507,6 +559,42 @@ func pickOne(
 	return nil
 }
 
+// pickFallback returns the fallback key of the device for the algorithm and
+// marks it used, it is kept until the device uploads a new one
+func pickFallback(
+	ctx context.Context,
+	cache service.Cache,
+	uid, device, al string,
+	encryptionDB model.EncryptorAPIDatabase,
+) *types.KeyHolder {
+	keys, ok := cache.GetFallbackKeys(uid, device)
+	if !ok {
+		return nil
+	}
+	key, ok := keys[al]
+	if !ok || key.KeyID == "" {
+		return nil
+	}
+	if !key.Used {
+		key.Used = true
+		if err := encryptionDB.InsertFallbackKey(ctx, device, uid, key.KeyID, key.Key, key.Algorithm, key.Signature,
+			common.GetDeviceMac(device), true); err != nil {
+			log.Errorf("pickFallback mark used user:%s device:%s err:%v", uid, device, err)
+		}
+		if err := cache.SetFallbackKey(uid, device, key); err != nil {
+			log.Errorf("pickFallback mark used in cache user:%s device:%s err:%v", uid, device, err)
+		}
+	}
+	return &types.KeyHolder{
+		UserID:       uid,
+		DeviceID:     device,
+		Signature:    key.Signature,
+		KeyAlgorithm: key.Algorithm,
+		KeyID:        key.KeyID,
+		Key:          key.Key,
+	}
+}
+
 func presetDeviceKeysQueryMap(
 	deviceKeysQueryMap map[string]external.DeviceKeys,
 	uid string,
@@ -671,3 +759,55 @@ func otmKeyProcess(
 	}
 	return
 }
+
+// fallbackKeyProcess replaces the fallback keys of the device, uploading the
+// key already stored keeps its used state
+func fallbackKeyProcess(
+	ctx context.Context,
+	body *types.UploadEncryptSpecific,
+	userID, deviceID string,
+	database model.EncryptorAPIDatabase,
+	cache service.Cache,
+) (err error) {
+	fallbackKeys := body.FallbackKey
+	if len(fallbackKeys.KeyString) == 0 && len(fallbackKeys.KeyObject) == 0 {
+		return
+	}
+	mac := common.GetDeviceMac(deviceID)
+	stored, _ := cache.GetFallbackKeys(userID, deviceID)
+	save := func(al, keyID, keyInfo, sig string) error {
+		if keyID == "" {
+			return nil
+		}
+		if old, ok := stored[al]; ok && old.KeyID == keyID && old.Key == keyInfo {
+			return nil
+		}
+		key := &types.FallbackKey{KeyID: keyID, Key: keyInfo, Algorithm: al, Signature: sig}
+		if err := cache.SetFallbackKey(userID, deviceID, key); err != nil {
+			return err
+		}
+		return database.InsertFallbackKey(ctx, deviceID, userID, keyID, keyInfo, al, sig, mac, false)
+	}
+	for alKeyID, val := range fallbackKeys.KeyString {
+		al, keyID := splitAlKeyID(alKeyID)
+		if err = save(al, keyID, val, ""); err != nil {
+			return
+		}
+	}
+	for alKeyID, val := range fallbackKeys.KeyObject {
+		al, keyID := splitAlKeyID(alKeyID)
+		sig := val.Signature[userID][fmt.Sprintf("%s:%s", "ed25519", deviceID)]
+		if err = save(al, keyID, val.Key, sig); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func splitAlKeyID(alKeyID string) (al, keyID string) {
+	parts := strings.SplitN(alKeyID, ":", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
@@ -0,0 +1,56 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/finogeeks/ligase/model/types"
+)
+
+func TestTurnSpecificFallbackKeys(t *testing.T) {
+	spec := turnSpecific(&types.UploadEncrypt{
+		OneTimeKey: map[string]interface{}{
+			"curve25519:AAAAAQ": "one time key",
+		},
+		FallbackKeys: map[string]interface{}{
+			"signed_curve25519:AAAAGj": map[string]interface{}{
+				"key":      "fallback key",
+				"fallback": true,
+				"signatures": map[string]interface{}{
+					"@alice:example.com": map[string]interface{}{"ed25519:JLAFKJWSCS": "sig"},
+				},
+			},
+		},
+	})
+
+	if len(spec.OneTimeKey.KeyString) != 1 || len(spec.OneTimeKey.KeyObject) != 0 {
+		t.Fatalf("want one time keys untouched, got %+v", spec.OneTimeKey)
+	}
+	key, ok := spec.FallbackKey.KeyObject["signed_curve25519:AAAAGj"]
+	if !ok {
+		t.Fatalf("want fallback key parsed, got %+v", spec.FallbackKey)
+	}
+	if key.Key != "fallback key" || !key.Fallback || key.Signature["@alice:example.com"]["ed25519:JLAFKJWSCS"] != "sig" {
+		t.Errorf("unexpected fallback key %+v", key)
+	}
+
+	if al, keyID := splitAlKeyID("signed_curve25519:AAAAGj"); al != "signed_curve25519" || keyID != "AAAAGj" {
+		t.Errorf("want signed_curve25519 AAAAGj, got %s %s", al, keyID)
+	}
+	if _, keyID := splitAlKeyID("signed_curve25519"); keyID != "" {
+		t.Errorf("want empty key id, got %s", keyID)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/uid"
+	"github.com/finogeeks/ligase/encryptoapi/routing"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/nats-io/go-nats"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+// DeviceListRpcConsumer handles the device list updates of remote users
+// received over federation and the device deletions of local users
+type DeviceListRpcConsumer struct {
+	rpcClient  *common.RpcClient
+	cache      service.Cache
+	federation *gomatrixserverlib.FederationClient
+	syncDB     model.SyncAPIDatabase
+	idg        *uid.UidGenerator
+	serverName []string
+	chanSize   uint32
+	msgChan    []chan common.ContextMsg
+}
+
+func NewDeviceListRpcConsumer(
+	rpcClient *common.RpcClient,
+	cache service.Cache,
+	federation *gomatrixserverlib.FederationClient,
+	syncDB model.SyncAPIDatabase,
+	idg *uid.UidGenerator,
+	serverName []string,
+) *DeviceListRpcConsumer {
+	s := &DeviceListRpcConsumer{
+		rpcClient:  rpcClient,
+		cache:      cache,
+		federation: federation,
+		syncDB:     syncDB,
+		idg:        idg,
+		serverName: serverName,
+		chanSize:   4,
+	}
+
+	return s
+}
+
+func (s *DeviceListRpcConsumer) GetCB() common.MsgHandlerWithContext {
+	return s.cb
+}
+
+func (s *DeviceListRpcConsumer) GetTopic() string {
+	return types.DeviceListUpdateTopicDef
+}
+
+func (s *DeviceListRpcConsumer) Clean() {
+}
+
+func (s *DeviceListRpcConsumer) cb(ctx context.Context, msg *nats.Msg) {
+	var result types.DeviceListUpdateContent
+	if err := json.Unmarshal(msg.Data, &result); err != nil {
+		log.Errorf("rpc device list update cb error %v", err)
+		return
+	}
+
+	idx := common.CalcStringHashCode(result.UserID) % s.chanSize
+	s.msgChan[idx] <- common.ContextMsg{Ctx: ctx, Msg: &result}
+}
+
+func (s *DeviceListRpcConsumer) startWorker(msgChan chan common.ContextMsg) {
+	for msg := range msgChan {
+		data := msg.Msg.(*types.DeviceListUpdateContent)
+		if err := routing.ProcessDeviceListUpdate(msg.Ctx, data, s.cache, s.federation, s.rpcClient, s.syncDB, s.idg, s.serverName); err != nil {
+			log.Errorf("process device list update of user %s device %s error %v", data.UserID, data.DeviceID, err)
+		}
+	}
+}
+
+func (s *DeviceListRpcConsumer) Start() error {
+	s.msgChan = make([]chan common.ContextMsg, s.chanSize)
+	for i := uint32(0); i < s.chanSize; i++ {
+		s.msgChan[i] = make(chan common.ContextMsg, 512)
+		go s.startWorker(s.msgChan[i])
+	}
+
+	s.rpcClient.ReplyGrpWithContext(s.GetTopic(), types.DEVICELIST_RPC_GROUP, s.cb)
+	return nil
+}
@@ -100,6 +100,7 @@ type Fed struct {
 		ServerKey  DataBaseConf `yaml:"server_key"`
 		Encryption DataBaseConf `yaml:"encryption"`
 		Account    DataBaseConf `yaml:"account"`
+		Device     DataBaseConf `yaml:"device"` //optional, /user/devices serves the cached devices without it
 		UseSync    bool         `yaml:"use_sync"`
 	} `yaml:"database"`
 	Redis struct {
@@ -129,6 +130,21 @@ type Fed struct {
 		DurationRefresh int `yaml:"durationRefresh"`
 	} `yaml:"cache"`
 
+	// Edu selects the EDU types sent to other servers, the spec ones unless
+	// compat is on or the destination is listed in compat_domains, where the
+	// private profile, receipt and typing EDUs of older ligase nodes are kept.
+	// Ligase servers older than this one only read the private EDUs, list
+	// them in compat_domains (or turn compat on) while they are upgraded or
+	// they lose the typing, receipts and presence of this server:
+	//
+	//   edu:
+	//     compat: false
+	//     compat_domains: ["old.example.com"]
+	Edu struct {
+		Compat        bool     `yaml:"compat"`
+		CompatDomains []string `yaml:"compat_domains"`
+	} `yaml:"edu"`
+
 	NotaryService struct {
 		CliHttpsEnable bool   `yaml:"cli_https_enable"`
 		RootCAUrl      string `yaml:"root_ca_url"`
@@ -143,8 +159,10 @@ type ConnectorConf struct {
 }
 
 type DataBaseConf struct {
-	Driver    string `yaml:"driver"`
-	Addresses string `yaml:"addresses"`
+	Driver        string   `yaml:"driver"`
+	Addresses     string   `yaml:"addresses"`
+	Replicas      []string `yaml:"replicas,omitempty"`
+	MaxReplicaLag int      `yaml:"max_replica_lag_ms,omitempty"`
 }
 
 type TransportConf struct {
@@ -202,6 +220,19 @@ func (f Fed) GetServerName() []string {
 	return f.Homeserver.ServerName
 }
 
+// UseLegacyEdu reports whether the private ligase EDUs are sent to domain
+func (f Fed) UseLegacyEdu(domain string) bool {
+	if f.Edu.Compat {
+		return true
+	}
+	for _, v := range f.Edu.CompatDomains {
+		if v == domain {
+			return true
+		}
+	}
+	return false
+}
+
 func (f Fed) GetServerFromDB() bool {
 	return f.Homeserver.ServerFromDB
 }
@@ -230,11 +261,36 @@ func (f Fed) GetDBConfig(name string) (driver string, createAddr string, addr st
 		return f.Database.Encryption.Driver, f.Database.CreateDB.Addresses, f.Database.Encryption.Addresses, f.Kafka.Producer.DBUpdates.Underlying, f.Kafka.Producer.DBUpdates.Name, !f.Database.UseSync
 	case "accounts":
 		return f.Database.Account.Driver, f.Database.CreateDB.Addresses, f.Database.Account.Addresses, f.Kafka.Producer.DBUpdates.Underlying, f.Kafka.Producer.DBUpdates.Name, !f.Database.UseSync
+	case "devices":
+		return f.Database.Device.Driver, f.Database.CreateDB.Addresses, f.Database.Device.Addresses, f.Kafka.Producer.DBUpdates.Underlying, f.Kafka.Producer.DBUpdates.Name, !f.Database.UseSync
 	default:
 		return "", "", "", "", "", false
 	}
 }
 
+func (f Fed) GetDBReplicaConfig(name string) (replicas []string, maxLagMS int) {
+	var conf DataBaseConf
+	switch name {
+	case "roomserver":
+		conf = f.Database.RoomServer
+	case "federation":
+		conf = f.Database.Federation
+	case "server_conf":
+		conf = f.Database.ServerConf
+	case "serverkey":
+		conf = f.Database.ServerKey
+	case "encryptoapi":
+		conf = f.Database.Encryption
+	case "accounts":
+		conf = f.Database.Account
+	case "devices":
+		conf = f.Database.Device
+	default:
+		return nil, 0
+	}
+	return conf.Replicas, conf.MaxReplicaLag
+}
+
 func Load(configPath string) error {
 	configData, err := ioutil.ReadFile(configPath)
 	if err != nil {
@@ -234,11 +234,23 @@ func startFedMonolith() {
 	}
 	encrytionDB := edb.(model.EncryptorAPIDatabase)
 
+	// /user/devices lists the cached devices when database.device is missing
+	var deviceDB model.DeviceDatabase
+	if cfg.Database.Device.Addresses != "" {
+		ddb, err := common.GetDBInstance("devices", &cfg)
+		if err != nil {
+			log.Panicw("failed to connect to devices db", log.KeysAndValues{"error", err})
+		}
+		deviceDB = ddb.(model.DeviceDatabase)
+	} else {
+		log.Warnf("database.device is not configured, /user/devices serves the cached devices")
+	}
+
 	publicroomsAPI := rpc.NewFedPublicRoomsRpcClient(&cfg, rpcClient)
 
 	fedAPIEntry := federationapi.NewFederationAPIComponent(&cfg, cache, fedClient, fedDB, keyDB,
 		feddomains, fedRpcCli, backfillRepo, joinRoomsRepo, backfill, publicroomsAPI,
-		rpcClient, encrytionDB, certInfo, idg, complexCache)
+		rpcClient, encrytionDB, deviceDB, certInfo, idg, complexCache)
 
 	//subject := fmt.Sprintf("%s.%s", fed.cfg.GetMsgBusReqTopic(), ">")
 	//fed.NatsBus.SubRegister(subject, "federation-msgbus")
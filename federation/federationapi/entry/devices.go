@@ -0,0 +1,84 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package entry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/federation/client"
+	fedmodel "github.com/finogeeks/ligase/federation/storage/model"
+	"github.com/finogeeks/ligase/model"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/plugins/message/external"
+)
+
+func init() {
+	Register(model.CMD_FED_USER_DEVICES, GetUserDevices)
+}
+
+// GetUserDevices serves the devices of a local user with their keys, the
+// stream id lets the remote server tell which device list updates it missed.
+// The devices come from the device db, or from the cache when the federation
+// config has no database.device.
+func GetUserDevices(ctx context.Context, msg *model.GobMessage, cache service.Cache, rpcCli roomserverapi.RoomserverRPCAPI, fedClient *client.FedClientWrap, db fedmodel.FederationDatabase) (*model.GobMessage, error) {
+	var req external.GetFedUserDevicesRequest
+	if err := json.Unmarshal(msg.Body, &req); err != nil {
+		return &model.GobMessage{}, err
+	}
+
+	server, _ := common.DomainFromID(req.UserID)
+	if !common.CheckValidDomain(server, cfg.GetServerName()) {
+		return &model.GobMessage{}, fmt.Errorf("userID is not ours")
+	}
+
+	var devices []authtypes.Device
+	if deviceDB != nil {
+		var err error
+		if devices, err = deviceDB.GetUserDevices(ctx, req.UserID); err != nil {
+			return &model.GobMessage{}, err
+		}
+	} else if cached := cache.GetDevicesByUserID(req.UserID); cached != nil {
+		devices = *cached
+	}
+
+	resp := external.GetFedUserDevicesResponse{
+		UserID:  req.UserID,
+		Devices: []external.FedUserDevice{},
+	}
+	resp.StreamID, _ = cache.GetDeviceListStreamID(req.UserID)
+	for _, device := range devices {
+		deviceKeysQueryMap := map[string]external.DeviceKeys{}
+		queryDeviceKeys(deviceKeysQueryMap, req.UserID, device.ID, cache)
+		keys, ok := deviceKeysQueryMap[device.ID]
+		if !ok {
+			// devices without keys can't take part in encryption
+			continue
+		}
+		resp.Devices = append(resp.Devices, external.FedUserDevice{
+			DeviceID:    device.ID,
+			DisplayName: device.DisplayName,
+			Keys:        keys,
+		})
+	}
+	resp.MasterKey, resp.SelfSigningKey = queryCrossSigningKeys(req.UserID, cache)
+
+	body, _ := resp.Encode()
+	return &model.GobMessage{Body: body}, nil
+}
@@ -0,0 +1,141 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package entry
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/federation/fedutil"
+	"github.com/finogeeks/ligase/model/service"
+	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+// processSpecEdu handles the spec EDUs, typing, receipts and presence are
+// turned into the private ones the rest of ligase consumes. Users the EDU
+// speaks for must belong to the origin of the transaction.
+func processSpecEdu(ctx context.Context, origin string, edu *gomatrixserverlib.EDU, cache service.Cache, rpcCli roomserverapi.RoomserverRPCAPI) {
+	switch edu.Type {
+	case types.EduMTyping:
+		typing, err := fedutil.LegacyTyping(edu.Content)
+		if err != nil {
+			log.Errorf("api send parse m.typing error: %v", err)
+			return
+		}
+		if !fromOrigin(typing.UserID, origin) {
+			return
+		}
+		content, _ := json.Marshal(typing)
+		rpcCli.ProcessTyping(&gomatrixserverlib.EDU{Type: types.EduTyping, Origin: origin, Content: content})
+	case types.EduMReceipt:
+		receipts, err := fedutil.LegacyReceipts(edu.Content)
+		if err != nil {
+			log.Errorf("api send parse m.receipt error: %v", err)
+			return
+		}
+		for _, receipt := range receipts {
+			if !fromOrigin(receipt.UserID, origin) {
+				continue
+			}
+			content, _ := json.Marshal(receipt)
+			rpcCli.ProcessReceipt(&gomatrixserverlib.EDU{Type: types.EduReceipt, Origin: origin, Content: content})
+		}
+	case types.EduMPresence:
+		var presence types.PresenceEduContent
+		if err := json.Unmarshal(edu.Content, &presence); err != nil {
+			log.Errorf("api send parse m.presence error: %v", err)
+			return
+		}
+		for _, update := range presence.Push {
+			if !fromOrigin(update.UserID, origin) {
+				continue
+			}
+			content, _ := json.Marshal(presenceToProfile(&update, cache))
+			rpcCli.ProcessProfile(&gomatrixserverlib.EDU{Type: types.EduProfile, Origin: origin, Content: content})
+		}
+	case types.EduMDeviceListUpdate:
+		var update types.DeviceListUpdateContent
+		if err := json.Unmarshal(edu.Content, &update); err != nil {
+			log.Errorf("api send parse m.device_list_update error: %v", err)
+			return
+		}
+		if !fromOrigin(update.UserID, origin) {
+			return
+		}
+		rpcCli.ProcessDeviceListUpdate(edu)
+	case types.EduMDirectToDevice:
+		var std types.DirectToDeviceContent
+		if err := json.Unmarshal(edu.Content, &std); err != nil {
+			log.Errorf("api send parse m.direct_to_device error: %v", err)
+			return
+		}
+		if !fromOrigin(std.Sender, origin) {
+			return
+		}
+		// only deliver to the users of this server
+		for userID := range std.Messages {
+			domain, _ := common.DomainFromID(userID)
+			if !common.CheckValidDomain(domain, cfg.GetServerName()) {
+				delete(std.Messages, userID)
+			}
+		}
+		if len(std.Messages) == 0 {
+			return
+		}
+		content, _ := json.Marshal(std)
+		rpcCli.ProcessSendToDevice(&gomatrixserverlib.EDU{Type: edu.Type, Origin: origin, Content: content})
+	default:
+		log.Infof("api send ignore edu type %s from %s", edu.Type, origin)
+	}
+}
+
+func fromOrigin(userID, origin string) bool {
+	domain, err := common.DomainFromID(userID)
+	if err != nil || domain != origin {
+		log.Warnf("api send drop edu for user %s from origin %s", userID, origin)
+		return false
+	}
+	return true
+}
+
+// presenceToProfile builds the private profile update of a presence update,
+// the profile the server already knows of the user is kept
+func presenceToProfile(update *types.PresenceEduUpdate, cache service.Cache) *types.ProfileContent {
+	profile := &types.ProfileContent{
+		UserID:    update.UserID,
+		Presence:  update.Presence,
+		StatusMsg: update.StatusMsg,
+	}
+	if p := cache.GetProfileByUserID(update.UserID); p != nil {
+		profile.DisplayName = p.DisplayName
+		profile.AvatarUrl = p.AvatarURL
+	}
+	if info := cache.GetUserInfoByUserID(update.UserID); info != nil {
+		profile.UserName = info.UserName
+		profile.JobNumber = info.JobNumber
+		profile.Mobile = info.Mobile
+		profile.Landline = info.Landline
+		profile.Email = info.Email
+		profile.State = info.State
+	}
+	if presences, ok := cache.GetPresences(update.UserID); ok {
+		profile.ExtStatusMsg = presences.ExtStatusMsg
+	}
+	return profile
+}
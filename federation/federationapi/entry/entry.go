@@ -52,6 +52,7 @@ var (
 	publicroomsAPI publicroomsapi.PublicRoomsQueryAPI
 	rpcClient      *common.RpcClient
 	encryptionDB   dbmodel.EncryptorAPIDatabase
+	deviceDB       dbmodel.DeviceDatabase
 	complexCache   *common.ComplexCache
 	rsRepo         *modelRepos.RoomServerCurStateRepo
 )
@@ -119,6 +120,10 @@ func SetEncryptionDB(db dbmodel.EncryptorAPIDatabase) {
 	encryptionDB = db
 }
 
+func SetDeviceDB(db dbmodel.DeviceDatabase) {
+	deviceDB = db
+}
+
 func SetComplexCache(cache *common.ComplexCache) {
 	complexCache = cache
 }
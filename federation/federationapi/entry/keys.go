@@ -40,7 +40,11 @@ func QueryClientKeys(ctx context.Context, msg *model.GobMessage, cache service.C
 	var reqParam external.PostQueryClientKeysRequest
 	reqParam.Decode(msg.Body)
 
-	resp := external.PostQueryClientKeysResponse{DeviceKeys: map[string]map[string]external.DeviceKeys{}}
+	resp := external.PostQueryClientKeysResponse{
+		DeviceKeys:      map[string]map[string]external.DeviceKeys{},
+		MasterKeys:      map[string]external.CrossSigningKey{},
+		SelfSigningKeys: map[string]external.CrossSigningKey{},
+	}
 
 	// query one's device key from user corresponding to uid
 	for uid, arr := range reqParam.DeviceKeys {
@@ -68,32 +72,15 @@ func QueryClientKeys(ctx context.Context, msg *model.GobMessage, cache service.C
 		}
 
 		for _, device := range midArr {
-			log.Infof("QueryPKeys for %s", device)
-			deviceKeyIDs, ok := cache.GetDeviceKeyIDs(uid, device)
-			if ok {
-				for _, deviceKeyID := range deviceKeyIDs {
-					log.Infof("QueryPKeys for %s", deviceKeyID)
-					key, exists := cache.GetDeviceKey(deviceKeyID)
-					if exists && key.UserID != "" {
-						log.Infof("QueryPKeys for %s %s %s", key.DeviceID, key.UserID, key.KeyID)
-						deviceKeysQueryMap = presetDeviceKeysQueryMap(deviceKeysQueryMap, uid, *key)
-						// load for accomplishment
-						single := deviceKeysQueryMap[key.DeviceID]
-						resKey := fmt.Sprintf("%s:%s", key.KeyAlgorithm, key.DeviceID)
-						resBody := key.Key
-						single.Keys[resKey] = resBody
-						single.DeviceID = key.DeviceID
-						single.UserID = key.UserID
-						single.Signatures[uid][fmt.Sprintf("%s:%s", "ed25519", key.DeviceID)] = key.Signature
-						single.Algorithms = takeAL(key.UserID, key.DeviceID, cache)
-						device := cache.GetDeviceByDeviceID(key.DeviceID, uid)
-						if device != nil {
-							single.Unsigned.DeviceDisplayName = device.DisplayName
-						}
-						deviceKeysQueryMap[key.DeviceID] = single
-					}
-				}
-			}
+			queryDeviceKeys(deviceKeysQueryMap, uid, device, cache)
+		}
+
+		master, selfSigning := queryCrossSigningKeys(uid, cache)
+		if master != nil {
+			resp.MasterKeys[uid] = *master
+		}
+		if selfSigning != nil {
+			resp.SelfSigningKeys[uid] = *selfSigning
 		}
 	}
 
@@ -101,6 +88,77 @@ func QueryClientKeys(ctx context.Context, msg *model.GobMessage, cache service.C
 	return &model.GobMessage{Body: body}, nil
 }
 
+// queryDeviceKeys adds the keys of the local device of the user to the map
+func queryDeviceKeys(deviceKeysQueryMap map[string]external.DeviceKeys, uid, device string, cache service.Cache) {
+	log.Infof("QueryPKeys for %s", device)
+	deviceKeyIDs, ok := cache.GetDeviceKeyIDs(uid, device)
+	if !ok {
+		return
+	}
+	for _, deviceKeyID := range deviceKeyIDs {
+		log.Infof("QueryPKeys for %s", deviceKeyID)
+		key, exists := cache.GetDeviceKey(deviceKeyID)
+		if exists && key.UserID != "" {
+			log.Infof("QueryPKeys for %s %s %s", key.DeviceID, key.UserID, key.KeyID)
+			deviceKeysQueryMap = presetDeviceKeysQueryMap(deviceKeysQueryMap, uid, *key)
+			// load for accomplishment
+			single := deviceKeysQueryMap[key.DeviceID]
+			resKey := fmt.Sprintf("%s:%s", key.KeyAlgorithm, key.DeviceID)
+			resBody := key.Key
+			single.Keys[resKey] = resBody
+			single.DeviceID = key.DeviceID
+			single.UserID = key.UserID
+			single.Signatures[uid][fmt.Sprintf("%s:%s", "ed25519", key.DeviceID)] = key.Signature
+			sigs, _ := cache.GetCrossSigningSigs(uid, key.DeviceID)
+			for _, sig := range sigs {
+				if sig.OriginUserID == uid {
+					single.Signatures[uid][sig.OriginKeyID] = sig.Signature
+				}
+			}
+			single.Algorithms = takeAL(key.UserID, key.DeviceID, cache)
+			device := cache.GetDeviceByDeviceID(key.DeviceID, uid)
+			if device != nil {
+				single.Unsigned.DeviceDisplayName = device.DisplayName
+			}
+			deviceKeysQueryMap[key.DeviceID] = single
+		}
+	}
+}
+
+// queryCrossSigningKeys returns the master and self-signing keys of the local
+// user, remote servers only see the signatures of the user themselves, the
+// user-signing key is never shared
+func queryCrossSigningKeys(uid string, cache service.Cache) (master, selfSigning *external.CrossSigningKey) {
+	keys, ok := cache.GetCrossSigningKeys(uid)
+	if !ok {
+		return nil, nil
+	}
+	if masterKey := keys[types.CrossSigningMasterKey]; masterKey != nil {
+		key := external.CrossSigningKey(*masterKey)
+		for keyID := range masterKey.Keys {
+			sigs, _ := cache.GetCrossSigningSigs(uid, strings.TrimPrefix(keyID, "ed25519:"))
+			for _, sig := range sigs {
+				if sig.OriginUserID != uid {
+					continue
+				}
+				if key.Signatures == nil {
+					key.Signatures = map[string]map[string]string{}
+				}
+				if key.Signatures[uid] == nil {
+					key.Signatures[uid] = map[string]string{}
+				}
+				key.Signatures[uid][sig.OriginKeyID] = sig.Signature
+			}
+		}
+		master = &key
+	}
+	if selfSigningKey := keys[types.CrossSigningSelfSigningKey]; selfSigningKey != nil {
+		key := external.CrossSigningKey(*selfSigningKey)
+		selfSigning = &key
+	}
+	return master, selfSigning
+}
+
 func ClaimClientKeys(ctx context.Context, msg *model.GobMessage, cache service.Cache, rpcCli roomserverapi.RoomserverRPCAPI, fedClient *client.FedClientWrap, db fedmodel.FederationDatabase) (*model.GobMessage, error) {
 	var reqParam external.PostClaimClientKeysRequest
 	reqParam.Decode(msg.Body)
@@ -120,8 +178,12 @@ func ClaimClientKeys(ctx context.Context, msg *model.GobMessage, cache service.C
 			}
 
 			key := pickOne(ctx, cache, uid, deviceID)
+			fallback := false
 			if key == nil || key.UserID == "" {
-				continue
+				if key = pickFallback(ctx, cache, uid, deviceID, al); key == nil {
+					continue
+				}
+				fallback = true
 			}
 
 			keyPreMap := resp.OneTimeKeys[uid]
@@ -137,7 +199,7 @@ func ClaimClientKeys(ctx context.Context, msg *model.GobMessage, cache service.C
 				sig := make(map[string]map[string]string)
 				sig[uid] = make(map[string]string)
 				sig[uid][fmt.Sprintf("%s:%s", "ed25519", deviceID)] = key.Signature
-				keymap[fmt.Sprintf("%s:%s", al, key.KeyID)] = types.KeyObject{Key: key.Key, Signature: sig}
+				keymap[fmt.Sprintf("%s:%s", al, key.KeyID)] = types.KeyObject{Key: key.Key, Fallback: fallback, Signature: sig}
 			}
 			resp.OneTimeKeys[uid][deviceID] = keymap
 
@@ -222,3 +284,31 @@ func pickOne(
 
 	return nil
 }
+
+func pickFallback(
+	ctx context.Context,
+	cache service.Cache,
+	uid, device, al string,
+) *types.KeyHolder {
+	keys, ok := cache.GetFallbackKeys(uid, device)
+	if !ok {
+		return nil
+	}
+	key, ok := keys[al]
+	if !ok || key.KeyID == "" {
+		return nil
+	}
+	if !key.Used {
+		key.Used = true
+		encryptionDB.InsertFallbackKey(ctx, device, uid, key.KeyID, key.Key, key.Algorithm, key.Signature, common.GetDeviceMac(device), true)
+		cache.SetFallbackKey(uid, device, key)
+	}
+	return &types.KeyHolder{
+		UserID:       uid,
+		DeviceID:     device,
+		Signature:    key.Signature,
+		KeyAlgorithm: key.Algorithm,
+		KeyID:        key.KeyID,
+		Key:          key.Key,
+	}
+}
@@ -34,6 +34,7 @@ import (
 	"github.com/finogeeks/ligase/model"
 	"github.com/finogeeks/ligase/model/service"
 	"github.com/finogeeks/ligase/model/service/roomserverapi"
+	"github.com/finogeeks/ligase/model/types"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/pkg/errors"
@@ -180,12 +181,14 @@ func Send(ctx context.Context, msg *model.GobMessage, cache service.Cache, rpcCl
 	}
 	for _, edu := range trans.EDUs {
 		switch edu.Type {
-		case "profile":
+		case types.EduProfile:
 			rpcCli.ProcessProfile(&edu)
-		case "receipt":
+		case types.EduReceipt:
 			rpcCli.ProcessReceipt(&edu)
-		case "typing":
+		case types.EduTyping:
 			rpcCli.ProcessTyping(&edu)
+		default:
+			processSpecEdu(ctx, string(trans.Origin), &edu, cache, rpcCli)
 		}
 	}
 	return retMsg, err
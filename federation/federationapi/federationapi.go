@@ -65,6 +65,7 @@ func NewFederationAPIComponent(
 	publicroomsAPI publicroomsapi.PublicRoomsQueryAPI,
 	rpcClient *common.RpcClient,
 	encryptionDB dbmodel.EncryptorAPIDatabase,
+	deviceDB dbmodel.DeviceDatabase,
 	c *cert.Cert,
 	idg *uid.UidGenerator,
 	complexCache *common.ComplexCache,
@@ -79,6 +80,7 @@ func NewFederationAPIComponent(
 	entry.SetPublicRoomsAPI(publicroomsAPI)
 	entry.SetRpcClient(rpcClient)
 	entry.SetEncryptionDB(encryptionDB)
+	entry.SetDeviceDB(deviceDB)
 	entry.SetComplexCache(complexCache)
 	lc := new(cache.LocalCacheRepo)
 	lc.Start(1, cfg.Cache.DurationDefault)
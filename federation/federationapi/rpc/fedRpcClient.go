@@ -149,6 +149,17 @@ func (fed *FederationRpcClient) RemoveRoomAlias(
 	return nil
 }
 
+func (fed *FederationRpcClient) MoveRoomAliases(
+	ctx context.Context,
+	request *roomserverapi.MoveRoomAliasesRequest,
+	response *roomserverapi.MoveRoomAliasesResponse,
+) error {
+	if fed.aliase != nil {
+		return fed.aliase.MoveRoomAliases(ctx, request, response)
+	}
+	return nil
+}
+
 func (fed *FederationRpcClient) QueryEventsByID( //fed&pub
 	ctx context.Context,
 	request *roomserverapi.QueryEventsByIDRequest,
@@ -306,3 +317,20 @@ func (fed *FederationRpcClient) ProcessTyping(edu *gomatrixserverlib.EDU) {
 func (fed *FederationRpcClient) ProcessProfile(edu *gomatrixserverlib.EDU) {
 	fed.rpcClient.Pub(types.ProfileUpdateTopicDef, edu.Content)
 }
+
+func (fed *FederationRpcClient) ProcessDeviceListUpdate(edu *gomatrixserverlib.EDU) {
+	fed.rpcClient.Pub(types.DeviceListUpdateTopicDef, edu.Content)
+}
+
+func (fed *FederationRpcClient) ProcessSendToDevice(edu *gomatrixserverlib.EDU) {
+	var content types.DirectToDeviceContent
+	if err := json.Unmarshal(edu.Content, &content); err != nil {
+		log.Errorf("FederationRpcClient ProcessSendToDevice unmarshal error %v", err)
+		return
+	}
+	fed.rpcClient.PubObj(types.StdTopicDef, types.StdContent{
+		StdRequest: types.StdRequest{Sender: content.Messages},
+		Sender:     content.Sender,
+		EventType:  content.Type,
+	})
+}
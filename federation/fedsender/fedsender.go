@@ -30,6 +30,7 @@ import (
 	"github.com/finogeeks/ligase/federation/config"
 	"github.com/finogeeks/ligase/federation/federationapi/rpc"
 	"github.com/finogeeks/ligase/federation/fedsender/queue"
+	"github.com/finogeeks/ligase/federation/fedutil"
 	fedrepos "github.com/finogeeks/ligase/federation/model/repos"
 	"github.com/finogeeks/ligase/model/repos"
 	"github.com/finogeeks/ligase/model/service/roomserverapi"
@@ -241,12 +242,42 @@ func (c *FederationSender) processEdu(ctx context.Context, partition int32, targ
 }
 
 func (c *FederationSender) sendEdu(ctx context.Context, edu *gomatrixserverlib.EDU) {
+	if !c.cfg.UseLegacyEdu(edu.Destination) {
+		specEdu, err := fedutil.ToSpecEdu(edu)
+		if err != nil {
+			log.Errorf("send edu error: %v", err)
+			return
+		}
+		if specEdu == nil {
+			return
+		}
+		edu = specEdu
+	}
+
 	var idx uint32
 	roomID := ""
 	switch edu.Type {
-	case "profile":
+	case types.EduProfile, types.EduMPresence, types.EduMDeviceListUpdate, types.EduMDirectToDevice:
 		idx = uint32(rand.Int31n(int32(c.chanSize)))
-	case "receipt":
+	case types.EduMReceipt:
+		var content types.ReceiptEduContent
+		if err := json.Unmarshal(edu.Content, &content); err != nil {
+			log.Errorf("send edu error: %v", err)
+			return
+		}
+		for roomID = range content {
+			break
+		}
+		idx = common.CalcStringHashCode(roomID) % uint32(c.chanSize)
+	case types.EduMTyping:
+		var content types.TypingEduContent
+		if err := json.Unmarshal(edu.Content, &content); err != nil {
+			log.Errorf("send edu error: %v", err)
+			return
+		}
+		roomID = content.RoomID
+		idx = common.CalcStringHashCode(content.RoomID) % uint32(c.chanSize)
+	case types.EduReceipt:
 		var content types.ReceiptContent
 		if err := json.Unmarshal(edu.Content, &content); err != nil {
 			log.Errorf("send edu error: %v", err)
@@ -254,7 +285,7 @@ func (c *FederationSender) sendEdu(ctx context.Context, edu *gomatrixserverlib.E
 		}
 		roomID = content.RoomID
 		idx = common.CalcStringHashCode(content.RoomID) % uint32(c.chanSize)
-	case "typing":
+	case types.EduTyping:
 		var content types.TypingContent
 		if err := json.Unmarshal(edu.Content, &content); err != nil {
 			log.Errorf("send edu error: %v", err)
@@ -0,0 +1,139 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fedutil
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+)
+
+// ToSpecEdu turns the private profile, receipt and typing EDUs into m.presence,
+// m.receipt and m.typing, other EDUs are returned as is. It returns nil when
+// the EDU has no spec counterpart, like a profile update without presence.
+func ToSpecEdu(edu *gomatrixserverlib.EDU) (*gomatrixserverlib.EDU, error) {
+	var content interface{}
+	var eduType string
+	switch edu.Type {
+	case types.EduTyping:
+		var typing types.TypingContent
+		if err := json.Unmarshal(edu.Content, &typing); err != nil {
+			return nil, err
+		}
+		eduType = types.EduMTyping
+		content = types.TypingEduContent{
+			RoomID: typing.RoomID,
+			UserID: typing.UserID,
+			Typing: typing.Type == "add",
+		}
+	case types.EduReceipt:
+		var receipt types.ReceiptContent
+		if err := json.Unmarshal(edu.Content, &receipt); err != nil {
+			return nil, err
+		}
+		receiptType := receipt.ReceiptType
+		if receiptType == "" {
+			receiptType = "m.read"
+		}
+		eduType = types.EduMReceipt
+		content = types.ReceiptEduContent{
+			receipt.RoomID: {
+				receiptType: {
+					receipt.UserID: {
+						Data:     types.ReceiptEduData{TS: time.Now().UnixNano() / 1000000},
+						EventIDs: []string{receipt.EventID},
+					},
+				},
+			},
+		}
+	case types.EduProfile:
+		var profile types.ProfileContent
+		if err := json.Unmarshal(edu.Content, &profile); err != nil {
+			return nil, err
+		}
+		if profile.Presence == "" {
+			return nil, nil
+		}
+		eduType = types.EduMPresence
+		content = types.PresenceEduContent{
+			Push: []types.PresenceEduUpdate{
+				{
+					UserID:          profile.UserID,
+					Presence:        profile.Presence,
+					StatusMsg:       profile.StatusMsg,
+					CurrentlyActive: profile.Presence == "online",
+				},
+			},
+		}
+	default:
+		return edu, nil
+	}
+
+	bytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+	return &gomatrixserverlib.EDU{
+		Type:        eduType,
+		Origin:      edu.Origin,
+		Destination: edu.Destination,
+		Content:     bytes,
+	}, nil
+}
+
+// LegacyTyping turns the content of m.typing into the private typing content
+func LegacyTyping(content []byte) (*types.TypingContent, error) {
+	var typing types.TypingEduContent
+	if err := json.Unmarshal(content, &typing); err != nil {
+		return nil, err
+	}
+	legacy := &types.TypingContent{
+		Type:   "remove",
+		RoomID: typing.RoomID,
+		UserID: typing.UserID,
+	}
+	if typing.Typing {
+		legacy.Type = "add"
+	}
+	return legacy, nil
+}
+
+// LegacyReceipts turns the content of m.receipt into private receipt contents,
+// one per room, receipt type and user, pointing at the last event read
+func LegacyReceipts(content []byte) ([]types.ReceiptContent, error) {
+	var receipts types.ReceiptEduContent
+	if err := json.Unmarshal(content, &receipts); err != nil {
+		return nil, err
+	}
+	var legacy []types.ReceiptContent
+	for roomID, byType := range receipts {
+		for receiptType, byUser := range byType {
+			for userID, receipt := range byUser {
+				if len(receipt.EventIDs) == 0 {
+					continue
+				}
+				legacy = append(legacy, types.ReceiptContent{
+					UserID:      userID,
+					RoomID:      roomID,
+					ReceiptType: receiptType,
+					EventID:     receipt.EventIDs[len(receipt.EventIDs)-1],
+				})
+			}
+		}
+	}
+	return legacy, nil
+}
@@ -0,0 +1,58 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package fedutil
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+)
+
+func TestTypingRoundTrip(t *testing.T) {
+	content, _ := json.Marshal(types.TypingContent{Type: "add", RoomID: "!r:a", UserID: "@u:a"})
+	edu, err := ToSpecEdu(&gomatrixserverlib.EDU{Type: types.EduTyping, Content: content})
+	if err != nil || edu == nil || edu.Type != types.EduMTyping {
+		t.Fatalf("unexpected edu %v, err %v", edu, err)
+	}
+	typing, err := LegacyTyping(edu.Content)
+	if err != nil || typing.Type != "add" || typing.RoomID != "!r:a" || typing.UserID != "@u:a" {
+		t.Fatalf("unexpected typing %v, err %v", typing, err)
+	}
+}
+
+func TestReceiptRoundTrip(t *testing.T) {
+	content, _ := json.Marshal(types.ReceiptContent{RoomID: "!r:a", UserID: "@u:a", EventID: "$e:a"})
+	edu, err := ToSpecEdu(&gomatrixserverlib.EDU{Type: types.EduReceipt, Content: content})
+	if err != nil || edu == nil || edu.Type != types.EduMReceipt {
+		t.Fatalf("unexpected edu %v, err %v", edu, err)
+	}
+	receipts, err := LegacyReceipts(edu.Content)
+	if err != nil || len(receipts) != 1 {
+		t.Fatalf("unexpected receipts %v, err %v", receipts, err)
+	}
+	if r := receipts[0]; r.ReceiptType != "m.read" || r.EventID != "$e:a" || r.UserID != "@u:a" {
+		t.Fatalf("unexpected receipt %v", r)
+	}
+}
+
+func TestProfileWithoutPresence(t *testing.T) {
+	content, _ := json.Marshal(types.ProfileContent{UserID: "@u:a", DisplayName: "u"})
+	edu, err := ToSpecEdu(&gomatrixserverlib.EDU{Type: types.EduProfile, Content: content})
+	if err != nil || edu != nil {
+		t.Fatalf("expected no edu, got %v, err %v", edu, err)
+	}
+}
@@ -56,11 +56,11 @@ type backfillRecordStatements struct {
 	updateBackfillRecordDomainsInfoStmt *sql.Stmt
 }
 
+func (s *backfillRecordStatements) getSchema() string {
+	return backfillRecordSchema
+}
+
 func (s *backfillRecordStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(backfillRecordSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertBackfillRecordStmt, err = db.Prepare(insertBackfillRecordSQL); err != nil {
 		return
 	}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/federation/storage/model"
 
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
@@ -57,6 +58,20 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	if result.db, err = sql.Open(driver, address); err != nil {
 		return nil, err
 	}
+	schemas := []string{
+		result.joinedRoomsStatements.getSchema(),
+		result.sendRecordStatements.getSchema(),
+		result.backfillRecordStatements.getSchema(),
+		result.missingEventsStatements.getSchema()}
+	for _, sqlStr := range schemas {
+		_, err := result.db.Exec(sqlStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = migration.Apply("federation", driver, createAddr, result.db); err != nil {
+		return nil, err
+	}
 	if err = result.prepare(); err != nil {
 		return nil, err
 	}
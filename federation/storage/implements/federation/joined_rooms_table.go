@@ -49,11 +49,11 @@ type joinedRoomsStatements struct {
 	selectJoinedRoomsStmt           *sql.Stmt
 }
 
+func (s *joinedRoomsStatements) getSchema() string {
+	return joinedRoomsSchema
+}
+
 func (s *joinedRoomsStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(joinedRoomsSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertJoinedRoomsStmt, err = db.Prepare(insertJoinedRoomsSQL); err != nil {
 		return
 	}
@@ -45,11 +45,11 @@ type missingEventsStatements struct {
 	selectMissingEventsStmt *sql.Stmt
 }
 
+func (s *missingEventsStatements) getSchema() string {
+	return missingEventsSchema
+}
+
 func (s *missingEventsStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(missingEventsSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertMissingEventsStmt, err = db.Prepare(insertMissingEventsSQL); err != nil {
 		return
 	}
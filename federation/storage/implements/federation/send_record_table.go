@@ -70,11 +70,11 @@ type sendRecordStatements struct {
 	updateSendRecordPendingSizeAndEventIDStmt *sql.Stmt
 }
 
+func (s *sendRecordStatements) getSchema() string {
+	return sendRecordSchema
+}
+
 func (s *sendRecordStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(sendRecordSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertSendRecordStmt, err = db.Prepare(insertSendRecordSQL); err != nil {
 		return
 	}
@@ -14,8 +14,9 @@ require (
 	github.com/jolestar/go-commons-pool v2.0.0+incompatible
 	github.com/json-iterator/go v1.1.9
 	github.com/lib/pq v1.5.2
+	github.com/mattn/go-sqlite3 v1.14.15
 	github.com/minio/blake2b-simd v0.0.0-20160723061019-3f5f724cb5b1
-	github.com/nats-io/gnatsd v1.4.1 // indirect
+	github.com/nats-io/gnatsd v1.4.1
 	github.com/nats-io/go-nats v1.7.2
 	github.com/nats-io/nkeys v0.1.4 // indirect
 	github.com/nats-io/nuid v1.0.1 // indirect
@@ -27,6 +27,11 @@ type Account struct {
 	ServerName   gomatrixserverlib.ServerName
 	Profile      *Profile
 	AppServiceID string
+	// Deactivated accounts can't log in again
+	Deactivated bool
+	// Suspended accounts can't log in until an admin unsuspends them
+	Suspended bool
+	CreatedTs int64
 	// TODO: Other flags like IsAdmin, IsGuest
 	// TODO: Devices
 	// TODO: Associations (e.g. with application services)
@@ -27,4 +27,20 @@ type Device struct {
 	Identifier   string `json:"identifier,omitempty"`
 	CreateTs     int64  `json:"create_ts,omitempty"`
 	LastActiveTs int64  `json:"last_active_ts,omitempty"`
+	// ExpireTs is when the access token of the request expires in ms, 0
+	// when it never expires
+	ExpireTs int64 `json:"expire_ts,omitempty"`
+}
+
+// RefreshToken is the device a refresh token renews the access token of
+type RefreshToken struct {
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id"`
+}
+
+// DeviceLastSeen is where a device last made an authenticated request from
+type DeviceLastSeen struct {
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	Ts        int64  `json:"ts"`
 }
@@ -0,0 +1,11080 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package dbtypes
+
+import (
+	capnp "zombiezen.com/go/capnproto2"
+	text "zombiezen.com/go/capnproto2/encoding/text"
+	schemas "zombiezen.com/go/capnproto2/schemas"
+)
+
+type DBEventCapn struct{ capnp.Struct }
+
+// DBEventCapn_TypeID is the unique identifier for the type DBEventCapn.
+const DBEventCapn_TypeID = 0xff9a16bcf09c606e
+
+func NewDBEventCapn(s *capnp.Segment) (DBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 8})
+	return DBEventCapn{st}, err
+}
+
+func NewRootDBEventCapn(s *capnp.Segment) (DBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 8})
+	return DBEventCapn{st}, err
+}
+
+func ReadRootDBEventCapn(msg *capnp.Message) (DBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return DBEventCapn{root.Struct()}, err
+}
+
+func (s DBEventCapn) String() string {
+	str, _ := text.Marshal(0xff9a16bcf09c606e, s.Struct)
+	return str
+}
+
+func (s DBEventCapn) Key() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s DBEventCapn) SetKey(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s DBEventCapn) Category() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s DBEventCapn) SetCategory(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s DBEventCapn) IsRecovery() bool {
+	return s.Struct.Bit(128)
+}
+
+func (s DBEventCapn) SetIsRecovery(v bool) {
+	s.Struct.SetBit(128, v)
+}
+
+func (s DBEventCapn) Uid() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s DBEventCapn) SetUid(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+func (s DBEventCapn) RoomDBEvents() (RoomDBEventCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return RoomDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasRoomDBEvents() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetRoomDBEvents(v RoomDBEventCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewRoomDBEvents sets the roomDBEvents field to a newly
+// allocated RoomDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewRoomDBEvents() (RoomDBEventCapn, error) {
+	ss, err := NewRoomDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) DeviceDBEvents() (DeviceDBEventCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return DeviceDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasDeviceDBEvents() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetDeviceDBEvents(v DeviceDBEventCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewDeviceDBEvents sets the deviceDBEvents field to a newly
+// allocated DeviceDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewDeviceDBEvents() (DeviceDBEventCapn, error) {
+	ss, err := NewDeviceDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return DeviceDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) AccountDBEvents() (AccountDBEventCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return AccountDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasAccountDBEvents() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetAccountDBEvents(v AccountDBEventCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewAccountDBEvents sets the accountDBEvents field to a newly
+// allocated AccountDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewAccountDBEvents() (AccountDBEventCapn, error) {
+	ss, err := NewAccountDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return AccountDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) PushDBEvents() (PushDBEventCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return PushDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasPushDBEvents() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetPushDBEvents(v PushDBEventCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewPushDBEvents sets the pushDBEvents field to a newly
+// allocated PushDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewPushDBEvents() (PushDBEventCapn, error) {
+	ss, err := NewPushDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return PushDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) E2EDBEvents() (E2EDBEventCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return E2EDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasE2EDBEvents() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetE2EDBEvents(v E2EDBEventCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewE2EDBEvents sets the e2EDBEvents field to a newly
+// allocated E2EDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewE2EDBEvents() (E2EDBEventCapn, error) {
+	ss, err := NewE2EDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return E2EDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) SyncDBEvents() (SyncDBEventCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return SyncDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasSyncDBEvents() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetSyncDBEvents(v SyncDBEventCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewSyncDBEvents sets the syncDBEvents field to a newly
+// allocated SyncDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewSyncDBEvents() (SyncDBEventCapn, error) {
+	ss, err := NewSyncDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) PublicRoomDBEvents() (PublicRoomDBEventCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return PublicRoomDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasPublicRoomDBEvents() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetPublicRoomDBEvents(v PublicRoomDBEventCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewPublicRoomDBEvents sets the publicRoomDBEvents field to a newly
+// allocated PublicRoomDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewPublicRoomDBEvents() (PublicRoomDBEventCapn, error) {
+	ss, err := NewPublicRoomDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return PublicRoomDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DBEventCapn) PresenceDBEvents() (PresenceDBEventCapn, error) {
+	p, err := s.Struct.Ptr(7)
+	return PresenceDBEventCapn{Struct: p.Struct()}, err
+}
+
+func (s DBEventCapn) HasPresenceDBEvents() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s DBEventCapn) SetPresenceDBEvents(v PresenceDBEventCapn) error {
+	return s.Struct.SetPtr(7, v.Struct.ToPtr())
+}
+
+// NewPresenceDBEvents sets the presenceDBEvents field to a newly
+// allocated PresenceDBEventCapn struct, preferring placement in s's segment.
+func (s DBEventCapn) NewPresenceDBEvents() (PresenceDBEventCapn, error) {
+	ss, err := NewPresenceDBEventCapn(s.Struct.Segment())
+	if err != nil {
+		return PresenceDBEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(7, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// DBEventCapn_List is a list of DBEventCapn.
+type DBEventCapn_List struct{ capnp.List }
+
+// NewDBEventCapn creates a new list of DBEventCapn.
+func NewDBEventCapn_List(s *capnp.Segment, sz int32) (DBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 8}, sz)
+	return DBEventCapn_List{l}, err
+}
+
+func (s DBEventCapn_List) At(i int) DBEventCapn { return DBEventCapn{s.List.Struct(i)} }
+
+func (s DBEventCapn_List) Set(i int, v DBEventCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s DBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xff9a16bcf09c606e, s.List)
+	return str
+}
+
+// DBEventCapn_Promise is a wrapper for a DBEventCapn promised by a client call.
+type DBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DBEventCapn_Promise) Struct() (DBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DBEventCapn{s}, err
+}
+
+func (p DBEventCapn_Promise) RoomDBEvents() RoomDBEventCapn_Promise {
+	return RoomDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DBEventCapn_Promise) DeviceDBEvents() DeviceDBEventCapn_Promise {
+	return DeviceDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p DBEventCapn_Promise) AccountDBEvents() AccountDBEventCapn_Promise {
+	return AccountDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p DBEventCapn_Promise) PushDBEvents() PushDBEventCapn_Promise {
+	return PushDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p DBEventCapn_Promise) E2EDBEvents() E2EDBEventCapn_Promise {
+	return E2EDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p DBEventCapn_Promise) SyncDBEvents() SyncDBEventCapn_Promise {
+	return SyncDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p DBEventCapn_Promise) PublicRoomDBEvents() PublicRoomDBEventCapn_Promise {
+	return PublicRoomDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+func (p DBEventCapn_Promise) PresenceDBEvents() PresenceDBEventCapn_Promise {
+	return PresenceDBEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(7)}
+}
+
+type RoomDBEventCapn struct{ capnp.Struct }
+
+// RoomDBEventCapn_TypeID is the unique identifier for the type RoomDBEventCapn.
+const RoomDBEventCapn_TypeID = 0xb6ed8988f8b07974
+
+func NewRoomDBEventCapn(s *capnp.Segment) (RoomDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 16})
+	return RoomDBEventCapn{st}, err
+}
+
+func NewRootRoomDBEventCapn(s *capnp.Segment) (RoomDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 16})
+	return RoomDBEventCapn{st}, err
+}
+
+func ReadRootRoomDBEventCapn(msg *capnp.Message) (RoomDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomDBEventCapn{root.Struct()}, err
+}
+
+func (s RoomDBEventCapn) String() string {
+	str, _ := text.Marshal(0xb6ed8988f8b07974, s.Struct)
+	return str
+}
+
+func (s RoomDBEventCapn) EventJsonInsert() (EventJsonInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return EventJsonInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventJsonInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventJsonInsert(v EventJsonInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewEventJsonInsert sets the eventJsonInsert field to a newly
+// allocated EventJsonInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventJsonInsert() (EventJsonInsertCapn, error) {
+	ss, err := NewEventJsonInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventJsonInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventInsert() (EventInsertCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return EventInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventInsert() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventInsert(v EventInsertCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewEventInsert sets the eventInsert field to a newly
+// allocated EventInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventInsert() (EventInsertCapn, error) {
+	ss, err := NewEventInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventRoomInsert() (EventRoomInsertCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return EventRoomInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventRoomInsert() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventRoomInsert(v EventRoomInsertCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewEventRoomInsert sets the eventRoomInsert field to a newly
+// allocated EventRoomInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventRoomInsert() (EventRoomInsertCapn, error) {
+	ss, err := NewEventRoomInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventRoomInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventRoomUpdate() (EventRoomUpdateCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return EventRoomUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventRoomUpdate() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventRoomUpdate(v EventRoomUpdateCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewEventRoomUpdate sets the eventRoomUpdate field to a newly
+// allocated EventRoomUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventRoomUpdate() (EventRoomUpdateCapn, error) {
+	ss, err := NewEventRoomUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return EventRoomUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventStateSnapInsert() (EventStateSnapInsertCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return EventStateSnapInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventStateSnapInsert() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventStateSnapInsert(v EventStateSnapInsertCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewEventStateSnapInsert sets the eventStateSnapInsert field to a newly
+// allocated EventStateSnapInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventStateSnapInsert() (EventStateSnapInsertCapn, error) {
+	ss, err := NewEventStateSnapInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventStateSnapInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventInviteInsert() (EventInviteInsertCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return EventInviteInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventInviteInsert() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventInviteInsert(v EventInviteInsertCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewEventInviteInsert sets the eventInviteInsert field to a newly
+// allocated EventInviteInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventInviteInsert() (EventInviteInsertCapn, error) {
+	ss, err := NewEventInviteInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventInviteInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventInviteUpdate() (EventInviteUpdateCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return EventInviteUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventInviteUpdate() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventInviteUpdate(v EventInviteUpdateCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewEventInviteUpdate sets the eventInviteUpdate field to a newly
+// allocated EventInviteUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventInviteUpdate() (EventInviteUpdateCapn, error) {
+	ss, err := NewEventInviteUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return EventInviteUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventMembershipInsert() (EventMembershipInsertCapn, error) {
+	p, err := s.Struct.Ptr(7)
+	return EventMembershipInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventMembershipInsert() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventMembershipInsert(v EventMembershipInsertCapn) error {
+	return s.Struct.SetPtr(7, v.Struct.ToPtr())
+}
+
+// NewEventMembershipInsert sets the eventMembershipInsert field to a newly
+// allocated EventMembershipInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventMembershipInsert() (EventMembershipInsertCapn, error) {
+	ss, err := NewEventMembershipInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return EventMembershipInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(7, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventMembershipUpdate() (EventMembershipUpdateCapn, error) {
+	p, err := s.Struct.Ptr(8)
+	return EventMembershipUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventMembershipUpdate() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventMembershipUpdate(v EventMembershipUpdateCapn) error {
+	return s.Struct.SetPtr(8, v.Struct.ToPtr())
+}
+
+// NewEventMembershipUpdate sets the eventMembershipUpdate field to a newly
+// allocated EventMembershipUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventMembershipUpdate() (EventMembershipUpdateCapn, error) {
+	ss, err := NewEventMembershipUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return EventMembershipUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(8, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) EventMembershipForgetUpdate() (EventMembershipForgetUpdateCapn, error) {
+	p, err := s.Struct.Ptr(9)
+	return EventMembershipForgetUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasEventMembershipForgetUpdate() bool {
+	p, err := s.Struct.Ptr(9)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetEventMembershipForgetUpdate(v EventMembershipForgetUpdateCapn) error {
+	return s.Struct.SetPtr(9, v.Struct.ToPtr())
+}
+
+// NewEventMembershipForgetUpdate sets the eventMembershipForgetUpdate field to a newly
+// allocated EventMembershipForgetUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewEventMembershipForgetUpdate() (EventMembershipForgetUpdateCapn, error) {
+	ss, err := NewEventMembershipForgetUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return EventMembershipForgetUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(9, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) AliaseInsert() (AliaseInsertCapn, error) {
+	p, err := s.Struct.Ptr(10)
+	return AliaseInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasAliaseInsert() bool {
+	p, err := s.Struct.Ptr(10)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetAliaseInsert(v AliaseInsertCapn) error {
+	return s.Struct.SetPtr(10, v.Struct.ToPtr())
+}
+
+// NewAliaseInsert sets the aliaseInsert field to a newly
+// allocated AliaseInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewAliaseInsert() (AliaseInsertCapn, error) {
+	ss, err := NewAliaseInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return AliaseInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(10, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) AliaseDelete() (AliaseDeleteCapn, error) {
+	p, err := s.Struct.Ptr(11)
+	return AliaseDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasAliaseDelete() bool {
+	p, err := s.Struct.Ptr(11)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetAliaseDelete(v AliaseDeleteCapn) error {
+	return s.Struct.SetPtr(11, v.Struct.ToPtr())
+}
+
+// NewAliaseDelete sets the aliaseDelete field to a newly
+// allocated AliaseDeleteCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewAliaseDelete() (AliaseDeleteCapn, error) {
+	ss, err := NewAliaseDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return AliaseDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(11, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) RoomDomainInsert() (RoomDomainInsertCapn, error) {
+	p, err := s.Struct.Ptr(12)
+	return RoomDomainInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasRoomDomainInsert() bool {
+	p, err := s.Struct.Ptr(12)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetRoomDomainInsert(v RoomDomainInsertCapn) error {
+	return s.Struct.SetPtr(12, v.Struct.ToPtr())
+}
+
+// NewRoomDomainInsert sets the roomDomainInsert field to a newly
+// allocated RoomDomainInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewRoomDomainInsert() (RoomDomainInsertCapn, error) {
+	ss, err := NewRoomDomainInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomDomainInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(12, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) RoomEventUpdate() (RoomEventUpdateCapn, error) {
+	p, err := s.Struct.Ptr(13)
+	return RoomEventUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasRoomEventUpdate() bool {
+	p, err := s.Struct.Ptr(13)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetRoomEventUpdate(v RoomEventUpdateCapn) error {
+	return s.Struct.SetPtr(13, v.Struct.ToPtr())
+}
+
+// NewRoomEventUpdate sets the roomEventUpdate field to a newly
+// allocated RoomEventUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewRoomEventUpdate() (RoomEventUpdateCapn, error) {
+	ss, err := NewRoomEventUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomEventUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(13, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) RoomDepthUpdate() (RoomDepthUpdateCapn, error) {
+	p, err := s.Struct.Ptr(14)
+	return RoomDepthUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasRoomDepthUpdate() bool {
+	p, err := s.Struct.Ptr(14)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetRoomDepthUpdate(v RoomDepthUpdateCapn) error {
+	return s.Struct.SetPtr(14, v.Struct.ToPtr())
+}
+
+// NewRoomDepthUpdate sets the roomDepthUpdate field to a newly
+// allocated RoomDepthUpdateCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewRoomDepthUpdate() (RoomDepthUpdateCapn, error) {
+	ss, err := NewRoomDepthUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomDepthUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(14, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s RoomDBEventCapn) SettingsInsert() (SettingsInsertCapn, error) {
+	p, err := s.Struct.Ptr(15)
+	return SettingsInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s RoomDBEventCapn) HasSettingsInsert() bool {
+	p, err := s.Struct.Ptr(15)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDBEventCapn) SetSettingsInsert(v SettingsInsertCapn) error {
+	return s.Struct.SetPtr(15, v.Struct.ToPtr())
+}
+
+// NewSettingsInsert sets the settingsInsert field to a newly
+// allocated SettingsInsertCapn struct, preferring placement in s's segment.
+func (s RoomDBEventCapn) NewSettingsInsert() (SettingsInsertCapn, error) {
+	ss, err := NewSettingsInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SettingsInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(15, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// RoomDBEventCapn_List is a list of RoomDBEventCapn.
+type RoomDBEventCapn_List struct{ capnp.List }
+
+// NewRoomDBEventCapn creates a new list of RoomDBEventCapn.
+func NewRoomDBEventCapn_List(s *capnp.Segment, sz int32) (RoomDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 16}, sz)
+	return RoomDBEventCapn_List{l}, err
+}
+
+func (s RoomDBEventCapn_List) At(i int) RoomDBEventCapn { return RoomDBEventCapn{s.List.Struct(i)} }
+
+func (s RoomDBEventCapn_List) Set(i int, v RoomDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xb6ed8988f8b07974, s.List)
+	return str
+}
+
+// RoomDBEventCapn_Promise is a wrapper for a RoomDBEventCapn promised by a client call.
+type RoomDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomDBEventCapn_Promise) Struct() (RoomDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomDBEventCapn{s}, err
+}
+
+func (p RoomDBEventCapn_Promise) EventJsonInsert() EventJsonInsertCapn_Promise {
+	return EventJsonInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p RoomDBEventCapn_Promise) EventInsert() EventInsertCapn_Promise {
+	return EventInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p RoomDBEventCapn_Promise) EventRoomInsert() EventRoomInsertCapn_Promise {
+	return EventRoomInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p RoomDBEventCapn_Promise) EventRoomUpdate() EventRoomUpdateCapn_Promise {
+	return EventRoomUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p RoomDBEventCapn_Promise) EventStateSnapInsert() EventStateSnapInsertCapn_Promise {
+	return EventStateSnapInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p RoomDBEventCapn_Promise) EventInviteInsert() EventInviteInsertCapn_Promise {
+	return EventInviteInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p RoomDBEventCapn_Promise) EventInviteUpdate() EventInviteUpdateCapn_Promise {
+	return EventInviteUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+func (p RoomDBEventCapn_Promise) EventMembershipInsert() EventMembershipInsertCapn_Promise {
+	return EventMembershipInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(7)}
+}
+
+func (p RoomDBEventCapn_Promise) EventMembershipUpdate() EventMembershipUpdateCapn_Promise {
+	return EventMembershipUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(8)}
+}
+
+func (p RoomDBEventCapn_Promise) EventMembershipForgetUpdate() EventMembershipForgetUpdateCapn_Promise {
+	return EventMembershipForgetUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(9)}
+}
+
+func (p RoomDBEventCapn_Promise) AliaseInsert() AliaseInsertCapn_Promise {
+	return AliaseInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(10)}
+}
+
+func (p RoomDBEventCapn_Promise) AliaseDelete() AliaseDeleteCapn_Promise {
+	return AliaseDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(11)}
+}
+
+func (p RoomDBEventCapn_Promise) RoomDomainInsert() RoomDomainInsertCapn_Promise {
+	return RoomDomainInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(12)}
+}
+
+func (p RoomDBEventCapn_Promise) RoomEventUpdate() RoomEventUpdateCapn_Promise {
+	return RoomEventUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(13)}
+}
+
+func (p RoomDBEventCapn_Promise) RoomDepthUpdate() RoomDepthUpdateCapn_Promise {
+	return RoomDepthUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(14)}
+}
+
+func (p RoomDBEventCapn_Promise) SettingsInsert() SettingsInsertCapn_Promise {
+	return SettingsInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(15)}
+}
+
+type EventJsonInsertCapn struct{ capnp.Struct }
+
+// EventJsonInsertCapn_TypeID is the unique identifier for the type EventJsonInsertCapn.
+const EventJsonInsertCapn_TypeID = 0xe0713593fef39d27
+
+func NewEventJsonInsertCapn(s *capnp.Segment) (EventJsonInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return EventJsonInsertCapn{st}, err
+}
+
+func NewRootEventJsonInsertCapn(s *capnp.Segment) (EventJsonInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return EventJsonInsertCapn{st}, err
+}
+
+func ReadRootEventJsonInsertCapn(msg *capnp.Message) (EventJsonInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventJsonInsertCapn{root.Struct()}, err
+}
+
+func (s EventJsonInsertCapn) String() string {
+	str, _ := text.Marshal(0xe0713593fef39d27, s.Struct)
+	return str
+}
+
+func (s EventJsonInsertCapn) EventNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventJsonInsertCapn) SetEventNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventJsonInsertCapn) EventJson() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s EventJsonInsertCapn) HasEventJson() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventJsonInsertCapn) SetEventJson(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s EventJsonInsertCapn) EventType() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s EventJsonInsertCapn) HasEventType() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s EventJsonInsertCapn) EventTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s EventJsonInsertCapn) SetEventType(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// EventJsonInsertCapn_List is a list of EventJsonInsertCapn.
+type EventJsonInsertCapn_List struct{ capnp.List }
+
+// NewEventJsonInsertCapn creates a new list of EventJsonInsertCapn.
+func NewEventJsonInsertCapn_List(s *capnp.Segment, sz int32) (EventJsonInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return EventJsonInsertCapn_List{l}, err
+}
+
+func (s EventJsonInsertCapn_List) At(i int) EventJsonInsertCapn {
+	return EventJsonInsertCapn{s.List.Struct(i)}
+}
+
+func (s EventJsonInsertCapn_List) Set(i int, v EventJsonInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventJsonInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xe0713593fef39d27, s.List)
+	return str
+}
+
+// EventJsonInsertCapn_Promise is a wrapper for a EventJsonInsertCapn promised by a client call.
+type EventJsonInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventJsonInsertCapn_Promise) Struct() (EventJsonInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventJsonInsertCapn{s}, err
+}
+
+type EventInsertCapn struct{ capnp.Struct }
+
+// EventInsertCapn_TypeID is the unique identifier for the type EventInsertCapn.
+const EventInsertCapn_TypeID = 0x8879a14485c01641
+
+func NewEventInsertCapn(s *capnp.Segment) (EventInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 8})
+	return EventInsertCapn{st}, err
+}
+
+func NewRootEventInsertCapn(s *capnp.Segment) (EventInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 8})
+	return EventInsertCapn{st}, err
+}
+
+func ReadRootEventInsertCapn(msg *capnp.Message) (EventInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventInsertCapn{root.Struct()}, err
+}
+
+func (s EventInsertCapn) String() string {
+	str, _ := text.Marshal(0x8879a14485c01641, s.Struct)
+	return str
+}
+
+func (s EventInsertCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventInsertCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventInsertCapn) EventType() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventInsertCapn) HasEventType() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) EventTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventInsertCapn) SetEventType(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s EventInsertCapn) EventStateKey() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s EventInsertCapn) HasEventStateKey() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) EventStateKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s EventInsertCapn) SetEventStateKey(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s EventInsertCapn) EventId() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s EventInsertCapn) HasEventId() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) EventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s EventInsertCapn) SetEventId(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s EventInsertCapn) RefSha() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s EventInsertCapn) HasRefSha() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) SetRefSha(v []byte) error {
+	return s.Struct.SetData(3, v)
+}
+
+func (s EventInsertCapn) AuthEventNids() (capnp.Int64List, error) {
+	p, err := s.Struct.Ptr(4)
+	return capnp.Int64List{List: p.List()}, err
+}
+
+func (s EventInsertCapn) HasAuthEventNids() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) SetAuthEventNids(v capnp.Int64List) error {
+	return s.Struct.SetPtr(4, v.List.ToPtr())
+}
+
+// NewAuthEventNids sets the authEventNids field to a newly
+// allocated capnp.Int64List, preferring placement in s's segment.
+func (s EventInsertCapn) NewAuthEventNids(n int32) (capnp.Int64List, error) {
+	l, err := capnp.NewInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.Int64List{}, err
+	}
+	err = s.Struct.SetPtr(4, l.List.ToPtr())
+	return l, err
+}
+
+func (s EventInsertCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventInsertCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s EventInsertCapn) EventNid() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s EventInsertCapn) SetEventNid(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s EventInsertCapn) StateSnapNid() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s EventInsertCapn) SetStateSnapNid(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+func (s EventInsertCapn) RefEventId() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s EventInsertCapn) HasRefEventId() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) RefEventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s EventInsertCapn) SetRefEventId(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s EventInsertCapn) Sha() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return []byte(p.Data()), err
+}
+
+func (s EventInsertCapn) HasSha() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) SetSha(v []byte) error {
+	return s.Struct.SetData(6, v)
+}
+
+func (s EventInsertCapn) Offset() int64 {
+	return int64(s.Struct.Uint64(32))
+}
+
+func (s EventInsertCapn) SetOffset(v int64) {
+	s.Struct.SetUint64(32, uint64(v))
+}
+
+func (s EventInsertCapn) Domain() (string, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.Text(), err
+}
+
+func (s EventInsertCapn) HasDomain() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInsertCapn) DomainBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.TextBytes(), err
+}
+
+func (s EventInsertCapn) SetDomain(v string) error {
+	return s.Struct.SetText(7, v)
+}
+
+// EventInsertCapn_List is a list of EventInsertCapn.
+type EventInsertCapn_List struct{ capnp.List }
+
+// NewEventInsertCapn creates a new list of EventInsertCapn.
+func NewEventInsertCapn_List(s *capnp.Segment, sz int32) (EventInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 8}, sz)
+	return EventInsertCapn_List{l}, err
+}
+
+func (s EventInsertCapn_List) At(i int) EventInsertCapn { return EventInsertCapn{s.List.Struct(i)} }
+
+func (s EventInsertCapn_List) Set(i int, v EventInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x8879a14485c01641, s.List)
+	return str
+}
+
+// EventInsertCapn_Promise is a wrapper for a EventInsertCapn promised by a client call.
+type EventInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventInsertCapn_Promise) Struct() (EventInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventInsertCapn{s}, err
+}
+
+type EventRoomInsertCapn struct{ capnp.Struct }
+
+// EventRoomInsertCapn_TypeID is the unique identifier for the type EventRoomInsertCapn.
+const EventRoomInsertCapn_TypeID = 0xb215e737bec195ed
+
+func NewEventRoomInsertCapn(s *capnp.Segment) (EventRoomInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return EventRoomInsertCapn{st}, err
+}
+
+func NewRootEventRoomInsertCapn(s *capnp.Segment) (EventRoomInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return EventRoomInsertCapn{st}, err
+}
+
+func ReadRootEventRoomInsertCapn(msg *capnp.Message) (EventRoomInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventRoomInsertCapn{root.Struct()}, err
+}
+
+func (s EventRoomInsertCapn) String() string {
+	str, _ := text.Marshal(0xb215e737bec195ed, s.Struct)
+	return str
+}
+
+func (s EventRoomInsertCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventRoomInsertCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventRoomInsertCapn) RoomId() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventRoomInsertCapn) HasRoomId() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventRoomInsertCapn) RoomIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventRoomInsertCapn) SetRoomId(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// EventRoomInsertCapn_List is a list of EventRoomInsertCapn.
+type EventRoomInsertCapn_List struct{ capnp.List }
+
+// NewEventRoomInsertCapn creates a new list of EventRoomInsertCapn.
+func NewEventRoomInsertCapn_List(s *capnp.Segment, sz int32) (EventRoomInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return EventRoomInsertCapn_List{l}, err
+}
+
+func (s EventRoomInsertCapn_List) At(i int) EventRoomInsertCapn {
+	return EventRoomInsertCapn{s.List.Struct(i)}
+}
+
+func (s EventRoomInsertCapn_List) Set(i int, v EventRoomInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventRoomInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xb215e737bec195ed, s.List)
+	return str
+}
+
+// EventRoomInsertCapn_Promise is a wrapper for a EventRoomInsertCapn promised by a client call.
+type EventRoomInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventRoomInsertCapn_Promise) Struct() (EventRoomInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventRoomInsertCapn{s}, err
+}
+
+type EventRoomUpdateCapn struct{ capnp.Struct }
+
+// EventRoomUpdateCapn_TypeID is the unique identifier for the type EventRoomUpdateCapn.
+const EventRoomUpdateCapn_TypeID = 0x9eea6d74d5ba3dca
+
+func NewEventRoomUpdateCapn(s *capnp.Segment) (EventRoomUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1})
+	return EventRoomUpdateCapn{st}, err
+}
+
+func NewRootEventRoomUpdateCapn(s *capnp.Segment) (EventRoomUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1})
+	return EventRoomUpdateCapn{st}, err
+}
+
+func ReadRootEventRoomUpdateCapn(msg *capnp.Message) (EventRoomUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return EventRoomUpdateCapn{root.Struct()}, err
+}
+
+func (s EventRoomUpdateCapn) String() string {
+	str, _ := text.Marshal(0x9eea6d74d5ba3dca, s.Struct)
+	return str
+}
+
+func (s EventRoomUpdateCapn) LatestEventNids() (capnp.Int64List, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.Int64List{List: p.List()}, err
+}
+
+func (s EventRoomUpdateCapn) HasLatestEventNids() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventRoomUpdateCapn) SetLatestEventNids(v capnp.Int64List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewLatestEventNids sets the latestEventNids field to a newly
+// allocated capnp.Int64List, preferring placement in s's segment.
+func (s EventRoomUpdateCapn) NewLatestEventNids(n int32) (capnp.Int64List, error) {
+	l, err := capnp.NewInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.Int64List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+func (s EventRoomUpdateCapn) LastEventSentNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventRoomUpdateCapn) SetLastEventSentNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventRoomUpdateCapn) StateSnapNid() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventRoomUpdateCapn) SetStateSnapNid(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s EventRoomUpdateCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s EventRoomUpdateCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s EventRoomUpdateCapn) Version() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s EventRoomUpdateCapn) SetVersion(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+func (s EventRoomUpdateCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(32))
+}
+
+func (s EventRoomUpdateCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(32, uint64(v))
+}
+
+// EventRoomUpdateCapn_List is a list of EventRoomUpdateCapn.
+type EventRoomUpdateCapn_List struct{ capnp.List }
+
+// NewEventRoomUpdateCapn creates a new list of EventRoomUpdateCapn.
+func NewEventRoomUpdateCapn_List(s *capnp.Segment, sz int32) (EventRoomUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 40, PointerCount: 1}, sz)
+	return EventRoomUpdateCapn_List{l}, err
+}
+
+func (s EventRoomUpdateCapn_List) At(i int) EventRoomUpdateCapn {
+	return EventRoomUpdateCapn{s.List.Struct(i)}
+}
+
+func (s EventRoomUpdateCapn_List) Set(i int, v EventRoomUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventRoomUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0x9eea6d74d5ba3dca, s.List)
+	return str
+}
+
+// EventRoomUpdateCapn_Promise is a wrapper for a EventRoomUpdateCapn promised by a client call.
+type EventRoomUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventRoomUpdateCapn_Promise) Struct() (EventRoomUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventRoomUpdateCapn{s}, err
+}
+
+type EventStateSnapInsertCapn struct{ capnp.Struct }
+
+// EventStateSnapInsertCapn_TypeID is the unique identifier for the type EventStateSnapInsertCapn.
+const EventStateSnapInsertCapn_TypeID = 0xaa9fd5003815aad9
+
+func NewEventStateSnapInsertCapn(s *capnp.Segment) (EventStateSnapInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return EventStateSnapInsertCapn{st}, err
+}
+
+func NewRootEventStateSnapInsertCapn(s *capnp.Segment) (EventStateSnapInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return EventStateSnapInsertCapn{st}, err
+}
+
+func ReadRootEventStateSnapInsertCapn(msg *capnp.Message) (EventStateSnapInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventStateSnapInsertCapn{root.Struct()}, err
+}
+
+func (s EventStateSnapInsertCapn) String() string {
+	str, _ := text.Marshal(0xaa9fd5003815aad9, s.Struct)
+	return str
+}
+
+func (s EventStateSnapInsertCapn) StateSnapNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventStateSnapInsertCapn) SetStateSnapNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventStateSnapInsertCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventStateSnapInsertCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s EventStateSnapInsertCapn) StateBlockNids() (capnp.Int64List, error) {
+	p, err := s.Struct.Ptr(0)
+	return capnp.Int64List{List: p.List()}, err
+}
+
+func (s EventStateSnapInsertCapn) HasStateBlockNids() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventStateSnapInsertCapn) SetStateBlockNids(v capnp.Int64List) error {
+	return s.Struct.SetPtr(0, v.List.ToPtr())
+}
+
+// NewStateBlockNids sets the stateBlockNids field to a newly
+// allocated capnp.Int64List, preferring placement in s's segment.
+func (s EventStateSnapInsertCapn) NewStateBlockNids(n int32) (capnp.Int64List, error) {
+	l, err := capnp.NewInt64List(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.Int64List{}, err
+	}
+	err = s.Struct.SetPtr(0, l.List.ToPtr())
+	return l, err
+}
+
+// EventStateSnapInsertCapn_List is a list of EventStateSnapInsertCapn.
+type EventStateSnapInsertCapn_List struct{ capnp.List }
+
+// NewEventStateSnapInsertCapn creates a new list of EventStateSnapInsertCapn.
+func NewEventStateSnapInsertCapn_List(s *capnp.Segment, sz int32) (EventStateSnapInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return EventStateSnapInsertCapn_List{l}, err
+}
+
+func (s EventStateSnapInsertCapn_List) At(i int) EventStateSnapInsertCapn {
+	return EventStateSnapInsertCapn{s.List.Struct(i)}
+}
+
+func (s EventStateSnapInsertCapn_List) Set(i int, v EventStateSnapInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventStateSnapInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xaa9fd5003815aad9, s.List)
+	return str
+}
+
+// EventStateSnapInsertCapn_Promise is a wrapper for a EventStateSnapInsertCapn promised by a client call.
+type EventStateSnapInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventStateSnapInsertCapn_Promise) Struct() (EventStateSnapInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventStateSnapInsertCapn{s}, err
+}
+
+type EventInviteInsertCapn struct{ capnp.Struct }
+
+// EventInviteInsertCapn_TypeID is the unique identifier for the type EventInviteInsertCapn.
+const EventInviteInsertCapn_TypeID = 0xb60edeb5c24cb043
+
+func NewEventInviteInsertCapn(s *capnp.Segment) (EventInviteInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return EventInviteInsertCapn{st}, err
+}
+
+func NewRootEventInviteInsertCapn(s *capnp.Segment) (EventInviteInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return EventInviteInsertCapn{st}, err
+}
+
+func ReadRootEventInviteInsertCapn(msg *capnp.Message) (EventInviteInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventInviteInsertCapn{root.Struct()}, err
+}
+
+func (s EventInviteInsertCapn) String() string {
+	str, _ := text.Marshal(0xb60edeb5c24cb043, s.Struct)
+	return str
+}
+
+func (s EventInviteInsertCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventInviteInsertCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventInviteInsertCapn) EventId() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventInviteInsertCapn) HasEventId() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInviteInsertCapn) EventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventInviteInsertCapn) SetEventId(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s EventInviteInsertCapn) Target() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s EventInviteInsertCapn) HasTarget() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInviteInsertCapn) TargetBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s EventInviteInsertCapn) SetTarget(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s EventInviteInsertCapn) Sender() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s EventInviteInsertCapn) HasSender() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInviteInsertCapn) SenderBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s EventInviteInsertCapn) SetSender(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s EventInviteInsertCapn) Content() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s EventInviteInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInviteInsertCapn) SetContent(v []byte) error {
+	return s.Struct.SetData(3, v)
+}
+
+// EventInviteInsertCapn_List is a list of EventInviteInsertCapn.
+type EventInviteInsertCapn_List struct{ capnp.List }
+
+// NewEventInviteInsertCapn creates a new list of EventInviteInsertCapn.
+func NewEventInviteInsertCapn_List(s *capnp.Segment, sz int32) (EventInviteInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return EventInviteInsertCapn_List{l}, err
+}
+
+func (s EventInviteInsertCapn_List) At(i int) EventInviteInsertCapn {
+	return EventInviteInsertCapn{s.List.Struct(i)}
+}
+
+func (s EventInviteInsertCapn_List) Set(i int, v EventInviteInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventInviteInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xb60edeb5c24cb043, s.List)
+	return str
+}
+
+// EventInviteInsertCapn_Promise is a wrapper for a EventInviteInsertCapn promised by a client call.
+type EventInviteInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventInviteInsertCapn_Promise) Struct() (EventInviteInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventInviteInsertCapn{s}, err
+}
+
+type EventInviteUpdateCapn struct{ capnp.Struct }
+
+// EventInviteUpdateCapn_TypeID is the unique identifier for the type EventInviteUpdateCapn.
+const EventInviteUpdateCapn_TypeID = 0xb464be80d7e5888b
+
+func NewEventInviteUpdateCapn(s *capnp.Segment) (EventInviteUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return EventInviteUpdateCapn{st}, err
+}
+
+func NewRootEventInviteUpdateCapn(s *capnp.Segment) (EventInviteUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return EventInviteUpdateCapn{st}, err
+}
+
+func ReadRootEventInviteUpdateCapn(msg *capnp.Message) (EventInviteUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return EventInviteUpdateCapn{root.Struct()}, err
+}
+
+func (s EventInviteUpdateCapn) String() string {
+	str, _ := text.Marshal(0xb464be80d7e5888b, s.Struct)
+	return str
+}
+
+func (s EventInviteUpdateCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventInviteUpdateCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventInviteUpdateCapn) Target() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventInviteUpdateCapn) HasTarget() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventInviteUpdateCapn) TargetBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventInviteUpdateCapn) SetTarget(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// EventInviteUpdateCapn_List is a list of EventInviteUpdateCapn.
+type EventInviteUpdateCapn_List struct{ capnp.List }
+
+// NewEventInviteUpdateCapn creates a new list of EventInviteUpdateCapn.
+func NewEventInviteUpdateCapn_List(s *capnp.Segment, sz int32) (EventInviteUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return EventInviteUpdateCapn_List{l}, err
+}
+
+func (s EventInviteUpdateCapn_List) At(i int) EventInviteUpdateCapn {
+	return EventInviteUpdateCapn{s.List.Struct(i)}
+}
+
+func (s EventInviteUpdateCapn_List) Set(i int, v EventInviteUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventInviteUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0xb464be80d7e5888b, s.List)
+	return str
+}
+
+// EventInviteUpdateCapn_Promise is a wrapper for a EventInviteUpdateCapn promised by a client call.
+type EventInviteUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventInviteUpdateCapn_Promise) Struct() (EventInviteUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventInviteUpdateCapn{s}, err
+}
+
+type EventMembershipInsertCapn struct{ capnp.Struct }
+
+// EventMembershipInsertCapn_TypeID is the unique identifier for the type EventMembershipInsertCapn.
+const EventMembershipInsertCapn_TypeID = 0xcefc928fad5f15d2
+
+func NewEventMembershipInsertCapn(s *capnp.Segment) (EventMembershipInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return EventMembershipInsertCapn{st}, err
+}
+
+func NewRootEventMembershipInsertCapn(s *capnp.Segment) (EventMembershipInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2})
+	return EventMembershipInsertCapn{st}, err
+}
+
+func ReadRootEventMembershipInsertCapn(msg *capnp.Message) (EventMembershipInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return EventMembershipInsertCapn{root.Struct()}, err
+}
+
+func (s EventMembershipInsertCapn) String() string {
+	str, _ := text.Marshal(0xcefc928fad5f15d2, s.Struct)
+	return str
+}
+
+func (s EventMembershipInsertCapn) RoomNID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventMembershipInsertCapn) SetRoomNID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventMembershipInsertCapn) Target() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventMembershipInsertCapn) HasTarget() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventMembershipInsertCapn) TargetBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventMembershipInsertCapn) SetTarget(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s EventMembershipInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s EventMembershipInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s EventMembershipInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s EventMembershipInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s EventMembershipInsertCapn) MembershipNID() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventMembershipInsertCapn) SetMembershipNID(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s EventMembershipInsertCapn) EventNID() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s EventMembershipInsertCapn) SetEventNID(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+// EventMembershipInsertCapn_List is a list of EventMembershipInsertCapn.
+type EventMembershipInsertCapn_List struct{ capnp.List }
+
+// NewEventMembershipInsertCapn creates a new list of EventMembershipInsertCapn.
+func NewEventMembershipInsertCapn_List(s *capnp.Segment, sz int32) (EventMembershipInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 2}, sz)
+	return EventMembershipInsertCapn_List{l}, err
+}
+
+func (s EventMembershipInsertCapn_List) At(i int) EventMembershipInsertCapn {
+	return EventMembershipInsertCapn{s.List.Struct(i)}
+}
+
+func (s EventMembershipInsertCapn_List) Set(i int, v EventMembershipInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventMembershipInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xcefc928fad5f15d2, s.List)
+	return str
+}
+
+// EventMembershipInsertCapn_Promise is a wrapper for a EventMembershipInsertCapn promised by a client call.
+type EventMembershipInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventMembershipInsertCapn_Promise) Struct() (EventMembershipInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventMembershipInsertCapn{s}, err
+}
+
+type EventMembershipUpdateCapn struct{ capnp.Struct }
+
+// EventMembershipUpdateCapn_TypeID is the unique identifier for the type EventMembershipUpdateCapn.
+const EventMembershipUpdateCapn_TypeID = 0x9ab891a076d1d0e7
+
+func NewEventMembershipUpdateCapn(s *capnp.Segment) (EventMembershipUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
+	return EventMembershipUpdateCapn{st}, err
+}
+
+func NewRootEventMembershipUpdateCapn(s *capnp.Segment) (EventMembershipUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2})
+	return EventMembershipUpdateCapn{st}, err
+}
+
+func ReadRootEventMembershipUpdateCapn(msg *capnp.Message) (EventMembershipUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return EventMembershipUpdateCapn{root.Struct()}, err
+}
+
+func (s EventMembershipUpdateCapn) String() string {
+	str, _ := text.Marshal(0x9ab891a076d1d0e7, s.Struct)
+	return str
+}
+
+func (s EventMembershipUpdateCapn) RoomID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventMembershipUpdateCapn) SetRoomID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventMembershipUpdateCapn) Target() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventMembershipUpdateCapn) HasTarget() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventMembershipUpdateCapn) TargetBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventMembershipUpdateCapn) SetTarget(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s EventMembershipUpdateCapn) Sender() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s EventMembershipUpdateCapn) HasSender() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s EventMembershipUpdateCapn) SenderBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s EventMembershipUpdateCapn) SetSender(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s EventMembershipUpdateCapn) Membership() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventMembershipUpdateCapn) SetMembership(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s EventMembershipUpdateCapn) EventNID() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s EventMembershipUpdateCapn) SetEventNID(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s EventMembershipUpdateCapn) Version() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s EventMembershipUpdateCapn) SetVersion(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+// EventMembershipUpdateCapn_List is a list of EventMembershipUpdateCapn.
+type EventMembershipUpdateCapn_List struct{ capnp.List }
+
+// NewEventMembershipUpdateCapn creates a new list of EventMembershipUpdateCapn.
+func NewEventMembershipUpdateCapn_List(s *capnp.Segment, sz int32) (EventMembershipUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 2}, sz)
+	return EventMembershipUpdateCapn_List{l}, err
+}
+
+func (s EventMembershipUpdateCapn_List) At(i int) EventMembershipUpdateCapn {
+	return EventMembershipUpdateCapn{s.List.Struct(i)}
+}
+
+func (s EventMembershipUpdateCapn_List) Set(i int, v EventMembershipUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventMembershipUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0x9ab891a076d1d0e7, s.List)
+	return str
+}
+
+// EventMembershipUpdateCapn_Promise is a wrapper for a EventMembershipUpdateCapn promised by a client call.
+type EventMembershipUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventMembershipUpdateCapn_Promise) Struct() (EventMembershipUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventMembershipUpdateCapn{s}, err
+}
+
+type EventMembershipForgetUpdateCapn struct{ capnp.Struct }
+
+// EventMembershipForgetUpdateCapn_TypeID is the unique identifier for the type EventMembershipForgetUpdateCapn.
+const EventMembershipForgetUpdateCapn_TypeID = 0xd73ab79f8e54cef8
+
+func NewEventMembershipForgetUpdateCapn(s *capnp.Segment) (EventMembershipForgetUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return EventMembershipForgetUpdateCapn{st}, err
+}
+
+func NewRootEventMembershipForgetUpdateCapn(s *capnp.Segment) (EventMembershipForgetUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return EventMembershipForgetUpdateCapn{st}, err
+}
+
+func ReadRootEventMembershipForgetUpdateCapn(msg *capnp.Message) (EventMembershipForgetUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return EventMembershipForgetUpdateCapn{root.Struct()}, err
+}
+
+func (s EventMembershipForgetUpdateCapn) String() string {
+	str, _ := text.Marshal(0xd73ab79f8e54cef8, s.Struct)
+	return str
+}
+
+func (s EventMembershipForgetUpdateCapn) RoomID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s EventMembershipForgetUpdateCapn) SetRoomID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s EventMembershipForgetUpdateCapn) Target() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s EventMembershipForgetUpdateCapn) HasTarget() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s EventMembershipForgetUpdateCapn) TargetBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s EventMembershipForgetUpdateCapn) SetTarget(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s EventMembershipForgetUpdateCapn) ForgetID() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s EventMembershipForgetUpdateCapn) SetForgetID(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+// EventMembershipForgetUpdateCapn_List is a list of EventMembershipForgetUpdateCapn.
+type EventMembershipForgetUpdateCapn_List struct{ capnp.List }
+
+// NewEventMembershipForgetUpdateCapn creates a new list of EventMembershipForgetUpdateCapn.
+func NewEventMembershipForgetUpdateCapn_List(s *capnp.Segment, sz int32) (EventMembershipForgetUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return EventMembershipForgetUpdateCapn_List{l}, err
+}
+
+func (s EventMembershipForgetUpdateCapn_List) At(i int) EventMembershipForgetUpdateCapn {
+	return EventMembershipForgetUpdateCapn{s.List.Struct(i)}
+}
+
+func (s EventMembershipForgetUpdateCapn_List) Set(i int, v EventMembershipForgetUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s EventMembershipForgetUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0xd73ab79f8e54cef8, s.List)
+	return str
+}
+
+// EventMembershipForgetUpdateCapn_Promise is a wrapper for a EventMembershipForgetUpdateCapn promised by a client call.
+type EventMembershipForgetUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p EventMembershipForgetUpdateCapn_Promise) Struct() (EventMembershipForgetUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return EventMembershipForgetUpdateCapn{s}, err
+}
+
+type AliaseInsertCapn struct{ capnp.Struct }
+
+// AliaseInsertCapn_TypeID is the unique identifier for the type AliaseInsertCapn.
+const AliaseInsertCapn_TypeID = 0xfe52005680b49373
+
+func NewAliaseInsertCapn(s *capnp.Segment) (AliaseInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return AliaseInsertCapn{st}, err
+}
+
+func NewRootAliaseInsertCapn(s *capnp.Segment) (AliaseInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return AliaseInsertCapn{st}, err
+}
+
+func ReadRootAliaseInsertCapn(msg *capnp.Message) (AliaseInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return AliaseInsertCapn{root.Struct()}, err
+}
+
+func (s AliaseInsertCapn) String() string {
+	str, _ := text.Marshal(0xfe52005680b49373, s.Struct)
+	return str
+}
+
+func (s AliaseInsertCapn) Alias() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s AliaseInsertCapn) HasAlias() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AliaseInsertCapn) AliasBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AliaseInsertCapn) SetAlias(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s AliaseInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s AliaseInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s AliaseInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s AliaseInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// AliaseInsertCapn_List is a list of AliaseInsertCapn.
+type AliaseInsertCapn_List struct{ capnp.List }
+
+// NewAliaseInsertCapn creates a new list of AliaseInsertCapn.
+func NewAliaseInsertCapn_List(s *capnp.Segment, sz int32) (AliaseInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return AliaseInsertCapn_List{l}, err
+}
+
+func (s AliaseInsertCapn_List) At(i int) AliaseInsertCapn { return AliaseInsertCapn{s.List.Struct(i)} }
+
+func (s AliaseInsertCapn_List) Set(i int, v AliaseInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s AliaseInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xfe52005680b49373, s.List)
+	return str
+}
+
+// AliaseInsertCapn_Promise is a wrapper for a AliaseInsertCapn promised by a client call.
+type AliaseInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AliaseInsertCapn_Promise) Struct() (AliaseInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AliaseInsertCapn{s}, err
+}
+
+type AliaseDeleteCapn struct{ capnp.Struct }
+
+// AliaseDeleteCapn_TypeID is the unique identifier for the type AliaseDeleteCapn.
+const AliaseDeleteCapn_TypeID = 0xef257e0a24528653
+
+func NewAliaseDeleteCapn(s *capnp.Segment) (AliaseDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return AliaseDeleteCapn{st}, err
+}
+
+func NewRootAliaseDeleteCapn(s *capnp.Segment) (AliaseDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return AliaseDeleteCapn{st}, err
+}
+
+func ReadRootAliaseDeleteCapn(msg *capnp.Message) (AliaseDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return AliaseDeleteCapn{root.Struct()}, err
+}
+
+func (s AliaseDeleteCapn) String() string {
+	str, _ := text.Marshal(0xef257e0a24528653, s.Struct)
+	return str
+}
+
+func (s AliaseDeleteCapn) Alias() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s AliaseDeleteCapn) HasAlias() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AliaseDeleteCapn) AliasBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AliaseDeleteCapn) SetAlias(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// AliaseDeleteCapn_List is a list of AliaseDeleteCapn.
+type AliaseDeleteCapn_List struct{ capnp.List }
+
+// NewAliaseDeleteCapn creates a new list of AliaseDeleteCapn.
+func NewAliaseDeleteCapn_List(s *capnp.Segment, sz int32) (AliaseDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return AliaseDeleteCapn_List{l}, err
+}
+
+func (s AliaseDeleteCapn_List) At(i int) AliaseDeleteCapn { return AliaseDeleteCapn{s.List.Struct(i)} }
+
+func (s AliaseDeleteCapn_List) Set(i int, v AliaseDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s AliaseDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xef257e0a24528653, s.List)
+	return str
+}
+
+// AliaseDeleteCapn_Promise is a wrapper for a AliaseDeleteCapn promised by a client call.
+type AliaseDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AliaseDeleteCapn_Promise) Struct() (AliaseDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AliaseDeleteCapn{s}, err
+}
+
+type RoomDomainInsertCapn struct{ capnp.Struct }
+
+// RoomDomainInsertCapn_TypeID is the unique identifier for the type RoomDomainInsertCapn.
+const RoomDomainInsertCapn_TypeID = 0xfdb40dacdfae9f6f
+
+func NewRoomDomainInsertCapn(s *capnp.Segment) (RoomDomainInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return RoomDomainInsertCapn{st}, err
+}
+
+func NewRootRoomDomainInsertCapn(s *capnp.Segment) (RoomDomainInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1})
+	return RoomDomainInsertCapn{st}, err
+}
+
+func ReadRootRoomDomainInsertCapn(msg *capnp.Message) (RoomDomainInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomDomainInsertCapn{root.Struct()}, err
+}
+
+func (s RoomDomainInsertCapn) String() string {
+	str, _ := text.Marshal(0xfdb40dacdfae9f6f, s.Struct)
+	return str
+}
+
+func (s RoomDomainInsertCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s RoomDomainInsertCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s RoomDomainInsertCapn) Domain() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s RoomDomainInsertCapn) HasDomain() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomDomainInsertCapn) DomainBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s RoomDomainInsertCapn) SetDomain(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s RoomDomainInsertCapn) Offset() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s RoomDomainInsertCapn) SetOffset(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+// RoomDomainInsertCapn_List is a list of RoomDomainInsertCapn.
+type RoomDomainInsertCapn_List struct{ capnp.List }
+
+// NewRoomDomainInsertCapn creates a new list of RoomDomainInsertCapn.
+func NewRoomDomainInsertCapn_List(s *capnp.Segment, sz int32) (RoomDomainInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 1}, sz)
+	return RoomDomainInsertCapn_List{l}, err
+}
+
+func (s RoomDomainInsertCapn_List) At(i int) RoomDomainInsertCapn {
+	return RoomDomainInsertCapn{s.List.Struct(i)}
+}
+
+func (s RoomDomainInsertCapn_List) Set(i int, v RoomDomainInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomDomainInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xfdb40dacdfae9f6f, s.List)
+	return str
+}
+
+// RoomDomainInsertCapn_Promise is a wrapper for a RoomDomainInsertCapn promised by a client call.
+type RoomDomainInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomDomainInsertCapn_Promise) Struct() (RoomDomainInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomDomainInsertCapn{s}, err
+}
+
+type RoomEventUpdateCapn struct{ capnp.Struct }
+
+// RoomEventUpdateCapn_TypeID is the unique identifier for the type RoomEventUpdateCapn.
+const RoomEventUpdateCapn_TypeID = 0x95f6b60a46846a53
+
+func NewRoomEventUpdateCapn(s *capnp.Segment) (RoomEventUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return RoomEventUpdateCapn{st}, err
+}
+
+func NewRootRoomEventUpdateCapn(s *capnp.Segment) (RoomEventUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1})
+	return RoomEventUpdateCapn{st}, err
+}
+
+func ReadRootRoomEventUpdateCapn(msg *capnp.Message) (RoomEventUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomEventUpdateCapn{root.Struct()}, err
+}
+
+func (s RoomEventUpdateCapn) String() string {
+	str, _ := text.Marshal(0x95f6b60a46846a53, s.Struct)
+	return str
+}
+
+func (s RoomEventUpdateCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s RoomEventUpdateCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s RoomEventUpdateCapn) EventNid() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s RoomEventUpdateCapn) SetEventNid(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s RoomEventUpdateCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s RoomEventUpdateCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s RoomEventUpdateCapn) Offset() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s RoomEventUpdateCapn) SetOffset(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+func (s RoomEventUpdateCapn) Domain() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s RoomEventUpdateCapn) HasDomain() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomEventUpdateCapn) DomainBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s RoomEventUpdateCapn) SetDomain(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// RoomEventUpdateCapn_List is a list of RoomEventUpdateCapn.
+type RoomEventUpdateCapn_List struct{ capnp.List }
+
+// NewRoomEventUpdateCapn creates a new list of RoomEventUpdateCapn.
+func NewRoomEventUpdateCapn_List(s *capnp.Segment, sz int32) (RoomEventUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 1}, sz)
+	return RoomEventUpdateCapn_List{l}, err
+}
+
+func (s RoomEventUpdateCapn_List) At(i int) RoomEventUpdateCapn {
+	return RoomEventUpdateCapn{s.List.Struct(i)}
+}
+
+func (s RoomEventUpdateCapn_List) Set(i int, v RoomEventUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomEventUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0x95f6b60a46846a53, s.List)
+	return str
+}
+
+// RoomEventUpdateCapn_Promise is a wrapper for a RoomEventUpdateCapn promised by a client call.
+type RoomEventUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomEventUpdateCapn_Promise) Struct() (RoomEventUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomEventUpdateCapn{s}, err
+}
+
+type RoomDepthUpdateCapn struct{ capnp.Struct }
+
+// RoomDepthUpdateCapn_TypeID is the unique identifier for the type RoomDepthUpdateCapn.
+const RoomDepthUpdateCapn_TypeID = 0x968dd9724c73332a
+
+func NewRoomDepthUpdateCapn(s *capnp.Segment) (RoomDepthUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return RoomDepthUpdateCapn{st}, err
+}
+
+func NewRootRoomDepthUpdateCapn(s *capnp.Segment) (RoomDepthUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0})
+	return RoomDepthUpdateCapn{st}, err
+}
+
+func ReadRootRoomDepthUpdateCapn(msg *capnp.Message) (RoomDepthUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomDepthUpdateCapn{root.Struct()}, err
+}
+
+func (s RoomDepthUpdateCapn) String() string {
+	str, _ := text.Marshal(0x968dd9724c73332a, s.Struct)
+	return str
+}
+
+func (s RoomDepthUpdateCapn) RoomNid() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s RoomDepthUpdateCapn) SetRoomNid(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s RoomDepthUpdateCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s RoomDepthUpdateCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+// RoomDepthUpdateCapn_List is a list of RoomDepthUpdateCapn.
+type RoomDepthUpdateCapn_List struct{ capnp.List }
+
+// NewRoomDepthUpdateCapn creates a new list of RoomDepthUpdateCapn.
+func NewRoomDepthUpdateCapn_List(s *capnp.Segment, sz int32) (RoomDepthUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 0}, sz)
+	return RoomDepthUpdateCapn_List{l}, err
+}
+
+func (s RoomDepthUpdateCapn_List) At(i int) RoomDepthUpdateCapn {
+	return RoomDepthUpdateCapn{s.List.Struct(i)}
+}
+
+func (s RoomDepthUpdateCapn_List) Set(i int, v RoomDepthUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomDepthUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0x968dd9724c73332a, s.List)
+	return str
+}
+
+// RoomDepthUpdateCapn_Promise is a wrapper for a RoomDepthUpdateCapn promised by a client call.
+type RoomDepthUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomDepthUpdateCapn_Promise) Struct() (RoomDepthUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomDepthUpdateCapn{s}, err
+}
+
+type SettingsInsertCapn struct{ capnp.Struct }
+
+// SettingsInsertCapn_TypeID is the unique identifier for the type SettingsInsertCapn.
+const SettingsInsertCapn_TypeID = 0x88e098179fb2df0e
+
+func NewSettingsInsertCapn(s *capnp.Segment) (SettingsInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SettingsInsertCapn{st}, err
+}
+
+func NewRootSettingsInsertCapn(s *capnp.Segment) (SettingsInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return SettingsInsertCapn{st}, err
+}
+
+func ReadRootSettingsInsertCapn(msg *capnp.Message) (SettingsInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SettingsInsertCapn{root.Struct()}, err
+}
+
+func (s SettingsInsertCapn) String() string {
+	str, _ := text.Marshal(0x88e098179fb2df0e, s.Struct)
+	return str
+}
+
+func (s SettingsInsertCapn) SettingKey() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SettingsInsertCapn) HasSettingKey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SettingsInsertCapn) SettingKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SettingsInsertCapn) SetSettingKey(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SettingsInsertCapn) Val() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SettingsInsertCapn) HasVal() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SettingsInsertCapn) ValBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SettingsInsertCapn) SetVal(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// SettingsInsertCapn_List is a list of SettingsInsertCapn.
+type SettingsInsertCapn_List struct{ capnp.List }
+
+// NewSettingsInsertCapn creates a new list of SettingsInsertCapn.
+func NewSettingsInsertCapn_List(s *capnp.Segment, sz int32) (SettingsInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return SettingsInsertCapn_List{l}, err
+}
+
+func (s SettingsInsertCapn_List) At(i int) SettingsInsertCapn {
+	return SettingsInsertCapn{s.List.Struct(i)}
+}
+
+func (s SettingsInsertCapn_List) Set(i int, v SettingsInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SettingsInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x88e098179fb2df0e, s.List)
+	return str
+}
+
+// SettingsInsertCapn_Promise is a wrapper for a SettingsInsertCapn promised by a client call.
+type SettingsInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SettingsInsertCapn_Promise) Struct() (SettingsInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SettingsInsertCapn{s}, err
+}
+
+type DeviceDBEventCapn struct{ capnp.Struct }
+
+// DeviceDBEventCapn_TypeID is the unique identifier for the type DeviceDBEventCapn.
+const DeviceDBEventCapn_TypeID = 0xf4c701d71ed2de49
+
+func NewDeviceDBEventCapn(s *capnp.Segment) (DeviceDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return DeviceDBEventCapn{st}, err
+}
+
+func NewRootDeviceDBEventCapn(s *capnp.Segment) (DeviceDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return DeviceDBEventCapn{st}, err
+}
+
+func ReadRootDeviceDBEventCapn(msg *capnp.Message) (DeviceDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return DeviceDBEventCapn{root.Struct()}, err
+}
+
+func (s DeviceDBEventCapn) String() string {
+	str, _ := text.Marshal(0xf4c701d71ed2de49, s.Struct)
+	return str
+}
+
+func (s DeviceDBEventCapn) DeviceInsert() (DeviceInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return DeviceInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s DeviceDBEventCapn) HasDeviceInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDBEventCapn) SetDeviceInsert(v DeviceInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewDeviceInsert sets the deviceInsert field to a newly
+// allocated DeviceInsertCapn struct, preferring placement in s's segment.
+func (s DeviceDBEventCapn) NewDeviceInsert() (DeviceInsertCapn, error) {
+	ss, err := NewDeviceInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return DeviceInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DeviceDBEventCapn) DeviceDelete() (DeviceDeleteCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return DeviceDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s DeviceDBEventCapn) HasDeviceDelete() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDBEventCapn) SetDeviceDelete(v DeviceDeleteCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewDeviceDelete sets the deviceDelete field to a newly
+// allocated DeviceDeleteCapn struct, preferring placement in s's segment.
+func (s DeviceDBEventCapn) NewDeviceDelete() (DeviceDeleteCapn, error) {
+	ss, err := NewDeviceDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return DeviceDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DeviceDBEventCapn) MigDeviceInsert() (MigDeviceInsertCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return MigDeviceInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s DeviceDBEventCapn) HasMigDeviceInsert() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDBEventCapn) SetMigDeviceInsert(v MigDeviceInsertCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewMigDeviceInsert sets the migDeviceInsert field to a newly
+// allocated MigDeviceInsertCapn struct, preferring placement in s's segment.
+func (s DeviceDBEventCapn) NewMigDeviceInsert() (MigDeviceInsertCapn, error) {
+	ss, err := NewMigDeviceInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return MigDeviceInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s DeviceDBEventCapn) DeviceUpdateTs() (DeviceUpdateTsCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return DeviceUpdateTsCapn{Struct: p.Struct()}, err
+}
+
+func (s DeviceDBEventCapn) HasDeviceUpdateTs() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDBEventCapn) SetDeviceUpdateTs(v DeviceUpdateTsCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewDeviceUpdateTs sets the deviceUpdateTs field to a newly
+// allocated DeviceUpdateTsCapn struct, preferring placement in s's segment.
+func (s DeviceDBEventCapn) NewDeviceUpdateTs() (DeviceUpdateTsCapn, error) {
+	ss, err := NewDeviceUpdateTsCapn(s.Struct.Segment())
+	if err != nil {
+		return DeviceUpdateTsCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// DeviceDBEventCapn_List is a list of DeviceDBEventCapn.
+type DeviceDBEventCapn_List struct{ capnp.List }
+
+// NewDeviceDBEventCapn creates a new list of DeviceDBEventCapn.
+func NewDeviceDBEventCapn_List(s *capnp.Segment, sz int32) (DeviceDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return DeviceDBEventCapn_List{l}, err
+}
+
+func (s DeviceDBEventCapn_List) At(i int) DeviceDBEventCapn {
+	return DeviceDBEventCapn{s.List.Struct(i)}
+}
+
+func (s DeviceDBEventCapn_List) Set(i int, v DeviceDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DeviceDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xf4c701d71ed2de49, s.List)
+	return str
+}
+
+// DeviceDBEventCapn_Promise is a wrapper for a DeviceDBEventCapn promised by a client call.
+type DeviceDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DeviceDBEventCapn_Promise) Struct() (DeviceDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DeviceDBEventCapn{s}, err
+}
+
+func (p DeviceDBEventCapn_Promise) DeviceInsert() DeviceInsertCapn_Promise {
+	return DeviceInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p DeviceDBEventCapn_Promise) DeviceDelete() DeviceDeleteCapn_Promise {
+	return DeviceDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p DeviceDBEventCapn_Promise) MigDeviceInsert() MigDeviceInsertCapn_Promise {
+	return MigDeviceInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p DeviceDBEventCapn_Promise) DeviceUpdateTs() DeviceUpdateTsCapn_Promise {
+	return DeviceUpdateTsCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+type DeviceInsertCapn struct{ capnp.Struct }
+
+// DeviceInsertCapn_TypeID is the unique identifier for the type DeviceInsertCapn.
+const DeviceInsertCapn_TypeID = 0x9caada5660f34b50
+
+func NewDeviceInsertCapn(s *capnp.Segment) (DeviceInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5})
+	return DeviceInsertCapn{st}, err
+}
+
+func NewRootDeviceInsertCapn(s *capnp.Segment) (DeviceInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5})
+	return DeviceInsertCapn{st}, err
+}
+
+func ReadRootDeviceInsertCapn(msg *capnp.Message) (DeviceInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return DeviceInsertCapn{root.Struct()}, err
+}
+
+func (s DeviceInsertCapn) String() string {
+	str, _ := text.Marshal(0x9caada5660f34b50, s.Struct)
+	return str
+}
+
+func (s DeviceInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DeviceInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DeviceInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DeviceInsertCapn) DisplayName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s DeviceInsertCapn) HasDisplayName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceInsertCapn) DisplayNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s DeviceInsertCapn) SetDisplayName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s DeviceInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s DeviceInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s DeviceInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s DeviceInsertCapn) CreatedTs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s DeviceInsertCapn) SetCreatedTs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s DeviceInsertCapn) DeviceType() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s DeviceInsertCapn) HasDeviceType() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceInsertCapn) DeviceTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s DeviceInsertCapn) SetDeviceType(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s DeviceInsertCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s DeviceInsertCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceInsertCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s DeviceInsertCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s DeviceInsertCapn) LastActiveTs() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s DeviceInsertCapn) SetLastActiveTs(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+// DeviceInsertCapn_List is a list of DeviceInsertCapn.
+type DeviceInsertCapn_List struct{ capnp.List }
+
+// NewDeviceInsertCapn creates a new list of DeviceInsertCapn.
+func NewDeviceInsertCapn_List(s *capnp.Segment, sz int32) (DeviceInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 5}, sz)
+	return DeviceInsertCapn_List{l}, err
+}
+
+func (s DeviceInsertCapn_List) At(i int) DeviceInsertCapn { return DeviceInsertCapn{s.List.Struct(i)} }
+
+func (s DeviceInsertCapn_List) Set(i int, v DeviceInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DeviceInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x9caada5660f34b50, s.List)
+	return str
+}
+
+// DeviceInsertCapn_Promise is a wrapper for a DeviceInsertCapn promised by a client call.
+type DeviceInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DeviceInsertCapn_Promise) Struct() (DeviceInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DeviceInsertCapn{s}, err
+}
+
+type DeviceDeleteCapn struct{ capnp.Struct }
+
+// DeviceDeleteCapn_TypeID is the unique identifier for the type DeviceDeleteCapn.
+const DeviceDeleteCapn_TypeID = 0xefa13433b67a1263
+
+func NewDeviceDeleteCapn(s *capnp.Segment) (DeviceDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return DeviceDeleteCapn{st}, err
+}
+
+func NewRootDeviceDeleteCapn(s *capnp.Segment) (DeviceDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return DeviceDeleteCapn{st}, err
+}
+
+func ReadRootDeviceDeleteCapn(msg *capnp.Message) (DeviceDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return DeviceDeleteCapn{root.Struct()}, err
+}
+
+func (s DeviceDeleteCapn) String() string {
+	str, _ := text.Marshal(0xefa13433b67a1263, s.Struct)
+	return str
+}
+
+func (s DeviceDeleteCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DeviceDeleteCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDeleteCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DeviceDeleteCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DeviceDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s DeviceDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s DeviceDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s DeviceDeleteCapn) CreateTs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s DeviceDeleteCapn) SetCreateTs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// DeviceDeleteCapn_List is a list of DeviceDeleteCapn.
+type DeviceDeleteCapn_List struct{ capnp.List }
+
+// NewDeviceDeleteCapn creates a new list of DeviceDeleteCapn.
+func NewDeviceDeleteCapn_List(s *capnp.Segment, sz int32) (DeviceDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return DeviceDeleteCapn_List{l}, err
+}
+
+func (s DeviceDeleteCapn_List) At(i int) DeviceDeleteCapn { return DeviceDeleteCapn{s.List.Struct(i)} }
+
+func (s DeviceDeleteCapn_List) Set(i int, v DeviceDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DeviceDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xefa13433b67a1263, s.List)
+	return str
+}
+
+// DeviceDeleteCapn_Promise is a wrapper for a DeviceDeleteCapn promised by a client call.
+type DeviceDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DeviceDeleteCapn_Promise) Struct() (DeviceDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DeviceDeleteCapn{s}, err
+}
+
+type MigDeviceInsertCapn struct{ capnp.Struct }
+
+// MigDeviceInsertCapn_TypeID is the unique identifier for the type MigDeviceInsertCapn.
+const MigDeviceInsertCapn_TypeID = 0xc5af7ccd500f00d9
+
+func NewMigDeviceInsertCapn(s *capnp.Segment) (MigDeviceInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return MigDeviceInsertCapn{st}, err
+}
+
+func NewRootMigDeviceInsertCapn(s *capnp.Segment) (MigDeviceInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return MigDeviceInsertCapn{st}, err
+}
+
+func ReadRootMigDeviceInsertCapn(msg *capnp.Message) (MigDeviceInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return MigDeviceInsertCapn{root.Struct()}, err
+}
+
+func (s MigDeviceInsertCapn) String() string {
+	str, _ := text.Marshal(0xc5af7ccd500f00d9, s.Struct)
+	return str
+}
+
+func (s MigDeviceInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s MigDeviceInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s MigDeviceInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s MigDeviceInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s MigDeviceInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s MigDeviceInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s MigDeviceInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s MigDeviceInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s MigDeviceInsertCapn) AccessToken() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s MigDeviceInsertCapn) HasAccessToken() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s MigDeviceInsertCapn) AccessTokenBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s MigDeviceInsertCapn) SetAccessToken(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s MigDeviceInsertCapn) MigAccessToken() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s MigDeviceInsertCapn) HasMigAccessToken() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s MigDeviceInsertCapn) MigAccessTokenBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s MigDeviceInsertCapn) SetMigAccessToken(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// MigDeviceInsertCapn_List is a list of MigDeviceInsertCapn.
+type MigDeviceInsertCapn_List struct{ capnp.List }
+
+// NewMigDeviceInsertCapn creates a new list of MigDeviceInsertCapn.
+func NewMigDeviceInsertCapn_List(s *capnp.Segment, sz int32) (MigDeviceInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return MigDeviceInsertCapn_List{l}, err
+}
+
+func (s MigDeviceInsertCapn_List) At(i int) MigDeviceInsertCapn {
+	return MigDeviceInsertCapn{s.List.Struct(i)}
+}
+
+func (s MigDeviceInsertCapn_List) Set(i int, v MigDeviceInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s MigDeviceInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xc5af7ccd500f00d9, s.List)
+	return str
+}
+
+// MigDeviceInsertCapn_Promise is a wrapper for a MigDeviceInsertCapn promised by a client call.
+type MigDeviceInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p MigDeviceInsertCapn_Promise) Struct() (MigDeviceInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return MigDeviceInsertCapn{s}, err
+}
+
+type DeviceUpdateTsCapn struct{ capnp.Struct }
+
+// DeviceUpdateTsCapn_TypeID is the unique identifier for the type DeviceUpdateTsCapn.
+const DeviceUpdateTsCapn_TypeID = 0x846de35bd5cb69c4
+
+func NewDeviceUpdateTsCapn(s *capnp.Segment) (DeviceUpdateTsCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return DeviceUpdateTsCapn{st}, err
+}
+
+func NewRootDeviceUpdateTsCapn(s *capnp.Segment) (DeviceUpdateTsCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return DeviceUpdateTsCapn{st}, err
+}
+
+func ReadRootDeviceUpdateTsCapn(msg *capnp.Message) (DeviceUpdateTsCapn, error) {
+	root, err := msg.RootPtr()
+	return DeviceUpdateTsCapn{root.Struct()}, err
+}
+
+func (s DeviceUpdateTsCapn) String() string {
+	str, _ := text.Marshal(0x846de35bd5cb69c4, s.Struct)
+	return str
+}
+
+func (s DeviceUpdateTsCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DeviceUpdateTsCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceUpdateTsCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DeviceUpdateTsCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DeviceUpdateTsCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s DeviceUpdateTsCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceUpdateTsCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s DeviceUpdateTsCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s DeviceUpdateTsCapn) LastActiveTs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s DeviceUpdateTsCapn) SetLastActiveTs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// DeviceUpdateTsCapn_List is a list of DeviceUpdateTsCapn.
+type DeviceUpdateTsCapn_List struct{ capnp.List }
+
+// NewDeviceUpdateTsCapn creates a new list of DeviceUpdateTsCapn.
+func NewDeviceUpdateTsCapn_List(s *capnp.Segment, sz int32) (DeviceUpdateTsCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return DeviceUpdateTsCapn_List{l}, err
+}
+
+func (s DeviceUpdateTsCapn_List) At(i int) DeviceUpdateTsCapn {
+	return DeviceUpdateTsCapn{s.List.Struct(i)}
+}
+
+func (s DeviceUpdateTsCapn_List) Set(i int, v DeviceUpdateTsCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DeviceUpdateTsCapn_List) String() string {
+	str, _ := text.MarshalList(0x846de35bd5cb69c4, s.List)
+	return str
+}
+
+// DeviceUpdateTsCapn_Promise is a wrapper for a DeviceUpdateTsCapn promised by a client call.
+type DeviceUpdateTsCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DeviceUpdateTsCapn_Promise) Struct() (DeviceUpdateTsCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DeviceUpdateTsCapn{s}, err
+}
+
+type AccountDBEventCapn struct{ capnp.Struct }
+
+// AccountDBEventCapn_TypeID is the unique identifier for the type AccountDBEventCapn.
+const AccountDBEventCapn_TypeID = 0xc1d4316536e88683
+
+func NewAccountDBEventCapn(s *capnp.Segment) (AccountDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8})
+	return AccountDBEventCapn{st}, err
+}
+
+func NewRootAccountDBEventCapn(s *capnp.Segment) (AccountDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8})
+	return AccountDBEventCapn{st}, err
+}
+
+func ReadRootAccountDBEventCapn(msg *capnp.Message) (AccountDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return AccountDBEventCapn{root.Struct()}, err
+}
+
+func (s AccountDBEventCapn) String() string {
+	str, _ := text.Marshal(0xc1d4316536e88683, s.Struct)
+	return str
+}
+
+func (s AccountDBEventCapn) AccountDataInsert() (AccountDataInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return AccountDataInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasAccountDataInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetAccountDataInsert(v AccountDataInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewAccountDataInsert sets the accountDataInsert field to a newly
+// allocated AccountDataInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewAccountDataInsert() (AccountDataInsertCapn, error) {
+	ss, err := NewAccountDataInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return AccountDataInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) AccountInsert() (AccountInsertCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return AccountInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasAccountInsert() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetAccountInsert(v AccountInsertCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewAccountInsert sets the accountInsert field to a newly
+// allocated AccountInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewAccountInsert() (AccountInsertCapn, error) {
+	ss, err := NewAccountInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return AccountInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) FilterInsert() (FilterInsertCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return FilterInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasFilterInsert() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetFilterInsert(v FilterInsertCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewFilterInsert sets the filterInsert field to a newly
+// allocated FilterInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewFilterInsert() (FilterInsertCapn, error) {
+	ss, err := NewFilterInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return FilterInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) ProfileInsert() (ProfileInsertCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return ProfileInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasProfileInsert() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetProfileInsert(v ProfileInsertCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewProfileInsert sets the profileInsert field to a newly
+// allocated ProfileInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewProfileInsert() (ProfileInsertCapn, error) {
+	ss, err := NewProfileInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return ProfileInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) RoomTagInsert() (RoomTagInsertCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return RoomTagInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasRoomTagInsert() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetRoomTagInsert(v RoomTagInsertCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewRoomTagInsert sets the roomTagInsert field to a newly
+// allocated RoomTagInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewRoomTagInsert() (RoomTagInsertCapn, error) {
+	ss, err := NewRoomTagInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomTagInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) RoomTagDelete() (RoomTagDeleteCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return RoomTagDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasRoomTagDelete() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetRoomTagDelete(v RoomTagDeleteCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewRoomTagDelete sets the roomTagDelete field to a newly
+// allocated RoomTagDeleteCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewRoomTagDelete() (RoomTagDeleteCapn, error) {
+	ss, err := NewRoomTagDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return RoomTagDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) UserInfoInsert() (UserInfoInsertCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return UserInfoInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasUserInfoInsert() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetUserInfoInsert(v UserInfoInsertCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewUserInfoInsert sets the userInfoInsert field to a newly
+// allocated UserInfoInsertCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewUserInfoInsert() (UserInfoInsertCapn, error) {
+	ss, err := NewUserInfoInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return UserInfoInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s AccountDBEventCapn) UserInfoDelete() (UserInfoDeleteCapn, error) {
+	p, err := s.Struct.Ptr(7)
+	return UserInfoDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s AccountDBEventCapn) HasUserInfoDelete() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDBEventCapn) SetUserInfoDelete(v UserInfoDeleteCapn) error {
+	return s.Struct.SetPtr(7, v.Struct.ToPtr())
+}
+
+// NewUserInfoDelete sets the userInfoDelete field to a newly
+// allocated UserInfoDeleteCapn struct, preferring placement in s's segment.
+func (s AccountDBEventCapn) NewUserInfoDelete() (UserInfoDeleteCapn, error) {
+	ss, err := NewUserInfoDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return UserInfoDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(7, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// AccountDBEventCapn_List is a list of AccountDBEventCapn.
+type AccountDBEventCapn_List struct{ capnp.List }
+
+// NewAccountDBEventCapn creates a new list of AccountDBEventCapn.
+func NewAccountDBEventCapn_List(s *capnp.Segment, sz int32) (AccountDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 8}, sz)
+	return AccountDBEventCapn_List{l}, err
+}
+
+func (s AccountDBEventCapn_List) At(i int) AccountDBEventCapn {
+	return AccountDBEventCapn{s.List.Struct(i)}
+}
+
+func (s AccountDBEventCapn_List) Set(i int, v AccountDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s AccountDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xc1d4316536e88683, s.List)
+	return str
+}
+
+// AccountDBEventCapn_Promise is a wrapper for a AccountDBEventCapn promised by a client call.
+type AccountDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AccountDBEventCapn_Promise) Struct() (AccountDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AccountDBEventCapn{s}, err
+}
+
+func (p AccountDBEventCapn_Promise) AccountDataInsert() AccountDataInsertCapn_Promise {
+	return AccountDataInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p AccountDBEventCapn_Promise) AccountInsert() AccountInsertCapn_Promise {
+	return AccountInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p AccountDBEventCapn_Promise) FilterInsert() FilterInsertCapn_Promise {
+	return FilterInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p AccountDBEventCapn_Promise) ProfileInsert() ProfileInsertCapn_Promise {
+	return ProfileInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p AccountDBEventCapn_Promise) RoomTagInsert() RoomTagInsertCapn_Promise {
+	return RoomTagInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p AccountDBEventCapn_Promise) RoomTagDelete() RoomTagDeleteCapn_Promise {
+	return RoomTagDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p AccountDBEventCapn_Promise) UserInfoInsert() UserInfoInsertCapn_Promise {
+	return UserInfoInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+func (p AccountDBEventCapn_Promise) UserInfoDelete() UserInfoDeleteCapn_Promise {
+	return UserInfoDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(7)}
+}
+
+type AccountDataInsertCapn struct{ capnp.Struct }
+
+// AccountDataInsertCapn_TypeID is the unique identifier for the type AccountDataInsertCapn.
+const AccountDataInsertCapn_TypeID = 0xb149d31e684e5065
+
+func NewAccountDataInsertCapn(s *capnp.Segment) (AccountDataInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return AccountDataInsertCapn{st}, err
+}
+
+func NewRootAccountDataInsertCapn(s *capnp.Segment) (AccountDataInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return AccountDataInsertCapn{st}, err
+}
+
+func ReadRootAccountDataInsertCapn(msg *capnp.Message) (AccountDataInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return AccountDataInsertCapn{root.Struct()}, err
+}
+
+func (s AccountDataInsertCapn) String() string {
+	str, _ := text.Marshal(0xb149d31e684e5065, s.Struct)
+	return str
+}
+
+func (s AccountDataInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s AccountDataInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDataInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AccountDataInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s AccountDataInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s AccountDataInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDataInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s AccountDataInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s AccountDataInsertCapn) Type() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s AccountDataInsertCapn) HasType() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDataInsertCapn) TypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s AccountDataInsertCapn) SetType(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s AccountDataInsertCapn) Content() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s AccountDataInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountDataInsertCapn) ContentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s AccountDataInsertCapn) SetContent(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// AccountDataInsertCapn_List is a list of AccountDataInsertCapn.
+type AccountDataInsertCapn_List struct{ capnp.List }
+
+// NewAccountDataInsertCapn creates a new list of AccountDataInsertCapn.
+func NewAccountDataInsertCapn_List(s *capnp.Segment, sz int32) (AccountDataInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return AccountDataInsertCapn_List{l}, err
+}
+
+func (s AccountDataInsertCapn_List) At(i int) AccountDataInsertCapn {
+	return AccountDataInsertCapn{s.List.Struct(i)}
+}
+
+func (s AccountDataInsertCapn_List) Set(i int, v AccountDataInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s AccountDataInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xb149d31e684e5065, s.List)
+	return str
+}
+
+// AccountDataInsertCapn_Promise is a wrapper for a AccountDataInsertCapn promised by a client call.
+type AccountDataInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AccountDataInsertCapn_Promise) Struct() (AccountDataInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AccountDataInsertCapn{s}, err
+}
+
+type AccountInsertCapn struct{ capnp.Struct }
+
+// AccountInsertCapn_TypeID is the unique identifier for the type AccountInsertCapn.
+const AccountInsertCapn_TypeID = 0xd5ae2f77b3854e35
+
+func NewAccountInsertCapn(s *capnp.Segment) (AccountInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return AccountInsertCapn{st}, err
+}
+
+func NewRootAccountInsertCapn(s *capnp.Segment) (AccountInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return AccountInsertCapn{st}, err
+}
+
+func ReadRootAccountInsertCapn(msg *capnp.Message) (AccountInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return AccountInsertCapn{root.Struct()}, err
+}
+
+func (s AccountInsertCapn) String() string {
+	str, _ := text.Marshal(0xd5ae2f77b3854e35, s.Struct)
+	return str
+}
+
+func (s AccountInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s AccountInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AccountInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s AccountInsertCapn) CreatedTs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s AccountInsertCapn) SetCreatedTs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s AccountInsertCapn) PassWordHash() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s AccountInsertCapn) HasPassWordHash() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountInsertCapn) PassWordHashBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s AccountInsertCapn) SetPassWordHash(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s AccountInsertCapn) AppServiceID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s AccountInsertCapn) HasAppServiceID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s AccountInsertCapn) AppServiceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s AccountInsertCapn) SetAppServiceID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// AccountInsertCapn_List is a list of AccountInsertCapn.
+type AccountInsertCapn_List struct{ capnp.List }
+
+// NewAccountInsertCapn creates a new list of AccountInsertCapn.
+func NewAccountInsertCapn_List(s *capnp.Segment, sz int32) (AccountInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return AccountInsertCapn_List{l}, err
+}
+
+func (s AccountInsertCapn_List) At(i int) AccountInsertCapn {
+	return AccountInsertCapn{s.List.Struct(i)}
+}
+
+func (s AccountInsertCapn_List) Set(i int, v AccountInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s AccountInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xd5ae2f77b3854e35, s.List)
+	return str
+}
+
+// AccountInsertCapn_Promise is a wrapper for a AccountInsertCapn promised by a client call.
+type AccountInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AccountInsertCapn_Promise) Struct() (AccountInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AccountInsertCapn{s}, err
+}
+
+type FilterInsertCapn struct{ capnp.Struct }
+
+// FilterInsertCapn_TypeID is the unique identifier for the type FilterInsertCapn.
+const FilterInsertCapn_TypeID = 0xdef224b5f6c7fe42
+
+func NewFilterInsertCapn(s *capnp.Segment) (FilterInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return FilterInsertCapn{st}, err
+}
+
+func NewRootFilterInsertCapn(s *capnp.Segment) (FilterInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return FilterInsertCapn{st}, err
+}
+
+func ReadRootFilterInsertCapn(msg *capnp.Message) (FilterInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return FilterInsertCapn{root.Struct()}, err
+}
+
+func (s FilterInsertCapn) String() string {
+	str, _ := text.Marshal(0xdef224b5f6c7fe42, s.Struct)
+	return str
+}
+
+func (s FilterInsertCapn) Filter() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s FilterInsertCapn) HasFilter() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s FilterInsertCapn) FilterBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s FilterInsertCapn) SetFilter(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s FilterInsertCapn) FilterID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s FilterInsertCapn) HasFilterID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s FilterInsertCapn) FilterIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s FilterInsertCapn) SetFilterID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s FilterInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s FilterInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FilterInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s FilterInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// FilterInsertCapn_List is a list of FilterInsertCapn.
+type FilterInsertCapn_List struct{ capnp.List }
+
+// NewFilterInsertCapn creates a new list of FilterInsertCapn.
+func NewFilterInsertCapn_List(s *capnp.Segment, sz int32) (FilterInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return FilterInsertCapn_List{l}, err
+}
+
+func (s FilterInsertCapn_List) At(i int) FilterInsertCapn { return FilterInsertCapn{s.List.Struct(i)} }
+
+func (s FilterInsertCapn_List) Set(i int, v FilterInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s FilterInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xdef224b5f6c7fe42, s.List)
+	return str
+}
+
+// FilterInsertCapn_Promise is a wrapper for a FilterInsertCapn promised by a client call.
+type FilterInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p FilterInsertCapn_Promise) Struct() (FilterInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return FilterInsertCapn{s}, err
+}
+
+type ProfileInsertCapn struct{ capnp.Struct }
+
+// ProfileInsertCapn_TypeID is the unique identifier for the type ProfileInsertCapn.
+const ProfileInsertCapn_TypeID = 0xfa3287842b3c69b2
+
+func NewProfileInsertCapn(s *capnp.Segment) (ProfileInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return ProfileInsertCapn{st}, err
+}
+
+func NewRootProfileInsertCapn(s *capnp.Segment) (ProfileInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return ProfileInsertCapn{st}, err
+}
+
+func ReadRootProfileInsertCapn(msg *capnp.Message) (ProfileInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return ProfileInsertCapn{root.Struct()}, err
+}
+
+func (s ProfileInsertCapn) String() string {
+	str, _ := text.Marshal(0xfa3287842b3c69b2, s.Struct)
+	return str
+}
+
+func (s ProfileInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s ProfileInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s ProfileInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s ProfileInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s ProfileInsertCapn) DisplayName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s ProfileInsertCapn) HasDisplayName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s ProfileInsertCapn) DisplayNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s ProfileInsertCapn) SetDisplayName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s ProfileInsertCapn) AvatarUrl() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s ProfileInsertCapn) HasAvatarUrl() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s ProfileInsertCapn) AvatarUrlBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s ProfileInsertCapn) SetAvatarUrl(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// ProfileInsertCapn_List is a list of ProfileInsertCapn.
+type ProfileInsertCapn_List struct{ capnp.List }
+
+// NewProfileInsertCapn creates a new list of ProfileInsertCapn.
+func NewProfileInsertCapn_List(s *capnp.Segment, sz int32) (ProfileInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return ProfileInsertCapn_List{l}, err
+}
+
+func (s ProfileInsertCapn_List) At(i int) ProfileInsertCapn {
+	return ProfileInsertCapn{s.List.Struct(i)}
+}
+
+func (s ProfileInsertCapn_List) Set(i int, v ProfileInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s ProfileInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xfa3287842b3c69b2, s.List)
+	return str
+}
+
+// ProfileInsertCapn_Promise is a wrapper for a ProfileInsertCapn promised by a client call.
+type ProfileInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p ProfileInsertCapn_Promise) Struct() (ProfileInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return ProfileInsertCapn{s}, err
+}
+
+type RoomTagInsertCapn struct{ capnp.Struct }
+
+// RoomTagInsertCapn_TypeID is the unique identifier for the type RoomTagInsertCapn.
+const RoomTagInsertCapn_TypeID = 0x8fa7fe6530c007cb
+
+func NewRoomTagInsertCapn(s *capnp.Segment) (RoomTagInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return RoomTagInsertCapn{st}, err
+}
+
+func NewRootRoomTagInsertCapn(s *capnp.Segment) (RoomTagInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return RoomTagInsertCapn{st}, err
+}
+
+func ReadRootRoomTagInsertCapn(msg *capnp.Message) (RoomTagInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomTagInsertCapn{root.Struct()}, err
+}
+
+func (s RoomTagInsertCapn) String() string {
+	str, _ := text.Marshal(0x8fa7fe6530c007cb, s.Struct)
+	return str
+}
+
+func (s RoomTagInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s RoomTagInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s RoomTagInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s RoomTagInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s RoomTagInsertCapn) Tag() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s RoomTagInsertCapn) HasTag() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagInsertCapn) TagBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagInsertCapn) SetTag(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s RoomTagInsertCapn) Content() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s RoomTagInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagInsertCapn) SetContent(v []byte) error {
+	return s.Struct.SetData(3, v)
+}
+
+// RoomTagInsertCapn_List is a list of RoomTagInsertCapn.
+type RoomTagInsertCapn_List struct{ capnp.List }
+
+// NewRoomTagInsertCapn creates a new list of RoomTagInsertCapn.
+func NewRoomTagInsertCapn_List(s *capnp.Segment, sz int32) (RoomTagInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return RoomTagInsertCapn_List{l}, err
+}
+
+func (s RoomTagInsertCapn_List) At(i int) RoomTagInsertCapn {
+	return RoomTagInsertCapn{s.List.Struct(i)}
+}
+
+func (s RoomTagInsertCapn_List) Set(i int, v RoomTagInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomTagInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x8fa7fe6530c007cb, s.List)
+	return str
+}
+
+// RoomTagInsertCapn_Promise is a wrapper for a RoomTagInsertCapn promised by a client call.
+type RoomTagInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomTagInsertCapn_Promise) Struct() (RoomTagInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomTagInsertCapn{s}, err
+}
+
+type RoomTagDeleteCapn struct{ capnp.Struct }
+
+// RoomTagDeleteCapn_TypeID is the unique identifier for the type RoomTagDeleteCapn.
+const RoomTagDeleteCapn_TypeID = 0xf54a62a1cdd8c583
+
+func NewRoomTagDeleteCapn(s *capnp.Segment) (RoomTagDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return RoomTagDeleteCapn{st}, err
+}
+
+func NewRootRoomTagDeleteCapn(s *capnp.Segment) (RoomTagDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return RoomTagDeleteCapn{st}, err
+}
+
+func ReadRootRoomTagDeleteCapn(msg *capnp.Message) (RoomTagDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return RoomTagDeleteCapn{root.Struct()}, err
+}
+
+func (s RoomTagDeleteCapn) String() string {
+	str, _ := text.Marshal(0xf54a62a1cdd8c583, s.Struct)
+	return str
+}
+
+func (s RoomTagDeleteCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s RoomTagDeleteCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagDeleteCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagDeleteCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s RoomTagDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s RoomTagDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s RoomTagDeleteCapn) Tag() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s RoomTagDeleteCapn) HasTag() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s RoomTagDeleteCapn) TagBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s RoomTagDeleteCapn) SetTag(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// RoomTagDeleteCapn_List is a list of RoomTagDeleteCapn.
+type RoomTagDeleteCapn_List struct{ capnp.List }
+
+// NewRoomTagDeleteCapn creates a new list of RoomTagDeleteCapn.
+func NewRoomTagDeleteCapn_List(s *capnp.Segment, sz int32) (RoomTagDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return RoomTagDeleteCapn_List{l}, err
+}
+
+func (s RoomTagDeleteCapn_List) At(i int) RoomTagDeleteCapn {
+	return RoomTagDeleteCapn{s.List.Struct(i)}
+}
+
+func (s RoomTagDeleteCapn_List) Set(i int, v RoomTagDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s RoomTagDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xf54a62a1cdd8c583, s.List)
+	return str
+}
+
+// RoomTagDeleteCapn_Promise is a wrapper for a RoomTagDeleteCapn promised by a client call.
+type RoomTagDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p RoomTagDeleteCapn_Promise) Struct() (RoomTagDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return RoomTagDeleteCapn{s}, err
+}
+
+type UserInfoInsertCapn struct{ capnp.Struct }
+
+// UserInfoInsertCapn_TypeID is the unique identifier for the type UserInfoInsertCapn.
+const UserInfoInsertCapn_TypeID = 0xcc4535339f0af3e9
+
+func NewUserInfoInsertCapn(s *capnp.Segment) (UserInfoInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return UserInfoInsertCapn{st}, err
+}
+
+func NewRootUserInfoInsertCapn(s *capnp.Segment) (UserInfoInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return UserInfoInsertCapn{st}, err
+}
+
+func ReadRootUserInfoInsertCapn(msg *capnp.Message) (UserInfoInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return UserInfoInsertCapn{root.Struct()}, err
+}
+
+func (s UserInfoInsertCapn) String() string {
+	str, _ := text.Marshal(0xcc4535339f0af3e9, s.Struct)
+	return str
+}
+
+func (s UserInfoInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s UserInfoInsertCapn) UserName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasUserName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) UserNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetUserName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s UserInfoInsertCapn) JobNumber() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasJobNumber() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) JobNumberBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetJobNumber(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s UserInfoInsertCapn) Mobile() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasMobile() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) MobileBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetMobile(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s UserInfoInsertCapn) Landline() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasLandline() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) LandlineBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetLandline(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s UserInfoInsertCapn) Email() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s UserInfoInsertCapn) HasEmail() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoInsertCapn) EmailBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoInsertCapn) SetEmail(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s UserInfoInsertCapn) State() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s UserInfoInsertCapn) SetState(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// UserInfoInsertCapn_List is a list of UserInfoInsertCapn.
+type UserInfoInsertCapn_List struct{ capnp.List }
+
+// NewUserInfoInsertCapn creates a new list of UserInfoInsertCapn.
+func NewUserInfoInsertCapn_List(s *capnp.Segment, sz int32) (UserInfoInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6}, sz)
+	return UserInfoInsertCapn_List{l}, err
+}
+
+func (s UserInfoInsertCapn_List) At(i int) UserInfoInsertCapn {
+	return UserInfoInsertCapn{s.List.Struct(i)}
+}
+
+func (s UserInfoInsertCapn_List) Set(i int, v UserInfoInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s UserInfoInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xcc4535339f0af3e9, s.List)
+	return str
+}
+
+// UserInfoInsertCapn_Promise is a wrapper for a UserInfoInsertCapn promised by a client call.
+type UserInfoInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p UserInfoInsertCapn_Promise) Struct() (UserInfoInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return UserInfoInsertCapn{s}, err
+}
+
+type UserInfoDeleteCapn struct{ capnp.Struct }
+
+// UserInfoDeleteCapn_TypeID is the unique identifier for the type UserInfoDeleteCapn.
+const UserInfoDeleteCapn_TypeID = 0xdc11cba3149e3be4
+
+func NewUserInfoDeleteCapn(s *capnp.Segment) (UserInfoDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return UserInfoDeleteCapn{st}, err
+}
+
+func NewRootUserInfoDeleteCapn(s *capnp.Segment) (UserInfoDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return UserInfoDeleteCapn{st}, err
+}
+
+func ReadRootUserInfoDeleteCapn(msg *capnp.Message) (UserInfoDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return UserInfoDeleteCapn{root.Struct()}, err
+}
+
+func (s UserInfoDeleteCapn) String() string {
+	str, _ := text.Marshal(0xdc11cba3149e3be4, s.Struct)
+	return str
+}
+
+func (s UserInfoDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s UserInfoDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s UserInfoDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s UserInfoDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// UserInfoDeleteCapn_List is a list of UserInfoDeleteCapn.
+type UserInfoDeleteCapn_List struct{ capnp.List }
+
+// NewUserInfoDeleteCapn creates a new list of UserInfoDeleteCapn.
+func NewUserInfoDeleteCapn_List(s *capnp.Segment, sz int32) (UserInfoDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return UserInfoDeleteCapn_List{l}, err
+}
+
+func (s UserInfoDeleteCapn_List) At(i int) UserInfoDeleteCapn {
+	return UserInfoDeleteCapn{s.List.Struct(i)}
+}
+
+func (s UserInfoDeleteCapn_List) Set(i int, v UserInfoDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s UserInfoDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xdc11cba3149e3be4, s.List)
+	return str
+}
+
+// UserInfoDeleteCapn_Promise is a wrapper for a UserInfoDeleteCapn promised by a client call.
+type UserInfoDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p UserInfoDeleteCapn_Promise) Struct() (UserInfoDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return UserInfoDeleteCapn{s}, err
+}
+
+type PushDBEventCapn struct{ capnp.Struct }
+
+// PushDBEventCapn_TypeID is the unique identifier for the type PushDBEventCapn.
+const PushDBEventCapn_TypeID = 0xb23426a7cf33195d
+
+func NewPushDBEventCapn(s *capnp.Segment) (PushDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return PushDBEventCapn{st}, err
+}
+
+func NewRootPushDBEventCapn(s *capnp.Segment) (PushDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return PushDBEventCapn{st}, err
+}
+
+func ReadRootPushDBEventCapn(msg *capnp.Message) (PushDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return PushDBEventCapn{root.Struct()}, err
+}
+
+func (s PushDBEventCapn) String() string {
+	str, _ := text.Marshal(0xb23426a7cf33195d, s.Struct)
+	return str
+}
+
+func (s PushDBEventCapn) PusherDelete() (PusherDeleteCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return PusherDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPusherDelete() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPusherDelete(v PusherDeleteCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPusherDelete sets the pusherDelete field to a newly
+// allocated PusherDeleteCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPusherDelete() (PusherDeleteCapn, error) {
+	ss, err := NewPusherDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return PusherDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PusherDeleteByKey() (PusherDeleteByKeyCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return PusherDeleteByKeyCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPusherDeleteByKey() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPusherDeleteByKey(v PusherDeleteByKeyCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewPusherDeleteByKey sets the pusherDeleteByKey field to a newly
+// allocated PusherDeleteByKeyCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPusherDeleteByKey() (PusherDeleteByKeyCapn, error) {
+	ss, err := NewPusherDeleteByKeyCapn(s.Struct.Segment())
+	if err != nil {
+		return PusherDeleteByKeyCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PusherDeleteByKeyOnly() (PusherDeleteByKeyOnlyCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return PusherDeleteByKeyOnlyCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPusherDeleteByKeyOnly() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPusherDeleteByKeyOnly(v PusherDeleteByKeyOnlyCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewPusherDeleteByKeyOnly sets the pusherDeleteByKeyOnly field to a newly
+// allocated PusherDeleteByKeyOnlyCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPusherDeleteByKeyOnly() (PusherDeleteByKeyOnlyCapn, error) {
+	ss, err := NewPusherDeleteByKeyOnlyCapn(s.Struct.Segment())
+	if err != nil {
+		return PusherDeleteByKeyOnlyCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PusherInsert() (PusherInsertCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return PusherInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPusherInsert() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPusherInsert(v PusherInsertCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewPusherInsert sets the pusherInsert field to a newly
+// allocated PusherInsertCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPusherInsert() (PusherInsertCapn, error) {
+	ss, err := NewPusherInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return PusherInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PushRuleInert() (PushRuleInertCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return PushRuleInertCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPushRuleInert() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPushRuleInert(v PushRuleInertCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewPushRuleInert sets the pushRuleInert field to a newly
+// allocated PushRuleInertCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPushRuleInert() (PushRuleInertCapn, error) {
+	ss, err := NewPushRuleInertCapn(s.Struct.Segment())
+	if err != nil {
+		return PushRuleInertCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PushRuleDelete() (PushRuleDeleteCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return PushRuleDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPushRuleDelete() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPushRuleDelete(v PushRuleDeleteCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewPushRuleDelete sets the pushRuleDelete field to a newly
+// allocated PushRuleDeleteCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPushRuleDelete() (PushRuleDeleteCapn, error) {
+	ss, err := NewPushRuleDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return PushRuleDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PushDBEventCapn) PushRuleEnableInsert() (PushRuleEnableInsertCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return PushRuleEnableInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s PushDBEventCapn) HasPushRuleEnableInsert() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s PushDBEventCapn) SetPushRuleEnableInsert(v PushRuleEnableInsertCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewPushRuleEnableInsert sets the pushRuleEnableInsert field to a newly
+// allocated PushRuleEnableInsertCapn struct, preferring placement in s's segment.
+func (s PushDBEventCapn) NewPushRuleEnableInsert() (PushRuleEnableInsertCapn, error) {
+	ss, err := NewPushRuleEnableInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return PushRuleEnableInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// PushDBEventCapn_List is a list of PushDBEventCapn.
+type PushDBEventCapn_List struct{ capnp.List }
+
+// NewPushDBEventCapn creates a new list of PushDBEventCapn.
+func NewPushDBEventCapn_List(s *capnp.Segment, sz int32) (PushDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7}, sz)
+	return PushDBEventCapn_List{l}, err
+}
+
+func (s PushDBEventCapn_List) At(i int) PushDBEventCapn { return PushDBEventCapn{s.List.Struct(i)} }
+
+func (s PushDBEventCapn_List) Set(i int, v PushDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PushDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xb23426a7cf33195d, s.List)
+	return str
+}
+
+// PushDBEventCapn_Promise is a wrapper for a PushDBEventCapn promised by a client call.
+type PushDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PushDBEventCapn_Promise) Struct() (PushDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PushDBEventCapn{s}, err
+}
+
+func (p PushDBEventCapn_Promise) PusherDelete() PusherDeleteCapn_Promise {
+	return PusherDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p PushDBEventCapn_Promise) PusherDeleteByKey() PusherDeleteByKeyCapn_Promise {
+	return PusherDeleteByKeyCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p PushDBEventCapn_Promise) PusherDeleteByKeyOnly() PusherDeleteByKeyOnlyCapn_Promise {
+	return PusherDeleteByKeyOnlyCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p PushDBEventCapn_Promise) PusherInsert() PusherInsertCapn_Promise {
+	return PusherInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p PushDBEventCapn_Promise) PushRuleInert() PushRuleInertCapn_Promise {
+	return PushRuleInertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p PushDBEventCapn_Promise) PushRuleDelete() PushRuleDeleteCapn_Promise {
+	return PushRuleDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p PushDBEventCapn_Promise) PushRuleEnableInsert() PushRuleEnableInsertCapn_Promise {
+	return PushRuleEnableInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+type PusherDeleteCapn struct{ capnp.Struct }
+
+// PusherDeleteCapn_TypeID is the unique identifier for the type PusherDeleteCapn.
+const PusherDeleteCapn_TypeID = 0xb0188cdd819e08c8
+
+func NewPusherDeleteCapn(s *capnp.Segment) (PusherDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return PusherDeleteCapn{st}, err
+}
+
+func NewRootPusherDeleteCapn(s *capnp.Segment) (PusherDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return PusherDeleteCapn{st}, err
+}
+
+func ReadRootPusherDeleteCapn(msg *capnp.Message) (PusherDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return PusherDeleteCapn{root.Struct()}, err
+}
+
+func (s PusherDeleteCapn) String() string {
+	str, _ := text.Marshal(0xb0188cdd819e08c8, s.Struct)
+	return str
+}
+
+func (s PusherDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PusherDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PusherDeleteCapn) AppID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PusherDeleteCapn) HasAppID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteCapn) AppIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteCapn) SetAppID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PusherDeleteCapn) PushKey() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s PusherDeleteCapn) HasPushKey() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteCapn) PushKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteCapn) SetPushKey(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// PusherDeleteCapn_List is a list of PusherDeleteCapn.
+type PusherDeleteCapn_List struct{ capnp.List }
+
+// NewPusherDeleteCapn creates a new list of PusherDeleteCapn.
+func NewPusherDeleteCapn_List(s *capnp.Segment, sz int32) (PusherDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return PusherDeleteCapn_List{l}, err
+}
+
+func (s PusherDeleteCapn_List) At(i int) PusherDeleteCapn { return PusherDeleteCapn{s.List.Struct(i)} }
+
+func (s PusherDeleteCapn_List) Set(i int, v PusherDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PusherDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xb0188cdd819e08c8, s.List)
+	return str
+}
+
+// PusherDeleteCapn_Promise is a wrapper for a PusherDeleteCapn promised by a client call.
+type PusherDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PusherDeleteCapn_Promise) Struct() (PusherDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PusherDeleteCapn{s}, err
+}
+
+type PusherDeleteByKeyCapn struct{ capnp.Struct }
+
+// PusherDeleteByKeyCapn_TypeID is the unique identifier for the type PusherDeleteByKeyCapn.
+const PusherDeleteByKeyCapn_TypeID = 0xc96ba43ce2771476
+
+func NewPusherDeleteByKeyCapn(s *capnp.Segment) (PusherDeleteByKeyCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return PusherDeleteByKeyCapn{st}, err
+}
+
+func NewRootPusherDeleteByKeyCapn(s *capnp.Segment) (PusherDeleteByKeyCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return PusherDeleteByKeyCapn{st}, err
+}
+
+func ReadRootPusherDeleteByKeyCapn(msg *capnp.Message) (PusherDeleteByKeyCapn, error) {
+	root, err := msg.RootPtr()
+	return PusherDeleteByKeyCapn{root.Struct()}, err
+}
+
+func (s PusherDeleteByKeyCapn) String() string {
+	str, _ := text.Marshal(0xc96ba43ce2771476, s.Struct)
+	return str
+}
+
+func (s PusherDeleteByKeyCapn) AppID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PusherDeleteByKeyCapn) HasAppID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteByKeyCapn) AppIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteByKeyCapn) SetAppID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PusherDeleteByKeyCapn) PushKey() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PusherDeleteByKeyCapn) HasPushKey() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteByKeyCapn) PushKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteByKeyCapn) SetPushKey(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// PusherDeleteByKeyCapn_List is a list of PusherDeleteByKeyCapn.
+type PusherDeleteByKeyCapn_List struct{ capnp.List }
+
+// NewPusherDeleteByKeyCapn creates a new list of PusherDeleteByKeyCapn.
+func NewPusherDeleteByKeyCapn_List(s *capnp.Segment, sz int32) (PusherDeleteByKeyCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return PusherDeleteByKeyCapn_List{l}, err
+}
+
+func (s PusherDeleteByKeyCapn_List) At(i int) PusherDeleteByKeyCapn {
+	return PusherDeleteByKeyCapn{s.List.Struct(i)}
+}
+
+func (s PusherDeleteByKeyCapn_List) Set(i int, v PusherDeleteByKeyCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PusherDeleteByKeyCapn_List) String() string {
+	str, _ := text.MarshalList(0xc96ba43ce2771476, s.List)
+	return str
+}
+
+// PusherDeleteByKeyCapn_Promise is a wrapper for a PusherDeleteByKeyCapn promised by a client call.
+type PusherDeleteByKeyCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PusherDeleteByKeyCapn_Promise) Struct() (PusherDeleteByKeyCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PusherDeleteByKeyCapn{s}, err
+}
+
+type PusherDeleteByKeyOnlyCapn struct{ capnp.Struct }
+
+// PusherDeleteByKeyOnlyCapn_TypeID is the unique identifier for the type PusherDeleteByKeyOnlyCapn.
+const PusherDeleteByKeyOnlyCapn_TypeID = 0xd86ef9bfceda971d
+
+func NewPusherDeleteByKeyOnlyCapn(s *capnp.Segment) (PusherDeleteByKeyOnlyCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return PusherDeleteByKeyOnlyCapn{st}, err
+}
+
+func NewRootPusherDeleteByKeyOnlyCapn(s *capnp.Segment) (PusherDeleteByKeyOnlyCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return PusherDeleteByKeyOnlyCapn{st}, err
+}
+
+func ReadRootPusherDeleteByKeyOnlyCapn(msg *capnp.Message) (PusherDeleteByKeyOnlyCapn, error) {
+	root, err := msg.RootPtr()
+	return PusherDeleteByKeyOnlyCapn{root.Struct()}, err
+}
+
+func (s PusherDeleteByKeyOnlyCapn) String() string {
+	str, _ := text.Marshal(0xd86ef9bfceda971d, s.Struct)
+	return str
+}
+
+func (s PusherDeleteByKeyOnlyCapn) PushKey() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PusherDeleteByKeyOnlyCapn) HasPushKey() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherDeleteByKeyOnlyCapn) PushKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PusherDeleteByKeyOnlyCapn) SetPushKey(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// PusherDeleteByKeyOnlyCapn_List is a list of PusherDeleteByKeyOnlyCapn.
+type PusherDeleteByKeyOnlyCapn_List struct{ capnp.List }
+
+// NewPusherDeleteByKeyOnlyCapn creates a new list of PusherDeleteByKeyOnlyCapn.
+func NewPusherDeleteByKeyOnlyCapn_List(s *capnp.Segment, sz int32) (PusherDeleteByKeyOnlyCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return PusherDeleteByKeyOnlyCapn_List{l}, err
+}
+
+func (s PusherDeleteByKeyOnlyCapn_List) At(i int) PusherDeleteByKeyOnlyCapn {
+	return PusherDeleteByKeyOnlyCapn{s.List.Struct(i)}
+}
+
+func (s PusherDeleteByKeyOnlyCapn_List) Set(i int, v PusherDeleteByKeyOnlyCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PusherDeleteByKeyOnlyCapn_List) String() string {
+	str, _ := text.MarshalList(0xd86ef9bfceda971d, s.List)
+	return str
+}
+
+// PusherDeleteByKeyOnlyCapn_Promise is a wrapper for a PusherDeleteByKeyOnlyCapn promised by a client call.
+type PusherDeleteByKeyOnlyCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PusherDeleteByKeyOnlyCapn_Promise) Struct() (PusherDeleteByKeyOnlyCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PusherDeleteByKeyOnlyCapn{s}, err
+}
+
+type PusherInsertCapn struct{ capnp.Struct }
+
+// PusherInsertCapn_TypeID is the unique identifier for the type PusherInsertCapn.
+const PusherInsertCapn_TypeID = 0xc4a6d75d32b47289
+
+func NewPusherInsertCapn(s *capnp.Segment) (PusherInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 10})
+	return PusherInsertCapn{st}, err
+}
+
+func NewRootPusherInsertCapn(s *capnp.Segment) (PusherInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 10})
+	return PusherInsertCapn{st}, err
+}
+
+func ReadRootPusherInsertCapn(msg *capnp.Message) (PusherInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return PusherInsertCapn{root.Struct()}, err
+}
+
+func (s PusherInsertCapn) String() string {
+	str, _ := text.Marshal(0xc4a6d75d32b47289, s.Struct)
+	return str
+}
+
+func (s PusherInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PusherInsertCapn) ProfileTag() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasProfileTag() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) ProfileTagBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetProfileTag(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PusherInsertCapn) Kind() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasKind() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) KindBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetKind(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s PusherInsertCapn) AppID() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasAppID() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) AppIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetAppID(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s PusherInsertCapn) AppDisplayName() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasAppDisplayName() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) AppDisplayNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetAppDisplayName(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s PusherInsertCapn) DeviceDisplayName() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasDeviceDisplayName() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) DeviceDisplayNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetDeviceDisplayName(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s PusherInsertCapn) PushKey() (string, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasPushKey() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) PushKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetPushKey(v string) error {
+	return s.Struct.SetText(6, v)
+}
+
+func (s PusherInsertCapn) PushKeyTs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s PusherInsertCapn) SetPushKeyTs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s PusherInsertCapn) Lang() (string, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasLang() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) LangBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetLang(v string) error {
+	return s.Struct.SetText(7, v)
+}
+
+func (s PusherInsertCapn) Data() ([]byte, error) {
+	p, err := s.Struct.Ptr(8)
+	return []byte(p.Data()), err
+}
+
+func (s PusherInsertCapn) HasData() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) SetData(v []byte) error {
+	return s.Struct.SetData(8, v)
+}
+
+func (s PusherInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(9)
+	return p.Text(), err
+}
+
+func (s PusherInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(9)
+	return p.IsValid() || err != nil
+}
+
+func (s PusherInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(9)
+	return p.TextBytes(), err
+}
+
+func (s PusherInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(9, v)
+}
+
+// PusherInsertCapn_List is a list of PusherInsertCapn.
+type PusherInsertCapn_List struct{ capnp.List }
+
+// NewPusherInsertCapn creates a new list of PusherInsertCapn.
+func NewPusherInsertCapn_List(s *capnp.Segment, sz int32) (PusherInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 10}, sz)
+	return PusherInsertCapn_List{l}, err
+}
+
+func (s PusherInsertCapn_List) At(i int) PusherInsertCapn { return PusherInsertCapn{s.List.Struct(i)} }
+
+func (s PusherInsertCapn_List) Set(i int, v PusherInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PusherInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xc4a6d75d32b47289, s.List)
+	return str
+}
+
+// PusherInsertCapn_Promise is a wrapper for a PusherInsertCapn promised by a client call.
+type PusherInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PusherInsertCapn_Promise) Struct() (PusherInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PusherInsertCapn{s}, err
+}
+
+type PushRuleInertCapn struct{ capnp.Struct }
+
+// PushRuleInertCapn_TypeID is the unique identifier for the type PushRuleInertCapn.
+const PushRuleInertCapn_TypeID = 0x8bcfb61e43c1be27
+
+func NewPushRuleInertCapn(s *capnp.Segment) (PushRuleInertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4})
+	return PushRuleInertCapn{st}, err
+}
+
+func NewRootPushRuleInertCapn(s *capnp.Segment) (PushRuleInertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4})
+	return PushRuleInertCapn{st}, err
+}
+
+func ReadRootPushRuleInertCapn(msg *capnp.Message) (PushRuleInertCapn, error) {
+	root, err := msg.RootPtr()
+	return PushRuleInertCapn{root.Struct()}, err
+}
+
+func (s PushRuleInertCapn) String() string {
+	str, _ := text.Marshal(0x8bcfb61e43c1be27, s.Struct)
+	return str
+}
+
+func (s PushRuleInertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PushRuleInertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleInertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleInertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PushRuleInertCapn) RuleID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PushRuleInertCapn) HasRuleID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleInertCapn) RuleIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleInertCapn) SetRuleID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PushRuleInertCapn) PriorityClass() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s PushRuleInertCapn) SetPriorityClass(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s PushRuleInertCapn) Priority() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s PushRuleInertCapn) SetPriority(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s PushRuleInertCapn) Conditions() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s PushRuleInertCapn) HasConditions() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleInertCapn) SetConditions(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+func (s PushRuleInertCapn) Actions() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s PushRuleInertCapn) HasActions() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleInertCapn) SetActions(v []byte) error {
+	return s.Struct.SetData(3, v)
+}
+
+// PushRuleInertCapn_List is a list of PushRuleInertCapn.
+type PushRuleInertCapn_List struct{ capnp.List }
+
+// NewPushRuleInertCapn creates a new list of PushRuleInertCapn.
+func NewPushRuleInertCapn_List(s *capnp.Segment, sz int32) (PushRuleInertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 4}, sz)
+	return PushRuleInertCapn_List{l}, err
+}
+
+func (s PushRuleInertCapn_List) At(i int) PushRuleInertCapn {
+	return PushRuleInertCapn{s.List.Struct(i)}
+}
+
+func (s PushRuleInertCapn_List) Set(i int, v PushRuleInertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PushRuleInertCapn_List) String() string {
+	str, _ := text.MarshalList(0x8bcfb61e43c1be27, s.List)
+	return str
+}
+
+// PushRuleInertCapn_Promise is a wrapper for a PushRuleInertCapn promised by a client call.
+type PushRuleInertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PushRuleInertCapn_Promise) Struct() (PushRuleInertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PushRuleInertCapn{s}, err
+}
+
+type PushRuleDeleteCapn struct{ capnp.Struct }
+
+// PushRuleDeleteCapn_TypeID is the unique identifier for the type PushRuleDeleteCapn.
+const PushRuleDeleteCapn_TypeID = 0xe7a7a8af415eadba
+
+func NewPushRuleDeleteCapn(s *capnp.Segment) (PushRuleDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return PushRuleDeleteCapn{st}, err
+}
+
+func NewRootPushRuleDeleteCapn(s *capnp.Segment) (PushRuleDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return PushRuleDeleteCapn{st}, err
+}
+
+func ReadRootPushRuleDeleteCapn(msg *capnp.Message) (PushRuleDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return PushRuleDeleteCapn{root.Struct()}, err
+}
+
+func (s PushRuleDeleteCapn) String() string {
+	str, _ := text.Marshal(0xe7a7a8af415eadba, s.Struct)
+	return str
+}
+
+func (s PushRuleDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PushRuleDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PushRuleDeleteCapn) RuleID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PushRuleDeleteCapn) HasRuleID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleDeleteCapn) RuleIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleDeleteCapn) SetRuleID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// PushRuleDeleteCapn_List is a list of PushRuleDeleteCapn.
+type PushRuleDeleteCapn_List struct{ capnp.List }
+
+// NewPushRuleDeleteCapn creates a new list of PushRuleDeleteCapn.
+func NewPushRuleDeleteCapn_List(s *capnp.Segment, sz int32) (PushRuleDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return PushRuleDeleteCapn_List{l}, err
+}
+
+func (s PushRuleDeleteCapn_List) At(i int) PushRuleDeleteCapn {
+	return PushRuleDeleteCapn{s.List.Struct(i)}
+}
+
+func (s PushRuleDeleteCapn_List) Set(i int, v PushRuleDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PushRuleDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xe7a7a8af415eadba, s.List)
+	return str
+}
+
+// PushRuleDeleteCapn_Promise is a wrapper for a PushRuleDeleteCapn promised by a client call.
+type PushRuleDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PushRuleDeleteCapn_Promise) Struct() (PushRuleDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PushRuleDeleteCapn{s}, err
+}
+
+type PushRuleEnableInsertCapn struct{ capnp.Struct }
+
+// PushRuleEnableInsertCapn_TypeID is the unique identifier for the type PushRuleEnableInsertCapn.
+const PushRuleEnableInsertCapn_TypeID = 0xa8c3ee7161d20e77
+
+func NewPushRuleEnableInsertCapn(s *capnp.Segment) (PushRuleEnableInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return PushRuleEnableInsertCapn{st}, err
+}
+
+func NewRootPushRuleEnableInsertCapn(s *capnp.Segment) (PushRuleEnableInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return PushRuleEnableInsertCapn{st}, err
+}
+
+func ReadRootPushRuleEnableInsertCapn(msg *capnp.Message) (PushRuleEnableInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return PushRuleEnableInsertCapn{root.Struct()}, err
+}
+
+func (s PushRuleEnableInsertCapn) String() string {
+	str, _ := text.Marshal(0xa8c3ee7161d20e77, s.Struct)
+	return str
+}
+
+func (s PushRuleEnableInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PushRuleEnableInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleEnableInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleEnableInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PushRuleEnableInsertCapn) RuleID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PushRuleEnableInsertCapn) HasRuleID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PushRuleEnableInsertCapn) RuleIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PushRuleEnableInsertCapn) SetRuleID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PushRuleEnableInsertCapn) Enabled() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s PushRuleEnableInsertCapn) SetEnabled(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// PushRuleEnableInsertCapn_List is a list of PushRuleEnableInsertCapn.
+type PushRuleEnableInsertCapn_List struct{ capnp.List }
+
+// NewPushRuleEnableInsertCapn creates a new list of PushRuleEnableInsertCapn.
+func NewPushRuleEnableInsertCapn_List(s *capnp.Segment, sz int32) (PushRuleEnableInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return PushRuleEnableInsertCapn_List{l}, err
+}
+
+func (s PushRuleEnableInsertCapn_List) At(i int) PushRuleEnableInsertCapn {
+	return PushRuleEnableInsertCapn{s.List.Struct(i)}
+}
+
+func (s PushRuleEnableInsertCapn_List) Set(i int, v PushRuleEnableInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PushRuleEnableInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xa8c3ee7161d20e77, s.List)
+	return str
+}
+
+// PushRuleEnableInsertCapn_Promise is a wrapper for a PushRuleEnableInsertCapn promised by a client call.
+type PushRuleEnableInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PushRuleEnableInsertCapn_Promise) Struct() (PushRuleEnableInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PushRuleEnableInsertCapn{s}, err
+}
+
+type E2EDBEventCapn struct{ capnp.Struct }
+
+// E2EDBEventCapn_TypeID is the unique identifier for the type E2EDBEventCapn.
+const E2EDBEventCapn_TypeID = 0xdc01c71d8eb831b9
+
+func NewE2EDBEventCapn(s *capnp.Segment) (E2EDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 11})
+	return E2EDBEventCapn{st}, err
+}
+
+func NewRootE2EDBEventCapn(s *capnp.Segment) (E2EDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 11})
+	return E2EDBEventCapn{st}, err
+}
+
+func ReadRootE2EDBEventCapn(msg *capnp.Message) (E2EDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return E2EDBEventCapn{root.Struct()}, err
+}
+
+func (s E2EDBEventCapn) String() string {
+	str, _ := text.Marshal(0xdc01c71d8eb831b9, s.Struct)
+	return str
+}
+
+func (s E2EDBEventCapn) KeyInsert() (KeyInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return KeyInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasKeyInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetKeyInsert(v KeyInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewKeyInsert sets the keyInsert field to a newly
+// allocated KeyInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewKeyInsert() (KeyInsertCapn, error) {
+	ss, err := NewKeyInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return KeyInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) KeyDelete() (KeyDeleteCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return KeyDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasKeyDelete() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetKeyDelete(v KeyDeleteCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewKeyDelete sets the keyDelete field to a newly
+// allocated KeyDeleteCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewKeyDelete() (KeyDeleteCapn, error) {
+	ss, err := NewKeyDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return KeyDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) AlInsert() (AlInsertCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return AlInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasAlInsert() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetAlInsert(v AlInsertCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewAlInsert sets the alInsert field to a newly
+// allocated AlInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewAlInsert() (AlInsertCapn, error) {
+	ss, err := NewAlInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return AlInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) DeviceKeyDelete() (DeviceKeyDeleteCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return DeviceKeyDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasDeviceKeyDelete() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetDeviceKeyDelete(v DeviceKeyDeleteCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewDeviceKeyDelete sets the deviceKeyDelete field to a newly
+// allocated DeviceKeyDeleteCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewDeviceKeyDelete() (DeviceKeyDeleteCapn, error) {
+	ss, err := NewDeviceKeyDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return DeviceKeyDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) MacKeyDelete() (MacKeyDeleteCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return MacKeyDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasMacKeyDelete() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetMacKeyDelete(v MacKeyDeleteCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewMacKeyDelete sets the macKeyDelete field to a newly
+// allocated MacKeyDeleteCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewMacKeyDelete() (MacKeyDeleteCapn, error) {
+	ss, err := NewMacKeyDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return MacKeyDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) CrossSigningKeyInsert() (CrossSigningKeyInsertCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return CrossSigningKeyInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasCrossSigningKeyInsert() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetCrossSigningKeyInsert(v CrossSigningKeyInsertCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewCrossSigningKeyInsert sets the crossSigningKeyInsert field to a newly
+// allocated CrossSigningKeyInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewCrossSigningKeyInsert() (CrossSigningKeyInsertCapn, error) {
+	ss, err := NewCrossSigningKeyInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return CrossSigningKeyInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) CrossSigningSigInsert() (CrossSigningSigInsertCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return CrossSigningSigInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasCrossSigningSigInsert() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetCrossSigningSigInsert(v CrossSigningSigInsertCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewCrossSigningSigInsert sets the crossSigningSigInsert field to a newly
+// allocated CrossSigningSigInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewCrossSigningSigInsert() (CrossSigningSigInsertCapn, error) {
+	ss, err := NewCrossSigningSigInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return CrossSigningSigInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) KeyBackupVersionInsert() (KeyBackupVersionInsertCapn, error) {
+	p, err := s.Struct.Ptr(7)
+	return KeyBackupVersionInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasKeyBackupVersionInsert() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetKeyBackupVersionInsert(v KeyBackupVersionInsertCapn) error {
+	return s.Struct.SetPtr(7, v.Struct.ToPtr())
+}
+
+// NewKeyBackupVersionInsert sets the keyBackupVersionInsert field to a newly
+// allocated KeyBackupVersionInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewKeyBackupVersionInsert() (KeyBackupVersionInsertCapn, error) {
+	ss, err := NewKeyBackupVersionInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return KeyBackupVersionInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(7, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) KeyBackupInsert() (KeyBackupInsertCapn, error) {
+	p, err := s.Struct.Ptr(8)
+	return KeyBackupInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasKeyBackupInsert() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetKeyBackupInsert(v KeyBackupInsertCapn) error {
+	return s.Struct.SetPtr(8, v.Struct.ToPtr())
+}
+
+// NewKeyBackupInsert sets the keyBackupInsert field to a newly
+// allocated KeyBackupInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewKeyBackupInsert() (KeyBackupInsertCapn, error) {
+	ss, err := NewKeyBackupInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return KeyBackupInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(8, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) KeyBackupDelete() (KeyBackupDeleteCapn, error) {
+	p, err := s.Struct.Ptr(9)
+	return KeyBackupDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasKeyBackupDelete() bool {
+	p, err := s.Struct.Ptr(9)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetKeyBackupDelete(v KeyBackupDeleteCapn) error {
+	return s.Struct.SetPtr(9, v.Struct.ToPtr())
+}
+
+// NewKeyBackupDelete sets the keyBackupDelete field to a newly
+// allocated KeyBackupDeleteCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewKeyBackupDelete() (KeyBackupDeleteCapn, error) {
+	ss, err := NewKeyBackupDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return KeyBackupDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(9, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s E2EDBEventCapn) FallbackKeyInsert() (FallbackKeyInsertCapn, error) {
+	p, err := s.Struct.Ptr(10)
+	return FallbackKeyInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s E2EDBEventCapn) HasFallbackKeyInsert() bool {
+	p, err := s.Struct.Ptr(10)
+	return p.IsValid() || err != nil
+}
+
+func (s E2EDBEventCapn) SetFallbackKeyInsert(v FallbackKeyInsertCapn) error {
+	return s.Struct.SetPtr(10, v.Struct.ToPtr())
+}
+
+// NewFallbackKeyInsert sets the fallbackKeyInsert field to a newly
+// allocated FallbackKeyInsertCapn struct, preferring placement in s's segment.
+func (s E2EDBEventCapn) NewFallbackKeyInsert() (FallbackKeyInsertCapn, error) {
+	ss, err := NewFallbackKeyInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return FallbackKeyInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(10, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// E2EDBEventCapn_List is a list of E2EDBEventCapn.
+type E2EDBEventCapn_List struct{ capnp.List }
+
+// NewE2EDBEventCapn creates a new list of E2EDBEventCapn.
+func NewE2EDBEventCapn_List(s *capnp.Segment, sz int32) (E2EDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 11}, sz)
+	return E2EDBEventCapn_List{l}, err
+}
+
+func (s E2EDBEventCapn_List) At(i int) E2EDBEventCapn { return E2EDBEventCapn{s.List.Struct(i)} }
+
+func (s E2EDBEventCapn_List) Set(i int, v E2EDBEventCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s E2EDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xdc01c71d8eb831b9, s.List)
+	return str
+}
+
+// E2EDBEventCapn_Promise is a wrapper for a E2EDBEventCapn promised by a client call.
+type E2EDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p E2EDBEventCapn_Promise) Struct() (E2EDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return E2EDBEventCapn{s}, err
+}
+
+func (p E2EDBEventCapn_Promise) KeyInsert() KeyInsertCapn_Promise {
+	return KeyInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p E2EDBEventCapn_Promise) KeyDelete() KeyDeleteCapn_Promise {
+	return KeyDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p E2EDBEventCapn_Promise) AlInsert() AlInsertCapn_Promise {
+	return AlInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p E2EDBEventCapn_Promise) DeviceKeyDelete() DeviceKeyDeleteCapn_Promise {
+	return DeviceKeyDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p E2EDBEventCapn_Promise) MacKeyDelete() MacKeyDeleteCapn_Promise {
+	return MacKeyDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p E2EDBEventCapn_Promise) CrossSigningKeyInsert() CrossSigningKeyInsertCapn_Promise {
+	return CrossSigningKeyInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p E2EDBEventCapn_Promise) CrossSigningSigInsert() CrossSigningSigInsertCapn_Promise {
+	return CrossSigningSigInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+func (p E2EDBEventCapn_Promise) KeyBackupVersionInsert() KeyBackupVersionInsertCapn_Promise {
+	return KeyBackupVersionInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(7)}
+}
+
+func (p E2EDBEventCapn_Promise) KeyBackupInsert() KeyBackupInsertCapn_Promise {
+	return KeyBackupInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(8)}
+}
+
+func (p E2EDBEventCapn_Promise) KeyBackupDelete() KeyBackupDeleteCapn_Promise {
+	return KeyBackupDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(9)}
+}
+
+func (p E2EDBEventCapn_Promise) FallbackKeyInsert() FallbackKeyInsertCapn_Promise {
+	return FallbackKeyInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(10)}
+}
+
+type KeyInsertCapn struct{ capnp.Struct }
+
+// KeyInsertCapn_TypeID is the unique identifier for the type KeyInsertCapn.
+const KeyInsertCapn_TypeID = 0xec0a05bd849a61eb
+
+func NewKeyInsertCapn(s *capnp.Segment) (KeyInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return KeyInsertCapn{st}, err
+}
+
+func NewRootKeyInsertCapn(s *capnp.Segment) (KeyInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7})
+	return KeyInsertCapn{st}, err
+}
+
+func ReadRootKeyInsertCapn(msg *capnp.Message) (KeyInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return KeyInsertCapn{root.Struct()}, err
+}
+
+func (s KeyInsertCapn) String() string {
+	str, _ := text.Marshal(0xec0a05bd849a61eb, s.Struct)
+	return str
+}
+
+func (s KeyInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s KeyInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s KeyInsertCapn) KeyID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasKeyID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) KeyIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetKeyID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s KeyInsertCapn) KeyInfo() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasKeyInfo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) KeyInfoBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetKeyInfo(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s KeyInsertCapn) Algorithm() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasAlgorithm() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) AlgorithmBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetAlgorithm(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s KeyInsertCapn) Signature() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasSignature() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) SignatureBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetSignature(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s KeyInsertCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.Text(), err
+}
+
+func (s KeyInsertCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyInsertCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s KeyInsertCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(6, v)
+}
+
+// KeyInsertCapn_List is a list of KeyInsertCapn.
+type KeyInsertCapn_List struct{ capnp.List }
+
+// NewKeyInsertCapn creates a new list of KeyInsertCapn.
+func NewKeyInsertCapn_List(s *capnp.Segment, sz int32) (KeyInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 7}, sz)
+	return KeyInsertCapn_List{l}, err
+}
+
+func (s KeyInsertCapn_List) At(i int) KeyInsertCapn { return KeyInsertCapn{s.List.Struct(i)} }
+
+func (s KeyInsertCapn_List) Set(i int, v KeyInsertCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s KeyInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xec0a05bd849a61eb, s.List)
+	return str
+}
+
+// KeyInsertCapn_Promise is a wrapper for a KeyInsertCapn promised by a client call.
+type KeyInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p KeyInsertCapn_Promise) Struct() (KeyInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return KeyInsertCapn{s}, err
+}
+
+type KeyDeleteCapn struct{ capnp.Struct }
+
+// KeyDeleteCapn_TypeID is the unique identifier for the type KeyDeleteCapn.
+const KeyDeleteCapn_TypeID = 0xae317646a68a1ac6
+
+func NewKeyDeleteCapn(s *capnp.Segment) (KeyDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return KeyDeleteCapn{st}, err
+}
+
+func NewRootKeyDeleteCapn(s *capnp.Segment) (KeyDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return KeyDeleteCapn{st}, err
+}
+
+func ReadRootKeyDeleteCapn(msg *capnp.Message) (KeyDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return KeyDeleteCapn{root.Struct()}, err
+}
+
+func (s KeyDeleteCapn) String() string {
+	str, _ := text.Marshal(0xae317646a68a1ac6, s.Struct)
+	return str
+}
+
+func (s KeyDeleteCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s KeyDeleteCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyDeleteCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s KeyDeleteCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s KeyDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s KeyDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s KeyDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s KeyDeleteCapn) KeyID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s KeyDeleteCapn) HasKeyID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyDeleteCapn) KeyIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s KeyDeleteCapn) SetKeyID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s KeyDeleteCapn) Algorithm() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s KeyDeleteCapn) HasAlgorithm() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyDeleteCapn) AlgorithmBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s KeyDeleteCapn) SetAlgorithm(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// KeyDeleteCapn_List is a list of KeyDeleteCapn.
+type KeyDeleteCapn_List struct{ capnp.List }
+
+// NewKeyDeleteCapn creates a new list of KeyDeleteCapn.
+func NewKeyDeleteCapn_List(s *capnp.Segment, sz int32) (KeyDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return KeyDeleteCapn_List{l}, err
+}
+
+func (s KeyDeleteCapn_List) At(i int) KeyDeleteCapn { return KeyDeleteCapn{s.List.Struct(i)} }
+
+func (s KeyDeleteCapn_List) Set(i int, v KeyDeleteCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s KeyDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xae317646a68a1ac6, s.List)
+	return str
+}
+
+// KeyDeleteCapn_Promise is a wrapper for a KeyDeleteCapn promised by a client call.
+type KeyDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p KeyDeleteCapn_Promise) Struct() (KeyDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return KeyDeleteCapn{s}, err
+}
+
+type AlInsertCapn struct{ capnp.Struct }
+
+// AlInsertCapn_TypeID is the unique identifier for the type AlInsertCapn.
+const AlInsertCapn_TypeID = 0x80b830aba77157fc
+
+func NewAlInsertCapn(s *capnp.Segment) (AlInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return AlInsertCapn{st}, err
+}
+
+func NewRootAlInsertCapn(s *capnp.Segment) (AlInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return AlInsertCapn{st}, err
+}
+
+func ReadRootAlInsertCapn(msg *capnp.Message) (AlInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return AlInsertCapn{root.Struct()}, err
+}
+
+func (s AlInsertCapn) String() string {
+	str, _ := text.Marshal(0x80b830aba77157fc, s.Struct)
+	return str
+}
+
+func (s AlInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s AlInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s AlInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s AlInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s AlInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s AlInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s AlInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s AlInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s AlInsertCapn) Algorithm() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s AlInsertCapn) HasAlgorithm() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s AlInsertCapn) AlgorithmBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s AlInsertCapn) SetAlgorithm(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s AlInsertCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s AlInsertCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s AlInsertCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s AlInsertCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// AlInsertCapn_List is a list of AlInsertCapn.
+type AlInsertCapn_List struct{ capnp.List }
+
+// NewAlInsertCapn creates a new list of AlInsertCapn.
+func NewAlInsertCapn_List(s *capnp.Segment, sz int32) (AlInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return AlInsertCapn_List{l}, err
+}
+
+func (s AlInsertCapn_List) At(i int) AlInsertCapn { return AlInsertCapn{s.List.Struct(i)} }
+
+func (s AlInsertCapn_List) Set(i int, v AlInsertCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s AlInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x80b830aba77157fc, s.List)
+	return str
+}
+
+// AlInsertCapn_Promise is a wrapper for a AlInsertCapn promised by a client call.
+type AlInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p AlInsertCapn_Promise) Struct() (AlInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return AlInsertCapn{s}, err
+}
+
+type DeviceKeyDeleteCapn struct{ capnp.Struct }
+
+// DeviceKeyDeleteCapn_TypeID is the unique identifier for the type DeviceKeyDeleteCapn.
+const DeviceKeyDeleteCapn_TypeID = 0xbf02bef32aee7bfc
+
+func NewDeviceKeyDeleteCapn(s *capnp.Segment) (DeviceKeyDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return DeviceKeyDeleteCapn{st}, err
+}
+
+func NewRootDeviceKeyDeleteCapn(s *capnp.Segment) (DeviceKeyDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2})
+	return DeviceKeyDeleteCapn{st}, err
+}
+
+func ReadRootDeviceKeyDeleteCapn(msg *capnp.Message) (DeviceKeyDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return DeviceKeyDeleteCapn{root.Struct()}, err
+}
+
+func (s DeviceKeyDeleteCapn) String() string {
+	str, _ := text.Marshal(0xbf02bef32aee7bfc, s.Struct)
+	return str
+}
+
+func (s DeviceKeyDeleteCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s DeviceKeyDeleteCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceKeyDeleteCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s DeviceKeyDeleteCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s DeviceKeyDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s DeviceKeyDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s DeviceKeyDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s DeviceKeyDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// DeviceKeyDeleteCapn_List is a list of DeviceKeyDeleteCapn.
+type DeviceKeyDeleteCapn_List struct{ capnp.List }
+
+// NewDeviceKeyDeleteCapn creates a new list of DeviceKeyDeleteCapn.
+func NewDeviceKeyDeleteCapn_List(s *capnp.Segment, sz int32) (DeviceKeyDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 2}, sz)
+	return DeviceKeyDeleteCapn_List{l}, err
+}
+
+func (s DeviceKeyDeleteCapn_List) At(i int) DeviceKeyDeleteCapn {
+	return DeviceKeyDeleteCapn{s.List.Struct(i)}
+}
+
+func (s DeviceKeyDeleteCapn_List) Set(i int, v DeviceKeyDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s DeviceKeyDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xbf02bef32aee7bfc, s.List)
+	return str
+}
+
+// DeviceKeyDeleteCapn_Promise is a wrapper for a DeviceKeyDeleteCapn promised by a client call.
+type DeviceKeyDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p DeviceKeyDeleteCapn_Promise) Struct() (DeviceKeyDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return DeviceKeyDeleteCapn{s}, err
+}
+
+type MacKeyDeleteCapn struct{ capnp.Struct }
+
+// MacKeyDeleteCapn_TypeID is the unique identifier for the type MacKeyDeleteCapn.
+const MacKeyDeleteCapn_TypeID = 0xacc5b958a9c4d9d9
+
+func NewMacKeyDeleteCapn(s *capnp.Segment) (MacKeyDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return MacKeyDeleteCapn{st}, err
+}
+
+func NewRootMacKeyDeleteCapn(s *capnp.Segment) (MacKeyDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return MacKeyDeleteCapn{st}, err
+}
+
+func ReadRootMacKeyDeleteCapn(msg *capnp.Message) (MacKeyDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return MacKeyDeleteCapn{root.Struct()}, err
+}
+
+func (s MacKeyDeleteCapn) String() string {
+	str, _ := text.Marshal(0xacc5b958a9c4d9d9, s.Struct)
+	return str
+}
+
+func (s MacKeyDeleteCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s MacKeyDeleteCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s MacKeyDeleteCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s MacKeyDeleteCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s MacKeyDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s MacKeyDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s MacKeyDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s MacKeyDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s MacKeyDeleteCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s MacKeyDeleteCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s MacKeyDeleteCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s MacKeyDeleteCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// MacKeyDeleteCapn_List is a list of MacKeyDeleteCapn.
+type MacKeyDeleteCapn_List struct{ capnp.List }
+
+// NewMacKeyDeleteCapn creates a new list of MacKeyDeleteCapn.
+func NewMacKeyDeleteCapn_List(s *capnp.Segment, sz int32) (MacKeyDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return MacKeyDeleteCapn_List{l}, err
+}
+
+func (s MacKeyDeleteCapn_List) At(i int) MacKeyDeleteCapn { return MacKeyDeleteCapn{s.List.Struct(i)} }
+
+func (s MacKeyDeleteCapn_List) Set(i int, v MacKeyDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s MacKeyDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xacc5b958a9c4d9d9, s.List)
+	return str
+}
+
+// MacKeyDeleteCapn_Promise is a wrapper for a MacKeyDeleteCapn promised by a client call.
+type MacKeyDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p MacKeyDeleteCapn_Promise) Struct() (MacKeyDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return MacKeyDeleteCapn{s}, err
+}
+
+type CrossSigningKeyInsertCapn struct{ capnp.Struct }
+
+// CrossSigningKeyInsertCapn_TypeID is the unique identifier for the type CrossSigningKeyInsertCapn.
+const CrossSigningKeyInsertCapn_TypeID = 0xffd2f6170ab39c6b
+
+func NewCrossSigningKeyInsertCapn(s *capnp.Segment) (CrossSigningKeyInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CrossSigningKeyInsertCapn{st}, err
+}
+
+func NewRootCrossSigningKeyInsertCapn(s *capnp.Segment) (CrossSigningKeyInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return CrossSigningKeyInsertCapn{st}, err
+}
+
+func ReadRootCrossSigningKeyInsertCapn(msg *capnp.Message) (CrossSigningKeyInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return CrossSigningKeyInsertCapn{root.Struct()}, err
+}
+
+func (s CrossSigningKeyInsertCapn) String() string {
+	str, _ := text.Marshal(0xffd2f6170ab39c6b, s.Struct)
+	return str
+}
+
+func (s CrossSigningKeyInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s CrossSigningKeyInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningKeyInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningKeyInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s CrossSigningKeyInsertCapn) KeyType() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s CrossSigningKeyInsertCapn) HasKeyType() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningKeyInsertCapn) KeyTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningKeyInsertCapn) SetKeyType(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s CrossSigningKeyInsertCapn) KeyInfo() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s CrossSigningKeyInsertCapn) HasKeyInfo() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningKeyInsertCapn) KeyInfoBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningKeyInsertCapn) SetKeyInfo(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// CrossSigningKeyInsertCapn_List is a list of CrossSigningKeyInsertCapn.
+type CrossSigningKeyInsertCapn_List struct{ capnp.List }
+
+// NewCrossSigningKeyInsertCapn creates a new list of CrossSigningKeyInsertCapn.
+func NewCrossSigningKeyInsertCapn_List(s *capnp.Segment, sz int32) (CrossSigningKeyInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return CrossSigningKeyInsertCapn_List{l}, err
+}
+
+func (s CrossSigningKeyInsertCapn_List) At(i int) CrossSigningKeyInsertCapn {
+	return CrossSigningKeyInsertCapn{s.List.Struct(i)}
+}
+
+func (s CrossSigningKeyInsertCapn_List) Set(i int, v CrossSigningKeyInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s CrossSigningKeyInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xffd2f6170ab39c6b, s.List)
+	return str
+}
+
+// CrossSigningKeyInsertCapn_Promise is a wrapper for a CrossSigningKeyInsertCapn promised by a client call.
+type CrossSigningKeyInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p CrossSigningKeyInsertCapn_Promise) Struct() (CrossSigningKeyInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return CrossSigningKeyInsertCapn{s}, err
+}
+
+type CrossSigningSigInsertCapn struct{ capnp.Struct }
+
+// CrossSigningSigInsertCapn_TypeID is the unique identifier for the type CrossSigningSigInsertCapn.
+const CrossSigningSigInsertCapn_TypeID = 0xbfb43e223a8bf84f
+
+func NewCrossSigningSigInsertCapn(s *capnp.Segment) (CrossSigningSigInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5})
+	return CrossSigningSigInsertCapn{st}, err
+}
+
+func NewRootCrossSigningSigInsertCapn(s *capnp.Segment) (CrossSigningSigInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5})
+	return CrossSigningSigInsertCapn{st}, err
+}
+
+func ReadRootCrossSigningSigInsertCapn(msg *capnp.Message) (CrossSigningSigInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return CrossSigningSigInsertCapn{root.Struct()}, err
+}
+
+func (s CrossSigningSigInsertCapn) String() string {
+	str, _ := text.Marshal(0xbfb43e223a8bf84f, s.Struct)
+	return str
+}
+
+func (s CrossSigningSigInsertCapn) OriginUserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s CrossSigningSigInsertCapn) HasOriginUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningSigInsertCapn) OriginUserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningSigInsertCapn) SetOriginUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s CrossSigningSigInsertCapn) OriginKeyID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s CrossSigningSigInsertCapn) HasOriginKeyID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningSigInsertCapn) OriginKeyIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningSigInsertCapn) SetOriginKeyID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s CrossSigningSigInsertCapn) TargetUserID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s CrossSigningSigInsertCapn) HasTargetUserID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningSigInsertCapn) TargetUserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningSigInsertCapn) SetTargetUserID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s CrossSigningSigInsertCapn) TargetKeyID() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s CrossSigningSigInsertCapn) HasTargetKeyID() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningSigInsertCapn) TargetKeyIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningSigInsertCapn) SetTargetKeyID(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s CrossSigningSigInsertCapn) Signature() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s CrossSigningSigInsertCapn) HasSignature() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s CrossSigningSigInsertCapn) SignatureBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s CrossSigningSigInsertCapn) SetSignature(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+// CrossSigningSigInsertCapn_List is a list of CrossSigningSigInsertCapn.
+type CrossSigningSigInsertCapn_List struct{ capnp.List }
+
+// NewCrossSigningSigInsertCapn creates a new list of CrossSigningSigInsertCapn.
+func NewCrossSigningSigInsertCapn_List(s *capnp.Segment, sz int32) (CrossSigningSigInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 5}, sz)
+	return CrossSigningSigInsertCapn_List{l}, err
+}
+
+func (s CrossSigningSigInsertCapn_List) At(i int) CrossSigningSigInsertCapn {
+	return CrossSigningSigInsertCapn{s.List.Struct(i)}
+}
+
+func (s CrossSigningSigInsertCapn_List) Set(i int, v CrossSigningSigInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s CrossSigningSigInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xbfb43e223a8bf84f, s.List)
+	return str
+}
+
+// CrossSigningSigInsertCapn_Promise is a wrapper for a CrossSigningSigInsertCapn promised by a client call.
+type CrossSigningSigInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p CrossSigningSigInsertCapn_Promise) Struct() (CrossSigningSigInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return CrossSigningSigInsertCapn{s}, err
+}
+
+type KeyBackupVersionInsertCapn struct{ capnp.Struct }
+
+// KeyBackupVersionInsertCapn_TypeID is the unique identifier for the type KeyBackupVersionInsertCapn.
+const KeyBackupVersionInsertCapn_TypeID = 0xdcdc2e009d590107
+
+func NewKeyBackupVersionInsertCapn(s *capnp.Segment) (KeyBackupVersionInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return KeyBackupVersionInsertCapn{st}, err
+}
+
+func NewRootKeyBackupVersionInsertCapn(s *capnp.Segment) (KeyBackupVersionInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3})
+	return KeyBackupVersionInsertCapn{st}, err
+}
+
+func ReadRootKeyBackupVersionInsertCapn(msg *capnp.Message) (KeyBackupVersionInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return KeyBackupVersionInsertCapn{root.Struct()}, err
+}
+
+func (s KeyBackupVersionInsertCapn) String() string {
+	str, _ := text.Marshal(0xdcdc2e009d590107, s.Struct)
+	return str
+}
+
+func (s KeyBackupVersionInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s KeyBackupVersionInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupVersionInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupVersionInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s KeyBackupVersionInsertCapn) Version() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s KeyBackupVersionInsertCapn) SetVersion(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s KeyBackupVersionInsertCapn) Algorithm() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s KeyBackupVersionInsertCapn) HasAlgorithm() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupVersionInsertCapn) AlgorithmBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupVersionInsertCapn) SetAlgorithm(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s KeyBackupVersionInsertCapn) AuthData() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s KeyBackupVersionInsertCapn) HasAuthData() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupVersionInsertCapn) AuthDataBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupVersionInsertCapn) SetAuthData(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s KeyBackupVersionInsertCapn) Etag() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s KeyBackupVersionInsertCapn) SetEtag(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s KeyBackupVersionInsertCapn) Deleted() bool {
+	return s.Struct.Bit(128)
+}
+
+func (s KeyBackupVersionInsertCapn) SetDeleted(v bool) {
+	s.Struct.SetBit(128, v)
+}
+
+// KeyBackupVersionInsertCapn_List is a list of KeyBackupVersionInsertCapn.
+type KeyBackupVersionInsertCapn_List struct{ capnp.List }
+
+// NewKeyBackupVersionInsertCapn creates a new list of KeyBackupVersionInsertCapn.
+func NewKeyBackupVersionInsertCapn_List(s *capnp.Segment, sz int32) (KeyBackupVersionInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 3}, sz)
+	return KeyBackupVersionInsertCapn_List{l}, err
+}
+
+func (s KeyBackupVersionInsertCapn_List) At(i int) KeyBackupVersionInsertCapn {
+	return KeyBackupVersionInsertCapn{s.List.Struct(i)}
+}
+
+func (s KeyBackupVersionInsertCapn_List) Set(i int, v KeyBackupVersionInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s KeyBackupVersionInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xdcdc2e009d590107, s.List)
+	return str
+}
+
+// KeyBackupVersionInsertCapn_Promise is a wrapper for a KeyBackupVersionInsertCapn promised by a client call.
+type KeyBackupVersionInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p KeyBackupVersionInsertCapn_Promise) Struct() (KeyBackupVersionInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return KeyBackupVersionInsertCapn{s}, err
+}
+
+type KeyBackupInsertCapn struct{ capnp.Struct }
+
+// KeyBackupInsertCapn_TypeID is the unique identifier for the type KeyBackupInsertCapn.
+const KeyBackupInsertCapn_TypeID = 0xaea7191ac13b267f
+
+func NewKeyBackupInsertCapn(s *capnp.Segment) (KeyBackupInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	return KeyBackupInsertCapn{st}, err
+}
+
+func NewRootKeyBackupInsertCapn(s *capnp.Segment) (KeyBackupInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4})
+	return KeyBackupInsertCapn{st}, err
+}
+
+func ReadRootKeyBackupInsertCapn(msg *capnp.Message) (KeyBackupInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return KeyBackupInsertCapn{root.Struct()}, err
+}
+
+func (s KeyBackupInsertCapn) String() string {
+	str, _ := text.Marshal(0xaea7191ac13b267f, s.Struct)
+	return str
+}
+
+func (s KeyBackupInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s KeyBackupInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s KeyBackupInsertCapn) Version() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s KeyBackupInsertCapn) SetVersion(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s KeyBackupInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s KeyBackupInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s KeyBackupInsertCapn) SessionID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s KeyBackupInsertCapn) HasSessionID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupInsertCapn) SessionIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupInsertCapn) SetSessionID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s KeyBackupInsertCapn) FirstMessageIndex() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s KeyBackupInsertCapn) SetFirstMessageIndex(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s KeyBackupInsertCapn) ForwardedCount() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s KeyBackupInsertCapn) SetForwardedCount(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s KeyBackupInsertCapn) IsVerified() bool {
+	return s.Struct.Bit(192)
+}
+
+func (s KeyBackupInsertCapn) SetIsVerified(v bool) {
+	s.Struct.SetBit(192, v)
+}
+
+func (s KeyBackupInsertCapn) SessionData() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s KeyBackupInsertCapn) HasSessionData() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupInsertCapn) SessionDataBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupInsertCapn) SetSessionData(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// KeyBackupInsertCapn_List is a list of KeyBackupInsertCapn.
+type KeyBackupInsertCapn_List struct{ capnp.List }
+
+// NewKeyBackupInsertCapn creates a new list of KeyBackupInsertCapn.
+func NewKeyBackupInsertCapn_List(s *capnp.Segment, sz int32) (KeyBackupInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 4}, sz)
+	return KeyBackupInsertCapn_List{l}, err
+}
+
+func (s KeyBackupInsertCapn_List) At(i int) KeyBackupInsertCapn {
+	return KeyBackupInsertCapn{s.List.Struct(i)}
+}
+
+func (s KeyBackupInsertCapn_List) Set(i int, v KeyBackupInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s KeyBackupInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xaea7191ac13b267f, s.List)
+	return str
+}
+
+// KeyBackupInsertCapn_Promise is a wrapper for a KeyBackupInsertCapn promised by a client call.
+type KeyBackupInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p KeyBackupInsertCapn_Promise) Struct() (KeyBackupInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return KeyBackupInsertCapn{s}, err
+}
+
+type KeyBackupDeleteCapn struct{ capnp.Struct }
+
+// KeyBackupDeleteCapn_TypeID is the unique identifier for the type KeyBackupDeleteCapn.
+const KeyBackupDeleteCapn_TypeID = 0x9b6eeda7013e5b0a
+
+func NewKeyBackupDeleteCapn(s *capnp.Segment) (KeyBackupDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return KeyBackupDeleteCapn{st}, err
+}
+
+func NewRootKeyBackupDeleteCapn(s *capnp.Segment) (KeyBackupDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return KeyBackupDeleteCapn{st}, err
+}
+
+func ReadRootKeyBackupDeleteCapn(msg *capnp.Message) (KeyBackupDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return KeyBackupDeleteCapn{root.Struct()}, err
+}
+
+func (s KeyBackupDeleteCapn) String() string {
+	str, _ := text.Marshal(0x9b6eeda7013e5b0a, s.Struct)
+	return str
+}
+
+func (s KeyBackupDeleteCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s KeyBackupDeleteCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupDeleteCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupDeleteCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s KeyBackupDeleteCapn) Version() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s KeyBackupDeleteCapn) SetVersion(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s KeyBackupDeleteCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s KeyBackupDeleteCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupDeleteCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupDeleteCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s KeyBackupDeleteCapn) SessionID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s KeyBackupDeleteCapn) HasSessionID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s KeyBackupDeleteCapn) SessionIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s KeyBackupDeleteCapn) SetSessionID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// KeyBackupDeleteCapn_List is a list of KeyBackupDeleteCapn.
+type KeyBackupDeleteCapn_List struct{ capnp.List }
+
+// NewKeyBackupDeleteCapn creates a new list of KeyBackupDeleteCapn.
+func NewKeyBackupDeleteCapn_List(s *capnp.Segment, sz int32) (KeyBackupDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return KeyBackupDeleteCapn_List{l}, err
+}
+
+func (s KeyBackupDeleteCapn_List) At(i int) KeyBackupDeleteCapn {
+	return KeyBackupDeleteCapn{s.List.Struct(i)}
+}
+
+func (s KeyBackupDeleteCapn_List) Set(i int, v KeyBackupDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s KeyBackupDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0x9b6eeda7013e5b0a, s.List)
+	return str
+}
+
+// KeyBackupDeleteCapn_Promise is a wrapper for a KeyBackupDeleteCapn promised by a client call.
+type KeyBackupDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p KeyBackupDeleteCapn_Promise) Struct() (KeyBackupDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return KeyBackupDeleteCapn{s}, err
+}
+
+type FallbackKeyInsertCapn struct{ capnp.Struct }
+
+// FallbackKeyInsertCapn_TypeID is the unique identifier for the type FallbackKeyInsertCapn.
+const FallbackKeyInsertCapn_TypeID = 0x826aa0b44e399fe5
+
+func NewFallbackKeyInsertCapn(s *capnp.Segment) (FallbackKeyInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 7})
+	return FallbackKeyInsertCapn{st}, err
+}
+
+func NewRootFallbackKeyInsertCapn(s *capnp.Segment) (FallbackKeyInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 7})
+	return FallbackKeyInsertCapn{st}, err
+}
+
+func ReadRootFallbackKeyInsertCapn(msg *capnp.Message) (FallbackKeyInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return FallbackKeyInsertCapn{root.Struct()}, err
+}
+
+func (s FallbackKeyInsertCapn) String() string {
+	str, _ := text.Marshal(0x826aa0b44e399fe5, s.Struct)
+	return str
+}
+
+func (s FallbackKeyInsertCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s FallbackKeyInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s FallbackKeyInsertCapn) KeyID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasKeyID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) KeyIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetKeyID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s FallbackKeyInsertCapn) KeyInfo() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasKeyInfo() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) KeyInfoBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetKeyInfo(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s FallbackKeyInsertCapn) Algorithm() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasAlgorithm() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) AlgorithmBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetAlgorithm(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s FallbackKeyInsertCapn) Signature() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasSignature() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) SignatureBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetSignature(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s FallbackKeyInsertCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.Text(), err
+}
+
+func (s FallbackKeyInsertCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s FallbackKeyInsertCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s FallbackKeyInsertCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(6, v)
+}
+
+func (s FallbackKeyInsertCapn) Used() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s FallbackKeyInsertCapn) SetUsed(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+// FallbackKeyInsertCapn_List is a list of FallbackKeyInsertCapn.
+type FallbackKeyInsertCapn_List struct{ capnp.List }
+
+// NewFallbackKeyInsertCapn creates a new list of FallbackKeyInsertCapn.
+func NewFallbackKeyInsertCapn_List(s *capnp.Segment, sz int32) (FallbackKeyInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 7}, sz)
+	return FallbackKeyInsertCapn_List{l}, err
+}
+
+func (s FallbackKeyInsertCapn_List) At(i int) FallbackKeyInsertCapn {
+	return FallbackKeyInsertCapn{s.List.Struct(i)}
+}
+
+func (s FallbackKeyInsertCapn_List) Set(i int, v FallbackKeyInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s FallbackKeyInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x826aa0b44e399fe5, s.List)
+	return str
+}
+
+// FallbackKeyInsertCapn_Promise is a wrapper for a FallbackKeyInsertCapn promised by a client call.
+type FallbackKeyInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p FallbackKeyInsertCapn_Promise) Struct() (FallbackKeyInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return FallbackKeyInsertCapn{s}, err
+}
+
+type SyncDBEventCapn struct{ capnp.Struct }
+
+// SyncDBEventCapn_TypeID is the unique identifier for the type SyncDBEventCapn.
+const SyncDBEventCapn_TypeID = 0xb1366c68d01f9a91
+
+func NewSyncDBEventCapn(s *capnp.Segment) (SyncDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 14})
+	return SyncDBEventCapn{st}, err
+}
+
+func NewRootSyncDBEventCapn(s *capnp.Segment) (SyncDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 14})
+	return SyncDBEventCapn{st}, err
+}
+
+func ReadRootSyncDBEventCapn(msg *capnp.Message) (SyncDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncDBEventCapn{root.Struct()}, err
+}
+
+func (s SyncDBEventCapn) String() string {
+	str, _ := text.Marshal(0xb1366c68d01f9a91, s.Struct)
+	return str
+}
+
+func (s SyncDBEventCapn) SyncEventInsert() (SyncEventInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return SyncEventInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncEventInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncEventInsert(v SyncEventInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewSyncEventInsert sets the syncEventInsert field to a newly
+// allocated SyncEventInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncEventInsert() (SyncEventInsertCapn, error) {
+	ss, err := NewSyncEventInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncEventInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncRoomStateUpdate() (SyncRoomStateUpdateCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return SyncRoomStateUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncRoomStateUpdate() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncRoomStateUpdate(v SyncRoomStateUpdateCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewSyncRoomStateUpdate sets the syncRoomStateUpdate field to a newly
+// allocated SyncRoomStateUpdateCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncRoomStateUpdate() (SyncRoomStateUpdateCapn, error) {
+	ss, err := NewSyncRoomStateUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncRoomStateUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncClientDataInsert() (SyncClientDataInsertCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return SyncClientDataInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncClientDataInsert() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncClientDataInsert(v SyncClientDataInsertCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewSyncClientDataInsert sets the syncClientDataInsert field to a newly
+// allocated SyncClientDataInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncClientDataInsert() (SyncClientDataInsertCapn, error) {
+	ss, err := NewSyncClientDataInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncClientDataInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncKeyStreamInsert() (SyncKeyStreamInsertCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return SyncKeyStreamInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncKeyStreamInsert() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncKeyStreamInsert(v SyncKeyStreamInsertCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewSyncKeyStreamInsert sets the syncKeyStreamInsert field to a newly
+// allocated SyncKeyStreamInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncKeyStreamInsert() (SyncKeyStreamInsertCapn, error) {
+	ss, err := NewSyncKeyStreamInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncKeyStreamInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncReceiptInsert() (SyncReceiptInsertCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return SyncReceiptInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncReceiptInsert() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncReceiptInsert(v SyncReceiptInsertCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewSyncReceiptInsert sets the syncReceiptInsert field to a newly
+// allocated SyncReceiptInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncReceiptInsert() (SyncReceiptInsertCapn, error) {
+	ss, err := NewSyncReceiptInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncReceiptInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncStdEventInsert() (SyncStdEventInsertCapn, error) {
+	p, err := s.Struct.Ptr(5)
+	return SyncStdEventInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncStdEventInsert() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncStdEventInsert(v SyncStdEventInsertCapn) error {
+	return s.Struct.SetPtr(5, v.Struct.ToPtr())
+}
+
+// NewSyncStdEventInsert sets the syncStdEventInsert field to a newly
+// allocated SyncStdEventInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncStdEventInsert() (SyncStdEventInsertCapn, error) {
+	ss, err := NewSyncStdEventInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncStdEventInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(5, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncStdEventDelete() (SyncStdEventDeleteCapn, error) {
+	p, err := s.Struct.Ptr(6)
+	return SyncStdEventDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncStdEventDelete() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncStdEventDelete(v SyncStdEventDeleteCapn) error {
+	return s.Struct.SetPtr(6, v.Struct.ToPtr())
+}
+
+// NewSyncStdEventDelete sets the syncStdEventDelete field to a newly
+// allocated SyncStdEventDeleteCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncStdEventDelete() (SyncStdEventDeleteCapn, error) {
+	ss, err := NewSyncStdEventDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncStdEventDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(6, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncPresenceInsert() (SyncPresenceInsertCapn, error) {
+	p, err := s.Struct.Ptr(7)
+	return SyncPresenceInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncPresenceInsert() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncPresenceInsert(v SyncPresenceInsertCapn) error {
+	return s.Struct.SetPtr(7, v.Struct.ToPtr())
+}
+
+// NewSyncPresenceInsert sets the syncPresenceInsert field to a newly
+// allocated SyncPresenceInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncPresenceInsert() (SyncPresenceInsertCapn, error) {
+	ss, err := NewSyncPresenceInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncPresenceInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(7, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncUserReceiptInsert() (SyncUserReceiptInsertCapn, error) {
+	p, err := s.Struct.Ptr(8)
+	return SyncUserReceiptInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncUserReceiptInsert() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncUserReceiptInsert(v SyncUserReceiptInsertCapn) error {
+	return s.Struct.SetPtr(8, v.Struct.ToPtr())
+}
+
+// NewSyncUserReceiptInsert sets the syncUserReceiptInsert field to a newly
+// allocated SyncUserReceiptInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncUserReceiptInsert() (SyncUserReceiptInsertCapn, error) {
+	ss, err := NewSyncUserReceiptInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncUserReceiptInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(8, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncMacStdEventDelete() (SyncMacStdEventDeleteCapn, error) {
+	p, err := s.Struct.Ptr(9)
+	return SyncMacStdEventDeleteCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncMacStdEventDelete() bool {
+	p, err := s.Struct.Ptr(9)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncMacStdEventDelete(v SyncMacStdEventDeleteCapn) error {
+	return s.Struct.SetPtr(9, v.Struct.ToPtr())
+}
+
+// NewSyncMacStdEventDelete sets the syncMacStdEventDelete field to a newly
+// allocated SyncMacStdEventDeleteCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncMacStdEventDelete() (SyncMacStdEventDeleteCapn, error) {
+	ss, err := NewSyncMacStdEventDeleteCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncMacStdEventDeleteCapn{}, err
+	}
+	err = s.Struct.SetPtr(9, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncUserTimeLineInsert() (SyncUserTimeLineInsertCapn, error) {
+	p, err := s.Struct.Ptr(10)
+	return SyncUserTimeLineInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncUserTimeLineInsert() bool {
+	p, err := s.Struct.Ptr(10)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncUserTimeLineInsert(v SyncUserTimeLineInsertCapn) error {
+	return s.Struct.SetPtr(10, v.Struct.ToPtr())
+}
+
+// NewSyncUserTimeLineInsert sets the syncUserTimeLineInsert field to a newly
+// allocated SyncUserTimeLineInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncUserTimeLineInsert() (SyncUserTimeLineInsertCapn, error) {
+	ss, err := NewSyncUserTimeLineInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncUserTimeLineInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(10, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncOutputMinStreamInsert() (SyncOutputMinStreamInsertCapn, error) {
+	p, err := s.Struct.Ptr(11)
+	return SyncOutputMinStreamInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncOutputMinStreamInsert() bool {
+	p, err := s.Struct.Ptr(11)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncOutputMinStreamInsert(v SyncOutputMinStreamInsertCapn) error {
+	return s.Struct.SetPtr(11, v.Struct.ToPtr())
+}
+
+// NewSyncOutputMinStreamInsert sets the syncOutputMinStreamInsert field to a newly
+// allocated SyncOutputMinStreamInsertCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncOutputMinStreamInsert() (SyncOutputMinStreamInsertCapn, error) {
+	ss, err := NewSyncOutputMinStreamInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncOutputMinStreamInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(11, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncEventUpdate() (SyncEventUpdateCapn, error) {
+	p, err := s.Struct.Ptr(12)
+	return SyncEventUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncEventUpdate() bool {
+	p, err := s.Struct.Ptr(12)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncEventUpdate(v SyncEventUpdateCapn) error {
+	return s.Struct.SetPtr(12, v.Struct.ToPtr())
+}
+
+// NewSyncEventUpdate sets the syncEventUpdate field to a newly
+// allocated SyncEventUpdateCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncEventUpdate() (SyncEventUpdateCapn, error) {
+	ss, err := NewSyncEventUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncEventUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(12, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncDBEventCapn) SyncEventUpdateContent() (SyncEventUpdateContentCapn, error) {
+	p, err := s.Struct.Ptr(13)
+	return SyncEventUpdateContentCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncDBEventCapn) HasSyncEventUpdateContent() bool {
+	p, err := s.Struct.Ptr(13)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncDBEventCapn) SetSyncEventUpdateContent(v SyncEventUpdateContentCapn) error {
+	return s.Struct.SetPtr(13, v.Struct.ToPtr())
+}
+
+// NewSyncEventUpdateContent sets the syncEventUpdateContent field to a newly
+// allocated SyncEventUpdateContentCapn struct, preferring placement in s's segment.
+func (s SyncDBEventCapn) NewSyncEventUpdateContent() (SyncEventUpdateContentCapn, error) {
+	ss, err := NewSyncEventUpdateContentCapn(s.Struct.Segment())
+	if err != nil {
+		return SyncEventUpdateContentCapn{}, err
+	}
+	err = s.Struct.SetPtr(13, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// SyncDBEventCapn_List is a list of SyncDBEventCapn.
+type SyncDBEventCapn_List struct{ capnp.List }
+
+// NewSyncDBEventCapn creates a new list of SyncDBEventCapn.
+func NewSyncDBEventCapn_List(s *capnp.Segment, sz int32) (SyncDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 14}, sz)
+	return SyncDBEventCapn_List{l}, err
+}
+
+func (s SyncDBEventCapn_List) At(i int) SyncDBEventCapn { return SyncDBEventCapn{s.List.Struct(i)} }
+
+func (s SyncDBEventCapn_List) Set(i int, v SyncDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xb1366c68d01f9a91, s.List)
+	return str
+}
+
+// SyncDBEventCapn_Promise is a wrapper for a SyncDBEventCapn promised by a client call.
+type SyncDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncDBEventCapn_Promise) Struct() (SyncDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncDBEventCapn{s}, err
+}
+
+func (p SyncDBEventCapn_Promise) SyncEventInsert() SyncEventInsertCapn_Promise {
+	return SyncEventInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncRoomStateUpdate() SyncRoomStateUpdateCapn_Promise {
+	return SyncRoomStateUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncClientDataInsert() SyncClientDataInsertCapn_Promise {
+	return SyncClientDataInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncKeyStreamInsert() SyncKeyStreamInsertCapn_Promise {
+	return SyncKeyStreamInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncReceiptInsert() SyncReceiptInsertCapn_Promise {
+	return SyncReceiptInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncStdEventInsert() SyncStdEventInsertCapn_Promise {
+	return SyncStdEventInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(5)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncStdEventDelete() SyncStdEventDeleteCapn_Promise {
+	return SyncStdEventDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(6)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncPresenceInsert() SyncPresenceInsertCapn_Promise {
+	return SyncPresenceInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(7)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncUserReceiptInsert() SyncUserReceiptInsertCapn_Promise {
+	return SyncUserReceiptInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(8)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncMacStdEventDelete() SyncMacStdEventDeleteCapn_Promise {
+	return SyncMacStdEventDeleteCapn_Promise{Pipeline: p.Pipeline.GetPipeline(9)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncUserTimeLineInsert() SyncUserTimeLineInsertCapn_Promise {
+	return SyncUserTimeLineInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(10)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncOutputMinStreamInsert() SyncOutputMinStreamInsertCapn_Promise {
+	return SyncOutputMinStreamInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(11)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncEventUpdate() SyncEventUpdateCapn_Promise {
+	return SyncEventUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(12)}
+}
+
+func (p SyncDBEventCapn_Promise) SyncEventUpdateContent() SyncEventUpdateContentCapn_Promise {
+	return SyncEventUpdateContentCapn_Promise{Pipeline: p.Pipeline.GetPipeline(13)}
+}
+
+type SyncEventInsertCapn struct{ capnp.Struct }
+
+// SyncEventInsertCapn_TypeID is the unique identifier for the type SyncEventInsertCapn.
+const SyncEventInsertCapn_TypeID = 0xc1ccb63acb1b2ac0
+
+func NewSyncEventInsertCapn(s *capnp.Segment) (SyncEventInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 9})
+	return SyncEventInsertCapn{st}, err
+}
+
+func NewRootSyncEventInsertCapn(s *capnp.Segment) (SyncEventInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 9})
+	return SyncEventInsertCapn{st}, err
+}
+
+func ReadRootSyncEventInsertCapn(msg *capnp.Message) (SyncEventInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncEventInsertCapn{root.Struct()}, err
+}
+
+func (s SyncEventInsertCapn) String() string {
+	str, _ := text.Marshal(0xc1ccb63acb1b2ac0, s.Struct)
+	return str
+}
+
+func (s SyncEventInsertCapn) Pos() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncEventInsertCapn) SetPos(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncEventInsertCapn) RoomId() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasRoomId() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) RoomIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetRoomId(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncEventInsertCapn) EventId() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasEventId() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) EventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetEventId(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncEventInsertCapn) EventJson() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s SyncEventInsertCapn) HasEventJson() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) SetEventJson(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+func (s SyncEventInsertCapn) Add() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(3)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s SyncEventInsertCapn) HasAdd() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) SetAdd(v capnp.TextList) error {
+	return s.Struct.SetPtr(3, v.List.ToPtr())
+}
+
+// NewAdd sets the add field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s SyncEventInsertCapn) NewAdd(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(3, l.List.ToPtr())
+	return l, err
+}
+
+func (s SyncEventInsertCapn) Remove() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(4)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s SyncEventInsertCapn) HasRemove() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) SetRemove(v capnp.TextList) error {
+	return s.Struct.SetPtr(4, v.List.ToPtr())
+}
+
+// NewRemove sets the remove field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s SyncEventInsertCapn) NewRemove(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(4, l.List.ToPtr())
+	return l, err
+}
+
+func (s SyncEventInsertCapn) Device() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasDevice() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) DeviceBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetDevice(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s SyncEventInsertCapn) TxnId() (string, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasTxnId() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) TxnIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetTxnId(v string) error {
+	return s.Struct.SetText(6, v)
+}
+
+func (s SyncEventInsertCapn) Type() (string, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasType() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) TypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetType(v string) error {
+	return s.Struct.SetText(7, v)
+}
+
+func (s SyncEventInsertCapn) DomainOffset() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s SyncEventInsertCapn) SetDomainOffset(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s SyncEventInsertCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s SyncEventInsertCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s SyncEventInsertCapn) Domain() (string, error) {
+	p, err := s.Struct.Ptr(8)
+	return p.Text(), err
+}
+
+func (s SyncEventInsertCapn) HasDomain() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventInsertCapn) DomainBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(8)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventInsertCapn) SetDomain(v string) error {
+	return s.Struct.SetText(8, v)
+}
+
+func (s SyncEventInsertCapn) OriginTs() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s SyncEventInsertCapn) SetOriginTs(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+// SyncEventInsertCapn_List is a list of SyncEventInsertCapn.
+type SyncEventInsertCapn_List struct{ capnp.List }
+
+// NewSyncEventInsertCapn creates a new list of SyncEventInsertCapn.
+func NewSyncEventInsertCapn_List(s *capnp.Segment, sz int32) (SyncEventInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 9}, sz)
+	return SyncEventInsertCapn_List{l}, err
+}
+
+func (s SyncEventInsertCapn_List) At(i int) SyncEventInsertCapn {
+	return SyncEventInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncEventInsertCapn_List) Set(i int, v SyncEventInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncEventInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xc1ccb63acb1b2ac0, s.List)
+	return str
+}
+
+// SyncEventInsertCapn_Promise is a wrapper for a SyncEventInsertCapn promised by a client call.
+type SyncEventInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncEventInsertCapn_Promise) Struct() (SyncEventInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncEventInsertCapn{s}, err
+}
+
+type SyncRoomStateUpdateCapn struct{ capnp.Struct }
+
+// SyncRoomStateUpdateCapn_TypeID is the unique identifier for the type SyncRoomStateUpdateCapn.
+const SyncRoomStateUpdateCapn_TypeID = 0xeab92d544808b59b
+
+func NewSyncRoomStateUpdateCapn(s *capnp.Segment) (SyncRoomStateUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return SyncRoomStateUpdateCapn{st}, err
+}
+
+func NewRootSyncRoomStateUpdateCapn(s *capnp.Segment) (SyncRoomStateUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6})
+	return SyncRoomStateUpdateCapn{st}, err
+}
+
+func ReadRootSyncRoomStateUpdateCapn(msg *capnp.Message) (SyncRoomStateUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncRoomStateUpdateCapn{root.Struct()}, err
+}
+
+func (s SyncRoomStateUpdateCapn) String() string {
+	str, _ := text.Marshal(0xeab92d544808b59b, s.Struct)
+	return str
+}
+
+func (s SyncRoomStateUpdateCapn) RoomId() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasRoomId() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) RoomIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncRoomStateUpdateCapn) SetRoomId(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncRoomStateUpdateCapn) EventId() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasEventId() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) EventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncRoomStateUpdateCapn) SetEventId(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncRoomStateUpdateCapn) Type() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasType() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) TypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncRoomStateUpdateCapn) SetType(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncRoomStateUpdateCapn) EventJson() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return []byte(p.Data()), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasEventJson() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) SetEventJson(v []byte) error {
+	return s.Struct.SetData(3, v)
+}
+
+func (s SyncRoomStateUpdateCapn) EventStateKey() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasEventStateKey() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) EventStateKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s SyncRoomStateUpdateCapn) SetEventStateKey(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s SyncRoomStateUpdateCapn) Membership() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s SyncRoomStateUpdateCapn) HasMembership() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncRoomStateUpdateCapn) MembershipBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s SyncRoomStateUpdateCapn) SetMembership(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s SyncRoomStateUpdateCapn) AddPos() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncRoomStateUpdateCapn) SetAddPos(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// SyncRoomStateUpdateCapn_List is a list of SyncRoomStateUpdateCapn.
+type SyncRoomStateUpdateCapn_List struct{ capnp.List }
+
+// NewSyncRoomStateUpdateCapn creates a new list of SyncRoomStateUpdateCapn.
+func NewSyncRoomStateUpdateCapn_List(s *capnp.Segment, sz int32) (SyncRoomStateUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 6}, sz)
+	return SyncRoomStateUpdateCapn_List{l}, err
+}
+
+func (s SyncRoomStateUpdateCapn_List) At(i int) SyncRoomStateUpdateCapn {
+	return SyncRoomStateUpdateCapn{s.List.Struct(i)}
+}
+
+func (s SyncRoomStateUpdateCapn_List) Set(i int, v SyncRoomStateUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncRoomStateUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0xeab92d544808b59b, s.List)
+	return str
+}
+
+// SyncRoomStateUpdateCapn_Promise is a wrapper for a SyncRoomStateUpdateCapn promised by a client call.
+type SyncRoomStateUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncRoomStateUpdateCapn_Promise) Struct() (SyncRoomStateUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncRoomStateUpdateCapn{s}, err
+}
+
+type SyncClientDataInsertCapn struct{ capnp.Struct }
+
+// SyncClientDataInsertCapn_TypeID is the unique identifier for the type SyncClientDataInsertCapn.
+const SyncClientDataInsertCapn_TypeID = 0xee9dc2e3f0844724
+
+func NewSyncClientDataInsertCapn(s *capnp.Segment) (SyncClientDataInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return SyncClientDataInsertCapn{st}, err
+}
+
+func NewRootSyncClientDataInsertCapn(s *capnp.Segment) (SyncClientDataInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return SyncClientDataInsertCapn{st}, err
+}
+
+func ReadRootSyncClientDataInsertCapn(msg *capnp.Message) (SyncClientDataInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncClientDataInsertCapn{root.Struct()}, err
+}
+
+func (s SyncClientDataInsertCapn) String() string {
+	str, _ := text.Marshal(0xee9dc2e3f0844724, s.Struct)
+	return str
+}
+
+func (s SyncClientDataInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncClientDataInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncClientDataInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncClientDataInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncClientDataInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncClientDataInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncClientDataInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncClientDataInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncClientDataInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncClientDataInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncClientDataInsertCapn) DataType() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncClientDataInsertCapn) HasDataType() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncClientDataInsertCapn) DataTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncClientDataInsertCapn) SetDataType(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncClientDataInsertCapn) StreamType() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s SyncClientDataInsertCapn) HasStreamType() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncClientDataInsertCapn) StreamTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s SyncClientDataInsertCapn) SetStreamType(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// SyncClientDataInsertCapn_List is a list of SyncClientDataInsertCapn.
+type SyncClientDataInsertCapn_List struct{ capnp.List }
+
+// NewSyncClientDataInsertCapn creates a new list of SyncClientDataInsertCapn.
+func NewSyncClientDataInsertCapn_List(s *capnp.Segment, sz int32) (SyncClientDataInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return SyncClientDataInsertCapn_List{l}, err
+}
+
+func (s SyncClientDataInsertCapn_List) At(i int) SyncClientDataInsertCapn {
+	return SyncClientDataInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncClientDataInsertCapn_List) Set(i int, v SyncClientDataInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncClientDataInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xee9dc2e3f0844724, s.List)
+	return str
+}
+
+// SyncClientDataInsertCapn_Promise is a wrapper for a SyncClientDataInsertCapn promised by a client call.
+type SyncClientDataInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncClientDataInsertCapn_Promise) Struct() (SyncClientDataInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncClientDataInsertCapn{s}, err
+}
+
+type SyncKeyStreamInsertCapn struct{ capnp.Struct }
+
+// SyncKeyStreamInsertCapn_TypeID is the unique identifier for the type SyncKeyStreamInsertCapn.
+const SyncKeyStreamInsertCapn_TypeID = 0x8241fbc07d342431
+
+func NewSyncKeyStreamInsertCapn(s *capnp.Segment) (SyncKeyStreamInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SyncKeyStreamInsertCapn{st}, err
+}
+
+func NewRootSyncKeyStreamInsertCapn(s *capnp.Segment) (SyncKeyStreamInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SyncKeyStreamInsertCapn{st}, err
+}
+
+func ReadRootSyncKeyStreamInsertCapn(msg *capnp.Message) (SyncKeyStreamInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncKeyStreamInsertCapn{root.Struct()}, err
+}
+
+func (s SyncKeyStreamInsertCapn) String() string {
+	str, _ := text.Marshal(0x8241fbc07d342431, s.Struct)
+	return str
+}
+
+func (s SyncKeyStreamInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncKeyStreamInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncKeyStreamInsertCapn) ChangedUserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncKeyStreamInsertCapn) HasChangedUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncKeyStreamInsertCapn) ChangedUserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncKeyStreamInsertCapn) SetChangedUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// SyncKeyStreamInsertCapn_List is a list of SyncKeyStreamInsertCapn.
+type SyncKeyStreamInsertCapn_List struct{ capnp.List }
+
+// NewSyncKeyStreamInsertCapn creates a new list of SyncKeyStreamInsertCapn.
+func NewSyncKeyStreamInsertCapn_List(s *capnp.Segment, sz int32) (SyncKeyStreamInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SyncKeyStreamInsertCapn_List{l}, err
+}
+
+func (s SyncKeyStreamInsertCapn_List) At(i int) SyncKeyStreamInsertCapn {
+	return SyncKeyStreamInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncKeyStreamInsertCapn_List) Set(i int, v SyncKeyStreamInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncKeyStreamInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x8241fbc07d342431, s.List)
+	return str
+}
+
+// SyncKeyStreamInsertCapn_Promise is a wrapper for a SyncKeyStreamInsertCapn promised by a client call.
+type SyncKeyStreamInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncKeyStreamInsertCapn_Promise) Struct() (SyncKeyStreamInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncKeyStreamInsertCapn{s}, err
+}
+
+type SyncReceiptInsertCapn struct{ capnp.Struct }
+
+// SyncReceiptInsertCapn_TypeID is the unique identifier for the type SyncReceiptInsertCapn.
+const SyncReceiptInsertCapn_TypeID = 0xa101b3e95f02908b
+
+func NewSyncReceiptInsertCapn(s *capnp.Segment) (SyncReceiptInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return SyncReceiptInsertCapn{st}, err
+}
+
+func NewRootSyncReceiptInsertCapn(s *capnp.Segment) (SyncReceiptInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2})
+	return SyncReceiptInsertCapn{st}, err
+}
+
+func ReadRootSyncReceiptInsertCapn(msg *capnp.Message) (SyncReceiptInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncReceiptInsertCapn{root.Struct()}, err
+}
+
+func (s SyncReceiptInsertCapn) String() string {
+	str, _ := text.Marshal(0xa101b3e95f02908b, s.Struct)
+	return str
+}
+
+func (s SyncReceiptInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncReceiptInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncReceiptInsertCapn) EvtOffset() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s SyncReceiptInsertCapn) SetEvtOffset(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s SyncReceiptInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncReceiptInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncReceiptInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncReceiptInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncReceiptInsertCapn) Content() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncReceiptInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncReceiptInsertCapn) ContentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncReceiptInsertCapn) SetContent(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// SyncReceiptInsertCapn_List is a list of SyncReceiptInsertCapn.
+type SyncReceiptInsertCapn_List struct{ capnp.List }
+
+// NewSyncReceiptInsertCapn creates a new list of SyncReceiptInsertCapn.
+func NewSyncReceiptInsertCapn_List(s *capnp.Segment, sz int32) (SyncReceiptInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 2}, sz)
+	return SyncReceiptInsertCapn_List{l}, err
+}
+
+func (s SyncReceiptInsertCapn_List) At(i int) SyncReceiptInsertCapn {
+	return SyncReceiptInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncReceiptInsertCapn_List) Set(i int, v SyncReceiptInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncReceiptInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xa101b3e95f02908b, s.List)
+	return str
+}
+
+// SyncReceiptInsertCapn_Promise is a wrapper for a SyncReceiptInsertCapn promised by a client call.
+type SyncReceiptInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncReceiptInsertCapn_Promise) Struct() (SyncReceiptInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncReceiptInsertCapn{s}, err
+}
+
+type SyncStdEventInsertCapn struct{ capnp.Struct }
+
+// SyncStdEventInsertCapn_TypeID is the unique identifier for the type SyncStdEventInsertCapn.
+const SyncStdEventInsertCapn_TypeID = 0x913d5747c3f4e4b3
+
+func NewSyncStdEventInsertCapn(s *capnp.Segment) (SyncStdEventInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return SyncStdEventInsertCapn{st}, err
+}
+
+func NewRootSyncStdEventInsertCapn(s *capnp.Segment) (SyncStdEventInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4})
+	return SyncStdEventInsertCapn{st}, err
+}
+
+func ReadRootSyncStdEventInsertCapn(msg *capnp.Message) (SyncStdEventInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncStdEventInsertCapn{root.Struct()}, err
+}
+
+func (s SyncStdEventInsertCapn) String() string {
+	str, _ := text.Marshal(0x913d5747c3f4e4b3, s.Struct)
+	return str
+}
+
+func (s SyncStdEventInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncStdEventInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncStdEventInsertCapn) StdEvent() (StdHolderCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return StdHolderCapn{Struct: p.Struct()}, err
+}
+
+func (s SyncStdEventInsertCapn) HasStdEvent() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventInsertCapn) SetStdEvent(v StdHolderCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewStdEvent sets the stdEvent field to a newly
+// allocated StdHolderCapn struct, preferring placement in s's segment.
+func (s SyncStdEventInsertCapn) NewStdEvent() (StdHolderCapn, error) {
+	ss, err := NewStdHolderCapn(s.Struct.Segment())
+	if err != nil {
+		return StdHolderCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s SyncStdEventInsertCapn) TargetUID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncStdEventInsertCapn) HasTargetUID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventInsertCapn) TargetUIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncStdEventInsertCapn) SetTargetUID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncStdEventInsertCapn) TargetDevice() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncStdEventInsertCapn) HasTargetDevice() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventInsertCapn) TargetDeviceBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncStdEventInsertCapn) SetTargetDevice(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncStdEventInsertCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s SyncStdEventInsertCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventInsertCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s SyncStdEventInsertCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// SyncStdEventInsertCapn_List is a list of SyncStdEventInsertCapn.
+type SyncStdEventInsertCapn_List struct{ capnp.List }
+
+// NewSyncStdEventInsertCapn creates a new list of SyncStdEventInsertCapn.
+func NewSyncStdEventInsertCapn_List(s *capnp.Segment, sz int32) (SyncStdEventInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 4}, sz)
+	return SyncStdEventInsertCapn_List{l}, err
+}
+
+func (s SyncStdEventInsertCapn_List) At(i int) SyncStdEventInsertCapn {
+	return SyncStdEventInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncStdEventInsertCapn_List) Set(i int, v SyncStdEventInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncStdEventInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x913d5747c3f4e4b3, s.List)
+	return str
+}
+
+// SyncStdEventInsertCapn_Promise is a wrapper for a SyncStdEventInsertCapn promised by a client call.
+type SyncStdEventInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncStdEventInsertCapn_Promise) Struct() (SyncStdEventInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncStdEventInsertCapn{s}, err
+}
+
+func (p SyncStdEventInsertCapn_Promise) StdEvent() StdHolderCapn_Promise {
+	return StdHolderCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type StdHolderCapn struct{ capnp.Struct }
+
+// StdHolderCapn_TypeID is the unique identifier for the type StdHolderCapn.
+const StdHolderCapn_TypeID = 0x92c3dca0a360ca2a
+
+func NewStdHolderCapn(s *capnp.Segment) (StdHolderCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return StdHolderCapn{st}, err
+}
+
+func NewRootStdHolderCapn(s *capnp.Segment) (StdHolderCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return StdHolderCapn{st}, err
+}
+
+func ReadRootStdHolderCapn(msg *capnp.Message) (StdHolderCapn, error) {
+	root, err := msg.RootPtr()
+	return StdHolderCapn{root.Struct()}, err
+}
+
+func (s StdHolderCapn) String() string {
+	str, _ := text.Marshal(0x92c3dca0a360ca2a, s.Struct)
+	return str
+}
+
+func (s StdHolderCapn) StreamID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s StdHolderCapn) SetStreamID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s StdHolderCapn) Sender() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s StdHolderCapn) HasSender() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s StdHolderCapn) SenderBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s StdHolderCapn) SetSender(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s StdHolderCapn) EventTyp() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s StdHolderCapn) HasEventTyp() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s StdHolderCapn) EventTypBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s StdHolderCapn) SetEventTyp(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s StdHolderCapn) Event() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s StdHolderCapn) HasEvent() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s StdHolderCapn) SetEvent(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+// StdHolderCapn_List is a list of StdHolderCapn.
+type StdHolderCapn_List struct{ capnp.List }
+
+// NewStdHolderCapn creates a new list of StdHolderCapn.
+func NewStdHolderCapn_List(s *capnp.Segment, sz int32) (StdHolderCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return StdHolderCapn_List{l}, err
+}
+
+func (s StdHolderCapn_List) At(i int) StdHolderCapn { return StdHolderCapn{s.List.Struct(i)} }
+
+func (s StdHolderCapn_List) Set(i int, v StdHolderCapn) error { return s.List.SetStruct(i, v.Struct) }
+
+func (s StdHolderCapn_List) String() string {
+	str, _ := text.MarshalList(0x92c3dca0a360ca2a, s.List)
+	return str
+}
+
+// StdHolderCapn_Promise is a wrapper for a StdHolderCapn promised by a client call.
+type StdHolderCapn_Promise struct{ *capnp.Pipeline }
+
+func (p StdHolderCapn_Promise) Struct() (StdHolderCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return StdHolderCapn{s}, err
+}
+
+type SyncStdEventDeleteCapn struct{ capnp.Struct }
+
+// SyncStdEventDeleteCapn_TypeID is the unique identifier for the type SyncStdEventDeleteCapn.
+const SyncStdEventDeleteCapn_TypeID = 0xc4ca665aca2521d3
+
+func NewSyncStdEventDeleteCapn(s *capnp.Segment) (SyncStdEventDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SyncStdEventDeleteCapn{st}, err
+}
+
+func NewRootSyncStdEventDeleteCapn(s *capnp.Segment) (SyncStdEventDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SyncStdEventDeleteCapn{st}, err
+}
+
+func ReadRootSyncStdEventDeleteCapn(msg *capnp.Message) (SyncStdEventDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncStdEventDeleteCapn{root.Struct()}, err
+}
+
+func (s SyncStdEventDeleteCapn) String() string {
+	str, _ := text.Marshal(0xc4ca665aca2521d3, s.Struct)
+	return str
+}
+
+func (s SyncStdEventDeleteCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncStdEventDeleteCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncStdEventDeleteCapn) TargetUID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncStdEventDeleteCapn) HasTargetUID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventDeleteCapn) TargetUIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncStdEventDeleteCapn) SetTargetUID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncStdEventDeleteCapn) TargetDevice() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncStdEventDeleteCapn) HasTargetDevice() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncStdEventDeleteCapn) TargetDeviceBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncStdEventDeleteCapn) SetTargetDevice(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// SyncStdEventDeleteCapn_List is a list of SyncStdEventDeleteCapn.
+type SyncStdEventDeleteCapn_List struct{ capnp.List }
+
+// NewSyncStdEventDeleteCapn creates a new list of SyncStdEventDeleteCapn.
+func NewSyncStdEventDeleteCapn_List(s *capnp.Segment, sz int32) (SyncStdEventDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return SyncStdEventDeleteCapn_List{l}, err
+}
+
+func (s SyncStdEventDeleteCapn_List) At(i int) SyncStdEventDeleteCapn {
+	return SyncStdEventDeleteCapn{s.List.Struct(i)}
+}
+
+func (s SyncStdEventDeleteCapn_List) Set(i int, v SyncStdEventDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncStdEventDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xc4ca665aca2521d3, s.List)
+	return str
+}
+
+// SyncStdEventDeleteCapn_Promise is a wrapper for a SyncStdEventDeleteCapn promised by a client call.
+type SyncStdEventDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncStdEventDeleteCapn_Promise) Struct() (SyncStdEventDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncStdEventDeleteCapn{s}, err
+}
+
+type SyncPresenceInsertCapn struct{ capnp.Struct }
+
+// SyncPresenceInsertCapn_TypeID is the unique identifier for the type SyncPresenceInsertCapn.
+const SyncPresenceInsertCapn_TypeID = 0xa439dda318deab4f
+
+func NewSyncPresenceInsertCapn(s *capnp.Segment) (SyncPresenceInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SyncPresenceInsertCapn{st}, err
+}
+
+func NewRootSyncPresenceInsertCapn(s *capnp.Segment) (SyncPresenceInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2})
+	return SyncPresenceInsertCapn{st}, err
+}
+
+func ReadRootSyncPresenceInsertCapn(msg *capnp.Message) (SyncPresenceInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncPresenceInsertCapn{root.Struct()}, err
+}
+
+func (s SyncPresenceInsertCapn) String() string {
+	str, _ := text.Marshal(0xa439dda318deab4f, s.Struct)
+	return str
+}
+
+func (s SyncPresenceInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncPresenceInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncPresenceInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncPresenceInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncPresenceInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncPresenceInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncPresenceInsertCapn) Content() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncPresenceInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncPresenceInsertCapn) ContentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncPresenceInsertCapn) SetContent(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+// SyncPresenceInsertCapn_List is a list of SyncPresenceInsertCapn.
+type SyncPresenceInsertCapn_List struct{ capnp.List }
+
+// NewSyncPresenceInsertCapn creates a new list of SyncPresenceInsertCapn.
+func NewSyncPresenceInsertCapn_List(s *capnp.Segment, sz int32) (SyncPresenceInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 2}, sz)
+	return SyncPresenceInsertCapn_List{l}, err
+}
+
+func (s SyncPresenceInsertCapn_List) At(i int) SyncPresenceInsertCapn {
+	return SyncPresenceInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncPresenceInsertCapn_List) Set(i int, v SyncPresenceInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncPresenceInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xa439dda318deab4f, s.List)
+	return str
+}
+
+// SyncPresenceInsertCapn_Promise is a wrapper for a SyncPresenceInsertCapn promised by a client call.
+type SyncPresenceInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncPresenceInsertCapn_Promise) Struct() (SyncPresenceInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncPresenceInsertCapn{s}, err
+}
+
+type SyncUserReceiptInsertCapn struct{ capnp.Struct }
+
+// SyncUserReceiptInsertCapn_TypeID is the unique identifier for the type SyncUserReceiptInsertCapn.
+const SyncUserReceiptInsertCapn_TypeID = 0xae42c17d5084fa3f
+
+func NewSyncUserReceiptInsertCapn(s *capnp.Segment) (SyncUserReceiptInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return SyncUserReceiptInsertCapn{st}, err
+}
+
+func NewRootSyncUserReceiptInsertCapn(s *capnp.Segment) (SyncUserReceiptInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return SyncUserReceiptInsertCapn{st}, err
+}
+
+func ReadRootSyncUserReceiptInsertCapn(msg *capnp.Message) (SyncUserReceiptInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncUserReceiptInsertCapn{root.Struct()}, err
+}
+
+func (s SyncUserReceiptInsertCapn) String() string {
+	str, _ := text.Marshal(0xae42c17d5084fa3f, s.Struct)
+	return str
+}
+
+func (s SyncUserReceiptInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncUserReceiptInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserReceiptInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserReceiptInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncUserReceiptInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncUserReceiptInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserReceiptInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserReceiptInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncUserReceiptInsertCapn) Content() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncUserReceiptInsertCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserReceiptInsertCapn) ContentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserReceiptInsertCapn) SetContent(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncUserReceiptInsertCapn) EvtOffset() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncUserReceiptInsertCapn) SetEvtOffset(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+// SyncUserReceiptInsertCapn_List is a list of SyncUserReceiptInsertCapn.
+type SyncUserReceiptInsertCapn_List struct{ capnp.List }
+
+// NewSyncUserReceiptInsertCapn creates a new list of SyncUserReceiptInsertCapn.
+func NewSyncUserReceiptInsertCapn_List(s *capnp.Segment, sz int32) (SyncUserReceiptInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return SyncUserReceiptInsertCapn_List{l}, err
+}
+
+func (s SyncUserReceiptInsertCapn_List) At(i int) SyncUserReceiptInsertCapn {
+	return SyncUserReceiptInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncUserReceiptInsertCapn_List) Set(i int, v SyncUserReceiptInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncUserReceiptInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xae42c17d5084fa3f, s.List)
+	return str
+}
+
+// SyncUserReceiptInsertCapn_Promise is a wrapper for a SyncUserReceiptInsertCapn promised by a client call.
+type SyncUserReceiptInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncUserReceiptInsertCapn_Promise) Struct() (SyncUserReceiptInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncUserReceiptInsertCapn{s}, err
+}
+
+type SyncMacStdEventDeleteCapn struct{ capnp.Struct }
+
+// SyncMacStdEventDeleteCapn_TypeID is the unique identifier for the type SyncMacStdEventDeleteCapn.
+const SyncMacStdEventDeleteCapn_TypeID = 0xa929813448d22939
+
+func NewSyncMacStdEventDeleteCapn(s *capnp.Segment) (SyncMacStdEventDeleteCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SyncMacStdEventDeleteCapn{st}, err
+}
+
+func NewRootSyncMacStdEventDeleteCapn(s *capnp.Segment) (SyncMacStdEventDeleteCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return SyncMacStdEventDeleteCapn{st}, err
+}
+
+func ReadRootSyncMacStdEventDeleteCapn(msg *capnp.Message) (SyncMacStdEventDeleteCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncMacStdEventDeleteCapn{root.Struct()}, err
+}
+
+func (s SyncMacStdEventDeleteCapn) String() string {
+	str, _ := text.Marshal(0xa929813448d22939, s.Struct)
+	return str
+}
+
+func (s SyncMacStdEventDeleteCapn) Identifier() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) HasIdentifier() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncMacStdEventDeleteCapn) IdentifierBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) SetIdentifier(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncMacStdEventDeleteCapn) TargetUID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) HasTargetUID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncMacStdEventDeleteCapn) TargetUIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) SetTargetUID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncMacStdEventDeleteCapn) TargetDevice() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) HasTargetDevice() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncMacStdEventDeleteCapn) TargetDeviceBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncMacStdEventDeleteCapn) SetTargetDevice(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// SyncMacStdEventDeleteCapn_List is a list of SyncMacStdEventDeleteCapn.
+type SyncMacStdEventDeleteCapn_List struct{ capnp.List }
+
+// NewSyncMacStdEventDeleteCapn creates a new list of SyncMacStdEventDeleteCapn.
+func NewSyncMacStdEventDeleteCapn_List(s *capnp.Segment, sz int32) (SyncMacStdEventDeleteCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return SyncMacStdEventDeleteCapn_List{l}, err
+}
+
+func (s SyncMacStdEventDeleteCapn_List) At(i int) SyncMacStdEventDeleteCapn {
+	return SyncMacStdEventDeleteCapn{s.List.Struct(i)}
+}
+
+func (s SyncMacStdEventDeleteCapn_List) Set(i int, v SyncMacStdEventDeleteCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncMacStdEventDeleteCapn_List) String() string {
+	str, _ := text.MarshalList(0xa929813448d22939, s.List)
+	return str
+}
+
+// SyncMacStdEventDeleteCapn_Promise is a wrapper for a SyncMacStdEventDeleteCapn promised by a client call.
+type SyncMacStdEventDeleteCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncMacStdEventDeleteCapn_Promise) Struct() (SyncMacStdEventDeleteCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncMacStdEventDeleteCapn{s}, err
+}
+
+type SyncUserTimeLineInsertCapn struct{ capnp.Struct }
+
+// SyncUserTimeLineInsertCapn_TypeID is the unique identifier for the type SyncUserTimeLineInsertCapn.
+const SyncUserTimeLineInsertCapn_TypeID = 0xbce7f3f0621f46dc
+
+func NewSyncUserTimeLineInsertCapn(s *capnp.Segment) (SyncUserTimeLineInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
+	return SyncUserTimeLineInsertCapn{st}, err
+}
+
+func NewRootSyncUserTimeLineInsertCapn(s *capnp.Segment) (SyncUserTimeLineInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3})
+	return SyncUserTimeLineInsertCapn{st}, err
+}
+
+func ReadRootSyncUserTimeLineInsertCapn(msg *capnp.Message) (SyncUserTimeLineInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncUserTimeLineInsertCapn{root.Struct()}, err
+}
+
+func (s SyncUserTimeLineInsertCapn) String() string {
+	str, _ := text.Marshal(0xbce7f3f0621f46dc, s.Struct)
+	return str
+}
+
+func (s SyncUserTimeLineInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncUserTimeLineInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncUserTimeLineInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserTimeLineInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncUserTimeLineInsertCapn) EventNID() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s SyncUserTimeLineInsertCapn) SetEventNID(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s SyncUserTimeLineInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserTimeLineInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncUserTimeLineInsertCapn) RoomState() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) HasRoomState() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncUserTimeLineInsertCapn) RoomStateBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncUserTimeLineInsertCapn) SetRoomState(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncUserTimeLineInsertCapn) Ts() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s SyncUserTimeLineInsertCapn) SetTs(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s SyncUserTimeLineInsertCapn) EventOffset() int64 {
+	return int64(s.Struct.Uint64(24))
+}
+
+func (s SyncUserTimeLineInsertCapn) SetEventOffset(v int64) {
+	s.Struct.SetUint64(24, uint64(v))
+}
+
+// SyncUserTimeLineInsertCapn_List is a list of SyncUserTimeLineInsertCapn.
+type SyncUserTimeLineInsertCapn_List struct{ capnp.List }
+
+// NewSyncUserTimeLineInsertCapn creates a new list of SyncUserTimeLineInsertCapn.
+func NewSyncUserTimeLineInsertCapn_List(s *capnp.Segment, sz int32) (SyncUserTimeLineInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 32, PointerCount: 3}, sz)
+	return SyncUserTimeLineInsertCapn_List{l}, err
+}
+
+func (s SyncUserTimeLineInsertCapn_List) At(i int) SyncUserTimeLineInsertCapn {
+	return SyncUserTimeLineInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncUserTimeLineInsertCapn_List) Set(i int, v SyncUserTimeLineInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncUserTimeLineInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xbce7f3f0621f46dc, s.List)
+	return str
+}
+
+// SyncUserTimeLineInsertCapn_Promise is a wrapper for a SyncUserTimeLineInsertCapn promised by a client call.
+type SyncUserTimeLineInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncUserTimeLineInsertCapn_Promise) Struct() (SyncUserTimeLineInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncUserTimeLineInsertCapn{s}, err
+}
+
+type SyncOutputMinStreamInsertCapn struct{ capnp.Struct }
+
+// SyncOutputMinStreamInsertCapn_TypeID is the unique identifier for the type SyncOutputMinStreamInsertCapn.
+const SyncOutputMinStreamInsertCapn_TypeID = 0x8c906f23688998cd
+
+func NewSyncOutputMinStreamInsertCapn(s *capnp.Segment) (SyncOutputMinStreamInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SyncOutputMinStreamInsertCapn{st}, err
+}
+
+func NewRootSyncOutputMinStreamInsertCapn(s *capnp.Segment) (SyncOutputMinStreamInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1})
+	return SyncOutputMinStreamInsertCapn{st}, err
+}
+
+func ReadRootSyncOutputMinStreamInsertCapn(msg *capnp.Message) (SyncOutputMinStreamInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncOutputMinStreamInsertCapn{root.Struct()}, err
+}
+
+func (s SyncOutputMinStreamInsertCapn) String() string {
+	str, _ := text.Marshal(0x8c906f23688998cd, s.Struct)
+	return str
+}
+
+func (s SyncOutputMinStreamInsertCapn) Id() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncOutputMinStreamInsertCapn) SetId(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncOutputMinStreamInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncOutputMinStreamInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncOutputMinStreamInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncOutputMinStreamInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+// SyncOutputMinStreamInsertCapn_List is a list of SyncOutputMinStreamInsertCapn.
+type SyncOutputMinStreamInsertCapn_List struct{ capnp.List }
+
+// NewSyncOutputMinStreamInsertCapn creates a new list of SyncOutputMinStreamInsertCapn.
+func NewSyncOutputMinStreamInsertCapn_List(s *capnp.Segment, sz int32) (SyncOutputMinStreamInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 1}, sz)
+	return SyncOutputMinStreamInsertCapn_List{l}, err
+}
+
+func (s SyncOutputMinStreamInsertCapn_List) At(i int) SyncOutputMinStreamInsertCapn {
+	return SyncOutputMinStreamInsertCapn{s.List.Struct(i)}
+}
+
+func (s SyncOutputMinStreamInsertCapn_List) Set(i int, v SyncOutputMinStreamInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncOutputMinStreamInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0x8c906f23688998cd, s.List)
+	return str
+}
+
+// SyncOutputMinStreamInsertCapn_Promise is a wrapper for a SyncOutputMinStreamInsertCapn promised by a client call.
+type SyncOutputMinStreamInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncOutputMinStreamInsertCapn_Promise) Struct() (SyncOutputMinStreamInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncOutputMinStreamInsertCapn{s}, err
+}
+
+type SyncEventUpdateCapn struct{ capnp.Struct }
+
+// SyncEventUpdateCapn_TypeID is the unique identifier for the type SyncEventUpdateCapn.
+const SyncEventUpdateCapn_TypeID = 0xd057b702b851d2b9
+
+func NewSyncEventUpdateCapn(s *capnp.Segment) (SyncEventUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return SyncEventUpdateCapn{st}, err
+}
+
+func NewRootSyncEventUpdateCapn(s *capnp.Segment) (SyncEventUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3})
+	return SyncEventUpdateCapn{st}, err
+}
+
+func ReadRootSyncEventUpdateCapn(msg *capnp.Message) (SyncEventUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncEventUpdateCapn{root.Struct()}, err
+}
+
+func (s SyncEventUpdateCapn) String() string {
+	str, _ := text.Marshal(0xd057b702b851d2b9, s.Struct)
+	return str
+}
+
+func (s SyncEventUpdateCapn) DomainOffset() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s SyncEventUpdateCapn) SetDomainOffset(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s SyncEventUpdateCapn) Domain() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateCapn) HasDomain() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateCapn) DomainBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateCapn) SetDomain(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncEventUpdateCapn) OriginTs() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s SyncEventUpdateCapn) SetOriginTs(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s SyncEventUpdateCapn) RoomId() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateCapn) HasRoomId() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateCapn) RoomIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateCapn) SetRoomId(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncEventUpdateCapn) EventId() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateCapn) HasEventId() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateCapn) EventIdBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateCapn) SetEventId(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// SyncEventUpdateCapn_List is a list of SyncEventUpdateCapn.
+type SyncEventUpdateCapn_List struct{ capnp.List }
+
+// NewSyncEventUpdateCapn creates a new list of SyncEventUpdateCapn.
+func NewSyncEventUpdateCapn_List(s *capnp.Segment, sz int32) (SyncEventUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 16, PointerCount: 3}, sz)
+	return SyncEventUpdateCapn_List{l}, err
+}
+
+func (s SyncEventUpdateCapn_List) At(i int) SyncEventUpdateCapn {
+	return SyncEventUpdateCapn{s.List.Struct(i)}
+}
+
+func (s SyncEventUpdateCapn_List) Set(i int, v SyncEventUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncEventUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0xd057b702b851d2b9, s.List)
+	return str
+}
+
+// SyncEventUpdateCapn_Promise is a wrapper for a SyncEventUpdateCapn promised by a client call.
+type SyncEventUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncEventUpdateCapn_Promise) Struct() (SyncEventUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncEventUpdateCapn{s}, err
+}
+
+type SyncEventUpdateContentCapn struct{ capnp.Struct }
+
+// SyncEventUpdateContentCapn_TypeID is the unique identifier for the type SyncEventUpdateContentCapn.
+const SyncEventUpdateContentCapn_TypeID = 0xfa848f8bf8b75825
+
+func NewSyncEventUpdateContentCapn(s *capnp.Segment) (SyncEventUpdateContentCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return SyncEventUpdateContentCapn{st}, err
+}
+
+func NewRootSyncEventUpdateContentCapn(s *capnp.Segment) (SyncEventUpdateContentCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return SyncEventUpdateContentCapn{st}, err
+}
+
+func ReadRootSyncEventUpdateContentCapn(msg *capnp.Message) (SyncEventUpdateContentCapn, error) {
+	root, err := msg.RootPtr()
+	return SyncEventUpdateContentCapn{root.Struct()}, err
+}
+
+func (s SyncEventUpdateContentCapn) String() string {
+	str, _ := text.Marshal(0xfa848f8bf8b75825, s.Struct)
+	return str
+}
+
+func (s SyncEventUpdateContentCapn) EventID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateContentCapn) HasEventID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateContentCapn) EventIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateContentCapn) SetEventID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s SyncEventUpdateContentCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateContentCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateContentCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateContentCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s SyncEventUpdateContentCapn) Content() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateContentCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateContentCapn) ContentBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateContentCapn) SetContent(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s SyncEventUpdateContentCapn) EventType() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s SyncEventUpdateContentCapn) HasEventType() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s SyncEventUpdateContentCapn) EventTypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s SyncEventUpdateContentCapn) SetEventType(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// SyncEventUpdateContentCapn_List is a list of SyncEventUpdateContentCapn.
+type SyncEventUpdateContentCapn_List struct{ capnp.List }
+
+// NewSyncEventUpdateContentCapn creates a new list of SyncEventUpdateContentCapn.
+func NewSyncEventUpdateContentCapn_List(s *capnp.Segment, sz int32) (SyncEventUpdateContentCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return SyncEventUpdateContentCapn_List{l}, err
+}
+
+func (s SyncEventUpdateContentCapn_List) At(i int) SyncEventUpdateContentCapn {
+	return SyncEventUpdateContentCapn{s.List.Struct(i)}
+}
+
+func (s SyncEventUpdateContentCapn_List) Set(i int, v SyncEventUpdateContentCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s SyncEventUpdateContentCapn_List) String() string {
+	str, _ := text.MarshalList(0xfa848f8bf8b75825, s.List)
+	return str
+}
+
+// SyncEventUpdateContentCapn_Promise is a wrapper for a SyncEventUpdateContentCapn promised by a client call.
+type SyncEventUpdateContentCapn_Promise struct{ *capnp.Pipeline }
+
+func (p SyncEventUpdateContentCapn_Promise) Struct() (SyncEventUpdateContentCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return SyncEventUpdateContentCapn{s}, err
+}
+
+type PublicRoomDBEventCapn struct{ capnp.Struct }
+
+// PublicRoomDBEventCapn_TypeID is the unique identifier for the type PublicRoomDBEventCapn.
+const PublicRoomDBEventCapn_TypeID = 0xde4f7ccd46b4df5f
+
+func NewPublicRoomDBEventCapn(s *capnp.Segment) (PublicRoomDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return PublicRoomDBEventCapn{st}, err
+}
+
+func NewRootPublicRoomDBEventCapn(s *capnp.Segment) (PublicRoomDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3})
+	return PublicRoomDBEventCapn{st}, err
+}
+
+func ReadRootPublicRoomDBEventCapn(msg *capnp.Message) (PublicRoomDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return PublicRoomDBEventCapn{root.Struct()}, err
+}
+
+func (s PublicRoomDBEventCapn) String() string {
+	str, _ := text.Marshal(0xde4f7ccd46b4df5f, s.Struct)
+	return str
+}
+
+func (s PublicRoomDBEventCapn) PublicRoomInsert() (PublicRoomInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return PublicRoomInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s PublicRoomDBEventCapn) HasPublicRoomInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomDBEventCapn) SetPublicRoomInsert(v PublicRoomInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPublicRoomInsert sets the publicRoomInsert field to a newly
+// allocated PublicRoomInsertCapn struct, preferring placement in s's segment.
+func (s PublicRoomDBEventCapn) NewPublicRoomInsert() (PublicRoomInsertCapn, error) {
+	ss, err := NewPublicRoomInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return PublicRoomInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PublicRoomDBEventCapn) PublicRoomUpdate() (PublicRoomUpdateCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return PublicRoomUpdateCapn{Struct: p.Struct()}, err
+}
+
+func (s PublicRoomDBEventCapn) HasPublicRoomUpdate() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomDBEventCapn) SetPublicRoomUpdate(v PublicRoomUpdateCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewPublicRoomUpdate sets the publicRoomUpdate field to a newly
+// allocated PublicRoomUpdateCapn struct, preferring placement in s's segment.
+func (s PublicRoomDBEventCapn) NewPublicRoomUpdate() (PublicRoomUpdateCapn, error) {
+	ss, err := NewPublicRoomUpdateCapn(s.Struct.Segment())
+	if err != nil {
+		return PublicRoomUpdateCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s PublicRoomDBEventCapn) PublicRoomJoined() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s PublicRoomDBEventCapn) HasPublicRoomJoined() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomDBEventCapn) PublicRoomJoinedBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomDBEventCapn) SetPublicRoomJoined(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s PublicRoomDBEventCapn) PublicRoomJoinedSet() bool {
+	return s.Struct.Bit(0)
+}
+
+func (s PublicRoomDBEventCapn) SetPublicRoomJoinedSet(v bool) {
+	s.Struct.SetBit(0, v)
+}
+
+// PublicRoomDBEventCapn_List is a list of PublicRoomDBEventCapn.
+type PublicRoomDBEventCapn_List struct{ capnp.List }
+
+// NewPublicRoomDBEventCapn creates a new list of PublicRoomDBEventCapn.
+func NewPublicRoomDBEventCapn_List(s *capnp.Segment, sz int32) (PublicRoomDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 3}, sz)
+	return PublicRoomDBEventCapn_List{l}, err
+}
+
+func (s PublicRoomDBEventCapn_List) At(i int) PublicRoomDBEventCapn {
+	return PublicRoomDBEventCapn{s.List.Struct(i)}
+}
+
+func (s PublicRoomDBEventCapn_List) Set(i int, v PublicRoomDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PublicRoomDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xde4f7ccd46b4df5f, s.List)
+	return str
+}
+
+// PublicRoomDBEventCapn_Promise is a wrapper for a PublicRoomDBEventCapn promised by a client call.
+type PublicRoomDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PublicRoomDBEventCapn_Promise) Struct() (PublicRoomDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PublicRoomDBEventCapn{s}, err
+}
+
+func (p PublicRoomDBEventCapn_Promise) PublicRoomInsert() PublicRoomInsertCapn_Promise {
+	return PublicRoomInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p PublicRoomDBEventCapn_Promise) PublicRoomUpdate() PublicRoomUpdateCapn_Promise {
+	return PublicRoomUpdateCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+type PublicRoomInsertCapn struct{ capnp.Struct }
+
+// PublicRoomInsertCapn_TypeID is the unique identifier for the type PublicRoomInsertCapn.
+const PublicRoomInsertCapn_TypeID = 0xc1ce73427c4e70b2
+
+func NewPublicRoomInsertCapn(s *capnp.Segment) (PublicRoomInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 6})
+	return PublicRoomInsertCapn{st}, err
+}
+
+func NewRootPublicRoomInsertCapn(s *capnp.Segment) (PublicRoomInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 24, PointerCount: 6})
+	return PublicRoomInsertCapn{st}, err
+}
+
+func ReadRootPublicRoomInsertCapn(msg *capnp.Message) (PublicRoomInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return PublicRoomInsertCapn{root.Struct()}, err
+}
+
+func (s PublicRoomInsertCapn) String() string {
+	str, _ := text.Marshal(0xc1ce73427c4e70b2, s.Struct)
+	return str
+}
+
+func (s PublicRoomInsertCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PublicRoomInsertCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomInsertCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PublicRoomInsertCapn) SeqID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s PublicRoomInsertCapn) SetSeqID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s PublicRoomInsertCapn) JoinedMembers() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s PublicRoomInsertCapn) SetJoinedMembers(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s PublicRoomInsertCapn) Aliases() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(1)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s PublicRoomInsertCapn) HasAliases() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) SetAliases(v capnp.TextList) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewAliases sets the aliases field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s PublicRoomInsertCapn) NewAliases(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s PublicRoomInsertCapn) CanonicalAlias() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s PublicRoomInsertCapn) HasCanonicalAlias() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) CanonicalAliasBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomInsertCapn) SetCanonicalAlias(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s PublicRoomInsertCapn) Name() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s PublicRoomInsertCapn) HasName() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) NameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomInsertCapn) SetName(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s PublicRoomInsertCapn) Topic() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s PublicRoomInsertCapn) HasTopic() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) TopicBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomInsertCapn) SetTopic(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s PublicRoomInsertCapn) WorldReadable() bool {
+	return s.Struct.Bit(128)
+}
+
+func (s PublicRoomInsertCapn) SetWorldReadable(v bool) {
+	s.Struct.SetBit(128, v)
+}
+
+func (s PublicRoomInsertCapn) GuestCanJoin() bool {
+	return s.Struct.Bit(129)
+}
+
+func (s PublicRoomInsertCapn) SetGuestCanJoin(v bool) {
+	s.Struct.SetBit(129, v)
+}
+
+func (s PublicRoomInsertCapn) AvatarUrl() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s PublicRoomInsertCapn) HasAvatarUrl() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomInsertCapn) AvatarUrlBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomInsertCapn) SetAvatarUrl(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s PublicRoomInsertCapn) Visibility() bool {
+	return s.Struct.Bit(130)
+}
+
+func (s PublicRoomInsertCapn) SetVisibility(v bool) {
+	s.Struct.SetBit(130, v)
+}
+
+// PublicRoomInsertCapn_List is a list of PublicRoomInsertCapn.
+type PublicRoomInsertCapn_List struct{ capnp.List }
+
+// NewPublicRoomInsertCapn creates a new list of PublicRoomInsertCapn.
+func NewPublicRoomInsertCapn_List(s *capnp.Segment, sz int32) (PublicRoomInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 24, PointerCount: 6}, sz)
+	return PublicRoomInsertCapn_List{l}, err
+}
+
+func (s PublicRoomInsertCapn_List) At(i int) PublicRoomInsertCapn {
+	return PublicRoomInsertCapn{s.List.Struct(i)}
+}
+
+func (s PublicRoomInsertCapn_List) Set(i int, v PublicRoomInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PublicRoomInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xc1ce73427c4e70b2, s.List)
+	return str
+}
+
+// PublicRoomInsertCapn_Promise is a wrapper for a PublicRoomInsertCapn promised by a client call.
+type PublicRoomInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PublicRoomInsertCapn_Promise) Struct() (PublicRoomInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PublicRoomInsertCapn{s}, err
+}
+
+type PublicRoomUpdateCapn struct{ capnp.Struct }
+
+// PublicRoomUpdateCapn_TypeID is the unique identifier for the type PublicRoomUpdateCapn.
+const PublicRoomUpdateCapn_TypeID = 0x8a00d12f0dd477f1
+
+func NewPublicRoomUpdateCapn(s *capnp.Segment) (PublicRoomUpdateCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return PublicRoomUpdateCapn{st}, err
+}
+
+func NewRootPublicRoomUpdateCapn(s *capnp.Segment) (PublicRoomUpdateCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return PublicRoomUpdateCapn{st}, err
+}
+
+func ReadRootPublicRoomUpdateCapn(msg *capnp.Message) (PublicRoomUpdateCapn, error) {
+	root, err := msg.RootPtr()
+	return PublicRoomUpdateCapn{root.Struct()}, err
+}
+
+func (s PublicRoomUpdateCapn) String() string {
+	str, _ := text.Marshal(0x8a00d12f0dd477f1, s.Struct)
+	return str
+}
+
+func (s PublicRoomUpdateCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PublicRoomUpdateCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomUpdateCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomUpdateCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PublicRoomUpdateCapn) AttrName() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PublicRoomUpdateCapn) HasAttrName() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomUpdateCapn) AttrNameBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PublicRoomUpdateCapn) SetAttrName(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PublicRoomUpdateCapn) AttrValue() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return []byte(p.Data()), err
+}
+
+func (s PublicRoomUpdateCapn) HasAttrValue() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PublicRoomUpdateCapn) SetAttrValue(v []byte) error {
+	return s.Struct.SetData(2, v)
+}
+
+// PublicRoomUpdateCapn_List is a list of PublicRoomUpdateCapn.
+type PublicRoomUpdateCapn_List struct{ capnp.List }
+
+// NewPublicRoomUpdateCapn creates a new list of PublicRoomUpdateCapn.
+func NewPublicRoomUpdateCapn_List(s *capnp.Segment, sz int32) (PublicRoomUpdateCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return PublicRoomUpdateCapn_List{l}, err
+}
+
+func (s PublicRoomUpdateCapn_List) At(i int) PublicRoomUpdateCapn {
+	return PublicRoomUpdateCapn{s.List.Struct(i)}
+}
+
+func (s PublicRoomUpdateCapn_List) Set(i int, v PublicRoomUpdateCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PublicRoomUpdateCapn_List) String() string {
+	str, _ := text.MarshalList(0x8a00d12f0dd477f1, s.List)
+	return str
+}
+
+// PublicRoomUpdateCapn_Promise is a wrapper for a PublicRoomUpdateCapn promised by a client call.
+type PublicRoomUpdateCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PublicRoomUpdateCapn_Promise) Struct() (PublicRoomUpdateCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PublicRoomUpdateCapn{s}, err
+}
+
+type PresenceDBEventCapn struct{ capnp.Struct }
+
+// PresenceDBEventCapn_TypeID is the unique identifier for the type PresenceDBEventCapn.
+const PresenceDBEventCapn_TypeID = 0x8e5ba76c8c0e47a6
+
+func NewPresenceDBEventCapn(s *capnp.Segment) (PresenceDBEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return PresenceDBEventCapn{st}, err
+}
+
+func NewRootPresenceDBEventCapn(s *capnp.Segment) (PresenceDBEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return PresenceDBEventCapn{st}, err
+}
+
+func ReadRootPresenceDBEventCapn(msg *capnp.Message) (PresenceDBEventCapn, error) {
+	root, err := msg.RootPtr()
+	return PresenceDBEventCapn{root.Struct()}, err
+}
+
+func (s PresenceDBEventCapn) String() string {
+	str, _ := text.Marshal(0x8e5ba76c8c0e47a6, s.Struct)
+	return str
+}
+
+func (s PresenceDBEventCapn) PresencesInsert() (PresencesInsertCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return PresencesInsertCapn{Struct: p.Struct()}, err
+}
+
+func (s PresenceDBEventCapn) HasPresencesInsert() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PresenceDBEventCapn) SetPresencesInsert(v PresencesInsertCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewPresencesInsert sets the presencesInsert field to a newly
+// allocated PresencesInsertCapn struct, preferring placement in s's segment.
+func (s PresenceDBEventCapn) NewPresencesInsert() (PresencesInsertCapn, error) {
+	ss, err := NewPresencesInsertCapn(s.Struct.Segment())
+	if err != nil {
+		return PresencesInsertCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// PresenceDBEventCapn_List is a list of PresenceDBEventCapn.
+type PresenceDBEventCapn_List struct{ capnp.List }
+
+// NewPresenceDBEventCapn creates a new list of PresenceDBEventCapn.
+func NewPresenceDBEventCapn_List(s *capnp.Segment, sz int32) (PresenceDBEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return PresenceDBEventCapn_List{l}, err
+}
+
+func (s PresenceDBEventCapn_List) At(i int) PresenceDBEventCapn {
+	return PresenceDBEventCapn{s.List.Struct(i)}
+}
+
+func (s PresenceDBEventCapn_List) Set(i int, v PresenceDBEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PresenceDBEventCapn_List) String() string {
+	str, _ := text.MarshalList(0x8e5ba76c8c0e47a6, s.List)
+	return str
+}
+
+// PresenceDBEventCapn_Promise is a wrapper for a PresenceDBEventCapn promised by a client call.
+type PresenceDBEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PresenceDBEventCapn_Promise) Struct() (PresenceDBEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PresenceDBEventCapn{s}, err
+}
+
+func (p PresenceDBEventCapn_Promise) PresencesInsert() PresencesInsertCapn_Promise {
+	return PresencesInsertCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type PresencesInsertCapn struct{ capnp.Struct }
+
+// PresencesInsertCapn_TypeID is the unique identifier for the type PresencesInsertCapn.
+const PresencesInsertCapn_TypeID = 0xa16a03a6d3c23073
+
+func NewPresencesInsertCapn(s *capnp.Segment) (PresencesInsertCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return PresencesInsertCapn{st}, err
+}
+
+func NewRootPresencesInsertCapn(s *capnp.Segment) (PresencesInsertCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return PresencesInsertCapn{st}, err
+}
+
+func ReadRootPresencesInsertCapn(msg *capnp.Message) (PresencesInsertCapn, error) {
+	root, err := msg.RootPtr()
+	return PresencesInsertCapn{root.Struct()}, err
+}
+
+func (s PresencesInsertCapn) String() string {
+	str, _ := text.Marshal(0xa16a03a6d3c23073, s.Struct)
+	return str
+}
+
+func (s PresencesInsertCapn) UserID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s PresencesInsertCapn) HasUserID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s PresencesInsertCapn) UserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s PresencesInsertCapn) SetUserID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s PresencesInsertCapn) Status() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s PresencesInsertCapn) HasStatus() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s PresencesInsertCapn) StatusBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s PresencesInsertCapn) SetStatus(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s PresencesInsertCapn) StatusMsg() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s PresencesInsertCapn) HasStatusMsg() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s PresencesInsertCapn) StatusMsgBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s PresencesInsertCapn) SetStatusMsg(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s PresencesInsertCapn) ExtStatusMsg() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s PresencesInsertCapn) HasExtStatusMsg() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s PresencesInsertCapn) ExtStatusMsgBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s PresencesInsertCapn) SetExtStatusMsg(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// PresencesInsertCapn_List is a list of PresencesInsertCapn.
+type PresencesInsertCapn_List struct{ capnp.List }
+
+// NewPresencesInsertCapn creates a new list of PresencesInsertCapn.
+func NewPresencesInsertCapn_List(s *capnp.Segment, sz int32) (PresencesInsertCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return PresencesInsertCapn_List{l}, err
+}
+
+func (s PresencesInsertCapn_List) At(i int) PresencesInsertCapn {
+	return PresencesInsertCapn{s.List.Struct(i)}
+}
+
+func (s PresencesInsertCapn_List) Set(i int, v PresencesInsertCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s PresencesInsertCapn_List) String() string {
+	str, _ := text.MarshalList(0xa16a03a6d3c23073, s.List)
+	return str
+}
+
+// PresencesInsertCapn_Promise is a wrapper for a PresencesInsertCapn promised by a client call.
+type PresencesInsertCapn_Promise struct{ *capnp.Pipeline }
+
+func (p PresencesInsertCapn_Promise) Struct() (PresencesInsertCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return PresencesInsertCapn{s}, err
+}
+
+const schema_c7d28e5a19f3b460 = "x\xda\xc4|{t\x14U\xb6\xf7\xd9U\x09\x8dHH" +
+	"z:\xa8\xf0\xa9Q\x04?\xe5\x13\x85\x04F\x88\x8fH" +
+	"\x13\x90\x04\x02\xa9\xeeD%#\xdfP\xe9\xae$\x15\xba" +
+	"\xab;]\x95`\xfb\xe9'08#\xf8\xc4\xd1{\xd5" +
+	"\x91QfT\xd4\xf1\x05\xa2\x03.u`F\xae\xe2k" +
+	"\x84\x91\xa5\xb8\xf05\xa3\xcbq\xae\x8c:\xca,\xf4\xaa" +
+	"}\xd7>U\xe7\xd4\xa9\xea\xea$x\xefZ\xf7?\xb2" +
+	"{s\x1e\xfb\xec\xb3\x9f\xbfSS\x7f3\xfe\x02iZ" +
+	"y\xcd8B\xe2\x13\xa1|D\xe1\x9b\x8b\xfb6\xfdf" +
+	"\xea\xb6\x95$\\\x01\x85e[\xbf\x18\xd7q\xc3\xde\xe7" +
+	"IyY\x88\x90H\xff\xe8\xbd\x04\"\xf9\xd1\x1f\x11(" +
+	"L\x9b8\xfd\xca\x1d\xff1{5Q*@d\x84\x10" +
+	"!uK*&\x03\x81\x88Z\xb1\x82@\xe1\xc3\xbbf" +
+	"-\xdazwo\x11g\x089wV\x8c\x07\x02u\xbb" +
+	"+\x9e\x07\x02\x85\xe7\xf4\x97\xf6\xfd\xe8/\xe95~V" +
+	"\x09Y7TJ8\xe8\xc6\xcaG\x09\x14f\x1f\xb3\xe3" +
+	"\xea\xc6\x8d\xf9k\x90\xb3\\\xe0\x1c\x89\xeb\x9c]\xf57" +
+	"\x02us\xab\xde\x95\x08\x14\xc6\xbc\xbb\xe5\xaeco{" +
+	"\xef\x1a\xdf\x86\xe8\x90K#tH5\xb2\x82\xc0\xef>" +
+	"_\xf1z\xc5Y{\xd6y\xd9d\xba\xc8\xc8\x0f\x90m" +
+	"w\x04g\xfe\xdf\xcf\xee\x9cs\xe2\x93\x7f\xbc\x16g\x96" +
+	"\xfc\x12\xd2\xab\xbf&P\x97\xae\xbe\x18w\xf3\xcamk" +
+	"{N\xc9\xdct]\xa0\x88v\x8d\xed\xc01\xf7\x8cE" +
+	"\x11\xddw\xe1\x98\xebR\x9b~t\x83o\x8d\x94q\xca" +
+	"1\xa3\x90q\xc61\x0d\x04\x0a/\x85vL\xd5\xbe\xdb" +
+	"tc\xd0\xe9,9\xe6k\x02\x91\xa5\xc7\xe0\xe9<\xfe" +
+	"\xc1\x97\x7f\xb8\xf0\xe2\xf3\xd6\xfb\xa7F\xc6\xbai\xc7N" +
+	"@\x99\xcf:\xb6\x06W9\xf9\xc5e\xbf\xbe\xfb\xc0\x1f" +
+	"n\xf6\xb3\xcat\xcc\xe3\xde\xc21\x8f\xc31\xe3\xbdk" +
+	"\xe6\x8dz\xf2\x9f\xb7\"c\x99\x7f\x95\xb3\xc6\xe1*\xeb" +
+	"f\x8f\xb3\xc7\xac3\x17\xe6\xf6_\xff/>\x19\xd1\xd9" +
+	"\xdb\xc7\xd3\xfd,\x1d\x8f\x1b\xff\xe8\xb5=\x03w\xaf\xdf" +
+	"v\x87\x7fLz:\xdb\xc7\xd7\xe3\x98;\xc7Si\x8e" +
+	"\xfa\xd1\xf9\xb0\xe9\xa0\xf1\x8b\xa0u\xd6\x8d=\x9e\x0ez" +
+	"\xc2\xf1\xb8\xd0\xd6\x05_,\xbb\xe8\xad\x07\xef\xf4\x9fP" +
+	"9\xee\xe8\xd0\xf1\xff P\xf7\xd5\xf17\xe2\x98/\x9e" +
+	"\xf7\xd4>+\xfd\xb7_\xfa\xb5\x88n\xe9\xf6\x13\xe9\x96" +
+	"6\x9eH\xa7\xbf\xf6&\xe9\xc7\x1f?\x0e\x1b\xfd\x83\xd2" +
+	"\x95V\x9c\x84Z\x1c\x19{\x12NoN\xfd\xfd\x9f\xee" +
+	"\x93{7\x06\x9cQ\xdd\xc1\x93\xe8:\x0fQ\xc6\xc5\xbf" +
+	"y\xe7\xb8_\xbf=\xeb\x9e@m\xdfs2\x1eRd" +
+	"\xff\xc9\xa8s+\xc6\xecU\xfb\xfe\xfe\x87\xfb\x039\xfb" +
+	"&\xd4\"g~\x02r\xce:}\xef\xfc\xe9\xabN\x7f" +
+	"\x80\x04\xa8q\xf8\x14\x94gd\xdc)\x8f\x12\xf8t\xff" +
+	"\x83cg\xee\xbb\xebA\xdfn\xe8\xc6\x9f9\x85\x0e\xb8" +
+	"\x0b\xf9\x0a\xfb\xf7?\xf7\xc0%\xdbw=\x140`\xa4" +
+	"\x7f\xe2?p\xe2\x89\xc8\xf7o\xe3\xd7\xdd7o`\xda" +
+	"#A\x9a9n\x12j\xd1\x09\x93p\xd3\x0d_\xafi" +
+	"\xbdrg\xf4\x91\xc0c\xfcj\x12]!\x9c\x8a\x9cW" +
+	"\x9dz\xce\xce\xf1\xe36=\xe2\xd7\x0d*\xc8\xfd\xa7\xd2" +
+	"\xc3y\xffTj7^\x18\xf9\xcbUo_w\xdcc" +
+	"A\x8b|\xe64\\\xe4\xce\xd3p\x91\xeb\xef\xa8y\xad" +
+	"'\xf5\xc3\xcd>\xbe1T\xd5OG\xa3\xb1\xf4\xf4c" +
+	"e\x02\x05\xaduQ\xcf\x89\x7fj\xda\x1ct\x86\x87\xce" +
+	"\xa0\x87\xfd\xed\x19\xb8\xc8\x83\xb7\xee|\xf6\xec\x8f\xc6n" +
+	"\x09\xbc\xe3{\xa6\xd0\xd3\xde?\x05U}\xe9\xb8\xba?" +
+	"n:u\xfa\x16\xdf\x90h\x05#3\xce\xc4\xb9g\x9d" +
+	"I\x95\xf2\xdak>|c\xe5\xb3\xc9\xad\x81C~|" +
+	"\x16\x9d\xfc\xf3\xb3p\xc89\x8f-\xfc\xfd\x13\xef\x8cy" +
+	"2\xf0\x96\xb7L\xa5\x96\xb5}*\xbd\x91V\xfe\xb1\xc3" +
+	"\xd7\xac=\xf8\xa4o\xf6*\x9c]\x9b\x86\xb3\xeb\xd3~" +
+	"\x86;?0\xaf\xa6\xf3\xb3/>z\xda/uz>" +
+	"\xe9\x19Q\x1c\xb3\x7f\x06]\xe87\xff\xef\xef\x93\xbfx" +
+	"V\xfa]\x90e=\xeal\xba\xf5\xf0\xd9\xb8\xce\xc5\x87" +
+	"\xaf\xad\x9fp\xfeV?c9\xd5\xde\xb3\xe9%\xcf\x9f" +
+	"M\x97\xb9c\xf2\xffz\xa9\xfe\xc9\x97w\xfag?\x8a" +
+	":\x80\x99\xf4\xcc\xef\x9dI\xed\xfa\x96\xec\xa2+\xa2\xe6" +
+	"\xab\x94U\x16XGP\x8b}\xce\x0f\xa8[9'\x83" +
+	"\xac?\xf9\xe9_\x7f\xa8M{}\xa7o~\xf4\x15u" +
+	"\xfa\xf9\x12r\xa6\xcf\xa7\x8a\xb46\xb7\xb5v\xe9\x1b\xf7" +
+	"=\xe7\x97\xe8(\x94S\xfb\x05h:\x96\\@\xc7\xfc" +
+	"\xd3\xc9\x93^\xec\xe8z\xf1\xb9\xc0+\xf9q\x94^\xde" +
+	"\xcf\xa3\x8f\x12\xf8v\x7fe\xeb+W<\xba+@\x93" +
+	"\xd6\xcf\xa1B\xba}\x0ej\xd2@\xf5\x8a?\x9f{\xcf" +
+	"\xf2\xddA\xd2\xcc7\xd2S_\xd5\x88\xd2\xfc\xf8\x8bQ" +
+	"w\xd5\xcd\x98\xfb\xb2\x7ff\xba\xf1\xb7\x1b\xe9v\xdeo" +
+	"\xa4'\xb4w\xec\x8f\x1f\xbe\xf1\xe6o^\xf5\xcb\x88\x0e" +
+	"\xda?\x8fJ\xfe\xcayT\xf2\xdb\xf7*\xdb\xa4\xdf^" +
+	"\xfc\x9a\xdf\xbe\xd1s\xbf\xf5B*\xf9\x0d\x17R\xd6\x19" +
+	"\x8b\xae~|\xc5Y\x8f\xec\x0b\xf4\x18O\xccG/\xb4" +
+	"}>n\xe9\xf0\xabm7\xdc\xf5\xdb\xfa7H\x90\x95" +
+	"\xb9\xbd\xa9\x17\xf7to\x13^\xcc\x13\xfe\xf5\xadW\x7f" +
+	"\xf7\x95\xf1f\x90\x03\x9c\xddL\x8dBS3:\xc0\xed" +
+	"\xd3\xb6\xddp\xc2\xf3p\xc0\xc7x4u\xbe\xcd\x7f\xc6" +
+	"\x93l\xa6\xe7\xf3\xc19\xbf\xac\xfe\xf5K\xe1\x03A#" +
+	"\x9e\xdcB\xdd\xfe\xa4\x96\x06\x02\x9f\x86`\xc9\x863\x0f" +
+	"\x1c\xf0I\x88n{n\x0bU\xf7\x96\x16\xea\x01~\xfc" +
+	"\xee\xd6y\xaf\\\xb1\xf8\x9d@\xcb\xb5q\x11=\xa1\x07" +
+	"\x16\xe1\xbe\xa3\xdf=\xff\xcf'&\xfe\xe3\x9d s\xb4" +
+	"a1\x9a\xa3\x8d\x8bi(\xb1\xe1\x8b\xef~>\xa3\xef" +
+	"\xbd@\x1d:\xaf\x95*\xc7\xdcV\xe4|\xea\xe1\xff;" +
+	"\xfb\xd1\xfb7}\x14\xa4\x1c\x1f\xb7\xd2\xdd\x1clE\xe5" +
+	"\xf8\xc5\x13#\xe7\xb7M\xd9\xfe\xb7@\xe5hR0," +
+	"\xabS\x14\xaa\x1c\xff\xae\xde\xb1\xe6\x99\xf2Q\x9f\x04\x19" +
+	"\xa4\x0fco\x11\xa8\xfb8F\x19'^\xb8\xe6\xb3\xbf" +
+	"\xfc~\xc3\xdf\x03\xcd\xcc\xaa6t\x16uk\xdb\xa8j" +
+	"\xc4\x7f\x1a\x9b8\xea\xffO\xfa4@\xea\x91\x8d\xed\xb8" +
+	"\xf3{\xdb\xf1\x18\x13?\xb8\xfc\xc9\xba\xe9\x1b?\x0d\xda" +
+	"yd\x17e\xdc\xdd\x8e\x1bozg\xef\x89o\xc0\xf3" +
+	"_\x06\xb9\x15\xfd\"T\xb5\xf4E(\xf2\x9f\xecz\xf3" +
+	"\x95\x8d\x9d\xcd\x87\x82D\xae\\\x8c|\xed\x17\xe3x[" +
+	"\xf4s\xff\xcf\x9a\x9f\xd5~\x1d\xc4w\x90\xf2}N\xf9" +
+	"&]\xf2\xdb\xc3\xd7\xde\xb8\xe6\xeb \x07\xb0\xfe\x92(" +
+	"\xbd\xb6\x97\xe0\xc4\x99\xbb\x1ey\xf7\xa1\x8a\xad\xdf\x06\xea" +
+	"x\xdf\x12\x1a8\xe6\x97<J\xe0]\xf3\xe7[W^" +
+	"\x14\xfb\xae\xf8\x04#\xe1\x0e\xdc\xf0\xd8\x0e<@c\xd9" +
+	"\x9d\x9f=}\xcc\x1d\x05\xbf\x05\xa4\x81m_\xc7\x8bh" +
+	"~;6I\x04\x0a\xcb\xef||\xd4\xb1\xff\xdc[\x08" +
+	"r\xf5K\x96\xd2;\xa3.\xc5\xbd$;\xb5\x01\xcd\xb0" +
+	"\xce\x94\x12j\xd6\xa8\x9f\x9dj2L-g\xcdQ\xb3" +
+	"`(Ur\x19!e@HXm&DY&\x83" +
+	"\x92\x92 \x0cP\x0dH\xd4\xeb\x09Q\x922(Y\x09" +
+	"\xc2\x92T\x0d\x12!\xe1t\x8c\x10%%\x83r\x99\x04" +
+	"aY\xae\x06\x99\x90p\x7f\x07!\x8a%\x83\xb2R\x82" +
+	"BR\x1b\xd0\x13ZS#!\x04F\x13\x09F\x13h" +
+	"\xe87\xb5\\S#\xfb\xb3\xa0\xa6\xba39\xdd\xea!" +
+	"\x90\xe64=\xa9\x19\x96\xde\xa5\x13Y\xcbq\"[\xbd" +
+	"LW\x1f\xcf\x1b\x89\x05Z>n\xe545mo\xa4" +
+	"r\x8e\x9a5\x94\x91|#\xa7\x8f'D\x99(\x832" +
+	"U\x02\xb6\x8f)9B\x943dPfJ \xebI" +
+	"('\x12\x94\xa3\x1e\xf6\xa8F\xb7\x96l'5\xde\xd5" +
+	"y'\x9d\xa7\xa6R\x9djb\xf9\x02-\xcfd'g" +
+	"\x0d\xe5$>\xe5\x1e\x94\xddk2(\x07\x04\xd9\xedG" +
+	"\xd9\xbd.\x83\xf2\x9e \xbb\xb7k\x09Q\xde\x94A\xf9" +
+	"@\x90\xdd\xfbQB\x94\x032(\x7f\x95 \\VV" +
+	"\x0de\x84\x84?D)\x7f \x83\xf2\x99\x04\xe1\xf2\xf2" +
+	"j('$|\x10\x89\x9f\xc8\xa0\x1c\x96 <bD" +
+	"5\x8c $|\x08E\xff\xa5\x0c1\x90\x00B\xd5\xa8" +
+	"y\xe1o'\x13\xa2\x1c\x96!^\x06\xc3:\x8f\x9a\xe5" +
+	"Z\xde\xfd\xeb*\xfc\xcb\xe8\xca\x0czZ\xa6\xdem\xa8" +
+	"V\x7f\x8e\x806\xe8\x09V\xf6\x9bZ\x12\x80H\x00E" +
+	"\x92m\xa4\x0bk\xcf&UKk3\xf1 \x892\x9a" +
+	"\x8bu.\x8a\xb5Q\x06\xa5U\x10k\x0b\x8au\xbe\x0c" +
+	"J\x9b\x04\xe0HU\xe9%Di\x95A\xb9tx\xca" +
+	"\x97RMkv\xc2\xd2I\xe5\x80\xd6frm\xf0^" +
+	"\x94\xb9\xf8oQ\xc5\xa6\xb3\x85E\x96B\x94\x90\xf8%" +
+	" C<\x09\\\xcb\"*\xc4\x08\x89/Cr\x0a\xf0" +
+	"\xd0\x81./\xa2C\x8e\x90x\x0f\xd2-\xa4\xcb\x12=" +
+	"\xf7H\x1f\x1d&\x85\xf4\xcb\x90^&\xd3\xa3\x8f\xf4C" +
+	"=!\xf1,\xd2\xaf@zy\x19=\xfdH\x9e\x8es" +
+	"\x19\xd2oA\xfa\x08\xa0\x0a\x10Y\x0f\xb5\x84\xc4\xafC" +
+	"\xfamH\x0fIT\x09\"\xb7B3!\xf1[\x90~" +
+	"7\xd2G\xca\xd50\x12}\x10\xf4\x12\x12\xbf\x13\xe9\xf7" +
+	"#\xfd\xa8\xf2j8\x8a\x90\xc8\xbd\xd0AH\xfc\x1e\xa4" +
+	"?\x86\xf4Q#\xaaa\x14!\x91\x87a\x02!\xf1\xfb" +
+	"\x91\xbe\x15\xe9G\x97U\xc3\xd1\x84D6\xd3u>\x84" +
+	"\xf4mH\x1f\x1d\xaa\x86\xd1\x18\x03P\xfacH\x7f\x1a" +
+	"$\xb8*\x97\xc9\xa4\x17\x09\xb7\x8eJ\xb9-\x9f\x155" +
+	"\x87\xd2\xe2\x96Jj,m\x81\x96\xe7\x9aH\xe9MI" +
+	"~\x929\xad+\xde\xa3B\x05\x91\xa0\x02\x15\xb3\xdf\xea" +
+	"\xc1\x83\"5\xd6\"=i\xc2\x18\x02\xad2\xd0\x99\xc6" +
+	"\x10\xa8IjY\xab\xc7;\xef\"=I\x08\xe14\xd3" +
+	"R--n\xa8\xa42+.1\xa7u\xd1\xf3'\xb2" +
+	";w\xc8t'n\xc8tu\x99\x9a\xc5\xf8\x1b\x92\x99" +
+	"\xb4\xaa\x1b\xa5\x8c\x96fY\xba\xd1mr\xc3k\x10\xd1" +
+	"^\xe1\xf5=M\x06e\xba\xa0\xe5\xd3&\xb8\x06\xab`" +
+	"\xda\xff\x7f\x01\x91]\xc1\x84\x06\xd4T\x89\xe9Z\xfb;" +
+	"Sz\"\x96\xc9\xa4\xed\x8be[z\xe1Z\xe1\x0d\xba" +
+	"@\x06e\xa10aS\xb3{\xad\xb8\xb5Rb\xee\xbd" +
+	"j\xc0S\x14\x0d\xb8e\xe5\x16\xa9iM\xb8f\x94v" +
+	"\x91\x9a\xea'\xa0\xf1\x13\xf2^\xaa\xd6~\xb3'\xd6\x9f" +
+	"\xd2\x9a\x0c\xad\xc1\x16\x85r\x1c_\xd8\xed\xb8\xb0[d" +
+	"P\xee\x16\x16\xb6\x01\x89\xb7\xc9\xa0\xdc\xe3\xde\xf7\x8dh" +
+	"\xce\xef\x96Ay\x08o\x13\xd8V\xf4\x01\xdc\xc1\xfd2" +
+	"([\xf1*I\xb6\x15\xdd\x8c\xc2}L\x06\xe5i\xbc" +
+	"G\xb2mE\xb7\xa3\xbd\xdd*\x83\xb2C\xf2\x9b\x86\x86" +
+	"\x1c.\xce\xdde6\xa7\xa3\xe1\xcb\x93\x9a9)\xd5t" +
+	"M\x05\xa7\x0b\xaa\x94\xc8\x18I\xdd\xd23D6L\xb6" +
+	"\xfd\xab\xd4\x84\xa5g\x0c\xb3H\x1ce\xdc\x9d-\xee\xb7" +
+	"\xb2\xfdV\x8bn\x88N\xcd\xf60C8\xb5zWq" +
+	"\x04\xa7\xe6?(\x9fr\xe44S3\x12Zc\x94\xea" +
+	"7UF\xa2\x94\xf1\x89*V\x134\xc1\xa0\x1c'\xe1" +
+	".mfpT\x97@\x95[\xde \x00UE\xe7\x8b" +
+	"Z\xd7\xa6v#;;_!\xc4\xc0\x05_*\x83\xd2" +
+	"#\x9c\xafV/\xc4\x1dL\xf1\xf4\x09B\xdc\xc1\xdcd" +
+	"\x1a\x8f\xadG\x06\xc5*\xd2F\xdf)\x86,\xb5\x9b[" +
+	"\x90D\xc6\xb04\xc3*:\x017\xa0\x88[I\xc1\xd8" +
+	"\xcf\x09\xe1\xaa\xab\xf9\xaa\xafD\xd1_&\x83\xb2\xc6\x15" +
+	"\xfd*\xd4\xb5\x952(\xd7\xb9f>\xbc\x16o\xcb5" +
+	"2(\xb7\xb86>\xbc\x1e]\xd3M2(w\xba\x06" +
+	">|{\x87\xab\xd3b8b:\x0b!\x04%\xcd+" +
+	"s\x8e\xa4-5\xd7\xadY\xedM\x04\xdc\xc3\xb5i\x8d" +
+	"\x1a\xa9D\xa77\xbcH\xca>\xa9\xb8\x95\x9c\x9fI%" +
+	"\xb5\x9c\xadj\xc1\x81`p\x1c\xe8\xec7\xdd,\xc6\x81" +
+	"\xce~\xfb1\xc0\xc9\xca\xa0\\\x81\x86\xcb\xd6\xe8F\xe1" +
+	"\x964\x98\x9a\x91\x14\x16\xc5}\x81kFj\xb4\x81\xd2" +
+	"\xe7\x85\x0aFe\xc4m\x1b\xea\xafpZQ\xf7\xb4\xc2" +
+	"\xc1\xc7\xe5\xe8\xd8Z\\\xe9\x1a\x19\x94\x9b\x04\x1d\xbb\xbe" +
+	"\xde=Cp\"\xb1\xf5H\xbbN\x06\xe5\xb6R\xbe\xcc" +
+	"\xebS\xbc^\xe7\x88<\x05\xee\xae\x11\xff\xb7gw\x82" +
+	"\x19\x88\xbaf\x80\xefnJ\xadk\x07\xfc\xeb\xf3y@" +
+	"\xeflT\x8e-Z\xbaS\xcb\x99=z\x16\xe7l\xb0" +
+	"\xb4A\x8cr\x90M\xe6\xea\xb0\x11\x89w\xca\xa0\xdc/" +
+	"\x18\xe5{Q\xd3\xef\x91AyL0\xca\x0f\xe3q<" +
+	"$\x83\xb2M0\xcaOD]K\xcdn7\x93\x99\xad" +
+	"\xe5\xfc\xb2\xfbT(\xed\xec\x80\xc8z\xd6w.\x1e\xd5" +
+	"\xbbj@\xcb\x99z\xc6(!\x8d\x05Z>\xaa&\x96" +
+	"\xf7g\x1b\xb5\x94\xc6e\x1fl\xbd\xb8\xf1\x8a\x8a\xc6\xcb" +
+	"\x11\x84\xe7\xb2\xb0{!&M>k\xe5_\x97\xdf\x80" +
+	"\x9b\x9a\x89?{\xee\xbd\xf7.\xdba45_5\xb6" +
+	"\xd1=\x9e/\xfb\x89f\xd7\xd9q\x95y\xa6\x93\x10\xe5" +
+	"i\x19\x94\x17\x84\x0b\xb1\x0b\xd7\xbdC\x06\xe5e\x09\xc0" +
+	"\xb9\x0f\xbbq\xd9/\xc8\xa0\xbc.\x98\xaf=\x1dB\xba" +
+	"\xe3\x04\xa7\xe1\xfd\x1dBf\xe3D\xa6\xe1\xf7\xd1\xfa\xbd" +
+	"'\x83\xf2Ip`^H\xeaf6\xa5\xe6\x17\x91\x90" +
+	"\x9a\xd6J\x85\xeb\x89\x9c\xa6ZZ\xb2\x8d\x80\x10\xab\xd3" +
+	"\xc1\xda\xf2D\xce\x0ea\xf6\x86\x08\xf6\x85\xab\xe0\x09\x99" +
+	"\xf0\xf0\x85[\xb0\xda\xd1\xf8\xad\xee\xe1o^'H\x96" +
+	"\x1d\xfe3\xbd\x82d\xd9\xe1\xef\x8a\xba\x92\xe5R\xdc\x8d" +
+	"\xc4\xe7dP^\x13\xa4\xf8J\xad+\xefBJ\xb54" +
+	"\xd3\x9a;\x00\xb6\x891\x89/\xbc\xa5[\xc3\x95\x83\x15" +
+	"gVh\x88\xc0\xd6o\x1f\xfc\xba7\xa8\xbd@_\x19" +
+	"\xd3\x12\x9a\x9e\xb5\xc4(E\xb8\"\xe3\x83\x1c|\xcc\xbd" +
+	"\x0e,\x80K\xd7\xbb\xae\x9c\xdb\x8a\xbe\xa8{Ed\x8f" +
+	"\x89\xb5\x16\xa3\x0d%`\x95\xb8\"\xdc\xc7\x0f\x1e\xf3\x88" +
+	"\x018\xf9~qIL\\\xb7sG\xfaz]\x97\xe7" +
+	"\x0f'\xf1\x14\xfaM\xf7*\xd3?[L\x02<>)" +
+	"h\x97a\xdec\xf5\x93J\xb3\xc5\xec\x1e\xa4\xee\xc1\xb6" +
+	"!\x86)BT?\xde\x8d\xeayP_/$\xd0L" +
+	"C[\xa2n\xa4/\x86\x8d>\xa34\x84H\x9d8~" +
+	"\xae\xa1v\xa6<\x96g\xa8<CX\x12H\xc5+\x1a" +
+	"<\x1e\xbfJ\xa3\xf3%\x07Q\xd0\x16\x95\xc7sh\xc8" +
+	"\x99C\x13\x96\xd5\x11TU\xc0\x83](\x83r\x89p" +
+	"\xda\xedx\xb0m2(\xcb\xa4\x12\xb6\xe5\x08B\xb2\x00" +
+	"{\x13w\xaeh\xb6\x84\xfcz\x83\x16\x1a\x0d(\x7f\\" +
+	"\xee\xa4i)i\xb8\xf7\xdef\x8b\xa62\xa4!\xb1<" +
+	" s\xf69\x98\x16\x15\xabn\xd41\xd6\x14\xc9s\xa8" +
+	"*\x8d\x9bNv\x1cY\x99f\x18Q,[\x956X" +
+	"\x14;D9\xb36\xe8N\xc7\x840\xf6H\xcbg\x81" +
+	"\xe5\xb2bMm7\xb5\x9c`N\xffK\xf9R\xd4\xdd" +
+	"\x18\xc8CF\x1cC\x99\xcf\x00\x8b[*X\xf2\x98T" +
+	"\xa1\"\x8a\xab|Y\x06\xe5M\xd7\x1a\xed\x8b\x0aa\x03" +
+	"\xb3F\x9e*)\xf3\x97o\xc7\xc4\x82(8\x05\xd1\x9b" +
+	"\x09Q\xfe*\x83\xf2%\xfaK\xc9\xf6\x97\x9f\xa3\xea\x7f" +
+	"&\x83\xf2\x0dF\x1d;\xec\xa8\xe3\xab\x0e\xa1\xf8\x19\x0e" +
+	"\xc9v5\x0c\xa0\x93\x90\x18\xc8\x10\x1f\x0d\xff-1X" +
+	"\x97\x9e3\xad\x16\xcd\x04S\xed\xd6\x9a\x8c\xa4\x06\x97q" +
+	"iuer+\xd4\\R#\x0d\xc99\x99~\xc3\x15" +
+	"\xa3n^\xa4\xe5l\x9dv\x8b\xa2\xce\xe8\x8d$\xa4Z" +
+	"j\x09MG\x8b\xab\xe5J\\\xc1@K[+\x1a\xff" +
+	"\xa1Mm\x8d\x9a\xcd\x0a\x02\xc9\xf6\x9b=B!\xce\x9f" +
+	"=\xe6\x8d\x84SB\xb0\x8b\xa33yq4\x0f\xabY" +
+	"\x95r\x0d\xb8\xeb\x89\xac\x82_\x11\x12_\x83\xf4\x9b\xc0" +
+	"]R\xe4zx\x90\x90\xf8MH\xbf\x13\xdc+\x18\xb9" +
+	"\x1d~\xe5\xa9R:\x85\xf1\xc8\xbdp\xb3\xa7\x1a\xe9\xd4" +
+	"\xc6#\x9b\xe1\x0eB\xe2[\x91\xbe\x03\xdc\xf2x\xe4\x19" +
+	"J\xdf\x81\xf4\x97\xa9>\xd8%\xf2\xc8nJ\x7f\x19\xe9" +
+	"o\xd2\xea\xe8H\xbb:\xba\x0f\xb6\x10\x12\x7f\x13\xe9\x1f" +
+	"\xd0\xea\xe8Qvu\xf4}J\xff\x00\xe9\x9f\xd1\xea\xe8" +
+	"(\xbb:z\x10\x9e\"$\xfe\x19\xd2\xbf\xa1\xd5\xd1\xa3" +
+	"\xed\xea\xe8W\xb0\x97\x90\x98\x84\xfa&I\x10\x1e=\xda" +
+	".\x8e\x1e%\xa1xF\"\xbd\x1a\xe9\x15\x15\xd5PA" +
+	"H$,\xe10\xd5H?IB;\x9e7\x12vl" +
+	"\xe7\xd6^x{\xdc\xa9\x08 \x0f\x06\xad@=I{" +
+	"6\x19R-\x0d\xaa\xdc.\xa0\xc07'\xa5k`X" +
+	"\x8d\xaa\xa56\x19\x958\"T\xb9\xad=\x81\x11\xdb+" +
+	"\xc0JQ!\x9b\x8f\xa3\xbd\xc4\x89\xb5\x84\x06,\x14\x04" +
+	"\xe4\xe2\x18\x19\x81\x0b}1\xb0\xe2\x8aL\x07\xe3\xe0\xa4" +
+	" 6Tp\x99n\x82w\xd8\x056\x0c\x81\x80\xc5@" +
+	"\xf6h\x1cE#\xb0\xa1e\x05fZk\x0cg\xb3\x1c" +
+	"z\"pb\xb4\x00,\\\xa8\xa1\x9e\x04\xaa\\\x14\x8d" +
+	"\x7f\xcc6=\xad-\xd4\x0d\xad\xa1\x89\x0d\xca\xf1\x12\x02" +
+	"+V\xf4$\x7fI\x8fJ\x88C\xc2\x04n\xfb\x90\xed" +
+	"\xbc\x03\x0f\x99w\xe2K\xf0\xccip\xacu\x95\xdb\xa5" +
+	"\xf4\xd5\xe2l\x1b=;\x91\xc8\xf4\xb3\x13\x0f\x0c\xd7\x87" +
+	"\xef_&\x0fU\x8f\x1b\xd4\xc1TZ\xf9\xacV\xd2\xdb" +
+	"\x94\xc8\xc4<\x9e%\xb8\x04\x12T\x09\xf5G:\xf6R" +
+	"\x92\x83XU\x8f\x1d\x13\x12\xe7\xde\xa0\xc4\xf9f!\x93" +
+	"c\xd2\xd9\xbd\xc5uv\\:\xfbz\x05\xc7\xc6\x9az" +
+	"o\xe7\x04\xc7\xc6\x9az\x1f^.t\xfaXS\xef\xe0" +
+	"\x83\xaec+d\x1d\xdbO*\x1d\x05\xe5\x80'\xe7\xe0" +
+	"\x19\x03\xe0\xef\xd1\xfc\x02\x0d\xf2P\xe5BJJq\xd5" +
+	"\xe4\x17\x1b)\xe4\xe4\xf8\x0b\x0fg\x93A\x98\xa1\xe0\xc0" +
+	"\x18\x81\x01\xe3\x7fR\xd3d\xd8\x1c\x1c\x17\xe9\xe7hh" +
+	"d\xd7\x8a\xa3\x18|,\xc0\xb2\x086\x1b\xc7\xbb\x05\xea" +
+	"\xb5S\xab\x1d\xd0-\x8de\xeb\xbeb\xf9\x91\xaa\x88\xb7" +
+	"\xbeTz:\xf1\x1a\x05\x97\x1cy\xc5\x11iW\xc8\xa0" +
+	"\\#\xc4:W\xd7\x0beH\x16\xeb\xac\xad\x17\xca\x90" +
+	"\xac6p}\xd4-C\x16\xa5\xec\xfe\x96\xd8\xa0\xd5\xb1" +
+	"\x92\xe5o\xb7^\xef\xd1\xff\x0b\xb8\x1f\xdf\x0c\xab\xc5." +
+	"\x1e\xf7\xe3\xdb\xa1\x93\x90\xf86\xa4?'\xfa\xf1\x9d\xb0" +
+	"\xda\xe3g\x99\x1f\xdfM\xe9/ \xfdu\xd1\x8f\xef\xa1" +
+	"~\xffu\xa4\xbf'\xfa\xf1\xb7\xa9\x7f\x7f\x0f\xe9\x9f\x88" +
+	"~\xfccJ\xff\x04\xe9\x87E?~\x88\xfa\xe5\xc3H" +
+	"/\x93\x04?\x0e\x12\xd2\xcb\xd0\xa1VI\x82\x1f\xaf\x90" +
+	"\xfe,:Z\xee\xc7O\x90\xb0+z<\xd2O\x93\x04" +
+	"?>\x89\xd2'\"}\xaa\xe8\xc8\xa7H\xeb\x08\x89O" +
+	"E\xfa\xb9\xa2#\x9fE\x1d\xfcL\xa47\"}\xcc\x98" +
+	"j\x18\x83\xa8dJ\xbf\x00\xe9\x0b\x91^YY\x0d\x95" +
+	"\x84D\x9a\xa4\xcb\x09\x89\xcfGz\x9b$9\x95\xcbf" +
+	"3\x03\x86\xeb\xf89R\xc8\xb9\x10\x9a\xe3Q\x89\xe3\x9f" +
+	"9\x1cZ\xfc=\x96\xc9@\xda\x1d\x83C\x15\x8bx\\" +
+	"\xdf\xc3\x01\xb1\"O\xdcR\x81\xe5\xa9\xce\x0de\xf0Q" +
+	"\xefr\x06\x80\xdd\x11\xea\xec8<1\x80\x8bN\x09h" +
+	"\x148\xdcQ\xe4j\xd1\xd2\xe0T\xa5]\xff\xcd\xd1l" +
+	"%8\xdbk\xe8>\xa0\xca\x85\x15\xfb8%\x87s^" +
+	"\x86\xa6\xed<^\xe2@5\x87_M\xe9\xaa\xa9\xb9\xe6" +
+	"\x8faw<?\x0b\xe6\x98\xc3\x9e\x1c\x06\xbc\xb1\x8d\x99" +
+	"\xb4\x0a:;C\x14.\x87\x0a\x09\\~\xe7\xcfA\xd6" +
+	"\xe2HZ\xd6\x82\x1e\x97\x87\xa3\xab\x1d\x1e\xa7=l\x12" +
+	"7*\xe1\xa0w\x9f\x01-\xf3$\x9f,\x9a\x11\xfb\xd2" +
+	"\x82\xff\x1b\xef\x16\xe4\x99Y\xdb^\x1fT\xf2l\x16K" +
+	"\x9eN1O,&\xf3\xc2\xbf\xa7\x9a\xccR\xb8=\xe3" +
+	"\x05\xef9B\xb6\xdd\xdf\xbeN\xd7{\x0e\xd2\xdb\x0c\xa8" +
+	"\xf2\xfb\x8b\x08\xf8?0<\x16\xc0\x06\xb2ez\xfb\x04" +
+	"\x8b\xbb\xbaHHh\xd2\x04\xa2T\x84R\x83?$i" +
+	"\x0e\xea\xe0\xd7\x0b\x1d\xfca\x14;\xbcS\xce\xc9eL" +
+	"3\xaew\x1b\xba\xd1\x1d\xd7=\xfdT\xc1\xf1\xf4\x0aN" +
+	"\x86\xcd{u\xa7\xe0OX\x84r}\xaf\xdb\xc2\xe2\x11" +
+	"\xca\xad\x9dB\xbb\x9dE(\x1bbn\x17\xa7\x90\xc9\xe9" +
+	"\xdd\xba\xd1n\x92J\xcfRm\xf2\x02\x8d\x84\xc4\xa2\x87" +
+	"S\x0b\xf33\xdbd?s\x10x\xa8\xb8B\"ve" +
+	"m\xa1\x8b\x18\x9c\x09\xc1\x18\x9czB\xe2\x97\"\xb9G" +
+	"\xc4\xe0h\x10\xf5`s\x18\x06G\x87\x98\x07\x9b\xc30" +
+	"8}0\x81as\xae\x1118W\xd3\xf1W\xf2l" +
+	"uD\xb9\xed\x9dn\x87z\x0f\xd6&4\xc2\xf6N\x1b" +
+	"(6\xe76\xa4\xdfC\xb3\xcc\x90\xed\x9d6\xc2d/" +
+	"\x06\x07\x18\x06\xa7\xd7\x8b\xc1\x91\x18\x06\xa7\xd6\x8b\xc1\x19" +
+	"Y\x02\x83#3\x0cN\xb3\x98\x0d\x87\xb2\x19\xb3DD" +
+	"\xec\x8f'\xb8\x03\"`\xb0\xa8!\xa4&\x93\xacB8" +
+	"\xda\xae\x106\xe4\xb4tf@\xf3SmM\xe75\x05" +
+	"\xeb2\xa3)\x19\x98\x06\x14\xecF\xe8\xe2.R)\xf6" +
+	"G}\xcdS_\xb7\xd4\xd6\xbc6S\x84]\x94\x02\xbf" +
+	"\x880\xc73\xb8\xdaL\xa2\xf2:\x09\xe5r\x86\xa06" +
+	"\xa7S\xf1ND\xf2TQm\xa6P\xc8\xd5\x19H\x9f" +
+	"\x09\xaey\x8b\xcc\xa0\xea4\x15\xe9\x0b\xc1\xb5p\x91&" +
+	"\xa0\xce\x1c\xe9m\xe0v7#\x0a=\xee\x85H\xbf\x84" +
+	"\xaaM\x99\xad6\xedt\xdeV\xa4_J\xd5f\xa5\xad" +
+	"6K 'jwx\xe4*[mT\xe8\xf5\xa81" +
+	"\x83n\xf9\xd5x\xd4j[m\xfa\xa0C\x84\x92\xf9\xec" +
+	"g\x8d\xa9\xf5\xb9m\xd6BoF7\xb4d\x8bFj" +
+	"hC\x95\x07\x9c\xb6\xbf3}G]H\xa8F\xc6\xd0" +
+	"\x13*iH\xcdF\x0e~\xcc\x86\xd0\xcb\xab\xb12Y" +
+	"=\xc1\xfe*\xac\xc8\xe4R\xc9\x98\xa6\x92\x9a$\x86\xfc" +
+	"\xbc\x02\xd6\xdd\xaf\x99\xd6\x1c\xd5 \x95\xcd\x19\xdd\xe0d" +
+	"u@\xb5\xd4\\{\x8e\x80\x0bv\x1a\xd0M\xbdSO" +
+	"\xe9D\xb6\xf2%`\x85,\xf3\x150.bm\xf2f" +
+	"!3cf\xd3\x93\x991\xb3\xf9a\xaf\x90\x991\xb3" +
+	"y0'`0\x99\xd9<\x94s1\x98<\xb1\xfb\x16" +
+	"\x89\xdf\xc8\x10\x1f)\xc6\xb1\xe5TE\xca\xf0H\xaa\xc4" +
+	"8\xb6\x82\xd2G#\xfd8\x90\xa0\xa0:\x9b\x00\x96\xbf" +
+	"\xd3\xa0\x8a\xbfLa\xb1\x88\xcd\xd5Dx\x98\xc4\xe1\xf9" +
+	"\x0eG\x97\x9e\xb2<\xb9\x1c\x87\xa7;\x0c\xd9\\\xa6K" +
+	"Oi\xc2\x10\x1c%-D!\x88\xeaq9\xf8C4" +
+	"/G#\xe15\x14\x8e\xc8v8\xa8\xbb3\xba2B" +
+	"\x94\xc2_2\x14\xb1\xf0\x94\x91\xc3\xf8\x03\xd1F\xadN" +
+	"\x9a\xeavOJ^\xf4\xb0{\xd3\xf1F\x9c\x86\xf4\xe9" +
+	"b\xfa2\x0d&{o\xba\xccnz-\xbb\xe9\xe7\x8a" +
+	"\xe9\xcb,z\\3\x91\xde(\xa6/\xb3i\x9a\xd2\x88" +
+	"\xf4V\xf1\xd8[ *Z\x06\x07\xa8\x1bQ \xe61" +
+	"\x00\xcc?,\xa1\xcbiC\xfa2z\xd1G\xda\x17}" +
+	")L\xf6\x18\x86QG\xd9\x17]\x85f\xd10\xf8\xa3" +
+	"\x0b\xe7\x8c\xdb\x88\xec\"\xa3*\x97\xebF2\xb8\xfa[" +
+	"P\xb3\xd9Fl\xcd\x93\x065\xbfH\xb8\xceN$\xd3" +
+	"\xa8\x83\xdd\xb7W\xd3\xa0\x95,\x19;\x7f\x8b}\xfb\xca" +
+	"\x94j\xb8\x0bHb\xb9\x9b'\xa6A\xf0\x80\x92X-" +
+	"'\x14\x1bN\x134&\xb6\xa2\xa0\x181,\xf6\x9a\xbf" +
+	"wC\xafE\xef\x16P\x18\x01=\xe6\xe1\xf7\xa3:\x83" +
+	"\xe0\xf5\x97\x1f!\xbc>\x91\xd0L\xb3-CB\xcb5" +
+	"\xd7}\xa6\xf5\xee\xd9\xf8\x03i0\xdb2\xe2\x0f\xc5\xcd" +
+	"]\xa7\xd5@\xcbD\xf9\xa2\x02Km\x10\x0c)\xeaF" +
+	"\xc1\xc3\xec%\xd8\xd3\xb5;\xd7\xbeD\x1aR/ 8" +
+	"y\x1e\x82\xd2\xdc&\x83\xf2\x9c \xb8\x9d1!\xe5`" +
+	"\x82\xdb]/\xa0,\x98\xb5~\xa5YH9\x98\xb5\xde" +
+	"W\xeb\xb6\xa7\xc0\xa9\xc2\xed\xafu]\x85_\xc4\xf8\xa7" +
+	"\x1f\x00\xdb\x9b\xe9\\\xd4\x9f\xee\xd4\x08\xf0\x02LC:" +
+	"\xd3\xa9\xa74\x01\x8eb$S\xba\xa1y\x00oiU" +
+	"\xe7\x0e\xae\x86\xb6f\x87\x07\xd9*\x91\x17\x04\x15\xa4\xea" +
+	"\x87,H9\x99\xdb\xda\x9c\x00cd\x99\xdb\xfaf\x17" +
+	"\xc6h\x17\xa4J\xc3\xb3|9\x1a\x87g\xd5\xe8Y\xe1" +
+	"\x7f\x05\xe5n\xa5R\x80RX?!\xff\xe1\xe9O}" +
+	"\x102\xb3Y\xd8\x12\xdb\xa7\x08\xeb\xe3\xfb\xbc5\xea\xee" +
+	"\xb3D\x84:\x8c\x98t\xa8\x08\xdb\xf7\"\xc7q\xe1C" +
+	"\xf6\x80\x83\x105.\x1a\xb37\x08\x8d\xd9\xeb\x9a\x8d\xe1" +
+	"\xa0\xaa\xb2\xaai^\x9c\xc9%I\xe5|\xd5\xec\x11\xbd" +
+	"A\\\xcb\x0d\xe8\xa42\xa1\x05\xa4\xaceA\xaa\xc9k" +
+	",\xaa\xa5U\x0e\xd2\xaf\x1c\x1c\xab\xd2\xec\xc22\x06\x07" +
+	"\x05b\xc7\xb5[\xb3\x06S\xa7\"\xb3\xb6\xd8hH\xe5" +
+	"\xe9\xd2\x04\xfcs\x94\xa6\xf6\xa0TKC\xf5@\xe7\xd6" +
+	"\xcee\x11f\xc8\x1f{\xc4<\xd9\x04\x8b=\xa6@\xcc" +
+	"\x13c\xb0\xd8c\x06u\xde\xd3\x91~\x81\x18{\x9cG" +
+	"K\xa7\xe7\"}\xbe\x18{\xcc\x85^O\x8c\xc1b\x8f" +
+	"\x16\xd8\xe2\x09&X\xec\xb1\x04\xb6x\x92b\x16rj" +
+	"\xf0\x94'k`\xa5\xd3>X-f\x0d\xbct\xeao" +
+	"\xed\xb2\xd2\xe9*\xb8Yl\xed\x16\x96;\x0f\xa5\x08\x0d" +
+	"Y\xf9CC'\x86[\xee\x14R\x08\xad\xff\xf1\x87\xe3" +
+	"\xbc\xb8\xc6Jf\x04\xaa\xdc\xcfQ8\xbf&\x9dR\x0c" +
+	"\xd8C\xd0z\x18\x7f\x87\xec\xf0\xa4\x1d\xb0\x0a/\xcf\xf1" +
+	"7\xec\x0eC\xc2)\xae\x00VW\xf0Q\x17\x0fk\xf9" +
+	"\x9b\xba N\xac\xc3pN\xfe\xa6\xd9\xdd\x14\x05D@" +
+	"\xf6\"\x1bI\xc0\xc3\\\xf6\xc2\xb4\x88\xd1-\xcd\xf2\xa7" +
+	"\xeeE<\xee.\xf9\xf7\x0fXX\xef<J\x03\xf6*" +
+	"\x8d\xca\x9a\x7fl#\xb0s\xc2<\xadP\xc7\x12U\xbf" +
+	"\xdeU\xfdR\xd5\xa92/\xfa\xc3\xd9\xab\xe8\xb9\x87@" +
+	"\x0eG\x83\x90\xc31\xf19\x87\x14\xf4\x9c\xc3\xc1\x80l" +
+	"\x9e,\"\x87W\x06 \x87\x07\x87u\x04\"s\xf0\x0d" +
+	"\x116I\x05\x8f\\\xa9\xe1\x0b\x02\x96\xff&\xa9\xb8J" +
+	"\xbdas+\x0e<\xdf\xf4\xb5Y\xd7\x09\xe6\x9a\x09\"" +
+	"\xbd\xce\x05\x18\xf1\x08&\xbfNphN\x00s\xf5\xaf" +
+	"\\\xdfU\xc8:s\x01+o\xd0K\xc2\x9f\xc2\xf3\xbe" +
+	"\x1a\xe7r\xaa\xc6\x04\xaa\xd8\xf7M\x8ay\x9ai\xda\xef" +
+	"n\xbe\xf8\xb7P\\\xb3\x8avo\x1b\xc1yNv9" +
+	"|\x00`\xe0C\xa3z\xc1\xce\xdb\x09\xab\x00\xb3\xa13" +
+	"\x0c\xbfv:\xd7\xa9_\x19\x9eP<\x18\xb0\x16\x8c\xff" +
+	"s\xf4\xb2=&\xe0\xff\x82\x1e\x8f\x05T\xca\x02\x1f\xb7" +
+	"\x05c'\xc5[(\xc4\xd6\xf5A\xb1\xb5\xd0\xbd\x1c\xe2" +
+	"\xc5R\x00d\xd7\xa9\x80k\xa23\x1e*\xc0\x8e\x8a\x85" +
+	"~\xe7\x94\x9e\x99,D\xdd,\xc0\xf6D\xdd,\xc0\xde" +
+	"\x9d\x13\x0b\xfdN\x80\xbd\xa7# \xc0\x16\xe0_CD" +
+	"M\xde\xeaaI\xd1\x17\xbf!\xf4>\x10`bS\x93" +
+	"\xc9\xd6L\xa9\xb7\x9f\xbe\x97\xbeC\xa1\xe9\xeb\x83\xb2\x91" +
+	"Z\xb1+\"\x07\x01\xc1\xcb\x82\xba\"LX1WX" +
+	"\x1c\x14 b\xec\xffg\x1f\xf5\x06\xa8\x19\x02\x8c\x04\xb4" +
+	"IMQV2\xfe{e%\xacM.\x86\xf0\xacM" +
+	"\xbe\xbe\xc3\x8d\xd6KC\x99\x8b\x1e\xc4\xa9\x96\xda\x96\xcf" +
+	"z\x127\xfb\xa5\x92\xef9\x81\xff\xe1\xbc\xdd\xf5s\x01" +
+	"x\x82\xeb\xacu]g\x8d*\x16C\x03_j|O" +
+	" -\xc7\xfb6\x1f\x19\x8e\xd6\x8e\xf4\x83\xab\xe6\x9ee" +
+	"\xa1\xffj0\x8aR\x90\xde\xa0\xd2E\xaf\x80\x9ae:" +
+	"\xdf\xb7ZpjL\xe7\xf3\x97\xbb\xe7\xceV\xeb\x16#" +
+	"\xf9\xc7\x9a<\x01\x9e\x10\xbc\xf1OE\xb0\xe8\xce)\xb4" +
+	"\x80\xc6\xe3'\xf6\x89\x15\xcf\x10\xedY\xd2`?+\x87" +
+	"*\xf7\xc3b\x83=Z,\x01\x17\x1f\x0a\xc5\xeeb+" +
+	"'\x08\xd8\xcaa\xbfO\xf4\x974\x9db\xac\x90\x0c\x0e" +
+	"\xb5\x96\xce\xa1^\xee\xfa\xbde\xd0\x9b\x9b\xc0R{q" +
+	"\xdbXL\xc7m`\x89\xed\xbf\x04m\x89~\x1f\xd0\xb2" +
+	"\x0b*\x13P\xcb\x8e\x078\x02\xd8\xf2P\xae7\xe64" +
+	"\xe6uC\xec\x0e\x09\x02\x8e\x0e71\x15\xaee\x11\x9c" +
+	"\xc8[ \xf0=\x00\x0c\xb4*\xa2\xc5\x1c\xaa\xccV/" +
+	"\x96\xd9DSS\xea\xe1\xafs\xc5=\x0f~\xa7\xf2\\" +
+	"u6L\xf0\xe4\x98,W\x9d\x0b\xcd\x9e\x1cSZi" +
+	"\xe7\xaa-\xd0\xe1\xe9d\xb1\\\xb5\x1d&\x88\xb9\xa7\xf3" +
+	"x2\xb2\x04z=\xf5\xear\xb0SU\x15.\x17\xeb" +
+	"\xd5\xe1\x11\x92\x9d\xaa\xea\xb0\xda\x93\x922\xf4v\x1f\xf4" +
+	"zR\xd2\x91ev\xaa\x9a\x87NOJ\xca\x1ab\xab" +
+	"\xa0\x97\xf5i\xaf\x13\xbfe\xb0\x16\xee\xf0|+\xe1\xe8" +
+	"\x90\xddG\xbd\x15\xd6\x89}\xda\xd0r-\xef>\xecV" +
+	"-\xad;\x93\xf3<\xf6\xd6\xcd\x98\x96\xc8\x0chD\xce" +
+	"\xf1.T\xa8_\xfc\x82\x80\x93\x10\x90J\xcd\xb0\xd0\x06" +
+	"\xf1Opy-]\x944\xcc\x1dpX\xf8\xd7n\xbc" +
+	"\xcd\x9dF\xb0\xcf\xce\xc4`\x9f\x7f\xccJ\x00\xd1y\xe6" +
+	"\xe1\x1f\x1as\x184\xa7LAB\xf6\xef\xfc\x13J\x02" +
+	"\xbe\xd43\x00\xffJZq\xaa`+\x91L\xd9\xf8\xf7" +
+	"\x90\x18\x1b{,\xde\xc8V\x8b\xcb\xe5\x9f6\x0cLH" +
+	"E\x98\x83\x13s\x0d\xd7\xeaE\x8f\x0c\xdd\x8e\xd1O\x9b" +
+	"\x88B\xf5EC\xff9\x00\xeb#\x87g"
+
+func init() {
+	schemas.Register(schema_c7d28e5a19f3b460,
+		0x80b830aba77157fc,
+		0x8241fbc07d342431,
+		0x826aa0b44e399fe5,
+		0x846de35bd5cb69c4,
+		0x8879a14485c01641,
+		0x88e098179fb2df0e,
+		0x8a00d12f0dd477f1,
+		0x8bcfb61e43c1be27,
+		0x8c906f23688998cd,
+		0x8e5ba76c8c0e47a6,
+		0x8fa7fe6530c007cb,
+		0x913d5747c3f4e4b3,
+		0x92c3dca0a360ca2a,
+		0x95f6b60a46846a53,
+		0x968dd9724c73332a,
+		0x9ab891a076d1d0e7,
+		0x9b6eeda7013e5b0a,
+		0x9caada5660f34b50,
+		0x9eea6d74d5ba3dca,
+		0xa101b3e95f02908b,
+		0xa16a03a6d3c23073,
+		0xa439dda318deab4f,
+		0xa8c3ee7161d20e77,
+		0xa929813448d22939,
+		0xaa9fd5003815aad9,
+		0xacc5b958a9c4d9d9,
+		0xae317646a68a1ac6,
+		0xae42c17d5084fa3f,
+		0xaea7191ac13b267f,
+		0xb0188cdd819e08c8,
+		0xb1366c68d01f9a91,
+		0xb149d31e684e5065,
+		0xb215e737bec195ed,
+		0xb23426a7cf33195d,
+		0xb464be80d7e5888b,
+		0xb60edeb5c24cb043,
+		0xb6ed8988f8b07974,
+		0xbce7f3f0621f46dc,
+		0xbf02bef32aee7bfc,
+		0xbfb43e223a8bf84f,
+		0xc1ccb63acb1b2ac0,
+		0xc1ce73427c4e70b2,
+		0xc1d4316536e88683,
+		0xc4a6d75d32b47289,
+		0xc4ca665aca2521d3,
+		0xc5af7ccd500f00d9,
+		0xc96ba43ce2771476,
+		0xcc4535339f0af3e9,
+		0xcefc928fad5f15d2,
+		0xd057b702b851d2b9,
+		0xd5ae2f77b3854e35,
+		0xd73ab79f8e54cef8,
+		0xd86ef9bfceda971d,
+		0xdc01c71d8eb831b9,
+		0xdc11cba3149e3be4,
+		0xdcdc2e009d590107,
+		0xde4f7ccd46b4df5f,
+		0xdef224b5f6c7fe42,
+		0xe0713593fef39d27,
+		0xe7a7a8af415eadba,
+		0xeab92d544808b59b,
+		0xec0a05bd849a61eb,
+		0xee9dc2e3f0844724,
+		0xef257e0a24528653,
+		0xefa13433b67a1263,
+		0xf4c701d71ed2de49,
+		0xf54a62a1cdd8c583,
+		0xfa3287842b3c69b2,
+		0xfa848f8bf8b75825,
+		0xfdb40dacdfae9f6f,
+		0xfe52005680b49373,
+		0xff9a16bcf09c606e,
+		0xffd2f6170ab39c6b)
+}
@@ -0,0 +1,3386 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dbtypes
+
+import (
+	"encoding/json"
+
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	capn "zombiezen.com/go/capnproto2"
+)
+
+// Encode encodes the db event with capn, it is used when the topic format is capn
+func (dbe *DBEvent) Encode() ([]byte, error) {
+	msg, seg, err := capn.NewMessage(capn.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewRootDBEventCapn(seg)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeDBEventCapn(c, dbe); err != nil {
+		return nil, err
+	}
+	return msg.Marshal()
+}
+
+// Decode decodes the db event encoded by Encode
+func (dbe *DBEvent) Decode(input []byte) error {
+	msg, err := capn.Unmarshal(input)
+	if err != nil {
+		return err
+	}
+	c, err := ReadRootDBEventCapn(msg)
+	if err != nil {
+		return err
+	}
+	return readDBEventCapn(c, dbe)
+}
+
+func writeDBEventCapn(c DBEventCapn, v *DBEvent) error {
+	c.SetKey(v.Key)
+	c.SetCategory(v.Category)
+	c.SetIsRecovery(v.IsRecovery)
+	c.SetUid(v.Uid)
+	if s, err := c.NewRoomDBEvents(); err != nil {
+		return err
+	} else if err := writeRoomDBEventCapn(s, &v.RoomDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewDeviceDBEvents(); err != nil {
+		return err
+	} else if err := writeDeviceDBEventCapn(s, &v.DeviceDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewAccountDBEvents(); err != nil {
+		return err
+	} else if err := writeAccountDBEventCapn(s, &v.AccountDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewPushDBEvents(); err != nil {
+		return err
+	} else if err := writePushDBEventCapn(s, &v.PushDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewE2EDBEvents(); err != nil {
+		return err
+	} else if err := writeE2EDBEventCapn(s, &v.E2EDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewSyncDBEvents(); err != nil {
+		return err
+	} else if err := writeSyncDBEventCapn(s, &v.SyncDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewPublicRoomDBEvents(); err != nil {
+		return err
+	} else if err := writePublicRoomDBEventCapn(s, &v.PublicRoomDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.NewPresenceDBEvents(); err != nil {
+		return err
+	} else if err := writePresenceDBEventCapn(s, &v.PresenceDBEvents); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readDBEventCapn(c DBEventCapn, v *DBEvent) error {
+	v.Key = c.Key()
+	v.Category = c.Category()
+	v.IsRecovery = c.IsRecovery()
+	v.Uid = c.Uid()
+	if s, err := c.RoomDBEvents(); err != nil {
+		return err
+	} else if err := readRoomDBEventCapn(s, &v.RoomDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.DeviceDBEvents(); err != nil {
+		return err
+	} else if err := readDeviceDBEventCapn(s, &v.DeviceDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.AccountDBEvents(); err != nil {
+		return err
+	} else if err := readAccountDBEventCapn(s, &v.AccountDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.PushDBEvents(); err != nil {
+		return err
+	} else if err := readPushDBEventCapn(s, &v.PushDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.E2EDBEvents(); err != nil {
+		return err
+	} else if err := readE2EDBEventCapn(s, &v.E2EDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.SyncDBEvents(); err != nil {
+		return err
+	} else if err := readSyncDBEventCapn(s, &v.SyncDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.PublicRoomDBEvents(); err != nil {
+		return err
+	} else if err := readPublicRoomDBEventCapn(s, &v.PublicRoomDBEvents); err != nil {
+		return err
+	}
+	if s, err := c.PresenceDBEvents(); err != nil {
+		return err
+	} else if err := readPresenceDBEventCapn(s, &v.PresenceDBEvents); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeRoomDBEventCapn(c RoomDBEventCapn, v *RoomDBEvent) error {
+	if v.EventJsonInsert != nil {
+		if s, err := c.NewEventJsonInsert(); err != nil {
+			return err
+		} else if err := writeEventJsonInsertCapn(s, v.EventJsonInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventInsert != nil {
+		if s, err := c.NewEventInsert(); err != nil {
+			return err
+		} else if err := writeEventInsertCapn(s, v.EventInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventRoomInsert != nil {
+		if s, err := c.NewEventRoomInsert(); err != nil {
+			return err
+		} else if err := writeEventRoomInsertCapn(s, v.EventRoomInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventRoomUpdate != nil {
+		if s, err := c.NewEventRoomUpdate(); err != nil {
+			return err
+		} else if err := writeEventRoomUpdateCapn(s, v.EventRoomUpdate); err != nil {
+			return err
+		}
+	}
+	if v.EventStateSnapInsert != nil {
+		if s, err := c.NewEventStateSnapInsert(); err != nil {
+			return err
+		} else if err := writeEventStateSnapInsertCapn(s, v.EventStateSnapInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventInviteInsert != nil {
+		if s, err := c.NewEventInviteInsert(); err != nil {
+			return err
+		} else if err := writeEventInviteInsertCapn(s, v.EventInviteInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventInviteUpdate != nil {
+		if s, err := c.NewEventInviteUpdate(); err != nil {
+			return err
+		} else if err := writeEventInviteUpdateCapn(s, v.EventInviteUpdate); err != nil {
+			return err
+		}
+	}
+	if v.EventMembershipInsert != nil {
+		if s, err := c.NewEventMembershipInsert(); err != nil {
+			return err
+		} else if err := writeEventMembershipInsertCapn(s, v.EventMembershipInsert); err != nil {
+			return err
+		}
+	}
+	if v.EventMembershipUpdate != nil {
+		if s, err := c.NewEventMembershipUpdate(); err != nil {
+			return err
+		} else if err := writeEventMembershipUpdateCapn(s, v.EventMembershipUpdate); err != nil {
+			return err
+		}
+	}
+	if v.EventMembershipForgetUpdate != nil {
+		if s, err := c.NewEventMembershipForgetUpdate(); err != nil {
+			return err
+		} else if err := writeEventMembershipForgetUpdateCapn(s, v.EventMembershipForgetUpdate); err != nil {
+			return err
+		}
+	}
+	if v.AliaseInsert != nil {
+		if s, err := c.NewAliaseInsert(); err != nil {
+			return err
+		} else if err := writeAliaseInsertCapn(s, v.AliaseInsert); err != nil {
+			return err
+		}
+	}
+	if v.AliaseDelete != nil {
+		if s, err := c.NewAliaseDelete(); err != nil {
+			return err
+		} else if err := writeAliaseDeleteCapn(s, v.AliaseDelete); err != nil {
+			return err
+		}
+	}
+	if v.RoomDomainInsert != nil {
+		if s, err := c.NewRoomDomainInsert(); err != nil {
+			return err
+		} else if err := writeRoomDomainInsertCapn(s, v.RoomDomainInsert); err != nil {
+			return err
+		}
+	}
+	if v.RoomEventUpdate != nil {
+		if s, err := c.NewRoomEventUpdate(); err != nil {
+			return err
+		} else if err := writeRoomEventUpdateCapn(s, v.RoomEventUpdate); err != nil {
+			return err
+		}
+	}
+	if v.RoomDepthUpdate != nil {
+		if s, err := c.NewRoomDepthUpdate(); err != nil {
+			return err
+		} else if err := writeRoomDepthUpdateCapn(s, v.RoomDepthUpdate); err != nil {
+			return err
+		}
+	}
+	if v.SettingsInsert != nil {
+		if s, err := c.NewSettingsInsert(); err != nil {
+			return err
+		} else if err := writeSettingsInsertCapn(s, v.SettingsInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readRoomDBEventCapn(c RoomDBEventCapn, v *RoomDBEvent) error {
+	if c.HasEventJsonInsert() {
+		s, err := c.EventJsonInsert()
+		if err != nil {
+			return err
+		}
+		v.EventJsonInsert = new(EventJsonInsert)
+		if err := readEventJsonInsertCapn(s, v.EventJsonInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventInsert() {
+		s, err := c.EventInsert()
+		if err != nil {
+			return err
+		}
+		v.EventInsert = new(EventInsert)
+		if err := readEventInsertCapn(s, v.EventInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventRoomInsert() {
+		s, err := c.EventRoomInsert()
+		if err != nil {
+			return err
+		}
+		v.EventRoomInsert = new(EventRoomInsert)
+		if err := readEventRoomInsertCapn(s, v.EventRoomInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventRoomUpdate() {
+		s, err := c.EventRoomUpdate()
+		if err != nil {
+			return err
+		}
+		v.EventRoomUpdate = new(EventRoomUpdate)
+		if err := readEventRoomUpdateCapn(s, v.EventRoomUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasEventStateSnapInsert() {
+		s, err := c.EventStateSnapInsert()
+		if err != nil {
+			return err
+		}
+		v.EventStateSnapInsert = new(EventStateSnapInsert)
+		if err := readEventStateSnapInsertCapn(s, v.EventStateSnapInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventInviteInsert() {
+		s, err := c.EventInviteInsert()
+		if err != nil {
+			return err
+		}
+		v.EventInviteInsert = new(EventInviteInsert)
+		if err := readEventInviteInsertCapn(s, v.EventInviteInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventInviteUpdate() {
+		s, err := c.EventInviteUpdate()
+		if err != nil {
+			return err
+		}
+		v.EventInviteUpdate = new(EventInviteUpdate)
+		if err := readEventInviteUpdateCapn(s, v.EventInviteUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasEventMembershipInsert() {
+		s, err := c.EventMembershipInsert()
+		if err != nil {
+			return err
+		}
+		v.EventMembershipInsert = new(EventMembershipInsert)
+		if err := readEventMembershipInsertCapn(s, v.EventMembershipInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasEventMembershipUpdate() {
+		s, err := c.EventMembershipUpdate()
+		if err != nil {
+			return err
+		}
+		v.EventMembershipUpdate = new(EventMembershipUpdate)
+		if err := readEventMembershipUpdateCapn(s, v.EventMembershipUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasEventMembershipForgetUpdate() {
+		s, err := c.EventMembershipForgetUpdate()
+		if err != nil {
+			return err
+		}
+		v.EventMembershipForgetUpdate = new(EventMembershipForgetUpdate)
+		if err := readEventMembershipForgetUpdateCapn(s, v.EventMembershipForgetUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasAliaseInsert() {
+		s, err := c.AliaseInsert()
+		if err != nil {
+			return err
+		}
+		v.AliaseInsert = new(AliaseInsert)
+		if err := readAliaseInsertCapn(s, v.AliaseInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasAliaseDelete() {
+		s, err := c.AliaseDelete()
+		if err != nil {
+			return err
+		}
+		v.AliaseDelete = new(AliaseDelete)
+		if err := readAliaseDeleteCapn(s, v.AliaseDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasRoomDomainInsert() {
+		s, err := c.RoomDomainInsert()
+		if err != nil {
+			return err
+		}
+		v.RoomDomainInsert = new(RoomDomainInsert)
+		if err := readRoomDomainInsertCapn(s, v.RoomDomainInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasRoomEventUpdate() {
+		s, err := c.RoomEventUpdate()
+		if err != nil {
+			return err
+		}
+		v.RoomEventUpdate = new(RoomEventUpdate)
+		if err := readRoomEventUpdateCapn(s, v.RoomEventUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasRoomDepthUpdate() {
+		s, err := c.RoomDepthUpdate()
+		if err != nil {
+			return err
+		}
+		v.RoomDepthUpdate = new(RoomDepthUpdate)
+		if err := readRoomDepthUpdateCapn(s, v.RoomDepthUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasSettingsInsert() {
+		s, err := c.SettingsInsert()
+		if err != nil {
+			return err
+		}
+		v.SettingsInsert = new(SettingsInsert)
+		if err := readSettingsInsertCapn(s, v.SettingsInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeEventJsonInsertCapn(c EventJsonInsertCapn, v *EventJsonInsert) error {
+	c.SetEventNid(v.EventNid)
+	if err := c.SetEventJson(v.EventJson); err != nil {
+		return err
+	}
+	if err := c.SetEventType(v.EventType); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readEventJsonInsertCapn(c EventJsonInsertCapn, v *EventJsonInsert) error {
+	var err error
+	v.EventNid = c.EventNid()
+	if c.HasEventJson() {
+		data, err := c.EventJson()
+		if err != nil {
+			return err
+		}
+		v.EventJson = append([]byte{}, data...)
+	}
+	if v.EventType, err = c.EventType(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeEventInsertCapn(c EventInsertCapn, v *EventInsert) error {
+	c.SetRoomNid(v.RoomNid)
+	if err := c.SetEventType(v.EventType); err != nil {
+		return err
+	}
+	if err := c.SetEventStateKey(v.EventStateKey); err != nil {
+		return err
+	}
+	if err := c.SetEventId(v.EventId); err != nil {
+		return err
+	}
+	if err := c.SetRefSha(v.RefSha); err != nil {
+		return err
+	}
+	if v.AuthEventNids != nil {
+		l, err := c.NewAuthEventNids(int32(len(v.AuthEventNids)))
+		if err != nil {
+			return err
+		}
+		for i, n := range v.AuthEventNids {
+			l.Set(i, n)
+		}
+	}
+	c.SetDepth(v.Depth)
+	c.SetEventNid(v.EventNid)
+	c.SetStateSnapNid(v.StateSnapNid)
+	if err := c.SetRefEventId(v.RefEventId); err != nil {
+		return err
+	}
+	if err := c.SetSha(v.Sha); err != nil {
+		return err
+	}
+	c.SetOffset(v.Offset)
+	if err := c.SetDomain(v.Domain); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readEventInsertCapn(c EventInsertCapn, v *EventInsert) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	if v.EventType, err = c.EventType(); err != nil {
+		return err
+	}
+	if v.EventStateKey, err = c.EventStateKey(); err != nil {
+		return err
+	}
+	if v.EventId, err = c.EventId(); err != nil {
+		return err
+	}
+	if c.HasRefSha() {
+		data, err := c.RefSha()
+		if err != nil {
+			return err
+		}
+		v.RefSha = append([]byte{}, data...)
+	}
+	if c.HasAuthEventNids() {
+		l, err := c.AuthEventNids()
+		if err != nil {
+			return err
+		}
+		v.AuthEventNids = make([]int64, l.Len())
+		for i := range v.AuthEventNids {
+			v.AuthEventNids[i] = l.At(i)
+		}
+	}
+	v.Depth = c.Depth()
+	v.EventNid = c.EventNid()
+	v.StateSnapNid = c.StateSnapNid()
+	if v.RefEventId, err = c.RefEventId(); err != nil {
+		return err
+	}
+	if c.HasSha() {
+		data, err := c.Sha()
+		if err != nil {
+			return err
+		}
+		v.Sha = append([]byte{}, data...)
+	}
+	v.Offset = c.Offset()
+	if v.Domain, err = c.Domain(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeEventRoomInsertCapn(c EventRoomInsertCapn, v *EventRoomInsert) error {
+	c.SetRoomNid(v.RoomNid)
+	if err := c.SetRoomId(v.RoomId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readEventRoomInsertCapn(c EventRoomInsertCapn, v *EventRoomInsert) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	if v.RoomId, err = c.RoomId(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeEventRoomUpdateCapn(c EventRoomUpdateCapn, v *EventRoomUpdate) error {
+	if v.LatestEventNids != nil {
+		l, err := c.NewLatestEventNids(int32(len(v.LatestEventNids)))
+		if err != nil {
+			return err
+		}
+		for i, n := range v.LatestEventNids {
+			l.Set(i, n)
+		}
+	}
+	c.SetLastEventSentNid(v.LastEventSentNid)
+	c.SetStateSnapNid(v.StateSnapNid)
+	c.SetRoomNid(v.RoomNid)
+	c.SetVersion(v.Version)
+	c.SetDepth(v.Depth)
+	return nil
+}
+
+func readEventRoomUpdateCapn(c EventRoomUpdateCapn, v *EventRoomUpdate) error {
+	if c.HasLatestEventNids() {
+		l, err := c.LatestEventNids()
+		if err != nil {
+			return err
+		}
+		v.LatestEventNids = make([]int64, l.Len())
+		for i := range v.LatestEventNids {
+			v.LatestEventNids[i] = l.At(i)
+		}
+	}
+	v.LastEventSentNid = c.LastEventSentNid()
+	v.StateSnapNid = c.StateSnapNid()
+	v.RoomNid = c.RoomNid()
+	v.Version = c.Version()
+	v.Depth = c.Depth()
+	return nil
+}
+
+func writeEventStateSnapInsertCapn(c EventStateSnapInsertCapn, v *EventStateSnapInsert) error {
+	c.SetStateSnapNid(v.StateSnapNid)
+	c.SetRoomNid(v.RoomNid)
+	if v.StateBlockNids != nil {
+		l, err := c.NewStateBlockNids(int32(len(v.StateBlockNids)))
+		if err != nil {
+			return err
+		}
+		for i, n := range v.StateBlockNids {
+			l.Set(i, n)
+		}
+	}
+	return nil
+}
+
+func readEventStateSnapInsertCapn(c EventStateSnapInsertCapn, v *EventStateSnapInsert) error {
+	v.StateSnapNid = c.StateSnapNid()
+	v.RoomNid = c.RoomNid()
+	if c.HasStateBlockNids() {
+		l, err := c.StateBlockNids()
+		if err != nil {
+			return err
+		}
+		v.StateBlockNids = make([]int64, l.Len())
+		for i := range v.StateBlockNids {
+			v.StateBlockNids[i] = l.At(i)
+		}
+	}
+	return nil
+}
+
+func writeEventInviteInsertCapn(c EventInviteInsertCapn, v *EventInviteInsert) error {
+	c.SetRoomNid(v.RoomNid)
+	if err := c.SetEventId(v.EventId); err != nil {
+		return err
+	}
+	if err := c.SetTarget(v.Target); err != nil {
+		return err
+	}
+	if err := c.SetSender(v.Sender); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readEventInviteInsertCapn(c EventInviteInsertCapn, v *EventInviteInsert) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	if v.EventId, err = c.EventId(); err != nil {
+		return err
+	}
+	if v.Target, err = c.Target(); err != nil {
+		return err
+	}
+	if v.Sender, err = c.Sender(); err != nil {
+		return err
+	}
+	if c.HasContent() {
+		data, err := c.Content()
+		if err != nil {
+			return err
+		}
+		v.Content = append([]byte{}, data...)
+	}
+	return nil
+}
+
+func writeEventInviteUpdateCapn(c EventInviteUpdateCapn, v *EventInviteUpdate) error {
+	c.SetRoomNid(v.RoomNid)
+	if err := c.SetTarget(v.Target); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readEventInviteUpdateCapn(c EventInviteUpdateCapn, v *EventInviteUpdate) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	if v.Target, err = c.Target(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeEventMembershipInsertCapn(c EventMembershipInsertCapn, v *EventMembershipInsert) error {
+	c.SetRoomNID(v.RoomNID)
+	if err := c.SetTarget(v.Target); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	c.SetMembershipNID(v.MembershipNID)
+	c.SetEventNID(v.EventNID)
+	return nil
+}
+
+func readEventMembershipInsertCapn(c EventMembershipInsertCapn, v *EventMembershipInsert) error {
+	var err error
+	v.RoomNID = c.RoomNID()
+	if v.Target, err = c.Target(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	v.MembershipNID = c.MembershipNID()
+	v.EventNID = c.EventNID()
+	return nil
+}
+
+func writeEventMembershipUpdateCapn(c EventMembershipUpdateCapn, v *EventMembershipUpdate) error {
+	c.SetRoomID(v.RoomID)
+	if err := c.SetTarget(v.Target); err != nil {
+		return err
+	}
+	if err := c.SetSender(v.Sender); err != nil {
+		return err
+	}
+	c.SetMembership(v.Membership)
+	c.SetEventNID(v.EventNID)
+	c.SetVersion(v.Version)
+	return nil
+}
+
+func readEventMembershipUpdateCapn(c EventMembershipUpdateCapn, v *EventMembershipUpdate) error {
+	var err error
+	v.RoomID = c.RoomID()
+	if v.Target, err = c.Target(); err != nil {
+		return err
+	}
+	if v.Sender, err = c.Sender(); err != nil {
+		return err
+	}
+	v.Membership = c.Membership()
+	v.EventNID = c.EventNID()
+	v.Version = c.Version()
+	return nil
+}
+
+func writeEventMembershipForgetUpdateCapn(c EventMembershipForgetUpdateCapn, v *EventMembershipForgetUpdate) error {
+	c.SetRoomID(v.RoomID)
+	if err := c.SetTarget(v.Target); err != nil {
+		return err
+	}
+	c.SetForgetID(v.ForgetID)
+	return nil
+}
+
+func readEventMembershipForgetUpdateCapn(c EventMembershipForgetUpdateCapn, v *EventMembershipForgetUpdate) error {
+	var err error
+	v.RoomID = c.RoomID()
+	if v.Target, err = c.Target(); err != nil {
+		return err
+	}
+	v.ForgetID = c.ForgetID()
+	return nil
+}
+
+func writeAliaseInsertCapn(c AliaseInsertCapn, v *AliaseInsert) error {
+	if err := c.SetAlias(v.Alias); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readAliaseInsertCapn(c AliaseInsertCapn, v *AliaseInsert) error {
+	var err error
+	if v.Alias, err = c.Alias(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeAliaseDeleteCapn(c AliaseDeleteCapn, v *AliaseDelete) error {
+	if err := c.SetAlias(v.Alias); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readAliaseDeleteCapn(c AliaseDeleteCapn, v *AliaseDelete) error {
+	var err error
+	if v.Alias, err = c.Alias(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeRoomDomainInsertCapn(c RoomDomainInsertCapn, v *RoomDomainInsert) error {
+	c.SetRoomNid(v.RoomNid)
+	if err := c.SetDomain(v.Domain); err != nil {
+		return err
+	}
+	c.SetOffset(v.Offset)
+	return nil
+}
+
+func readRoomDomainInsertCapn(c RoomDomainInsertCapn, v *RoomDomainInsert) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	if v.Domain, err = c.Domain(); err != nil {
+		return err
+	}
+	v.Offset = c.Offset()
+	return nil
+}
+
+func writeRoomEventUpdateCapn(c RoomEventUpdateCapn, v *RoomEventUpdate) error {
+	c.SetRoomNid(v.RoomNid)
+	c.SetEventNid(v.EventNid)
+	c.SetDepth(v.Depth)
+	c.SetOffset(v.Offset)
+	if err := c.SetDomain(v.Domain); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readRoomEventUpdateCapn(c RoomEventUpdateCapn, v *RoomEventUpdate) error {
+	var err error
+	v.RoomNid = c.RoomNid()
+	v.EventNid = c.EventNid()
+	v.Depth = c.Depth()
+	v.Offset = c.Offset()
+	if v.Domain, err = c.Domain(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeRoomDepthUpdateCapn(c RoomDepthUpdateCapn, v *RoomDepthUpdate) error {
+	c.SetRoomNid(v.RoomNid)
+	c.SetDepth(v.Depth)
+	return nil
+}
+
+func readRoomDepthUpdateCapn(c RoomDepthUpdateCapn, v *RoomDepthUpdate) error {
+	v.RoomNid = c.RoomNid()
+	v.Depth = c.Depth()
+	return nil
+}
+
+func writeSettingsInsertCapn(c SettingsInsertCapn, v *SettingsInsert) error {
+	if err := c.SetSettingKey(v.SettingKey); err != nil {
+		return err
+	}
+	if err := c.SetVal(v.Val); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSettingsInsertCapn(c SettingsInsertCapn, v *SettingsInsert) error {
+	var err error
+	if v.SettingKey, err = c.SettingKey(); err != nil {
+		return err
+	}
+	if v.Val, err = c.Val(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeDeviceDBEventCapn(c DeviceDBEventCapn, v *DeviceDBEvent) error {
+	if v.DeviceInsert != nil {
+		if s, err := c.NewDeviceInsert(); err != nil {
+			return err
+		} else if err := writeDeviceInsertCapn(s, v.DeviceInsert); err != nil {
+			return err
+		}
+	}
+	if v.DeviceDelete != nil {
+		if s, err := c.NewDeviceDelete(); err != nil {
+			return err
+		} else if err := writeDeviceDeleteCapn(s, v.DeviceDelete); err != nil {
+			return err
+		}
+	}
+	if v.MigDeviceInsert != nil {
+		if s, err := c.NewMigDeviceInsert(); err != nil {
+			return err
+		} else if err := writeMigDeviceInsertCapn(s, v.MigDeviceInsert); err != nil {
+			return err
+		}
+	}
+	if v.DeviceUpdateTs != nil {
+		if s, err := c.NewDeviceUpdateTs(); err != nil {
+			return err
+		} else if err := writeDeviceUpdateTsCapn(s, v.DeviceUpdateTs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readDeviceDBEventCapn(c DeviceDBEventCapn, v *DeviceDBEvent) error {
+	if c.HasDeviceInsert() {
+		s, err := c.DeviceInsert()
+		if err != nil {
+			return err
+		}
+		v.DeviceInsert = new(DeviceInsert)
+		if err := readDeviceInsertCapn(s, v.DeviceInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasDeviceDelete() {
+		s, err := c.DeviceDelete()
+		if err != nil {
+			return err
+		}
+		v.DeviceDelete = new(DeviceDelete)
+		if err := readDeviceDeleteCapn(s, v.DeviceDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasMigDeviceInsert() {
+		s, err := c.MigDeviceInsert()
+		if err != nil {
+			return err
+		}
+		v.MigDeviceInsert = new(MigDeviceInsert)
+		if err := readMigDeviceInsertCapn(s, v.MigDeviceInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasDeviceUpdateTs() {
+		s, err := c.DeviceUpdateTs()
+		if err != nil {
+			return err
+		}
+		v.DeviceUpdateTs = new(DeviceUpdateTs)
+		if err := readDeviceUpdateTsCapn(s, v.DeviceUpdateTs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeDeviceInsertCapn(c DeviceInsertCapn, v *DeviceInsert) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetDisplayName(v.DisplayName); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetCreatedTs(v.CreatedTs)
+	if err := c.SetDeviceType(v.DeviceType); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	c.SetLastActiveTs(v.LastActiveTs)
+	return nil
+}
+
+func readDeviceInsertCapn(c DeviceInsertCapn, v *DeviceInsert) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.DisplayName, err = c.DisplayName(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.CreatedTs = c.CreatedTs()
+	if v.DeviceType, err = c.DeviceType(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	v.LastActiveTs = c.LastActiveTs()
+	return nil
+}
+
+func writeDeviceDeleteCapn(c DeviceDeleteCapn, v *DeviceDelete) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetCreateTs(v.CreateTs)
+	return nil
+}
+
+func readDeviceDeleteCapn(c DeviceDeleteCapn, v *DeviceDelete) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.CreateTs = c.CreateTs()
+	return nil
+}
+
+func writeMigDeviceInsertCapn(c MigDeviceInsertCapn, v *MigDeviceInsert) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetAccessToken(v.AccessToken); err != nil {
+		return err
+	}
+	if err := c.SetMigAccessToken(v.MigAccessToken); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readMigDeviceInsertCapn(c MigDeviceInsertCapn, v *MigDeviceInsert) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.AccessToken, err = c.AccessToken(); err != nil {
+		return err
+	}
+	if v.MigAccessToken, err = c.MigAccessToken(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeDeviceUpdateTsCapn(c DeviceUpdateTsCapn, v *DeviceUpdateTs) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetLastActiveTs(v.LastActiveTs)
+	return nil
+}
+
+func readDeviceUpdateTsCapn(c DeviceUpdateTsCapn, v *DeviceUpdateTs) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.LastActiveTs = c.LastActiveTs()
+	return nil
+}
+
+func writeAccountDBEventCapn(c AccountDBEventCapn, v *AccountDBEvent) error {
+	if v.AccountDataInsert != nil {
+		if s, err := c.NewAccountDataInsert(); err != nil {
+			return err
+		} else if err := writeAccountDataInsertCapn(s, v.AccountDataInsert); err != nil {
+			return err
+		}
+	}
+	if v.AccountInsert != nil {
+		if s, err := c.NewAccountInsert(); err != nil {
+			return err
+		} else if err := writeAccountInsertCapn(s, v.AccountInsert); err != nil {
+			return err
+		}
+	}
+	if v.FilterInsert != nil {
+		if s, err := c.NewFilterInsert(); err != nil {
+			return err
+		} else if err := writeFilterInsertCapn(s, v.FilterInsert); err != nil {
+			return err
+		}
+	}
+	if v.ProfileInsert != nil {
+		if s, err := c.NewProfileInsert(); err != nil {
+			return err
+		} else if err := writeProfileInsertCapn(s, v.ProfileInsert); err != nil {
+			return err
+		}
+	}
+	if v.RoomTagInsert != nil {
+		if s, err := c.NewRoomTagInsert(); err != nil {
+			return err
+		} else if err := writeRoomTagInsertCapn(s, v.RoomTagInsert); err != nil {
+			return err
+		}
+	}
+	if v.RoomTagDelete != nil {
+		if s, err := c.NewRoomTagDelete(); err != nil {
+			return err
+		} else if err := writeRoomTagDeleteCapn(s, v.RoomTagDelete); err != nil {
+			return err
+		}
+	}
+	if v.UserInfoInsert != nil {
+		if s, err := c.NewUserInfoInsert(); err != nil {
+			return err
+		} else if err := writeUserInfoInsertCapn(s, v.UserInfoInsert); err != nil {
+			return err
+		}
+	}
+	if v.UserInfoDelete != nil {
+		if s, err := c.NewUserInfoDelete(); err != nil {
+			return err
+		} else if err := writeUserInfoDeleteCapn(s, v.UserInfoDelete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readAccountDBEventCapn(c AccountDBEventCapn, v *AccountDBEvent) error {
+	if c.HasAccountDataInsert() {
+		s, err := c.AccountDataInsert()
+		if err != nil {
+			return err
+		}
+		v.AccountDataInsert = new(AccountDataInsert)
+		if err := readAccountDataInsertCapn(s, v.AccountDataInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasAccountInsert() {
+		s, err := c.AccountInsert()
+		if err != nil {
+			return err
+		}
+		v.AccountInsert = new(AccountInsert)
+		if err := readAccountInsertCapn(s, v.AccountInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasFilterInsert() {
+		s, err := c.FilterInsert()
+		if err != nil {
+			return err
+		}
+		v.FilterInsert = new(FilterInsert)
+		if err := readFilterInsertCapn(s, v.FilterInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasProfileInsert() {
+		s, err := c.ProfileInsert()
+		if err != nil {
+			return err
+		}
+		v.ProfileInsert = new(ProfileInsert)
+		if err := readProfileInsertCapn(s, v.ProfileInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasRoomTagInsert() {
+		s, err := c.RoomTagInsert()
+		if err != nil {
+			return err
+		}
+		v.RoomTagInsert = new(RoomTagInsert)
+		if err := readRoomTagInsertCapn(s, v.RoomTagInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasRoomTagDelete() {
+		s, err := c.RoomTagDelete()
+		if err != nil {
+			return err
+		}
+		v.RoomTagDelete = new(RoomTagDelete)
+		if err := readRoomTagDeleteCapn(s, v.RoomTagDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasUserInfoInsert() {
+		s, err := c.UserInfoInsert()
+		if err != nil {
+			return err
+		}
+		v.UserInfoInsert = new(UserInfoInsert)
+		if err := readUserInfoInsertCapn(s, v.UserInfoInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasUserInfoDelete() {
+		s, err := c.UserInfoDelete()
+		if err != nil {
+			return err
+		}
+		v.UserInfoDelete = new(UserInfoDelete)
+		if err := readUserInfoDeleteCapn(s, v.UserInfoDelete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeAccountDataInsertCapn(c AccountDataInsertCapn, v *AccountDataInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetType(v.Type); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readAccountDataInsertCapn(c AccountDataInsertCapn, v *AccountDataInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.Type, err = c.Type(); err != nil {
+		return err
+	}
+	if v.Content, err = c.Content(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeAccountInsertCapn(c AccountInsertCapn, v *AccountInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetCreatedTs(v.CreatedTs)
+	if err := c.SetPassWordHash(v.PassWordHash); err != nil {
+		return err
+	}
+	if err := c.SetAppServiceID(v.AppServiceID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readAccountInsertCapn(c AccountInsertCapn, v *AccountInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.CreatedTs = c.CreatedTs()
+	if v.PassWordHash, err = c.PassWordHash(); err != nil {
+		return err
+	}
+	if v.AppServiceID, err = c.AppServiceID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeFilterInsertCapn(c FilterInsertCapn, v *FilterInsert) error {
+	if err := c.SetFilter(v.Filter); err != nil {
+		return err
+	}
+	if err := c.SetFilterID(v.FilterID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readFilterInsertCapn(c FilterInsertCapn, v *FilterInsert) error {
+	var err error
+	if v.Filter, err = c.Filter(); err != nil {
+		return err
+	}
+	if v.FilterID, err = c.FilterID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeProfileInsertCapn(c ProfileInsertCapn, v *ProfileInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetDisplayName(v.DisplayName); err != nil {
+		return err
+	}
+	if err := c.SetAvatarUrl(v.AvatarUrl); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readProfileInsertCapn(c ProfileInsertCapn, v *ProfileInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.DisplayName, err = c.DisplayName(); err != nil {
+		return err
+	}
+	if v.AvatarUrl, err = c.AvatarUrl(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeRoomTagInsertCapn(c RoomTagInsertCapn, v *RoomTagInsert) error {
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetTag(v.Tag); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readRoomTagInsertCapn(c RoomTagInsertCapn, v *RoomTagInsert) error {
+	var err error
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Tag, err = c.Tag(); err != nil {
+		return err
+	}
+	if c.HasContent() {
+		data, err := c.Content()
+		if err != nil {
+			return err
+		}
+		v.Content = append([]byte{}, data...)
+	}
+	return nil
+}
+
+func writeRoomTagDeleteCapn(c RoomTagDeleteCapn, v *RoomTagDelete) error {
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetTag(v.Tag); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readRoomTagDeleteCapn(c RoomTagDeleteCapn, v *RoomTagDelete) error {
+	var err error
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Tag, err = c.Tag(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeUserInfoInsertCapn(c UserInfoInsertCapn, v *UserInfoInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetUserName(v.UserName); err != nil {
+		return err
+	}
+	if err := c.SetJobNumber(v.JobNumber); err != nil {
+		return err
+	}
+	if err := c.SetMobile(v.Mobile); err != nil {
+		return err
+	}
+	if err := c.SetLandline(v.Landline); err != nil {
+		return err
+	}
+	if err := c.SetEmail(v.Email); err != nil {
+		return err
+	}
+	c.SetState(int64(v.State))
+	return nil
+}
+
+func readUserInfoInsertCapn(c UserInfoInsertCapn, v *UserInfoInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.UserName, err = c.UserName(); err != nil {
+		return err
+	}
+	if v.JobNumber, err = c.JobNumber(); err != nil {
+		return err
+	}
+	if v.Mobile, err = c.Mobile(); err != nil {
+		return err
+	}
+	if v.Landline, err = c.Landline(); err != nil {
+		return err
+	}
+	if v.Email, err = c.Email(); err != nil {
+		return err
+	}
+	v.State = int(c.State())
+	return nil
+}
+
+func writeUserInfoDeleteCapn(c UserInfoDeleteCapn, v *UserInfoDelete) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readUserInfoDeleteCapn(c UserInfoDeleteCapn, v *UserInfoDelete) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePushDBEventCapn(c PushDBEventCapn, v *PushDBEvent) error {
+	if v.PusherDelete != nil {
+		if s, err := c.NewPusherDelete(); err != nil {
+			return err
+		} else if err := writePusherDeleteCapn(s, v.PusherDelete); err != nil {
+			return err
+		}
+	}
+	if v.PusherDeleteByKey != nil {
+		if s, err := c.NewPusherDeleteByKey(); err != nil {
+			return err
+		} else if err := writePusherDeleteByKeyCapn(s, v.PusherDeleteByKey); err != nil {
+			return err
+		}
+	}
+	if v.PusherDeleteByKeyOnly != nil {
+		if s, err := c.NewPusherDeleteByKeyOnly(); err != nil {
+			return err
+		} else if err := writePusherDeleteByKeyOnlyCapn(s, v.PusherDeleteByKeyOnly); err != nil {
+			return err
+		}
+	}
+	if v.PusherInsert != nil {
+		if s, err := c.NewPusherInsert(); err != nil {
+			return err
+		} else if err := writePusherInsertCapn(s, v.PusherInsert); err != nil {
+			return err
+		}
+	}
+	if v.PushRuleInert != nil {
+		if s, err := c.NewPushRuleInert(); err != nil {
+			return err
+		} else if err := writePushRuleInertCapn(s, v.PushRuleInert); err != nil {
+			return err
+		}
+	}
+	if v.PushRuleDelete != nil {
+		if s, err := c.NewPushRuleDelete(); err != nil {
+			return err
+		} else if err := writePushRuleDeleteCapn(s, v.PushRuleDelete); err != nil {
+			return err
+		}
+	}
+	if v.PushRuleEnableInsert != nil {
+		if s, err := c.NewPushRuleEnableInsert(); err != nil {
+			return err
+		} else if err := writePushRuleEnableInsertCapn(s, v.PushRuleEnableInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPushDBEventCapn(c PushDBEventCapn, v *PushDBEvent) error {
+	if c.HasPusherDelete() {
+		s, err := c.PusherDelete()
+		if err != nil {
+			return err
+		}
+		v.PusherDelete = new(PusherDelete)
+		if err := readPusherDeleteCapn(s, v.PusherDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasPusherDeleteByKey() {
+		s, err := c.PusherDeleteByKey()
+		if err != nil {
+			return err
+		}
+		v.PusherDeleteByKey = new(PusherDeleteByKey)
+		if err := readPusherDeleteByKeyCapn(s, v.PusherDeleteByKey); err != nil {
+			return err
+		}
+	}
+	if c.HasPusherDeleteByKeyOnly() {
+		s, err := c.PusherDeleteByKeyOnly()
+		if err != nil {
+			return err
+		}
+		v.PusherDeleteByKeyOnly = new(PusherDeleteByKeyOnly)
+		if err := readPusherDeleteByKeyOnlyCapn(s, v.PusherDeleteByKeyOnly); err != nil {
+			return err
+		}
+	}
+	if c.HasPusherInsert() {
+		s, err := c.PusherInsert()
+		if err != nil {
+			return err
+		}
+		v.PusherInsert = new(PusherInsert)
+		if err := readPusherInsertCapn(s, v.PusherInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasPushRuleInert() {
+		s, err := c.PushRuleInert()
+		if err != nil {
+			return err
+		}
+		v.PushRuleInert = new(PushRuleInert)
+		if err := readPushRuleInertCapn(s, v.PushRuleInert); err != nil {
+			return err
+		}
+	}
+	if c.HasPushRuleDelete() {
+		s, err := c.PushRuleDelete()
+		if err != nil {
+			return err
+		}
+		v.PushRuleDelete = new(PushRuleDelete)
+		if err := readPushRuleDeleteCapn(s, v.PushRuleDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasPushRuleEnableInsert() {
+		s, err := c.PushRuleEnableInsert()
+		if err != nil {
+			return err
+		}
+		v.PushRuleEnableInsert = new(PushRuleEnableInsert)
+		if err := readPushRuleEnableInsertCapn(s, v.PushRuleEnableInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePusherDeleteCapn(c PusherDeleteCapn, v *PusherDelete) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetAppID(v.AppID); err != nil {
+		return err
+	}
+	if err := c.SetPushKey(v.PushKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPusherDeleteCapn(c PusherDeleteCapn, v *PusherDelete) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.AppID, err = c.AppID(); err != nil {
+		return err
+	}
+	if v.PushKey, err = c.PushKey(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePusherDeleteByKeyCapn(c PusherDeleteByKeyCapn, v *PusherDeleteByKey) error {
+	if err := c.SetAppID(v.AppID); err != nil {
+		return err
+	}
+	if err := c.SetPushKey(v.PushKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPusherDeleteByKeyCapn(c PusherDeleteByKeyCapn, v *PusherDeleteByKey) error {
+	var err error
+	if v.AppID, err = c.AppID(); err != nil {
+		return err
+	}
+	if v.PushKey, err = c.PushKey(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePusherDeleteByKeyOnlyCapn(c PusherDeleteByKeyOnlyCapn, v *PusherDeleteByKeyOnly) error {
+	if err := c.SetPushKey(v.PushKey); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPusherDeleteByKeyOnlyCapn(c PusherDeleteByKeyOnlyCapn, v *PusherDeleteByKeyOnly) error {
+	var err error
+	if v.PushKey, err = c.PushKey(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePusherInsertCapn(c PusherInsertCapn, v *PusherInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetProfileTag(v.ProfileTag); err != nil {
+		return err
+	}
+	if err := c.SetKind(v.Kind); err != nil {
+		return err
+	}
+	if err := c.SetAppID(v.AppID); err != nil {
+		return err
+	}
+	if err := c.SetAppDisplayName(v.AppDisplayName); err != nil {
+		return err
+	}
+	if err := c.SetDeviceDisplayName(v.DeviceDisplayName); err != nil {
+		return err
+	}
+	if err := c.SetPushKey(v.PushKey); err != nil {
+		return err
+	}
+	c.SetPushKeyTs(v.PushKeyTs)
+	if err := c.SetLang(v.Lang); err != nil {
+		return err
+	}
+	if err := c.SetData(v.Data); err != nil {
+		return err
+	}
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPusherInsertCapn(c PusherInsertCapn, v *PusherInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.ProfileTag, err = c.ProfileTag(); err != nil {
+		return err
+	}
+	if v.Kind, err = c.Kind(); err != nil {
+		return err
+	}
+	if v.AppID, err = c.AppID(); err != nil {
+		return err
+	}
+	if v.AppDisplayName, err = c.AppDisplayName(); err != nil {
+		return err
+	}
+	if v.DeviceDisplayName, err = c.DeviceDisplayName(); err != nil {
+		return err
+	}
+	if v.PushKey, err = c.PushKey(); err != nil {
+		return err
+	}
+	v.PushKeyTs = c.PushKeyTs()
+	if v.Lang, err = c.Lang(); err != nil {
+		return err
+	}
+	if c.HasData() {
+		data, err := c.Data()
+		if err != nil {
+			return err
+		}
+		v.Data = append([]byte{}, data...)
+	}
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePushRuleInertCapn(c PushRuleInertCapn, v *PushRuleInert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRuleID(v.RuleID); err != nil {
+		return err
+	}
+	c.SetPriorityClass(int64(v.PriorityClass))
+	c.SetPriority(int64(v.Priority))
+	if err := c.SetConditions(v.Conditions); err != nil {
+		return err
+	}
+	if err := c.SetActions(v.Actions); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPushRuleInertCapn(c PushRuleInertCapn, v *PushRuleInert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RuleID, err = c.RuleID(); err != nil {
+		return err
+	}
+	v.PriorityClass = int(c.PriorityClass())
+	v.Priority = int(c.Priority())
+	if c.HasConditions() {
+		data, err := c.Conditions()
+		if err != nil {
+			return err
+		}
+		v.Conditions = append([]byte{}, data...)
+	}
+	if c.HasActions() {
+		data, err := c.Actions()
+		if err != nil {
+			return err
+		}
+		v.Actions = append([]byte{}, data...)
+	}
+	return nil
+}
+
+func writePushRuleDeleteCapn(c PushRuleDeleteCapn, v *PushRuleDelete) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRuleID(v.RuleID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPushRuleDeleteCapn(c PushRuleDeleteCapn, v *PushRuleDelete) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RuleID, err = c.RuleID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePushRuleEnableInsertCapn(c PushRuleEnableInsertCapn, v *PushRuleEnableInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRuleID(v.RuleID); err != nil {
+		return err
+	}
+	c.SetEnabled(int64(v.Enabled))
+	return nil
+}
+
+func readPushRuleEnableInsertCapn(c PushRuleEnableInsertCapn, v *PushRuleEnableInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RuleID, err = c.RuleID(); err != nil {
+		return err
+	}
+	v.Enabled = int(c.Enabled())
+	return nil
+}
+
+func writeE2EDBEventCapn(c E2EDBEventCapn, v *E2EDBEvent) error {
+	if v.KeyInsert != nil {
+		if s, err := c.NewKeyInsert(); err != nil {
+			return err
+		} else if err := writeKeyInsertCapn(s, v.KeyInsert); err != nil {
+			return err
+		}
+	}
+	if v.KeyDelete != nil {
+		if s, err := c.NewKeyDelete(); err != nil {
+			return err
+		} else if err := writeKeyDeleteCapn(s, v.KeyDelete); err != nil {
+			return err
+		}
+	}
+	if v.AlInsert != nil {
+		if s, err := c.NewAlInsert(); err != nil {
+			return err
+		} else if err := writeAlInsertCapn(s, v.AlInsert); err != nil {
+			return err
+		}
+	}
+	if v.DeviceKeyDelete != nil {
+		if s, err := c.NewDeviceKeyDelete(); err != nil {
+			return err
+		} else if err := writeDeviceKeyDeleteCapn(s, v.DeviceKeyDelete); err != nil {
+			return err
+		}
+	}
+	if v.MacKeyDelete != nil {
+		if s, err := c.NewMacKeyDelete(); err != nil {
+			return err
+		} else if err := writeMacKeyDeleteCapn(s, v.MacKeyDelete); err != nil {
+			return err
+		}
+	}
+	if v.CrossSigningKeyInsert != nil {
+		if s, err := c.NewCrossSigningKeyInsert(); err != nil {
+			return err
+		} else if err := writeCrossSigningKeyInsertCapn(s, v.CrossSigningKeyInsert); err != nil {
+			return err
+		}
+	}
+	if v.CrossSigningSigInsert != nil {
+		if s, err := c.NewCrossSigningSigInsert(); err != nil {
+			return err
+		} else if err := writeCrossSigningSigInsertCapn(s, v.CrossSigningSigInsert); err != nil {
+			return err
+		}
+	}
+	if v.KeyBackupVersionInsert != nil {
+		if s, err := c.NewKeyBackupVersionInsert(); err != nil {
+			return err
+		} else if err := writeKeyBackupVersionInsertCapn(s, v.KeyBackupVersionInsert); err != nil {
+			return err
+		}
+	}
+	if v.KeyBackupInsert != nil {
+		if s, err := c.NewKeyBackupInsert(); err != nil {
+			return err
+		} else if err := writeKeyBackupInsertCapn(s, v.KeyBackupInsert); err != nil {
+			return err
+		}
+	}
+	if v.KeyBackupDelete != nil {
+		if s, err := c.NewKeyBackupDelete(); err != nil {
+			return err
+		} else if err := writeKeyBackupDeleteCapn(s, v.KeyBackupDelete); err != nil {
+			return err
+		}
+	}
+	if v.FallbackKeyInsert != nil {
+		if s, err := c.NewFallbackKeyInsert(); err != nil {
+			return err
+		} else if err := writeFallbackKeyInsertCapn(s, v.FallbackKeyInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readE2EDBEventCapn(c E2EDBEventCapn, v *E2EDBEvent) error {
+	if c.HasKeyInsert() {
+		s, err := c.KeyInsert()
+		if err != nil {
+			return err
+		}
+		v.KeyInsert = new(KeyInsert)
+		if err := readKeyInsertCapn(s, v.KeyInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasKeyDelete() {
+		s, err := c.KeyDelete()
+		if err != nil {
+			return err
+		}
+		v.KeyDelete = new(KeyDelete)
+		if err := readKeyDeleteCapn(s, v.KeyDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasAlInsert() {
+		s, err := c.AlInsert()
+		if err != nil {
+			return err
+		}
+		v.AlInsert = new(AlInsert)
+		if err := readAlInsertCapn(s, v.AlInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasDeviceKeyDelete() {
+		s, err := c.DeviceKeyDelete()
+		if err != nil {
+			return err
+		}
+		v.DeviceKeyDelete = new(DeviceKeyDelete)
+		if err := readDeviceKeyDeleteCapn(s, v.DeviceKeyDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasMacKeyDelete() {
+		s, err := c.MacKeyDelete()
+		if err != nil {
+			return err
+		}
+		v.MacKeyDelete = new(MacKeyDelete)
+		if err := readMacKeyDeleteCapn(s, v.MacKeyDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasCrossSigningKeyInsert() {
+		s, err := c.CrossSigningKeyInsert()
+		if err != nil {
+			return err
+		}
+		v.CrossSigningKeyInsert = new(CrossSigningKeyInsert)
+		if err := readCrossSigningKeyInsertCapn(s, v.CrossSigningKeyInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasCrossSigningSigInsert() {
+		s, err := c.CrossSigningSigInsert()
+		if err != nil {
+			return err
+		}
+		v.CrossSigningSigInsert = new(CrossSigningSigInsert)
+		if err := readCrossSigningSigInsertCapn(s, v.CrossSigningSigInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasKeyBackupVersionInsert() {
+		s, err := c.KeyBackupVersionInsert()
+		if err != nil {
+			return err
+		}
+		v.KeyBackupVersionInsert = new(KeyBackupVersionInsert)
+		if err := readKeyBackupVersionInsertCapn(s, v.KeyBackupVersionInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasKeyBackupInsert() {
+		s, err := c.KeyBackupInsert()
+		if err != nil {
+			return err
+		}
+		v.KeyBackupInsert = new(KeyBackupInsert)
+		if err := readKeyBackupInsertCapn(s, v.KeyBackupInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasKeyBackupDelete() {
+		s, err := c.KeyBackupDelete()
+		if err != nil {
+			return err
+		}
+		v.KeyBackupDelete = new(KeyBackupDelete)
+		if err := readKeyBackupDeleteCapn(s, v.KeyBackupDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasFallbackKeyInsert() {
+		s, err := c.FallbackKeyInsert()
+		if err != nil {
+			return err
+		}
+		v.FallbackKeyInsert = new(FallbackKeyInsert)
+		if err := readFallbackKeyInsertCapn(s, v.FallbackKeyInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeKeyInsertCapn(c KeyInsertCapn, v *KeyInsert) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetKeyID(v.KeyID); err != nil {
+		return err
+	}
+	if err := c.SetKeyInfo(v.KeyInfo); err != nil {
+		return err
+	}
+	if err := c.SetAlgorithm(v.Algorithm); err != nil {
+		return err
+	}
+	if err := c.SetSignature(v.Signature); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readKeyInsertCapn(c KeyInsertCapn, v *KeyInsert) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.KeyID, err = c.KeyID(); err != nil {
+		return err
+	}
+	if v.KeyInfo, err = c.KeyInfo(); err != nil {
+		return err
+	}
+	if v.Algorithm, err = c.Algorithm(); err != nil {
+		return err
+	}
+	if v.Signature, err = c.Signature(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeKeyDeleteCapn(c KeyDeleteCapn, v *KeyDelete) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetKeyID(v.KeyID); err != nil {
+		return err
+	}
+	if err := c.SetAlgorithm(v.Algorithm); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readKeyDeleteCapn(c KeyDeleteCapn, v *KeyDelete) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.KeyID, err = c.KeyID(); err != nil {
+		return err
+	}
+	if v.Algorithm, err = c.Algorithm(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeAlInsertCapn(c AlInsertCapn, v *AlInsert) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetAlgorithm(v.Algorithm); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readAlInsertCapn(c AlInsertCapn, v *AlInsert) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Algorithm, err = c.Algorithm(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeDeviceKeyDeleteCapn(c DeviceKeyDeleteCapn, v *DeviceKeyDelete) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readDeviceKeyDeleteCapn(c DeviceKeyDeleteCapn, v *DeviceKeyDelete) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeMacKeyDeleteCapn(c MacKeyDeleteCapn, v *MacKeyDelete) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readMacKeyDeleteCapn(c MacKeyDeleteCapn, v *MacKeyDelete) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeCrossSigningKeyInsertCapn(c CrossSigningKeyInsertCapn, v *CrossSigningKeyInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetKeyType(v.KeyType); err != nil {
+		return err
+	}
+	if err := c.SetKeyInfo(v.KeyInfo); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readCrossSigningKeyInsertCapn(c CrossSigningKeyInsertCapn, v *CrossSigningKeyInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.KeyType, err = c.KeyType(); err != nil {
+		return err
+	}
+	if v.KeyInfo, err = c.KeyInfo(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeCrossSigningSigInsertCapn(c CrossSigningSigInsertCapn, v *CrossSigningSigInsert) error {
+	if err := c.SetOriginUserID(v.OriginUserID); err != nil {
+		return err
+	}
+	if err := c.SetOriginKeyID(v.OriginKeyID); err != nil {
+		return err
+	}
+	if err := c.SetTargetUserID(v.TargetUserID); err != nil {
+		return err
+	}
+	if err := c.SetTargetKeyID(v.TargetKeyID); err != nil {
+		return err
+	}
+	if err := c.SetSignature(v.Signature); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readCrossSigningSigInsertCapn(c CrossSigningSigInsertCapn, v *CrossSigningSigInsert) error {
+	var err error
+	if v.OriginUserID, err = c.OriginUserID(); err != nil {
+		return err
+	}
+	if v.OriginKeyID, err = c.OriginKeyID(); err != nil {
+		return err
+	}
+	if v.TargetUserID, err = c.TargetUserID(); err != nil {
+		return err
+	}
+	if v.TargetKeyID, err = c.TargetKeyID(); err != nil {
+		return err
+	}
+	if v.Signature, err = c.Signature(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeKeyBackupVersionInsertCapn(c KeyBackupVersionInsertCapn, v *KeyBackupVersionInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetVersion(v.Version)
+	if err := c.SetAlgorithm(v.Algorithm); err != nil {
+		return err
+	}
+	if err := c.SetAuthData(v.AuthData); err != nil {
+		return err
+	}
+	c.SetEtag(v.Etag)
+	c.SetDeleted(v.Deleted)
+	return nil
+}
+
+func readKeyBackupVersionInsertCapn(c KeyBackupVersionInsertCapn, v *KeyBackupVersionInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.Version = c.Version()
+	if v.Algorithm, err = c.Algorithm(); err != nil {
+		return err
+	}
+	if v.AuthData, err = c.AuthData(); err != nil {
+		return err
+	}
+	v.Etag = c.Etag()
+	v.Deleted = c.Deleted()
+	return nil
+}
+
+func writeKeyBackupInsertCapn(c KeyBackupInsertCapn, v *KeyBackupInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetVersion(v.Version)
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetSessionID(v.SessionID); err != nil {
+		return err
+	}
+	c.SetFirstMessageIndex(v.FirstMessageIndex)
+	c.SetForwardedCount(v.ForwardedCount)
+	c.SetIsVerified(v.IsVerified)
+	if err := c.SetSessionData(v.SessionData); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readKeyBackupInsertCapn(c KeyBackupInsertCapn, v *KeyBackupInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.Version = c.Version()
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.SessionID, err = c.SessionID(); err != nil {
+		return err
+	}
+	v.FirstMessageIndex = c.FirstMessageIndex()
+	v.ForwardedCount = c.ForwardedCount()
+	v.IsVerified = c.IsVerified()
+	if v.SessionData, err = c.SessionData(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeKeyBackupDeleteCapn(c KeyBackupDeleteCapn, v *KeyBackupDelete) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	c.SetVersion(v.Version)
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetSessionID(v.SessionID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readKeyBackupDeleteCapn(c KeyBackupDeleteCapn, v *KeyBackupDelete) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	v.Version = c.Version()
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.SessionID, err = c.SessionID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeFallbackKeyInsertCapn(c FallbackKeyInsertCapn, v *FallbackKeyInsert) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetKeyID(v.KeyID); err != nil {
+		return err
+	}
+	if err := c.SetKeyInfo(v.KeyInfo); err != nil {
+		return err
+	}
+	if err := c.SetAlgorithm(v.Algorithm); err != nil {
+		return err
+	}
+	if err := c.SetSignature(v.Signature); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	c.SetUsed(v.Used)
+	return nil
+}
+
+func readFallbackKeyInsertCapn(c FallbackKeyInsertCapn, v *FallbackKeyInsert) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.KeyID, err = c.KeyID(); err != nil {
+		return err
+	}
+	if v.KeyInfo, err = c.KeyInfo(); err != nil {
+		return err
+	}
+	if v.Algorithm, err = c.Algorithm(); err != nil {
+		return err
+	}
+	if v.Signature, err = c.Signature(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	v.Used = c.Used()
+	return nil
+}
+
+func writeSyncDBEventCapn(c SyncDBEventCapn, v *SyncDBEvent) error {
+	if v.SyncEventInsert != nil {
+		if s, err := c.NewSyncEventInsert(); err != nil {
+			return err
+		} else if err := writeSyncEventInsertCapn(s, v.SyncEventInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncRoomStateUpdate != nil {
+		if s, err := c.NewSyncRoomStateUpdate(); err != nil {
+			return err
+		} else if err := writeSyncRoomStateUpdateCapn(s, v.SyncRoomStateUpdate); err != nil {
+			return err
+		}
+	}
+	if v.SyncClientDataInsert != nil {
+		if s, err := c.NewSyncClientDataInsert(); err != nil {
+			return err
+		} else if err := writeSyncClientDataInsertCapn(s, v.SyncClientDataInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncKeyStreamInsert != nil {
+		if s, err := c.NewSyncKeyStreamInsert(); err != nil {
+			return err
+		} else if err := writeSyncKeyStreamInsertCapn(s, v.SyncKeyStreamInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncReceiptInsert != nil {
+		if s, err := c.NewSyncReceiptInsert(); err != nil {
+			return err
+		} else if err := writeSyncReceiptInsertCapn(s, v.SyncReceiptInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncStdEventInsert != nil {
+		if s, err := c.NewSyncStdEventInsert(); err != nil {
+			return err
+		} else if err := writeSyncStdEventInsertCapn(s, v.SyncStdEventInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncStdEventDelete != nil {
+		if s, err := c.NewSyncStdEventDelete(); err != nil {
+			return err
+		} else if err := writeSyncStdEventDeleteCapn(s, v.SyncStdEventDelete); err != nil {
+			return err
+		}
+	}
+	if v.SyncPresenceInsert != nil {
+		if s, err := c.NewSyncPresenceInsert(); err != nil {
+			return err
+		} else if err := writeSyncPresenceInsertCapn(s, v.SyncPresenceInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncUserReceiptInsert != nil {
+		if s, err := c.NewSyncUserReceiptInsert(); err != nil {
+			return err
+		} else if err := writeSyncUserReceiptInsertCapn(s, v.SyncUserReceiptInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncMacStdEventDelete != nil {
+		if s, err := c.NewSyncMacStdEventDelete(); err != nil {
+			return err
+		} else if err := writeSyncMacStdEventDeleteCapn(s, v.SyncMacStdEventDelete); err != nil {
+			return err
+		}
+	}
+	if v.SyncUserTimeLineInsert != nil {
+		if s, err := c.NewSyncUserTimeLineInsert(); err != nil {
+			return err
+		} else if err := writeSyncUserTimeLineInsertCapn(s, v.SyncUserTimeLineInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncOutputMinStreamInsert != nil {
+		if s, err := c.NewSyncOutputMinStreamInsert(); err != nil {
+			return err
+		} else if err := writeSyncOutputMinStreamInsertCapn(s, v.SyncOutputMinStreamInsert); err != nil {
+			return err
+		}
+	}
+	if v.SyncEventUpdate != nil {
+		if s, err := c.NewSyncEventUpdate(); err != nil {
+			return err
+		} else if err := writeSyncEventUpdateCapn(s, v.SyncEventUpdate); err != nil {
+			return err
+		}
+	}
+	if v.SyncEventUpdateContent != nil {
+		if s, err := c.NewSyncEventUpdateContent(); err != nil {
+			return err
+		} else if err := writeSyncEventUpdateContentCapn(s, v.SyncEventUpdateContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readSyncDBEventCapn(c SyncDBEventCapn, v *SyncDBEvent) error {
+	if c.HasSyncEventInsert() {
+		s, err := c.SyncEventInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncEventInsert = new(SyncEventInsert)
+		if err := readSyncEventInsertCapn(s, v.SyncEventInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncRoomStateUpdate() {
+		s, err := c.SyncRoomStateUpdate()
+		if err != nil {
+			return err
+		}
+		v.SyncRoomStateUpdate = new(SyncRoomStateUpdate)
+		if err := readSyncRoomStateUpdateCapn(s, v.SyncRoomStateUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncClientDataInsert() {
+		s, err := c.SyncClientDataInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncClientDataInsert = new(SyncClientDataInsert)
+		if err := readSyncClientDataInsertCapn(s, v.SyncClientDataInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncKeyStreamInsert() {
+		s, err := c.SyncKeyStreamInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncKeyStreamInsert = new(SyncKeyStreamInsert)
+		if err := readSyncKeyStreamInsertCapn(s, v.SyncKeyStreamInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncReceiptInsert() {
+		s, err := c.SyncReceiptInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncReceiptInsert = new(SyncReceiptInsert)
+		if err := readSyncReceiptInsertCapn(s, v.SyncReceiptInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncStdEventInsert() {
+		s, err := c.SyncStdEventInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncStdEventInsert = new(SyncStdEventInsert)
+		if err := readSyncStdEventInsertCapn(s, v.SyncStdEventInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncStdEventDelete() {
+		s, err := c.SyncStdEventDelete()
+		if err != nil {
+			return err
+		}
+		v.SyncStdEventDelete = new(SyncStdEventDelete)
+		if err := readSyncStdEventDeleteCapn(s, v.SyncStdEventDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncPresenceInsert() {
+		s, err := c.SyncPresenceInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncPresenceInsert = new(SyncPresenceInsert)
+		if err := readSyncPresenceInsertCapn(s, v.SyncPresenceInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncUserReceiptInsert() {
+		s, err := c.SyncUserReceiptInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncUserReceiptInsert = new(SyncUserReceiptInsert)
+		if err := readSyncUserReceiptInsertCapn(s, v.SyncUserReceiptInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncMacStdEventDelete() {
+		s, err := c.SyncMacStdEventDelete()
+		if err != nil {
+			return err
+		}
+		v.SyncMacStdEventDelete = new(SyncMacStdEventDelete)
+		if err := readSyncMacStdEventDeleteCapn(s, v.SyncMacStdEventDelete); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncUserTimeLineInsert() {
+		s, err := c.SyncUserTimeLineInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncUserTimeLineInsert = new(SyncUserTimeLineInsert)
+		if err := readSyncUserTimeLineInsertCapn(s, v.SyncUserTimeLineInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncOutputMinStreamInsert() {
+		s, err := c.SyncOutputMinStreamInsert()
+		if err != nil {
+			return err
+		}
+		v.SyncOutputMinStreamInsert = new(SyncOutputMinStreamInsert)
+		if err := readSyncOutputMinStreamInsertCapn(s, v.SyncOutputMinStreamInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncEventUpdate() {
+		s, err := c.SyncEventUpdate()
+		if err != nil {
+			return err
+		}
+		v.SyncEventUpdate = new(SyncEventUpdate)
+		if err := readSyncEventUpdateCapn(s, v.SyncEventUpdate); err != nil {
+			return err
+		}
+	}
+	if c.HasSyncEventUpdateContent() {
+		s, err := c.SyncEventUpdateContent()
+		if err != nil {
+			return err
+		}
+		v.SyncEventUpdateContent = new(SyncEventUpdateContent)
+		if err := readSyncEventUpdateContentCapn(s, v.SyncEventUpdateContent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeSyncEventInsertCapn(c SyncEventInsertCapn, v *SyncEventInsert) error {
+	c.SetPos(v.Pos)
+	if err := c.SetRoomId(v.RoomId); err != nil {
+		return err
+	}
+	if err := c.SetEventId(v.EventId); err != nil {
+		return err
+	}
+	if err := c.SetEventJson(v.EventJson); err != nil {
+		return err
+	}
+	if v.Add != nil {
+		l, err := c.NewAdd(int32(len(v.Add)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.Add {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	if v.Remove != nil {
+		l, err := c.NewRemove(int32(len(v.Remove)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.Remove {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.SetDevice(v.Device); err != nil {
+		return err
+	}
+	if err := c.SetTxnId(v.TxnId); err != nil {
+		return err
+	}
+	if err := c.SetType(v.Type); err != nil {
+		return err
+	}
+	c.SetDomainOffset(v.DomainOffset)
+	c.SetDepth(v.Depth)
+	if err := c.SetDomain(v.Domain); err != nil {
+		return err
+	}
+	c.SetOriginTs(v.OriginTs)
+	return nil
+}
+
+func readSyncEventInsertCapn(c SyncEventInsertCapn, v *SyncEventInsert) error {
+	var err error
+	v.Pos = c.Pos()
+	if v.RoomId, err = c.RoomId(); err != nil {
+		return err
+	}
+	if v.EventId, err = c.EventId(); err != nil {
+		return err
+	}
+	if c.HasEventJson() {
+		data, err := c.EventJson()
+		if err != nil {
+			return err
+		}
+		v.EventJson = append([]byte{}, data...)
+	}
+	if c.HasAdd() {
+		l, err := c.Add()
+		if err != nil {
+			return err
+		}
+		v.Add = make([]string, l.Len())
+		for i := range v.Add {
+			if v.Add[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	if c.HasRemove() {
+		l, err := c.Remove()
+		if err != nil {
+			return err
+		}
+		v.Remove = make([]string, l.Len())
+		for i := range v.Remove {
+			if v.Remove[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	if v.Device, err = c.Device(); err != nil {
+		return err
+	}
+	if v.TxnId, err = c.TxnId(); err != nil {
+		return err
+	}
+	if v.Type, err = c.Type(); err != nil {
+		return err
+	}
+	v.DomainOffset = c.DomainOffset()
+	v.Depth = c.Depth()
+	if v.Domain, err = c.Domain(); err != nil {
+		return err
+	}
+	v.OriginTs = c.OriginTs()
+	return nil
+}
+
+func writeSyncRoomStateUpdateCapn(c SyncRoomStateUpdateCapn, v *SyncRoomStateUpdate) error {
+	if err := c.SetRoomId(v.RoomId); err != nil {
+		return err
+	}
+	if err := c.SetEventId(v.EventId); err != nil {
+		return err
+	}
+	if err := c.SetType(v.Type); err != nil {
+		return err
+	}
+	if err := c.SetEventJson(v.EventJson); err != nil {
+		return err
+	}
+	if err := c.SetEventStateKey(v.EventStateKey); err != nil {
+		return err
+	}
+	if err := c.SetMembership(v.Membership); err != nil {
+		return err
+	}
+	c.SetAddPos(v.AddPos)
+	return nil
+}
+
+func readSyncRoomStateUpdateCapn(c SyncRoomStateUpdateCapn, v *SyncRoomStateUpdate) error {
+	var err error
+	if v.RoomId, err = c.RoomId(); err != nil {
+		return err
+	}
+	if v.EventId, err = c.EventId(); err != nil {
+		return err
+	}
+	if v.Type, err = c.Type(); err != nil {
+		return err
+	}
+	if c.HasEventJson() {
+		data, err := c.EventJson()
+		if err != nil {
+			return err
+		}
+		v.EventJson = append([]byte{}, data...)
+	}
+	if v.EventStateKey, err = c.EventStateKey(); err != nil {
+		return err
+	}
+	if v.Membership, err = c.Membership(); err != nil {
+		return err
+	}
+	v.AddPos = c.AddPos()
+	return nil
+}
+
+func writeSyncClientDataInsertCapn(c SyncClientDataInsertCapn, v *SyncClientDataInsert) error {
+	c.SetId(v.ID)
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetDataType(v.DataType); err != nil {
+		return err
+	}
+	if err := c.SetStreamType(v.StreamType); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncClientDataInsertCapn(c SyncClientDataInsertCapn, v *SyncClientDataInsert) error {
+	var err error
+	v.ID = c.Id()
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.DataType, err = c.DataType(); err != nil {
+		return err
+	}
+	if v.StreamType, err = c.StreamType(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncKeyStreamInsertCapn(c SyncKeyStreamInsertCapn, v *SyncKeyStreamInsert) error {
+	c.SetId(v.ID)
+	if err := c.SetChangedUserID(v.ChangedUserID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncKeyStreamInsertCapn(c SyncKeyStreamInsertCapn, v *SyncKeyStreamInsert) error {
+	var err error
+	v.ID = c.Id()
+	if v.ChangedUserID, err = c.ChangedUserID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncReceiptInsertCapn(c SyncReceiptInsertCapn, v *SyncReceiptInsert) error {
+	c.SetId(v.ID)
+	c.SetEvtOffset(v.EvtOffset)
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncReceiptInsertCapn(c SyncReceiptInsertCapn, v *SyncReceiptInsert) error {
+	var err error
+	v.ID = c.Id()
+	v.EvtOffset = c.EvtOffset()
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.Content, err = c.Content(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncStdEventInsertCapn(c SyncStdEventInsertCapn, v *SyncStdEventInsert) error {
+	c.SetId(v.ID)
+	if s, err := c.NewStdEvent(); err != nil {
+		return err
+	} else if err := writeStdHolderCapn(s, &v.StdEvent); err != nil {
+		return err
+	}
+	if err := c.SetTargetUID(v.TargetUID); err != nil {
+		return err
+	}
+	if err := c.SetTargetDevice(v.TargetDevice); err != nil {
+		return err
+	}
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncStdEventInsertCapn(c SyncStdEventInsertCapn, v *SyncStdEventInsert) error {
+	var err error
+	v.ID = c.Id()
+	if s, err := c.StdEvent(); err != nil {
+		return err
+	} else if err := readStdHolderCapn(s, &v.StdEvent); err != nil {
+		return err
+	}
+	if v.TargetUID, err = c.TargetUID(); err != nil {
+		return err
+	}
+	if v.TargetDevice, err = c.TargetDevice(); err != nil {
+		return err
+	}
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeStdHolderCapn(c StdHolderCapn, v *syncapitypes.StdHolder) error {
+	c.SetStreamID(v.StreamID)
+	if err := c.SetSender(v.Sender); err != nil {
+		return err
+	}
+	if err := c.SetEventTyp(v.EventTyp); err != nil {
+		return err
+	}
+	if err := c.SetEvent(v.Event); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readStdHolderCapn(c StdHolderCapn, v *syncapitypes.StdHolder) error {
+	var err error
+	v.StreamID = c.StreamID()
+	if v.Sender, err = c.Sender(); err != nil {
+		return err
+	}
+	if v.EventTyp, err = c.EventTyp(); err != nil {
+		return err
+	}
+	if c.HasEvent() {
+		data, err := c.Event()
+		if err != nil {
+			return err
+		}
+		v.Event = append([]byte{}, data...)
+	}
+	return nil
+}
+
+func writeSyncStdEventDeleteCapn(c SyncStdEventDeleteCapn, v *SyncStdEventDelete) error {
+	c.SetId(v.ID)
+	if err := c.SetTargetUID(v.TargetUID); err != nil {
+		return err
+	}
+	if err := c.SetTargetDevice(v.TargetDevice); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncStdEventDeleteCapn(c SyncStdEventDeleteCapn, v *SyncStdEventDelete) error {
+	var err error
+	v.ID = c.Id()
+	if v.TargetUID, err = c.TargetUID(); err != nil {
+		return err
+	}
+	if v.TargetDevice, err = c.TargetDevice(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncPresenceInsertCapn(c SyncPresenceInsertCapn, v *SyncPresenceInsert) error {
+	c.SetId(v.ID)
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncPresenceInsertCapn(c SyncPresenceInsertCapn, v *SyncPresenceInsert) error {
+	var err error
+	v.ID = c.Id()
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Content, err = c.Content(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncUserReceiptInsertCapn(c SyncUserReceiptInsertCapn, v *SyncUserReceiptInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	c.SetEvtOffset(v.EvtOffset)
+	return nil
+}
+
+func readSyncUserReceiptInsertCapn(c SyncUserReceiptInsertCapn, v *SyncUserReceiptInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.Content, err = c.Content(); err != nil {
+		return err
+	}
+	v.EvtOffset = c.EvtOffset()
+	return nil
+}
+
+func writeSyncMacStdEventDeleteCapn(c SyncMacStdEventDeleteCapn, v *SyncMacStdEventDelete) error {
+	if err := c.SetIdentifier(v.Identifier); err != nil {
+		return err
+	}
+	if err := c.SetTargetUID(v.TargetUID); err != nil {
+		return err
+	}
+	if err := c.SetTargetDevice(v.TargetDevice); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncMacStdEventDeleteCapn(c SyncMacStdEventDeleteCapn, v *SyncMacStdEventDelete) error {
+	var err error
+	if v.Identifier, err = c.Identifier(); err != nil {
+		return err
+	}
+	if v.TargetUID, err = c.TargetUID(); err != nil {
+		return err
+	}
+	if v.TargetDevice, err = c.TargetDevice(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncUserTimeLineInsertCapn(c SyncUserTimeLineInsertCapn, v *SyncUserTimeLineInsert) error {
+	c.SetId(v.ID)
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	c.SetEventNID(v.EventNID)
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetRoomState(v.RoomState); err != nil {
+		return err
+	}
+	c.SetTs(v.Ts)
+	c.SetEventOffset(v.EventOffset)
+	return nil
+}
+
+func readSyncUserTimeLineInsertCapn(c SyncUserTimeLineInsertCapn, v *SyncUserTimeLineInsert) error {
+	var err error
+	v.ID = c.Id()
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	v.EventNID = c.EventNID()
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.RoomState, err = c.RoomState(); err != nil {
+		return err
+	}
+	v.Ts = c.Ts()
+	v.EventOffset = c.EventOffset()
+	return nil
+}
+
+func writeSyncOutputMinStreamInsertCapn(c SyncOutputMinStreamInsertCapn, v *SyncOutputMinStreamInsert) error {
+	c.SetId(v.ID)
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncOutputMinStreamInsertCapn(c SyncOutputMinStreamInsertCapn, v *SyncOutputMinStreamInsert) error {
+	var err error
+	v.ID = c.Id()
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncEventUpdateCapn(c SyncEventUpdateCapn, v *SyncEventUpdate) error {
+	c.SetDomainOffset(v.DomainOffset)
+	if err := c.SetDomain(v.Domain); err != nil {
+		return err
+	}
+	c.SetOriginTs(v.OriginTs)
+	if err := c.SetRoomId(v.RoomId); err != nil {
+		return err
+	}
+	if err := c.SetEventId(v.EventId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncEventUpdateCapn(c SyncEventUpdateCapn, v *SyncEventUpdate) error {
+	var err error
+	v.DomainOffset = c.DomainOffset()
+	if v.Domain, err = c.Domain(); err != nil {
+		return err
+	}
+	v.OriginTs = c.OriginTs()
+	if v.RoomId, err = c.RoomId(); err != nil {
+		return err
+	}
+	if v.EventId, err = c.EventId(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeSyncEventUpdateContentCapn(c SyncEventUpdateContentCapn, v *SyncEventUpdateContent) error {
+	if err := c.SetEventID(v.EventID); err != nil {
+		return err
+	}
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	if err := c.SetEventType(v.EventType); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readSyncEventUpdateContentCapn(c SyncEventUpdateContentCapn, v *SyncEventUpdateContent) error {
+	var err error
+	if v.EventID, err = c.EventID(); err != nil {
+		return err
+	}
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.Content, err = c.Content(); err != nil {
+		return err
+	}
+	if v.EventType, err = c.EventType(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writePublicRoomDBEventCapn(c PublicRoomDBEventCapn, v *PublicRoomDBEvent) error {
+	if v.PublicRoomInsert != nil {
+		if s, err := c.NewPublicRoomInsert(); err != nil {
+			return err
+		} else if err := writePublicRoomInsertCapn(s, v.PublicRoomInsert); err != nil {
+			return err
+		}
+	}
+	if v.PublicRoomUpdate != nil {
+		if s, err := c.NewPublicRoomUpdate(); err != nil {
+			return err
+		} else if err := writePublicRoomUpdateCapn(s, v.PublicRoomUpdate); err != nil {
+			return err
+		}
+	}
+	if v.PublicRoomJoined != nil {
+		c.SetPublicRoomJoinedSet(true)
+		if err := c.SetPublicRoomJoined(*v.PublicRoomJoined); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPublicRoomDBEventCapn(c PublicRoomDBEventCapn, v *PublicRoomDBEvent) error {
+	if c.HasPublicRoomInsert() {
+		s, err := c.PublicRoomInsert()
+		if err != nil {
+			return err
+		}
+		v.PublicRoomInsert = new(PublicRoomInsert)
+		if err := readPublicRoomInsertCapn(s, v.PublicRoomInsert); err != nil {
+			return err
+		}
+	}
+	if c.HasPublicRoomUpdate() {
+		s, err := c.PublicRoomUpdate()
+		if err != nil {
+			return err
+		}
+		v.PublicRoomUpdate = new(PublicRoomUpdate)
+		if err := readPublicRoomUpdateCapn(s, v.PublicRoomUpdate); err != nil {
+			return err
+		}
+	}
+	if c.PublicRoomJoinedSet() {
+		s, err := c.PublicRoomJoined()
+		if err != nil {
+			return err
+		}
+		v.PublicRoomJoined = &s
+	}
+	return nil
+}
+
+func writePublicRoomInsertCapn(c PublicRoomInsertCapn, v *PublicRoomInsert) error {
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	c.SetSeqID(v.SeqID)
+	c.SetJoinedMembers(v.JoinedMembers)
+	if v.Aliases != nil {
+		l, err := c.NewAliases(int32(len(v.Aliases)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.Aliases {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.SetCanonicalAlias(v.CanonicalAlias); err != nil {
+		return err
+	}
+	if err := c.SetName(v.Name); err != nil {
+		return err
+	}
+	if err := c.SetTopic(v.Topic); err != nil {
+		return err
+	}
+	c.SetWorldReadable(v.WorldReadable)
+	c.SetGuestCanJoin(v.GuestCanJoin)
+	if err := c.SetAvatarUrl(v.AvatarUrl); err != nil {
+		return err
+	}
+	c.SetVisibility(v.Visibility)
+	return nil
+}
+
+func readPublicRoomInsertCapn(c PublicRoomInsertCapn, v *PublicRoomInsert) error {
+	var err error
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	v.SeqID = c.SeqID()
+	v.JoinedMembers = c.JoinedMembers()
+	if c.HasAliases() {
+		l, err := c.Aliases()
+		if err != nil {
+			return err
+		}
+		v.Aliases = make([]string, l.Len())
+		for i := range v.Aliases {
+			if v.Aliases[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	if v.CanonicalAlias, err = c.CanonicalAlias(); err != nil {
+		return err
+	}
+	if v.Name, err = c.Name(); err != nil {
+		return err
+	}
+	if v.Topic, err = c.Topic(); err != nil {
+		return err
+	}
+	v.WorldReadable = c.WorldReadable()
+	v.GuestCanJoin = c.GuestCanJoin()
+	if v.AvatarUrl, err = c.AvatarUrl(); err != nil {
+		return err
+	}
+	v.Visibility = c.Visibility()
+	return nil
+}
+
+func writePublicRoomUpdateCapn(c PublicRoomUpdateCapn, v *PublicRoomUpdate) error {
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetAttrName(v.AttrName); err != nil {
+		return err
+	}
+	if v.AttrValue != nil {
+		data, err := json.Marshal(v.AttrValue)
+		if err != nil {
+			return err
+		}
+		if err := c.SetAttrValue(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPublicRoomUpdateCapn(c PublicRoomUpdateCapn, v *PublicRoomUpdate) error {
+	var err error
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.AttrName, err = c.AttrName(); err != nil {
+		return err
+	}
+	if c.HasAttrValue() {
+		data, err := c.AttrValue()
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &v.AttrValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePresenceDBEventCapn(c PresenceDBEventCapn, v *PresenceDBEvent) error {
+	if v.PresencesInsert != nil {
+		if s, err := c.NewPresencesInsert(); err != nil {
+			return err
+		} else if err := writePresencesInsertCapn(s, v.PresencesInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readPresenceDBEventCapn(c PresenceDBEventCapn, v *PresenceDBEvent) error {
+	if c.HasPresencesInsert() {
+		s, err := c.PresencesInsert()
+		if err != nil {
+			return err
+		}
+		v.PresencesInsert = new(PresencesInsert)
+		if err := readPresencesInsertCapn(s, v.PresencesInsert); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writePresencesInsertCapn(c PresencesInsertCapn, v *PresencesInsert) error {
+	if err := c.SetUserID(v.UserID); err != nil {
+		return err
+	}
+	if err := c.SetStatus(v.Status); err != nil {
+		return err
+	}
+	if err := c.SetStatusMsg(v.StatusMsg); err != nil {
+		return err
+	}
+	if err := c.SetExtStatusMsg(v.ExtStatusMsg); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readPresencesInsertCapn(c PresencesInsertCapn, v *PresencesInsert) error {
+	var err error
+	if v.UserID, err = c.UserID(); err != nil {
+		return err
+	}
+	if v.Status, err = c.Status(); err != nil {
+		return err
+	}
+	if v.StatusMsg, err = c.StatusMsg(); err != nil {
+		return err
+	}
+	if v.ExtStatusMsg, err = c.ExtStatusMsg(); err != nil {
+		return err
+	}
+	return nil
+}
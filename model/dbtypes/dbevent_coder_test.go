@@ -0,0 +1,80 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package dbtypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+)
+
+func TestDBEventCapnRoundTrip(t *testing.T) {
+	joined := "3"
+	events := []DBEvent{
+		{
+			Key:      EventInsertKey,
+			Category: CATEGORY_ROOM_DB_EVENT,
+			Uid:      42,
+			RoomDBEvents: RoomDBEvent{EventInsert: &EventInsert{
+				RoomNid:       1,
+				EventType:     "m.room.message",
+				EventId:       "$event:example.com",
+				RefSha:        []byte{1, 2, 3},
+				AuthEventNids: []int64{4, 5},
+				Depth:         6,
+				Sha:           []byte{},
+			}},
+		},
+		{
+			Key:        DeviceInsertKey,
+			Category:   CATEGORY_DEVICE_DB_EVENT,
+			IsRecovery: true,
+			DeviceDBEvents: DeviceDBEvent{DeviceInsert: &DeviceInsert{
+				DeviceID:  "DEVICE",
+				UserID:    "@alice:example.com",
+				CreatedTs: 1600000000000,
+			}},
+		},
+		{
+			Category: CATEGORY_PUBLICROOM_DB_EVENT,
+			PublicRoomDBEvents: PublicRoomDBEvent{
+				PublicRoomUpdate: &PublicRoomUpdate{RoomID: "!room:example.com", AttrName: "name", AttrValue: "room"},
+				PublicRoomJoined: &joined,
+			},
+		},
+		{
+			Category: CATEGORY_SYNC_DB_EVENT,
+			SyncDBEvents: SyncDBEvent{SyncStdEventInsert: &SyncStdEventInsert{
+				ID:       7,
+				StdEvent: syncapitypes.StdHolder{StreamID: 7, Sender: "@alice:example.com", Event: []byte(`{}`)},
+			}},
+		},
+	}
+	for i := range events {
+		data, err := core.EncodeMsg(core.FORMAT_CAPN, &events[i])
+		if err != nil {
+			t.Fatalf("encode %d: %v", i, err)
+		}
+		var ev DBEvent
+		if err := core.DecodeMsg(core.FORMAT_CAPN, data, &ev); err != nil {
+			t.Fatalf("decode %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(ev, events[i]) {
+			t.Errorf("round trip %d: got %+v want %+v", i, ev, events[i])
+		}
+	}
+}
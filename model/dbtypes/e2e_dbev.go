@@ -25,7 +25,13 @@ const (
 	MacOneTimeKeyDeleteKey    int64 = 7
 	MacDeviceKeyDeleteKey     int64 = 8
 	MacDeviceAlDeleteKey      int64 = 9
-	E2EMaxKey                 int64 = 10
+	CrossSigningKeyInsertKey  int64 = 10
+	CrossSigningSigInsertKey  int64 = 11
+	KeyBackupVersionInsertKey int64 = 12
+	KeyBackupInsertKey        int64 = 13
+	KeyBackupDeleteKey        int64 = 14
+	FallbackKeyInsertKey      int64 = 15
+	E2EMaxKey                 int64 = 16
 )
 
 func E2EDBEventKeyToStr(key int64) string {
@@ -50,6 +56,18 @@ func E2EDBEventKeyToStr(key int64) string {
 		return "MacDeviceKeyDeleteKey"
 	case MacDeviceAlDeleteKey:
 		return "MacDeviceAlDeleteKey"
+	case CrossSigningKeyInsertKey:
+		return "CrossSigningKeyInsert"
+	case CrossSigningSigInsertKey:
+		return "CrossSigningSigInsert"
+	case KeyBackupVersionInsertKey:
+		return "KeyBackupVersionInsert"
+	case KeyBackupInsertKey:
+		return "KeyBackupInsert"
+	case KeyBackupDeleteKey:
+		return "KeyBackupDelete"
+	case FallbackKeyInsertKey:
+		return "FallbackKeyInsert"
 	default:
 		return "unknown"
 	}
@@ -63,6 +81,16 @@ func E2EDBEventKeyToTableStr(key int64) string {
 		return "encrypt_onetime_key"
 	case AlInsertKey, DeviceAlDeleteKey, MacDeviceAlDeleteKey:
 		return "encrypt_algorithm"
+	case CrossSigningKeyInsertKey:
+		return "encrypt_cross_signing_key"
+	case CrossSigningSigInsertKey:
+		return "encrypt_cross_signing_sig"
+	case KeyBackupVersionInsertKey:
+		return "encrypt_key_backup_version"
+	case KeyBackupInsertKey, KeyBackupDeleteKey:
+		return "encrypt_key_backup"
+	case FallbackKeyInsertKey:
+		return "encrypt_fallback_key"
 	default:
 		return "unknown"
 	}
@@ -74,6 +102,15 @@ type E2EDBEvent struct {
 	AlInsert        *AlInsert        `json:"al_insert,omitempty"`
 	DeviceKeyDelete *DeviceKeyDelete `json:"device_key_delete,omitempty"`
 	MacKeyDelete    *MacKeyDelete    `json:"mac_key_delete,omitempty"`
+
+	CrossSigningKeyInsert *CrossSigningKeyInsert `json:"cross_signing_key_insert,omitempty"`
+	CrossSigningSigInsert *CrossSigningSigInsert `json:"cross_signing_sig_insert,omitempty"`
+
+	KeyBackupVersionInsert *KeyBackupVersionInsert `json:"key_backup_version_insert,omitempty"`
+	KeyBackupInsert        *KeyBackupInsert        `json:"key_backup_insert,omitempty"`
+	KeyBackupDelete        *KeyBackupDelete        `json:"key_backup_delete,omitempty"`
+
+	FallbackKeyInsert *FallbackKeyInsert `json:"fallback_key_insert,omitempty"`
 }
 
 type DeviceKeyDelete struct {
@@ -110,3 +147,59 @@ type MacKeyDelete struct {
 	UserID     string `json:"user_id"`
 	Identifier string `json:"identifier"`
 }
+
+type CrossSigningKeyInsert struct {
+	UserID  string `json:"user_id"`
+	KeyType string `json:"key_type"`
+	KeyInfo string `json:"key_info"`
+}
+
+type CrossSigningSigInsert struct {
+	OriginUserID string `json:"origin_user_id"`
+	OriginKeyID  string `json:"origin_key_id"`
+	TargetUserID string `json:"target_user_id"`
+	TargetKeyID  string `json:"target_key_id"`
+	Signature    string `json:"signature"`
+}
+
+type KeyBackupVersionInsert struct {
+	UserID    string `json:"user_id"`
+	Version   int64  `json:"version"`
+	Algorithm string `json:"algorithm"`
+	AuthData  string `json:"auth_data"`
+	Etag      int64  `json:"etag"`
+	Deleted   bool   `json:"deleted"`
+}
+
+type KeyBackupInsert struct {
+	UserID            string `json:"user_id"`
+	Version           int64  `json:"version"`
+	RoomID            string `json:"room_id"`
+	SessionID         string `json:"session_id"`
+	FirstMessageIndex int64  `json:"first_message_index"`
+	ForwardedCount    int64  `json:"forwarded_count"`
+	IsVerified        bool   `json:"is_verified"`
+	SessionData       string `json:"session_data"`
+}
+
+// KeyBackupDelete deletes a session of the backup, all sessions of the room
+// when SessionID is empty and the whole backup when RoomID is empty too
+type KeyBackupDelete struct {
+	UserID    string `json:"user_id"`
+	Version   int64  `json:"version"`
+	RoomID    string `json:"room_id"`
+	SessionID string `json:"session_id"`
+}
+
+// FallbackKeyInsert replaces the fallback key of the device for the algorithm,
+// a used key is kept and marked used until the device uploads a new one
+type FallbackKeyInsert struct {
+	DeviceID   string `json:"device_id"`
+	UserID     string `json:"user_id"`
+	KeyID      string `json:"key_id"`
+	KeyInfo    string `json:"key_info"`
+	Algorithm  string `json:"algorithm"`
+	Signature  string `json:"signature"`
+	Identifier string `json:"identifier"`
+	Used       bool   `json:"used"`
+}
@@ -17,6 +17,7 @@ package repos
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -35,6 +36,7 @@ type KeyChangeStreamRepo struct {
 	cache               service.Cache
 	repo                *sync.Map
 	OneTimeKeyCountInfo *sync.Map
+	FallbackKeyInfo     *sync.Map
 	maxPosition         sync.Map
 	ready               sync.Map
 	loading             sync.Map
@@ -49,6 +51,7 @@ func NewKeyChangeStreamRepo(
 	tls.repo = new(sync.Map)
 	tls.userTimeLine = userTimeLine
 	tls.OneTimeKeyCountInfo = new(sync.Map)
+	tls.FallbackKeyInfo = new(sync.Map)
 
 	return tls
 }
@@ -99,9 +102,36 @@ func (tl *KeyChangeStreamRepo) UpdateOneTimeKeyCount(userID string, deviceID str
 	}
 
 	tl.OneTimeKeyCountInfo.Store(key, alCountMap)
+
+	// claims and uploads of fallback keys notify as one time key updates too
+	unusedTypes := []string{}
+	fallbackKeys, ok := tl.cache.GetFallbackKeys(userID, deviceID)
+	if ok {
+		for al, fallbackKey := range fallbackKeys {
+			if !fallbackKey.Used {
+				unusedTypes = append(unusedTypes, al)
+			}
+		}
+	}
+	sort.Strings(unusedTypes)
+	tl.FallbackKeyInfo.Store(key, unusedTypes)
 	return nil
 }
 
+func (tl *KeyChangeStreamRepo) GetUnusedFallbackKeyTypes(userID string, deviceID string) (unusedTypes []string, err error) {
+	key := fmt.Sprintf("%s:%s", userID, deviceID)
+
+	if val, ok := tl.FallbackKeyInfo.Load(key); ok {
+		return val.([]string), nil
+	} else {
+		err := tl.UpdateOneTimeKeyCount(userID, deviceID)
+		if err != nil {
+			return nil, err
+		}
+		return tl.GetUnusedFallbackKeyTypes(userID, deviceID)
+	}
+}
+
 func (tl *KeyChangeStreamRepo) AddKeyChangeStream(ctx context.Context,
 	dataStream *types.KeyChangeStream, offset int64, broadCast bool) {
 	keyChangeStream := new(feedstypes.KeyChangeStream)
@@ -253,6 +253,15 @@ func (tl *RoomHistoryTimeLineRepo) GetRoomLastOffset(roomID string) int64 {
 	return int64(-1)
 }
 
+// InvalidateRoom drops the cached history of a room after it was purged, it
+// is loaded from the db again on the next access
+func (tl *RoomHistoryTimeLineRepo) InvalidateRoom(roomID string) {
+	tl.ready.Delete(roomID)
+	tl.repo.remove(roomID)
+	tl.roomMinStream.Delete(roomID)
+	log.Infof("RoomHistoryTimeLineRepo invalidate room:%s", roomID)
+}
+
 func (tl *RoomHistoryTimeLineRepo) GetRoomMinStream(ctx context.Context, roomID string) int64 {
 	if val, ok := tl.roomMinStream.Load(roomID); ok {
 		tl.queryHitCounter.WithLabelValues("cache", "RoomHistoryTimeLineRepo", "GetRoomMinStream").Add(1)
@@ -48,6 +48,7 @@ type RoomState struct {
 	canonicalAlias       string
 	power                *common.PowerLevelContent
 	isEncrypted          bool
+	retention            *common.RetentionContent
 
 	join   sync.Map
 	leave  sync.Map
@@ -170,6 +171,10 @@ func (rs *RoomState) onEvent(ev *gomatrixserverlib.ClientEvent, offset int64, ba
 			rs.canonicalAlias = alias.Alias
 		case "m.room.encryption":
 			rs.isEncrypted = true
+		case "m.room.retention":
+			retention := common.RetentionContent{}
+			json.Unmarshal(ev.Content, &retention)
+			rs.retention = &retention
 		}
 
 		if common.IsStateClientEv(ev) {
@@ -539,6 +544,13 @@ func (rs *RoomState) IsEncrypted() bool {
 	return rs.isEncrypted
 }
 
+func (rs *RoomState) GetRetention() *common.RetentionContent {
+	if rs == nil {
+		return nil
+	}
+	return rs.retention
+}
+
 //shared 历史可见，join 历史不可见
 func (rs *RoomState) onUserMembershipChange(user string, visibility, preMembership, membership string, offset int64) {
 	var items []*RangeItem
@@ -24,6 +24,7 @@ import (
 
 	"github.com/finogeeks/ligase/adapter"
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/replica"
 	"github.com/finogeeks/ligase/model/feedstypes"
 	"github.com/finogeeks/ligase/model/service"
 	"github.com/finogeeks/ligase/model/types"
@@ -71,8 +72,10 @@ type RoomServerState struct {
 	Power             *gomatrixserverlib.Event `json:"power_ev"`
 	GuestAccess       *gomatrixserverlib.Event `json:"guest_access"`
 
-	Avatar *gomatrixserverlib.Event `json:"avatar_ev"`
-	Pin    *gomatrixserverlib.Event `json:"pin_ev"`
+	Avatar    *gomatrixserverlib.Event `json:"avatar_ev"`
+	Pin       *gomatrixserverlib.Event `json:"pin_ev"`
+	Retention *gomatrixserverlib.Event `json:"retention_ev"`
+	Tombstone *gomatrixserverlib.Event `json:"tombstone_ev"`
 
 	join        sync.Map
 	leave       sync.Map
@@ -128,6 +131,12 @@ func (rs *RoomServerState) GetAllState() []gomatrixserverlib.Event {
 	if rs.GuestAccess != nil {
 		res = append(res, *rs.GuestAccess)
 	}
+	if rs.Retention != nil {
+		res = append(res, *rs.Retention)
+	}
+	if rs.Tombstone != nil {
+		res = append(res, *rs.Tombstone)
+	}
 	rs.join.Range(func(key, value interface{}) bool {
 		res = append(res, *value.(*gomatrixserverlib.Event))
 		return true
@@ -194,6 +203,10 @@ func (rs *RoomServerState) GetRefs(ev *gomatrixserverlib.Event) (string, []byte)
 		fallthrough
 	case "m.room.pinned_events":
 		fallthrough
+	case "m.room.retention":
+		fallthrough
+	case "m.room.tombstone":
+		fallthrough
 	case "m.room.canonical_alias":
 		log.Debugf("GetRefs type:%s id:%s", ev.Type(), rs.ext.PreStateId)
 		return rs.ext.PreStateId, []byte{}
@@ -254,6 +267,10 @@ func (rs *RoomServerState) GetPreEvent(ev *gomatrixserverlib.Event) (*gomatrixse
 		return rs.GuestAccess, true
 	case "m.room.pinned_events":
 		return rs.Pin, true
+	case "m.room.retention":
+		return rs.Retention, true
+	case "m.room.tombstone":
+		return rs.Tombstone, true
 	case "m.room.encryption":
 		return nil, true
 	}
@@ -514,6 +531,10 @@ func (rs *RoomServerState) onEventUpdate(ev *gomatrixserverlib.Event, offset int
 		rs.IsEncrypted = true
 	case "m.room.guest_access":
 		rs.GuestAccess = ev
+	case "m.room.retention":
+		rs.Retention = ev
+	case "m.room.tombstone":
+		rs.Tombstone = ev
 	}
 }
 
@@ -716,6 +737,14 @@ func (repo *RoomServerCurStateRepo) parseCacheRoomState(rs *RoomServerState) {
 		states = append(states, rs.GuestAccess)
 		rs.GuestAccess = nil
 	}
+	if rs.Retention != nil {
+		states = append(states, rs.Retention)
+		rs.Retention = nil
+	}
+	if rs.Tombstone != nil {
+		states = append(states, rs.Tombstone)
+		rs.Tombstone = nil
+	}
 	if rs.JoinExport != nil {
 		for _, v := range rs.JoinExport {
 			states = append(states, v)
@@ -765,6 +794,9 @@ func (repo *RoomServerCurStateRepo) getRoomStateFromCache(roomid string) (*RoomS
 }
 
 func (repo *RoomServerCurStateRepo) getRoomStateFromDb(ctx context.Context, roomid string) (*RoomServerState, error) {
+	// the state is cached and new events are applied on top of it, a lagging
+	// replica would lose the latest state events
+	ctx = replica.WithPrimary(ctx)
 	log.Infof("RoomServerCurStateRepo GetRoomState %s in db", roomid)
 	repo.queryHitCounter.WithLabelValues("db", "RoomServerCurStateRepo", "GetRoomState").Add(1)
 	bs := time.Now().UnixNano() / 1000000
@@ -802,6 +834,7 @@ func (repo *RoomServerCurStateRepo) getRoomStateFromDb(ctx context.Context, room
 }
 
 func (repo *RoomServerCurStateRepo) getRoomStateExtFromDb(ctx context.Context, roomid string) (*types.RoomStateExt, error) {
+	ctx = replica.WithPrimary(ctx)
 	log.Infof("RoomServerCurStateRepo GetRoomStateExt %s in db", roomid)
 	repo.queryHitCounter.WithLabelValues("db", "RoomServerCurStateRepo", "GetRoomStateExt").Add(1)
 	ext := &types.RoomStateExt{
@@ -479,6 +479,28 @@ func (tl *UserTimeLineRepo) UpdateRoomOffset(roomID string, offset int64){
 	}
 }
 
+// InvalidateRoom reloads the latest offset of a room after its history was
+// purged
+func (tl *UserTimeLineRepo) InvalidateRoom(ctx context.Context, roomID string) error {
+	if _, ok := tl.roomOffsets.Load(roomID); !ok {
+		return nil
+	}
+	roomMap, err := tl.persist.GetRoomLastOffsets(ctx, []string{roomID})
+	if err != nil {
+		log.Errorf("UserTimeLineRepo invalidate room:%s err:%v", roomID, err)
+		return err
+	}
+	tl.roomMutex.Lock()
+	defer tl.roomMutex.Unlock()
+	if offset, ok := roomMap[roomID]; ok {
+		tl.roomOffsets.Store(roomID, offset)
+	} else {
+		tl.roomOffsets.Delete(roomID)
+	}
+	log.Infof("UserTimeLineRepo invalidate room:%s", roomID)
+	return nil
+}
+
 func (tl *UserTimeLineRepo) GetRoomOffset(roomID, user, membership string) int64 {
 	switch membership {
 	case "invite", "leave":
@@ -20,6 +20,7 @@ import (
 	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/model/pushapitypes"
 	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 )
 
 type Cache interface {
@@ -88,6 +89,44 @@ type Cache interface {
 
 	SetOneTimeKey(userID, deviceID, keyID, keyInfo, algorithm, signature string) error
 
+	GetCrossSigningKeys(userID string) (map[string]*types.CrossSigningKey, bool)
+
+	SetCrossSigningKey(userID, keyType, keyInfo string) error
+
+	GetCrossSigningSigs(targetUserID, targetKeyID string) ([]types.CrossSigningSig, bool)
+
+	SetCrossSigningSig(originUserID, originKeyID, targetUserID, targetKeyID, signature string) error
+
+	GetKeyBackupVersions(userID string) ([]*types.KeyBackupVersion, bool)
+
+	SetKeyBackupVersion(userID string, version *types.KeyBackupVersion) error
+
+	NextKeyBackupVersion(userID string) (int64, error)
+
+	IncrKeyBackupEtag(userID string, version int64) (int64, error)
+
+	GetKeyBackups(userID string, version int64) ([]*types.KeyBackupSession, bool)
+
+	GetKeyBackup(userID string, version int64, roomID, sessionID string) (*types.KeyBackupSession, bool)
+
+	SetKeyBackup(userID string, version int64, session *types.KeyBackupSession) error
+
+	DeleteKeyBackup(userID string, version int64, roomID, sessionID string) error
+
+	GetFallbackKeys(userID, deviceID string) (map[string]*types.FallbackKey, bool)
+
+	SetFallbackKey(userID, deviceID string, key *types.FallbackKey) error
+
+	GetDeviceListStreamID(userID string) (int64, bool)
+
+	SetDeviceListStreamID(userID string, streamID int64) error
+
+	GetRemoteDeviceList(userID string) (*gomatrixserverlib.RespUserDevices, bool)
+
+	SetRemoteDeviceList(devices *gomatrixserverlib.RespUserDevices) error
+
+	DelRemoteDeviceList(userID string) error
+
 	GetRoomUnreadCount(userID, roomID string) (int64, int64, error)
 
 	GetPresences(userID string) (*authtypes.Presences, bool)
@@ -113,6 +152,15 @@ type Cache interface {
 	CheckPwdChangeDevice(deviceID, userID string) bool
 	DelPwdChangeDevice(deviceID, userID string) error
 	ExpirePwdChangeDevice(userID string) error
+	SetDeviceLastSeen(userID, deviceID string, lastSeen *authtypes.DeviceLastSeen) error
+	GetDevicesLastSeen(userID string) (map[string]*authtypes.DeviceLastSeen, error)
+	SetLoginToken(token, userID string, expire int64) error
+	TakeLoginToken(token string) (string, error)
+	SetSSOSession(state, redirectURL string, expire int64) error
+	TakeSSOSession(state string) (string, error)
+	SetRefreshToken(token, userID, deviceID string, expire int64) error
+	TakeRefreshToken(token string) (*authtypes.RefreshToken, error)
+	DelDeviceRefreshToken(userID, deviceID string) error
 
 	GetSetting(settingKey string) (int64, error)
 	GetSettingRaw(settingKey string) (string, error)
@@ -135,6 +183,10 @@ type Cache interface {
 	GetAlias(key string) (string, error)
 	DelAlias(key string) error
 
+	//purge history
+	SetPurgeHistoryStatus(purgeID, status string, expire int64) error
+	GetPurgeHistoryStatus(purgeID string) (string, error)
+
 	//txn
 	GetTxnID(roomID, msgID string) (string, bool)
 	PutTxnID(roomID, txnID, eventID string) error
@@ -60,11 +60,28 @@ type RemoveRoomAliasRequest struct {
 // RemoveRoomAliasResponse is a response to RemoveRoomAlias
 type RemoveRoomAliasResponse struct{}
 
+// MoveRoomAliasesRequest is a request to MoveRoomAliases
+type MoveRoomAliasesRequest struct {
+	// ID of the user upgrading the room
+	UserID string `json:"user_id"`
+	// The room the aliases refer to now
+	OldRoomID string `json:"old_room_id"`
+	// The room the aliases will refer to
+	NewRoomID string `json:"new_room_id"`
+}
+
+// MoveRoomAliasesResponse is a response to MoveRoomAliases
+type MoveRoomAliasesResponse struct {
+	// The aliases that were moved
+	Aliases []string `json:"aliases"`
+}
+
 type RoomserverAliasRequest struct {
 	SetRoomAliasRequest    *SetRoomAliasRequest    `json:"set_room_alias,omitempty"`
 	GetAliasRoomIDRequest  *GetAliasRoomIDRequest  `json:"get_room_alias,omitempty"`
 	RemoveRoomAliasRequest *RemoveRoomAliasRequest `json:"rem_room_alias,omitempty"`
 	AllocRoomAliasRequest  *SetRoomAliasRequest    `json:"alloc_room_alias,omitempty"`
+	MoveRoomAliasesRequest *MoveRoomAliasesRequest `json:"move_room_aliases,omitempty"`
 	Reply                  string
 }
 
@@ -96,4 +113,11 @@ type RoomserverAliasAPI interface {
 		req *RemoveRoomAliasRequest,
 		response *RemoveRoomAliasResponse,
 	) error
+
+	// Point all aliases of an upgraded room at its replacement
+	MoveRoomAliases( //cli
+		ctx context.Context,
+		req *MoveRoomAliasesRequest,
+		response *MoveRoomAliasesResponse,
+	) error
 }
@@ -20,4 +20,6 @@ type EduApi interface {
 	ProcessReceipt(edu *gomatrixserverlib.EDU)
 	ProcessTyping(edu *gomatrixserverlib.EDU)
 	ProcessProfile(edu *gomatrixserverlib.EDU)
+	ProcessDeviceListUpdate(edu *gomatrixserverlib.EDU)
+	ProcessSendToDevice(edu *gomatrixserverlib.EDU)
 }
@@ -0,0 +1,1065 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Code generated by capnpc-go. DO NOT EDIT.
+
+package roomserverapi
+
+import (
+	capnp "zombiezen.com/go/capnproto2"
+	text "zombiezen.com/go/capnproto2/encoding/text"
+	schemas "zombiezen.com/go/capnproto2/schemas"
+)
+
+type OutputEventCapn struct{ capnp.Struct }
+
+// OutputEventCapn_TypeID is the unique identifier for the type OutputEventCapn.
+const OutputEventCapn_TypeID = 0xa17dc19466a15afc
+
+func NewOutputEventCapn(s *capnp.Segment) (OutputEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return OutputEventCapn{st}, err
+}
+
+func NewRootOutputEventCapn(s *capnp.Segment) (OutputEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return OutputEventCapn{st}, err
+}
+
+func ReadRootOutputEventCapn(msg *capnp.Message) (OutputEventCapn, error) {
+	root, err := msg.RootPtr()
+	return OutputEventCapn{root.Struct()}, err
+}
+
+func (s OutputEventCapn) String() string {
+	str, _ := text.Marshal(0xa17dc19466a15afc, s.Struct)
+	return str
+}
+
+func (s OutputEventCapn) Type() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s OutputEventCapn) HasType() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputEventCapn) TypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s OutputEventCapn) SetType(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s OutputEventCapn) NewRoomEvent() (OutputNewRoomEventCapn, error) {
+	p, err := s.Struct.Ptr(1)
+	return OutputNewRoomEventCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputEventCapn) HasNewRoomEvent() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputEventCapn) SetNewRoomEvent(v OutputNewRoomEventCapn) error {
+	return s.Struct.SetPtr(1, v.Struct.ToPtr())
+}
+
+// NewNewRoomEvent sets the newRoomEvent field to a newly
+// allocated OutputNewRoomEventCapn struct, preferring placement in s's segment.
+func (s OutputEventCapn) NewNewRoomEvent() (OutputNewRoomEventCapn, error) {
+	ss, err := NewOutputNewRoomEventCapn(s.Struct.Segment())
+	if err != nil {
+		return OutputNewRoomEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(1, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s OutputEventCapn) NewInviteEvent() (OutputNewInviteEventCapn, error) {
+	p, err := s.Struct.Ptr(2)
+	return OutputNewInviteEventCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputEventCapn) HasNewInviteEvent() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputEventCapn) SetNewInviteEvent(v OutputNewInviteEventCapn) error {
+	return s.Struct.SetPtr(2, v.Struct.ToPtr())
+}
+
+// NewNewInviteEvent sets the newInviteEvent field to a newly
+// allocated OutputNewInviteEventCapn struct, preferring placement in s's segment.
+func (s OutputEventCapn) NewNewInviteEvent() (OutputNewInviteEventCapn, error) {
+	ss, err := NewOutputNewInviteEventCapn(s.Struct.Segment())
+	if err != nil {
+		return OutputNewInviteEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(2, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s OutputEventCapn) RetireInviteEvent() (OutputRetireInviteEventCapn, error) {
+	p, err := s.Struct.Ptr(3)
+	return OutputRetireInviteEventCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputEventCapn) HasRetireInviteEvent() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputEventCapn) SetRetireInviteEvent(v OutputRetireInviteEventCapn) error {
+	return s.Struct.SetPtr(3, v.Struct.ToPtr())
+}
+
+// NewRetireInviteEvent sets the retireInviteEvent field to a newly
+// allocated OutputRetireInviteEventCapn struct, preferring placement in s's segment.
+func (s OutputEventCapn) NewRetireInviteEvent() (OutputRetireInviteEventCapn, error) {
+	ss, err := NewOutputRetireInviteEventCapn(s.Struct.Segment())
+	if err != nil {
+		return OutputRetireInviteEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(3, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// OutputEventCapn_List is a list of OutputEventCapn.
+type OutputEventCapn_List struct{ capnp.List }
+
+// NewOutputEventCapn creates a new list of OutputEventCapn.
+func NewOutputEventCapn_List(s *capnp.Segment, sz int32) (OutputEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return OutputEventCapn_List{l}, err
+}
+
+func (s OutputEventCapn_List) At(i int) OutputEventCapn { return OutputEventCapn{s.List.Struct(i)} }
+
+func (s OutputEventCapn_List) Set(i int, v OutputEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s OutputEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xa17dc19466a15afc, s.List)
+	return str
+}
+
+// OutputEventCapn_Promise is a wrapper for a OutputEventCapn promised by a client call.
+type OutputEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p OutputEventCapn_Promise) Struct() (OutputEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return OutputEventCapn{s}, err
+}
+
+func (p OutputEventCapn_Promise) NewRoomEvent() OutputNewRoomEventCapn_Promise {
+	return OutputNewRoomEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(1)}
+}
+
+func (p OutputEventCapn_Promise) NewInviteEvent() OutputNewInviteEventCapn_Promise {
+	return OutputNewInviteEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(2)}
+}
+
+func (p OutputEventCapn_Promise) RetireInviteEvent() OutputRetireInviteEventCapn_Promise {
+	return OutputRetireInviteEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(3)}
+}
+
+type OutputNewRoomEventCapn struct{ capnp.Struct }
+
+// OutputNewRoomEventCapn_TypeID is the unique identifier for the type OutputNewRoomEventCapn.
+const OutputNewRoomEventCapn_TypeID = 0xf142bbf6ad94807b
+
+func NewOutputNewRoomEventCapn(s *capnp.Segment) (OutputNewRoomEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return OutputNewRoomEventCapn{st}, err
+}
+
+func NewRootOutputNewRoomEventCapn(s *capnp.Segment) (OutputNewRoomEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6})
+	return OutputNewRoomEventCapn{st}, err
+}
+
+func ReadRootOutputNewRoomEventCapn(msg *capnp.Message) (OutputNewRoomEventCapn, error) {
+	root, err := msg.RootPtr()
+	return OutputNewRoomEventCapn{root.Struct()}, err
+}
+
+func (s OutputNewRoomEventCapn) String() string {
+	str, _ := text.Marshal(0xf142bbf6ad94807b, s.Struct)
+	return str
+}
+
+func (s OutputNewRoomEventCapn) Event() (ClientEventCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return ClientEventCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputNewRoomEventCapn) HasEvent() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SetEvent(v ClientEventCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewEvent sets the event field to a newly
+// allocated ClientEventCapn struct, preferring placement in s's segment.
+func (s OutputNewRoomEventCapn) NewEvent() (ClientEventCapn, error) {
+	ss, err := NewClientEventCapn(s.Struct.Segment())
+	if err != nil {
+		return ClientEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s OutputNewRoomEventCapn) AddsStateEventIDs() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(1)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s OutputNewRoomEventCapn) HasAddsStateEventIDs() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SetAddsStateEventIDs(v capnp.TextList) error {
+	return s.Struct.SetPtr(1, v.List.ToPtr())
+}
+
+// NewAddsStateEventIDs sets the addsStateEventIDs field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s OutputNewRoomEventCapn) NewAddsStateEventIDs(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(1, l.List.ToPtr())
+	return l, err
+}
+
+func (s OutputNewRoomEventCapn) RemovesStateEventIDs() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(2)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s OutputNewRoomEventCapn) HasRemovesStateEventIDs() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SetRemovesStateEventIDs(v capnp.TextList) error {
+	return s.Struct.SetPtr(2, v.List.ToPtr())
+}
+
+// NewRemovesStateEventIDs sets the removesStateEventIDs field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s OutputNewRoomEventCapn) NewRemovesStateEventIDs(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(2, l.List.ToPtr())
+	return l, err
+}
+
+func (s OutputNewRoomEventCapn) SendAsServer() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s OutputNewRoomEventCapn) HasSendAsServer() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SendAsServerBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s OutputNewRoomEventCapn) SetSendAsServer(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s OutputNewRoomEventCapn) TransactionID() (TransactionIDCapn, error) {
+	p, err := s.Struct.Ptr(4)
+	return TransactionIDCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputNewRoomEventCapn) HasTransactionID() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SetTransactionID(v TransactionIDCapn) error {
+	return s.Struct.SetPtr(4, v.Struct.ToPtr())
+}
+
+// NewTransactionID sets the transactionID field to a newly
+// allocated TransactionIDCapn struct, preferring placement in s's segment.
+func (s OutputNewRoomEventCapn) NewTransactionID() (TransactionIDCapn, error) {
+	ss, err := NewTransactionIDCapn(s.Struct.Segment())
+	if err != nil {
+		return TransactionIDCapn{}, err
+	}
+	err = s.Struct.SetPtr(4, ss.Struct.ToPtr())
+	return ss, err
+}
+
+func (s OutputNewRoomEventCapn) Joined() (capnp.TextList, error) {
+	p, err := s.Struct.Ptr(5)
+	return capnp.TextList{List: p.List()}, err
+}
+
+func (s OutputNewRoomEventCapn) HasJoined() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewRoomEventCapn) SetJoined(v capnp.TextList) error {
+	return s.Struct.SetPtr(5, v.List.ToPtr())
+}
+
+// NewJoined sets the joined field to a newly
+// allocated capnp.TextList, preferring placement in s's segment.
+func (s OutputNewRoomEventCapn) NewJoined(n int32) (capnp.TextList, error) {
+	l, err := capnp.NewTextList(s.Struct.Segment(), n)
+	if err != nil {
+		return capnp.TextList{}, err
+	}
+	err = s.Struct.SetPtr(5, l.List.ToPtr())
+	return l, err
+}
+
+// OutputNewRoomEventCapn_List is a list of OutputNewRoomEventCapn.
+type OutputNewRoomEventCapn_List struct{ capnp.List }
+
+// NewOutputNewRoomEventCapn creates a new list of OutputNewRoomEventCapn.
+func NewOutputNewRoomEventCapn_List(s *capnp.Segment, sz int32) (OutputNewRoomEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 6}, sz)
+	return OutputNewRoomEventCapn_List{l}, err
+}
+
+func (s OutputNewRoomEventCapn_List) At(i int) OutputNewRoomEventCapn {
+	return OutputNewRoomEventCapn{s.List.Struct(i)}
+}
+
+func (s OutputNewRoomEventCapn_List) Set(i int, v OutputNewRoomEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s OutputNewRoomEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xf142bbf6ad94807b, s.List)
+	return str
+}
+
+// OutputNewRoomEventCapn_Promise is a wrapper for a OutputNewRoomEventCapn promised by a client call.
+type OutputNewRoomEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p OutputNewRoomEventCapn_Promise) Struct() (OutputNewRoomEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return OutputNewRoomEventCapn{s}, err
+}
+
+func (p OutputNewRoomEventCapn_Promise) Event() ClientEventCapn_Promise {
+	return ClientEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+func (p OutputNewRoomEventCapn_Promise) TransactionID() TransactionIDCapn_Promise {
+	return TransactionIDCapn_Promise{Pipeline: p.Pipeline.GetPipeline(4)}
+}
+
+type ClientEventCapn struct{ capnp.Struct }
+
+// ClientEventCapn_TypeID is the unique identifier for the type ClientEventCapn.
+const ClientEventCapn_TypeID = 0xcbe318ecdc3d6f1c
+
+func NewClientEventCapn(s *capnp.Segment) (ClientEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 48, PointerCount: 9})
+	return ClientEventCapn{st}, err
+}
+
+func NewRootClientEventCapn(s *capnp.Segment) (ClientEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 48, PointerCount: 9})
+	return ClientEventCapn{st}, err
+}
+
+func ReadRootClientEventCapn(msg *capnp.Message) (ClientEventCapn, error) {
+	root, err := msg.RootPtr()
+	return ClientEventCapn{root.Struct()}, err
+}
+
+func (s ClientEventCapn) String() string {
+	str, _ := text.Marshal(0xcbe318ecdc3d6f1c, s.Struct)
+	return str
+}
+
+func (s ClientEventCapn) Content() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return []byte(p.Data()), err
+}
+
+func (s ClientEventCapn) HasContent() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) SetContent(v []byte) error {
+	return s.Struct.SetData(0, v)
+}
+
+func (s ClientEventCapn) EventID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasEventID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) EventIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetEventID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s ClientEventCapn) EventNID() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s ClientEventCapn) SetEventNID(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
+func (s ClientEventCapn) DomainOffset() int64 {
+	return int64(s.Struct.Uint64(8))
+}
+
+func (s ClientEventCapn) SetDomainOffset(v int64) {
+	s.Struct.SetUint64(8, uint64(v))
+}
+
+func (s ClientEventCapn) Depth() int64 {
+	return int64(s.Struct.Uint64(16))
+}
+
+func (s ClientEventCapn) SetDepth(v int64) {
+	s.Struct.SetUint64(16, uint64(v))
+}
+
+func (s ClientEventCapn) OriginServerTS() uint64 {
+	return s.Struct.Uint64(24)
+}
+
+func (s ClientEventCapn) SetOriginServerTS(v uint64) {
+	s.Struct.SetUint64(24, v)
+}
+
+func (s ClientEventCapn) RoomID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasRoomID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) RoomIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetRoomID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s ClientEventCapn) Sender() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasSender() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) SenderBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetSender(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+func (s ClientEventCapn) StateKey() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasStateKey() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) StateKeyBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetStateKey(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s ClientEventCapn) StateKeySet() bool {
+	return s.Struct.Bit(256)
+}
+
+func (s ClientEventCapn) SetStateKeySet(v bool) {
+	s.Struct.SetBit(256, v)
+}
+
+func (s ClientEventCapn) Type() (string, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasType() bool {
+	p, err := s.Struct.Ptr(5)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) TypeBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(5)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetType(v string) error {
+	return s.Struct.SetText(5, v)
+}
+
+func (s ClientEventCapn) Redacts() (string, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasRedacts() bool {
+	p, err := s.Struct.Ptr(6)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) RedactsBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(6)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetRedacts(v string) error {
+	return s.Struct.SetText(6, v)
+}
+
+func (s ClientEventCapn) Hint() (string, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.Text(), err
+}
+
+func (s ClientEventCapn) HasHint() bool {
+	p, err := s.Struct.Ptr(7)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) HintBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(7)
+	return p.TextBytes(), err
+}
+
+func (s ClientEventCapn) SetHint(v string) error {
+	return s.Struct.SetText(7, v)
+}
+
+func (s ClientEventCapn) Visible() bool {
+	return s.Struct.Bit(257)
+}
+
+func (s ClientEventCapn) SetVisible(v bool) {
+	s.Struct.SetBit(257, v)
+}
+
+func (s ClientEventCapn) Unsigned() ([]byte, error) {
+	p, err := s.Struct.Ptr(8)
+	return []byte(p.Data()), err
+}
+
+func (s ClientEventCapn) HasUnsigned() bool {
+	p, err := s.Struct.Ptr(8)
+	return p.IsValid() || err != nil
+}
+
+func (s ClientEventCapn) SetUnsigned(v []byte) error {
+	return s.Struct.SetData(8, v)
+}
+
+func (s ClientEventCapn) EventOffset() int64 {
+	return int64(s.Struct.Uint64(40))
+}
+
+func (s ClientEventCapn) SetEventOffset(v int64) {
+	s.Struct.SetUint64(40, uint64(v))
+}
+
+// ClientEventCapn_List is a list of ClientEventCapn.
+type ClientEventCapn_List struct{ capnp.List }
+
+// NewClientEventCapn creates a new list of ClientEventCapn.
+func NewClientEventCapn_List(s *capnp.Segment, sz int32) (ClientEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 48, PointerCount: 9}, sz)
+	return ClientEventCapn_List{l}, err
+}
+
+func (s ClientEventCapn_List) At(i int) ClientEventCapn { return ClientEventCapn{s.List.Struct(i)} }
+
+func (s ClientEventCapn_List) Set(i int, v ClientEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s ClientEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xcbe318ecdc3d6f1c, s.List)
+	return str
+}
+
+// ClientEventCapn_Promise is a wrapper for a ClientEventCapn promised by a client call.
+type ClientEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p ClientEventCapn_Promise) Struct() (ClientEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return ClientEventCapn{s}, err
+}
+
+type TransactionIDCapn struct{ capnp.Struct }
+
+// TransactionIDCapn_TypeID is the unique identifier for the type TransactionIDCapn.
+const TransactionIDCapn_TypeID = 0xbe722da5e08489cf
+
+func NewTransactionIDCapn(s *capnp.Segment) (TransactionIDCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return TransactionIDCapn{st}, err
+}
+
+func NewRootTransactionIDCapn(s *capnp.Segment) (TransactionIDCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3})
+	return TransactionIDCapn{st}, err
+}
+
+func ReadRootTransactionIDCapn(msg *capnp.Message) (TransactionIDCapn, error) {
+	root, err := msg.RootPtr()
+	return TransactionIDCapn{root.Struct()}, err
+}
+
+func (s TransactionIDCapn) String() string {
+	str, _ := text.Marshal(0xbe722da5e08489cf, s.Struct)
+	return str
+}
+
+func (s TransactionIDCapn) DeviceID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s TransactionIDCapn) HasDeviceID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s TransactionIDCapn) DeviceIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s TransactionIDCapn) SetDeviceID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s TransactionIDCapn) TransactionID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s TransactionIDCapn) HasTransactionID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s TransactionIDCapn) TransactionIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s TransactionIDCapn) SetTransactionID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s TransactionIDCapn) Ip() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s TransactionIDCapn) HasIp() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s TransactionIDCapn) IpBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s TransactionIDCapn) SetIp(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+// TransactionIDCapn_List is a list of TransactionIDCapn.
+type TransactionIDCapn_List struct{ capnp.List }
+
+// NewTransactionIDCapn creates a new list of TransactionIDCapn.
+func NewTransactionIDCapn_List(s *capnp.Segment, sz int32) (TransactionIDCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 3}, sz)
+	return TransactionIDCapn_List{l}, err
+}
+
+func (s TransactionIDCapn_List) At(i int) TransactionIDCapn {
+	return TransactionIDCapn{s.List.Struct(i)}
+}
+
+func (s TransactionIDCapn_List) Set(i int, v TransactionIDCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s TransactionIDCapn_List) String() string {
+	str, _ := text.MarshalList(0xbe722da5e08489cf, s.List)
+	return str
+}
+
+// TransactionIDCapn_Promise is a wrapper for a TransactionIDCapn promised by a client call.
+type TransactionIDCapn_Promise struct{ *capnp.Pipeline }
+
+func (p TransactionIDCapn_Promise) Struct() (TransactionIDCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return TransactionIDCapn{s}, err
+}
+
+type OutputNewInviteEventCapn struct{ capnp.Struct }
+
+// OutputNewInviteEventCapn_TypeID is the unique identifier for the type OutputNewInviteEventCapn.
+const OutputNewInviteEventCapn_TypeID = 0xfea4b33e6f41c1c3
+
+func NewOutputNewInviteEventCapn(s *capnp.Segment) (OutputNewInviteEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return OutputNewInviteEventCapn{st}, err
+}
+
+func NewRootOutputNewInviteEventCapn(s *capnp.Segment) (OutputNewInviteEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1})
+	return OutputNewInviteEventCapn{st}, err
+}
+
+func ReadRootOutputNewInviteEventCapn(msg *capnp.Message) (OutputNewInviteEventCapn, error) {
+	root, err := msg.RootPtr()
+	return OutputNewInviteEventCapn{root.Struct()}, err
+}
+
+func (s OutputNewInviteEventCapn) String() string {
+	str, _ := text.Marshal(0xfea4b33e6f41c1c3, s.Struct)
+	return str
+}
+
+func (s OutputNewInviteEventCapn) Event() (ClientEventCapn, error) {
+	p, err := s.Struct.Ptr(0)
+	return ClientEventCapn{Struct: p.Struct()}, err
+}
+
+func (s OutputNewInviteEventCapn) HasEvent() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputNewInviteEventCapn) SetEvent(v ClientEventCapn) error {
+	return s.Struct.SetPtr(0, v.Struct.ToPtr())
+}
+
+// NewEvent sets the event field to a newly
+// allocated ClientEventCapn struct, preferring placement in s's segment.
+func (s OutputNewInviteEventCapn) NewEvent() (ClientEventCapn, error) {
+	ss, err := NewClientEventCapn(s.Struct.Segment())
+	if err != nil {
+		return ClientEventCapn{}, err
+	}
+	err = s.Struct.SetPtr(0, ss.Struct.ToPtr())
+	return ss, err
+}
+
+// OutputNewInviteEventCapn_List is a list of OutputNewInviteEventCapn.
+type OutputNewInviteEventCapn_List struct{ capnp.List }
+
+// NewOutputNewInviteEventCapn creates a new list of OutputNewInviteEventCapn.
+func NewOutputNewInviteEventCapn_List(s *capnp.Segment, sz int32) (OutputNewInviteEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 1}, sz)
+	return OutputNewInviteEventCapn_List{l}, err
+}
+
+func (s OutputNewInviteEventCapn_List) At(i int) OutputNewInviteEventCapn {
+	return OutputNewInviteEventCapn{s.List.Struct(i)}
+}
+
+func (s OutputNewInviteEventCapn_List) Set(i int, v OutputNewInviteEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s OutputNewInviteEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xfea4b33e6f41c1c3, s.List)
+	return str
+}
+
+// OutputNewInviteEventCapn_Promise is a wrapper for a OutputNewInviteEventCapn promised by a client call.
+type OutputNewInviteEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p OutputNewInviteEventCapn_Promise) Struct() (OutputNewInviteEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return OutputNewInviteEventCapn{s}, err
+}
+
+func (p OutputNewInviteEventCapn_Promise) Event() ClientEventCapn_Promise {
+	return ClientEventCapn_Promise{Pipeline: p.Pipeline.GetPipeline(0)}
+}
+
+type OutputRetireInviteEventCapn struct{ capnp.Struct }
+
+// OutputRetireInviteEventCapn_TypeID is the unique identifier for the type OutputRetireInviteEventCapn.
+const OutputRetireInviteEventCapn_TypeID = 0xc0361312a6697a3f
+
+func NewOutputRetireInviteEventCapn(s *capnp.Segment) (OutputRetireInviteEventCapn, error) {
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return OutputRetireInviteEventCapn{st}, err
+}
+
+func NewRootOutputRetireInviteEventCapn(s *capnp.Segment) (OutputRetireInviteEventCapn, error) {
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	return OutputRetireInviteEventCapn{st}, err
+}
+
+func ReadRootOutputRetireInviteEventCapn(msg *capnp.Message) (OutputRetireInviteEventCapn, error) {
+	root, err := msg.RootPtr()
+	return OutputRetireInviteEventCapn{root.Struct()}, err
+}
+
+func (s OutputRetireInviteEventCapn) String() string {
+	str, _ := text.Marshal(0xc0361312a6697a3f, s.Struct)
+	return str
+}
+
+func (s OutputRetireInviteEventCapn) EventID() (string, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.Text(), err
+}
+
+func (s OutputRetireInviteEventCapn) HasEventID() bool {
+	p, err := s.Struct.Ptr(0)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputRetireInviteEventCapn) EventIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(0)
+	return p.TextBytes(), err
+}
+
+func (s OutputRetireInviteEventCapn) SetEventID(v string) error {
+	return s.Struct.SetText(0, v)
+}
+
+func (s OutputRetireInviteEventCapn) TargetUserID() (string, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.Text(), err
+}
+
+func (s OutputRetireInviteEventCapn) HasTargetUserID() bool {
+	p, err := s.Struct.Ptr(1)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputRetireInviteEventCapn) TargetUserIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(1)
+	return p.TextBytes(), err
+}
+
+func (s OutputRetireInviteEventCapn) SetTargetUserID(v string) error {
+	return s.Struct.SetText(1, v)
+}
+
+func (s OutputRetireInviteEventCapn) RetiredByEventID() (string, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.Text(), err
+}
+
+func (s OutputRetireInviteEventCapn) HasRetiredByEventID() bool {
+	p, err := s.Struct.Ptr(2)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputRetireInviteEventCapn) RetiredByEventIDBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(2)
+	return p.TextBytes(), err
+}
+
+func (s OutputRetireInviteEventCapn) SetRetiredByEventID(v string) error {
+	return s.Struct.SetText(2, v)
+}
+
+func (s OutputRetireInviteEventCapn) Membership() (string, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.Text(), err
+}
+
+func (s OutputRetireInviteEventCapn) HasMembership() bool {
+	p, err := s.Struct.Ptr(3)
+	return p.IsValid() || err != nil
+}
+
+func (s OutputRetireInviteEventCapn) MembershipBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(3)
+	return p.TextBytes(), err
+}
+
+func (s OutputRetireInviteEventCapn) SetMembership(v string) error {
+	return s.Struct.SetText(3, v)
+}
+
+// OutputRetireInviteEventCapn_List is a list of OutputRetireInviteEventCapn.
+type OutputRetireInviteEventCapn_List struct{ capnp.List }
+
+// NewOutputRetireInviteEventCapn creates a new list of OutputRetireInviteEventCapn.
+func NewOutputRetireInviteEventCapn_List(s *capnp.Segment, sz int32) (OutputRetireInviteEventCapn_List, error) {
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	return OutputRetireInviteEventCapn_List{l}, err
+}
+
+func (s OutputRetireInviteEventCapn_List) At(i int) OutputRetireInviteEventCapn {
+	return OutputRetireInviteEventCapn{s.List.Struct(i)}
+}
+
+func (s OutputRetireInviteEventCapn_List) Set(i int, v OutputRetireInviteEventCapn) error {
+	return s.List.SetStruct(i, v.Struct)
+}
+
+func (s OutputRetireInviteEventCapn_List) String() string {
+	str, _ := text.MarshalList(0xc0361312a6697a3f, s.List)
+	return str
+}
+
+// OutputRetireInviteEventCapn_Promise is a wrapper for a OutputRetireInviteEventCapn promised by a client call.
+type OutputRetireInviteEventCapn_Promise struct{ *capnp.Pipeline }
+
+func (p OutputRetireInviteEventCapn_Promise) Struct() (OutputRetireInviteEventCapn, error) {
+	s, err := p.Pipeline.Struct()
+	return OutputRetireInviteEventCapn{s}, err
+}
+
+const schema_a1c37f4b62d9e805 = "x\xda\x8c\x95_\x88T\xe5\x1f\xc6\x9f\xe7}\xe7\xdf\xea" +
+	"\xec\xce\xce\xef\x1c\xf8\x89\x04K\xb7\x82\x91J]\x085" +
+	"\xba\xee^\xac\x96\xfaz\x94B\xbc\x99\xddy\xd7=\xe2" +
+	"\x9c3\x9csvr-\xd9.\x8c\xfe\\\x867]\x04" +
+	"\x0a\xd5E\x14\x15\x11(I(z#\x19\x19((\xac" +
+	"\xb0X\xd2^\x18hh\x14l\x9dx\xcf\xcc\x9c9\xae" +
+	"\x0a]\x9e\xcf<\xef\xfb~\x9f\xef\xbfy\xf6\x1d\xb1E" +
+	"l\xc8\xff_\x00jM\xbe\x10/\xef?5}\xe2\xfc" +
+	"\xb1S\xa8\x96\x19\xe7\x97nL\xee\x98\xbfp\x0a\xf9\\" +
+	"\x11\xa8\xde\xbb\x05V\x1f\xfc\x0a\xc6?\xbe{|\xf1\xe3" +
+	"\xf5\xc1w+T\xb2\x08X7\xf8;h\xdd\xe4\x17`" +
+	"\\;\xea~\xf2?\xeb\xf9s\x8f\xb9m\xd311J" +
+	"\xd0zK\x98\x0b\x9f\xf2_X\xb8\xb3\xe6\xe7\xef\xa1\xca" +
+	",d\x94\x03\xe6FW\xde\x0275\xe5\xdb\x12\x8c_" +
+	"\x7f\xf3\xc4\xe7\x7f|;zo\xc5\x95\x05s\xe5\xfa\xe2" +
+	"Z\x82\x9b\x9e+\xbeB0\xbep~\xab\xff\xe2\xd7\x1f" +
+	"\xfd\xb3BI\xa3\xbcTZg\x1e\xff\xa9T\x03c\x7f" +
+	"6j\xcdF\xcfL\x89z\xcb\xdb\xbc+\xf9\x18ok" +
+	"/\xdaV\xac\xb7<5,s@\x8e@\xb5\xbe\x0eP" +
+	"\x07$\xd5\x8c`\x95\xb4i\xa0>\x04\xa8\x86\xa4j\x09" +
+	"V\x85\xb0)\x80j\xf3(\xa0\x0eK\xaa#\x82U)" +
+	"mJ\xa0:\xfb>\xa0\x8eH\xaa\xe3\x82\x95h\xae\xa5" +
+	"Y\x86`\x19\x8c=\xfd\xda\x1e\xdfo\x8e\xa3b\x9e\xe5" +
+	"p\xdf$\xc8\xe1\x8e`\xc2k\xbb\x11jz\xbc+I" +
+	"\xddu%\x81\x8e\xdc@Oxl\xbbQ\"\xa2Q\xa5" +
+	"\x05\xe8\xaa\x1e\xb2\xba7\xa8{a}*r}ob" +
+	"dl\x9b1[N\xcd\x8eo\x07\xd4\x98\xa4\xda\x9d1" +
+	"\xfbr\x00\xa8\x97$\xd5\xab\x19\xb3\xfb\xd6\x02j\xb7\xa4" +
+	": \x187t\xdb\x9d\xd2\x13c\x00R{Q\xf7\x1d" +
+	"\x8c\x98\x97\xc6z\\\xba\xadT\xd2\x8b+\xd7/\xc1\x9e" +
+	"\xae\xa1\x9e\x9f\xc8\x04\x88l9F\xffs9\xde\x03T" +
+	"KR\xbd\x91)\xc7\xdc\xfe~9\xe6\xb5y\xa0\x1fZ" +
+	"\x1c\xd5\x83\x83:\xda\x17\xa2\xa2\x83\x0c\xee$\xb91\xca" +
+	"\xb9\xf1\xce\x81\x8c\xcb\xa6nN\xea \x9c\xc1\xe3|%" +
+	"\xf9\xdev\xd8\xd5^\xb6\xb5\xb6\xf4\xbcX_q\x14p" +
+	">\xa3\xa4s\x9a};\xd67\x09\xff\xd2\xf0\xb3\x14d" +
+	"\xc7\x90u\x86\xdb\x01\xe7\xb4\xc1\x17\x8d\\2\xf1d\x9d" +
+	"\xe7!\xc09g\xf8e\xc3s\xc2f\x0e\xb0.q#" +
+	"\xe0\\4\xfc\x8a\xe1yi3\x0fX?\xf0(\xe0\\" +
+	"6\xfc\xba\xe1\x05a\xb3\x00X\xd7\xb8\x19p\xae\x18\xbe" +
+	"`xQ\xda\xec\xcc\xb6\xe1W\x0d_4\xbc\x94\xb3Y" +
+	"\x02\xac\x9bI<\x0b\x86/Q\xf0\xe9\x01\xda\x1c\x00\xac" +
+	"\xdb\x9c\x04\x9c_\x0c\xbfk\xf4\xab\xf26W\x01\xd6o" +
+	"\\\x078K\x86\xdf7|u\xc1\xe6j\xc0\xba\x97\xd8" +
+	"\xbdc\xf8\x9f\x86\x97\x8b6\xcb\x80\xf5 \xd1\xdf5|" +
+	"\x99\x82\x1b\x06M~\x06\x01\xeb\xaf\xe4\xc0}J\xee\x11" +
+	"\x82\xd5\xa1\x92\xcd!\xc0\xfa;\x89g\xd9\xe8K\x86W" +
+	"\xf26+\x80\x95\x17&\x9e\x9c\x90t\x86\x85\xe0\xfc\x94" +
+	"\xefEf\x9c\x06!8\x88G\xdb \xf9\xde\xd9\xe9\xe6" +
+	"<\x04\xf3`\xdc\xf0\x9bu\xd7\xdb5\x8d\xcat\xa8\xa3" +
+	"\x1e\x1ei\xe8V4\x93\x8a\xfc\xc0=\xe8z\x8eF-" +
+	"h\xeb`\xaf\xc3\x01\x08\x0e\x80\xb5\xc0\xf7\x9b\xfd\x07j" +
+	"\xa1\xf6\x1a:H\xdf\x0b\xa3z\xa4w\xe8\xb9\xec\xf4\xa4" +
+	"\xac\xe8\xe8\x88\x84 \xf1\xf0\xfe\x98\x0ft\xa3>\x15\x85" +
+	"\xbd\xef\xca\x8c\xebE\xe9\x8fm7t'\x0f\xeb\xde\xc9" +
+	"x\xd6\x0b\xdd\x83\x9en\x00\xe8\xf9\xee\xf8\xdc5=\x8d" +
+	"b\xc6R\xda\xbc\xb2?\x94;\xbb\x9b\xaa3\x8f\xb2\xe5" +
+	"\xa95\xe9<~\xb0\x11P'$\xd5\xc9\xcc<~h" +
+	"\x96\xdeIIu63\x8fg>\x05\xd4YIu=" +
+	"3\x8f\xd7\xcc\xe4^\x95T\x8b\xa6qsI\xe3Vo" +
+	"\x9a\x85\xb3 \xa9\x96L\xd7\xe6\x93\xae\xad\xde\xde\x0c\xa8" +
+	"EI\xb5,8\xa2\xbb\xfb0\xfd\x07\xe9n\xbaz\xa3" +
+	"\x11:Q=\xa2\xee\x8e*C\x0e\x81\xbb%\x93\xcc\x0c" +
+	"%\xd3\xdc\xf4\xdb:th2lT\x95hb\xec\x11" +
+	"\x95)\xd1\xd6\xd0\xd1\xa8\x98R>q\xa9\x0d\xf7\xff\x14" +
+	";\x11\xd4\x0e\xf9\xae\xa7\x1b+\xaf{|R3k\xae" +
+	"\x92,\xe2\\\x9a\xd6A\x93\xd6\x92\xa4\xb2\x9f\xec\xf6\xdf" +
+	"\x01\x00\xab\xcb\xd0\xa1"
+
+func init() {
+	schemas.Register(schema_a1c37f4b62d9e805,
+		0xa17dc19466a15afc,
+		0xbe722da5e08489cf,
+		0xc0361312a6697a3f,
+		0xcbe318ecdc3d6f1c,
+		0xf142bbf6ad94807b,
+		0xfea4b33e6f41c1c3)
+}
@@ -0,0 +1,396 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package roomserverapi
+
+import (
+	"github.com/finogeeks/ligase/model/roomservertypes"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	capn "zombiezen.com/go/capnproto2"
+)
+
+// Encode encodes the output event with capn, it is used when the topic format is capn
+func (e *OutputEvent) Encode() ([]byte, error) {
+	msg, seg, err := capn.NewMessage(capn.SingleSegment(nil))
+	if err != nil {
+		return nil, err
+	}
+	c, err := NewRootOutputEventCapn(seg)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeOutputEventCapn(c, e); err != nil {
+		return nil, err
+	}
+	return msg.Marshal()
+}
+
+// Decode decodes the output event encoded by Encode
+func (e *OutputEvent) Decode(input []byte) error {
+	msg, err := capn.Unmarshal(input)
+	if err != nil {
+		return err
+	}
+	c, err := ReadRootOutputEventCapn(msg)
+	if err != nil {
+		return err
+	}
+	return readOutputEventCapn(c, e)
+}
+
+func writeOutputEventCapn(c OutputEventCapn, v *OutputEvent) error {
+	if err := c.SetType(string(v.Type)); err != nil {
+		return err
+	}
+	if v.NewRoomEvent != nil {
+		if s, err := c.NewNewRoomEvent(); err != nil {
+			return err
+		} else if err := writeOutputNewRoomEventCapn(s, v.NewRoomEvent); err != nil {
+			return err
+		}
+	}
+	if v.NewInviteEvent != nil {
+		if s, err := c.NewNewInviteEvent(); err != nil {
+			return err
+		} else if err := writeOutputNewInviteEventCapn(s, v.NewInviteEvent); err != nil {
+			return err
+		}
+	}
+	if v.RetireInviteEvent != nil {
+		if s, err := c.NewRetireInviteEvent(); err != nil {
+			return err
+		} else if err := writeOutputRetireInviteEventCapn(s, v.RetireInviteEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readOutputEventCapn(c OutputEventCapn, v *OutputEvent) error {
+	if s, err := c.Type(); err != nil {
+		return err
+	} else {
+		v.Type = OutputType(s)
+	}
+	if c.HasNewRoomEvent() {
+		s, err := c.NewRoomEvent()
+		if err != nil {
+			return err
+		}
+		v.NewRoomEvent = new(OutputNewRoomEvent)
+		if err := readOutputNewRoomEventCapn(s, v.NewRoomEvent); err != nil {
+			return err
+		}
+	}
+	if c.HasNewInviteEvent() {
+		s, err := c.NewInviteEvent()
+		if err != nil {
+			return err
+		}
+		v.NewInviteEvent = new(OutputNewInviteEvent)
+		if err := readOutputNewInviteEventCapn(s, v.NewInviteEvent); err != nil {
+			return err
+		}
+	}
+	if c.HasRetireInviteEvent() {
+		s, err := c.RetireInviteEvent()
+		if err != nil {
+			return err
+		}
+		v.RetireInviteEvent = new(OutputRetireInviteEvent)
+		if err := readOutputRetireInviteEventCapn(s, v.RetireInviteEvent); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeOutputNewRoomEventCapn(c OutputNewRoomEventCapn, v *OutputNewRoomEvent) error {
+	if s, err := c.NewEvent(); err != nil {
+		return err
+	} else if err := writeClientEventCapn(s, &v.Event); err != nil {
+		return err
+	}
+	if v.AddsStateEventIDs != nil {
+		l, err := c.NewAddsStateEventIDs(int32(len(v.AddsStateEventIDs)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.AddsStateEventIDs {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	if v.RemovesStateEventIDs != nil {
+		l, err := c.NewRemovesStateEventIDs(int32(len(v.RemovesStateEventIDs)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.RemovesStateEventIDs {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.SetSendAsServer(v.SendAsServer); err != nil {
+		return err
+	}
+	if v.TransactionID != nil {
+		if s, err := c.NewTransactionID(); err != nil {
+			return err
+		} else if err := writeTransactionIDCapn(s, v.TransactionID); err != nil {
+			return err
+		}
+	}
+	if v.Joined != nil {
+		l, err := c.NewJoined(int32(len(v.Joined)))
+		if err != nil {
+			return err
+		}
+		for i, s := range v.Joined {
+			if err := l.Set(i, s); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readOutputNewRoomEventCapn(c OutputNewRoomEventCapn, v *OutputNewRoomEvent) error {
+	var err error
+	if s, err := c.Event(); err != nil {
+		return err
+	} else if err := readClientEventCapn(s, &v.Event); err != nil {
+		return err
+	}
+	if c.HasAddsStateEventIDs() {
+		l, err := c.AddsStateEventIDs()
+		if err != nil {
+			return err
+		}
+		v.AddsStateEventIDs = make([]string, l.Len())
+		for i := range v.AddsStateEventIDs {
+			if v.AddsStateEventIDs[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	if c.HasRemovesStateEventIDs() {
+		l, err := c.RemovesStateEventIDs()
+		if err != nil {
+			return err
+		}
+		v.RemovesStateEventIDs = make([]string, l.Len())
+		for i := range v.RemovesStateEventIDs {
+			if v.RemovesStateEventIDs[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	if v.SendAsServer, err = c.SendAsServer(); err != nil {
+		return err
+	}
+	if c.HasTransactionID() {
+		s, err := c.TransactionID()
+		if err != nil {
+			return err
+		}
+		v.TransactionID = new(roomservertypes.TransactionID)
+		if err := readTransactionIDCapn(s, v.TransactionID); err != nil {
+			return err
+		}
+	}
+	if c.HasJoined() {
+		l, err := c.Joined()
+		if err != nil {
+			return err
+		}
+		v.Joined = make([]string, l.Len())
+		for i := range v.Joined {
+			if v.Joined[i], err = l.At(i); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeClientEventCapn(c ClientEventCapn, v *gomatrixserverlib.ClientEvent) error {
+	if err := c.SetContent(v.Content); err != nil {
+		return err
+	}
+	if err := c.SetEventID(v.EventID); err != nil {
+		return err
+	}
+	c.SetEventNID(v.EventNID)
+	c.SetDomainOffset(v.DomainOffset)
+	c.SetDepth(v.Depth)
+	c.SetOriginServerTS(uint64(v.OriginServerTS))
+	if err := c.SetRoomID(v.RoomID); err != nil {
+		return err
+	}
+	if err := c.SetSender(v.Sender); err != nil {
+		return err
+	}
+	if v.StateKey != nil {
+		c.SetStateKeySet(true)
+		if err := c.SetStateKey(*v.StateKey); err != nil {
+			return err
+		}
+	}
+	if err := c.SetType(v.Type); err != nil {
+		return err
+	}
+	if err := c.SetRedacts(v.Redacts); err != nil {
+		return err
+	}
+	if err := c.SetHint(v.Hint); err != nil {
+		return err
+	}
+	c.SetVisible(v.Visible)
+	if err := c.SetUnsigned(v.Unsigned); err != nil {
+		return err
+	}
+	c.SetEventOffset(v.EventOffset)
+	return nil
+}
+
+func readClientEventCapn(c ClientEventCapn, v *gomatrixserverlib.ClientEvent) error {
+	var err error
+	if c.HasContent() {
+		data, err := c.Content()
+		if err != nil {
+			return err
+		}
+		v.Content = append([]byte{}, data...)
+	}
+	if v.EventID, err = c.EventID(); err != nil {
+		return err
+	}
+	v.EventNID = c.EventNID()
+	v.DomainOffset = c.DomainOffset()
+	v.Depth = c.Depth()
+	v.OriginServerTS = gomatrixserverlib.Timestamp(c.OriginServerTS())
+	if v.RoomID, err = c.RoomID(); err != nil {
+		return err
+	}
+	if v.Sender, err = c.Sender(); err != nil {
+		return err
+	}
+	if c.StateKeySet() {
+		s, err := c.StateKey()
+		if err != nil {
+			return err
+		}
+		v.StateKey = &s
+	}
+	if v.Type, err = c.Type(); err != nil {
+		return err
+	}
+	if v.Redacts, err = c.Redacts(); err != nil {
+		return err
+	}
+	if v.Hint, err = c.Hint(); err != nil {
+		return err
+	}
+	v.Visible = c.Visible()
+	if c.HasUnsigned() {
+		data, err := c.Unsigned()
+		if err != nil {
+			return err
+		}
+		v.Unsigned = append([]byte{}, data...)
+	}
+	v.EventOffset = c.EventOffset()
+	return nil
+}
+
+func writeTransactionIDCapn(c TransactionIDCapn, v *roomservertypes.TransactionID) error {
+	if err := c.SetDeviceID(v.DeviceID); err != nil {
+		return err
+	}
+	if err := c.SetTransactionID(v.TransactionID); err != nil {
+		return err
+	}
+	if err := c.SetIp(v.IP); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readTransactionIDCapn(c TransactionIDCapn, v *roomservertypes.TransactionID) error {
+	var err error
+	if v.DeviceID, err = c.DeviceID(); err != nil {
+		return err
+	}
+	if v.TransactionID, err = c.TransactionID(); err != nil {
+		return err
+	}
+	if v.IP, err = c.Ip(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeOutputNewInviteEventCapn(c OutputNewInviteEventCapn, v *OutputNewInviteEvent) error {
+	if s, err := c.NewEvent(); err != nil {
+		return err
+	} else if err := writeClientEventCapn(s, &v.Event); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readOutputNewInviteEventCapn(c OutputNewInviteEventCapn, v *OutputNewInviteEvent) error {
+	if s, err := c.Event(); err != nil {
+		return err
+	} else if err := readClientEventCapn(s, &v.Event); err != nil {
+		return err
+	}
+	return nil
+}
+
+func writeOutputRetireInviteEventCapn(c OutputRetireInviteEventCapn, v *OutputRetireInviteEvent) error {
+	if err := c.SetEventID(v.EventID); err != nil {
+		return err
+	}
+	if err := c.SetTargetUserID(v.TargetUserID); err != nil {
+		return err
+	}
+	if err := c.SetRetiredByEventID(v.RetiredByEventID); err != nil {
+		return err
+	}
+	if err := c.SetMembership(v.Membership); err != nil {
+		return err
+	}
+	return nil
+}
+
+func readOutputRetireInviteEventCapn(c OutputRetireInviteEventCapn, v *OutputRetireInviteEvent) error {
+	var err error
+	if v.EventID, err = c.EventID(); err != nil {
+		return err
+	}
+	if v.TargetUserID, err = c.TargetUserID(); err != nil {
+		return err
+	}
+	if v.RetiredByEventID, err = c.RetiredByEventID(); err != nil {
+		return err
+	}
+	if v.Membership, err = c.Membership(); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package roomserverapi
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/roomservertypes"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+)
+
+func TestOutputEventCapnRoundTrip(t *testing.T) {
+	stateKey := ""
+	events := []OutputEvent{
+		{
+			Type: OutputTypeNewRoomEvent,
+			NewRoomEvent: &OutputNewRoomEvent{
+				Event: gomatrixserverlib.ClientEvent{
+					Content:        []byte(`{"name":"room"}`),
+					EventID:        "$event:example.com",
+					EventNID:       1,
+					Depth:          2,
+					OriginServerTS: 1600000000000,
+					RoomID:         "!room:example.com",
+					Sender:         "@alice:example.com",
+					StateKey:       &stateKey,
+					Type:           "m.room.name",
+					Visible:        true,
+				},
+				AddsStateEventIDs:    []string{"$event:example.com"},
+				RemovesStateEventIDs: []string{},
+				SendAsServer:         "example.com",
+				TransactionID:        &roomservertypes.TransactionID{DeviceID: "DEVICE", TransactionID: "txn"},
+				Joined:               []string{"@alice:example.com"},
+			},
+		},
+		{
+			Type: OutputTypeRetireInviteEvent,
+			RetireInviteEvent: &OutputRetireInviteEvent{
+				EventID:      "$invite:example.com",
+				TargetUserID: "@bob:example.com",
+				Membership:   "join",
+			},
+		},
+	}
+	for i := range events {
+		data, err := core.EncodeMsg(core.FORMAT_CAPN, &events[i])
+		if err != nil {
+			t.Fatalf("encode %d: %v", i, err)
+		}
+		var ev OutputEvent
+		if err := core.DecodeMsg(core.FORMAT_CAPN, data, &ev); err != nil {
+			t.Fatalf("decode %d: %v", i, err)
+		}
+		if !reflect.DeepEqual(ev, events[i]) {
+			t.Errorf("round trip %d: got %+v want %+v", i, ev, events[i])
+		}
+	}
+}
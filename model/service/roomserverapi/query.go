@@ -121,6 +121,7 @@ type QueryRoomStateResponse struct {
 	ThirdInvite       map[string]*gomatrixserverlib.Event `json:"third_invite_map"`
 	Avatar            *gomatrixserverlib.Event            `json:"avatar_ev"`
 	GuestAccess       *gomatrixserverlib.Event            `json:"guest_access"`
+	Tombstone         *gomatrixserverlib.Event            `json:"tombstone_ev"`
 }
 
 type RoomserverRpcRequest struct {
@@ -175,6 +176,8 @@ func (rs *QueryRoomStateResponse) InitFromEvents(events []gomatrixserverlib.Even
 			rs.Avatar = &events[idx]
 		} else if ev.Type() == "m.room.guest_access" {
 			rs.GuestAccess = &events[idx]
+		} else if ev.Type() == "m.room.tombstone" {
+			rs.Tombstone = &events[idx]
 		} else if ev.Type() == "m.room.third_party_invite" {
 			rs.ThirdInvite[*ev.StateKey()] = &events[idx]
 		} else if ev.Type() == "m.room.member" {
@@ -225,6 +228,9 @@ func (rs *QueryRoomStateResponse) GetAllState() []gomatrixserverlib.Event {
 	if rs.GuestAccess != nil {
 		res = append(res, *rs.GuestAccess)
 	}
+	if rs.Tombstone != nil {
+		res = append(res, *rs.Tombstone)
+	}
 	for _, value := range rs.Join {
 		res = append(res, *value)
 	}
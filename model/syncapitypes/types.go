@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 	"github.com/json-iterator/go"
 )
@@ -66,7 +67,9 @@ type Response struct {
 	DeviceList DeviceLists `json:"device_lists"`
 	// compatibility with no definition todo: del it
 	SignNum map[string]int `json:"device_one_time_keys_count"`
-	Lock    *sync.Mutex    `json:"-"`
+	// UnusedFallbackKeyTypes algorithms the device has an unused fallback key for
+	UnusedFallbackKeyTypes []string    `json:"device_unused_fallback_key_types"`
+	Lock                   *sync.Mutex `json:"-"`
 }
 
 func (p *Response) Encode() ([]byte, error) {
@@ -321,6 +324,20 @@ type ReceiptUpdate struct {
 	RoomID string   `json:"room_id"`
 }
 
+type PurgeHistoryUpdate struct {
+	RoomID string `json:"room_id"`
+}
+
+// SearchEventResult is a hit of the search index
+type SearchEventResult struct {
+	ID             int64
+	EventID        string
+	RoomID         string
+	Sender         string
+	Rank           float64
+	OriginServerTS int64
+}
+
 type TypingUpdate struct {
 	Type      string   `json:"type,omitempty"`
 	RoomID    string   `json:"room_id,omitempty"`
@@ -369,6 +386,37 @@ type SyncUnreadResponse struct {
 	Count int64 `json:"count,omitempty"`
 }
 
+// SyncSearchRequest asks a syncserver instance for the next page of search
+// results in the rooms of a user it holds, the results start after FromRank
+// and FromID of the last result of the previous page
+type SyncSearchRequest struct {
+	UserID         string   `json:"user_id"`
+	Rooms          []string `json:"rooms"`
+	SyncInstance   uint32   `json:"sync_instance"`
+	SearchTerm     string   `json:"search_term"`
+	OrderByRank    bool     `json:"order_by_rank"`
+	FromRank       float64  `json:"from_rank"`
+	FromID         int64    `json:"from_id"`
+	Limit          int      `json:"limit"`
+	Senders        []string `json:"senders,omitempty"`
+	NotSenders     []string `json:"not_senders,omitempty"`
+	BeforeLimit    int      `json:"before_limit"`
+	AfterLimit     int      `json:"after_limit"`
+	IncludeProfile bool     `json:"include_profile"`
+	Reply          string
+}
+
+type SyncSearchResponse struct {
+	Count   int64            `json:"count"`
+	Results []SyncSearchItem `json:"results"`
+	Error   string           `json:"error,omitempty"`
+}
+
+type SyncSearchItem struct {
+	ID     int64                 `json:"id"`
+	Result external.SearchResult `json:"result"`
+}
+
 type UserTimeLineStream struct {
 	Offset     int64  `json:"offset,omitempty"`
 	UserID     string `json:"user_id,omitempty"`
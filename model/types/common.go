@@ -33,11 +33,14 @@ var ReceiptTopicDef = "sync-receipt-topic"
 var EventUpdateTopicDef = "sync-event-update-topic"
 var TypingUpdateTopicDef = "sync-typing-update-topic"
 var ReceiptUpdateTopicDef = "sync-receipt-update-topic"
+var PurgeHistoryTopicDef = "sync-purge-history-topic"
 var SyncServerTopicDef = "sync-server-topic"
 var LoginTopicDef = "login-info-topic"
 var UnreadReqTopicDef = "sync-unread-req-topic"
 var SyncUnreadTopicDef = "sync-server-unread-topic"
+var SyncSearchTopicDef = "sync-server-search-topic"
 var EduTopicDef = "fed-edu-topic"
+var DeviceListUpdateTopicDef = "fed-device-list-update-topic"
 var ProfileUpdateTopicDef = "fed-profile-update-topic"
 var FilterTokenTopicDef = "filter-token-topic"
 var DeviceStateUpdateDef = "sync-device-state-update-topic"
@@ -57,6 +60,7 @@ const (
 	PROFILE_RPC_GROUP    = "profilerpc"
 	PUBLICROOM_RPC_GROUP = "publicroomrpc"
 	RCSSERVER_RPC_GROUP  = "rcsserverrpc"
+	DEVICELIST_RPC_GROUP = "devicelistrpc"
 	ROOMINPUT_RPC_GROUP  = "roominputrpc"
 	ROOOMALIAS_RPC_GROUP = "roomaliasrpc"
 	ROOMQRY_PRC_GROUP    = "roomqryrpc"
@@ -174,8 +178,17 @@ type KeyUpdateContent struct {
 }
 
 type DeviceKeyChanges struct {
-	Offset        int64  `json:"off_set"`
-	ChangedUserID string `json:"device_key_change_user"`
+	Offset          int64  `json:"off_set"`
+	ChangedUserID   string `json:"device_key_change_user"`
+	ChangedDeviceID string `json:"device_key_change_device,omitempty"`
+	// PrevOffset is the offset of the previous change of the devices of a
+	// local user, sent as prev_id of m.device_list_update
+	PrevOffset int64 `json:"prev_off_set,omitempty"`
+	// DeviceDisplayName, Keys and Deleted describe the changed device in the
+	// m.device_list_update sent to the servers sharing a room with the user
+	DeviceDisplayName string                             `json:"device_display_name,omitempty"`
+	Keys              *gomatrixserverlib.DeviceKeysQuery `json:"keys,omitempty"`
+	Deleted           bool                               `json:"deleted,omitempty"`
 }
 
 type EventContent struct {
@@ -329,6 +342,47 @@ type ProfileStreamUpdate struct {
 	IsUpdateBase   bool         `json:"is_update_base"` //matrix /presence/{userID}/status only update presence, status_msg, ext_status_msg
 }
 
+// UserDirectoryEntry is a profile of the user directory
+type UserDirectoryEntry struct {
+	UserID      string
+	DisplayName string
+	AvatarURL   string
+	UserName    string
+	JobNumber   string
+	Email       string
+	IsLocal     bool
+}
+
+// EventReport is a report of an event waiting in or handled by the moderation queue
+type EventReport struct {
+	ID         int64
+	RoomID     string
+	EventID    string
+	Reporter   string
+	Sender     string
+	Reason     string
+	Score      int
+	ReceivedTs int64
+	Status     string
+	Assignee   string
+	ResolvedBy string
+	ResolvedTs int64
+	Resolution string
+	Actions    []string
+}
+
+// EventReportFilter selects event reports, empty fields match any report
+type EventReportFilter struct {
+	RoomID   string
+	Reporter string
+	Sender   string
+	Status   string
+	Assignee string
+	// only reports with a smaller id, 0 starts at the newest
+	FromID int64
+	Limit  int
+}
+
 type StdEvent struct {
 	Sender  string      `json:"sender"`
 	Type    string      `json:"type"`
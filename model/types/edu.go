@@ -0,0 +1,83 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import "github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+
+// EDU types sent over federation, profile, receipt and typing are the private
+// ones older ligase nodes speak
+const (
+	EduProfile           = "profile"
+	EduReceipt           = "receipt"
+	EduTyping            = "typing"
+	EduMTyping           = "m.typing"
+	EduMReceipt          = "m.receipt"
+	EduMPresence         = "m.presence"
+	EduMDeviceListUpdate = "m.device_list_update"
+	EduMDirectToDevice   = "m.direct_to_device"
+)
+
+// TypingEduContent is the content of m.typing
+type TypingEduContent struct {
+	RoomID string `json:"room_id"`
+	UserID string `json:"user_id"`
+	Typing bool   `json:"typing"`
+}
+
+// ReceiptEduContent is the content of m.receipt, receipts by room id and
+// receipt type
+type ReceiptEduContent map[string]map[string]map[string]ReceiptEduUserReceipt
+
+type ReceiptEduUserReceipt struct {
+	Data     ReceiptEduData `json:"data"`
+	EventIDs []string       `json:"event_ids"`
+}
+
+type ReceiptEduData struct {
+	TS int64 `json:"ts"`
+}
+
+// PresenceEduContent is the content of m.presence
+type PresenceEduContent struct {
+	Push []PresenceEduUpdate `json:"push"`
+}
+
+type PresenceEduUpdate struct {
+	UserID          string `json:"user_id"`
+	Presence        string `json:"presence"`
+	StatusMsg       string `json:"status_msg,omitempty"`
+	LastActiveAgo   int64  `json:"last_active_ago"`
+	CurrentlyActive bool   `json:"currently_active,omitempty"`
+}
+
+// DeviceListUpdateContent is the content of m.device_list_update
+type DeviceListUpdateContent struct {
+	UserID            string                             `json:"user_id"`
+	DeviceID          string                             `json:"device_id"`
+	DeviceDisplayName string                             `json:"device_display_name,omitempty"`
+	StreamID          int64                              `json:"stream_id"`
+	PrevID            []int64                            `json:"prev_id,omitempty"`
+	Deleted           bool                               `json:"deleted,omitempty"`
+	Keys              *gomatrixserverlib.DeviceKeysQuery `json:"keys,omitempty"`
+}
+
+// DirectToDeviceContent is the content of m.direct_to_device, messages by
+// user id and device id
+type DirectToDeviceContent struct {
+	Sender    string                            `json:"sender"`
+	Type      string                            `json:"type"`
+	MessageID string                            `json:"message_id"`
+	Messages  map[string]map[string]interface{} `json:"messages"`
+}
@@ -20,3 +20,10 @@ const (
 	ONETIMEKEYSTRING
 	ONETIMEKEYOBJECT
 )
+
+// cross-signing key types, they are also the usages of the keys
+const (
+	CrossSigningMasterKey      = "master"
+	CrossSigningSelfSigningKey = "self_signing"
+	CrossSigningUserSigningKey = "user_signing"
+)
@@ -34,3 +34,47 @@ type AlHolder struct {
 	DeviceID,
 	SupportedAlgorithm string
 }
+
+// CrossSigningKey structure
+type CrossSigningKey struct {
+	UserID     string                       `json:"user_id"`
+	Usage      []string                     `json:"usage"`
+	Keys       map[string]string            `json:"keys"`
+	Signatures map[string]map[string]string `json:"signatures,omitempty"`
+}
+
+// CrossSigningSig structure, a signature by the origin key
+type CrossSigningSig struct {
+	OriginUserID string `json:"origin_user_id"`
+	OriginKeyID  string `json:"origin_key_id"`
+	Signature    string `json:"signature"`
+}
+
+// KeyBackupVersion structure
+type KeyBackupVersion struct {
+	Version   int64  `json:"version"`
+	Algorithm string `json:"algorithm"`
+	AuthData  string `json:"auth_data"`
+	Etag      int64  `json:"etag"`
+	Deleted   bool   `json:"deleted,omitempty"`
+}
+
+// KeyBackupSession structure
+type KeyBackupSession struct {
+	RoomID            string `json:"room_id"`
+	SessionID         string `json:"session_id"`
+	FirstMessageIndex int64  `json:"first_message_index"`
+	ForwardedCount    int64  `json:"forwarded_count"`
+	IsVerified        bool   `json:"is_verified"`
+	SessionData       string `json:"session_data"`
+}
+
+// FallbackKey structure, the key a device hands out once its one time keys
+// run out
+type FallbackKey struct {
+	KeyID     string `json:"key_id"`
+	Key       string `json:"key"`
+	Algorithm string `json:"algorithm"`
+	Signature string `json:"signature"`
+	Used      bool   `json:"used"`
+}
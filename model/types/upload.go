@@ -19,8 +19,9 @@ package types
 
 // UploadEncrypt structure
 type UploadEncrypt struct {
-	DeviceKeys DeviceKeys             `json:"device_keys"`
-	OneTimeKey map[string]interface{} `json:"one_time_keys"`
+	DeviceKeys   DeviceKeys             `json:"device_keys"`
+	OneTimeKey   map[string]interface{} `json:"one_time_keys"`
+	FallbackKeys map[string]interface{} `json:"fallback_keys"`
 }
 
 func (r *UploadEncrypt) Encode() ([]byte, error) {
@@ -33,8 +34,9 @@ func (r *UploadEncrypt) Decode(input []byte) error {
 
 // UploadEncryptSpecific structure
 type UploadEncryptSpecific struct {
-	DeviceKeys DeviceKeys         `json:"device_keys"`
-	OneTimeKey OneTimeKeySpecific `json:"one_time_keys"`
+	DeviceKeys  DeviceKeys         `json:"device_keys"`
+	OneTimeKey  OneTimeKeySpecific `json:"one_time_keys"`
+	FallbackKey OneTimeKeySpecific `json:"fallback_keys"`
 }
 
 // DeviceKeys structure
@@ -49,6 +51,7 @@ type DeviceKeys struct {
 // KeyObject structure
 type KeyObject struct {
 	Key       string                       `json:"key"`
+	Fallback  bool                         `json:"fallback,omitempty"`
 	Signature map[string]map[string]string `json:"signatures"`
 }
 
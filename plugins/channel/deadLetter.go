@@ -0,0 +1,191 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package channel
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/finogeeks/ligase/core"
+	log "github.com/finogeeks/ligase/skunkworks/log"
+)
+
+// RetryConf is implemented by consumer configs which retry failed messages
+// and park them in the dead letter topic
+type RetryConf interface {
+	RetryTimes() *int
+	RetryBackoffMS() *int
+	RetryMaxBackoffMS() *int
+	DeadLetterEnable() *bool
+}
+
+const (
+	DeadLetterSuffix = ".dlq"
+
+	DefaultRetryBackoffMS    = 100
+	DefaultRetryMaxBackoffMS = 5000
+
+	// headers added to the messages of a dead letter topic
+	HeaderDLQTopic     = "dlq-topic"
+	HeaderDLQPartition = "dlq-partition"
+	HeaderDLQOffset    = "dlq-offset"
+	HeaderDLQGroup     = "dlq-group"
+	HeaderDLQError     = "dlq-error"
+	HeaderDLQRetries   = "dlq-retries"
+	HeaderDLQTimestamp = "dlq-ts"
+)
+
+// DeadLetterTopic returns the topic where failed messages of topic are parked
+func DeadLetterTopic(topic string) string {
+	return topic + DeadLetterSuffix
+}
+
+// retryPolicy decides how many times a failed message is handed to the
+// consumer again before it is dropped or parked in the dead letter topic
+type retryPolicy struct {
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	deadLetter bool
+}
+
+func newRetryPolicy(conf interface{}) retryPolicy {
+	backoffMS := DefaultRetryBackoffMS
+	maxBackoffMS := DefaultRetryMaxBackoffMS
+	p := retryPolicy{}
+	if retryConf, ok := conf.(RetryConf); ok {
+		if retryConf.RetryTimes() != nil {
+			p.retries = *retryConf.RetryTimes()
+		}
+		if retryConf.RetryBackoffMS() != nil {
+			backoffMS = *retryConf.RetryBackoffMS()
+		}
+		if retryConf.RetryMaxBackoffMS() != nil {
+			maxBackoffMS = *retryConf.RetryMaxBackoffMS()
+		}
+		if retryConf.DeadLetterEnable() != nil {
+			p.deadLetter = *retryConf.DeadLetterEnable()
+		}
+	}
+	p.backoff = time.Duration(backoffMS) * time.Millisecond
+	p.maxBackoff = time.Duration(maxBackoffMS) * time.Millisecond
+	return p
+}
+
+// handle delivers a message to the consumer, a panic or an error reported by
+// a core.IChannelErrConsumer is retried with exponential backoff. It returns
+// the number of retries and the last failure once the policy is exhausted.
+func (p *retryPolicy) handle(ctx context.Context, consumer core.IChannelConsumer, topic string, partition int32, data []byte, rawMsg interface{}) (retries int, err error) {
+	backoff := p.backoff
+	for {
+		err = deliver(ctx, consumer, topic, partition, data, rawMsg)
+		if err == nil || retries >= p.retries {
+			return retries, err
+		}
+		retries++
+		log.Warnf("channel consumer topic:%s partition:%d failed: %v, retry %d/%d after %v", topic, partition, err, retries, p.retries, backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > p.maxBackoff {
+			backoff = p.maxBackoff
+		}
+	}
+}
+
+// handleAsync delivers a message to an async consumer and returns at once, a
+// reported failure is delivered again after the backoff and park is called
+// once the policy is exhausted. A retried message may be written after the
+// messages which followed it.
+func (p *retryPolicy) handleAsync(ctx context.Context, consumer core.IChannelAsyncConsumer, topic string, partition int32, data []byte, rawMsg interface{}, park func(retries int, err error)) {
+	var attempt func(retries int, backoff time.Duration)
+	attempt = func(retries int, backoff time.Duration) {
+		deliverAsync(ctx, consumer, topic, partition, data, rawMsg, func(err error) {
+			if err == nil {
+				return
+			}
+			if retries >= p.retries {
+				park(retries, err)
+				return
+			}
+			log.Warnf("channel consumer topic:%s partition:%d failed: %v, retry %d/%d after %v", topic, partition, err, retries+1, p.retries, backoff)
+			next := backoff * 2
+			if next > p.maxBackoff {
+				next = p.maxBackoff
+			}
+			time.AfterFunc(backoff, func() {
+				attempt(retries+1, next)
+			})
+		})
+	}
+	attempt(0, p.backoff)
+}
+
+func deliverAsync(ctx context.Context, consumer core.IChannelAsyncConsumer, topic string, partition int32, data []byte, rawMsg interface{}, done func(error)) {
+	defer func() {
+		if e := recover(); e != nil {
+			log.Errorf("channel consumer panic: %#v", e)
+			done(fmt.Errorf("panic: %v", e))
+		}
+	}()
+	consumer.OnMessageAsync(ctx, topic, partition, data, rawMsg, done)
+}
+
+func deliver(ctx context.Context, consumer core.IChannelConsumer, topic string, partition int32, data []byte, rawMsg interface{}) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			log.Errorf("channel consumer panic: %#v", e)
+			err = fmt.Errorf("panic: %v", e)
+		}
+	}()
+	if errConsumer, ok := consumer.(core.IChannelErrConsumer); ok {
+		return errConsumer.OnMessageErr(ctx, topic, partition, data, rawMsg)
+	}
+	consumer.OnMessage(ctx, topic, partition, data, rawMsg)
+	return nil
+}
+
+// deadLetterHeaders returns the headers of a message parked in the dead
+// letter topic, the original headers are kept
+func deadLetterHeaders(headers map[string]string, topic string, partition int32, offset int64, group string, err error, retries int) map[string]string {
+	h := make(map[string]string, len(headers)+7)
+	for k, v := range headers {
+		h[k] = v
+	}
+	h[HeaderDLQTopic] = topic
+	h[HeaderDLQPartition] = strconv.Itoa(int(partition))
+	h[HeaderDLQOffset] = strconv.FormatInt(offset, 10)
+	h[HeaderDLQGroup] = group
+	h[HeaderDLQError] = err.Error()
+	h[HeaderDLQRetries] = strconv.Itoa(retries)
+	h[HeaderDLQTimestamp] = strconv.FormatInt(time.Now().UnixNano()/1000000, 10)
+	return h
+}
+
+// ReplayHeaders strips the dead letter metadata from the headers of a parked
+// message so that it can be published to its original topic again
+func ReplayHeaders(headers map[string]string) map[string]string {
+	h := make(map[string]string, len(headers))
+	for k, v := range headers {
+		switch k {
+		case HeaderDLQTopic, HeaderDLQPartition, HeaderDLQOffset, HeaderDLQGroup,
+			HeaderDLQError, HeaderDLQRetries, HeaderDLQTimestamp, "retries":
+		default:
+			h[k] = v
+		}
+	}
+	return h
+}
@@ -72,6 +72,9 @@ type KafkaChannel struct {
 	broker      string
 	conf        interface{}
 	subTopics   []string
+	retry       retryPolicy
+	dlqMutex    sync.Mutex
+	dlqProducer *kafka.Producer
 }
 
 func init() {
@@ -93,6 +96,10 @@ func (c *KafkaChannel) SetTopic(topic string) {
 	c.topic = topic
 }
 
+func (c *KafkaChannel) GetTopic() string {
+	return c.topic
+}
+
 func (c *KafkaChannel) SetGroup(group string) {
 	c.grp = group
 }
@@ -226,6 +233,10 @@ func (c *KafkaChannel) Close() {
 	if c.consumer != nil {
 		c.consumer.Close()
 	}
+
+	if c.dlqProducer != nil {
+		c.dlqProducer.Close()
+	}
 }
 
 func (c *KafkaChannel) Commit(rawMsgs []interface{}) error {
@@ -323,7 +334,16 @@ func (c *KafkaChannel) startConsumer() error {
 		span := common.StartSpanFromMsgAfterReceived(metricName, msg)
 		defer span.Finish()
 		ctx := common.ContextWithSpan(context.Background(), span)
-		consumer.OnMessage(ctx, *msg.TopicPartition.Topic, msg.TopicPartition.Partition, msg.Value, msg)
+		if asyncConsumer, ok := consumer.(core.IChannelAsyncConsumer); ok {
+			c.retry.handleAsync(ctx, asyncConsumer, *msg.TopicPartition.Topic, msg.TopicPartition.Partition, msg.Value, msg, func(retries int, err error) {
+				c.deadLetter(msg, retries, err)
+			})
+			return
+		}
+		retries, err := c.retry.handle(ctx, consumer, *msg.TopicPartition.Topic, msg.TopicPartition.Partition, msg.Value, msg)
+		if err != nil {
+			c.deadLetter(msg, retries, err)
+		}
 	}
 	var evHandler func()
 	evHandler = func() {
@@ -462,6 +482,7 @@ func (c *KafkaChannel) preStartConsumer(broker string, statsInterval int) error
 		}
 
 		c.consumer = s
+		c.retry = newRetryPolicy(c.conf)
 	}
 
 	return nil
@@ -685,6 +706,75 @@ func (c *KafkaChannel) pubRetry(msg *kafka.Message) (retry bool, retries int) {
 	}
 }
 
+// deadLetter parks a message which failed all its retries in the dead letter
+// topic of its topic, it is dropped when the dead letter topic is disabled
+func (c *KafkaChannel) deadLetter(msg *kafka.Message, retries int, err error) {
+	topic := *msg.TopicPartition.Topic
+	if !c.retry.deadLetter {
+		log.Errorf("channel consumer drop msg topic:%s partition:%d offset:%d retries:%d err:%v",
+			topic, msg.TopicPartition.Partition, msg.TopicPartition.Offset, retries, err)
+		return
+	}
+	headers := make(map[string]string, len(msg.Headers))
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	headers = deadLetterHeaders(headers, topic, msg.TopicPartition.Partition, int64(msg.TopicPartition.Offset), c.grp, err, retries)
+	dlqTopic := DeadLetterTopic(topic)
+	if err := c.pubDeadLetter(dlqTopic, msg.Key, msg.Value, headers); err != nil {
+		log.Errorf("channel consumer failed to park msg topic:%s partition:%d offset:%d in %s err:%v",
+			topic, msg.TopicPartition.Partition, msg.TopicPartition.Offset, dlqTopic, err)
+		return
+	}
+	log.Warnf("channel consumer parked msg topic:%s partition:%d offset:%d in %s", topic, msg.TopicPartition.Partition, msg.TopicPartition.Offset, dlqTopic)
+}
+
+// pubDeadLetter publishes synchronously with a producer owned by the consumer
+// channel, it is created on the first dead letter
+func (c *KafkaChannel) pubDeadLetter(topic string, keys, bytes []byte, headers map[string]string) error {
+	c.dlqMutex.Lock()
+	if c.dlqProducer == nil {
+		p, err := kafka.NewProducer(&kafka.ConfigMap{"bootstrap.servers": c.broker})
+		if err != nil {
+			c.dlqMutex.Unlock()
+			return err
+		}
+		go func() {
+			for e := range p.Events() {
+				if ev, ok := e.(kafka.Error); ok {
+					log.Errorf("dead letter producer error: %v", ev)
+				}
+			}
+		}()
+		c.dlqProducer = p
+	}
+	c.dlqMutex.Unlock()
+
+	if _, ok := c.cacheTopics.Load(topic); !ok {
+		if err := c.createTopic(c.broker, topic); err != nil {
+			return err
+		}
+	}
+	msg := kafka.Message{
+		TopicPartition: kafka.TopicPartition{Topic: &topic, Partition: kafka.PartitionAny},
+		Key:            keys,
+		Value:          bytes,
+	}
+	for k, v := range headers {
+		msg.Headers = append(msg.Headers, kafka.Header{Key: k, Value: []byte(v)})
+	}
+	deliveryChan := make(chan kafka.Event, 1)
+	if err := c.dlqProducer.Produce(&msg, deliveryChan); err != nil {
+		return err
+	}
+	select {
+	case e := <-deliveryChan:
+		return e.(*kafka.Message).TopicPartition.Error
+	case <-time.After(time.Duration(DefaultTimeOut) * time.Second):
+		return errors.New("dead letter delivery timeout")
+	}
+}
+
 //nats methed interface
 func (c *KafkaChannel) SendRecv(topic string, bytes []byte, timeout int, headers map[string]string) ([]byte, error) {
 	return nil, errors.New("unsupported commond SendRecv")
@@ -0,0 +1,509 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/finogeeks/ligase/adapter"
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/core"
+	log "github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const (
+	DefaultMemoryQueueSize      = 4096
+	DefaultMemoryPublishTimeout = time.Second * 30
+)
+
+// memoryPublishTimeout bounds how long a publish waits on a full queue
+var memoryPublishTimeout = DefaultMemoryPublishTimeout
+
+// MemoryMessage is the raw message handed to IChannelConsumer.OnMessage by
+// the memory channel, it can be passed back to Commit like a kafka message.
+type MemoryMessage struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string]string
+
+	group *memoryGroup
+	reply chan []byte
+}
+
+func (m *MemoryMessage) GetHeaders() map[string]string {
+	return m.Headers
+}
+
+// Respond answers a message published with SendRecv, it is a no-op for
+// messages which expect no reply.
+func (m *MemoryMessage) Respond(data []byte) error {
+	if m.reply == nil {
+		return errors.New("memory message has no reply subject")
+	}
+	select {
+	case m.reply <- data:
+	default:
+		// only the first response is delivered, like nats request
+	}
+	return nil
+}
+
+// memoryBroker holds the topics of one transport, transports with
+// different addresses are isolated from each other.
+type memoryBroker struct {
+	mutex  sync.Mutex
+	topics map[string]*memoryTopic
+}
+
+type memoryTopic struct {
+	name       string
+	partitions int32
+	mutex      sync.RWMutex
+	offsets    []int64
+	groups     map[string]*memoryGroup
+}
+
+type memoryGroup struct {
+	name      string
+	topic     *memoryTopic
+	mutex     sync.RWMutex
+	members   []*MemoryChannel
+	queues    []chan *MemoryMessage
+	committed []int64
+	ready     chan struct{}
+	readyOnce sync.Once
+}
+
+var memoryBrokers sync.Map
+
+func getMemoryBroker(broker string) *memoryBroker {
+	val, _ := memoryBrokers.LoadOrStore(broker, &memoryBroker{topics: make(map[string]*memoryTopic)})
+	return val.(*memoryBroker)
+}
+
+func (b *memoryBroker) getTopic(topic string) *memoryTopic {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	t, ok := b.topics[topic]
+	if !ok {
+		partitions := int32(adapter.GetKafkaNumPartitions())
+		if partitions <= 0 {
+			partitions = 1
+		}
+		t = &memoryTopic{
+			name:       topic,
+			partitions: partitions,
+			offsets:    make([]int64, partitions),
+			groups:     make(map[string]*memoryGroup),
+		}
+		b.topics[topic] = t
+	}
+	return t
+}
+
+func (t *memoryTopic) getGroup(group string) *memoryGroup {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	g, ok := t.groups[group]
+	if !ok {
+		g = &memoryGroup{
+			name:      group,
+			topic:     t,
+			queues:    make([]chan *MemoryMessage, t.partitions),
+			committed: make([]int64, t.partitions),
+			ready:     make(chan struct{}),
+		}
+		for i := int32(0); i < t.partitions; i++ {
+			g.queues[i] = make(chan *MemoryMessage, DefaultMemoryQueueSize)
+			go g.startWorker(i)
+		}
+		t.groups[group] = g
+	}
+	return g
+}
+
+func (t *memoryTopic) partition(partition int32, keys []byte) int32 {
+	if keys != nil {
+		return int32(common.CalcStringHashCode(string(keys)) % uint32(t.partitions))
+	}
+	if partition < 0 {
+		return 0
+	}
+	return partition % t.partitions
+}
+
+// publish waits while the queue of a group is full, so the publishers are
+// slowed down to the pace of the slowest consumer instead of losing messages.
+// A consumer publishing to a topic it consumes itself could wait on its own
+// full queue forever, the wait is bounded by memoryPublishTimeout and the
+// publisher gets an error for the groups which missed the message.
+func (t *memoryTopic) publish(partition int32, keys, bytes []byte, headers map[string]string, reply chan []byte) error {
+	t.mutex.Lock()
+	offset := t.offsets[partition]
+	t.offsets[partition]++
+	groups := make([]*memoryGroup, 0, len(t.groups))
+	for _, g := range t.groups {
+		groups = append(groups, g)
+	}
+	t.mutex.Unlock()
+
+	if len(groups) == 0 {
+		log.Warnf("memory channel topic:%s has no consumer, drop msg offset:%d", t.name, offset)
+		return nil
+	}
+	var full []string
+	var expired <-chan struct{}
+	for _, g := range groups {
+		msg := &MemoryMessage{
+			Topic:     t.name,
+			Partition: partition,
+			Offset:    offset,
+			Key:       keys,
+			Value:     bytes,
+			Headers:   headers,
+			group:     g,
+			reply:     reply,
+		}
+		select {
+		case g.queues[partition] <- msg:
+			continue
+		default:
+		}
+		if expired == nil {
+			ctx, cancel := context.WithTimeout(context.Background(), memoryPublishTimeout)
+			defer cancel()
+			expired = ctx.Done()
+		}
+		select {
+		case g.queues[partition] <- msg:
+		case <-expired:
+			log.Errorf("memory channel topic:%s partition:%d group:%s queue is full for %v, drop msg offset:%d", t.name, partition, g.name, memoryPublishTimeout, offset)
+			full = append(full, g.name)
+		}
+	}
+	if len(full) > 0 {
+		return fmt.Errorf("memory channel topic:%s partition:%d queue is full for groups %v", t.name, partition, full)
+	}
+	return nil
+}
+
+func (g *memoryGroup) join(c *MemoryChannel) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, member := range g.members {
+		if member == c {
+			return
+		}
+	}
+	g.members = append(g.members, c)
+	g.readyOnce.Do(func() {
+		close(g.ready)
+	})
+}
+
+func (g *memoryGroup) leave(c *MemoryChannel) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for i, member := range g.members {
+		if member == c {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			return
+		}
+	}
+}
+
+// assigned returns the member which owns the partition, partitions are
+// spread over the group members like a kafka range assignor would.
+func (g *memoryGroup) assigned(partition int32) *MemoryChannel {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	if len(g.members) == 0 {
+		return nil
+	}
+	return g.members[int(partition)%len(g.members)]
+}
+
+func (g *memoryGroup) startWorker(partition int32) {
+	<-g.ready
+	for msg := range g.queues[partition] {
+		for {
+			member := g.assigned(partition)
+			if member != nil {
+				member.onMessage(msg)
+				break
+			}
+			time.Sleep(time.Millisecond * 100)
+		}
+	}
+}
+
+func (g *memoryGroup) commit(partition int32, offset int64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if offset+1 > g.committed[partition] {
+		g.committed[partition] = offset + 1
+	}
+}
+
+// MemoryChannel is a channel of the in-process memory transport. It only
+// replaces the kafka channels, the rpc between components still goes
+// through nats (common.NewRpcClient), which nats.embedded runs in process.
+type MemoryChannel struct {
+	start   bool
+	logPorf bool
+	dir     int
+	id      string
+	//default topic
+	topic     string
+	grp       string
+	handler   core.IChannelConsumer
+	broker    *memoryBroker
+	subTopics []string
+	groups    []*memoryGroup
+	conf      interface{}
+	retry     retryPolicy
+}
+
+func init() {
+	core.RegisterChannel("memory", NewMemoryChannel)
+}
+
+func NewMemoryChannel(conf interface{}) (core.IChannel, error) {
+	c := new(MemoryChannel)
+	c.conf = conf
+	return c, nil
+}
+
+func (c *MemoryChannel) Init(logPorf bool) {
+	c.start = false
+	c.logPorf = logPorf
+}
+
+func (c *MemoryChannel) SetTopic(topic string) {
+	c.topic = topic
+}
+
+func (c *MemoryChannel) GetTopic() string {
+	return c.topic
+}
+
+func (c *MemoryChannel) SetGroup(group string) {
+	c.grp = group
+}
+
+func (c *MemoryChannel) SetID(id string) {
+	c.id = id
+}
+
+func (c *MemoryChannel) GetID() string {
+	return c.id
+}
+
+func (c *MemoryChannel) SetDir(dir int) {
+	c.dir = dir
+}
+
+func (c *MemoryChannel) GetDir() int {
+	return c.dir
+}
+
+func (c *MemoryChannel) SetHandler(handler core.IChannelConsumer) {
+	c.handler = handler
+}
+
+func (c *MemoryChannel) groupName() string {
+	if c.grp == "" {
+		// every subscriber without group receives all messages
+		return "__" + c.id
+	}
+	return c.grp
+}
+
+// PreStart registers the consumer group so that messages published before
+// Start are queued instead of dropped.
+func (c *MemoryChannel) PreStart(broker string, statsInterval int) {
+	if c.broker != nil {
+		return
+	}
+	c.broker = getMemoryBroker(broker)
+	if c.dir == core.CHANNEL_SUB {
+		c.retry = newRetryPolicy(c.conf)
+		c.subTopics = []string{c.topic}
+		c.groups = []*memoryGroup{c.broker.getTopic(c.topic).getGroup(c.groupName())}
+	}
+}
+
+func (c *MemoryChannel) Start() {
+	if c.broker == nil {
+		log.Fatalf("MemoryChannel: start fail, channel %s not prestart", c.id)
+		return
+	}
+
+	if c.start == false {
+		c.start = true
+		if c.dir == core.CHANNEL_SUB {
+			log.Infof("StartConsumer memory topic:%s group:%s", c.topic, c.grp)
+			for _, g := range c.groups {
+				g.join(c)
+			}
+		}
+	}
+}
+
+func (c *MemoryChannel) Stop() {
+	c.start = false
+	for _, g := range c.groups {
+		g.leave(c)
+	}
+}
+
+func (c *MemoryChannel) Close() {
+	c.Stop()
+}
+
+func (c *MemoryChannel) SubscribeTopic(topic string) error {
+	if c.broker == nil {
+		return errors.New("memory consumer not prestart yet")
+	}
+	for _, t := range c.subTopics {
+		if t == topic {
+			return nil
+		}
+	}
+	g := c.broker.getTopic(topic).getGroup(c.groupName())
+	c.subTopics = append(c.subTopics, topic)
+	c.groups = append(c.groups, g)
+	if c.start {
+		g.join(c)
+	}
+	return nil
+}
+
+func (c *MemoryChannel) onMessage(msg *MemoryMessage) {
+	defer func() {
+		if e := recover(); e != nil {
+			log.Errorf("channel consumer panic: %#v", e)
+		}
+	}()
+	if c.handler == nil {
+		log.Warnf("memory channel:%s topic:%s has no handler", c.id, msg.Topic)
+		return
+	}
+	metricName := fmt.Sprintf("t[%s]:p[%d]:g[%s]", msg.Topic, msg.Partition, c.grp)
+	span := common.StartSpanFromMsgAfterReceived(metricName, msg)
+	defer span.Finish()
+	ctx := common.ContextWithSpan(context.Background(), span)
+	if asyncConsumer, ok := c.handler.(core.IChannelAsyncConsumer); ok {
+		c.retry.handleAsync(ctx, asyncConsumer, msg.Topic, msg.Partition, msg.Value, msg, func(retries int, err error) {
+			c.deadLetter(msg, retries, err)
+		})
+		return
+	}
+	retries, err := c.retry.handle(ctx, c.handler, msg.Topic, msg.Partition, msg.Value, msg)
+	if err != nil {
+		c.deadLetter(msg, retries, err)
+	}
+}
+
+// deadLetter parks a message which failed all its retries in the dead letter
+// topic of its topic, it is dropped when the dead letter topic is disabled
+func (c *MemoryChannel) deadLetter(msg *MemoryMessage, retries int, err error) {
+	if !c.retry.deadLetter {
+		log.Errorf("channel consumer drop msg topic:%s partition:%d offset:%d retries:%d err:%v",
+			msg.Topic, msg.Partition, msg.Offset, retries, err)
+		return
+	}
+	headers := deadLetterHeaders(msg.Headers, msg.Topic, msg.Partition, msg.Offset, c.grp, err, retries)
+	t := c.broker.getTopic(DeadLetterTopic(msg.Topic))
+	if err := t.publish(t.partition(-1, msg.Key), msg.Key, msg.Value, headers, nil); err != nil {
+		log.Errorf("channel consumer drop msg topic:%s partition:%d offset:%d err:%v", msg.Topic, msg.Partition, msg.Offset, err)
+		return
+	}
+	log.Warnf("channel consumer parked msg topic:%s partition:%d offset:%d in %s", msg.Topic, msg.Partition, msg.Offset, t.name)
+}
+
+func (c *MemoryChannel) Commit(rawMsgs []interface{}) error {
+	for _, rawMsg := range rawMsgs {
+		if v, ok := rawMsg.(*MemoryMessage); ok && v.group != nil {
+			v.group.commit(v.Partition, v.Offset)
+		}
+	}
+	return nil
+}
+
+func (c *MemoryChannel) send(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
+	if c.start == false {
+		return errors.New("Memory producer not start yet")
+	}
+	if topic == "" {
+		topic = c.topic
+	}
+	t := c.broker.getTopic(topic)
+	return t.publish(t.partition(partition, keys), keys, bytes, headers, nil)
+}
+
+func (c *MemoryChannel) Send(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
+	return c.send(topic, partition, keys, bytes, headers)
+}
+
+func (c *MemoryChannel) SendWithRetry(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
+	return c.send(topic, partition, keys, bytes, headers)
+}
+
+func (c *MemoryChannel) SendAndRecv(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
+	return c.send(topic, partition, keys, bytes, headers)
+}
+
+func (c *MemoryChannel) SendAndRecvWithRetry(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
+	return c.send(topic, partition, keys, bytes, headers)
+}
+
+// SendRecv publishes a request and waits for the first consumer to answer
+// it with MemoryMessage.Respond.
+func (c *MemoryChannel) SendRecv(topic string, bytes []byte, timeout int, headers map[string]string) ([]byte, error) {
+	if c.start == false {
+		return nil, errors.New("Memory producer not start yet")
+	}
+	if topic == "" {
+		topic = c.topic
+	}
+	reply := make(chan []byte, 1)
+	t := c.broker.getTopic(topic)
+	if err := t.publish(t.partition(-1, nil), nil, bytes, headers, reply); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(time.Duration(timeout) * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case data := <-reply:
+		return data, nil
+	case <-timer.C:
+		return nil, errors.New("memory channel SendRecv timeout")
+	}
+}
@@ -0,0 +1,278 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package channel
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/finogeeks/ligase/core"
+)
+
+type memoryTestConsumer struct {
+	msgs chan *MemoryMessage
+}
+
+func (c *memoryTestConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
+	msg := rawMsg.(*MemoryMessage)
+	if msg.reply != nil {
+		msg.Respond(append([]byte("re:"), data...))
+	}
+	c.msgs <- msg
+}
+
+func newMemoryTestChannel(t *testing.T, broker string, dir int, id, topic, grp string) *MemoryChannel {
+	ch, err := core.GetChannel("memory", nil)
+	if err != nil {
+		t.Fatalf("get memory channel: %v", err)
+	}
+	ch.Init(false)
+	ch.SetDir(dir)
+	ch.SetID(id)
+	ch.SetTopic(topic)
+	ch.SetGroup(grp)
+	ch.PreStart(broker, 0)
+	return ch.(*MemoryChannel)
+}
+
+func recvMemoryMsg(t *testing.T, c *memoryTestConsumer) *MemoryMessage {
+	select {
+	case msg := <-c.msgs:
+		return msg
+	case <-time.After(time.Second):
+		t.Fatalf("memory channel message not delivered")
+	}
+	return nil
+}
+
+func TestMemoryChannelGroups(t *testing.T) {
+	pub := newMemoryTestChannel(t, "groups", core.CHANNEL_PUB, "pub", "topic", "")
+	subA := newMemoryTestChannel(t, "groups", core.CHANNEL_SUB, "subA", "topic", "grpA")
+	subB := newMemoryTestChannel(t, "groups", core.CHANNEL_SUB, "subB", "topic", "grpB")
+	consumerA := &memoryTestConsumer{msgs: make(chan *MemoryMessage, 8)}
+	consumerB := &memoryTestConsumer{msgs: make(chan *MemoryMessage, 8)}
+	subA.SetHandler(consumerA)
+	subB.SetHandler(consumerB)
+
+	pub.Start()
+	// queued before the consumers start
+	if err := pub.Send("", 0, []byte("key"), []byte("hello"), map[string]string{"h": "v"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	subA.Start()
+	subB.Start()
+
+	msgA := recvMemoryMsg(t, consumerA)
+	msgB := recvMemoryMsg(t, consumerB)
+	if string(msgA.Value) != "hello" || string(msgB.Value) != "hello" {
+		t.Fatalf("unexpected values %s %s", msgA.Value, msgB.Value)
+	}
+	if msgA.Headers["h"] != "v" {
+		t.Fatalf("header lost: %v", msgA.Headers)
+	}
+	if err := subA.Commit([]interface{}{msgA}); err != nil {
+		t.Fatalf("commit: %v", err)
+	}
+	if msgA.group.committed[msgA.Partition] != msgA.Offset+1 {
+		t.Fatalf("commit offset not recorded")
+	}
+}
+
+func TestMemoryChannelSendRecv(t *testing.T) {
+	pub := newMemoryTestChannel(t, "rpc", core.CHANNEL_PUB, "pub", "rpc", "")
+	sub := newMemoryTestChannel(t, "rpc", core.CHANNEL_SUB, "sub", "rpc", "rpc-grp")
+	consumer := &memoryTestConsumer{msgs: make(chan *MemoryMessage, 8)}
+	sub.SetHandler(consumer)
+	pub.Start()
+	sub.Start()
+
+	data, err := pub.SendRecv("", []byte("ping"), 1000, nil)
+	if err != nil {
+		t.Fatalf("send recv: %v", err)
+	}
+	if string(data) != "re:ping" {
+		t.Fatalf("unexpected response %s", data)
+	}
+
+	if _, err := pub.SendRecv("nobody", []byte("ping"), 10, nil); err == nil {
+		t.Fatalf("expected timeout without consumer")
+	}
+}
+
+func TestMemoryChannelQueueFull(t *testing.T) {
+	pub := newMemoryTestChannel(t, "full", core.CHANNEL_PUB, "pub", "topic", "")
+	// not started yet, its queue fills up
+	sub := newMemoryTestChannel(t, "full", core.CHANNEL_SUB, "sub", "topic", "grp")
+	consumer := &memoryTestConsumer{msgs: make(chan *MemoryMessage, DefaultMemoryQueueSize+1)}
+	sub.SetHandler(consumer)
+	pub.Start()
+
+	for i := 0; i < DefaultMemoryQueueSize; i++ {
+		if err := pub.Send("", 0, nil, []byte("hello"), nil); err != nil {
+			t.Fatalf("send %d: %v", i, err)
+		}
+	}
+
+	memoryPublishTimeout = time.Millisecond * 50
+	defer func() { memoryPublishTimeout = DefaultMemoryPublishTimeout }()
+	if err := pub.Send("", 0, nil, []byte("timeout"), nil); err == nil {
+		t.Fatalf("expected an error when the queue stays full")
+	}
+
+	memoryPublishTimeout = time.Second * 5
+	done := make(chan error, 1)
+	go func() {
+		done <- pub.Send("", 0, nil, []byte("last"), nil)
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("send should wait on a full queue, got %v", err)
+	case <-time.After(time.Millisecond * 100):
+	}
+	sub.Start()
+	if err := <-done; err != nil {
+		t.Fatalf("send after the consumer started: %v", err)
+	}
+	for i := 0; i < DefaultMemoryQueueSize; i++ {
+		recvMemoryMsg(t, consumer)
+	}
+	if msg := recvMemoryMsg(t, consumer); string(msg.Value) != "last" {
+		t.Fatalf("unexpected last message %s", msg.Value)
+	}
+}
+
+type memoryTestRetryConf struct {
+	retries    int
+	backoffMS  int
+	deadLetter bool
+}
+
+func (c *memoryTestRetryConf) RetryTimes() *int        { return &c.retries }
+func (c *memoryTestRetryConf) RetryBackoffMS() *int    { return &c.backoffMS }
+func (c *memoryTestRetryConf) RetryMaxBackoffMS() *int { return &c.backoffMS }
+func (c *memoryTestRetryConf) DeadLetterEnable() *bool { return &c.deadLetter }
+
+type memoryTestFailConsumer struct {
+	calls int
+}
+
+func (c *memoryTestFailConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
+	c.calls++
+	panic("poison message")
+}
+
+func TestMemoryChannelDeadLetter(t *testing.T) {
+	pub := newMemoryTestChannel(t, "dlq", core.CHANNEL_PUB, "pub", "events", "")
+	ch, _ := core.GetChannel("memory", &memoryTestRetryConf{retries: 2, backoffMS: 1, deadLetter: true})
+	ch.Init(false)
+	ch.SetDir(core.CHANNEL_SUB)
+	ch.SetID("sub")
+	ch.SetTopic("events")
+	ch.SetGroup("events-grp")
+	ch.PreStart("dlq", 0)
+	failing := &memoryTestFailConsumer{}
+	ch.SetHandler(failing)
+	dlq := newMemoryTestChannel(t, "dlq", core.CHANNEL_SUB, "dlq", DeadLetterTopic("events"), "dlq-grp")
+	consumer := &memoryTestConsumer{msgs: make(chan *MemoryMessage, 8)}
+	dlq.SetHandler(consumer)
+	pub.Start()
+	ch.Start()
+	dlq.Start()
+
+	if err := pub.Send("", 0, []byte("key"), []byte("bad"), map[string]string{"h": "v"}); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	msg := recvMemoryMsg(t, consumer)
+	if failing.calls != 3 {
+		t.Fatalf("expected 3 deliveries, got %d", failing.calls)
+	}
+	if string(msg.Value) != "bad" || msg.Headers["h"] != "v" || msg.Headers[HeaderDLQTopic] != "events" ||
+		msg.Headers[HeaderDLQRetries] != "2" || msg.Headers[HeaderDLQGroup] != "events-grp" || msg.Headers[HeaderDLQError] == "" {
+		t.Fatalf("unexpected dead letter %s %v", msg.Value, msg.Headers)
+	}
+	if replay := ReplayHeaders(msg.Headers); len(replay) != 1 || replay["h"] != "v" {
+		t.Fatalf("unexpected replay headers %v", replay)
+	}
+}
+
+type memoryTestAsyncConsumer struct {
+	mutex sync.Mutex
+	calls map[string]int
+	done  chan string
+}
+
+func (c *memoryTestAsyncConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
+}
+
+func (c *memoryTestAsyncConsumer) OnMessageAsync(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}, done func(error)) {
+	c.mutex.Lock()
+	c.calls[string(data)]++
+	c.mutex.Unlock()
+	go func() {
+		if string(data) == "bad" {
+			done(errors.New("write failed"))
+			return
+		}
+		c.done <- string(data)
+		done(nil)
+	}()
+}
+
+func TestMemoryChannelAsyncRetry(t *testing.T) {
+	pub := newMemoryTestChannel(t, "async", core.CHANNEL_PUB, "pub", "events", "")
+	ch, _ := core.GetChannel("memory", &memoryTestRetryConf{retries: 2, backoffMS: 200, deadLetter: true})
+	ch.Init(false)
+	ch.SetDir(core.CHANNEL_SUB)
+	ch.SetID("sub")
+	ch.SetTopic("events")
+	ch.SetGroup("events-grp")
+	ch.PreStart("async", 0)
+	async := &memoryTestAsyncConsumer{calls: make(map[string]int), done: make(chan string, 8)}
+	ch.SetHandler(async)
+	dlq := newMemoryTestChannel(t, "async", core.CHANNEL_SUB, "dlq", DeadLetterTopic("events"), "dlq-grp")
+	consumer := &memoryTestConsumer{msgs: make(chan *MemoryMessage, 8)}
+	dlq.SetHandler(consumer)
+	pub.Start()
+	ch.Start()
+	dlq.Start()
+
+	for _, val := range []string{"bad", "good"} {
+		if err := pub.Send("", 0, nil, []byte(val), nil); err != nil {
+			t.Fatalf("send: %v", err)
+		}
+	}
+	// the failing message waits for its retry without holding up the next one
+	select {
+	case val := <-async.done:
+		if val != "good" {
+			t.Fatalf("unexpected message %s", val)
+		}
+	case <-time.After(100 * time.Millisecond):
+		t.Fatalf("the next message waited for the failing one")
+	}
+
+	msg := recvMemoryMsg(t, consumer)
+	if string(msg.Value) != "bad" || msg.Headers[HeaderDLQRetries] != "2" {
+		t.Fatalf("unexpected dead letter %s %v", msg.Value, msg.Headers)
+	}
+	async.mutex.Lock()
+	defer async.mutex.Unlock()
+	if async.calls["bad"] != 3 || async.calls["good"] != 1 {
+		t.Fatalf("unexpected deliveries %v", async.calls)
+	}
+}
@@ -37,6 +37,7 @@ type NatsChannel struct {
 	handler core.IChannelConsumer
 	conn    *nats.Conn
 	sub     *nats.Subscription
+	retry   retryPolicy
 }
 
 func init() {
@@ -45,6 +46,7 @@ func init() {
 
 func NewNatsChannel(conf interface{}) (core.IChannel, error) {
 	k := new(NatsChannel)
+	k.retry = newRetryPolicy(conf)
 	return k, nil
 }
 
@@ -57,6 +59,10 @@ func (c *NatsChannel) SetTopic(topic string) {
 	c.topic = topic
 }
 
+func (c *NatsChannel) GetTopic() string {
+	return c.topic
+}
+
 func (c *NatsChannel) SetGroup(group string) {
 	c.grp = group
 }
@@ -163,7 +169,31 @@ func (c *NatsChannel) cb(msg *nats.Msg) {
 	span.SetBaggageItem("sob", nowStr)
 	span.SetBaggageItem("som", nowStr)
 	ctx := common.ContextWithSpan(context.Background(), span)
-	c.handler.OnMessage(ctx, msg.Subject, -1, msg.Data, msg)
+	if asyncConsumer, ok := c.handler.(core.IChannelAsyncConsumer); ok {
+		c.retry.handleAsync(ctx, asyncConsumer, msg.Subject, -1, msg.Data, msg, func(retries int, err error) {
+			c.deadLetter(msg, retries, err)
+		})
+		return
+	}
+	retries, err := c.retry.handle(ctx, c.handler, msg.Subject, -1, msg.Data, msg)
+	if err != nil {
+		c.deadLetter(msg, retries, err)
+	}
+}
+
+// deadLetter parks a message which failed all its retries in the dead letter
+// subject, nats messages carry no headers so the error is only logged
+func (c *NatsChannel) deadLetter(msg *nats.Msg, retries int, err error) {
+	if !c.retry.deadLetter {
+		log.Errorf("channel consumer drop msg subject:%s retries:%d err:%v", msg.Subject, retries, err)
+		return
+	}
+	dlqTopic := DeadLetterTopic(msg.Subject)
+	if err := c.conn.Publish(dlqTopic, msg.Data); err != nil {
+		log.Errorf("channel consumer failed to park msg subject:%s in %s err:%v", msg.Subject, dlqTopic, err)
+		return
+	}
+	log.Warnf("channel consumer parked msg subject:%s in %s retries:%d err:%v", msg.Subject, dlqTopic, retries, err)
 }
 
 func (c *NatsChannel) SendAndRecv(topic string, partition int32, keys, bytes []byte, headers map[string]string) error {
@@ -40,6 +40,7 @@ type GetSyncResponse struct {
 	ToDevice               ToDevice       `json:"to_device"`
 	DeviceList             DeviceLists    `json:"device_lists"`
 	DeviceOneTimeKeysCount map[string]int `json:"device_one_time_keys_count"`
+	UnusedFallbackKeyTypes []string       `json:"device_unused_fallback_key_types"`
 }
 
 type JoinedRoom struct {
@@ -197,7 +197,27 @@ type PostQueryClientKeysRequest struct {
 }
 
 type PostQueryClientKeysResponse struct {
-	DeviceKeys map[string]map[string]DeviceKeys `json:"device_keys"`
+	DeviceKeys      map[string]map[string]DeviceKeys `json:"device_keys"`
+	MasterKeys      map[string]CrossSigningKey       `json:"master_keys,omitempty"`
+	SelfSigningKeys map[string]CrossSigningKey       `json:"self_signing_keys,omitempty"`
+}
+
+type GetFedUserDevicesRequest struct {
+	UserID string `json:"userId"`
+}
+
+type GetFedUserDevicesResponse struct {
+	UserID         string           `json:"user_id"`
+	StreamID       int64            `json:"stream_id"`
+	Devices        []FedUserDevice  `json:"devices"`
+	MasterKey      *CrossSigningKey `json:"master_key,omitempty"`
+	SelfSigningKey *CrossSigningKey `json:"self_signing_key,omitempty"`
+}
+
+type FedUserDevice struct {
+	DeviceID    string     `json:"device_id"`
+	DisplayName string     `json:"device_display_name,omitempty"`
+	Keys        DeviceKeys `json:"keys"`
 }
 
 type PostClaimClientKeysRequest struct {
@@ -266,12 +266,12 @@ type PostLoginResponseCapn struct{ capnp.Struct }
 const PostLoginResponseCapn_TypeID = 0xc853484cccd8383e
 
 func NewPostLoginResponseCapn(s *capnp.Segment) (PostLoginResponseCapn, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return PostLoginResponseCapn{st}, err
 }
 
 func NewRootPostLoginResponseCapn(s *capnp.Segment) (PostLoginResponseCapn, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return PostLoginResponseCapn{st}, err
 }
 
@@ -361,12 +361,39 @@ func (s PostLoginResponseCapn) SetDeviceID(v string) error {
 	return s.Struct.SetText(3, v)
 }
 
+func (s PostLoginResponseCapn) RefreshToken() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s PostLoginResponseCapn) HasRefreshToken() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s PostLoginResponseCapn) RefreshTokenBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s PostLoginResponseCapn) SetRefreshToken(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s PostLoginResponseCapn) ExpiresInMs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s PostLoginResponseCapn) SetExpiresInMs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
 // PostLoginResponseCapn_List is a list of PostLoginResponseCapn.
 type PostLoginResponseCapn_List struct{ capnp.List }
 
 // NewPostLoginResponseCapn creates a new list of PostLoginResponseCapn.
 func NewPostLoginResponseCapn_List(s *capnp.Segment, sz int32) (PostLoginResponseCapn_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5}, sz)
 	return PostLoginResponseCapn_List{l}, err
 }
 
@@ -465,39 +492,41 @@ func (p UserIdentifierCapn_Promise) Struct() (UserIdentifierCapn, error) {
 	return UserIdentifierCapn{s}, err
 }
 
-const schema_d2a718af8a61f4a1 = "x\xdal\x91Ok\x13Q\x14\xc5\xefy/1\xc5\xa6" +
-	"\xdaa\xb2\xa8\x0b\xe9$t\xe1\x1f\x94\xaaP4\xa0i" +
-	"\x9b\xc4\x9a\xd2E^SA\xd4\xcd\x98\xbc\xb6\x83\xc9\xcc" +
-	"t\xde\xa41\xab\xea\xd6\x95[\xdd\xe9\xc2,]\xfa\x15" +
-	"\x0ct\xe5\xca\x95\xd0\x95t!\x88\x08R7#/\xd4" +
-	"1)a6\xf3\x0e?\xce=\xf7\xdc\xf9\xfe\xc4\"\xbb" +
-	"\x96\xec-\x12\xd5~\xb0\xe4\xa9\xe8\xd1\xef\xd7\x0b\xab\xe5" +
-	"\xf7o\xc8\x98D\xf4\xf6\x97\xfd\xf2\xc3L\xef3%\x91" +
-	"\"\xbaq\xcbb0\xcbV\x8a\xc8\\\xb2\x0a\x84(}" +
-	"\xb0\xf7\xa0\xb8\xf0\xf1$\xcc4\xb1c\xbd3\xbb\x03\xb6" +
-	"mu\x08\xd1\xbeZ9\xcc\xbdx\xd5\x1bcl\x1eX" +
-	"?\xcd\xef\x03\xf6p\xe0{\xe7\xe6\x97\xfd\xb5{\xb5O" +
-	"'\xd8\x84\x0e1\x95=\x0d\xf3|V\xc3\xe7\xb2\xdf\x08" +
-	"Q\xbf\xdc\xbf\xbc~{\xf9\x88\xc4$\x86h\x0d\x9bG" +
-	"\xd9?f2\xa7\xff\x90+P4\xf2}\x8d\x9a\xde\x96" +
-	"\xe3^\xad\xdb\xdcw\xf3+2\\\xd3\xcfu\xa9|\xcf" +
-	"U\xb2h\xfb.U\x01\x91\xe0\x09\xa2\x04\x88\x8c\xa9\xeb" +
-	"Db\x82C\xcc1\xccn6\xbd\x8e\xc2\x19B\x95\x03" +
-	"\xd3\xff\x9b h1\xb6\x86\xef\xe6\xef6\xbdN1e" +
-	"\xfb\xae\xb6\x9b\x88\xed.^\"\x12s\x1cb\x9e\xc1\x00" +
-	"2\xd0\xe2\x95<\x91\xb8\xc0!J\x0cg\xc3\xae/\x91" +
-	"&\x864\xa1\xa0B{K\xc6\x13\xb5:<\x87\xf9n" +
-	"\xfe\xbe\x92A\xa5!\xdd\xd0\xd9t\xe4lP<\x9e8" +
-	"\xb4\xc0*\x91Hs\x88\x19\x86\xa8\xadd\xb0\xd1\xf5%" +
-	"\x11\xfd\x9b1RH\xd5Sc\x1a\x19T2\x1d;\xda" +
-	":\xeec\x0e\xb1=\xb4\x83|B$\x1a\x1c\xc2g0" +
-	"\x18\xcb\x80\x11\x19\xad\x87D\xa2\xc9!\x9e1\x18\x9cg" +
-	"\xc0\x89\x8c\xb6\x0e\x14r\x88\xe7\x0c\x05\x1d\xa8R\x8a\xb3" +
-	"\xd8\xf5\xbaTj\xc3\xa3\xd4S\xe9\xc6\xea\xb6\xd7\x925" +
-	"\x19\xec\x12\x97A,6\xe4\xaeS\x97\x95\xd2\xb8U\xd8" +
-	"\xc8mw\xda\xb2\xa0\xc21\xcd,\x1f\x9f6\xc3\xb0\xe7" +
-	"\xa8\xa5F\xcbq\x01b\x00\xe1o\x00\x00\x00\xff\xff/" +
-	"\xa6\xc5\xfa"
+const schema_d2a718af8a61f4a1 = "x\xdal\x92OHT_\x1c\xc5\xcf\xb9\xf7\xbd\xdf\xc8" +
+	"\xcfQ{\xcc[\xb8\x13\xc2E\x7f(4Ab\xa0\xa6" +
+	"\x1c\xcdF\x0c\xbcj\x14\xb5\x9a\xc6\xab\xbe\xd2\xf7^\xef" +
+	"\x8e\x9a+i\xdb\xaaE\x1b\xa3\xa0\"\xb3\xa0\xa8EQ" +
+	"\x8b\xa26\x81\x82-j\xd5\xb6e\xdb\x08\xa2 &\xae" +
+	"\xd8\xf8\x92\xd9\xdd\xfb\xe5\xdc\xcf\xf7\x9c\xc3\xed\xca\xf2\x98" +
+	"\xe8v\xdf\x12P\xbe\xfb_\xed\xfc\x8f\xe5\xde\xa1\x81\x07" +
+	"7\xe15\xb3v\xf7{\xf9\xda\xd3\xf6\xd5Op\x99\x01" +
+	"\xba\xdf\x0bz\x1f3\x80\xf7\xa1\x00\xd6\xb2_\x96\xce\x16" +
+	"{_\xeeT\x0a+\xf8}/\xb7\xf9$G.\x80\xb5" +
+	"\x0d3\xf8u\xf7\xd5\xeb\xab\x0d\xa0\xb9Y~\xcb-n" +
+	"\x9e\xe6h\xb1G\x0f\x7f\xde\x18>9\xb6\x06\xd5\xcc\xb4" +
+	"\xd8\xcd\x00=\xcb\xfc\x9f\xb9GV\xdd\xb3\xc23\x04k" +
+	"\xeb\x03\xeb\xfbG\x8f\xf4\xfd\xdc!w,\xd0\x95\xbfr" +
+	"\x9e\xb4\xa7\x16i\xd13\xd1T\x10\x1e\xac\x94e\x1c\xe6" +
+	"\x07uu\xd8^G\xb5\x89\xa3\xd0\xe8b9\x0e1B" +
+	"*G:\x80C\xc0k9\x04\xa8&I\xd5)\xd81" +
+	"9\x13-\x18\xb6\x82#\x92\xdc\xb5\x1d\x1edk\x0a\xcd" +
+	"8\xcc\x9f\x98\x89\x16\x8a\x99r\x1cZ\\S\x1d\xb7w" +
+	"\x1f\xa0:%U\x97\xa0G\xfa\xb4\xc3\x03y@\xed\x91" +
+	"T\xfd\x82m\xd5\xc5X3\x0b\xc1,X0\xd5\xf2\x94" +
+	"\xaeo\xb4\xd3\xf4\x1e\x11\x87\xf9\xd3F'\xa5\x09\x1dV" +
+	"\x83\xc9@w$\xc5\xad\x8d\xa9\x00C\x80\xcaJ\xaav" +
+	"\xc1\xda\x9c\xd1\xc9\xf8b\xac\x01\xfc\xdd\xf1O!#\x91" +
+	"i\xd0\xc8f%\xedu\xe2\xb2\xb5{CR\xddIe" +
+	"\xb8}\x01P\xb7$\xd5CAO\x08\x9f\x02\xf0V\xce" +
+	"\x01\xea\xbe\xa4z&\xe8I\xe9S\x02\xde\x13k\xe8\xb1" +
+	"\xa4z%\xe89\x8eO\x07\xf0^\\\x04\xd4sI\xf5" +
+	"N\x90\xaeO\x17\xf0\xdeX\xe4kI\xb5&X\xb0\xce" +
+	"K\xfdu\xd3\xe5JE\x1b3\x1e!sI\x87\xf5\xe9" +
+	"t4\xab\xc7t2\x0f\xa9\x93\xfapB\xcf\x07\x15]" +
+	"\xeaOgN\xf4d\xa2\xcd\xf48\xda\xa2\xf4{}%" +
+	"\x0e\x12mJ\xc8\x84\xa7\x0c]\x08\xba;\xea\xde\xfe1" +
+	"\x97\xe7t\xc1T\x1b\xf4\xdd\xb7\xf5a|\xc1\xa5\xc0\x1c" +
+	"\x9f\x98\x0dB\x12\x82\x04\xff\x0c\x00aI\xd0L"
 
 func init() {
 	schemas.Register(schema_d2a718af8a61f4a1,
@@ -44,6 +44,7 @@ type PostLoginRequest struct {
 	InitialDisplayName *string        `json:"initial_device_display_name"`
 	IsHuman            *bool          `json:"is_human"`
 	IsAdmin            bool           `json:"is_admin"`
+	RefreshToken       bool           `json:"refresh_token"`
 }
 type PostLoginAdminRequest PostLoginRequest
 
@@ -53,10 +54,33 @@ type UserIdentifier struct {
 
 //response
 type PostLoginResponse struct {
-	UserID      string `json:"user_id"`
-	AccessToken string `json:"access_token"`
-	HomeServer  string `json:"home_server"`
-	DeviceID    string `json:"device_id"`
+	UserID       string `json:"user_id"`
+	AccessToken  string `json:"access_token"`
+	HomeServer   string `json:"home_server"`
+	DeviceID     string `json:"device_id"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresInMs  int64  `json:"expires_in_ms,omitempty"`
+}
+
+//POST /_matrix/client/r0/refresh
+type PostRefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type PostRefreshResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresInMs  int64  `json:"expires_in_ms,omitempty"`
+}
+
+//POST /_matrix/client/r0/login/get_token
+type PostLoginTokenRequest struct {
+	Auth AuthData `json:"auth"`
+}
+
+type PostLoginTokenResponse struct {
+	LoginToken  string `json:"login_token"`
+	ExpiresInMs int64  `json:"expires_in_ms"`
 }
 
 //POST /_matrix/client/r0/logout
@@ -14,6 +14,10 @@
 
 package external
 
+import (
+	jsonRaw "encoding/json"
+)
+
 //GET /_matrix/client/r0/voip/turnServer
 type GetTurnServerResponse struct {
 	UserName string   `json:"username"`
@@ -177,8 +181,9 @@ type PostDevicesDelRequest struct {
 
 //POST /_matrix/client/r0/keys/upload
 type PostUploadKeysRequest struct {
-	DeviceKeys  DeviceKeys             `json:"device_keys"`
-	OneTimeKeys map[string]interface{} `json:"one_time_keys"`
+	DeviceKeys   DeviceKeys             `json:"device_keys"`
+	OneTimeKeys  map[string]interface{} `json:"one_time_keys"`
+	FallbackKeys map[string]interface{} `json:"fallback_keys"`
 }
 
 type DeviceKeys struct {
@@ -206,8 +211,128 @@ type PostQueryKeysRequest struct {
 }
 
 type PostQueryKeysResponse struct {
-	Failures   map[string]interface{}           `json:"failures"`
-	DeviceKeys map[string]map[string]DeviceKeys `json:"device_keys"`
+	Failures        map[string]interface{}           `json:"failures"`
+	DeviceKeys      map[string]map[string]DeviceKeys `json:"device_keys"`
+	MasterKeys      map[string]CrossSigningKey       `json:"master_keys,omitempty"`
+	SelfSigningKeys map[string]CrossSigningKey       `json:"self_signing_keys,omitempty"`
+	UserSigningKeys map[string]CrossSigningKey       `json:"user_signing_keys,omitempty"`
+}
+
+type CrossSigningKey struct {
+	UserID     string                       `json:"user_id"`
+	Usage      []string                     `json:"usage"`
+	Keys       map[string]string            `json:"keys"`
+	Signatures map[string]map[string]string `json:"signatures,omitempty"`
+}
+
+//POST /_matrix/client/r0/keys/device_signing/upload
+type PostDeviceSigningKeysUploadRequest struct {
+	MasterKey      *CrossSigningKey `json:"master_key,omitempty"`
+	SelfSigningKey *CrossSigningKey `json:"self_signing_key,omitempty"`
+	UserSigningKey *CrossSigningKey `json:"user_signing_key,omitempty"`
+	Auth           AuthData         `json:"auth"`
+}
+
+//POST /_matrix/client/r0/keys/signatures/upload
+type PostSignaturesUploadRequest map[string]map[string]jsonRaw.RawMessage
+
+type PostSignaturesUploadResponse struct {
+	Failures map[string]map[string]interface{} `json:"failures"`
+}
+
+type KeyBackupData struct {
+	FirstMessageIndex int64              `json:"first_message_index"`
+	ForwardedCount    int64              `json:"forwarded_count"`
+	IsVerified        bool               `json:"is_verified"`
+	SessionData       jsonRaw.RawMessage `json:"session_data"`
+}
+
+type RoomKeyBackup struct {
+	Sessions map[string]KeyBackupData `json:"sessions"`
+}
+
+//POST /_matrix/client/r0/room_keys/version
+type PostRoomKeysVersionRequest struct {
+	Algorithm string             `json:"algorithm"`
+	AuthData  jsonRaw.RawMessage `json:"auth_data"`
+}
+
+type PostRoomKeysVersionResponse struct {
+	Version string `json:"version"`
+}
+
+//GET /_matrix/client/r0/room_keys/version/{version}
+type GetRoomKeysVersionRequest struct {
+	Version string `json:"version"`
+}
+
+type GetRoomKeysVersionResponse struct {
+	Algorithm string             `json:"algorithm"`
+	AuthData  jsonRaw.RawMessage `json:"auth_data"`
+	Count     int64              `json:"count"`
+	Etag      string             `json:"etag"`
+	Version   string             `json:"version"`
+}
+
+//PUT /_matrix/client/r0/room_keys/version/{version}
+type PutRoomKeysVersionRequest struct {
+	Version     string             `json:"version"`
+	Algorithm   string             `json:"algorithm"`
+	AuthData    jsonRaw.RawMessage `json:"auth_data"`
+	BodyVersion string             `json:"body_version,omitempty"`
+}
+
+//DELETE /_matrix/client/r0/room_keys/version/{version}
+type DelRoomKeysVersionRequest struct {
+	Version string `json:"version"`
+}
+
+//PUT /_matrix/client/r0/room_keys/keys
+type PutRoomKeysRequest struct {
+	Version string                   `json:"version"`
+	Rooms   map[string]RoomKeyBackup `json:"rooms"`
+}
+
+//PUT /_matrix/client/r0/room_keys/keys/{roomId}
+type PutRoomKeysByRoomRequest struct {
+	Version  string                   `json:"version"`
+	RoomID   string                   `json:"room_id"`
+	Sessions map[string]KeyBackupData `json:"sessions"`
+}
+
+//PUT /_matrix/client/r0/room_keys/keys/{roomId}/{sessionId}
+type PutRoomKeysBySessionRequest struct {
+	KeyBackupData
+	Version   string `json:"version"`
+	RoomID    string `json:"room_id"`
+	SessionID string `json:"session_id"`
+}
+
+type PutRoomKeysResponse struct {
+	Etag  string `json:"etag"`
+	Count int64  `json:"count"`
+}
+
+//GET /_matrix/client/r0/room_keys/keys/{roomId}/{sessionId}
+type GetRoomKeysRequest struct {
+	Version   string `json:"version"`
+	RoomID    string `json:"room_id"`
+	SessionID string `json:"session_id"`
+}
+
+type GetRoomKeysResponse struct {
+	Rooms map[string]RoomKeyBackup `json:"rooms"`
+}
+
+type GetRoomKeysByRoomResponse RoomKeyBackup
+
+type GetRoomKeysBySessionResponse KeyBackupData
+
+//DELETE /_matrix/client/r0/room_keys/keys/{roomId}/{sessionId}
+type DelRoomKeysRequest struct {
+	Version   string `json:"version"`
+	RoomID    string `json:"room_id"`
+	SessionID string `json:"session_id"`
 }
 
 //POST /_matrix/client/r0/keys/claim
@@ -486,15 +611,70 @@ type DeviceInfo struct {
 }
 
 type SessionInfo struct {
-	Connections []ConnectionInfo `json:"connections`
+	Connections []ConnectionInfo `json:"connections"`
 }
 
 type ConnectionInfo struct {
 	Ip        string `json:"ip"`
-	LastSeen  int    `json:"last_seen"`
+	LastSeen  int64  `json:"last_seen"`
 	UserAgent string `json:"user_agent"`
 }
 
+//GET /system/manager/users
+type GetAdminUsersRequest struct {
+	Search string `json:"search"`
+	From   string `json:"from"`
+	Limit  int    `json:"limit"`
+}
+
+type AdminUser struct {
+	UserID      string `json:"user_id"`
+	CreatedTs   int64  `json:"created_ts"`
+	Deactivated bool   `json:"deactivated"`
+	Suspended   bool   `json:"suspended"`
+}
+
+type GetAdminUsersResponse struct {
+	Users     []AdminUser `json:"users"`
+	NextToken string      `json:"next_token,omitempty"`
+}
+
+//GET /system/manager/users/{userID}/devices
+type GetAdminUserDevicesRequest struct {
+	UserID string `json:"user_id"`
+}
+
+type AdminUserDevice struct {
+	DeviceID          string `json:"device_id"`
+	DisplayName       string `json:"display_name,omitempty"`
+	LastSeenIP        string `json:"last_seen_ip,omitempty"`
+	LastSeenUserAgent string `json:"last_seen_user_agent,omitempty"`
+	LastSeenTs        int64  `json:"last_seen_ts,omitempty"`
+}
+
+type GetAdminUserDevicesResponse struct {
+	Devices []AdminUserDevice `json:"devices"`
+}
+
+//POST /system/manager/users/{userID}/password
+type PostAdminUserPasswordRequest struct {
+	UserID        string `json:"user_id"`
+	NewPassword   string `json:"new_password"`
+	LogoutDevices *bool  `json:"logout_devices,omitempty"`
+}
+
+//PUT /system/manager/users/{userID}/suspend
+type PutAdminUserSuspendRequest struct {
+	UserID  string `json:"user_id"`
+	Suspend bool   `json:"suspend"`
+}
+
+//POST /system/manager/users/{userID}/logout
+type PostAdminUserLogoutRequest struct {
+	UserID   string `json:"user_id"`
+	DeviceID string `json:"device_id,omitempty"`
+}
+
 //GET /_matrix/client/r0/login/cas/redirect
 type GetCasLoginRedirectRequest struct {
 	RedirectURL string `json:"redirectUrl"`
@@ -506,6 +686,28 @@ type GetCasLoginTickerRequest struct {
 	Ticket      string `json:"ticket"`
 }
 
+//GET /_matrix/client/r0/login/sso/redirect
+type GetSSOLoginRedirectRequest struct {
+	RedirectURL string `json:"redirectUrl"`
+}
+
+//GET /_matrix/client/r0/login/sso/callback
+type GetSSOLoginCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+	Error string `json:"error"`
+}
+
+// LoginRedirectResponse redirects the browser, to the identity provider or
+// back to the client with the login token
+type LoginRedirectResponse struct {
+	Location string `json:"-"`
+}
+
+func (r *LoginRedirectResponse) Headers() map[string]string {
+	return map[string]string{"Location": r.Location}
+}
+
 //POST /_matrix/client/r0/rooms/{roomId}/report/{eventId}
 type PostRoomReportRequest struct {
 	RoomID  string `json:"roomId"`
@@ -514,23 +716,76 @@ type PostRoomReportRequest struct {
 	Reason  string `json:"reason"`
 }
 
+type EventReport struct {
+	ID         string   `json:"id"`
+	RoomID     string   `json:"room_id"`
+	EventID    string   `json:"event_id"`
+	UserID     string   `json:"user_id"`
+	Sender     string   `json:"sender"`
+	Reason     string   `json:"reason"`
+	Score      int      `json:"score"`
+	ReceivedTs int64    `json:"received_ts"`
+	Status     string   `json:"status"`
+	Assignee   string   `json:"assignee,omitempty"`
+	ResolvedBy string   `json:"resolved_by,omitempty"`
+	ResolvedTs int64    `json:"resolved_ts,omitempty"`
+	Resolution string   `json:"resolution,omitempty"`
+	Actions    []string `json:"actions,omitempty"`
+}
+
+//GET /system/manager/event_reports
+type GetEventReportsRequest struct {
+	RoomID   string `json:"room_id"`
+	UserID   string `json:"user_id"`
+	Sender   string `json:"sender"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+	From     string `json:"from"`
+	Limit    int    `json:"limit"`
+}
+
+type GetEventReportsResponse struct {
+	EventReports []EventReport `json:"event_reports"`
+	NextToken    string        `json:"next_token,omitempty"`
+}
+
+//GET /system/manager/event_reports/{reportID}
+type GetEventReportRequest struct {
+	ReportID string `json:"report_id"`
+}
+
+//PUT /system/manager/event_reports/{reportID}/assign
+type PutEventReportAssignRequest struct {
+	ReportID string `json:"report_id"`
+	Assignee string `json:"assignee"`
+}
+
+//POST /system/manager/event_reports/{reportID}/resolve
+type PostEventReportResolveRequest struct {
+	ReportID   string   `json:"report_id"`
+	Resolution string   `json:"resolution"`
+	Actions    []string `json:"actions"`
+}
+
 //GET /_matrix/client/r0/thirdparty/protocols
-type GetThirdPartyProtocalsResponse struct {
+type GetThirdPartyProtocalsResponse map[string]ThirdPartyProtocol
+
+type ThirdPartyProtocol struct {
 	UserFields     []string              `json:"user_fields"`
 	LocationFields []string              `json:"location_fields"`
 	Icon           string                `json:"icon"`
-	FieldsTypes    map[string]FieldsType `json:"fields_types"`
+	FieldTypes     map[string]FieldsType `json:"field_types"`
 	Instances      []ProtocolInstance    `json:"instances"`
 }
 
 type FieldsType struct {
 	Regexp      string `json:"regexp"`
-	PlaceHolder string `json:"placeholder`
+	PlaceHolder string `json:"placeholder"`
 }
 
 type ProtocolInstance struct {
 	Desc      string      `json:"desc"`
-	Icon      string      `json:"icon"`
+	Icon      string      `json:"icon,omitempty"`
 	Fields    interface{} `json:"fields"`
 	NetworkID string      `json:"network_id"`
 }
@@ -540,23 +795,15 @@ type GetThirdPartyProtocalByNameRequest struct {
 	Protocol string `json:"protocol"`
 }
 
-type GetThirdPartyProtocalByNameResponse struct {
-	UserFields     []string              `json:"user_fields"`
-	LocationFields []string              `json:"location_fields"`
-	Icon           string                `json:"icon"`
-	FieldsTypes    map[string]FieldsType `json:"fields_types"`
-	Instances      []ProtocolInstance    `json:"instances"`
-}
+type GetThirdPartyProtocalByNameResponse ThirdPartyProtocol
 
 //GET /_matrix/client/r0/thirdparty/location/{protocol}
 type GetThirdPartyLocationByProtocolRequest struct {
-	Protocol     string `json:"protocol"`
-	SearchFields string `json:"searchFields"`
+	Protocol string            `json:"protocol"`
+	Fields   map[string]string `json:"fields"`
 }
 
-type GetThirdPartyLocationByProtocolResponse struct {
-	Body []Location `json:"<body"`
-}
+type GetThirdPartyLocationByProtocolResponse []Location
 
 type Location struct {
 	Alias    string      `json:"alias"`
@@ -566,13 +813,11 @@ type Location struct {
 
 //GET /_matrix/client/r0/thirdparty/user/{protocol}
 type GetThirdPartyUserByProtocolRequest struct {
-	Protocol string `json:"protocol"`
-	Fields   string `json:"fields..."`
+	Protocol string            `json:"protocol"`
+	Fields   map[string]string `json:"fields"`
 }
 
-type GetThirdPartyUserByProtocolResponse struct {
-	Body []ThirdPartyUser `json:"<body>"`
-}
+type GetThirdPartyUserByProtocolResponse []ThirdPartyUser
 
 type ThirdPartyUser struct {
 	UserID   string      `json:"userid"`
@@ -585,18 +830,14 @@ type GetThirdPartyLocationRequest struct {
 	Alias string `json:"alias"`
 }
 
-type GetThirdPartyLocationResponse struct {
-	Body []Location `json:"<body>"`
-}
+type GetThirdPartyLocationResponse []Location
 
 //GET /_matrix/client/r0/thirdparty/user
 type GetThirdPartyUserRequest struct {
 	UserID string `json:"userid"`
 }
 
-type GetThirdPartyUserResponse struct {
-	Body []ThirdPartyUser `json:"<body>"`
-}
+type GetThirdPartyUserResponse []ThirdPartyUser
 
 //POST /_matrix/client/r0/user/{userId}/openid/request_token
 type PostUserOpenIDRequest struct {
@@ -610,11 +851,48 @@ type PostUserOpenIDResponse struct {
 	ExpiresIn        int    `json:"expires_in"`
 }
 
+//GET /_matrix/federation/v1/openid/userinfo
+type GetFedOpenIDUserInfoRequest struct {
+	AccessToken string `json:"access_token"`
+}
+
+type GetFedOpenIDUserInfoResponse struct {
+	Sub string `json:"sub"`
+}
+
 //POST /system/manager//{type}
 type PostSystemManagerRequest struct {
 	Type string `json:"type"`
 }
 
+//POST /system/manager/purge_history/{roomID}
+type PostPurgeHistoryRequest struct {
+	RoomID        string `json:"room_id"`
+	BeforeEventID string `json:"before_event_id,omitempty"`
+	BeforeTs      int64  `json:"before_ts,omitempty"`
+}
+
+type PostPurgeHistoryResponse struct {
+	PurgeID string `json:"purge_id"`
+}
+
+//GET /system/manager/purge_history_status/{purgeID}
+type GetPurgeHistoryStatusRequest struct {
+	PurgeID string `json:"purge_id"`
+}
+
+type GetPurgeHistoryStatusResponse struct {
+	PurgeID  string `json:"purge_id"`
+	RoomID   string `json:"room_id"`
+	Status   string `json:"status"`
+	Scanned  int64  `json:"scanned"`
+	Deleted  int64  `json:"deleted"`
+	Kept     int64  `json:"kept"`
+	Error    string `json:"error,omitempty"`
+	StartTs  int64  `json:"start_ts"`
+	UpdateTs int64  `json:"update_ts"`
+}
+
 //GET /unread/{userID}
 type GetUserUnread struct {
 	UserID string `json:"userID"`
@@ -1883,6 +1883,14 @@ func (s PostRegisterRequestCapn) SetAdmin(v bool) {
 	s.Struct.SetBit(2, v)
 }
 
+func (s PostRegisterRequestCapn) RefreshToken() bool {
+	return s.Struct.Bit(3)
+}
+
+func (s PostRegisterRequestCapn) SetRefreshToken(v bool) {
+	s.Struct.SetBit(3, v)
+}
+
 // PostRegisterRequestCapn_List is a list of PostRegisterRequestCapn.
 type PostRegisterRequestCapn_List struct{ capnp.List }
 
@@ -1923,12 +1931,12 @@ type RegisterResponseCapn struct{ capnp.Struct }
 const RegisterResponseCapn_TypeID = 0xc235bc5c20ec749c
 
 func NewRegisterResponseCapn(s *capnp.Segment) (RegisterResponseCapn, error) {
-	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	st, err := capnp.NewStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return RegisterResponseCapn{st}, err
 }
 
 func NewRootRegisterResponseCapn(s *capnp.Segment) (RegisterResponseCapn, error) {
-	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4})
+	st, err := capnp.NewRootStruct(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5})
 	return RegisterResponseCapn{st}, err
 }
 
@@ -2018,12 +2026,39 @@ func (s RegisterResponseCapn) SetDeviceID(v string) error {
 	return s.Struct.SetText(3, v)
 }
 
+func (s RegisterResponseCapn) RefreshToken() (string, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.Text(), err
+}
+
+func (s RegisterResponseCapn) HasRefreshToken() bool {
+	p, err := s.Struct.Ptr(4)
+	return p.IsValid() || err != nil
+}
+
+func (s RegisterResponseCapn) RefreshTokenBytes() ([]byte, error) {
+	p, err := s.Struct.Ptr(4)
+	return p.TextBytes(), err
+}
+
+func (s RegisterResponseCapn) SetRefreshToken(v string) error {
+	return s.Struct.SetText(4, v)
+}
+
+func (s RegisterResponseCapn) ExpiresInMs() int64 {
+	return int64(s.Struct.Uint64(0))
+}
+
+func (s RegisterResponseCapn) SetExpiresInMs(v int64) {
+	s.Struct.SetUint64(0, uint64(v))
+}
+
 // RegisterResponseCapn_List is a list of RegisterResponseCapn.
 type RegisterResponseCapn_List struct{ capnp.List }
 
 // NewRegisterResponseCapn creates a new list of RegisterResponseCapn.
 func NewRegisterResponseCapn_List(s *capnp.Segment, sz int32) (RegisterResponseCapn_List, error) {
-	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 0, PointerCount: 4}, sz)
+	l, err := capnp.NewCompositeList(s, capnp.ObjectSize{DataSize: 8, PointerCount: 5}, sz)
 	return RegisterResponseCapn_List{l}, err
 }
 
@@ -2048,104 +2083,106 @@ func (p RegisterResponseCapn_Promise) Struct() (RegisterResponseCapn, error) {
 	return RegisterResponseCapn{s}, err
 }
 
-const schema_e5137205f57b43a9 = "x\xda\xecW\x7fhTW\x16>\xe7\xdd\x99L\xa2\xf9" +
-	"\xe1pgw\xd5\xc55\x13\x93\x98\x04\x15\x8d.\xbb\x8a" +
-	"K\x123\xee\x9a\xec$\xce\xcd\xe8\xfe\x08.\xf8f\xe6" +
-	"\x92y\xc9\xcc\x9b\xc9\xbc\x97\xc4\xb8\x06\xb3\xa0\xbb\x06\xb2" +
-	"\xbb*V4\x0d-\x16S+m\xa1\x85V\x9a6\xa1" +
-	"\xb6T\xeb?V,TjZh)\xd8bk\x8bm" +
-	")m)2\xe5\xbe\xcc\xbcy\x19\x9f?\xb0\xfd\xab\x94" +
-	"\xfc3|\xf98\xf3}\xdf9\xf7\xdc;\xab\x1b\x8a\x1b" +
-	"\xa55\xceS\x8d\x00\xc1\x9b\x92\xb3 M6v\xbe\xff" +
-	"\xcb\x97\x1e\xfd\x0f\xb8K1}\xba\xf9\x9f_9S\xf4" +
-	"\x1a8%\x17\xc0\xda\xf5\xe5\xddH\xdb\xca]\x00\xb4\xa5" +
-	"|\x000=\xb9\xd5?\xef\xef\x1fT<\x9cGFA" +
-	">]\x1eB:m\x90'\xcb\x1b\x00\xd3G\x7fs>" +
-	"6\xd5\xfb\xab\xc7\xec\xc8W\xcbw#\xfd\xd4 _7" +
-	"\xc8#e\xfem\xebF\x03\x13v\xe4\"o\x08\xe9\x12" +
-	"\xaf /\xf2\x0a\xf2\xc5\xbf|x\xa2\xf0\xf1!;2" +
-	"]\xef\x9d\xa1\x9b\x0dn\x93\xc1m\x8c\xbc\xb35|\xf4" +
-	"\xcc\xb3\xc0J\xd1Bv\x88\xc2\xbd\xdeN\xa4\xfb\x0d\xf2" +
-	"\xbf\x0c\xf2\x15\x12Z\xf5\xd6\xf9\xc63v*&\xbcc" +
-	"H\xa7\x0d\xf2\xa4An\xf9$\xb1b\xf4\x7f[\xa6\xf2" +
-	"*;\x8d\xd2W\xbd\x9b\x90^\x17\xec\xb5\xd7\xbcK\x11" +
-	"0\xfd\xee\xbf\x1b&\xc7\xdf<|\xd6\x96~\xab\xa2\x13" +
-	"\xa9{\x99\xf8X\xb2\xcc\xa0\x8f\xeb7\xcawL\xfd\xf6" +
-	"\xd5<)\x06{e\xe5b\xa4\x7f\xa84\xecV~\x04" +
-	"\x98>\xb7~\xe4w~\xdf\xf9\xd7\xedt/\xaa\x1aC" +
-	"\xba\xa6J\x90WV\x09\xdd\xf4\xc5}\x83\xed'\xfd\x17" +
-	"\xf3\x858\x05\xbbM\xb0\xb9`\xaf\x95\xab\xfe*\x84\xdc" +
-	"\xd8\xdd\xff\xc8\xc7W\xe4Kv\xb5_\xa9^\x8c\xf4r" +
-	"\xb5\xa8}\xb1Z\xd4.\x9c\x08\xe8\xef}\xfd\xf2%\x9b" +
-	"i\xa2\x9fW\xcf\xd0[\x06\xf7\xdbj1L#\xa7\x97" +
-	"\xfc\xe2\x8d\xee'glul_\x1eB\xaa,\x17\x1f" +
-	"\xf9rC\xc7\x97\xaf]\xf8\xc2\xd3z\xed3\x9b@\xe8" +
-	"\xb9\x9a\x19z\xb9\xc6\x90Q#\xf2\xf8\x7f`\xa6\xe7\xcf" +
-	"\xf5c7m\xb3\x9e\xa8=\x8ct\xbaV|\x9c\xac5" +
-	"\xb2\xf6\xfez\xf7wC\xfc\xedo\xf2\xe9EF'\xeb" +
-	"\xea\x91^\xaf3:YwJ\x82\xf4\x9c\xbf\xad\xe9\x14" +
-	"\xefR4\x9d\xa7\x1c\xab\xc2rR\xdd\x10HhzS" +
-	"8\x9c\xe8S\xf5\x80\xaci\x03\x89T\xa4\x83\xf7\xf6q" +
-	"M/k\x96\x93j\x00\x91\x15\x12\x07\x80\x03\x01\xdc\xb5" +
-	"!\x00VC\x90\xad\x93\xd0\x8d\xe8A\x01\xae\xa9\x03`" +
-	"+\x08\xb2\xdfK\x98V\xf9\x80Q\x06\\\x89T\x04\x8b" +
-	"A\xc2b\xc02\xb9O\x8f\xe2\x82\\\xda\x80\xb8\x00\xd0" +
-	"NJG\x06j\xd3\x14-\xd2\xc1\xb5dB\xd5x\xb3" +
-	"+\xa3\xc4a*)\xa9\x00`\x85\x04\x99GB\x97\xd6" +
-	"\xe2\xcb~\xd5]\xec\xf9\xb8\x1c\xd6\x95~Y\xe7\x86\xc1" +
-	"\x06Mo\xbe\xbdl]\xae\xec\x03\xa8\xde\x1c\x97\x95\xd8" +
-	"\x0fQ-\xcd\xd6l\xea\xd3\xa3\x7f\x8c%\x06\x9aer" +
-	"[\x89\x0d\x99\x12\x95\x126h\xba\xdc\xc55,\x05\x0c" +
-	"\x104\x8a\x95\xde.\xf0O\xdc\xd4\xd7\xd4o\xd5gS" +
-	"\xbc\x03\x80\x15\x13d\x0b%L\xcb\x82,\x87b\x80\x1c" +
-	"\x11$DKi\xe7\x1d\x87gn\xe3l\xbe\xe2>\x1b" +
-	"\xe7\xe7]rx0+<3\x92\xa2a \xeay\xcc" +
-	"zC\xad\x00l\x0fAv\xc02\x92\xfb\x05\xb8\x8f " +
-	";(!J\x1e\x94\x00\xdc\xff\xad\x07`\x07\x08\xb2#" +
-	"\x12\xba\x89\xe4A\x02\xe0>$\xfa=J\x90\x1d\x93\xd0" +
-	"\xed \x1et\x00\xb8\x1f\x12\x12\x0f\x12d\xe3\x12\xa6\x93" +
-	"\x19_\x00`J\xed\xd3xJ\x95\xe3\xdc\x82-\x95#" +
-	"qE\xcd\xe6T\xa6\x0f&y\xf6_\xae\xb8\x1c\xc6\x12" +
-	"\x90\xb0\xe4\xbe\x86'c4\x13\x9c\xc5h\xb7\x9dQa" +
-	"j\x98 \x1b\xcd\x19\x1d\x09\xd9\x19m\xcdy2\x8d\x1e" +
-	"\x17\xe01\x82\xec\xa4\x84\xe9pL\xe1\xaa\x1e\xe4P\x16" +
-	"Nq\xdd\xf4\xc5\x85*\xd3\xb9\xc6\xd5H\x93\xaesp" +
-	"\xc5\x93::AB'\x88C\xbfK\xf7+j\x8f5" +
-	"#\xc5\x17\xe4\xa9~\x9e\xb2bY\xf3d\xd6|\xae\xb9" +
-	"y\xd3\xb2\xc04-\x8bi\xdfA\x90E-\xa6\xb90" +
-	"\x18!\xc8\x92\x12\xba\xa5\x8c\xebx'\x00\x8b\x11d\xbb" +
-	"\x84k2\xeb\xbaO\x18\xd4\x09\xb2a\x09\x1bD\xd7," +
-	"\xf3&\x87\xc3\\\xd3\xb6%\xc0\xd5\xc3U\x13\x8d&\xe2" +
-	"\\\x08\x07\xc2S&\x18\xe1\xfdJ\x98\xb7\xf8\xec\xbc\xdc" +
-	"\xf9$\xcc]\x06\x0fp\x12\xeeq\xc8\xd4\xfcYYh" +
-	"\x96>\xde\x9dk\xac\x19\xdb\x89M\x00l\x9c {\xc2" +
-	"\x12\xdb\x84\xc8\xf2$A\xf6\x8c\x84\x98I\xedi\x81=" +
-	"E\x90\xbd`\x99\x95\xe7E\x94\xcf\x11dg%t;" +
-	"\x1d\x1et\x02\xb8\xa7\x058E\x90]\xb8\xe3\x00\xed5" +
-	"\x84\xa7\x06Mw\xc9hB\xe5\xed}qp\x85,\x11" +
-	"\xff\xa8\x83\x95\xbf\xf1\xec\xc2o\xb5l:1\x19\xeds" +
-	"\xcf\xb3\xddF\xf6\xc9\xbal\x96\xb2\\\x8ab\x87T\x12" +
-	"d\xab-a\xaf\xdc\x94\xbb)\xe7l\x83\xbd\x1a\xd74" +
-	"%\xa1\xde\xed\xc2\x9as\x09\x9am\xce^'?\xb7\xd9" +
-	"\xda\x13%\xac\xdb\xed\x8d:\xbb\xbd!\x92\xd9I\x90\xc5" +
-	",\xc9(\x15\x96e\x92\xdd\x1b\xf1\xd6\xdc2\xb9k\xf7" +
-	"\xf2v\xfb\xecY\x7f\xa05\x91\xbd\xdf\xf0'\xbf\xf6\xad" +
-	"\x13>\xeb{\xa9\xf9\x12[\x9d\xf5M\x9b\xb0\x0e \xb8" +
-	"\x11\x09\x06\xb7\xa0\x84\x19\xe7t3v\x00\x04}\x02\x0e" +
-	"\xa0h#\x1a\xeei\x1b\xb6\x02\x04\xfd\x02\xff\x1b\xe6\x02" +
-	"\xa0\xdb\x0d|\x9b\xc0wb.\x03\xfa\x0f\x03\xdf!\xf0" +
-	"(\xe6F\x9dr\x1c\x03\x08F\x05\xae\x0b\xbc\x00=X" +
-	"\x00@{\xb1\x1b \x98\x14\xf8\x1e\x81\xbb\x9c\x1e\xe3\xe7" +
-	"\xdb\xa0!S\x17\xf8\xb0\xc0\x0b\x0b<X\x08@\x87p" +
-	"\x03@p\x97\xc0\xf7\x09\xbc\xc8\xe5\xc1\"\xf1\xbb\x0dC" +
-	"\x00\xc1a\x81\x8f\x0a|^\xa1\x07\xe7\x01\xd0\x11\xec\x04" +
-	"\x08\x1e\x10\xf8\x11\x81\xcf\x97<8\x1f\x80\x1e\xc2z\x80" +
-	"\xe0\xa8\xc0\x8fa\xeeMj\xfe\xb8\xc8\xbcIC\x8aj" +
-	"\x0c\x12`\xcc|\xab\xd9\xbcRl_3v\xb7\x9b\xa2" +
-	"*\xba\"\xc7|\xa8h\xc9\x98<\xd8.\x938\xb7\xfc" +
-	"3\xaa\x84\x14\xdd\x0fe\x89.\xcb\x9b\xa7GQ\xcd\x17" +
-	"\x7fC$\x11\x97\x15\xf5\x1e\x97m\x8a\xc7\x13:o\x8a" +
-	"\x00\x89\xa4\xec\x1fR\xdf\x07\x00\x00\xff\xff\xf9\x0f\xc8\x7f"
+const schema_e5137205f57b43a9 = "x\xda\xecW_l\x14_\x15>g\xeeNw\xbbl" +
+	"[6wQ\xc0(BxP\x02\x04\x0a\x06!&\xa5" +
+	"\xb4(\xad\xa5\xf4v\x89\x7f\x1aL\x98\xdd\xbdv\xa7\xdd" +
+	"\x9d\xdd\xceL\xff!\x04I\x8a\xd2\xa4h%@\xa0\x16" +
+	"\xa5\xa65\x10mB\x13 \xa25\x12#\xc8\x0b\x12M" +
+	" ZMP\x134Q4FC4\xf1a\xcd\x99\xee" +
+	"\xceN\xb7\x03U\x8c/\xe6\xf7v\xf3\xed\xd7\xaf\xdfw" +
+	"\xce\xb9\x7ff\xc7Fu\xbf\xb2S\xddS\x05 \x0e\xa9" +
+	"U\x05\xf6\x91\xae_\xbf\xeb{_\xff\"Dk\xb1p" +
+	"\xb3\xe9s\xafT\x93\xbf\x00U\x09\x02\xecB\xd6\x83|" +
+	"\x0d\x0b\x02\xf0(\x1b\x04,\xdc;\xd2\x16\xfe\xf4o7" +
+	"}\xb5\x82\x8cD\xeec\x09\xe4g\x1d\xf2\x19\xd6\x00X" +
+	"\xb8\xfc\xbe\x87\x99\xf9\xbew\x7f\xc3\x8f<\xc5N \xbf" +
+	"\xe3\x90\xe7\x1c\xf2h]\xdb\xd1\xddc\x1d3~\xe4\x9f" +
+	"\x92\xf2\x0b\x87\xfc\x1b\x87\xfc\xf8\x13\xbf\x9b\x0a}\xf3\x94" +
+	"\x1f\x99c`\x81\xd7\x04hU\x1d \xee\xfe\xd4/\x8f" +
+	"$/\xdf\x9d\x03Q\x8b\x1e21v\xed\x0dt!?" +
+	"\xec\x90[\x1c\xf23\x96\xd8\xfe\xf4\xe1\xfe\xbb~.\xb2" +
+	"\x81\x09\xe4g\x1d\xf2\x19\x87\xdc\xf2\xc7\xdc\xd6\xb1/\x1d" +
+	"\x9a\xafPV\x1d\xe9\xa9\xc0\x01\xe4s\xcer6\xb0\x01" +
+	"\x01\x0b\xbf\xfaB\xc3\xbd\xc9\x9f]\xb8\xefK\x7f\xa0v" +
+	"!\xff\x85J\xcb\xa7\xaaC\x9f\xb4_\xbe\xff\xd8\xfc\x87" +
+	"~XIw8\xaf\xaa\xd6#W\x83\xb4\xc4\xe0'\x89" +
+	"\xfe`\xef\xe8\x9e\xb6\xe6\x87?\xf6s\xae\x85&\x90\x0f" +
+	"\x87\xc8y\x7f\x88\x9c\xf3\xef\x8e\x0c\xb7O\xb7=\xf6\xd5" +
+	"\xbeD\xecYb\xef\xba\x19r\xb4_\x9e\x18\xf8\xda\x1f" +
+	"\x9eiO\xfc\xb4\xab\xc3\xeb\x91\xaf\x0b\x93\xf6\x9a0i" +
+	"\x87f:\xec\xe7\x7f\xff\xc1\x13\x9fy\xe2{\xc3\x0b\xfc" +
+	"\xa0\xc3m\x0c\xd38\x8d\xde|\xef\x9a\x9f\xf4|k\xc1" +
+	"\xd7\xc7\xb5p\x02\xf9\\\xd8\xa9`\xd8\xf1\xf1\xb7\x1f=" +
+	"\xfak\xac\xf5\xc5\x9f+\xa4\x9d\x96\xd4D\x16\xf8\xba\x88" +
+	"c#\xf2{\xc0\xc2\x97;\x16z?^?\xf1\x17\xdf" +
+	"j\xff)r\x01\xb9ZCK\xacq\xaa\xbd\xf1='" +
+	"\xfeyJ\xfe\xfc\x1f\x95\xf4j\xe2l\xac\xadG\xbe\xb3" +
+	"\x96\x96\xdbj\x9f+\x80\x05Sv\xeb\x96-\xcd\xc0\xf6" +
+	"\xa4\x967\xf6u\xe4,\xbb1\x99\xcc\xf5\x1bv\x87f" +
+	"Y\x8393\xd5)\xfb\xfa\xa5e\xd75iy\xa3\x03" +
+	"Q\x84X\x00 \x80\x00\xd1\x0f&\x00\xc4\x07\x18\x8a\xdd" +
+	"\x0aF\x11cH\xe0\xce-\x00b+C\xf1a\x05\x0b" +
+	"\x86\x1ctd \x983S\x18\x01\x05#\x80uZ\xbf" +
+	"\x9d\xc6\xd5\xe5\xfa\x02\xe2j\x7f+\x9dE\xe8\xb0\xa5[" +
+	"\xa9Ni\xe5s\x86%\x9b\x82E'\x01\xd7I\xcd&" +
+	"\x00\x11b(b\x0a\x06\xad\x96\xe6\xd2\xbfzC\xbcf" +
+	"\xa9%m}@\xb3\xa5\x13\xb0\xc1\xb2\x9b\x96\xcbn)" +
+	"\xcb\xbe\x85\xeb\x83YM\xcf\xfc7\xae\x95E\xcd\xc6~" +
+	";\xfd\xd1Ln\xb0Ic\xcb$\xf6\x15%6+\xd8" +
+	"`\xd9Z\xb7\xb4\xb0\x16\xb0\x83\xa1#V\xbb\xdc\xe0\xc7" +
+	"\xa4\xeb\xafq\xc0\xeb\xcfG\xbc\x13@D\x18\x8a\xb5\x0a" +
+	"\x164\"k\x89\x0c\xa0D\x04\x05\xd1#\xad\xbevx" +
+	"\x966Nc\xffq\x09\x8a\xae\xdbd\xb7\x96\x1c.\x19" +
+	"/\x8e$5\x0cH/\xe6\xea\x9dj\x05\x10'\x19\x8a" +
+	"s\x9e\x91<K\xe0\x08C1\xae *1T\x00\xa2" +
+	"\xe7\xeb\x01\xc49\x86\xe2\xa2\x82Q\xa6\xc4\x90\x01D\xbf" +
+	"B\xfd\x1ec(\xae(\x18\x0d\xb0\x18\x06\x00\xa2\x97\xc8" +
+	"\xe28C1\xa9`!_\xcc\x05\x00\xae\xd5~K\x9a" +
+	"\x86\x96\x95\x1el\x83\x96\xca\xeaF\xa9Nu\xf6p^" +
+	"\x96~\x0af\xb5$\xd6\x80\x825\xff\xd6\xf0\x14\x83\x16" +
+	"\x0b\xe7\x09\xda\xe3\x17\x94B}\x9e\xa1\x18+\x07\x1dM" +
+	"\xf8\x05m-gr\x83^%\xf0\x0aC1\xad`!" +
+	"\x99\xd1\xa5a\xc7%\xd4%Mi\xbb\xb9$\xb9r\x93" +
+	"[\xd2H5\xda\xb6\x84`6o\xa3\x0a\x0a\xaa@\x9b" +
+	"~\xc8n\xd3\x8d^o\x8d\xf4\xe6\xb84\x07\xa4\xe9\xc5" +
+	"J\xe1\xd9b\xf8rs+\xa6e\xad\x1b\xfa*M\xfb" +
+	"E\x86\xe2\xba'\xf45\x0a8\xc9P\xdcP0\xaa\x14" +
+	"S\xcft\x01\x88i\x86\xe2\x16\xa5f\x8b\xa9g)\xe0" +
+	"\xb7\x19\x8a\xefP\xea\xc0b\xea;T\xc8\xdb\x0c\xc5}" +
+	"\x05Q\x8d\xa1\x0a\x10\xfd>I\xce3\x14\x8f\x14l\xa0" +
+	"\xf6z\x06SK&\xa5e\x1d\xcdA\xb0W\x1a.\x9a" +
+	"\xcee%%\x04&M\x17L\xc9\x01=)[\x9a\x97" +
+	"\x86\xfe\xac)\xad\xf4Q\xa8\xcby\xff^\x0e\xe5uS" +
+	"Z-\x104\x0e[n%W\xde_K\x8f\x98\xb7\xd8" +
+	"_+l]\xa3r\x02=\xcd\xe8)\x8f\x8b\xdb\x8c\xa9" +
+	"\x03~\xcdH\x94\x9b\x81\xa5^$\xbc\xbd(N\xe0\x9d" +
+	"\xd6r/\xa2j\xa0\xd8\x8c\xd6r3^3\x96\xa7\x1d" +
+	"\xe3\xe6\xb0\x9b.\x9f\xce\x19\xb2\xbd?\x0b\xc1\x844\xff" +
+	"7\xe3Zy\x8e\xfa\x15\xbf\xd5s~\xd2\x18\xb5/=" +
+	"%\xfc\xce\xf9f\xcd\xd6\\)\xcfUK'\xd3f\x86" +
+	"b\x87\xa7\xd8\xdb\x0e\x94\xef\xdf%g\xcciKZ\x96" +
+	"\x9e3\xdet\x0d.\xb9Z\xdd6\x07\xb5w\xda\xbc\xbc" +
+	"'z\xb2\xbc\x01V\xbb\x95\xd1\xa8'\xc7\x18\x8a\xb4\xa7" +
+	"2\x92*s\x9c\xa1\xc8x*\xa3\xd3.L1\x14y" +
+	"\xcfi\x94\xa5\xc0\x19\x86bh\x85\xeeU\xdc\x18\x8b{" +
+	"\xdd\xcf\xf2\x8a\xc7D\xe9\xd6\xc4\xff\xfb\xcb\xc4;\xe1\x8b" +
+	"\xb97\xb8\xef\xbb\xdd\xa5\xdc\xfc3\xb8\x05 \xfe)d" +
+	"\x18O\xa1\x82\xc5\xe4\\\xc3N\x80\xf8q\x823Hm" +
+	"D'=\xd7\xb1\x15 \x9e&\xdc\xc6r\x01x\x9f\x83" +
+	"\xe7\x09?\x89\xe5\x1a\xf0a\x07\x1f\"|\x04\xcb\xa3\xce" +
+	"\xcf\xe0\x04@|\x84\xf0q\xc2\xab0\x86U\x00\xfc<" +
+	"\xf6\x00\xc4\xc7\x08\xbfBxP\x8d9\x1f\x82\x97\x1c\x9b" +
+	"\xe3\x84O\x12\x1e\xaa\x8aa\x08\x80_\xc5}\x00\xf1\x8b" +
+	"\x84_'\xbc:\x18\xc3j\x00~\x0d\x13\x00\xf1I\xc2" +
+	"o\x10\x1e\x0e\xc50\x0c\xc0g\xb0\x0b >M\xf8-" +
+	"\xc2W)1\\\x05\xc0g\xb1\x1e ~\x83\xf0\xdb\x84" +
+	"GX\x0c#\xf4=\xeb\xf8\xb9E\xf8<\x96_\xc0\xee" +
+	"\xc7K\xf1\x05\x9c\xd0\x0dg\xc0\x003\xee\xcb\xd0\xe7M" +
+	"\xe4\xfbv\xf2\xbb\"uC\xb7u-\xd3\x8c\xba\x95\xcf" +
+	"h\xc3\xed\x1a\xcbJ\xcf\x8fi=\xa1\xdbmP\x97\xeb" +
+	"\xf6\xbc\xb0zu\xc3\xfd\xbehH\xe5\xb2\x9an\xacp" +
+	"c\x9b2\x9b\xb3ec\x0aX\xca\xf4\x7f\xb6U\xde\xd5" +
+	"E\xf8_\x03\x00\x941\xd4\xb1"
 
 func init() {
 	schemas.Register(schema_e5137205f57b43a9,
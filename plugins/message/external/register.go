@@ -31,6 +31,7 @@ type PostRegisterRequest struct {
 	DeviceID           string   `json:"device_id"`
 	InitialDisplayName string   `json:"initial_device_display_name"`
 	InhibitLogin       bool     `json:"inhibit_login"`
+	RefreshToken       bool     `json:"refresh_token"`
 
 	Kind        string `json:"kind"`
 	Domain      string `json:"domain"`
@@ -52,10 +53,12 @@ type LegacyRegisterRequest struct {
 
 //response
 type RegisterResponse struct {
-	UserID      string `json:"user_id"`
-	AccessToken string `json:"access_token"`
-	HomeServer  string `json:"home_server"`
-	DeviceID    string `json:"device_id"`
+	UserID       string `json:"user_id"`
+	AccessToken  string `json:"access_token"`
+	HomeServer   string `json:"home_server"`
+	DeviceID     string `json:"device_id"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	ExpiresInMs  int64  `json:"expires_in_ms,omitempty"`
 }
 
 // Flow represents one possible way that the client can authenticate a request.
@@ -106,13 +109,22 @@ type PostRegisterMsisdResponse struct {
 //POST /_matrix/client/r0/account/password
 //request
 type PostAccountPasswordRequest struct {
-	NewPassword string   `json:"new_password"`
-	Auth        AuthData `json:"auth"`
+	NewPassword   string   `json:"new_password"`
+	LogoutDevices *bool    `json:"logout_devices,omitempty"`
+	Auth          AuthData `json:"auth"`
 }
 
 type AuthData struct {
-	Type    string `json:"type"`
-	Session string `json:"session"`
+	Type       string               `json:"type"`
+	Session    string               `json:"session"`
+	User       string               `json:"user,omitempty"`
+	Identifier *AuthDataIdentifier  `json:"identifier,omitempty"`
+	Password   string               `json:"password,omitempty"`
+}
+
+type AuthDataIdentifier struct {
+	Type string `json:"type"`
+	User string `json:"user,omitempty"`
 }
 
 //POST /_matrix/client/r0/account/password/email/requestToken
@@ -148,7 +160,12 @@ type PostAccountPasswordMsisdResponse struct {
 
 //POST /_matrix/client/r0/account/deactivate
 type PostAccountDeactivateRequest struct {
-	Auth AuthData `json:"auth"`
+	Auth     AuthData `json:"auth"`
+	IDServer string   `json:"id_server,omitempty"`
+}
+
+type PostAccountDeactivateResponse struct {
+	IDServerUnbindResult string `json:"id_server_unbind_result"`
 }
 
 // GET /_matrix/client/r0/register/available
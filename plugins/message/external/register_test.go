@@ -0,0 +1,49 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"testing"
+)
+
+func TestRefreshTokenCapnRoundTrip(t *testing.T) {
+	req := PostRegisterRequest{Username: "alice", DeviceID: "DEVICE", RefreshToken: true}
+	data, err := req.Encode()
+	if err != nil {
+		t.Fatalf("encode register request: %v", err)
+	}
+	var decReq PostRegisterRequest
+	if err := decReq.Decode(data); err != nil || decReq.Username != req.Username || !decReq.RefreshToken {
+		t.Fatalf("decode register request: %+v %v", decReq, err)
+	}
+
+	regRes := RegisterResponse{UserID: "@alice:example.com", AccessToken: "access", RefreshToken: "refresh", ExpiresInMs: 300000}
+	if data, err = regRes.Encode(); err != nil {
+		t.Fatalf("encode register response: %v", err)
+	}
+	var decRegRes RegisterResponse
+	if err := decRegRes.Decode(data); err != nil || decRegRes != regRes {
+		t.Fatalf("decode register response: %+v %v", decRegRes, err)
+	}
+
+	loginRes := PostLoginResponse{UserID: "@alice:example.com", AccessToken: "access", DeviceID: "DEVICE", RefreshToken: "refresh", ExpiresInMs: 300000}
+	if data, err = loginRes.Encode(); err != nil {
+		t.Fatalf("encode login response: %v", err)
+	}
+	var decLoginRes PostLoginResponse
+	if err := decLoginRes.Decode(data); err != nil || decLoginRes != loginRes {
+		t.Fatalf("decode login response: %+v %v", decLoginRes, err)
+	}
+}
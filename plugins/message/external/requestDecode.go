@@ -96,6 +96,22 @@ func (externalReq *PostRoomReportRequest) Decode(input []byte) error {
 	return json.Unmarshal(input, externalReq)
 }
 
+func (externalReq *GetEventReportsRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetEventReportRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutEventReportAssignRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostEventReportResolveRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
 func (externalReq *GetThirdPartyProtocalByNameRequest) Decode(input []byte) error {
 	return json.Unmarshal(input, externalReq)
 }
@@ -439,6 +455,7 @@ func (externalReq *PostRegisterRequest) Decode(input []byte) error {
 		return err
 	}
 	externalReq.InhibitLogin = reqCapn.InhibitLogin()
+	externalReq.RefreshToken = reqCapn.RefreshToken()
 	externalReq.Kind, err = reqCapn.Kind()
 	if err != nil {
 		return err
@@ -1854,6 +1871,34 @@ func (externalReq *PostSystemManagerRequest) Decode(input []byte) error {
 	return json.Unmarshal(input, externalReq)
 }
 
+func (externalReq *PostPurgeHistoryRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetPurgeHistoryStatusRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostSearchRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostUserSearchRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostAccountPasswordRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostAccountDeactivateRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostRoomUpgradeRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
 func (externalReq *GetUserUnread) Decode(input []byte) error {
 	return json.Unmarshal(input, externalReq)
 }
@@ -1993,6 +2038,10 @@ func (externalReq *PostQueryClientKeysRequest) Decode(data []byte) error {
 	return json.Unmarshal(data, externalReq)
 }
 
+func (externalReq *GetFedUserDevicesRequest) Decode(data []byte) error {
+	return json.Unmarshal(data, externalReq)
+}
+
 func (externalReq *PostClaimClientKeysRequest) Decode(data []byte) error {
 	return json.Unmarshal(data, externalReq)
 }
@@ -2000,3 +2049,87 @@ func (externalReq *PostClaimClientKeysRequest) Decode(data []byte) error {
 func (externalReq *DismissRoomRequest) Decode(data []byte) error {
 	return json.Unmarshal(data, externalReq)
 }
+
+func (externalReq *GetAdminUsersRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetAdminUserDevicesRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostAdminUserPasswordRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutAdminUserSuspendRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostAdminUserLogoutRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetFedOpenIDUserInfoRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetSSOLoginRedirectRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetSSOLoginCallbackRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostLoginTokenRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostRefreshRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostDeviceSigningKeysUploadRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostSignaturesUploadRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PostRoomKeysVersionRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetRoomKeysVersionRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutRoomKeysVersionRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *DelRoomKeysVersionRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutRoomKeysRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutRoomKeysByRoomRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *PutRoomKeysBySessionRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *GetRoomKeysRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
+
+func (externalReq *DelRoomKeysRequest) Decode(input []byte) error {
+	return json.Unmarshal(input, externalReq)
+}
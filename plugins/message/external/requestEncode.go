@@ -102,6 +102,22 @@ func (externalReq *PostRoomReportRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
 
+func (externalReq *GetEventReportsRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetEventReportRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutEventReportAssignRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostEventReportResolveRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
 func (externalReq *GetThirdPartyProtocalByNameRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
@@ -383,6 +399,7 @@ func (externalReq *PostRegisterRequest) Encode() ([]byte, error) {
 	reqCapn.SetDeviceID(externalReq.DeviceID)
 	reqCapn.SetInitialDisplayName(externalReq.InitialDisplayName)
 	reqCapn.SetInhibitLogin(externalReq.InhibitLogin)
+	reqCapn.SetRefreshToken(externalReq.RefreshToken)
 	reqCapn.SetKind(externalReq.Kind)
 	reqCapn.SetDomain(externalReq.Domain)
 	reqCapn.SetAccessToken(externalReq.AccessToken)
@@ -1700,6 +1717,34 @@ func (externalReq *PostSystemManagerRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
 
+func (externalReq *PostPurgeHistoryRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetPurgeHistoryStatusRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostSearchRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostUserSearchRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostAccountPasswordRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostAccountDeactivateRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostRoomUpgradeRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
 func (externalReq *GetUserUnread) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
@@ -1840,6 +1885,10 @@ func (externalReq *PostQueryClientKeysRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
 
+func (externalReq *GetFedUserDevicesRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
 func (externalReq *PostClaimClientKeysRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
@@ -1847,3 +1896,87 @@ func (externalReq *PostClaimClientKeysRequest) Encode() ([]byte, error) {
 func (externalReq *DismissRoomRequest) Encode() ([]byte, error) {
 	return json.Marshal(externalReq)
 }
+
+func (externalReq *GetAdminUsersRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetAdminUserDevicesRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostAdminUserPasswordRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutAdminUserSuspendRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostAdminUserLogoutRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetFedOpenIDUserInfoRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetSSOLoginRedirectRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetSSOLoginCallbackRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostLoginTokenRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostRefreshRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostDeviceSigningKeysUploadRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostSignaturesUploadRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PostRoomKeysVersionRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetRoomKeysVersionRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutRoomKeysVersionRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *DelRoomKeysVersionRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutRoomKeysRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutRoomKeysByRoomRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *PutRoomKeysBySessionRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *GetRoomKeysRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
+
+func (externalReq *DelRoomKeysRequest) Encode() ([]byte, error) {
+	return json.Marshal(externalReq)
+}
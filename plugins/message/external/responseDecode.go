@@ -81,6 +81,11 @@ func (res *PostLoginResponse) Decode(input []byte) error {
 	if err != nil {
 		return err
 	}
+	res.RefreshToken, err = resCapn.RefreshToken()
+	if err != nil {
+		return err
+	}
+	res.ExpiresInMs = resCapn.ExpiresInMs()
 	return nil
 }
 
@@ -111,6 +116,11 @@ func (res *RegisterResponse) Decode(input []byte) error {
 	if err != nil {
 		return err
 	}
+	res.RefreshToken, err = resCapn.RefreshToken()
+	if err != nil {
+		return err
+	}
+	res.ExpiresInMs = resCapn.ExpiresInMs()
 	return nil
 }
 
@@ -1143,10 +1153,126 @@ func (res *PostQueryClientKeysResponse) Decode(input []byte) error {
 	return json.Unmarshal(input, res)
 }
 
+func (res *GetFedUserDevicesResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, res)
+}
+
 func (res *PostClaimClientKeysResponse) Decode(input []byte) error {
 	return json.Unmarshal(input, res)
 }
 
 func (res *DismissRoomResponse) Decode(input []byte) error {
 	return json.Unmarshal(input, res)
-}
\ No newline at end of file
+}
+
+func (r *PostPurgeHistoryResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetPurgeHistoryStatusResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostSearchResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostAccountDeactivateResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostRoomUpgradeResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *EventReport) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetEventReportsResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetWhoIsResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetAdminUsersResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetAdminUserDevicesResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostUserOpenIDResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetFedOpenIDUserInfoResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *LoginRedirectResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostLoginTokenResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostRefreshResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyProtocalsResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyProtocalByNameResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyLocationByProtocolResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyUserByProtocolResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyLocationResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetThirdPartyUserResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostSignaturesUploadResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PostRoomKeysVersionResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetRoomKeysVersionResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *PutRoomKeysResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetRoomKeysResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetRoomKeysByRoomResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
+
+func (r *GetRoomKeysBySessionResponse) Decode(input []byte) error {
+	return json.Unmarshal(input, r)
+}
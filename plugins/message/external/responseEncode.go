@@ -70,6 +70,8 @@ func (res *PostLoginResponse) Encode() ([]byte, error) {
 	resCapn.SetAccessToken(res.AccessToken)
 	resCapn.SetHomeServer(res.HomeServer)
 	resCapn.SetDeviceID(res.DeviceID)
+	resCapn.SetRefreshToken(res.RefreshToken)
+	resCapn.SetExpiresInMs(res.ExpiresInMs)
 
 	data, err := msg.Marshal()
 	if err != nil {
@@ -93,6 +95,8 @@ func (res *RegisterResponse) Encode() ([]byte, error) {
 	resCapn.SetAccessToken(res.AccessToken)
 	resCapn.SetHomeServer(res.HomeServer)
 	resCapn.SetDeviceID(res.DeviceID)
+	resCapn.SetRefreshToken(res.RefreshToken)
+	resCapn.SetExpiresInMs(res.ExpiresInMs)
 
 	data, err := msg.Marshal()
 	if err != nil {
@@ -1130,10 +1134,125 @@ func (r *PostQueryClientKeysResponse) Encode() ([]byte, error) {
 	return json.Marshal(r)
 }
 
+func (r *GetFedUserDevicesResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
 func (r *PostClaimClientKeysResponse) Encode() ([]byte, error) {
 	return json.Marshal(r)
 }
 
 func (res *DismissRoomResponse) Encode() ([]byte, error) {
 	return json.Marshal(res)
-}
\ No newline at end of file
+}
+func (r *PostPurgeHistoryResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetPurgeHistoryStatusResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostSearchResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostAccountDeactivateResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostRoomUpgradeResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *EventReport) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetEventReportsResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetWhoIsResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetAdminUsersResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetAdminUserDevicesResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostUserOpenIDResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetFedOpenIDUserInfoResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *LoginRedirectResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostLoginTokenResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostRefreshResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyProtocalsResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyProtocalByNameResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyLocationByProtocolResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyUserByProtocolResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyLocationResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetThirdPartyUserResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostSignaturesUploadResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PostRoomKeysVersionResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetRoomKeysVersionResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *PutRoomKeysResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetRoomKeysResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetRoomKeysByRoomResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
+
+func (r *GetRoomKeysBySessionResponse) Encode() ([]byte, error) {
+	return json.Marshal(r)
+}
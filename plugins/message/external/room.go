@@ -265,4 +265,14 @@ type DismissRoomRequest struct {
 }
 
 type DismissRoomResponse struct {
-}
\ No newline at end of file
+}
+
+//POST /_matrix/client/r0/rooms/{roomId}/upgrade
+type PostRoomUpgradeRequest struct {
+	RoomID     string `json:"room_id"`
+	NewVersion string `json:"new_version"`
+}
+
+type PostRoomUpgradeResponse struct {
+	ReplacementRoom string `json:"replacement_room"`
+}
@@ -0,0 +1,95 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package external
+
+import (
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+)
+
+// POST /_matrix/client/r0/search
+type PostSearchRequest struct {
+	NextBatch        string           `json:"next_batch,omitempty"`
+	SearchCategories SearchCategories `json:"search_categories"`
+}
+
+type SearchCategories struct {
+	RoomEvents *RoomEventsCriteria `json:"room_events,omitempty"`
+}
+
+type RoomEventsCriteria struct {
+	SearchTerm   string                `json:"search_term"`
+	Keys         []string              `json:"keys,omitempty"`
+	Filter       *RoomEventFilter      `json:"filter,omitempty"`
+	OrderBy      string                `json:"order_by,omitempty"`
+	EventContext *SearchContextRequest `json:"event_context,omitempty"`
+	IncludeState bool                  `json:"include_state,omitempty"`
+	Groupings    *SearchGroupings      `json:"groupings,omitempty"`
+}
+
+type SearchContextRequest struct {
+	BeforeLimit    *int `json:"before_limit,omitempty"`
+	AfterLimit     *int `json:"after_limit,omitempty"`
+	IncludeProfile bool `json:"include_profile,omitempty"`
+}
+
+type SearchGroupings struct {
+	GroupBy []SearchGroup `json:"group_by,omitempty"`
+}
+
+type SearchGroup struct {
+	Key string `json:"key"`
+}
+
+type PostSearchResponse struct {
+	SearchCategories SearchCategoriesResult `json:"search_categories"`
+}
+
+type SearchCategoriesResult struct {
+	RoomEvents *RoomEventsResult `json:"room_events,omitempty"`
+}
+
+type RoomEventsResult struct {
+	Count      int64                                      `json:"count"`
+	Highlights []string                                   `json:"highlights"`
+	Results    []SearchResult                             `json:"results"`
+	State      map[string][]gomatrixserverlib.ClientEvent `json:"state,omitempty"`
+	Groups     map[string]map[string]*SearchGroupResult   `json:"groups,omitempty"`
+	NextBatch  string                                     `json:"next_batch,omitempty"`
+}
+
+type SearchResult struct {
+	Rank    float64                       `json:"rank"`
+	Result  gomatrixserverlib.ClientEvent `json:"result"`
+	Context *SearchEventContext           `json:"context,omitempty"`
+}
+
+type SearchEventContext struct {
+	Start        string                          `json:"start,omitempty"`
+	End          string                          `json:"end,omitempty"`
+	ProfileInfo  map[string]SearchUserProfile    `json:"profile_info,omitempty"`
+	EventsBefore []gomatrixserverlib.ClientEvent `json:"events_before"`
+	EventsAfter  []gomatrixserverlib.ClientEvent `json:"events_after"`
+}
+
+type SearchUserProfile struct {
+	DisplayName string `json:"displayname,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+}
+
+type SearchGroupResult struct {
+	NextBatch string   `json:"next_batch,omitempty"`
+	Order     int      `json:"order"`
+	Results   []string `json:"results"`
+}
@@ -45,6 +45,8 @@ const (
 	MSG_POST_LOGIN_ADMIN int32 = 0x00010103
 	MSG_POST_LOGOUT      int32 = 0x00010202
 	MSG_POST_LOGOUT_ALL  int32 = 0x00010302
+	MSG_POST_LOGIN_TOKEN int32 = 0x00010402
+	MSG_POST_REFRESH     int32 = 0x00010502
 
 	MSG_POST_REGISTER           int32 = 0x00020002
 	MSG_POST_REGISTER_LEGACY    int32 = 0x00020003
@@ -108,6 +110,7 @@ const (
 	MSG_POST_ROOM_FORGET int32 = 0x000e0102
 	MSG_POST_ROOM_KICK   int32 = 0x000e0202
 	MSG_POST_ROOM_DISMISS int32 = 0x000e0302
+	MSG_POST_ROOM_UPGRADE int32 = 0x000e0402
 	MSG_POST_ROOM_BAN   int32 = 0x000f0202
 	MSG_POST_ROOM_UNBAN int32 = 0x000f0302
 
@@ -166,6 +169,23 @@ const (
 	MSG_POST_KEYS_QUERY               int32 = 0x001b0102
 	MSG_POST_KEYS_CLAIM               int32 = 0x001b0202
 	MSG_GET_KEYS_CHANGES              int32 = 0x001b0300
+	MSG_POST_KEYS_DEVICE_SIGNING      int32 = 0x001b0402
+	MSG_POST_KEYS_SIGNATURES          int32 = 0x001b0502
+
+	MSG_POST_ROOM_KEYS_VERSION      int32 = 0x001b0602
+	MSG_GET_ROOM_KEYS_VERSION       int32 = 0x001b0700
+	MSG_GET_ROOM_KEYS_VERSION_BY_ID int32 = 0x001b0800
+	MSG_PUT_ROOM_KEYS_VERSION_BY_ID int32 = 0x001b0901
+	MSG_DEL_ROOM_KEYS_VERSION_BY_ID int32 = 0x001b0a03
+	MSG_GET_ROOM_KEYS               int32 = 0x001b0b00
+	MSG_PUT_ROOM_KEYS               int32 = 0x001b0c01
+	MSG_DEL_ROOM_KEYS               int32 = 0x001b0d03
+	MSG_GET_ROOM_KEYS_BY_ROOM       int32 = 0x001b0e00
+	MSG_PUT_ROOM_KEYS_BY_ROOM       int32 = 0x001b0f01
+	MSG_DEL_ROOM_KEYS_BY_ROOM       int32 = 0x001b1103
+	MSG_GET_ROOM_KEYS_BY_SESSION    int32 = 0x001b1200
+	MSG_PUT_ROOM_KEYS_BY_SESSION    int32 = 0x001b1301
+	MSG_DEL_ROOM_KEYS_BY_SESSION    int32 = 0x001b1403
 
 	MSG_GET_VISIBILITY_RANGE int32 = 0x001b1000
 
@@ -196,14 +216,25 @@ const (
 	MSG_GET_USER_NEW_TOKEN    int32 = 0x00210201
 	MSG_GET_SUPER_ADMIN_TOKEN int32 = 0x00210301
 
-	MSG_GET_WHO_IS int32 = 0x00220001
+	MSG_GET_WHO_IS               int32 = 0x00220001
+	MSG_GET_ADMIN_USERS          int32 = 0x00220100
+	MSG_GET_ADMIN_USER_DEVICES   int32 = 0x00220200
+	MSG_POST_ADMIN_USER_PASSWORD int32 = 0x00220302
+	MSG_PUT_ADMIN_USER_SUSPEND   int32 = 0x00220401
+	MSG_POST_ADMIN_USER_LOGOUT   int32 = 0x00220502
 
 	MSG_GET_ROOM_EVENT_CONTEXT int32 = 0x00230001
 
 	MSG_GET_CAS_LOGIN_REDIRECT int32 = 0x00240001
 	MSG_GET_CAS_LOGIN_TICKET   int32 = 0x00240101
+	MSG_GET_SSO_LOGIN_REDIRECT int32 = 0x00240201
+	MSG_GET_SSO_LOGIN_CALLBACK int32 = 0x00240301
 
-	MSG_POST_ROOM_REPORT int32 = 0x00250002
+	MSG_POST_ROOM_REPORT          int32 = 0x00250002
+	MSG_GET_EVENT_REPORTS         int32 = 0x00250100
+	MSG_GET_EVENT_REPORT          int32 = 0x00250200
+	MSG_PUT_EVENT_REPORT_ASSIGN   int32 = 0x00250301
+	MSG_POST_EVENT_REPORT_RESOLVE int32 = 0x00250402
 
 	MSG_GET_THIRDPARTY_PROTOS            int32 = 0x00260001
 	MSG_GET_THIRDPARTY_PROTO_BY_NAME     int32 = 0x00260101
@@ -214,7 +245,9 @@ const (
 
 	MSG_POST_USER_OPENID int32 = 0x00270002
 
-	MSG_POST_SYSTEM_MANAGER int32 = 0x00280001
+	MSG_POST_SYSTEM_MANAGER      int32 = 0x00280001
+	MSG_POST_PURGE_HISTORY       int32 = 0x00280101
+	MSG_GET_PURGE_HISTORY_STATUS int32 = 0x00280200
 
 	MSG_GET_FED_VER               int32 = 0x00290001
 	MSG_GET_FED_DIRECTOR          int32 = 0x00290101
@@ -240,6 +273,7 @@ const (
 	MSG_GET_FED_USER_DEVICES      int32 = 0x00294701
 	MSG_GET_FED_CLIENT_KEYS       int32 = 0x00294801
 	MSG_GET_FED_CLIENT_KEYS_CLAIM int32 = 0x00294901
+	MSG_GET_FED_OPENID_USERINFO   int32 = 0x00295001
 
 	MSG_PUT_FED_EXCHANGE_THIRD_PARTY_INVITE int32 = 0x00294901
 
@@ -380,6 +414,8 @@ type Device struct {
 	Identifier   string `json:"identifier,omitempty"`
 	CreateTs     int64  `json:"create_ts,omitempty"`
 	LastActiveTs int64  `json:"last_active_ts,omitempty"`
+	// ExpireTs mirrors authtypes.Device, it isn't sent to the backends
+	ExpireTs int64 `json:"expire_ts,omitempty"`
 }
 
 type InputMsg struct {
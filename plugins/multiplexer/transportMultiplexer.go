@@ -118,7 +118,7 @@ func (m *TransportMultiplexer) SendRecvNode(nodeName, serviceID string, obj inte
 	if err != nil {
 		return nil, err
 	}
-	value, err := msg.Encode()
+	value, err := m.encode(channnel, msg)
 	if err != nil {
 		return nil, err
 	}
@@ -129,6 +129,20 @@ func (m *TransportMultiplexer) SendRecvNode(nodeName, serviceID string, obj inte
 	return nil, errors.New("can't find channel")
 }
 
+// encode uses the format configured for the topic unless the message asks
+// for another format than json
+func (m *TransportMultiplexer) encode(channel core.IChannel, msg *core.TransportPubMsg) ([]byte, error) {
+	format := msg.Format
+	if format == core.FORMAT_JSON {
+		topic := msg.Topic
+		if topic == "" && channel != nil {
+			topic = channel.GetTopic()
+		}
+		format = core.GetTopicFormat(topic)
+	}
+	return core.EncodeMsg(format, msg.Obj)
+}
+
 func (m *TransportMultiplexer) getNodeInst(serviceID string, msg *core.TransportPubMsg) string {
 	inst := msg.Inst
 	if inst <= 0 {
@@ -155,7 +169,7 @@ func (m *TransportMultiplexer) Send(nodeName, serviceID string, obj interface{})
 	if err != nil {
 		return err
 	}
-	value, err := msg.Encode()
+	value, err := m.encode(channnel, msg)
 	if err != nil {
 		log.Errorf("TransportMultiplexer Failed to encodemsg:%v, err:%v", obj, err)
 		return err
@@ -170,7 +184,7 @@ func (m *TransportMultiplexer) SendAndRecv(nodeName, serviceID string, obj inter
 	if err != nil {
 		return err
 	}
-	value, err := msg.Encode()
+	value, err := m.encode(channnel, msg)
 	if err != nil {
 		log.Errorf("TransportMultiplexer Failed to encodemsg:%v, err:%v", obj, err)
 		return err
@@ -185,7 +199,7 @@ func (m *TransportMultiplexer) SendWithRetry(nodeName, serviceID string, obj int
 	if err != nil {
 		return err
 	}
-	value, err := msg.Encode()
+	value, err := m.encode(channnel, msg)
 	if err != nil {
 		log.Errorf("TransportMultiplexer Failed to encodemsg:%v, err:%v", obj, err)
 		return err
@@ -200,7 +214,7 @@ func (m *TransportMultiplexer) SendAndRecvWithRetry(nodeName, serviceID string,
 	if err != nil {
 		return err
 	}
-	value, err := msg.Encode()
+	value, err := m.encode(channnel, msg)
 	if err != nil {
 		log.Errorf("TransportMultiplexer Failed to encodemsg:%v, err:%v", obj, err)
 		return err
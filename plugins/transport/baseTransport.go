@@ -54,6 +54,13 @@ func (t *baseTransport) GetStatsInterval() int {
 	return t.statsInterval
 }
 
+// setTopicFormat records the wire format configured for the topic of a channel
+func (t *baseTransport) setTopicFormat(topic string, conf interface{}) {
+	if err := core.SetTopicFormatConf(topic, conf); err != nil {
+		log.Errorf("transport %s topic:%s %v", t.id, topic, err)
+	}
+}
+
 func (t *baseTransport) StartChannel(id string) {
 	val, ok := t.channels.Load(id)
 	if ok == true {
@@ -46,6 +46,7 @@ func (t *KafkaTransport) AddChannel(dir int, id, topic, grp string, conf interfa
 	channel.Init(t.logPorf)
 	channel.SetDir(dir)
 	channel.SetTopic(topic)
+	t.setTopicFormat(topic, conf)
 	channel.SetID(id)
 	channel.SetGroup(grp)
 
@@ -48,6 +48,7 @@ func (t *NatsTransport) AddChannel(dir int, id, topic, grp string, conf interfac
 	channel.Init(t.logPorf)
 	channel.SetDir(dir)
 	channel.SetTopic(topic)
+	t.setTopicFormat(topic, conf)
 	channel.SetID(id)
 	channel.SetGroup(grp)
 
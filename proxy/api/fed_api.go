@@ -90,6 +90,7 @@ func init() {
 	apiconsumer.SetAPIProcessor(ReqGetStateIDs{})
 	apiconsumer.SetAPIProcessor(ReqGetPublicRooms{})
 	apiconsumer.SetAPIProcessor(ReqPostPublicRooms{})
+	apiconsumer.SetAPIProcessor(ReqGetUserDevices{})
 	apiconsumer.SetAPIProcessor(ReqPostQueryClientKeys{})
 	apiconsumer.SetAPIProcessor(ReqPostClaimClientKeys{})
 }
@@ -1381,6 +1382,54 @@ func (ReqPostPublicRooms) Process(ctx context.Context, ud interface{}, msg core.
 	return http.StatusOK, &res
 }
 
+type ReqGetUserDevices struct{}
+
+func (ReqGetUserDevices) GetRoute() string                     { return "/user/devices/{userId}" }
+func (ReqGetUserDevices) GetMetricsName() string               { return "user_devices" }
+func (ReqGetUserDevices) GetMsgType() int32                    { return internals.MSG_GET_FED_USER_DEVICES }
+func (ReqGetUserDevices) GetAPIType() int8                     { return apiconsumer.APITypeFed }
+func (ReqGetUserDevices) GetMethod() []string                  { return []string{http.MethodGet} }
+func (ReqGetUserDevices) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetUserDevices) GetPrefix() []string                  { return []string{"fedV1"} }
+func (ReqGetUserDevices) NewRequest() core.Coder               { return new(external.GetFedUserDevicesRequest) }
+func (ReqGetUserDevices) NewResponse(code int) core.Coder      { return new(external.GetFedUserDevicesResponse) }
+func (ReqGetUserDevices) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetFedUserDevicesRequest)
+	msg.UserID = vars["userId"]
+	return nil
+}
+func (ReqGetUserDevices) Process(ctx context.Context, ud interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	req := msg.(*external.GetFedUserDevicesRequest)
+	cfg := ud.(*FedApiUserData).Cfg
+	idg := ud.(*FedApiUserData).Idg
+
+	domain, err := common.DomainFromID(req.UserID)
+	if err != nil {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue(err.Error())
+	}
+	if !common.CheckValidDomain(domain, cfg.Matrix.ServerName) {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("userId is not on this server")
+	}
+
+	gobMsg := model.GobMessage{}
+	gobMsg.MsgSeq = genMsgSeq(idg)
+	gobMsg.Body, _ = json.Marshal(req)
+	gobMsg.Cmd = model.CMD_FED_USER_DEVICES
+
+	log.Debugf("get user devices req: %s", gobMsg.Body)
+	resp, err := bridge.SendAndRecv(gobMsg, 30000)
+	if err != nil {
+		return http.StatusRequestTimeout, jsonerror.Unknown(err.Error())
+	} else if resp.Head.ErrStr != "" {
+		return http.StatusInternalServerError, jsonerror.Unknown(resp.Head.ErrStr)
+	}
+
+	var res external.GetFedUserDevicesResponse
+	json.Unmarshal(resp.Body, &res)
+
+	return http.StatusOK, &res
+}
+
 type ReqPostQueryClientKeys struct{}
 
 func (ReqPostQueryClientKeys) GetRoute() string                     { return "/user/keys/query" }
@@ -303,7 +303,7 @@ func (w *HttpProcessor) handleRecv(processor apiconsumer.APIProcessor, outputMsg
 
 	var resp util.JSONResponse
 	resp.Code = outputMsg.Code
-	if resp.Headers != nil {
+	if len(outputMsg.Headers) > 0 {
 		err := json.Unmarshal(outputMsg.Headers, &resp.Headers)
 		if err != nil {
 			return util.JSONResponse{
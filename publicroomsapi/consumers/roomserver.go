@@ -74,7 +74,7 @@ func (s *OutputRoomEventConsumer) Start() error {
 // OnMessage is called when the sync server receives a new event from the room server output log.
 func (s *OutputRoomEventConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output roomserverapi.OutputEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		// If the message was invalid, log it and move on to the next message in the stream
 		log.Errorw("publicroomsapi: message parse failure", log.KeysAndValues{"error", err})
 		return
@@ -115,6 +115,8 @@ func (s *OutputRoomEventConsumer) isCare(ev *gomatrixserverlib.ClientEvent) bool
 		return true
 	case "m.room.guest_access":
 		return true
+	case "m.room.tombstone":
+		return true
 	default:
 		return false
 	}
@@ -179,6 +179,42 @@ func (r *AliasProcessor) RemoveRoomAlias(
 	return r.sendUpdatedAliasesEvent(ctx, request.UserID, roomID)
 }
 
+// MoveRoomAliases implements roomserverapi.RoomserverAliasAPI
+func (r *AliasProcessor) MoveRoomAliases(
+	ctx context.Context,
+	request *roomserverapi.MoveRoomAliasesRequest,
+	response *roomserverapi.MoveRoomAliasesResponse,
+) error {
+	aliases, err := r.DB.GetAliasesFromRoomID(ctx, request.OldRoomID)
+	if err != nil {
+		return err
+	}
+	response.Aliases = aliases
+	if len(aliases) == 0 {
+		return nil
+	}
+
+	for _, alias := range aliases {
+		if err := r.DB.RemoveRoomAlias(ctx, alias); err != nil {
+			return err
+		}
+		if err := r.DB.SetRoomAlias(ctx, alias, request.NewRoomID); err != nil {
+			return err
+		}
+		err := r.Cache.SetAlias(alias, request.NewRoomID, 0)
+		if err != nil {
+			log.Warnf("MoveRoomAliases set alias:%s room_id:%s to cache err:%v", alias, request.NewRoomID, err)
+		}
+	}
+
+	// the db may be written async, so the moved aliases are sent as they are
+	// instead of being read back
+	if err := r.sendAliasesEvent(ctx, request.UserID, request.NewRoomID, aliases); err != nil {
+		return err
+	}
+	return r.sendAliasesEvent(ctx, request.UserID, request.OldRoomID, []string{})
+}
+
 type roomAliasesContent struct {
 	Aliases []string `json:"aliases"`
 }
@@ -187,6 +223,18 @@ type roomAliasesContent struct {
 // removal of an alias
 func (r *AliasProcessor) sendUpdatedAliasesEvent(
 	ctx context.Context, userID string, roomID string,
+) error {
+	// Retrieve the updated list of aliases and send it
+	aliases, err := r.DB.GetAliasesFromRoomID(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	return r.sendAliasesEvent(ctx, userID, roomID, aliases)
+}
+
+// sendAliasesEvent sends a m.room.aliases event listing the aliases to the room
+func (r *AliasProcessor) sendAliasesEvent(
+	ctx context.Context, userID string, roomID string, aliases []string,
 ) error {
 	domainID, _ := common.DomainFromID(userID)
 
@@ -197,14 +245,8 @@ func (r *AliasProcessor) sendUpdatedAliasesEvent(
 		StateKey: &domainID,
 	}
 
-	// Retrieve the updated list of aliases, marhal it and set it as the
-	// event's content
-	aliases, err := r.DB.GetAliasesFromRoomID(ctx, roomID)
-	if err != nil {
-		return err
-	}
 	content := roomAliasesContent{Aliases: aliases}
-	err = builder.SetContent(content)
+	err := builder.SetContent(content)
 	if err != nil {
 		return err
 	}
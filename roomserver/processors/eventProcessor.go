@@ -128,7 +128,7 @@ func (r *EventsProcessor) WriteOutputEvents(ctx context.Context, roomID string,
 				r.Cfg.Kafka.Producer.OutputRoomEvent.Name,
 				&core.TransportPubMsg{
 					Keys:    roomIDData,
-					Obj:     updates[i],
+					Obj:     &updates[i],
 					Inst:    r.Cfg.Kafka.Producer.OutputRoomEvent.Inst,
 					Headers: common.InjectSpanToHeaderForSending(span),
 				})
@@ -63,11 +63,13 @@ func (s *RoomQryProcessor) QueryRoomState(
 	response.JoinRule = rs.JoinRule
 	response.Name = rs.Name
 	response.Topic = rs.Topic
+	response.Desc = rs.Desc
 	response.CanonicalAlias = rs.CanonicalAlias
 	response.Power = rs.Power
 	response.Alias = rs.Alias
 	response.Avatar = rs.Avatar
 	response.GuestAccess = rs.GuestAccess
+	response.Tombstone = rs.Tombstone
 
 	response.Join = make(map[string]*gomatrixserverlib.Event)
 	response.Leave = make(map[string]*gomatrixserverlib.Event)
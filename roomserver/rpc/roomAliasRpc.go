@@ -74,6 +74,8 @@ func (s *RoomAliasRpcConsumer) Start() error {
 				s.processRemoveRoomAlias(msg.Ctx, data.RemoveRoomAliasRequest, data.Reply)
 			} else if data.AllocRoomAliasRequest != nil {
 				s.processAllocRoomAlias(msg.Ctx, data.AllocRoomAliasRequest, data.Reply)
+			} else if data.MoveRoomAliasesRequest != nil {
+				s.processMoveRoomAliases(msg.Ctx, data.MoveRoomAliasesRequest, data.Reply)
 			}
 		}
 	}()
@@ -151,3 +153,14 @@ func (s *RoomAliasRpcConsumer) processAllocRoomAlias(
 	s.Proc.AllocRoomAlias(ctx, request, &response)
 	s.rpcClient.PubObj(reply, response)
 }
+
+func (s *RoomAliasRpcConsumer) processMoveRoomAliases(
+	ctx context.Context,
+	request *roomserverapi.MoveRoomAliasesRequest,
+	reply string,
+) {
+	var response roomserverapi.MoveRoomAliasesResponse
+
+	s.Proc.MoveRoomAliases(ctx, request, &response)
+	s.rpcClient.PubObj(reply, response)
+}
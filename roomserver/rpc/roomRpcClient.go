@@ -154,6 +154,28 @@ func (c *RoomserverRpcClient) RemoveRoomAlias(
 	return err
 }
 
+func (c *RoomserverRpcClient) MoveRoomAliases(
+	ctx context.Context,
+	req *roomserverapi.MoveRoomAliasesRequest,
+	response *roomserverapi.MoveRoomAliasesResponse,
+) error {
+	if c.aliase != nil {
+		return c.aliase.MoveRoomAliases(ctx, req, response)
+	}
+
+	content := roomserverapi.RoomserverAliasRequest{
+		MoveRoomAliasesRequest: req,
+	}
+	bytes, err := json.Marshal(content)
+	data, err := c.rpcClient.Request(c.cfg.Rpc.AliasTopic, bytes, 30000)
+	if err == nil {
+		json.Unmarshal(data, response)
+		return nil
+	}
+
+	return err
+}
+
 func (c *RoomserverRpcClient) QueryEventsByID(
 	ctx context.Context,
 	req *roomserverapi.QueryEventsByIDRequest,
@@ -400,3 +422,11 @@ func (c *RoomserverRpcClient) ProcessTyping(edu *gomatrixserverlib.EDU) {
 func (c *RoomserverRpcClient) ProcessProfile(edu *gomatrixserverlib.EDU) {
 
 }
+
+func (c *RoomserverRpcClient) ProcessDeviceListUpdate(edu *gomatrixserverlib.EDU) {
+
+}
+
+func (c *RoomserverRpcClient) ProcessSendToDevice(edu *gomatrixserverlib.EDU) {
+
+}
@@ -345,12 +345,23 @@ func (ac *FederationClient) LookupDeviceKeys(
 	ctx context.Context, s ServerName, content *QueryRequest,
 ) (res QueryResponse, err error) {
 	path := federationPathPrefix + "/user/keys/query"
-	req := NewFederationRequest("POST", "python:9999", path)
+	req := NewFederationRequest("POST", s, path)
 	req.SetContent(*content)
 	err = ac.doRequest(ctx, req, &res)
 	return
 }
 
+// GetUserDevices gets the devices of a user on the server with their keys
+// and the stream id of the device list
+func (ac *FederationClient) GetUserDevices(
+	ctx context.Context, s ServerName, userID string,
+) (res RespUserDevices, err error) {
+	path := federationPathPrefix + "/user/devices/" + url.PathEscape(userID)
+	req := NewFederationRequest("GET", s, path)
+	err = ac.doRequest(ctx, req, &res)
+	return
+}
+
 // LookupOneTimeKeys lookup a key for certain device
 // which is used to encryption chatting session set up
 func (ac *FederationClient) LookupOneTimeKeys(
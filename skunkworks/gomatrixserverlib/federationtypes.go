@@ -473,7 +473,17 @@ type QueryRequest struct {
 
 // QueryResponse structure
 type QueryResponse struct {
-	DeviceKeys map[string]map[string]DeviceKeysQuery `json:"device_keys"`
+	DeviceKeys      map[string]map[string]DeviceKeysQuery `json:"device_keys"`
+	MasterKeys      map[string]CrossSigningKey            `json:"master_keys,omitempty"`
+	SelfSigningKeys map[string]CrossSigningKey            `json:"self_signing_keys,omitempty"`
+}
+
+// CrossSigningKey structure
+type CrossSigningKey struct {
+	UserID     string                       `json:"user_id"`
+	Usage      []string                     `json:"usage"`
+	Keys       map[string]string            `json:"keys"`
+	Signatures map[string]map[string]string `json:"signatures,omitempty"`
 }
 
 // DeviceKeysQuery structure
@@ -491,6 +501,22 @@ type UnsignedDeviceInfo struct {
 	Info string `json:"device_display_name"`
 }
 
+// RespUserDevices is the device list of a user returned by /user/devices
+type RespUserDevices struct {
+	UserID         string           `json:"user_id"`
+	StreamID       int64            `json:"stream_id"`
+	Devices        []RespUserDevice `json:"devices"`
+	MasterKey      *CrossSigningKey `json:"master_key,omitempty"`
+	SelfSigningKey *CrossSigningKey `json:"self_signing_key,omitempty"`
+}
+
+// RespUserDevice structure
+type RespUserDevice struct {
+	DeviceID    string          `json:"device_id"`
+	DisplayName string          `json:"device_display_name,omitempty"`
+	Keys        DeviceKeysQuery `json:"keys"`
+}
+
 // ClaimResponse structure
 type ClaimResponse struct {
 	Failures  map[string]interface{}                       `json:"failures"`
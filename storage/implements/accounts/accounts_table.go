@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
@@ -46,6 +47,18 @@ CREATE TABLE IF NOT EXISTS account_accounts (
 CREATE UNIQUE INDEX IF NOT EXISTS account_accounts_user_id ON account_accounts(user_id);
 `
 
+func init() {
+	migration.Register("accounts", migration.Migration{
+		Version: 1,
+		Name:    "account deactivated",
+		Up:      "ALTER TABLE account_accounts ADD COLUMN deactivated BOOLEAN NOT NULL DEFAULT FALSE",
+	}, migration.Migration{
+		Version: 2,
+		Name:    "account suspended",
+		Up:      "ALTER TABLE account_accounts ADD COLUMN suspended BOOLEAN NOT NULL DEFAULT FALSE",
+	})
+}
+
 const insertAccountSQL = "" +
 	"INSERT INTO account_accounts(user_id, created_ts, password_hash, app_service_id) VALUES ($1, $2, $3, $4)" +
 	"ON CONFLICT (user_id) DO NOTHING"
@@ -54,7 +67,23 @@ const selectAccountsCountSQL = "" +
 	"SELECT count(1) FROM account_accounts"
 
 const selectAccountSQL = "" +
-	"SELECT user_id, app_service_id FROM account_accounts WHERE user_id = $1"
+	"SELECT user_id, app_service_id, deactivated, suspended FROM account_accounts WHERE user_id = $1"
+
+const selectAccountsSQL = "" +
+	"SELECT user_id, created_ts, app_service_id, deactivated, suspended FROM account_accounts" +
+	" WHERE ($1 = '' OR user_id LIKE $1) AND user_id > $2 ORDER BY user_id LIMIT $3"
+
+const selectPasswordHashSQL = "" +
+	"SELECT COALESCE(password_hash, '') FROM account_accounts WHERE user_id = $1 AND deactivated = FALSE"
+
+const updatePasswordSQL = "" +
+	"UPDATE account_accounts SET password_hash = $1 WHERE user_id = $2"
+
+const deactivateAccountSQL = "" +
+	"UPDATE account_accounts SET deactivated = TRUE, password_hash = NULL WHERE user_id = $1"
+
+const updateSuspendedSQL = "" +
+	"UPDATE account_accounts SET suspended = $1 WHERE user_id = $2"
 
 const selectActualCountSQL = "" +
 	"SELECT count(1) FROM account_accounts where app_service_id = 'actual'"
@@ -69,6 +98,11 @@ type accountsStatements struct {
 	selectAccountStmt       *sql.Stmt
 	selectActualCountStmt   *sql.Stmt
 	updateAccountStmt       *sql.Stmt
+	selectPasswordHashStmt  *sql.Stmt
+	updatePasswordStmt      *sql.Stmt
+	deactivateAccountStmt   *sql.Stmt
+	selectAccountsStmt      *sql.Stmt
+	updateSuspendedStmt     *sql.Stmt
 }
 
 func (s *accountsStatements) getSchema() string {
@@ -92,6 +126,21 @@ func (s *accountsStatements) prepare(d *Database) (err error) {
 	if s.updateAccountStmt, err = d.db.Prepare(updateAccountSQL); err != nil {
 		return
 	}
+	if s.selectPasswordHashStmt, err = d.db.Prepare(selectPasswordHashSQL); err != nil {
+		return
+	}
+	if s.updatePasswordStmt, err = d.db.Prepare(updatePasswordSQL); err != nil {
+		return
+	}
+	if s.deactivateAccountStmt, err = d.db.Prepare(deactivateAccountSQL); err != nil {
+		return
+	}
+	if s.selectAccountsStmt, err = d.db.Prepare(selectAccountsSQL); err != nil {
+		return
+	}
+	if s.updateSuspendedStmt, err = d.db.Prepare(updateSuspendedSQL); err != nil {
+		return
+	}
 	return
 }
 
@@ -104,7 +153,7 @@ func (s *accountsStatements) selectAccount(ctx context.Context, userID string) (
 	var account authtypes.Account
 	defer rows.Close()
 	for rows.Next() {
-		if err := rows.Scan(&account.UserID, &account.AppServiceID); err != nil {
+		if err := rows.Scan(&account.UserID, &account.AppServiceID, &account.Deactivated, &account.Suspended); err != nil {
 			return nil, err
 		}
 	}
@@ -160,6 +209,62 @@ func (s *accountsStatements) updateAccount(
 	return err
 }
 
+// selectPasswordHash returns the password hash of an active account, it is
+// empty for passwordless accounts and sql.ErrNoRows for missing ones
+func (s *accountsStatements) selectPasswordHash(
+	ctx context.Context, userID string,
+) (hash string, err error) {
+	err = s.selectPasswordHashStmt.QueryRowContext(ctx, userID).Scan(&hash)
+	return
+}
+
+func (s *accountsStatements) updatePassword(
+	ctx context.Context, userID, hash string,
+) (err error) {
+	_, err = s.updatePasswordStmt.ExecContext(ctx, hash, userID)
+	return err
+}
+
+// selectAccounts returns the accounts ordered by user id after from, pattern
+// is a LIKE pattern of the user id or empty for all the accounts
+func (s *accountsStatements) selectAccounts(
+	ctx context.Context, pattern, from string, limit int,
+) ([]authtypes.Account, error) {
+	rows, err := s.selectAccountsStmt.QueryContext(ctx, pattern, from, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := []authtypes.Account{}
+	for rows.Next() {
+		var account authtypes.Account
+		var appServiceID sql.NullString
+		if err := rows.Scan(
+			&account.UserID, &account.CreatedTs, &appServiceID, &account.Deactivated, &account.Suspended,
+		); err != nil {
+			return nil, err
+		}
+		account.AppServiceID = appServiceID.String
+		accounts = append(accounts, account)
+	}
+	return accounts, rows.Err()
+}
+
+func (s *accountsStatements) updateSuspended(
+	ctx context.Context, userID string, suspended bool,
+) (err error) {
+	_, err = s.updateSuspendedStmt.ExecContext(ctx, suspended, userID)
+	return err
+}
+
+func (s *accountsStatements) deactivateAccount(
+	ctx context.Context, userID string,
+) (err error) {
+	_, err = s.deactivateAccountStmt.ExecContext(ctx, userID)
+	return err
+}
+
 func (s *accountsStatements) onInsertAccount(
 	ctx context.Context, userID, hash, appServiceID string, createdTs int64,
 ) error {
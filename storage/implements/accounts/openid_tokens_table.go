@@ -0,0 +1,94 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package accounts
+
+import (
+	"context"
+	"database/sql"
+)
+
+const openIDTokensSchema = `
+-- Stores the OpenID tokens handed out to users, they are only used to
+-- prove the identity of a user to a third party.
+CREATE TABLE IF NOT EXISTS account_openid_tokens (
+    token TEXT NOT NULL PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    -- When the token expires, as a unix timestamp (ms resolution).
+    expires_ts BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS account_openid_tokens_expires_ts_idx ON account_openid_tokens(expires_ts);
+`
+
+const insertOpenIDTokenSQL = "" +
+	"INSERT INTO account_openid_tokens(token, user_id, expires_ts) VALUES ($1, $2, $3)"
+
+const selectOpenIDTokenSQL = "" +
+	"SELECT user_id FROM account_openid_tokens WHERE token = $1 AND expires_ts > $2"
+
+const deleteExpiredOpenIDTokensSQL = "" +
+	"DELETE FROM account_openid_tokens WHERE expires_ts <= $1"
+
+type openIDTokensStatements struct {
+	db                            *Database
+	insertOpenIDTokenStmt         *sql.Stmt
+	selectOpenIDTokenStmt         *sql.Stmt
+	deleteExpiredOpenIDTokensStmt *sql.Stmt
+}
+
+func (s *openIDTokensStatements) getSchema() string {
+	return openIDTokensSchema
+}
+
+func (s *openIDTokensStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertOpenIDTokenStmt, err = d.db.Prepare(insertOpenIDTokenSQL); err != nil {
+		return
+	}
+	if s.selectOpenIDTokenStmt, err = d.db.Prepare(selectOpenIDTokenSQL); err != nil {
+		return
+	}
+	if s.deleteExpiredOpenIDTokensStmt, err = d.db.Prepare(deleteExpiredOpenIDTokensSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *openIDTokensStatements) insertOpenIDToken(
+	ctx context.Context, token, userID string, expiresTs int64,
+) error {
+	_, err := s.insertOpenIDTokenStmt.ExecContext(ctx, token, userID, expiresTs)
+	return err
+}
+
+// selectOpenIDToken returns the user of a token not expired at nowTs, it is
+// empty when there is no such token
+func (s *openIDTokensStatements) selectOpenIDToken(
+	ctx context.Context, token string, nowTs int64,
+) (string, error) {
+	var userID string
+	err := s.selectOpenIDTokenStmt.QueryRowContext(ctx, token, nowTs).Scan(&userID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return userID, err
+}
+
+func (s *openIDTokensStatements) deleteExpiredOpenIDTokens(
+	ctx context.Context, nowTs int64,
+) error {
+	_, err := s.deleteExpiredOpenIDTokensStmt.ExecContext(ctx, nowTs)
+	return err
+}
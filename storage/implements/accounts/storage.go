@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
@@ -48,6 +49,7 @@ type Database struct {
 	filter      filterStatements
 	tags        roomTagsStatements
 	userInfo    userInfoStatements
+	openIDs     openIDTokensStatements
 	AsyncSave   bool
 
 	qryDBGauge mon.LabeledGauge
@@ -69,7 +71,7 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	acc.db.SetMaxIdleConns(30)
 	acc.db.SetConnMaxLifetime(time.Minute * 3)
 
-	schemas := []string{acc.accounts.getSchema(), acc.profiles.getSchema(), acc.accountData.getSchema(), acc.filter.getSchema(), acc.tags.getSchema(), acc.userInfo.getSchema()}
+	schemas := []string{acc.accounts.getSchema(), acc.profiles.getSchema(), acc.accountData.getSchema(), acc.filter.getSchema(), acc.tags.getSchema(), acc.userInfo.getSchema(), acc.openIDs.getSchema()}
 	for _, sqlStr := range schemas {
 		_, err := acc.db.Exec(sqlStr)
 		if err != nil {
@@ -77,6 +79,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("accounts", driver, createAddr, acc.db); err != nil {
+		return nil, err
+	}
+
 	if err = acc.accounts.prepare(acc); err != nil {
 		return nil, err
 	}
@@ -95,6 +101,9 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	if err = acc.userInfo.prepare(acc); err != nil {
 		return nil, err
 	}
+	if err = acc.openIDs.prepare(acc); err != nil {
+		return nil, err
+	}
 
 	acc.AsyncSave = useAsync
 	acc.underlying = underlying
@@ -259,6 +268,78 @@ func (d *Database) InsertAccount(
 	return d.accounts.insertAccount(ctx, userID, hash, appServiceID)
 }
 
+// GetAccountByPassword returns the account if the password matches the one
+// stored, passwordless and deactivated accounts never match
+func (d *Database) GetAccountByPassword(
+	ctx context.Context, userID, plaintextPassword string,
+) (*authtypes.Account, error) {
+	hash, err := d.accounts.selectPasswordHash(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if hash == "" {
+		return nil, bcrypt.ErrMismatchedHashAndPassword
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(plaintextPassword)); err != nil {
+		return nil, err
+	}
+	return d.accounts.selectAccount(ctx, userID)
+}
+
+func (d *Database) SetPassword(
+	ctx context.Context, userID, plaintextPassword string,
+) error {
+	hash, err := hashPassword(plaintextPassword)
+	if err != nil {
+		return err
+	}
+	return d.accounts.updatePassword(ctx, userID, hash)
+}
+
+// DeactivateAccount marks the account unusable and drops its password
+func (d *Database) DeactivateAccount(
+	ctx context.Context, userID string,
+) error {
+	return d.accounts.deactivateAccount(ctx, userID)
+}
+
+// GetAccounts returns a page of the accounts ordered by user id, search
+// matches a part of the user id
+func (d *Database) GetAccounts(
+	ctx context.Context, search, from string, limit int,
+) ([]authtypes.Account, error) {
+	pattern := ""
+	if search != "" {
+		pattern = "%" + search + "%"
+	}
+	return d.accounts.selectAccounts(ctx, pattern, from, limit)
+}
+
+func (d *Database) SetAccountSuspended(
+	ctx context.Context, userID string, suspended bool,
+) error {
+	return d.accounts.updateSuspended(ctx, userID, suspended)
+}
+
+// InsertOpenIDToken stores an OpenID token of the user, the expired tokens
+// are dropped on the way
+func (d *Database) InsertOpenIDToken(
+	ctx context.Context, token, userID string, expiresTs int64,
+) error {
+	if err := d.openIDs.deleteExpiredOpenIDTokens(ctx, time.Now().UnixNano()/1000000); err != nil {
+		log.Warnf("delete expired openid tokens error %v", err)
+	}
+	return d.openIDs.insertOpenIDToken(ctx, token, userID, expiresTs)
+}
+
+// GetOpenIDTokenUser returns the user of an OpenID token, it is empty when
+// the token is unknown or expired
+func (d *Database) GetOpenIDTokenUser(
+	ctx context.Context, token string,
+) (string, error) {
+	return d.openIDs.selectOpenIDToken(ctx, token, time.Now().UnixNano()/1000000)
+}
+
 func hashPassword(plaintext string) (hash string, err error) {
 	hashBytes, err := bcrypt.GenerateFromPassword([]byte(plaintext), bcrypt.DefaultCost)
 	return string(hashBytes), err
@@ -119,12 +119,11 @@ type eventsStatements struct {
 	selectEncMsgEventsCountStmt            *sql.Stmt
 }
 
-func (s *eventsStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(appserviceEventsSchema)
-	if err != nil {
-		return
-	}
+func (s *eventsStatements) getSchema() string {
+	return appserviceEventsSchema
+}
 
+func (s *eventsStatements) prepare(db *sql.DB) (err error) {
 	if s.selectEventsByApplicationServiceIDStmt, err = db.Prepare(selectEventsByApplicationServiceIDSQL); err != nil {
 		return
 	}
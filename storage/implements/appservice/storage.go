@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 )
@@ -58,6 +59,18 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	result.topic = topic
 	result.underlying = underlying
 
+	schemas := []string{result.events.getSchema(), result.txnID.getSchema()}
+	for _, sqlStr := range schemas {
+		_, err := result.db.Exec(sqlStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = migration.Apply("appservice", driver, createAddr, result.db); err != nil {
+		return nil, err
+	}
+
 	if err = result.prepare(); err != nil {
 		return nil, err
 	}
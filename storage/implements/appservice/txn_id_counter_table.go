@@ -33,12 +33,11 @@ type txnStatements struct {
 	selectTxnIDStmt *sql.Stmt
 }
 
-func (s *txnStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(txnIDSchema)
-	if err != nil {
-		return
-	}
+func (s *txnStatements) getSchema() string {
+	return txnIDSchema
+}
 
+func (s *txnStatements) prepare(db *sql.DB) (err error) {
 	if s.selectTxnIDStmt, err = db.Prepare(selectTxnIDSQL); err != nil {
 		return
 	}
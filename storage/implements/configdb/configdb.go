@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 )
 
@@ -61,6 +62,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("server_conf", driver, createAddr, dataBase.db); err != nil {
+		return nil, err
+	}
+
 	if err = dataBase.statements.prepare(dataBase); err != nil {
 		return nil, err
 	}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	"github.com/finogeeks/ligase/skunkworks/log"
 )
@@ -80,6 +81,9 @@ const selectUnActiveDeviceSQL = "" +
 const checkDeviceSQL = "" +
 	"SELECT device_id, device_type, created_ts FROM device_devices WHERE identifier = $1 AND user_id = $2"
 
+const selectUserDevicesSQL = "" +
+	"SELECT device_id, display_name, device_type, identifier, created_ts FROM device_devices WHERE user_id = $1 AND (device_type = 'actual' OR device_type = 'bot')"
+
 type devicesStatements struct {
 	db                       *Database
 	upsertDeviceStmt         *sql.Stmt
@@ -90,6 +94,7 @@ type devicesStatements struct {
 	updateDeviceTsStmt       *sql.Stmt
 	selectUnActiveDeviceStmt *sql.Stmt
 	CheckDeviceStmt          *sql.Stmt
+	selectUserDevicesStmt    *sql.Stmt
 }
 
 func (s *devicesStatements) getSchema() string {
@@ -122,6 +127,9 @@ func (s *devicesStatements) prepare(d *Database) (err error) {
 	if s.CheckDeviceStmt, err = d.db.Prepare(checkDeviceSQL); err != nil {
 		return
 	}
+	if s.selectUserDevicesStmt, err = d.db.Prepare(selectUserDevicesSQL); err != nil {
+		return
+	}
 	return
 }
 
@@ -351,3 +359,24 @@ func (s *devicesStatements) checkDevice(
 	err = s.CheckDeviceStmt.QueryRowContext(ctx, identifier, userID).Scan(&deviceID, &deviceType, &ts)
 	return
 }
+
+func (s *devicesStatements) selectUserDevices(
+	ctx context.Context, userID string,
+) ([]authtypes.Device, error) {
+	rows, err := s.selectUserDevicesStmt.QueryContext(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	devices := []authtypes.Device{}
+	for rows.Next() {
+		var displayName sql.NullString
+		dev := authtypes.Device{UserID: userID}
+		if err = rows.Scan(&dev.ID, &displayName, &dev.DeviceType, &dev.Identifier, &dev.CreateTs); err != nil {
+			return nil, err
+		}
+		dev.DisplayName = displayName.String
+		devices = append(devices, dev)
+	}
+	return devices, rows.Err()
+}
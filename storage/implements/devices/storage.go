@@ -24,6 +24,7 @@ import (
 
 	"github.com/finogeeks/ligase/common"
 	"github.com/finogeeks/ligase/common/filter"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/authtypes"
 	"github.com/finogeeks/ligase/model/dbtypes"
@@ -70,6 +71,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("devices", driver, createAddr, dataBase.db); err != nil {
+		return nil, err
+	}
+
 	if err = dataBase.devices.prepare(dataBase); err != nil {
 		return nil, err
 	}
@@ -250,6 +255,13 @@ func (d *Database) CheckDevice(
 	return d.devices.checkDevice(ctx, identifier, userID)
 }
 
+// GetUserDevices returns the actual and bot devices of the user
+func (d *Database) GetUserDevices(
+	ctx context.Context, userID string,
+) ([]authtypes.Device, error) {
+	return d.devices.selectUserDevices(ctx, userID)
+}
+
 func (d *Database) LoadSimpleFilterData(ctx context.Context, f *filter.SimpleFilter) bool {
 	offset := 0
 	finish := false
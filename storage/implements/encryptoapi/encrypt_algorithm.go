@@ -66,12 +66,12 @@ type alStatements struct {
 	deleteMacAlStmt *sql.Stmt
 }
 
+func (s *alStatements) getSchema() string {
+	return algorithmSchema
+}
+
 func (s *alStatements) prepare(d *Database) (err error) {
 	s.db = d
-	_, err = d.db.Exec(algorithmSchema)
-	if err != nil {
-		return
-	}
 	if s.insertAlStmt, err = d.db.Prepare(insertAlSQL); err != nil {
 		return
 	}
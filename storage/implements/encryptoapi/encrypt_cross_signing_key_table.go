@@ -0,0 +1,146 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package encryptoapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const crossSigningKeySchema = `
+-- Stores the cross-signing keys of the users, key_type is one of master,
+-- self_signing and user_signing and key_info the key json.
+CREATE TABLE IF NOT EXISTS encrypt_cross_signing_key (
+    user_id TEXT NOT NULL,
+    key_type TEXT NOT NULL,
+    key_info TEXT NOT NULL,
+    CONSTRAINT encrypt_cross_signing_key_unique UNIQUE (user_id, key_type)
+);
+`
+
+const insertCrossSigningKeySQL = `
+INSERT INTO encrypt_cross_signing_key (user_id, key_type, key_info)
+VALUES ($1, $2, $3) ON CONFLICT ON CONSTRAINT encrypt_cross_signing_key_unique
+DO UPDATE SET key_info = EXCLUDED.key_info
+`
+
+const recoverCrossSigningKeysSQL = `
+SELECT user_id, key_type, key_info FROM encrypt_cross_signing_key ORDER BY user_id, key_type limit $1 offset $2
+`
+
+type crossSigningKeyStatements struct {
+	db                         *Database
+	insertCrossSigningKeyStmt  *sql.Stmt
+	recoverCrossSigningKeyStmt *sql.Stmt
+}
+
+func (s *crossSigningKeyStatements) getSchema() string {
+	return crossSigningKeySchema
+}
+
+func (s *crossSigningKeyStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertCrossSigningKeyStmt, err = d.db.Prepare(insertCrossSigningKeySQL); err != nil {
+		return
+	}
+	if s.recoverCrossSigningKeyStmt, err = d.db.Prepare(recoverCrossSigningKeysSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *crossSigningKeyStatements) recoverCrossSigningKey(ctx context.Context) error {
+	limit := 1000
+	offset := 0
+	exists := true
+	for exists {
+		exists = false
+		rows, err := s.recoverCrossSigningKeyStmt.QueryContext(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		offset = offset + limit
+		exists, err = s.processRecover(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *crossSigningKeyStatements) processRecover(ctx context.Context, rows *sql.Rows) (exists bool, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		exists = true
+		var keyInsert dbtypes.CrossSigningKeyInsert
+		if err1 := rows.Scan(&keyInsert.UserID, &keyInsert.KeyType, &keyInsert.KeyInfo); err1 != nil {
+			log.Errorf("load cross signing key error: %v", err1)
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.CrossSigningKeyInsertKey
+		update.IsRecovery = true
+		update.E2EDBEvents.CrossSigningKeyInsert = &keyInsert
+		update.SetUid(int64(common.CalcStringHashCode64(keyInsert.UserID)))
+		err2 := s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_cross_signing_key")
+		if err2 != nil {
+			log.Errorf("update cross signing key cache error: %v", err2)
+			if err == nil {
+				err = err2
+			}
+			continue
+		}
+	}
+	return
+}
+
+func (s *crossSigningKeyStatements) insertCrossSigningKey(
+	ctx context.Context,
+	userID, keyType, keyInfo string,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.CrossSigningKeyInsertKey
+		update.E2EDBEvents.CrossSigningKeyInsert = &dbtypes.CrossSigningKeyInsert{
+			UserID:  userID,
+			KeyType: keyType,
+			KeyInfo: keyInfo,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(userID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_cross_signing_key")
+	} else {
+		_, err := s.insertCrossSigningKeyStmt.ExecContext(ctx, userID, keyType, keyInfo)
+		return err
+	}
+}
+
+func (s *crossSigningKeyStatements) onInsertCrossSigningKey(
+	ctx context.Context,
+	userID, keyType, keyInfo string,
+) error {
+	_, err := s.insertCrossSigningKeyStmt.ExecContext(ctx, userID, keyType, keyInfo)
+	return err
+}
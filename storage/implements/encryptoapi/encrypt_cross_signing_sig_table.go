@@ -0,0 +1,153 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package encryptoapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const crossSigningSigSchema = `
+-- Stores the signatures made with cross-signing and device keys, the target
+-- key is a device id for device keys and the key id for master keys.
+CREATE TABLE IF NOT EXISTS encrypt_cross_signing_sig (
+    origin_user_id TEXT NOT NULL,
+    origin_key_id TEXT NOT NULL,
+    target_user_id TEXT NOT NULL,
+    target_key_id TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    CONSTRAINT encrypt_cross_signing_sig_unique UNIQUE (origin_user_id, origin_key_id, target_user_id, target_key_id)
+);
+
+CREATE INDEX IF NOT EXISTS encrypt_cross_signing_sig_target ON encrypt_cross_signing_sig(target_user_id, target_key_id);
+`
+
+const insertCrossSigningSigSQL = `
+INSERT INTO encrypt_cross_signing_sig (origin_user_id, origin_key_id, target_user_id, target_key_id, signature)
+VALUES ($1, $2, $3, $4, $5) ON CONFLICT ON CONSTRAINT encrypt_cross_signing_sig_unique
+DO UPDATE SET signature = EXCLUDED.signature
+`
+
+const recoverCrossSigningSigsSQL = `
+SELECT origin_user_id, origin_key_id, target_user_id, target_key_id, signature FROM encrypt_cross_signing_sig
+ORDER BY target_user_id, target_key_id, origin_user_id, origin_key_id limit $1 offset $2
+`
+
+type crossSigningSigStatements struct {
+	db                         *Database
+	insertCrossSigningSigStmt  *sql.Stmt
+	recoverCrossSigningSigStmt *sql.Stmt
+}
+
+func (s *crossSigningSigStatements) getSchema() string {
+	return crossSigningSigSchema
+}
+
+func (s *crossSigningSigStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertCrossSigningSigStmt, err = d.db.Prepare(insertCrossSigningSigSQL); err != nil {
+		return
+	}
+	if s.recoverCrossSigningSigStmt, err = d.db.Prepare(recoverCrossSigningSigsSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *crossSigningSigStatements) recoverCrossSigningSig(ctx context.Context) error {
+	limit := 1000
+	offset := 0
+	exists := true
+	for exists {
+		exists = false
+		rows, err := s.recoverCrossSigningSigStmt.QueryContext(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		offset = offset + limit
+		exists, err = s.processRecover(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *crossSigningSigStatements) processRecover(ctx context.Context, rows *sql.Rows) (exists bool, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		exists = true
+		var sigInsert dbtypes.CrossSigningSigInsert
+		if err1 := rows.Scan(&sigInsert.OriginUserID, &sigInsert.OriginKeyID, &sigInsert.TargetUserID, &sigInsert.TargetKeyID, &sigInsert.Signature); err1 != nil {
+			log.Errorf("load cross signing signature error: %v", err1)
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.CrossSigningSigInsertKey
+		update.IsRecovery = true
+		update.E2EDBEvents.CrossSigningSigInsert = &sigInsert
+		update.SetUid(int64(common.CalcStringHashCode64(sigInsert.TargetUserID)))
+		err2 := s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_cross_signing_sig")
+		if err2 != nil {
+			log.Errorf("update cross signing signature cache error: %v", err2)
+			if err == nil {
+				err = err2
+			}
+			continue
+		}
+	}
+	return
+}
+
+func (s *crossSigningSigStatements) insertCrossSigningSig(
+	ctx context.Context,
+	originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.CrossSigningSigInsertKey
+		update.E2EDBEvents.CrossSigningSigInsert = &dbtypes.CrossSigningSigInsert{
+			OriginUserID: originUserID,
+			OriginKeyID:  originKeyID,
+			TargetUserID: targetUserID,
+			TargetKeyID:  targetKeyID,
+			Signature:    signature,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(targetUserID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_cross_signing_sig")
+	} else {
+		_, err := s.insertCrossSigningSigStmt.ExecContext(ctx, originUserID, originKeyID, targetUserID, targetKeyID, signature)
+		return err
+	}
+}
+
+func (s *crossSigningSigStatements) onInsertCrossSigningSig(
+	ctx context.Context,
+	originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+) error {
+	_, err := s.insertCrossSigningSigStmt.ExecContext(ctx, originUserID, originKeyID, targetUserID, targetKeyID, signature)
+	return err
+}
@@ -68,12 +68,12 @@ type deviceKeyStatements struct {
 	deleteMacDeviceKeyStmt *sql.Stmt
 }
 
+func (s *deviceKeyStatements) getSchema() string {
+	return deviceKeySchema
+}
+
 func (s *deviceKeyStatements) prepare(d *Database) (err error) {
 	s.db = d
-	_, err = d.db.Exec(deviceKeySchema)
-	if err != nil {
-		return
-	}
 	if s.insertDeviceKeyStmt, err = d.db.Prepare(insertDeviceKeySQL); err != nil {
 		return
 	}
@@ -0,0 +1,162 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package encryptoapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const fallbackKeySchema = `
+-- Stores the fallback key of a device per algorithm, claims hand it out once
+-- the one time keys of the device run out. It is kept after use and only
+-- replaced when the device uploads a new one.
+CREATE TABLE IF NOT EXISTS encrypt_fallback_key (
+    device_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    key_id TEXT NOT NULL,
+    key_info TEXT NOT NULL,
+    algorithm TEXT NOT NULL,
+    signature TEXT NOT NULL,
+    identifier TEXT NOT NULL DEFAULT '',
+    used BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT encrypt_fallback_key_unique UNIQUE (device_id, user_id, algorithm)
+);
+
+CREATE INDEX IF NOT EXISTS encrypt_fallback_key_user_id ON encrypt_fallback_key(user_id);
+`
+
+const insertFallbackKeySQL = `
+INSERT INTO encrypt_fallback_key (device_id, user_id, key_id, key_info, algorithm, signature, identifier, used)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT ON CONSTRAINT encrypt_fallback_key_unique
+DO UPDATE SET key_id = EXCLUDED.key_id, key_info = EXCLUDED.key_info, signature = EXCLUDED.signature,
+identifier = EXCLUDED.identifier, used = EXCLUDED.used
+`
+
+const recoverFallbackKeySQL = `
+SELECT device_id, user_id, key_id, key_info, algorithm, signature, identifier, used FROM encrypt_fallback_key
+ORDER BY user_id, device_id, algorithm limit $1 offset $2
+`
+
+type fallbackKeyStatements struct {
+	db                     *Database
+	insertFallbackKeyStmt  *sql.Stmt
+	recoverFallbackKeyStmt *sql.Stmt
+}
+
+func (s *fallbackKeyStatements) getSchema() string {
+	return fallbackKeySchema
+}
+
+func (s *fallbackKeyStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertFallbackKeyStmt, err = d.db.Prepare(insertFallbackKeySQL); err != nil {
+		return
+	}
+	if s.recoverFallbackKeyStmt, err = d.db.Prepare(recoverFallbackKeySQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *fallbackKeyStatements) recoverFallbackKey(ctx context.Context) error {
+	limit := 1000
+	offset := 0
+	exists := true
+	for exists {
+		exists = false
+		rows, err := s.recoverFallbackKeyStmt.QueryContext(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		offset = offset + limit
+		exists, err = s.processRecover(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *fallbackKeyStatements) processRecover(ctx context.Context, rows *sql.Rows) (exists bool, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		exists = true
+		var keyInsert dbtypes.FallbackKeyInsert
+		if err1 := rows.Scan(&keyInsert.DeviceID, &keyInsert.UserID, &keyInsert.KeyID, &keyInsert.KeyInfo,
+			&keyInsert.Algorithm, &keyInsert.Signature, &keyInsert.Identifier, &keyInsert.Used); err1 != nil {
+			log.Errorf("load fallback key error: %v", err1)
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.FallbackKeyInsertKey
+		update.IsRecovery = true
+		update.E2EDBEvents.FallbackKeyInsert = &keyInsert
+		update.SetUid(int64(common.CalcStringHashCode64(keyInsert.UserID)))
+		err2 := s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_fallback_key")
+		if err2 != nil {
+			log.Errorf("update fallback key cache error: %v", err2)
+			if err == nil {
+				err = err2
+			}
+			continue
+		}
+	}
+	return
+}
+
+func (s *fallbackKeyStatements) insertFallbackKey(
+	ctx context.Context,
+	deviceID, userID, keyID, keyInfo, algorithm, signature, identifier string, used bool,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.FallbackKeyInsertKey
+		update.E2EDBEvents.FallbackKeyInsert = &dbtypes.FallbackKeyInsert{
+			DeviceID:   deviceID,
+			UserID:     userID,
+			KeyID:      keyID,
+			KeyInfo:    keyInfo,
+			Algorithm:  algorithm,
+			Signature:  signature,
+			Identifier: identifier,
+			Used:       used,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(userID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_fallback_key")
+	} else {
+		_, err := s.insertFallbackKeyStmt.ExecContext(ctx, deviceID, userID, keyID, keyInfo, algorithm, signature, identifier, used)
+		return err
+	}
+}
+
+func (s *fallbackKeyStatements) onInsertFallbackKey(
+	ctx context.Context,
+	deviceID, userID, keyID, keyInfo, algorithm, signature, identifier string, used bool,
+) error {
+	_, err := s.insertFallbackKeyStmt.ExecContext(ctx, deviceID, userID, keyID, keyInfo, algorithm, signature, identifier, used)
+	return err
+}
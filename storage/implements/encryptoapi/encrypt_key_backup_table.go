@@ -0,0 +1,220 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package encryptoapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const keyBackupSchema = `
+-- Stores the room keys backed up by the users, session_data is the key json
+-- encrypted by the client with the algorithm of the backup version.
+CREATE TABLE IF NOT EXISTS encrypt_key_backup (
+    user_id TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    room_id TEXT NOT NULL,
+    session_id TEXT NOT NULL,
+    first_message_index BIGINT NOT NULL,
+    forwarded_count BIGINT NOT NULL,
+    is_verified BOOLEAN NOT NULL,
+    session_data TEXT NOT NULL,
+    CONSTRAINT encrypt_key_backup_unique UNIQUE (user_id, version, room_id, session_id)
+);
+`
+
+const insertKeyBackupSQL = `
+INSERT INTO encrypt_key_backup (user_id, version, room_id, session_id, first_message_index, forwarded_count, is_verified, session_data)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT ON CONSTRAINT encrypt_key_backup_unique
+DO UPDATE SET first_message_index = EXCLUDED.first_message_index, forwarded_count = EXCLUDED.forwarded_count,
+is_verified = EXCLUDED.is_verified, session_data = EXCLUDED.session_data
+`
+
+const deleteKeyBackupSQL = `
+DELETE FROM encrypt_key_backup WHERE user_id = $1 AND version = $2 AND room_id = $3 AND session_id = $4
+`
+
+const deleteRoomKeyBackupSQL = `
+DELETE FROM encrypt_key_backup WHERE user_id = $1 AND version = $2 AND room_id = $3
+`
+
+const deleteVersionKeyBackupSQL = `
+DELETE FROM encrypt_key_backup WHERE user_id = $1 AND version = $2
+`
+
+const recoverKeyBackupsSQL = `
+SELECT user_id, version, room_id, session_id, first_message_index, forwarded_count, is_verified, session_data
+FROM encrypt_key_backup ORDER BY user_id, version, room_id, session_id limit $1 offset $2
+`
+
+type keyBackupStatements struct {
+	db                         *Database
+	insertKeyBackupStmt        *sql.Stmt
+	deleteKeyBackupStmt        *sql.Stmt
+	deleteRoomKeyBackupStmt    *sql.Stmt
+	deleteVersionKeyBackupStmt *sql.Stmt
+	recoverKeyBackupStmt       *sql.Stmt
+}
+
+func (s *keyBackupStatements) getSchema() string {
+	return keyBackupSchema
+}
+
+func (s *keyBackupStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertKeyBackupStmt, err = d.db.Prepare(insertKeyBackupSQL); err != nil {
+		return
+	}
+	if s.deleteKeyBackupStmt, err = d.db.Prepare(deleteKeyBackupSQL); err != nil {
+		return
+	}
+	if s.deleteRoomKeyBackupStmt, err = d.db.Prepare(deleteRoomKeyBackupSQL); err != nil {
+		return
+	}
+	if s.deleteVersionKeyBackupStmt, err = d.db.Prepare(deleteVersionKeyBackupSQL); err != nil {
+		return
+	}
+	if s.recoverKeyBackupStmt, err = d.db.Prepare(recoverKeyBackupsSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *keyBackupStatements) recoverKeyBackup(ctx context.Context) error {
+	limit := 1000
+	offset := 0
+	exists := true
+	for exists {
+		exists = false
+		rows, err := s.recoverKeyBackupStmt.QueryContext(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		offset = offset + limit
+		exists, err = s.processRecover(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *keyBackupStatements) processRecover(ctx context.Context, rows *sql.Rows) (exists bool, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		exists = true
+		var keyInsert dbtypes.KeyBackupInsert
+		if err1 := rows.Scan(&keyInsert.UserID, &keyInsert.Version, &keyInsert.RoomID, &keyInsert.SessionID,
+			&keyInsert.FirstMessageIndex, &keyInsert.ForwardedCount, &keyInsert.IsVerified, &keyInsert.SessionData); err1 != nil {
+			log.Errorf("load key backup error: %v", err1)
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.KeyBackupInsertKey
+		update.IsRecovery = true
+		update.E2EDBEvents.KeyBackupInsert = &keyInsert
+		update.SetUid(int64(common.CalcStringHashCode64(keyInsert.UserID)))
+		err2 := s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_key_backup")
+		if err2 != nil {
+			log.Errorf("update key backup cache error: %v", err2)
+			if err == nil {
+				err = err2
+			}
+			continue
+		}
+	}
+	return
+}
+
+func (s *keyBackupStatements) insertKeyBackup(
+	ctx context.Context,
+	userID string, version int64, roomID, sessionID string,
+	firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.KeyBackupInsertKey
+		update.E2EDBEvents.KeyBackupInsert = &dbtypes.KeyBackupInsert{
+			UserID:            userID,
+			Version:           version,
+			RoomID:            roomID,
+			SessionID:         sessionID,
+			FirstMessageIndex: firstMessageIndex,
+			ForwardedCount:    forwardedCount,
+			IsVerified:        isVerified,
+			SessionData:       sessionData,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(userID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_key_backup")
+	} else {
+		return s.onInsertKeyBackup(ctx, userID, version, roomID, sessionID, firstMessageIndex, forwardedCount, isVerified, sessionData)
+	}
+}
+
+func (s *keyBackupStatements) onInsertKeyBackup(
+	ctx context.Context,
+	userID string, version int64, roomID, sessionID string,
+	firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+) error {
+	_, err := s.insertKeyBackupStmt.ExecContext(ctx, userID, version, roomID, sessionID, firstMessageIndex, forwardedCount, isVerified, sessionData)
+	return err
+}
+
+func (s *keyBackupStatements) deleteKeyBackup(
+	ctx context.Context,
+	userID string, version int64, roomID, sessionID string,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.KeyBackupDeleteKey
+		update.E2EDBEvents.KeyBackupDelete = &dbtypes.KeyBackupDelete{
+			UserID:    userID,
+			Version:   version,
+			RoomID:    roomID,
+			SessionID: sessionID,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(userID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_key_backup")
+	} else {
+		return s.onDeleteKeyBackup(ctx, userID, version, roomID, sessionID)
+	}
+}
+
+func (s *keyBackupStatements) onDeleteKeyBackup(
+	ctx context.Context,
+	userID string, version int64, roomID, sessionID string,
+) (err error) {
+	switch {
+	case roomID == "":
+		_, err = s.deleteVersionKeyBackupStmt.ExecContext(ctx, userID, version)
+	case sessionID == "":
+		_, err = s.deleteRoomKeyBackupStmt.ExecContext(ctx, userID, version, roomID)
+	default:
+		_, err = s.deleteKeyBackupStmt.ExecContext(ctx, userID, version, roomID, sessionID)
+	}
+	return
+}
@@ -0,0 +1,155 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package encryptoapi
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/model/dbtypes"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const keyBackupVersionSchema = `
+-- Stores the versions of the room key backups of the users, auth_data is the
+-- json the client needs to restore the backup. Deleted versions are kept so
+-- their number isn't handed out again.
+CREATE TABLE IF NOT EXISTS encrypt_key_backup_version (
+    user_id TEXT NOT NULL,
+    version BIGINT NOT NULL,
+    algorithm TEXT NOT NULL,
+    auth_data TEXT NOT NULL,
+    etag BIGINT NOT NULL DEFAULT 0,
+    deleted BOOLEAN NOT NULL DEFAULT FALSE,
+    CONSTRAINT encrypt_key_backup_version_unique UNIQUE (user_id, version)
+);
+`
+
+const insertKeyBackupVersionSQL = `
+INSERT INTO encrypt_key_backup_version (user_id, version, algorithm, auth_data, etag, deleted)
+VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT ON CONSTRAINT encrypt_key_backup_version_unique
+DO UPDATE SET algorithm = EXCLUDED.algorithm, auth_data = EXCLUDED.auth_data, etag = EXCLUDED.etag, deleted = EXCLUDED.deleted
+`
+
+const recoverKeyBackupVersionsSQL = `
+SELECT user_id, version, algorithm, auth_data, etag, deleted FROM encrypt_key_backup_version
+ORDER BY user_id, version limit $1 offset $2
+`
+
+type keyBackupVersionStatements struct {
+	db                          *Database
+	insertKeyBackupVersionStmt  *sql.Stmt
+	recoverKeyBackupVersionStmt *sql.Stmt
+}
+
+func (s *keyBackupVersionStatements) getSchema() string {
+	return keyBackupVersionSchema
+}
+
+func (s *keyBackupVersionStatements) prepare(d *Database) (err error) {
+	s.db = d
+	if s.insertKeyBackupVersionStmt, err = d.db.Prepare(insertKeyBackupVersionSQL); err != nil {
+		return
+	}
+	if s.recoverKeyBackupVersionStmt, err = d.db.Prepare(recoverKeyBackupVersionsSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *keyBackupVersionStatements) recoverKeyBackupVersion(ctx context.Context) error {
+	limit := 1000
+	offset := 0
+	exists := true
+	for exists {
+		exists = false
+		rows, err := s.recoverKeyBackupVersionStmt.QueryContext(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		offset = offset + limit
+		exists, err = s.processRecover(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *keyBackupVersionStatements) processRecover(ctx context.Context, rows *sql.Rows) (exists bool, err error) {
+	defer rows.Close()
+	for rows.Next() {
+		exists = true
+		var versionInsert dbtypes.KeyBackupVersionInsert
+		if err1 := rows.Scan(&versionInsert.UserID, &versionInsert.Version, &versionInsert.Algorithm,
+			&versionInsert.AuthData, &versionInsert.Etag, &versionInsert.Deleted); err1 != nil {
+			log.Errorf("load key backup version error: %v", err1)
+			if err == nil {
+				err = err1
+			}
+			continue
+		}
+
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.KeyBackupVersionInsertKey
+		update.IsRecovery = true
+		update.E2EDBEvents.KeyBackupVersionInsert = &versionInsert
+		update.SetUid(int64(common.CalcStringHashCode64(versionInsert.UserID)))
+		err2 := s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_key_backup_version")
+		if err2 != nil {
+			log.Errorf("update key backup version cache error: %v", err2)
+			if err == nil {
+				err = err2
+			}
+			continue
+		}
+	}
+	return
+}
+
+func (s *keyBackupVersionStatements) insertKeyBackupVersion(
+	ctx context.Context,
+	userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+) error {
+	if s.db.AsyncSave == true {
+		var update dbtypes.DBEvent
+		update.Category = dbtypes.CATEGORY_E2E_DB_EVENT
+		update.Key = dbtypes.KeyBackupVersionInsertKey
+		update.E2EDBEvents.KeyBackupVersionInsert = &dbtypes.KeyBackupVersionInsert{
+			UserID:    userID,
+			Version:   version,
+			Algorithm: algorithm,
+			AuthData:  authData,
+			Etag:      etag,
+			Deleted:   deleted,
+		}
+		update.SetUid(int64(common.CalcStringHashCode64(userID)))
+		return s.db.WriteDBEventWithTbl(ctx, &update, "encrypt_key_backup_version")
+	} else {
+		_, err := s.insertKeyBackupVersionStmt.ExecContext(ctx, userID, version, algorithm, authData, etag, deleted)
+		return err
+	}
+}
+
+func (s *keyBackupVersionStatements) onInsertKeyBackupVersion(
+	ctx context.Context,
+	userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+) error {
+	_, err := s.insertKeyBackupVersionStmt.ExecContext(ctx, userID, version, algorithm, authData, etag, deleted)
+	return err
+}
@@ -74,12 +74,12 @@ type oneTimeKeyStatements struct {
 	deleteMacOneTimeKeyStmt    *sql.Stmt
 }
 
+func (s *oneTimeKeyStatements) getSchema() string {
+	return oneTimeKeySchema
+}
+
 func (s *oneTimeKeyStatements) prepare(d *Database) (err error) {
 	s.db = d
-	_, err = d.db.Exec(oneTimeKeySchema)
-	if err != nil {
-		return
-	}
 	if s.insertOneTimeKeyStmt, err = d.db.Prepare(insertOneTimeKeySQL); err != nil {
 		return
 	}
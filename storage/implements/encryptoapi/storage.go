@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	log "github.com/finogeeks/ligase/skunkworks/log"
@@ -41,6 +42,11 @@ type Database struct {
 	deviceKeyStatements  deviceKeyStatements
 	oneTimeKeyStatements oneTimeKeyStatements
 	alStatements         alStatements
+	crossSigningKeys     crossSigningKeyStatements
+	crossSigningSigs     crossSigningSigStatements
+	keyBackupVersions    keyBackupVersionStatements
+	keyBackups           keyBackupStatements
+	fallbackKeys         fallbackKeyStatements
 	AsyncSave            bool
 
 	qryDBGauge mon.LabeledGauge
@@ -60,6 +66,23 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	dataBase.db.SetMaxIdleConns(30)
 	dataBase.db.SetConnMaxLifetime(time.Minute * 3)
 
+	schemas := []string{
+		dataBase.deviceKeyStatements.getSchema(), dataBase.oneTimeKeyStatements.getSchema(), dataBase.alStatements.getSchema(),
+		dataBase.crossSigningKeys.getSchema(), dataBase.crossSigningSigs.getSchema(),
+		dataBase.keyBackupVersions.getSchema(), dataBase.keyBackups.getSchema(),
+		dataBase.fallbackKeys.getSchema(),
+	}
+	for _, sqlStr := range schemas {
+		_, err := dataBase.db.Exec(sqlStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = migration.Apply("encryptoapi", driver, createAddr, dataBase.db); err != nil {
+		return nil, err
+	}
+
 	if err = dataBase.deviceKeyStatements.prepare(dataBase); err != nil {
 		return nil, err
 	}
@@ -69,6 +92,21 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	if err = dataBase.alStatements.prepare(dataBase); err != nil {
 		return nil, err
 	}
+	if err = dataBase.crossSigningKeys.prepare(dataBase); err != nil {
+		return nil, err
+	}
+	if err = dataBase.crossSigningSigs.prepare(dataBase); err != nil {
+		return nil, err
+	}
+	if err = dataBase.keyBackupVersions.prepare(dataBase); err != nil {
+		return nil, err
+	}
+	if err = dataBase.keyBackups.prepare(dataBase); err != nil {
+		return nil, err
+	}
+	if err = dataBase.fallbackKeys.prepare(dataBase); err != nil {
+		return nil, err
+	}
 
 	dataBase.AsyncSave = useAsync
 	dataBase.topic = topic
@@ -127,6 +165,31 @@ func (d *Database) RecoverCache() {
 		log.Errorf("alStatements.recoverAls error %v", err)
 	}
 
+	err = d.crossSigningKeys.recoverCrossSigningKey(ctx)
+	if err != nil {
+		log.Errorf("crossSigningKeys.recoverCrossSigningKey error %v", err)
+	}
+
+	err = d.crossSigningSigs.recoverCrossSigningSig(ctx)
+	if err != nil {
+		log.Errorf("crossSigningSigs.recoverCrossSigningSig error %v", err)
+	}
+
+	err = d.keyBackupVersions.recoverKeyBackupVersion(ctx)
+	if err != nil {
+		log.Errorf("keyBackupVersions.recoverKeyBackupVersion error %v", err)
+	}
+
+	err = d.keyBackups.recoverKeyBackup(ctx)
+	if err != nil {
+		log.Errorf("keyBackups.recoverKeyBackup error %v", err)
+	}
+
+	err = d.fallbackKeys.recoverFallbackKey(ctx)
+	if err != nil {
+		log.Errorf("fallbackKeys.recoverFallbackKey error %v", err)
+	}
+
 	log.Info("e2e db load finished")
 }
 
@@ -271,3 +334,88 @@ func (d *Database) DeleteDeviceOneTimeKey(
 ) error {
 	return d.oneTimeKeyStatements.deleteDeviceOneTimeKey(ctx, deviceID, userID)
 }
+
+// InsertCrossSigningKey persists a cross-signing key of the user, keyInfo
+// is the key json
+func (d *Database) InsertCrossSigningKey(
+	ctx context.Context, userID, keyType, keyInfo string,
+) error {
+	return d.crossSigningKeys.insertCrossSigningKey(ctx, userID, keyType, keyInfo)
+}
+
+func (d *Database) OnInsertCrossSigningKey(
+	ctx context.Context, userID, keyType, keyInfo string,
+) error {
+	return d.crossSigningKeys.onInsertCrossSigningKey(ctx, userID, keyType, keyInfo)
+}
+
+// InsertCrossSigningSig persists a signature made by the origin key over the
+// target key
+func (d *Database) InsertCrossSigningSig(
+	ctx context.Context, originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+) error {
+	return d.crossSigningSigs.insertCrossSigningSig(ctx, originUserID, originKeyID, targetUserID, targetKeyID, signature)
+}
+
+func (d *Database) OnInsertCrossSigningSig(
+	ctx context.Context, originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+) error {
+	return d.crossSigningSigs.onInsertCrossSigningSig(ctx, originUserID, originKeyID, targetUserID, targetKeyID, signature)
+}
+
+// InsertKeyBackupVersion persists a room key backup version of the user,
+// deleting a version only marks it deleted
+func (d *Database) InsertKeyBackupVersion(
+	ctx context.Context, userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+) error {
+	return d.keyBackupVersions.insertKeyBackupVersion(ctx, userID, version, algorithm, authData, etag, deleted)
+}
+
+func (d *Database) OnInsertKeyBackupVersion(
+	ctx context.Context, userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+) error {
+	return d.keyBackupVersions.onInsertKeyBackupVersion(ctx, userID, version, algorithm, authData, etag, deleted)
+}
+
+// InsertKeyBackup persists a backed up room key session
+func (d *Database) InsertKeyBackup(
+	ctx context.Context, userID string, version int64, roomID, sessionID string,
+	firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+) error {
+	return d.keyBackups.insertKeyBackup(ctx, userID, version, roomID, sessionID, firstMessageIndex, forwardedCount, isVerified, sessionData)
+}
+
+func (d *Database) OnInsertKeyBackup(
+	ctx context.Context, userID string, version int64, roomID, sessionID string,
+	firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+) error {
+	return d.keyBackups.onInsertKeyBackup(ctx, userID, version, roomID, sessionID, firstMessageIndex, forwardedCount, isVerified, sessionData)
+}
+
+// DeleteKeyBackup deletes a backed up session, all sessions of the room when
+// sessionID is empty and the whole backup when roomID is empty too
+func (d *Database) DeleteKeyBackup(
+	ctx context.Context, userID string, version int64, roomID, sessionID string,
+) error {
+	return d.keyBackups.deleteKeyBackup(ctx, userID, version, roomID, sessionID)
+}
+
+func (d *Database) OnDeleteKeyBackup(
+	ctx context.Context, userID string, version int64, roomID, sessionID string,
+) error {
+	return d.keyBackups.onDeleteKeyBackup(ctx, userID, version, roomID, sessionID)
+}
+
+// InsertFallbackKey replaces the fallback key of the device for the algorithm,
+// claiming it only marks it used
+func (d *Database) InsertFallbackKey(
+	ctx context.Context, deviceID, userID, keyID, keyInfo, al, sig, identifier string, used bool,
+) error {
+	return d.fallbackKeys.insertFallbackKey(ctx, deviceID, userID, keyID, keyInfo, al, sig, identifier, used)
+}
+
+func (d *Database) OnInsertFallbackKey(
+	ctx context.Context, deviceID, userID, keyID, keyInfo, al, sig, identifier string, used bool,
+) error {
+	return d.fallbackKeys.onInsertFallbackKey(ctx, deviceID, userID, keyID, keyInfo, al, sig, identifier, used)
+}
@@ -40,11 +40,11 @@ type certStatements struct {
 	updateCRLStmt      *sql.Stmt
 }
 
+func (s *certStatements) getSchema() string {
+	return CertSchema
+}
+
 func (s *certStatements) prepare(db *sql.DB) (err error) {
-	_, err = db.Exec(CertSchema)
-	if err != nil {
-		return err
-	}
 	if s.insertRootCAStmt, err = db.Prepare(insertRootCASQL); err != nil {
 		return
 	}
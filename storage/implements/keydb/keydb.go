@@ -25,6 +25,7 @@ import (
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 )
 
@@ -57,7 +58,15 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	db.SetConnMaxLifetime(time.Minute * 3)
 
 	d := new(Database)
-	if _, err = db.Exec(d.statements.getSchema()); err != nil {
+	schemas := []string{d.statements.getSchema(), d.cert.getSchema()}
+	for _, sqlStr := range schemas {
+		_, err := db.Exec(sqlStr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err = migration.Apply("serverkey", driver, createAddr, db); err != nil {
 		return nil, err
 	}
 
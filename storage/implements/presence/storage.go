@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	log "github.com/finogeeks/ligase/skunkworks/log"
@@ -62,6 +63,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("presence", driver, createAddr, dataBase.db); err != nil {
+		return nil, err
+	}
+
 	if err = dataBase.presence.prepare(dataBase); err != nil {
 		return nil, err
 	}
@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/dbtypes"
@@ -71,6 +72,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("publicroomapi", driver, createAddr, public.db); err != nil {
+		return nil, err
+	}
+
 	if err = public.statements.prepare(public); err != nil {
 		return nil, err
 	}
@@ -173,6 +178,10 @@ func (d *Database) UpdateRoomFromEvent(
 		attrName := "guest_can_join"
 		strForTrue := "can_join"
 		return d.updateBooleanAttribute(ctx, attrName, event, &content, field, strForTrue)
+	case "m.room.tombstone":
+		// an upgraded room leaves the directory, the replacement room copied
+		// its m.room.visibility and is listed instead
+		return d.statements.updateRoomAttribute(ctx, "visibility", false, event.RoomID)
 	}
 
 	// If the event type didn't match, return with no error
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	log "github.com/finogeeks/ligase/skunkworks/log"
@@ -64,6 +65,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("pushapi", driver, createAddr, d.db); err != nil {
+		return nil, err
+	}
+
 	if err = d.pushers.prepare(d); err != nil {
 		return nil, err
 	}
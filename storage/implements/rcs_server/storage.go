@@ -23,6 +23,7 @@ import (
 	"github.com/finogeeks/ligase/skunkworks/log"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/model/types"
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
@@ -60,6 +61,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("rcsserver", driver, createAddr, d.db); err != nil {
+		return nil, err
+	}
+
 	if err = d.statements.prepare(d.db, d); err != nil {
 		return nil, err
 	}
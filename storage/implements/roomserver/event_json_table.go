@@ -21,6 +21,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/finogeeks/ligase/common"
 	"github.com/finogeeks/ligase/common/encryption"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
@@ -91,6 +92,12 @@ const updateMsgEventSQL = "" +
 const selectRoomEventByNIDSQL = "" +
 	"SELECT event_json FROM roomserver_event_json WHERE event_nid = $1"
 
+const deleteEventJSONSQL = "" +
+	"DELETE FROM roomserver_event_json WHERE event_nid = ANY($1)"
+
+const deleteEventJSONMirrorSQL = "" +
+	"DELETE FROM roomserver_event_json_mirror WHERE event_nid = ANY($1)"
+
 type eventJSONStatements struct {
 	db                                 *Database
 	insertEventJSONStmt                *sql.Stmt
@@ -105,6 +112,8 @@ type eventJSONStatements struct {
 	selectMsgEventsCountStmt           *sql.Stmt
 	updateMsgEventStmt                 *sql.Stmt
 	selectRoomEventByNIDStmt           *sql.Stmt
+	deleteEventJSONStmt                *sql.Stmt
+	deleteEventJSONMirrorStmt          *sql.Stmt
 }
 
 func (s *eventJSONStatements) getSchema() string {
@@ -125,6 +134,8 @@ func (s *eventJSONStatements) prepare(db *sql.DB, d *Database) (err error) {
 		{&s.selectMsgEventsCountStmt, selectMsgEventsCountSQL},
 		{&s.updateMsgEventStmt, updateMsgEventSQL},
 		{&s.selectRoomEventByNIDStmt, selectRoomEventByNIDSQL},
+		{&s.deleteEventJSONStmt, deleteEventJSONSQL},
+		{&s.deleteEventJSONMirrorStmt, deleteEventJSONMirrorSQL},
 	}.prepare(db)
 }
 
@@ -319,3 +330,11 @@ func (s *eventJSONStatements) selectRoomEventByNID(ctx context.Context, eventNID
 	}
 	return nil, nil
 }
+
+func (s *eventJSONStatements) deleteEventJSON(ctx context.Context, txn *sql.Tx, eventNIDs []int64) error {
+	if _, err := common.TxStmt(txn, s.deleteEventJSONStmt).ExecContext(ctx, pq.Int64Array(eventNIDs)); err != nil {
+		return err
+	}
+	_, err := common.TxStmt(txn, s.deleteEventJSONMirrorStmt).ExecContext(ctx, pq.Int64Array(eventNIDs))
+	return err
+}
@@ -21,6 +21,7 @@ import (
 	"context"
 	"database/sql"
 
+	"github.com/finogeeks/ligase/common"
 	"github.com/finogeeks/ligase/model/dbtypes"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/lib/pq"
@@ -86,7 +87,7 @@ const selectEventNIDSQL = "SELECT event_nid FROM roomserver_events WHERE event_i
 
 const bulkSelectEventNIDSQL = "SELECT event_id, event_nid FROM roomserver_events WHERE event_id = ANY($1)"
 
-const selectRoomStateNIDSQL = "SELECT event_nid FROM roomserver_events WHERE room_nid = $1 and event_type_id=any('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.third_party_invite\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\"}') order by event_nid asc"
+const selectRoomStateNIDSQL = "SELECT event_nid FROM roomserver_events WHERE room_nid = $1 and event_type_id=any('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.third_party_invite\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\", \"m.room.retention\"}') order by event_nid asc"
 
 //backfill
 //const selectRoomBackfillNIDSQL = "SELECT event_nid FROM roomserver_events WHERE room_nid = $1 and domain = $2 and offsets< $3 and not (event_type_id=any('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.third_party_invite\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\"}')) order by event_nid desc limit $3"
@@ -101,7 +102,7 @@ const selectRoomEventsByDomainOffsetSQL = "SELECT event_nid FROM roomserver_even
 
 const selectEventStateSnapshotNIDSQL = "SELECT state_snapshot_nid FROM roomserver_events WHERE event_id = $1"
 
-const selectRoomStateNIDByStateBlockNIDSQL = "SELECT event_nid, event_type_id, event_state_key_id, domain FROM roomserver_events WHERE room_nid = $1 AND event_nid <= $2 AND event_type_id=ANY('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.third_party_invite\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\"}') ORDER BY event_nid ASC"
+const selectRoomStateNIDByStateBlockNIDSQL = "SELECT event_nid, event_type_id, event_state_key_id, domain FROM roomserver_events WHERE room_nid = $1 AND event_nid <= $2 AND event_type_id=ANY('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.third_party_invite\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\", \"m.room.retention\"}') ORDER BY event_nid ASC"
 
 //fix db
 const getLastConfirmEventSQL = "select event_nid, state_snapshot_nid, depth from roomserver_events where room_nid = $1 and state_snapshot_nid != 0 and sent_to_output=true order by event_nid desc limit 1"
@@ -123,6 +124,8 @@ const selectRoomEventByDepthSQL = "SELECT event_nid, event_id FROM roomserver_ev
 
 const selectRoomMaxDomainOffsetSQL = "SELECT t.domain, t.m, m.event_id FROM(SELECT MAX(offsets) AS m, domain FROM roomserver_events WHERE room_nid=$1 GROUP BY domain) t LEFT JOIN roomserver_events m ON room_nid=$1 AND t.domain=m.domain AND t.m=m.offsets"
 
+const deleteEventsSQL = "DELETE FROM roomserver_events WHERE event_nid = ANY($1)"
+
 type eventStatements struct {
 	db                                         *Database
 	insertEventStmt                            *sql.Stmt
@@ -147,6 +150,7 @@ type eventStatements struct {
 	updateRoomEventStmt                        *sql.Stmt
 	selectRoomEventByDepthStmt                 *sql.Stmt
 	selectRoomMaxDomainOffsetStmt              *sql.Stmt
+	deleteEventsStmt                           *sql.Stmt
 }
 
 func (s *eventStatements) getSchema() string {
@@ -179,6 +183,7 @@ func (s *eventStatements) prepare(db *sql.DB, d *Database) (err error) {
 		{&s.updateRoomEventStmt, updateRoomEventSQL},
 		{&s.selectRoomEventByDepthStmt, selectRoomEventByDepthSQL},
 		{&s.selectRoomMaxDomainOffsetStmt, selectRoomMaxDomainOffsetSQL},
+		{&s.deleteEventsStmt, deleteEventsSQL},
 	}.prepare(db)
 }
 
@@ -630,3 +635,8 @@ func (s *eventStatements) selectRoomStateNIDByStateBlockNID(ctx context.Context,
 	}
 	return eventNIDs, eventTypes, stateKeys, domains, nil
 }
+
+func (s *eventStatements) deleteEvents(ctx context.Context, txn *sql.Tx, eventNIDs []int64) error {
+	_, err := common.TxStmt(txn, s.deleteEventsStmt).ExecContext(ctx, pq.Int64Array(eventNIDs))
+	return err
+}
@@ -27,6 +27,7 @@ import (
 	// Import the postgres database driver.
 	"github.com/finogeeks/ligase/common"
 	"github.com/finogeeks/ligase/common/filter"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/common/utils"
 	"github.com/finogeeks/ligase/core"
@@ -90,6 +91,10 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		}
 	}
 
+	if err = migration.Apply("roomserver", driver, createAddr, d.db); err != nil {
+		return nil, err
+	}
+
 	if err = d.statements.prepare(d.db, d); err != nil {
 		return nil, err
 	}
@@ -441,6 +446,17 @@ func (d *Database) GetRoomStates(
 	return events, ids, err
 }
 
+// DeleteEvents removes events and their JSON, it is used to purge the
+// history of a room
+func (d *Database) DeleteEvents(ctx context.Context, eventNIDs []int64) error {
+	return common.WithTransaction(d.db, func(txn *sql.Tx) error {
+		if err := d.statements.deleteEventJSON(ctx, txn, eventNIDs); err != nil {
+			return err
+		}
+		return d.statements.deleteEvents(ctx, txn, eventNIDs)
+	})
+}
+
 func (d *Database) EventsCount(ctx context.Context) (count int, err error) {
 
 	count, err = d.statements.selectEventsTotal(ctx)
@@ -87,6 +87,9 @@ const updateRoomStateSQL = "" +
 const selectRoomStateByEventIDSQL = "" +
 	"SELECT event_json FROM syncapi_current_room_state WHERE event_id = $1"
 
+const selectRoomsStateByTypeSQL = "" +
+	"SELECT event_json FROM syncapi_current_room_state WHERE type = $1 AND state_key = ''"
+
 type currentRoomStateStatements struct {
 	db                              *Database
 	upsertRoomStateStmt             *sql.Stmt
@@ -98,6 +101,7 @@ type currentRoomStateStatements struct {
 	selectRoomStateCountStmt     *sql.Stmt
 	updateRoomStateStmt          *sql.Stmt
 	selectRoomStateByEventIDStmt *sql.Stmt
+	selectRoomsStateByTypeStmt   *sql.Stmt
 }
 
 func (s *currentRoomStateStatements) getSchema() string {
@@ -134,6 +138,9 @@ func (s *currentRoomStateStatements) prepare(db *sql.DB, d *Database) (err error
 	if s.selectRoomStateByEventIDStmt, err = db.Prepare(selectRoomStateByEventIDSQL); err != nil {
 		return
 	}
+	if s.selectRoomsStateByTypeStmt, err = db.Prepare(selectRoomsStateByTypeSQL); err != nil {
+		return
+	}
 	return
 }
 
@@ -405,3 +412,32 @@ func (s *currentRoomStateStatements) selectRoomStateByEventID(
 	}
 	return eventBytes, nil
 }
+
+// selectRoomsStateByType returns the current state event of the type with an
+// empty state key of every room which has one
+func (s *currentRoomStateStatements) selectRoomsStateByType(
+	ctx context.Context, eventType string,
+) ([]gomatrixserverlib.ClientEvent, error) {
+	rows, err := s.selectRoomsStateByTypeStmt.QueryContext(ctx, eventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // nolint: errcheck
+
+	result := []gomatrixserverlib.ClientEvent{}
+	for rows.Next() {
+		var eventBytes []byte
+		if err := rows.Scan(&eventBytes); err != nil {
+			return nil, err
+		}
+		if encryption.CheckCrypto(eventType) {
+			eventBytes = encryption.Decrypt(eventBytes)
+		}
+		var ev gomatrixserverlib.ClientEvent
+		if err := json.Unmarshal(eventBytes, &ev); err != nil {
+			return nil, err
+		}
+		result = append(result, ev)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,186 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syncapi
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/finogeeks/ligase/model/types"
+)
+
+const eventReportsSchema = `
+-- Stores the events reported by users, the moderation queue.
+CREATE TABLE IF NOT EXISTS syncapi_event_reports (
+    id BIGINT NOT NULL PRIMARY KEY,
+    room_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    reporter TEXT NOT NULL,
+    -- The sender of the reported event
+    sender TEXT NOT NULL DEFAULT '',
+    reason TEXT NOT NULL DEFAULT '',
+    score INTEGER NOT NULL DEFAULT 0,
+    received_ts BIGINT NOT NULL,
+    -- open, assigned or resolved
+    status TEXT NOT NULL DEFAULT 'open',
+    assignee TEXT NOT NULL DEFAULT '',
+    resolved_by TEXT NOT NULL DEFAULT '',
+    resolved_ts BIGINT NOT NULL DEFAULT 0,
+    resolution TEXT NOT NULL DEFAULT '',
+    -- The comma separated actions taken when resolving
+    actions TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS syncapi_event_reports_status_idx ON syncapi_event_reports(status);
+CREATE INDEX IF NOT EXISTS syncapi_event_reports_room_id_idx ON syncapi_event_reports(room_id);
+`
+
+const eventReportsColumns = "id, room_id, event_id, reporter, sender, reason, score, received_ts," +
+	" status, assignee, resolved_by, resolved_ts, resolution, actions"
+
+const insertEventReportSQL = "" +
+	"INSERT INTO syncapi_event_reports (id, room_id, event_id, reporter, sender, reason, score, received_ts, status)" +
+	" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)"
+
+const selectEventReportSQL = "" +
+	"SELECT " + eventReportsColumns + " FROM syncapi_event_reports WHERE id = $1"
+
+const selectEventReportsSQL = "" +
+	"SELECT " + eventReportsColumns + " FROM syncapi_event_reports" +
+	" WHERE ($1 = '' OR room_id = $1) AND ($2 = '' OR reporter = $2) AND ($3 = '' OR sender = $3)" +
+	" AND ($4 = '' OR status = $4) AND ($5 = '' OR assignee = $5) AND id < $6" +
+	" ORDER BY id DESC LIMIT $7"
+
+const updateEventReportAssigneeSQL = "" +
+	"UPDATE syncapi_event_reports SET assignee = $2, status = $3 WHERE id = $1"
+
+const updateEventReportResolvedSQL = "" +
+	"UPDATE syncapi_event_reports SET status = $2, resolved_by = $3, resolved_ts = $4, resolution = $5, actions = $6" +
+	" WHERE id = $1"
+
+type eventReportsStatements struct {
+	db                            *Database
+	insertEventReportStmt         *sql.Stmt
+	selectEventReportStmt         *sql.Stmt
+	selectEventReportsStmt        *sql.Stmt
+	updateEventReportAssigneeStmt *sql.Stmt
+	updateEventReportResolvedStmt *sql.Stmt
+}
+
+func (s *eventReportsStatements) getSchema() string {
+	return eventReportsSchema
+}
+
+func (s *eventReportsStatements) prepare(db *sql.DB, d *Database) (err error) {
+	s.db = d
+	if s.insertEventReportStmt, err = db.Prepare(insertEventReportSQL); err != nil {
+		return
+	}
+	if s.selectEventReportStmt, err = db.Prepare(selectEventReportSQL); err != nil {
+		return
+	}
+	if s.selectEventReportsStmt, err = db.Prepare(selectEventReportsSQL); err != nil {
+		return
+	}
+	if s.updateEventReportAssigneeStmt, err = db.Prepare(updateEventReportAssigneeSQL); err != nil {
+		return
+	}
+	if s.updateEventReportResolvedStmt, err = db.Prepare(updateEventReportResolvedSQL); err != nil {
+		return
+	}
+	return
+}
+
+func (s *eventReportsStatements) insertEventReport(
+	ctx context.Context, report *types.EventReport,
+) error {
+	_, err := s.insertEventReportStmt.ExecContext(
+		ctx, report.ID, report.RoomID, report.EventID, report.Reporter, report.Sender,
+		report.Reason, report.Score, report.ReceivedTs, report.Status,
+	)
+	return err
+}
+
+type eventReportScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEventReport(row eventReportScanner) (*types.EventReport, error) {
+	var report types.EventReport
+	var actions string
+	err := row.Scan(
+		&report.ID, &report.RoomID, &report.EventID, &report.Reporter, &report.Sender,
+		&report.Reason, &report.Score, &report.ReceivedTs, &report.Status, &report.Assignee,
+		&report.ResolvedBy, &report.ResolvedTs, &report.Resolution, &actions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if actions != "" {
+		report.Actions = strings.Split(actions, ",")
+	}
+	return &report, nil
+}
+
+func (s *eventReportsStatements) selectEventReport(
+	ctx context.Context, id int64,
+) (*types.EventReport, error) {
+	report, err := scanEventReport(s.selectEventReportStmt.QueryRowContext(ctx, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return report, err
+}
+
+func (s *eventReportsStatements) selectEventReports(
+	ctx context.Context, filter *types.EventReportFilter,
+) ([]types.EventReport, error) {
+	rows, err := s.selectEventReportsStmt.QueryContext(
+		ctx, filter.RoomID, filter.Reporter, filter.Sender, filter.Status,
+		filter.Assignee, filter.FromID, filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reports := []types.EventReport{}
+	for rows.Next() {
+		report, err := scanEventReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, *report)
+	}
+	return reports, rows.Err()
+}
+
+func (s *eventReportsStatements) updateEventReportAssignee(
+	ctx context.Context, id int64, assignee, status string,
+) error {
+	_, err := s.updateEventReportAssigneeStmt.ExecContext(ctx, id, assignee, status)
+	return err
+}
+
+func (s *eventReportsStatements) updateEventReportResolved(
+	ctx context.Context, report *types.EventReport,
+) error {
+	_, err := s.updateEventReportResolvedStmt.ExecContext(
+		ctx, report.ID, report.Status, report.ResolvedBy, report.ResolvedTs,
+		report.Resolution, strings.Join(report.Actions, ","),
+	)
+	return err
+}
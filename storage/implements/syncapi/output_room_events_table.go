@@ -149,7 +149,7 @@ const selectHistoryEvents = "" +
 	" ORDER BY id DESC LIMIT $2"
 
 const selectRoomStateStreamSQL = "" +
-	"SELECT event_json, id, type FROM syncapi_output_room_events WHERE room_id = $1 AND type=any('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\", \"m.room.avatar\", \"m.room.encryption\"}') AND id >= $2 ORDER BY id ASC"
+	"SELECT event_json, id, type FROM syncapi_output_room_events WHERE room_id = $1 AND type=any('{\"m.room.create\", \"m.room.member\", \"m.room.power_levels\", \"m.room.join_rules\", \"m.room.history_visibility\", \"m.room.visibility\",\"m.room.name\", \"m.room.topic\", \"m.room.desc\", \"m.room.pinned_events\",\"m.room.aliases\", \"m.room.canonical_alias\", \"m.room.avatar\", \"m.room.encryption\", \"m.room.retention\"}') AND id >= $2 ORDER BY id ASC"
 
 const selectRoomLatestStreamsSQL = "" +
 	"SELECT max(id), room_id FROM syncapi_output_room_events WHERE room_id = ANY($1) group by room_id"
@@ -207,6 +207,15 @@ const selectEventsByRoomIDSQL = "" +
 const selectEventsByEventsSQL = "" +
 	"SELECT id, event_id, room_id FROM syncapi_output_room_events WHERE event_id = ANY($1)"
 
+const selectPurgeEventsSQL = "" +
+	"SELECT id, event_json, type FROM syncapi_output_room_events WHERE room_id = $1 AND id > $2 AND id < $3 ORDER BY id ASC LIMIT $4"
+
+const deleteEventsSQL = "" +
+	"DELETE FROM syncapi_output_room_events WHERE id = ANY($1)"
+
+const deleteEventsMirrorSQL = "" +
+	"DELETE FROM syncapi_output_room_events_mirror WHERE id = ANY($1)"
+
 type outputRoomEventsStatements struct {
 	db                          *Database
 	insertEventStmt             *sql.Stmt
@@ -238,6 +247,9 @@ type outputRoomEventsStatements struct {
 	selectEventRawStmt            *sql.Stmt
 	selectEventsByRoomIDStmt      *sql.Stmt
 	selectEventsByEventsStmt 	  *sql.Stmt
+	selectPurgeEventsStmt         *sql.Stmt
+	deleteEventsStmt              *sql.Stmt
+	deleteEventsMirrorStmt        *sql.Stmt
 }
 
 func (s *outputRoomEventsStatements) getSchema() string {
@@ -337,6 +349,15 @@ func (s *outputRoomEventsStatements) prepare(db *sql.DB, d *Database) (err error
 	if s.selectEventsByEventsStmt, err = db.Prepare(selectEventsByEventsSQL); err != nil {
 		return
 	}
+	if s.selectPurgeEventsStmt, err = db.Prepare(selectPurgeEventsSQL); err != nil {
+		return
+	}
+	if s.deleteEventsStmt, err = db.Prepare(deleteEventsSQL); err != nil {
+		return
+	}
+	if s.deleteEventsMirrorStmt, err = db.Prepare(deleteEventsMirrorSQL); err != nil {
+		return
+	}
 	return
 }
 
@@ -515,10 +536,24 @@ func (s *outputRoomEventsStatements) insertEvent(
 				deviceID, txnID, event.Type, domainOffset, depth, domain, originTs,
 			)
 		}
+		if err == nil {
+			s.indexEvent(ctx, id, event.RoomID, event.EventID, event.Type, eventBytes, originTs)
+		}
 	}
 	return
 }
 
+// indexEvent adds the event to the search index, a failure only costs search
+// results so the event is stored anyway
+func (s *outputRoomEventsStatements) indexEvent(
+	ctx context.Context,
+	id int64, roomID, eventID, eventType string, eventJSON []byte, originTs int64,
+) {
+	if err := s.db.search.insertSearchEvent(ctx, id, eventID, roomID, eventType, eventJSON, originTs); err != nil {
+		log.Errorf("outputRoomEventsStatements.indexEvent room:%s event:%s err:%v", roomID, eventID, err)
+	}
+}
+
 func (s *outputRoomEventsStatements) insertEventRaw(
 	ctx context.Context,
 	id int64, roomId, eventId string, json []byte, addState, removeState []string,
@@ -548,6 +583,7 @@ func (s *outputRoomEventsStatements) insertEventRaw(
 	if err != nil {
 		return err
 	}
+	s.indexEvent(ctx, id, roomId, eventId, eventType, json, originTs)
 
 	if depth > 1 {
 		var eventID string
@@ -740,6 +776,11 @@ func (s *outputRoomEventsStatements) updateEventRaw(
 			ctx, eventJson, eventID, RoomID,
 		)
 	}
+	if err == nil {
+		if e := s.db.search.updateSearchEvent(ctx, eventID, eventType, eventJson); e != nil {
+			log.Errorf("outputRoomEventsStatements.updateEventRaw reindex event:%s err:%v", eventID, e)
+		}
+	}
 
 	return err
 }
@@ -1099,3 +1140,56 @@ func (s *outputRoomEventsStatements) selectEventsByEvents(
 	}
 	return ids, eventIDs, roomIDs, nil
 }
+
+// selectPurgeEvents returns the events of a room with a stream position in
+// (fromPos, toPos) in stream order
+func (s *outputRoomEventsStatements) selectPurgeEvents(
+	ctx context.Context, roomID string, fromPos, toPos int64, limit int,
+) ([]gomatrixserverlib.ClientEvent, []int64, error) {
+	rows, err := s.selectPurgeEventsStmt.QueryContext(ctx, roomID, fromPos, toPos, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close() // nolint: errcheck
+
+	var evs []gomatrixserverlib.ClientEvent
+	var pos []int64
+	for rows.Next() {
+		var (
+			streamPos  int64
+			eventBytes []byte
+			eventType  string
+		)
+		if err = rows.Scan(&streamPos, &eventBytes, &eventType); err != nil {
+			return nil, nil, err
+		}
+
+		// decrypt messages
+		if encryption.CheckCrypto(eventType) {
+			eventBytes = encryption.Decrypt(eventBytes)
+		}
+
+		var ev gomatrixserverlib.ClientEvent
+		if err = json.Unmarshal(eventBytes, &ev); err != nil {
+			log.Errorf("outputRoomEvents selectPurgeEvents json unmarshal failed, id: %d, room: %s, type: %s, err: %v", streamPos, roomID, eventType, err)
+			return nil, nil, err
+		}
+		evs = append(evs, ev)
+		pos = append(pos, streamPos)
+	}
+	return evs, pos, rows.Err()
+}
+
+func (s *outputRoomEventsStatements) deleteEvents(
+	ctx context.Context, ids []int64,
+) error {
+	return common.WithTransaction(s.db.db, func(txn *sql.Tx) error {
+		if _, err := common.TxStmt(txn, s.deleteEventsStmt).ExecContext(ctx, pq.Int64Array(ids)); err != nil {
+			return err
+		}
+		if _, err := common.TxStmt(txn, s.deleteEventsMirrorStmt).ExecContext(ctx, pq.Int64Array(ids)); err != nil {
+			return err
+		}
+		return s.db.search.deleteSearchEvents(ctx, txn, ids)
+	})
+}
@@ -0,0 +1,272 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syncapi
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/encryption"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/lib/pq"
+)
+
+const searchEventsSchema = `
+-- Stores the full text search index of the m.room.message bodies.
+CREATE TABLE IF NOT EXISTS syncapi_search_events (
+    -- The stream position of the event in syncapi_output_room_events
+    id BIGINT PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    room_id TEXT NOT NULL,
+    sender TEXT NOT NULL,
+    -- The tokens of content.body, see searchTokens
+    vector TSVECTOR NOT NULL,
+    origin_server_ts BIGINT NOT NULL,
+    CONSTRAINT syncapi_search_events_unique UNIQUE (event_id)
+);
+CREATE INDEX IF NOT EXISTS syncapi_search_events_vector_idx ON syncapi_search_events USING GIN (vector);
+CREATE INDEX IF NOT EXISTS syncapi_search_events_room_idx ON syncapi_search_events(room_id, id);
+`
+
+const insertSearchEventSQL = "" +
+	"INSERT INTO syncapi_search_events (id, event_id, room_id, sender, vector, origin_server_ts)" +
+	" VALUES ($1, $2, $3, $4, to_tsvector('simple', $5), $6) ON CONFLICT DO NOTHING"
+
+const updateSearchEventSQL = "" +
+	"UPDATE syncapi_search_events SET vector = to_tsvector('simple', $2) WHERE event_id = $1"
+
+const deleteSearchEventSQL = "" +
+	"DELETE FROM syncapi_search_events WHERE event_id = $1"
+
+const deleteSearchEventsSQL = "" +
+	"DELETE FROM syncapi_search_events WHERE id = ANY($1)"
+
+const selectSearchEventsRecentSQL = "" +
+	"SELECT id, event_id, room_id, sender, ts_rank(vector, plainto_tsquery('simple', $1)) AS rank, origin_server_ts" +
+	" FROM syncapi_search_events WHERE vector @@ plainto_tsquery('simple', $1) AND room_id = ANY($2) AND id < $3" +
+	" ORDER BY id DESC LIMIT $4"
+
+const selectSearchEventsRankSQL = "" +
+	"SELECT id, event_id, room_id, sender, rank, origin_server_ts FROM (" +
+	"SELECT id, event_id, room_id, sender, ts_rank(vector, plainto_tsquery('simple', $1)) AS rank, origin_server_ts" +
+	" FROM syncapi_search_events WHERE vector @@ plainto_tsquery('simple', $1) AND room_id = ANY($2)" +
+	") AS ranked WHERE rank < $3::real OR (rank = $3::real AND id < $4) ORDER BY rank DESC, id DESC LIMIT $5"
+
+const selectSearchEventsMatchSQL = "" +
+	"SELECT room_id, sender, origin_server_ts FROM syncapi_search_events WHERE vector @@ plainto_tsquery('simple', $1) AND room_id = ANY($2)"
+
+type searchEventsStatements struct {
+	db                           *Database
+	insertSearchEventStmt        *sql.Stmt
+	updateSearchEventStmt        *sql.Stmt
+	deleteSearchEventStmt        *sql.Stmt
+	deleteSearchEventsStmt       *sql.Stmt
+	selectSearchEventsRecentStmt *sql.Stmt
+	selectSearchEventsRankStmt   *sql.Stmt
+	selectSearchEventsMatchStmt  *sql.Stmt
+}
+
+func (s *searchEventsStatements) getSchema() string {
+	return searchEventsSchema
+}
+
+func (s *searchEventsStatements) prepare(db *sql.DB, d *Database) (err error) {
+	s.db = d
+	if s.insertSearchEventStmt, err = db.Prepare(insertSearchEventSQL); err != nil {
+		return
+	}
+	if s.updateSearchEventStmt, err = db.Prepare(updateSearchEventSQL); err != nil {
+		return
+	}
+	if s.deleteSearchEventStmt, err = db.Prepare(deleteSearchEventSQL); err != nil {
+		return
+	}
+	if s.deleteSearchEventsStmt, err = db.Prepare(deleteSearchEventsSQL); err != nil {
+		return
+	}
+	if s.selectSearchEventsRecentStmt, err = db.Prepare(selectSearchEventsRecentSQL); err != nil {
+		return
+	}
+	if s.selectSearchEventsRankStmt, err = db.Prepare(selectSearchEventsRankSQL); err != nil {
+		return
+	}
+	if s.selectSearchEventsMatchStmt, err = db.Prepare(selectSearchEventsMatchSQL); err != nil {
+		return
+	}
+	return
+}
+
+// searchable returns the tokens of the body of a m.room.message event, the
+// bodies of event types stored encrypted are only indexed with a plaintext
+// mirror, the index would leak them otherwise
+func searchable(eventType string, eventJSON []byte) (string, bool) {
+	if eventType != "m.room.message" {
+		return "", false
+	}
+	if encryption.CheckCrypto(eventType) && !encryption.CheckMirror(eventType) {
+		return "", false
+	}
+	var ev struct {
+		Content struct {
+			Body string `json:"body"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(eventJSON, &ev); err != nil {
+		return "", false
+	}
+	tokens := searchTokens(ev.Content.Body)
+	return tokens, tokens != ""
+}
+
+func (s *searchEventsStatements) insertSearchEvent(
+	ctx context.Context, id int64, eventID, roomID, eventType string, eventJSON []byte, originTs int64,
+) error {
+	tokens, ok := searchable(eventType, eventJSON)
+	if !ok {
+		return nil
+	}
+	var ev gomatrixserverlib.ClientEvent
+	if err := json.Unmarshal(eventJSON, &ev); err != nil {
+		return err
+	}
+	_, err := s.insertSearchEventStmt.ExecContext(ctx, id, eventID, roomID, ev.Sender, tokens, originTs)
+	return err
+}
+
+// updateSearchEvent reindexes an edited event, redacted events have no body
+// and leave the index
+func (s *searchEventsStatements) updateSearchEvent(
+	ctx context.Context, eventID, eventType string, eventJSON []byte,
+) error {
+	if eventType != "m.room.message" {
+		return nil
+	}
+	tokens, ok := searchable(eventType, eventJSON)
+	if !ok {
+		_, err := s.deleteSearchEventStmt.ExecContext(ctx, eventID)
+		return err
+	}
+	_, err := s.updateSearchEventStmt.ExecContext(ctx, eventID, tokens)
+	return err
+}
+
+func (s *searchEventsStatements) deleteSearchEvents(
+	ctx context.Context, txn *sql.Tx, ids []int64,
+) error {
+	_, err := common.TxStmt(txn, s.deleteSearchEventsStmt).ExecContext(ctx, pq.Int64Array(ids))
+	return err
+}
+
+func (s *searchEventsStatements) selectSearchEvents(
+	ctx context.Context, term string, rooms []string, orderByRank bool, fromRank float64, fromID int64, limit int,
+) ([]syncapitypes.SearchEventResult, error) {
+	query := searchTokens(term)
+	if query == "" || len(rooms) == 0 {
+		return nil, nil
+	}
+	var rows *sql.Rows
+	var err error
+	if orderByRank {
+		rows, err = s.selectSearchEventsRankStmt.QueryContext(ctx, query, pq.StringArray(rooms), fromRank, fromID, limit)
+	} else {
+		rows, err = s.selectSearchEventsRecentStmt.QueryContext(ctx, query, pq.StringArray(rooms), fromID, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []syncapitypes.SearchEventResult
+	for rows.Next() {
+		var r syncapitypes.SearchEventResult
+		if err = rows.Scan(&r.ID, &r.EventID, &r.RoomID, &r.Sender, &r.Rank, &r.OriginServerTS); err != nil {
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, rows.Err()
+}
+
+// countSearchEvents counts the events matching term which pass visible, the
+// results only carry the room, sender and timestamp of the events
+func (s *searchEventsStatements) countSearchEvents(
+	ctx context.Context, term string, rooms []string, visible func(*syncapitypes.SearchEventResult) bool,
+) (int64, error) {
+	query := searchTokens(term)
+	if query == "" || len(rooms) == 0 {
+		return 0, nil
+	}
+	rows, err := s.selectSearchEventsMatchStmt.QueryContext(ctx, query, pq.StringArray(rooms))
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	var count int64
+	for rows.Next() {
+		var r syncapitypes.SearchEventResult
+		if err = rows.Scan(&r.RoomID, &r.Sender, &r.OriginServerTS); err != nil {
+			return 0, err
+		}
+		if visible(&r) {
+			count++
+		}
+	}
+	return count, rows.Err()
+}
+
+// searchTokens splits text into the lower cased words of the search index.
+// Han, kana and hangul are written without spaces between words, their runs
+// are indexed as single characters and overlapping bigrams so that a query
+// finds them anywhere in a sentence.
+func searchTokens(text string) string {
+	var tokens []string
+	var word, run []rune
+	flushWord := func() {
+		if len(word) > 0 {
+			tokens = append(tokens, string(word))
+			word = word[:0]
+		}
+	}
+	flushRun := func() {
+		for i := range run {
+			tokens = append(tokens, string(run[i]))
+			if i+1 < len(run) {
+				tokens = append(tokens, string(run[i:i+2]))
+			}
+		}
+		run = run[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			flushWord()
+			run = append(run, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushRun()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushRun()
+		}
+	}
+	flushWord()
+	flushRun()
+	return strings.Join(tokens, " ")
+}
@@ -25,6 +25,7 @@ import (
 	mon "github.com/finogeeks/ligase/skunkworks/monitor/go-client/monitor"
 
 	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/migration"
 	"github.com/finogeeks/ligase/common/uid"
 	"github.com/finogeeks/ligase/core"
 	"github.com/finogeeks/ligase/model/authtypes"
@@ -67,6 +68,9 @@ type Database struct {
 	presenceData    presenceDataStreamStatements
 	userTimeLine    userTimeLineStatements
 	outputMinStream outputMinStreamStatements
+	search          searchEventsStatements
+	userDirectory   userDirectoryStatements
+	eventReports    eventReportsStatements
 	AsyncSave       bool
 
 	qryDBGauge mon.LabeledGauge
@@ -136,13 +140,21 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 		d.presenceData.getSchema(),
 		d.userReceiptData.getSchema(),
 		d.userTimeLine.getSchema(),
-		d.outputMinStream.getSchema()}
+		d.outputMinStream.getSchema(),
+		d.search.getSchema(),
+		d.userDirectory.getSchema(),
+		d.eventReports.getSchema()}
 	for _, sqlStr := range schemas {
 		_, err := d.db.Exec(sqlStr)
 		if err != nil {
 			return nil, err
 		}
 	}
+	d.userDirectory.createSearchIndex(d.db, driver)
+
+	if err = migration.Apply("syncapi", driver, createAddr, d.db); err != nil {
+		return nil, err
+	}
 
 	if err = d.events.prepare(d.db, d); err != nil {
 		return nil, err
@@ -174,6 +186,15 @@ func NewDatabase(driver, createAddr, address, underlying, topic string, useAsync
 	if err := d.outputMinStream.prepare(d.db, d); err != nil {
 		return nil, err
 	}
+	if err := d.search.prepare(d.db, d); err != nil {
+		return nil, err
+	}
+	if err := d.userDirectory.prepare(d.db, d); err != nil {
+		return nil, err
+	}
+	if err := d.eventReports.prepare(d.db, d); err != nil {
+		return nil, err
+	}
 	return d, nil
 }
 
@@ -611,6 +632,12 @@ func (d *Database) GetAllSyncRooms() ([]string, error) {
 	return d.events.selectAllSyncRooms()
 }
 
+func (d *Database) GetRoomsStateByType(
+	ctx context.Context, eventType string,
+) ([]gomatrixserverlib.ClientEvent, error) {
+	return d.roomstate.selectRoomsStateByType(ctx, eventType)
+}
+
 func (d *Database) SelectUserTimeLineEvents(
 	ctx context.Context,
 	userID string,
@@ -724,6 +751,65 @@ func (d *Database) UpdateSyncMsgEventMigration(ctx context.Context, id int64, En
 func (d *Database) GetEventRaw(ctx context.Context, eventID string) (int64, []byte, error) {
 	return d.events.selectEventRaw(ctx, eventID)
 }
+
+// SelectPurgeEvents returns up to limit events of a room between the stream
+// positions fromPos and toPos, both exclusive
+func (d *Database) SelectPurgeEvents(ctx context.Context, roomID string, fromPos, toPos int64, limit int) ([]gomatrixserverlib.ClientEvent, []int64, error) {
+	return d.events.selectPurgeEvents(ctx, roomID, fromPos, toPos, limit)
+}
+
+func (d *Database) DeleteEvents(ctx context.Context, ids []int64) error {
+	return d.events.deleteEvents(ctx, ids)
+}
+
+// SearchEvents returns up to limit indexed m.room.message events of the rooms
+// matching term, by rank or by recency. The results start after fromRank and
+// fromID of the last result of the previous page.
+func (d *Database) SearchEvents(ctx context.Context, term string, rooms []string, orderByRank bool, fromRank float64, fromID int64, limit int) ([]syncapitypes.SearchEventResult, error) {
+	return d.search.selectSearchEvents(ctx, term, rooms, orderByRank, fromRank, fromID, limit)
+}
+
+// CountSearchEvents counts the indexed events of the rooms matching term which
+// pass visible
+func (d *Database) CountSearchEvents(ctx context.Context, term string, rooms []string, visible func(*syncapitypes.SearchEventResult) bool) (int64, error) {
+	return d.search.countSearchEvents(ctx, term, rooms, visible)
+}
+
+// UpsertUserDirectory stores the profile of a user in the user directory,
+// with onlyNew an existing profile is kept
+func (d *Database) UpsertUserDirectory(ctx context.Context, entry *types.UserDirectoryEntry, onlyNew bool) error {
+	return d.userDirectory.upsertUserDirectory(ctx, entry, onlyNew)
+}
+
+// SearchUserDirectory returns up to limit profiles matching term which userID
+// shares a joined room with, with searchAll also the profiles of local users
+func (d *Database) SearchUserDirectory(ctx context.Context, userID, term string, searchAll bool, limit int) ([]types.UserDirectoryEntry, error) {
+	return d.userDirectory.selectUserDirectory(ctx, userID, term, searchAll, limit)
+}
+
+// InsertEventReport adds a report to the moderation queue
+func (d *Database) InsertEventReport(ctx context.Context, report *types.EventReport) error {
+	return d.eventReports.insertEventReport(ctx, report)
+}
+
+// GetEventReport returns the report with the id, nil if there is none
+func (d *Database) GetEventReport(ctx context.Context, id int64) (*types.EventReport, error) {
+	return d.eventReports.selectEventReport(ctx, id)
+}
+
+// GetEventReports returns the reports the filter selects, newest first
+func (d *Database) GetEventReports(ctx context.Context, filter *types.EventReportFilter) ([]types.EventReport, error) {
+	return d.eventReports.selectEventReports(ctx, filter)
+}
+
+func (d *Database) UpdateEventReportAssignee(ctx context.Context, id int64, assignee, status string) error {
+	return d.eventReports.updateEventReportAssignee(ctx, id, assignee, status)
+}
+
+func (d *Database) UpdateEventReportResolved(ctx context.Context, report *types.EventReport) error {
+	return d.eventReports.updateEventReportResolved(ctx, report)
+}
+
 func (d *Database) GetMsgEventsByRoomIDMigration(ctx context.Context, roomID string) ([]int64, []string, [][]byte, error) {
 	return d.events.selectEventsByRoomIDMigration(ctx, roomID)
 }
@@ -0,0 +1,151 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package syncapi
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/finogeeks/ligase/common/sqlite"
+	"github.com/finogeeks/ligase/model/types"
+	log "github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const userDirectorySchema = `
+-- Stores the profiles searched by /user_directory/search.
+CREATE TABLE IF NOT EXISTS syncapi_user_directory (
+    user_id TEXT NOT NULL PRIMARY KEY,
+    display_name TEXT NOT NULL DEFAULT '',
+    avatar_url TEXT NOT NULL DEFAULT '',
+    user_name TEXT NOT NULL DEFAULT '',
+    job_number TEXT NOT NULL DEFAULT '',
+    email TEXT NOT NULL DEFAULT '',
+    -- Whether the user belongs to this server
+    is_local BOOLEAN NOT NULL DEFAULT FALSE,
+    -- The lower cased searchable fields, see directorySearchText
+    search_text TEXT NOT NULL DEFAULT ''
+);
+`
+
+// userDirectorySearchIndex serves the LIKE '%term%' searches of search_text
+// on postgres, see createSearchIndex
+const userDirectorySearchIndex = `
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS syncapi_user_directory_search_idx ON syncapi_user_directory USING GIN (search_text gin_trgm_ops);
+`
+
+const upsertUserDirectorySQL = "" +
+	"INSERT INTO syncapi_user_directory (user_id, display_name, avatar_url, user_name, job_number, email, is_local, search_text)" +
+	" VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id) DO UPDATE SET" +
+	" display_name = EXCLUDED.display_name, avatar_url = EXCLUDED.avatar_url, user_name = EXCLUDED.user_name," +
+	" job_number = EXCLUDED.job_number, email = EXCLUDED.email, is_local = EXCLUDED.is_local, search_text = EXCLUDED.search_text"
+
+const insertUserDirectorySQL = "" +
+	"INSERT INTO syncapi_user_directory (user_id, display_name, avatar_url, user_name, job_number, email, is_local, search_text)" +
+	" VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id) DO NOTHING"
+
+// a user is visible to the searcher when they share a joined room, or when
+// $3 is set and the user is local. The members of the rooms of the searcher
+// are looked up once, not for every matching entry.
+const selectUserDirectorySQL = "" +
+	"SELECT d.user_id, d.display_name, d.avatar_url FROM syncapi_user_directory d" +
+	" WHERE d.search_text LIKE $1 ESCAPE '\\' AND (d.user_id = $2 OR ($3 AND d.is_local) OR d.user_id IN (" +
+	"SELECT b.state_key FROM syncapi_current_room_state a JOIN syncapi_current_room_state b ON a.room_id = b.room_id" +
+	" WHERE a.type = 'm.room.member' AND a.state_key = $2 AND a.membership = 'join'" +
+	" AND b.type = 'm.room.member' AND b.membership = 'join'))" +
+	" ORDER BY d.display_name, d.user_id LIMIT $4"
+
+type userDirectoryStatements struct {
+	db                      *Database
+	upsertUserDirectoryStmt *sql.Stmt
+	insertUserDirectoryStmt *sql.Stmt
+	selectUserDirectoryStmt *sql.Stmt
+}
+
+func (s *userDirectoryStatements) getSchema() string {
+	return userDirectorySchema
+}
+
+// createSearchIndex creates the trigram index of the searches. Creating the
+// pg_trgm extension needs more rights than the tables, the search scans the
+// directory without the index so a failure is only logged. sqlite has no
+// trigram index.
+func (s *userDirectoryStatements) createSearchIndex(db *sql.DB, driver string) {
+	if sqlite.IsDriver(driver) {
+		return
+	}
+	if _, err := db.Exec(userDirectorySearchIndex); err != nil {
+		log.Warnf("user directory search index not created, searches scan the directory: %v", err)
+	}
+}
+
+func (s *userDirectoryStatements) prepare(db *sql.DB, d *Database) (err error) {
+	s.db = d
+	if s.upsertUserDirectoryStmt, err = db.Prepare(upsertUserDirectorySQL); err != nil {
+		return
+	}
+	if s.insertUserDirectoryStmt, err = db.Prepare(insertUserDirectorySQL); err != nil {
+		return
+	}
+	if s.selectUserDirectoryStmt, err = db.Prepare(selectUserDirectorySQL); err != nil {
+		return
+	}
+	return
+}
+
+// directorySearchText joins the searchable fields of an entry, a search term
+// can't match across two of them
+func directorySearchText(entry *types.UserDirectoryEntry) string {
+	return strings.ToLower(strings.Join([]string{
+		entry.UserID, entry.DisplayName, entry.UserName, entry.JobNumber, entry.Email,
+	}, "\n"))
+}
+
+func (s *userDirectoryStatements) upsertUserDirectory(
+	ctx context.Context, entry *types.UserDirectoryEntry, onlyNew bool,
+) error {
+	stmt := s.upsertUserDirectoryStmt
+	if onlyNew {
+		stmt = s.insertUserDirectoryStmt
+	}
+	_, err := stmt.ExecContext(
+		ctx, entry.UserID, entry.DisplayName, entry.AvatarURL, entry.UserName,
+		entry.JobNumber, entry.Email, entry.IsLocal, directorySearchText(entry),
+	)
+	return err
+}
+
+func (s *userDirectoryStatements) selectUserDirectory(
+	ctx context.Context, userID, term string, searchAll bool, limit int,
+) ([]types.UserDirectoryEntry, error) {
+	escaper := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	pattern := "%" + escaper.Replace(strings.ToLower(term)) + "%"
+	rows, err := s.selectUserDirectoryStmt.QueryContext(ctx, pattern, userID, searchAll, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []types.UserDirectoryEntry
+	for rows.Next() {
+		var entry types.UserDirectoryEntry
+		if err = rows.Scan(&entry.UserID, &entry.DisplayName, &entry.AvatarURL); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
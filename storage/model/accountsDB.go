@@ -39,6 +39,13 @@ type AccountsDatabase interface {
 	) (*authtypes.Account, error)
 
 	GetAccount(ctx context.Context, userID string) (*authtypes.Account, error)
+	GetAccountByPassword(ctx context.Context, userID, plaintextPassword string) (*authtypes.Account, error)
+	SetPassword(ctx context.Context, userID, plaintextPassword string) error
+	DeactivateAccount(ctx context.Context, userID string) error
+	GetAccounts(ctx context.Context, search, from string, limit int) ([]authtypes.Account, error)
+	SetAccountSuspended(ctx context.Context, userID string, suspended bool) error
+	InsertOpenIDToken(ctx context.Context, token, userID string, expiresTs int64) error
+	GetOpenIDTokenUser(ctx context.Context, token string) (string, error)
 
 	UpsertProfile(ctx context.Context, userID, displayName, avatarURL string) error
 	UpsertProfileSync(ctx context.Context, userID, displayName, avatarURL string) error
@@ -87,6 +87,10 @@ type DeviceDatabase interface {
 		ctx context.Context, identifier, userID string,
 	) (string, string, int64, error)
 
+	GetUserDevices(
+		ctx context.Context, userID string,
+	) ([]authtypes.Device, error)
+
 	LoadSimpleFilterData(ctx context.Context, f *filter.SimpleFilter) bool
 
 	LoadFilterData(ctx context.Context, key string, f *filter.Filter) bool
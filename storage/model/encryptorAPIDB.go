@@ -111,4 +111,54 @@ type EncryptorAPIDatabase interface {
 	DeleteDeviceOneTimeKey(
 		ctx context.Context, deviceID, userID string,
 	) error
+
+	InsertCrossSigningKey(
+		ctx context.Context, userID, keyType, keyInfo string,
+	) error
+
+	OnInsertCrossSigningKey(
+		ctx context.Context, userID, keyType, keyInfo string,
+	) error
+
+	InsertCrossSigningSig(
+		ctx context.Context, originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+	) error
+
+	OnInsertCrossSigningSig(
+		ctx context.Context, originUserID, originKeyID, targetUserID, targetKeyID, signature string,
+	) error
+
+	InsertKeyBackupVersion(
+		ctx context.Context, userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+	) error
+
+	OnInsertKeyBackupVersion(
+		ctx context.Context, userID string, version int64, algorithm, authData string, etag int64, deleted bool,
+	) error
+
+	InsertKeyBackup(
+		ctx context.Context, userID string, version int64, roomID, sessionID string,
+		firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+	) error
+
+	OnInsertKeyBackup(
+		ctx context.Context, userID string, version int64, roomID, sessionID string,
+		firstMessageIndex, forwardedCount int64, isVerified bool, sessionData string,
+	) error
+
+	DeleteKeyBackup(
+		ctx context.Context, userID string, version int64, roomID, sessionID string,
+	) error
+
+	OnDeleteKeyBackup(
+		ctx context.Context, userID string, version int64, roomID, sessionID string,
+	) error
+
+	InsertFallbackKey(
+		ctx context.Context, deviceID, userID, keyID, keyInfo, al, sig, identifier string, used bool,
+	) error
+
+	OnInsertFallbackKey(
+		ctx context.Context, deviceID, userID, keyID, keyInfo, al, sig, identifier string, used bool,
+	) error
 }
@@ -89,6 +89,7 @@ type RoomServerDatabase interface {
 		ctx context.Context, roomID string,
 	) ([]*gomatrixserverlib.Event, []int64, error)
 	EventsCount(ctx context.Context) (count int, err error)
+	DeleteEvents(ctx context.Context, eventNIDs []int64) error
 	FixCorruptRooms()
 	InsertEventJSON(ctx context.Context, eventNID int64, eventJSON []byte, eventType string) error
 	InsertEvent(ctx context.Context, eventNID int64,
@@ -212,6 +212,9 @@ type SyncAPIDatabase interface {
 		ctx context.Context, userID, oldAvatarUrl, newAvatarUrl string,
 	) error
 	GetAllSyncRooms() ([]string, error)
+	GetRoomsStateByType(
+		ctx context.Context, eventType string,
+	) ([]gomatrixserverlib.ClientEvent, error)
 	SelectUserTimeLineEvents(
 		ctx context.Context,
 		userID string,
@@ -278,6 +281,17 @@ type SyncAPIDatabase interface {
 	UpdateSyncMsgEventMigration(ctx context.Context, id int64, EncryptedEventBytes []byte) error
 	GetEventRaw(ctx context.Context, eventID string) (int64, []byte, error)
 	GetMsgEventsByRoomIDMigration(ctx context.Context, roomID string) ([]int64, []string, [][]byte, error)
+	SelectPurgeEvents(ctx context.Context, roomID string, fromPos, toPos int64, limit int) ([]gomatrixserverlib.ClientEvent, []int64, error)
+	DeleteEvents(ctx context.Context, ids []int64) error
+	SearchEvents(ctx context.Context, term string, rooms []string, orderByRank bool, fromRank float64, fromID int64, limit int) ([]syncapitypes.SearchEventResult, error)
+	CountSearchEvents(ctx context.Context, term string, rooms []string, visible func(*syncapitypes.SearchEventResult) bool) (int64, error)
+	UpsertUserDirectory(ctx context.Context, entry *types.UserDirectoryEntry, onlyNew bool) error
+	SearchUserDirectory(ctx context.Context, userID, term string, searchAll bool, limit int) ([]types.UserDirectoryEntry, error)
+	InsertEventReport(ctx context.Context, report *types.EventReport) error
+	GetEventReport(ctx context.Context, id int64) (*types.EventReport, error)
+	GetEventReports(ctx context.Context, filter *types.EventReportFilter) ([]types.EventReport, error)
+	UpdateEventReportAssignee(ctx context.Context, id int64, assignee, status string) error
+	UpdateEventReportResolved(ctx context.Context, report *types.EventReport) error
 
 	GetRoomStateWithLimit(ctx context.Context, limit, offset int64) ([]string, [][]byte, error)
 	GetRoomStateTotal(ctx context.Context) (int, error)
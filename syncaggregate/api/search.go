@@ -0,0 +1,334 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const defaultSearchLimit = 10
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostSearch{})
+}
+
+type ReqPostSearch struct{}
+
+func (ReqPostSearch) GetRoute() string       { return "/search" }
+func (ReqPostSearch) GetMetricsName() string { return "search" }
+func (ReqPostSearch) GetMsgType() int32      { return internals.MSG_POST_SEARCH }
+func (ReqPostSearch) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostSearch) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostSearch) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostSearch) GetPrefix() []string                  { return []string{"r0"} }
+func (ReqPostSearch) NewRequest() core.Coder {
+	return new(external.PostSearchRequest)
+}
+func (ReqPostSearch) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostSearchRequest)
+	err := common.UnmarshalJSON(req, msg)
+	if err != nil {
+		return err
+	}
+	msg.NextBatch = req.URL.Query().Get("next_batch")
+	return nil
+}
+func (ReqPostSearch) NewResponse(code int) core.Coder {
+	return new(external.PostSearchResponse)
+}
+func (ReqPostSearch) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	if !common.IsRelatedRequest(device.UserID, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+		return internals.HTTP_RESP_DISCARD, jsonerror.MsgDiscard("msg discard")
+	}
+	req := msg.(*external.PostSearchRequest)
+	criteria := req.SearchCategories.RoomEvents
+	if criteria == nil {
+		return http.StatusOK, &external.PostSearchResponse{}
+	}
+	if strings.TrimSpace(criteria.SearchTerm) == "" {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("search_term must not be empty")
+	}
+
+	orderByRank := true
+	switch criteria.OrderBy {
+	case "", "rank":
+	case "recent":
+		orderByRank = false
+	default:
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("order_by must be rank or recent")
+	}
+	fromRank, fromID, err := parseSearchBatch(req.NextBatch, orderByRank)
+	if err != nil {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue(err.Error())
+	}
+
+	result := &external.RoomEventsResult{
+		Highlights: searchHighlights(criteria.SearchTerm),
+		Results:    []external.SearchResult{},
+	}
+	resp := &external.PostSearchResponse{}
+	resp.SearchCategories.RoomEvents = result
+
+	// only the bodies are indexed, content.name and content.topic never match
+	if len(criteria.Keys) > 0 && !containsString(criteria.Keys, "content.body") {
+		return http.StatusOK, resp
+	}
+
+	limit := defaultSearchLimit
+	filter := criteria.Filter
+	if filter == nil {
+		filter = &external.RoomEventFilter{}
+	}
+	if filter.Limit > 0 {
+		limit = filter.Limit
+	}
+
+	rooms, err := c.searchRooms(ctx, device.UserID, filter)
+	if err != nil {
+		return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+	}
+	requestMap := make(map[uint32]*syncapitypes.SyncSearchRequest)
+	for _, roomID := range rooms {
+		instance := common.GetSyncInstance(roomID, c.Cfg.MultiInstance.SyncServerTotal)
+		request, ok := requestMap[instance]
+		if !ok {
+			request = &syncapitypes.SyncSearchRequest{
+				UserID:       device.UserID,
+				SyncInstance: instance,
+				SearchTerm:   criteria.SearchTerm,
+				OrderByRank:  orderByRank,
+				FromRank:     fromRank,
+				FromID:       fromID,
+				Limit:        limit,
+				Senders:      filter.Senders,
+				NotSenders:   filter.NotSenders,
+			}
+			if evCtx := criteria.EventContext; evCtx != nil {
+				request.BeforeLimit = 5
+				if evCtx.BeforeLimit != nil {
+					request.BeforeLimit = *evCtx.BeforeLimit
+				}
+				request.AfterLimit = 5
+				if evCtx.AfterLimit != nil {
+					request.AfterLimit = *evCtx.AfterLimit
+				}
+				request.IncludeProfile = evCtx.IncludeProfile
+			}
+			requestMap[instance] = request
+		}
+		request.Rooms = append(request.Rooms, roomID)
+	}
+
+	// a page missing the results of an instance would skip them for good, the
+	// next batch starts after the merged page, so any failure fails the search
+	var mutex sync.Mutex
+	var items []syncapitypes.SyncSearchItem
+	var searchErr error
+	var wg sync.WaitGroup
+	for _, syncReq := range requestMap {
+		wg.Add(1)
+		go func(syncReq *syncapitypes.SyncSearchRequest) {
+			defer wg.Done()
+			res, err := c.syncSearch(syncReq)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				log.Errorf("sync search user %s instance %d error %v", syncReq.UserID, syncReq.SyncInstance, err)
+				searchErr = err
+				return
+			}
+			result.Count += res.Count
+			items = append(items, res.Results...)
+		}(syncReq)
+	}
+	wg.Wait()
+	if searchErr != nil {
+		return http.StatusInternalServerError, jsonerror.Unknown("search failed: " + searchErr.Error())
+	}
+
+	// every instance returns its own best page, the merged page is the best
+	// of them and the next one starts right after its last result
+	sort.Slice(items, func(i, j int) bool {
+		if orderByRank && items[i].Result.Rank != items[j].Result.Rank {
+			return items[i].Result.Rank > items[j].Result.Rank
+		}
+		return items[i].ID > items[j].ID
+	})
+	// a full page may be followed by more, the next one tells
+	if len(items) >= limit {
+		items = items[:limit]
+		last := items[limit-1]
+		if orderByRank {
+			result.NextBatch = fmt.Sprintf("%s_%d", strconv.FormatFloat(last.Result.Rank, 'g', -1, 32), last.ID)
+		} else {
+			result.NextBatch = strconv.FormatInt(last.ID, 10)
+		}
+	}
+	for _, item := range items {
+		result.Results = append(result.Results, item.Result)
+	}
+
+	if criteria.Groupings != nil {
+		for _, group := range criteria.Groupings.GroupBy {
+			if group.Key != "room_id" && group.Key != "sender" {
+				continue
+			}
+			if result.Groups == nil {
+				result.Groups = make(map[string]map[string]*external.SearchGroupResult)
+			}
+			groups := make(map[string]*external.SearchGroupResult)
+			for _, item := range result.Results {
+				key := item.Result.RoomID
+				if group.Key == "sender" {
+					key = item.Result.Sender
+				}
+				g, ok := groups[key]
+				if !ok {
+					g = &external.SearchGroupResult{Order: len(groups) + 1, Results: []string{}}
+					groups[key] = g
+				}
+				g.Results = append(g.Results, item.Result.EventID)
+			}
+			result.Groups[group.Key] = groups
+		}
+	}
+
+	return http.StatusOK, resp
+}
+
+// syncSearch runs the search of one sync server instance
+func (c *InternalMsgConsumer) syncSearch(syncReq *syncapitypes.SyncSearchRequest) (*syncapitypes.SyncSearchResponse, error) {
+	bytes, err := json.Marshal(*syncReq)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.RpcCli.Request(types.SyncSearchTopicDef, bytes, 30000)
+	if err != nil {
+		return nil, err
+	}
+	var res syncapitypes.SyncSearchResponse
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, err
+	}
+	if res.Error != "" {
+		return nil, errors.New(res.Error)
+	}
+	return &res, nil
+}
+
+// searchRooms returns the rooms a user is or was in that the filter allows
+func (c *InternalMsgConsumer) searchRooms(ctx context.Context, userID string, filter *external.RoomEventFilter) ([]string, error) {
+	var rooms []string
+	allowed := func(roomID string) bool {
+		if len(filter.Rooms) > 0 && !containsString(filter.Rooms, roomID) {
+			return false
+		}
+		return !containsString(filter.NotRooms, roomID)
+	}
+	joinMap, err := c.userTimeLine.GetJoinRooms(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	leaveMap, err := c.userTimeLine.GetLeaveRooms(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range []*sync.Map{joinMap, leaveMap} {
+		if m == nil {
+			continue
+		}
+		m.Range(func(key, value interface{}) bool {
+			if roomID := key.(string); allowed(roomID) {
+				rooms = append(rooms, roomID)
+			}
+			return true
+		})
+	}
+	return rooms, nil
+}
+
+// parseSearchBatch reads the position a next_batch token points at, rank
+// ordered tokens carry the rank and the stream position of the last result,
+// recent ordered ones only the stream position. Ranks are real in the
+// database, the first page starts from the largest real.
+func parseSearchBatch(token string, orderByRank bool) (float64, int64, error) {
+	if token == "" {
+		return math.MaxFloat32, math.MaxInt64, nil
+	}
+	if !orderByRank {
+		id, err := strconv.ParseInt(token, 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("invalid next_batch %s", token)
+		}
+		return math.MaxFloat32, id, nil
+	}
+	parts := strings.SplitN(token, "_", 2)
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid next_batch %s", token)
+	}
+	rank, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid next_batch %s", token)
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid next_batch %s", token)
+	}
+	return rank, id, nil
+}
+
+func searchHighlights(term string) []string {
+	highlights := []string{}
+	seen := make(map[string]bool)
+	for _, word := range strings.Fields(strings.ToLower(term)) {
+		if !seen[word] {
+			seen[word] = true
+			highlights = append(highlights, word)
+		}
+	}
+	return highlights
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
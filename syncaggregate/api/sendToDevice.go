@@ -27,6 +27,7 @@ import (
 	"github.com/finogeeks/ligase/model/types"
 	"github.com/finogeeks/ligase/plugins/message/external"
 	"github.com/finogeeks/ligase/plugins/message/internals"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 	"github.com/finogeeks/ligase/skunkworks/log"
 )
 
@@ -79,6 +80,27 @@ func (ReqPutSendToDevice) Process(ctx context.Context, consumer interface{}, msg
 	stdRq := types.StdRequest{}
 	json.Unmarshal(req.Content, &stdRq)
 
+	// messages to users on other servers are relayed as m.direct_to_device
+	// edus, one per destination, by the instance owning the sender
+	remote := make(map[string]map[string]map[string]interface{})
+	for uid, deviceMap := range stdRq.Sender {
+		domain, _ := common.DomainFromID(uid)
+		if domain == "" || common.CheckValidDomain(domain, c.Cfg.Matrix.ServerName) {
+			continue
+		}
+		delete(stdRq.Sender, uid)
+		if !common.IsRelatedRequest(sender, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+			continue
+		}
+		if _, ok := remote[domain]; !ok {
+			remote[domain] = make(map[string]map[string]interface{})
+		}
+		remote[domain][uid] = deviceMap
+	}
+	for domain, messages := range remote {
+		c.sendDirectToDevice(device, domain, eventType, req.TxnId, messages)
+	}
+
 	for uid, deviceMap := range stdRq.Sender {
 		if common.IsRelatedRequest(uid, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
 			// uid is local domain
@@ -122,3 +144,28 @@ func (ReqPutSendToDevice) Process(ctx context.Context, consumer interface{}, msg
 
 	return http.StatusOK, nil
 }
+
+func (c *InternalMsgConsumer) sendDirectToDevice(device *authtypes.Device, domain, eventType, txnID string, messages map[string]map[string]interface{}) {
+	senderDomain, _ := common.DomainFromID(device.UserID)
+	content, err := json.Marshal(types.DirectToDeviceContent{
+		Sender:    device.UserID,
+		Type:      eventType,
+		MessageID: device.ID + "_" + txnID,
+		Messages:  messages,
+	})
+	if err != nil {
+		log.Errorf("sendToDevice marshal direct to device content error %v", err)
+		return
+	}
+	bytes, err := json.Marshal(gomatrixserverlib.EDU{
+		Type:        types.EduMDirectToDevice,
+		Origin:      senderDomain,
+		Destination: domain,
+		Content:     content,
+	})
+	if err != nil {
+		log.Errorf("sendToDevice marshal direct to device edu error %v", err)
+		return
+	}
+	c.RpcCli.Pub(types.EduTopicDef, bytes)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/finogeeks/ligase/common/filter"
 	"github.com/finogeeks/ligase/model/repos"
 	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
 	"github.com/finogeeks/ligase/skunkworks/log"
 	"github.com/nats-io/go-nats"
 	"strconv"
@@ -29,6 +30,7 @@ import (
 type KeyUpdateRpcConsumer struct {
 	rpcClient     *common.RpcClient
 	keyChangeRepo *repos.KeyChangeStreamRepo
+	userTimeLine  *repos.UserTimeLineRepo
 	chanSize      uint32
 	//msgChan       []chan *types.KeyUpdateContent
 	msgChan   []chan common.ContextMsg
@@ -38,11 +40,13 @@ type KeyUpdateRpcConsumer struct {
 
 func NewKeyUpdateRpcConsumer(
 	keyChangeRepo *repos.KeyChangeStreamRepo,
+	userTimeLine *repos.UserTimeLineRepo,
 	rpcClient *common.RpcClient,
 	cfg *config.Dendrite,
 ) *KeyUpdateRpcConsumer {
 	s := &KeyUpdateRpcConsumer{
 		keyChangeRepo: keyChangeRepo,
+		userTimeLine:  userTimeLine,
 		rpcClient:     rpcClient,
 		chanSize:      2,
 		cfg:           cfg,
@@ -106,8 +110,65 @@ func (s *KeyUpdateRpcConsumer) processKeyUpdate(ctx context.Context, data *types
 				ChangedUserID: changed.ChangedUserID,
 			}
 			s.keyChangeRepo.AddKeyChangeStream(ctx, &keyStream, changed.Offset, true)
+			if changed.ChangedDeviceID != "" {
+				s.sendDeviceListUpdate(ctx, &changed)
+			}
 		}
 	default:
 		return
 	}
 }
+
+// sendDeviceListUpdate tells the servers sharing a room with a local user that
+// the keys of a device of the user changed or the device was deleted
+func (s *KeyUpdateRpcConsumer) sendDeviceListUpdate(ctx context.Context, changed *types.DeviceKeyChanges) {
+	senderDomain, _ := common.DomainFromID(changed.ChangedUserID)
+	if !common.CheckValidDomain(senderDomain, s.cfg.Matrix.ServerName) {
+		return
+	}
+	if !common.IsRelatedRequest(changed.ChangedUserID, s.cfg.MultiInstance.Instance, s.cfg.MultiInstance.Total, s.cfg.MultiInstance.MultiWrite) {
+		return
+	}
+
+	domainMap := make(map[string]bool)
+	friendShipMap := s.userTimeLine.GetFriendShip(ctx, changed.ChangedUserID, true)
+	if friendShipMap != nil {
+		friendShipMap.Range(func(key, _ interface{}) bool {
+			domain, _ := common.DomainFromID(key.(string))
+			if !common.CheckValidDomain(domain, s.cfg.Matrix.ServerName) {
+				domainMap[domain] = true
+			}
+			return true
+		})
+	}
+	if len(domainMap) == 0 {
+		return
+	}
+
+	update := types.DeviceListUpdateContent{
+		UserID:            changed.ChangedUserID,
+		DeviceID:          changed.ChangedDeviceID,
+		DeviceDisplayName: changed.DeviceDisplayName,
+		StreamID:          changed.Offset,
+		Deleted:           changed.Deleted,
+		Keys:              changed.Keys,
+	}
+	if changed.PrevOffset != 0 {
+		update.PrevID = []int64{changed.PrevOffset}
+	}
+	content, _ := json.Marshal(update)
+	for domain := range domainMap {
+		edu := gomatrixserverlib.EDU{
+			Type:        types.EduMDeviceListUpdate,
+			Origin:      senderDomain,
+			Destination: domain,
+			Content:     content,
+		}
+		bytes, err := json.Marshal(edu)
+		if err == nil {
+			s.rpcClient.Pub(types.EduTopicDef, bytes)
+		} else {
+			log.Errorf("KeyUpdateRpcConsumer pub device list update edu error %v", err)
+		}
+	}
+}
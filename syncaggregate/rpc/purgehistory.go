@@ -0,0 +1,66 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/model/repos"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/nats-io/go-nats"
+)
+
+type PurgeHistoryRpcConsumer struct {
+	rpcClient    *common.RpcClient
+	userTimeLine *repos.UserTimeLineRepo
+	cfg          *config.Dendrite
+}
+
+func NewPurgeHistoryRpcConsumer(
+	userTimeLine *repos.UserTimeLineRepo,
+	rpcClient *common.RpcClient,
+	cfg *config.Dendrite,
+) *PurgeHistoryRpcConsumer {
+	s := &PurgeHistoryRpcConsumer{
+		userTimeLine: userTimeLine,
+		rpcClient:    rpcClient,
+		cfg:          cfg,
+	}
+
+	return s
+}
+
+func (s *PurgeHistoryRpcConsumer) GetTopic() string {
+	return types.PurgeHistoryTopicDef
+}
+
+func (s *PurgeHistoryRpcConsumer) cb(ctx context.Context, msg *nats.Msg) {
+	var result syncapitypes.PurgeHistoryUpdate
+	if err := json.Unmarshal(msg.Data, &result); err != nil {
+		log.Errorf("rpc purge history cb error %v", err)
+		return
+	}
+	log.Infof("process purge history room:%s", result.RoomID)
+	go s.userTimeLine.InvalidateRoom(ctx, result.RoomID)
+}
+
+func (s *PurgeHistoryRpcConsumer) Start() error {
+	s.rpcClient.ReplyWithContext(s.GetTopic(), s.cb)
+	return nil
+}
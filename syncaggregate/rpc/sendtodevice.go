@@ -141,6 +141,10 @@ func (s *StdRpcConsumer) processStd(ctx context.Context, stdRq *types.StdRequest
 		}
 	}
 
+	// to-device messages from other servers don't wait for a reply
+	if reply == "" {
+		return
+	}
 	resp := util.JSONResponse{
 		Code: http.StatusOK,
 		JSON: struct{}{},
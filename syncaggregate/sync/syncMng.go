@@ -895,6 +895,13 @@ func (sm *SyncMng) addOneTimeKeyCountInfo(ctx context.Context, req *request, res
 		return
 	}
 	res.SignNum = alCountMap
+
+	unusedTypes, err := sm.keyChangeRepo.GetUnusedFallbackKeyTypes(req.device.UserID, req.device.ID)
+	if err != nil {
+		log.Errorf("SyncMng add UnusedFallbackKeyTypes, traceid:%s, user:%s, device:%s, err:%v ", req.traceId, req.device.UserID, req.device.ID, err)
+		return
+	}
+	res.UnusedFallbackKeyTypes = unusedTypes
 	return
 }
 
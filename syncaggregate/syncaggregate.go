@@ -125,7 +125,7 @@ func SetupSyncAggregateComponent(
 		log.Panicf("failed to start sync key change rpc consumer err:%v", err)
 	}
 
-	keyUpdateRpcConsumer := rpc.NewKeyUpdateRpcConsumer(kcRepo, rpcClient, base.Cfg)
+	keyUpdateRpcConsumer := rpc.NewKeyUpdateRpcConsumer(kcRepo, userTimeLine, rpcClient, base.Cfg)
 	if err := keyUpdateRpcConsumer.Start(); err != nil {
 		log.Panicf("failed to start sync key update rpc consumer err:%v", err)
 	}
@@ -135,6 +135,11 @@ func SetupSyncAggregateComponent(
 		log.Panicf("failed to start sync receipt update rpc consumer err:%v", err)
 	}
 
+	purgeHistoryRpcConsumer := rpc.NewPurgeHistoryRpcConsumer(userTimeLine, rpcClient, base.Cfg)
+	if err := purgeHistoryRpcConsumer.Start(); err != nil {
+		log.Panicf("failed to start sync purge history rpc consumer err:%v", err)
+	}
+
 	stdRpcConsumer := rpc.NewStdRpcConsumer(rpcClient, stdEventStreamRepo, cacheIn, syncDB, base.Cfg)
 	if err := stdRpcConsumer.Start(); err != nil {
 		log.Panicf("failed to start sync send to device rpc consumer err:%v", err)
@@ -73,6 +73,9 @@ func (c *InternalMsgConsumer) Start() {
 	c.APIConsumer.Init("syncapi", c, c.Cfg.Rpc.ProxySyncApiTopic)
 	//c.APIConsumer.InitGroup("syncapi",c,c.Cfg.Rpc.ProxySyncApiTopic,types.SYNC_API_GROUP)
 	c.APIConsumer.Start()
+	if c.Cfg.Retention.Enable {
+		c.startRetentionPurger()
+	}
 }
 
 func getProxyRpcTopic(cfg *config.Dendrite) string {
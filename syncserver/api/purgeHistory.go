@@ -0,0 +1,325 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+)
+
+const (
+	purgeBatchSize = 500
+	// purge status is kept for a week after the last update
+	purgeStatusExpire = 7 * 24 * 3600
+
+	PurgeStatusActive   = "active"
+	PurgeStatusComplete = "complete"
+	PurgeStatusFailed   = "failed"
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostPurgeHistory{})
+	apiconsumer.SetAPIProcessor(ReqGetPurgeHistoryStatus{})
+}
+
+type ReqPostPurgeHistory struct{}
+
+func (ReqPostPurgeHistory) GetRoute() string       { return "/purge_history/{roomID}" }
+func (ReqPostPurgeHistory) GetMetricsName() string { return "purge_history" }
+func (ReqPostPurgeHistory) GetMsgType() int32      { return internals.MSG_POST_PURGE_HISTORY }
+func (ReqPostPurgeHistory) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqPostPurgeHistory) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostPurgeHistory) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqPostPurgeHistory) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqPostPurgeHistory) NewRequest() core.Coder {
+	return new(external.PostPurgeHistoryRequest)
+}
+func (ReqPostPurgeHistory) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostPurgeHistoryRequest)
+	if err := common.UnmarshalJSON(req, msg); err != nil {
+		return err
+	}
+	if vars != nil {
+		msg.RoomID = vars["roomID"]
+	}
+	return nil
+}
+func (ReqPostPurgeHistory) NewResponse(code int) core.Coder {
+	return new(external.PostPurgeHistoryResponse)
+}
+
+// Process starts a background job deleting the non state events of a room
+// sent before an event or a timestamp. State events are kept since they are
+// needed to auth the room, and so is the latest event of the room.
+func (ReqPostPurgeHistory) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.PostPurgeHistoryRequest)
+	if !common.IsRelatedRequest(req.RoomID, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+		return internals.HTTP_RESP_DISCARD, jsonerror.MsgDiscard("msg discard")
+	}
+	if !c.Cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can purge history")
+	}
+	if (req.BeforeEventID == "") == (req.BeforeTs <= 0) {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("exactly one of before_event_id and before_ts is required")
+	}
+
+	latest, err := c.db.GetRoomLastOffsets(ctx, []string{req.RoomID})
+	if err != nil {
+		log.Errorf("purge history room:%s get latest offset err:%v", req.RoomID, err)
+		return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+	}
+	toPos, ok := latest[req.RoomID]
+	if !ok {
+		return http.StatusNotFound, jsonerror.NotFound("room not found")
+	}
+	if req.BeforeEventID != "" {
+		evs, offsets, err := c.db.StreamEvents(ctx, []string{req.BeforeEventID})
+		if err != nil {
+			log.Errorf("purge history room:%s get event:%s err:%v", req.RoomID, req.BeforeEventID, err)
+			return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+		}
+		if len(evs) == 0 || evs[0].RoomID != req.RoomID {
+			return http.StatusNotFound, jsonerror.NotFound("event not found in room")
+		}
+		if offsets[0] < toPos {
+			toPos = offsets[0]
+		}
+	}
+
+	id, _ := c.idg.Next()
+	job := &purgeJob{
+		c:        c,
+		roomID:   req.RoomID,
+		toPos:    toPos,
+		beforeTs: req.BeforeTs,
+		status: external.GetPurgeHistoryStatusResponse{
+			PurgeID: strconv.FormatInt(id, 10),
+			RoomID:  req.RoomID,
+			Status:  PurgeStatusActive,
+			StartTs: time.Now().UnixNano() / 1000000,
+		},
+	}
+	if running, loaded := purging.LoadOrStore(req.RoomID, job.status.PurgeID); loaded {
+		return http.StatusConflict, jsonerror.Unknown("purge " + running.(string) + " of the room is still running")
+	}
+	if err := job.report(); err != nil {
+		purging.Delete(req.RoomID)
+		return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+	}
+	log.Infof("purge history start purge:%s room:%s before event:%s ts:%d pos:%d", job.status.PurgeID, req.RoomID, req.BeforeEventID, req.BeforeTs, toPos)
+	go job.run(context.Background())
+
+	return http.StatusOK, &external.PostPurgeHistoryResponse{PurgeID: job.status.PurgeID}
+}
+
+type ReqGetPurgeHistoryStatus struct{}
+
+func (ReqGetPurgeHistoryStatus) GetRoute() string       { return "/purge_history_status/{purgeID}" }
+func (ReqGetPurgeHistoryStatus) GetMetricsName() string { return "purge_history_status" }
+func (ReqGetPurgeHistoryStatus) GetMsgType() int32      { return internals.MSG_GET_PURGE_HISTORY_STATUS }
+func (ReqGetPurgeHistoryStatus) GetAPIType() int8       { return apiconsumer.APITypeAuth }
+func (ReqGetPurgeHistoryStatus) GetMethod() []string {
+	return []string{http.MethodGet, http.MethodOptions}
+}
+func (ReqGetPurgeHistoryStatus) GetTopic(cfg *config.Dendrite) string { return getProxyRpcTopic(cfg) }
+func (ReqGetPurgeHistoryStatus) GetPrefix() []string                  { return []string{"sys"} }
+func (ReqGetPurgeHistoryStatus) NewRequest() core.Coder {
+	return new(external.GetPurgeHistoryStatusRequest)
+}
+func (ReqGetPurgeHistoryStatus) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.GetPurgeHistoryStatusRequest)
+	if vars != nil {
+		msg.PurgeID = vars["purgeID"]
+	}
+	return nil
+}
+func (ReqGetPurgeHistoryStatus) NewResponse(code int) core.Coder {
+	return new(external.GetPurgeHistoryStatusResponse)
+}
+func (ReqGetPurgeHistoryStatus) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	req := msg.(*external.GetPurgeHistoryStatusRequest)
+	// the status is in the cache so any instance could answer, the one the
+	// purge id maps to replies for the request to be answered once
+	if !common.IsRelatedRequest(req.PurgeID, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+		return internals.HTTP_RESP_DISCARD, jsonerror.MsgDiscard("msg discard")
+	}
+	if !c.Cfg.IsServerAdmin(device.UserID) {
+		return http.StatusForbidden, jsonerror.Forbidden("only server admins can purge history")
+	}
+	val, err := c.cache.GetPurgeHistoryStatus(req.PurgeID)
+	if err != nil || val == "" {
+		return http.StatusNotFound, jsonerror.NotFound("purge not found")
+	}
+	resp := new(external.GetPurgeHistoryStatusResponse)
+	if err := json.Unmarshal([]byte(val), resp); err != nil {
+		return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+	}
+	return http.StatusOK, resp
+}
+
+// purging holds the purge id of the rooms being purged by this instance
+var purging sync.Map
+
+var (
+	roomDBOnce sync.Once
+	roomDB     model.RoomServerDatabase
+	roomDBErr  error
+)
+
+// getRoomDB opens the roomserver database, only the purge jobs of the
+// syncserver write to it
+func getRoomDB(cfg *config.Dendrite) (model.RoomServerDatabase, error) {
+	roomDBOnce.Do(func() {
+		db, err := common.GetDBInstance("roomserver", cfg)
+		if err != nil {
+			roomDBErr = err
+			return
+		}
+		roomDB = db.(model.RoomServerDatabase)
+	})
+	return roomDB, roomDBErr
+}
+
+type purgeJob struct {
+	c        *InternalMsgConsumer
+	roomID   string
+	toPos    int64
+	beforeTs int64
+	status   external.GetPurgeHistoryStatusResponse
+}
+
+// report saves the status of the job, retention jobs have no purge id and
+// keep no status
+func (j *purgeJob) report() error {
+	if j.status.PurgeID == "" {
+		return nil
+	}
+	j.status.UpdateTs = time.Now().UnixNano() / 1000000
+	data, err := json.Marshal(&j.status)
+	if err != nil {
+		return err
+	}
+	err = j.c.cache.SetPurgeHistoryStatus(j.status.PurgeID, string(data), purgeStatusExpire)
+	if err != nil {
+		log.Errorf("purge history purge:%s report status err:%v", j.status.PurgeID, err)
+	}
+	return err
+}
+
+func (j *purgeJob) run(ctx context.Context) {
+	defer purging.Delete(j.roomID)
+
+	err := j.purge(ctx)
+	// drop the cached timelines even after a partial purge
+	if j.status.Deleted > 0 {
+		j.c.rmHsTimeline.InvalidateRoom(j.roomID)
+		if bytes, e := json.Marshal(&syncapitypes.PurgeHistoryUpdate{RoomID: j.roomID}); e == nil {
+			j.c.RpcCli.Pub(types.PurgeHistoryTopicDef, bytes)
+		}
+	}
+
+	if err != nil {
+		log.Errorf("purge history purge:%s room:%s failed after %d deleted err:%v", j.status.PurgeID, j.roomID, j.status.Deleted, err)
+		j.status.Status = PurgeStatusFailed
+		j.status.Error = err.Error()
+	} else {
+		log.Infof("purge history purge:%s room:%s complete, scanned:%d deleted:%d kept:%d", j.status.PurgeID, j.roomID, j.status.Scanned, j.status.Deleted, j.status.Kept)
+		j.status.Status = PurgeStatusComplete
+	}
+	j.report()
+}
+
+// purge deletes the events batch by batch in stream order, the roomserver
+// copies go first so that a failed batch is found again by the next purge
+func (j *purgeJob) purge(ctx context.Context) error {
+	rsDB, err := getRoomDB(&j.c.Cfg)
+	if err != nil {
+		return err
+	}
+	if rsDB == nil {
+		return errors.New("roomserver database not available")
+	}
+
+	fromPos := int64(math.MinInt64)
+	for {
+		evs, offsets, err := j.c.db.SelectPurgeEvents(ctx, j.roomID, fromPos, j.toPos, purgeBatchSize)
+		if err != nil {
+			return err
+		}
+		if len(evs) == 0 {
+			return nil
+		}
+		fromPos = offsets[len(offsets)-1]
+
+		var ids []int64
+		var eventIDs []string
+		for idx := range evs {
+			j.status.Scanned++
+			ev := &evs[idx]
+			if ev.StateKey != nil {
+				j.status.Kept++
+				continue
+			}
+			if j.beforeTs > 0 && int64(ev.OriginServerTS) >= j.beforeTs {
+				continue
+			}
+			ids = append(ids, offsets[idx])
+			eventIDs = append(eventIDs, ev.EventID)
+		}
+
+		if len(ids) > 0 {
+			nids, err := rsDB.EventNIDs(ctx, eventIDs)
+			if err != nil {
+				return err
+			}
+			if len(nids) > 0 {
+				eventNIDs := make([]int64, 0, len(nids))
+				for _, nid := range nids {
+					eventNIDs = append(eventNIDs, nid)
+				}
+				if err = rsDB.DeleteEvents(ctx, eventNIDs); err != nil {
+					return err
+				}
+			}
+			if err = j.c.db.DeleteEvents(ctx, ids); err != nil {
+				return err
+			}
+			j.status.Deleted += int64(len(ids))
+		}
+		j.report()
+	}
+}
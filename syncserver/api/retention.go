@@ -0,0 +1,128 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const defaultRetentionPurgeInterval = 3600
+
+// startRetentionPurger deletes the expired events of the rooms owned by this
+// instance every retention.purge_interval seconds. Expired events are already
+// hidden from /sync, /messages and /context, the purger only frees the space.
+func (c *InternalMsgConsumer) startRetentionPurger() {
+	interval := c.Cfg.Retention.PurgeInterval
+	if interval <= 0 {
+		interval = defaultRetentionPurgeInterval
+	}
+	go func() {
+		t := time.NewTicker(time.Duration(interval) * time.Second)
+		defer t.Stop()
+		for range t.C {
+			c.purgeExpiredEvents(context.Background())
+		}
+	}()
+}
+
+// retentionRooms returns the rooms which may have expired events with their
+// m.room.retention content, read from the database instead of loading the
+// state of every room. Every room expires when there is a default lifetime.
+func (c *InternalMsgConsumer) retentionRooms(ctx context.Context) (map[string]*common.RetentionContent, error) {
+	evs, err := c.db.GetRoomsStateByType(ctx, "m.room.retention")
+	if err != nil {
+		return nil, err
+	}
+	rooms := make(map[string]*common.RetentionContent, len(evs))
+	if c.Cfg.Retention.DefaultMaxLifetime > 0 {
+		roomIDs, err := c.db.GetAllSyncRooms()
+		if err != nil {
+			return nil, err
+		}
+		for _, roomID := range roomIDs {
+			rooms[roomID] = nil
+		}
+	}
+	for _, ev := range evs {
+		content := common.RetentionContent{}
+		if err := json.Unmarshal(ev.Content, &content); err != nil {
+			log.Warnf("retention purger room:%s bad retention event:%s err:%v", ev.RoomID, ev.EventID, err)
+			continue
+		}
+		rooms[ev.RoomID] = &content
+	}
+	return rooms, nil
+}
+
+func (c *InternalMsgConsumer) purgeExpiredEvents(ctx context.Context) {
+	if !c.Cfg.Retention.Enable {
+		return
+	}
+	rooms, err := c.retentionRooms(ctx)
+	if err != nil {
+		log.Errorf("retention purger get rooms err:%v", err)
+		return
+	}
+	var owned []string
+	for roomID := range rooms {
+		if common.IsRelatedRequest(roomID, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+			owned = append(owned, roomID)
+		}
+	}
+	if len(owned) == 0 {
+		return
+	}
+	latest, err := c.db.GetRoomLastOffsets(ctx, owned)
+	if err != nil {
+		log.Errorf("retention purger get latest offsets err:%v", err)
+		return
+	}
+
+	start := time.Now()
+	var deleted int64
+	for _, roomID := range owned {
+		toPos, ok := latest[roomID]
+		if !ok {
+			continue
+		}
+		lifetime := common.RetentionMaxLifetime(&c.Cfg, rooms[roomID])
+		if lifetime <= 0 {
+			continue
+		}
+		// an admin purge of the room is running, try again next time
+		if _, loaded := purging.LoadOrStore(roomID, "retention"); loaded {
+			continue
+		}
+		job := &purgeJob{
+			c:        c,
+			roomID:   roomID,
+			toPos:    toPos,
+			beforeTs: time.Now().UnixNano()/1000000 - lifetime,
+			status: external.GetPurgeHistoryStatusResponse{
+				RoomID:  roomID,
+				Status:  PurgeStatusActive,
+				StartTs: time.Now().UnixNano() / 1000000,
+			},
+		}
+		job.run(ctx)
+		deleted += job.status.Deleted
+	}
+	log.Infof("retention purger checked %d rooms, deleted %d events, spent %v", len(owned), deleted, time.Since(start))
+}
@@ -202,7 +202,7 @@ func (r ReqGetRoomMessages) Process(ctx context.Context, consumer interface{}, m
 			loadFromDB     = false
 			dbFromPos      = fromPos
 			dbFromTs       = fromTs
-			visibilityTime = c.settings.GetMessageVisilibityTime()
+			visibilityTime = common.RoomVisibilityTime(&c.Cfg, c.settings, rs.GetRetention())
 			nowTs          = time.Now().Unix()
 
 			foundAll    = false // loaded all request event from cache
@@ -364,7 +364,7 @@ func (r ReqGetRoomMessages) selectFromDB(
 		return
 	}
 
-	visibilityTime := c.settings.GetMessageVisilibityTime()
+	visibilityTime := common.RoomVisibilityTime(&c.Cfg, c.settings, rs.GetRetention())
 	nowTs := time.Now().Unix()
 
 	for idx := range events {
@@ -0,0 +1,104 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/apiconsumer"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/common/jsonerror"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/authtypes"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/plugins/message/internals"
+	"github.com/finogeeks/ligase/skunkworks/log"
+)
+
+const (
+	defaultUserDirectoryLimit = 10
+	maxUserDirectoryLimit     = 100
+)
+
+func init() {
+	apiconsumer.SetAPIProcessor(ReqPostUserDirectorySearch{})
+}
+
+type ReqPostUserDirectorySearch struct{}
+
+func (ReqPostUserDirectorySearch) GetRoute() string       { return "/user_directory/search" }
+func (ReqPostUserDirectorySearch) GetMetricsName() string { return "user_directory_search" }
+func (ReqPostUserDirectorySearch) GetMsgType() int32 {
+	return internals.MSG_POST_USER_DIRECTORY_SEARCH
+}
+func (ReqPostUserDirectorySearch) GetAPIType() int8 { return apiconsumer.APITypeAuth }
+func (ReqPostUserDirectorySearch) GetMethod() []string {
+	return []string{http.MethodPost, http.MethodOptions}
+}
+func (ReqPostUserDirectorySearch) GetTopic(cfg *config.Dendrite) string {
+	return getProxyRpcTopic(cfg)
+}
+func (ReqPostUserDirectorySearch) GetPrefix() []string { return []string{"r0"} }
+func (ReqPostUserDirectorySearch) NewRequest() core.Coder {
+	return new(external.PostUserSearchRequest)
+}
+func (ReqPostUserDirectorySearch) FillRequest(coder core.Coder, req *http.Request, vars map[string]string) error {
+	msg := coder.(*external.PostUserSearchRequest)
+	return common.UnmarshalJSON(req, msg)
+}
+func (ReqPostUserDirectorySearch) NewResponse(code int) core.Coder {
+	return new(external.PostUserSearchResponse)
+}
+func (ReqPostUserDirectorySearch) Process(ctx context.Context, consumer interface{}, msg core.Coder, device *authtypes.Device) (int, core.Coder) {
+	c := consumer.(*InternalMsgConsumer)
+	if !common.IsRelatedRequest(device.UserID, c.Cfg.MultiInstance.Instance, c.Cfg.MultiInstance.Total, c.Cfg.MultiInstance.MultiWrite) {
+		return internals.HTTP_RESP_DISCARD, jsonerror.MsgDiscard("msg discard")
+	}
+	req := msg.(*external.PostUserSearchRequest)
+	term := strings.TrimSpace(req.SearchTerm)
+	if term == "" {
+		return http.StatusBadRequest, jsonerror.InvalidArgumentValue("search_term must not be empty")
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultUserDirectoryLimit
+	}
+	if limit > maxUserDirectoryLimit {
+		limit = maxUserDirectoryLimit
+	}
+
+	// one more than asked for tells whether the results are limited
+	entries, err := c.db.SearchUserDirectory(ctx, device.UserID, term, c.Cfg.UserDirectory.SearchAllUsers, limit+1)
+	if err != nil {
+		log.Errorf("search user directory user %s term %s error %v", device.UserID, term, err)
+		return http.StatusInternalServerError, jsonerror.Unknown(err.Error())
+	}
+	resp := &external.PostUserSearchResponse{Results: []external.User{}}
+	if len(entries) > limit {
+		entries = entries[:limit]
+		resp.Limited = true
+	}
+	for _, entry := range entries {
+		resp.Results = append(resp.Results, external.User{
+			UserID:      entry.UserID,
+			DisplayName: entry.DisplayName,
+			AvatarURL:   entry.AvatarURL,
+		})
+	}
+	return http.StatusOK, resp
+}
@@ -145,7 +145,7 @@ func (s *RoomEventFeedConsumer) Start() error {
 
 func (s *RoomEventFeedConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output roomserverapi.OutputEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("syncapi: message parse failure", log.KeysAndValues{"error", err})
 		return
 	}
@@ -694,7 +694,7 @@ func (s *SyncServer) buildRoomJoinResp(ctx context.Context, req *syncapitypes.Sy
 		reqStart = -1
 	}
 
-	visibilityTime := s.settings.GetMessageVisilibityTime()
+	visibilityTime := common.RoomVisibilityTime(s.cfg, s.settings, rs.GetRetention())
 	nowTs := time.Now().Unix()
 
 	evRecords := make(map[string]int)
@@ -985,7 +985,7 @@ func (s *SyncServer) buildRoomLeaveResp(ctx context.Context, req *syncapitypes.S
 	msgEvent = []gomatrixserverlib.ClientEvent{}
 	minStream := s.roomHistory.GetRoomMinStream(ctx, roomID)
 
-	visibilityTime := s.settings.GetMessageVisilibityTime()
+	visibilityTime := common.RoomVisibilityTime(s.cfg, s.settings, rs.GetRetention())
 	nowTs := time.Now().Unix()
 
 	for i, feed := range feeds {
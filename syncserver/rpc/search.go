@@ -0,0 +1,315 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/model/repos"
+	"github.com/finogeeks/ligase/model/syncapitypes"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/plugins/message/external"
+	"github.com/finogeeks/ligase/skunkworks/gomatrixserverlib"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+	"github.com/finogeeks/ligase/syncserver/extra"
+	"github.com/nats-io/go-nats"
+)
+
+// SearchRpcConsumer answers the search requests for the rooms of this
+// instance, the room states held here decide which hits a user may see
+type SearchRpcConsumer struct {
+	rpcClient       *common.RpcClient
+	chanSize        uint32
+	msgChan         []chan common.ContextMsg
+	db              model.SyncAPIDatabase
+	rsTimeline      *repos.RoomStateTimeLineRepo
+	rsCurState      *repos.RoomCurStateRepo
+	displayNameRepo *repos.DisplayNameRepo
+	settings        *common.Settings
+	cfg             *config.Dendrite
+}
+
+func NewSearchRpcConsumer(
+	rpcClient *common.RpcClient,
+	db model.SyncAPIDatabase,
+	rsTimeline *repos.RoomStateTimeLineRepo,
+	rsCurState *repos.RoomCurStateRepo,
+	displayNameRepo *repos.DisplayNameRepo,
+	settings *common.Settings,
+	cfg *config.Dendrite,
+) *SearchRpcConsumer {
+	s := &SearchRpcConsumer{
+		rpcClient:       rpcClient,
+		chanSize:        16,
+		db:              db,
+		rsTimeline:      rsTimeline,
+		rsCurState:      rsCurState,
+		displayNameRepo: displayNameRepo,
+		settings:        settings,
+		cfg:             cfg,
+	}
+
+	return s
+}
+
+func (s *SearchRpcConsumer) GetCB() common.MsgHandlerWithContext {
+	return s.cb
+}
+
+func (s *SearchRpcConsumer) GetTopic() string {
+	return types.SyncSearchTopicDef
+}
+
+func (s *SearchRpcConsumer) Clean() {
+}
+
+func (s *SearchRpcConsumer) cb(ctx context.Context, msg *nats.Msg) {
+	var result syncapitypes.SyncSearchRequest
+	if err := json.Unmarshal(msg.Data, &result); err != nil {
+		log.Errorf("rpc search cb error %v", err)
+		return
+	}
+	if common.IsRelatedSyncRequest(result.SyncInstance, s.cfg.MultiInstance.Instance, s.cfg.MultiInstance.Total, s.cfg.MultiInstance.MultiWrite) {
+		result.Reply = msg.Reply
+		idx := common.CalcStringHashCode(result.UserID) % s.chanSize
+		s.msgChan[idx] <- common.ContextMsg{Ctx: ctx, Msg: &result}
+	}
+}
+
+func (s *SearchRpcConsumer) startWorker(msgChan chan common.ContextMsg) {
+	for msg := range msgChan {
+		data := msg.Msg.(*syncapitypes.SyncSearchRequest)
+		s.onSearchRequest(msg.Ctx, data)
+	}
+}
+
+func (s *SearchRpcConsumer) Start() error {
+	s.msgChan = make([]chan common.ContextMsg, s.chanSize)
+	for i := uint32(0); i < s.chanSize; i++ {
+		s.msgChan[i] = make(chan common.ContextMsg, 512)
+		go s.startWorker(s.msgChan[i])
+	}
+
+	s.rpcClient.ReplyWithContext(s.GetTopic(), s.cb)
+
+	return nil
+}
+
+func (s *SearchRpcConsumer) onSearchRequest(ctx context.Context, req *syncapitypes.SyncSearchRequest) {
+	result, err := s.search(ctx, req)
+	if err != nil {
+		log.Errorf("rpc search user %s term %s error %v", req.UserID, req.SearchTerm, err)
+		result = &syncapitypes.SyncSearchResponse{Error: err.Error()}
+	}
+	s.rpcClient.PubObj(req.Reply, result)
+}
+
+func (s *SearchRpcConsumer) search(ctx context.Context, req *syncapitypes.SyncSearchRequest) (*syncapitypes.SyncSearchResponse, error) {
+	states := make(map[string]*repos.RoomState)
+	var rooms []string
+	for _, roomID := range req.Rooms {
+		if s.rsTimeline.GetStateStreams(ctx, roomID) == nil {
+			continue
+		}
+		if rs := s.rsCurState.GetRoomState(roomID); rs != nil {
+			states[roomID] = rs
+			rooms = append(rooms, roomID)
+		}
+	}
+
+	resp := &syncapitypes.SyncSearchResponse{}
+	if len(rooms) == 0 || req.Limit <= 0 {
+		return resp, nil
+	}
+
+	senders := toSet(req.Senders)
+	notSenders := toSet(req.NotSenders)
+	nowTs := time.Now().Unix()
+	// the hits the user can't see are neither counted nor returned
+	visible := func(hit *syncapitypes.SearchEventResult) bool {
+		if (senders != nil && !senders[hit.Sender]) || notSenders[hit.Sender] {
+			return false
+		}
+		rs := states[hit.RoomID]
+		if !rs.CheckEventVisibility(req.UserID, hit.OriginServerTS) {
+			return false
+		}
+		visibilityTime := common.RoomVisibilityTime(s.cfg, s.settings, rs.GetRetention())
+		return visibilityTime <= 0 || hit.OriginServerTS/1000+visibilityTime >= nowTs
+	}
+	count, err := s.db.CountSearchEvents(ctx, req.SearchTerm, rooms, visible)
+	if err != nil {
+		return nil, err
+	}
+	resp.Count = count
+
+	fromRank, fromID := req.FromRank, req.FromID
+	// hits are dropped by visible, so keep reading until the page is full or
+	// the index is exhausted
+	for len(resp.Results) < req.Limit {
+		batch := req.Limit * 2
+		hits, err := s.db.SearchEvents(ctx, req.SearchTerm, rooms, req.OrderByRank, fromRank, fromID, batch)
+		if err != nil {
+			return nil, err
+		}
+		var shown []syncapitypes.SearchEventResult
+		var eventIDs []string
+		for i := range hits {
+			hit := &hits[i]
+			fromRank, fromID = hit.Rank, hit.ID
+			if !visible(hit) {
+				continue
+			}
+			shown = append(shown, *hit)
+			eventIDs = append(eventIDs, hit.EventID)
+		}
+
+		if len(eventIDs) > 0 {
+			evs, err := s.db.Events(ctx, eventIDs)
+			if err != nil {
+				return nil, err
+			}
+			evMap := make(map[string]*gomatrixserverlib.ClientEvent, len(evs))
+			for i := range evs {
+				evMap[evs[i].EventID] = &evs[i]
+			}
+			for _, hit := range shown {
+				ev, ok := evMap[hit.EventID]
+				if !ok {
+					continue
+				}
+				extra.ExpandMessages(ev, req.UserID, s.rsCurState, s.displayNameRepo)
+				item := syncapitypes.SyncSearchItem{
+					ID:     hit.ID,
+					Result: external.SearchResult{Rank: hit.Rank, Result: *ev},
+				}
+				if req.BeforeLimit > 0 || req.AfterLimit > 0 || req.IncludeProfile {
+					item.Result.Context, err = s.getContext(ctx, req, states[hit.RoomID], ev, hit.ID, nowTs)
+					if err != nil {
+						return nil, err
+					}
+				}
+				resp.Results = append(resp.Results, item)
+				if len(resp.Results) >= req.Limit {
+					break
+				}
+			}
+		}
+
+		if len(hits) < batch {
+			break
+		}
+	}
+	return resp, nil
+}
+
+// getContext returns the visible events around a result, like /context does
+func (s *SearchRpcConsumer) getContext(
+	ctx context.Context, req *syncapitypes.SyncSearchRequest, rs *repos.RoomState,
+	ev *gomatrixserverlib.ClientEvent, pos, nowTs int64,
+) (*external.SearchEventContext, error) {
+	evCtx := &external.SearchEventContext{
+		EventsBefore: []gomatrixserverlib.ClientEvent{},
+		EventsAfter:  []gomatrixserverlib.ClientEvent{},
+	}
+	visibilityTime := common.RoomVisibilityTime(s.cfg, s.settings, rs.GetRetention())
+	visible := func(e *gomatrixserverlib.ClientEvent) bool {
+		if common.IsStateClientEv(e) {
+			return true
+		}
+		if common.IsExtEvent(e) || !rs.CheckEventVisibility(req.UserID, int64(e.OriginServerTS)) {
+			return false
+		}
+		return visibilityTime <= 0 || int64(e.OriginServerTS)/1000+visibilityTime >= nowTs
+	}
+
+	start, end := pos, pos
+	startTs, endTs := int64(ev.OriginServerTS), int64(ev.OriginServerTS)
+	if req.BeforeLimit > 0 {
+		evs, offsets, _, err, _, _ := s.db.SelectEventsByDir(ctx, req.UserID, ev.RoomID, "b", pos-1, req.BeforeLimit*2)
+		if err != nil {
+			return nil, err
+		}
+		for i := range evs {
+			if len(evCtx.EventsBefore) >= req.BeforeLimit {
+				break
+			}
+			if visible(&evs[i]) {
+				extra.ExpandMessages(&evs[i], req.UserID, s.rsCurState, s.displayNameRepo)
+				evCtx.EventsBefore = append(evCtx.EventsBefore, evs[i])
+				start, startTs = offsets[i], int64(evs[i].OriginServerTS)
+			}
+		}
+	}
+	if req.AfterLimit > 0 {
+		evs, offsets, _, err, _, _ := s.db.SelectEventsByDir(ctx, req.UserID, ev.RoomID, "f", pos+1, req.AfterLimit*2)
+		if err != nil {
+			return nil, err
+		}
+		for i := range evs {
+			if len(evCtx.EventsAfter) >= req.AfterLimit {
+				break
+			}
+			if visible(&evs[i]) {
+				extra.ExpandMessages(&evs[i], req.UserID, s.rsCurState, s.displayNameRepo)
+				evCtx.EventsAfter = append(evCtx.EventsAfter, evs[i])
+				end, endTs = offsets[i], int64(evs[i].OriginServerTS)
+			}
+		}
+	}
+	evCtx.Start = common.BuildPreBatch(start-1, startTs)
+	evCtx.End = common.BuildPreBatch(end+1, endTs)
+
+	if req.IncludeProfile {
+		evCtx.ProfileInfo = make(map[string]external.SearchUserProfile)
+		senders := []string{ev.Sender}
+		for _, e := range evCtx.EventsBefore {
+			senders = append(senders, e.Sender)
+		}
+		for _, e := range evCtx.EventsAfter {
+			senders = append(senders, e.Sender)
+		}
+		for _, sender := range senders {
+			if _, ok := evCtx.ProfileInfo[sender]; ok {
+				continue
+			}
+			var profile external.SearchUserProfile
+			if member := rs.GetState("m.room.member", sender); member != nil && member.Ev != nil {
+				var content external.MemberContent
+				if json.Unmarshal(member.Ev.Content, &content) == nil {
+					profile.DisplayName = content.DisplayName
+					profile.AvatarURL = content.AvatarURL
+				}
+			}
+			evCtx.ProfileInfo[sender] = profile
+		}
+	}
+	return evCtx, nil
+}
+
+func toSet(list []string) map[string]bool {
+	if len(list) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(list))
+	for _, v := range list {
+		set[v] = true
+	}
+	return set
+}
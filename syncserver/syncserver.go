@@ -168,6 +168,11 @@ func SetupSyncServerComponent(
 		log.Panicf("failed to start sync unread rpc consumer err:%v", err)
 	}
 
+	searchRpcConsumer := rpc.NewSearchRpcConsumer(rpcClient, syncDB, rsTimeline, rsCurState, displayNameRepo, settings, base.Cfg)
+	if err := searchRpcConsumer.Start(); err != nil {
+		log.Panicf("failed to start search rpc consumer err:%v", err)
+	}
+
 	log.Infof("instance:%d,syncserver total:%d", base.Cfg.MultiInstance.Instance, base.Cfg.MultiInstance.SyncServerTotal)
 	apiConsumer := api.NewInternalMsgConsumer(*base.Cfg, rpcClient, idg, syncDB, rsCurState, rsTimeline, roomHistory, displayNameRepo, receiptConsumer, settings, cacheIn)
 	apiConsumer.Start()
@@ -195,6 +200,8 @@ func FixSyncCorruptRooms(
 		"m.room.canonical_alias",
 		"m.room.avatar",
 		"m.room.encryption",
+		"m.room.retention",
+		"m.room.tombstone",
 	}
 
 	if fixRoom == "*" {
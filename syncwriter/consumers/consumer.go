@@ -132,7 +132,7 @@ func (s *RoomEventConsumer) Start() error {
 
 func (s *RoomEventConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
 	var output roomserverapi.OutputEvent
-	if err := json.Unmarshal(data, &output); err != nil {
+	if err := core.DecodeTopicMsg(topic, data, &output); err != nil {
 		log.Errorw("sync writer: message parse failure", log.KeysAndValues{"error", err})
 		return
 	}
@@ -565,6 +565,9 @@ func (s *RoomEventConsumer) onNewRoomEvent(
 			con := external.MemberContent{}
 			json.Unmarshal(ev.Content, &con)
 			membership = con.Membership
+			if membership == "join" && ev.StateKey != nil {
+				s.updateUserDirectory(ctx, *ev.StateKey, &con)
+			}
 		}
 
 		err = s.db.UpdateRoomState(ctx, ev, &membership, syncapitypes.StreamPosition(ev.EventOffset))
@@ -581,6 +584,24 @@ func (s *RoomEventConsumer) onNewRoomEvent(
 	return nil
 }
 
+// updateUserDirectory adds the users joining a room to the user directory.
+// The profile updates are what the directory holds for local users, the
+// member events only add the ones without an entry. Remote users have no
+// profile updates here, their latest member event is the best we know.
+func (s *RoomEventConsumer) updateUserDirectory(ctx context.Context, userID string, con *external.MemberContent) {
+	domain, _ := common.DomainFromID(userID)
+	isLocal := common.CheckValidDomain(domain, s.cfg.Matrix.ServerName)
+	entry := &types.UserDirectoryEntry{
+		UserID:      userID,
+		DisplayName: con.DisplayName,
+		AvatarURL:   con.AvatarURL,
+		IsLocal:     isLocal,
+	}
+	if err := s.db.UpsertUserDirectory(ctx, entry, isLocal); err != nil {
+		log.Errorw("syncwriter: update user directory failure", log.KeysAndValues{"user_id", userID, "error", err})
+	}
+}
+
 func (s *RoomEventConsumer) onBackFillEvent(
 	ctx context.Context, msg *roomserverapi.OutputNewRoomEvent,
 ) error {
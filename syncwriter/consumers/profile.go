@@ -0,0 +1,155 @@
+// Copyright (C) 2020 Finogeeks Co., Ltd
+//
+// This program is free software: you can redistribute it and/or  modify
+// it under the terms of the GNU Affero General Public License, version 3,
+// as published by the Free Software Foundation.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package consumers
+
+import (
+	"context"
+
+	"github.com/finogeeks/ligase/common"
+	"github.com/finogeeks/ligase/common/config"
+	"github.com/finogeeks/ligase/core"
+	"github.com/finogeeks/ligase/model/types"
+	"github.com/finogeeks/ligase/skunkworks/log"
+	"github.com/finogeeks/ligase/storage/model"
+	"github.com/tidwall/gjson"
+)
+
+// ProfileConsumer keeps the user directory current with the profile updates
+type ProfileConsumer struct {
+	channel  core.IChannel
+	db       model.SyncAPIDatabase
+	chanSize uint32
+	msgChan  []chan common.ContextMsg
+	cfg      *config.Dendrite
+}
+
+func NewProfileConsumer(
+	cfg *config.Dendrite,
+	store model.SyncAPIDatabase,
+) *ProfileConsumer {
+	val, ok := common.GetTransportMultiplexer().GetChannel(
+		cfg.Kafka.Consumer.OutputProfileSyncWriter.Underlying,
+		cfg.Kafka.Consumer.OutputProfileSyncWriter.Name,
+	)
+	if ok {
+		channel := val.(core.IChannel)
+		s := &ProfileConsumer{
+			channel:  channel,
+			db:       store,
+			chanSize: 4,
+			cfg:      cfg,
+		}
+		channel.SetHandler(s)
+
+		return s
+	}
+
+	return nil
+}
+
+func (s *ProfileConsumer) startWorker(msgChan chan common.ContextMsg) {
+	for msg := range msgChan {
+		data := msg.Msg.(*types.ProfileStreamUpdate)
+		s.onMessage(msg.Ctx, data)
+	}
+}
+
+func (s *ProfileConsumer) Start() error {
+	s.loadHistory()
+
+	s.msgChan = make([]chan common.ContextMsg, s.chanSize)
+	for i := uint32(0); i < s.chanSize; i++ {
+		s.msgChan[i] = make(chan common.ContextMsg, 512)
+		go s.startWorker(s.msgChan[i])
+	}
+	return nil
+}
+
+// loadHistory adds the profiles stored before the user directory existed,
+// the entries already in the directory are newer and kept
+func (s *ProfileConsumer) loadHistory() {
+	span, ctx := common.StartSobSomSpan(context.Background(), "ProfileConsumer.loadHistory")
+	defer span.Finish()
+
+	limit := 1000
+	offset := 0
+	for {
+		streams, _, err := s.db.GetHistoryPresenceDataStream(ctx, limit, offset)
+		if err != nil {
+			log.Errorf("user directory load history offset %d error %v", offset, err)
+			return
+		}
+		for _, stream := range streams {
+			if !s.isRelated(stream.UserID) {
+				continue
+			}
+			content := gjson.GetBytes(stream.Content, "content")
+			entry := &types.UserDirectoryEntry{
+				UserID:      stream.UserID,
+				DisplayName: content.Get("displayname").String(),
+				AvatarURL:   content.Get("avatar_url").String(),
+				UserName:    content.Get("user_name").String(),
+				JobNumber:   content.Get("job_number").String(),
+				Email:       content.Get("email").String(),
+				IsLocal:     s.isLocal(stream.UserID),
+			}
+			if err := s.db.UpsertUserDirectory(ctx, entry, true); err != nil {
+				log.Errorf("user directory load user %s error %v", stream.UserID, err)
+			}
+		}
+		if len(streams) < limit {
+			return
+		}
+		offset += len(streams)
+	}
+}
+
+func (s *ProfileConsumer) OnMessage(ctx context.Context, topic string, partition int32, data []byte, rawMsg interface{}) {
+	var output types.ProfileStreamUpdate
+	if err := json.Unmarshal(data, &output); err != nil {
+		log.Errorw("sync writer profile consumer: message parse failure", log.KeysAndValues{"error", err})
+		return
+	}
+	if !s.isRelated(output.UserID) {
+		return
+	}
+
+	idx := common.CalcStringHashCode(output.UserID) % s.chanSize
+	s.msgChan[idx] <- common.ContextMsg{Ctx: ctx, Msg: &output}
+}
+
+func (s *ProfileConsumer) onMessage(ctx context.Context, output *types.ProfileStreamUpdate) {
+	entry := &types.UserDirectoryEntry{
+		UserID:      output.UserID,
+		DisplayName: output.Presence.DisplayName,
+		AvatarURL:   output.Presence.AvatarURL,
+		UserName:    output.Presence.UserName,
+		JobNumber:   output.Presence.JobNumber,
+		Email:       output.Presence.Email,
+		IsLocal:     s.isLocal(output.UserID),
+	}
+	if err := s.db.UpsertUserDirectory(ctx, entry, false); err != nil {
+		log.Errorw("sync writer: update user directory failure", log.KeysAndValues{"user_id", output.UserID, "error", err})
+	}
+}
+
+func (s *ProfileConsumer) isRelated(userID string) bool {
+	return common.IsRelatedRequest(userID, s.cfg.MultiInstance.Instance, s.cfg.MultiInstance.Total, s.cfg.MultiInstance.MultiWrite)
+}
+
+func (s *ProfileConsumer) isLocal(userID string) bool {
+	domain, _ := common.DomainFromID(userID)
+	return common.CheckValidDomain(domain, s.cfg.Matrix.ServerName)
+}
@@ -56,4 +56,13 @@ func SetupSyncWriterComponent(
 	if err := eventServer.Start(); err != nil {
 		log.Panicf("failed to start sync room server consumer err:%v", err)
 	}
+
+	profileConsumer := consumers.NewProfileConsumer(base.Cfg, syncDB)
+	if profileConsumer == nil {
+		log.Warnf("kafka.consumer.output_profile_syncwriter is not configured, the user directory is not updated")
+		return
+	}
+	if err := profileConsumer.Start(); err != nil {
+		log.Panicf("failed to start sync writer profile consumer err:%v", err)
+	}
 }